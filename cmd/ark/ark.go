@@ -60,6 +60,7 @@ var (
 //   - cache: Manage application cache
 //   - configure: Configure the CLI
 //   - login: Authenticate with services
+//   - token: Inspect login tokens
 //   - exec: Execute service actions
 //
 // The function will call os.Exit(1) if command execution fails.
@@ -74,9 +75,10 @@ func main() {
 	profilesLoader := profiles.DefaultProfilesLoader()
 	arkActions := []actions.ArkAction{
 		actions.NewArkProfilesAction(profilesLoader),
-		actions.NewArkCacheAction(),
+		actions.NewArkCacheAction(profilesLoader),
 		actions.NewArkConfigureAction(profilesLoader),
 		actions.NewArkLoginAction(profilesLoader),
+		actions.NewArkTokenAction(profilesLoader),
 		actions.NewArkServiceExecAction(profilesLoader),
 	}
 
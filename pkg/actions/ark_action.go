@@ -84,7 +84,7 @@ func (a *ArkBaseAction) CommonActionsConfiguration(cmd *cobra.Command) {
 	cmd.PersistentFlags().Bool("silent", false, "Silent execution, no interactiveness")
 	cmd.PersistentFlags().Bool("allow-output", false, "Allow stdout / stderr even when silent and not interactive")
 	cmd.PersistentFlags().Bool("verbose", false, "Whether to verbose log")
-	cmd.PersistentFlags().String("logger-style", "default", "Which verbose logger style to use")
+	cmd.PersistentFlags().String("logger-style", "default", "Which verbose logger style to use, default or json")
 	cmd.PersistentFlags().String("log-level", "INFO", "Log level to use while verbose")
 	cmd.PersistentFlags().Bool("disable-cert-verification", false, "Disables certificate verification on HTTPS calls, unsafe! Avoid using in production environments!")
 	cmd.PersistentFlags().String("trusted-cert", "", "Certificate to use for HTTPS calls")
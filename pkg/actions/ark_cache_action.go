@@ -1,21 +1,44 @@
 package actions
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Iilun/survey/v2"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	commonargs "github.com/cyberark/ark-sdk-golang/pkg/common/args"
 	"github.com/cyberark/ark-sdk-golang/pkg/common/keyring"
+	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
+	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	"github.com/spf13/cobra"
 )
 
+// identityCacheServiceName is the keyring service name the identity authentication caches its tokens with.
+const identityCacheServiceName = "arkidentity"
+
+// arkCacheExport is the document written by the cache export command.
+type arkCacheExport struct {
+	ExportedAt time.Time                 `json:"exported_at"`
+	Tokens     []*keyring.ArkCachedToken `json:"tokens"`
+}
+
 // ArkCacheAction is a struct that implements the ArkAction interface for cache management.
 //
 // ArkCacheAction provides functionality for managing cache operations in the Ark SDK CLI.
 // It embeds ArkBaseAction to inherit common CLI functionality and adds specific cache
-// management commands such as clearing cached profiles and credentials.
+// management commands such as inspecting, pruning and clearing cached credentials.
 //
-// The action specifically supports clearing cache for basic keyring implementations,
-// which store credentials and profile information in local files.
+// Cached tokens are listed per profile, service name and postfix from both the OS keyring
+// and the basic keyring, which stores credentials in local files, without revealing secrets.
 type ArkCacheAction struct {
 	// ArkBaseAction provides common action functionality
 	*ArkBaseAction
+	// profilesLoader loads the profiles whose cached tokens are inspected
+	profilesLoader *profiles.ProfileLoader
 }
 
 // NewArkCacheAction creates a new instance of ArkCacheAction.
@@ -24,29 +47,33 @@ type ArkCacheAction struct {
 // providing all the common CLI functionality along with cache-specific operations.
 // The returned instance is ready to be used for defining cache management commands.
 //
+// Parameters:
+//   - profilesLoader: A ProfileLoader interface for loading the profiles whose cached tokens are inspected
+//
 // Returns a new ArkCacheAction instance with initialized base action functionality.
 //
 // Example:
 //
-//	cacheAction := NewArkCacheAction()
+//	cacheAction := NewArkCacheAction(profiles.DefaultProfilesLoader())
 //	cacheAction.DefineAction(rootCmd)
-func NewArkCacheAction() *ArkCacheAction {
+func NewArkCacheAction(profilesLoader *profiles.ProfileLoader) *ArkCacheAction {
 	return &ArkCacheAction{
-		ArkBaseAction: NewArkBaseAction(),
+		ArkBaseAction:  NewArkBaseAction(),
+		profilesLoader: profilesLoader,
 	}
 }
 
 // DefineAction defines the CLI cache action and adds cache management subcommands.
 //
 // DefineAction creates a "cache" command with subcommands for cache management operations.
-// Currently, it provides a "clear" subcommand that removes cached credentials and profile
-// information from the basic keyring implementation.
 //
-// The function sets up:
-//  1. A parent "cache" command for cache management
-//  2. Common action configuration through embedded ArkBaseAction
-//  3. A "clear" subcommand for clearing cached data
-//  4. Persistent pre-run hook for common action execution
+// The function creates the following subcommands:
+//   - list: Lists the cached tokens, with their expiry, refresh token presence and backend
+//   - show: Shows the details of the cached tokens of a profile, service name and postfix
+//   - delete: Deletes selected cached tokens of a profile
+//   - prune: Deletes the cached tokens which are expired and cannot be refreshed anymore
+//   - export: Exports the description of the cached tokens as JSON
+//   - clear: Clears all the cached data
 //
 // Parameters:
 //   - cmd: The parent cobra command to which the cache command will be added
@@ -56,9 +83,9 @@ func NewArkCacheAction() *ArkCacheAction {
 //
 // Example:
 //
-//	cacheAction := NewArkCacheAction()
+//	cacheAction := NewArkCacheAction(loader)
 //	cacheAction.DefineAction(rootCmd)
-//	// This adds: myapp cache clear
+//	// This adds: myapp cache [list|show|delete|prune|export|clear]
 func (a *ArkCacheAction) DefineAction(cmd *cobra.Command) {
 	cacheCmd := &cobra.Command{
 		Use:   "cache",
@@ -69,16 +96,303 @@ func (a *ArkCacheAction) DefineAction(cmd *cobra.Command) {
 	}
 	a.CommonActionsConfiguration(cacheCmd)
 
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List cached tokens",
+		Run:   a.runListCacheAction,
+	}
+	listCmd.Flags().String("profile-name", "", "Profile name to list the cached tokens of, if not given, lists those of all profiles")
+	listCmd.Flags().String("service-name", "", "Service name to filter the cached tokens with, such as ArkISPAuth or sia-sso")
+	listCmd.Flags().Bool("json", false, "Whether to output the cached tokens as JSON")
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show cached tokens",
+		Run:   a.runShowCacheAction,
+	}
+	showCmd.Flags().String("profile-name", profiles.DefaultProfileName(), "Profile name to show the cached tokens of")
+	showCmd.Flags().String("service-name", "", "Service name of the cached tokens to show, if not given, shows all of them")
+	showCmd.Flags().String("postfix", "", "Postfix of the cached tokens to show, such as the username, if not given, shows all of them")
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete cached tokens",
+		Run:   a.runDeleteCacheAction,
+	}
+	deleteCmd.Flags().String("profile-name", profiles.DefaultProfileName(), "Profile name to delete the cached tokens of")
+	deleteCmd.Flags().String("service-name", "", "Service name of the cached tokens to delete, if not given, deletes all of them")
+	deleteCmd.Flags().String("postfix", "", "Postfix of the cached tokens to delete, such as the username, if not given, deletes all of them")
+	deleteCmd.Flags().Bool("yes", false, "Whether to approve deletion non interactively")
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete stale cached tokens",
+		Run:   a.runPruneCacheAction,
+	}
+	pruneCmd.Flags().String("profile-name", "", "Profile name to prune the cached tokens of, if not given, prunes those of all profiles")
+	pruneCmd.Flags().Bool("include-refreshable", false, "Whether to also delete expired tokens which could still be refreshed")
+	pruneCmd.Flags().Bool("json", false, "Whether to output the pruned tokens as JSON")
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the description of cached tokens as JSON",
+		Run:   a.runExportCacheAction,
+	}
+	exportCmd.Flags().String("profile-name", "", "Profile name to export the cached tokens of, if not given, exports those of all profiles")
+	exportCmd.Flags().String("output-path", "", "File to write the export to, if not given, writes it to stdout")
+
 	clearCmd := &cobra.Command{
 		Use:   "clear",
 		Short: "Clears all profiles cache",
 		Run:   a.runClearCacheAction,
 	}
 
-	cacheCmd.AddCommand(clearCmd)
+	cacheCmd.AddCommand(listCmd, showCmd, deleteCmd, pruneCmd, exportCmd, clearCmd)
 	cmd.AddCommand(cacheCmd)
 }
 
+// cacheServiceNames returns the keyring service names tokens are cached with, which are the cache namespaces
+// of the registered authenticators, and the names of the identity authentication and of the registered services.
+func (a *ArkCacheAction) cacheServiceNames() []string {
+	serviceNames := []string{identityCacheServiceName}
+	for _, authenticator := range auth.Authenticators() {
+		if namespaced, ok := authenticator.(interface{ CacheNamespace() string }); ok {
+			serviceNames = append(serviceNames, namespaced.CacheNamespace())
+			continue
+		}
+		serviceNames = append(serviceNames, authenticator.AuthenticatorName())
+	}
+	for _, serviceConfig := range services.AllServiceConfigs() {
+		serviceNames = append(serviceNames, serviceConfig.ServiceName)
+	}
+	slices.Sort(serviceNames)
+	return slices.Compact(serviceNames)
+}
+
+// cacheProfileNames returns the profile name given to the command, or the names of all the profiles.
+func (a *ArkCacheAction) cacheProfileNames(cmd *cobra.Command) ([]string, error) {
+	if profileName, _ := cmd.Flags().GetString("profile-name"); profileName != "" {
+		return []string{profileName}, nil
+	}
+	loadedProfiles, err := (*a.profilesLoader).LoadAllProfiles()
+	if err != nil {
+		return nil, err
+	}
+	profileNames := make([]string, 0, len(loadedProfiles))
+	for _, profile := range loadedProfiles {
+		profileNames = append(profileNames, profile.ProfileName)
+	}
+	return profileNames, nil
+}
+
+// listCachedTokens lists the cached tokens of the profiles of the command, filtered by its service-name and postfix flags.
+func (a *ArkCacheAction) listCachedTokens(cmd *cobra.Command) ([]*keyring.ArkCachedToken, error) {
+	profileNames, err := a.cacheProfileNames(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(profileNames) == 0 {
+		return nil, nil
+	}
+	serviceName, _ := cmd.Flags().GetString("service-name")
+	postfix, _ := cmd.Flags().GetString("postfix")
+	var tokens []*keyring.ArkCachedToken
+	for _, name := range a.cacheServiceNames() {
+		if serviceName != "" && name != serviceName {
+			continue
+		}
+		serviceTokens, err := keyring.NewArkKeyring(name).ListTokens(profileNames...)
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range serviceTokens {
+			if postfix == "" || token.Postfix == postfix {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// describeCachedToken describes a cached token in a single line.
+func (a *ArkCacheAction) describeCachedToken(token *keyring.ArkCachedToken) string {
+	expiry := "never expires"
+	if token.ExpiresAt != nil {
+		expiry = "expires " + token.ExpiresAt.Local().Format(time.RFC3339)
+		if token.Expired {
+			expiry = "expired " + token.ExpiresAt.Local().Format(time.RFC3339)
+		}
+	}
+	refreshToken := "no refresh token"
+	if token.HasRefreshToken {
+		refreshToken = "refresh token"
+	}
+	parts := []string{token.Profile, token.ServiceName, token.Postfix, token.Backend, expiry, refreshToken}
+	if token.Stale {
+		parts = append(parts, "stale")
+	}
+	if token.Error != "" {
+		parts = append(parts, token.Error)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// printCachedTokens prints the cached tokens as JSON, or a line per token.
+func (a *ArkCacheAction) printCachedTokens(tokens []*keyring.ArkCachedToken, asJSON bool) {
+	if asJSON {
+		if tokens == nil {
+			tokens = []*keyring.ArkCachedToken{}
+		}
+		data, _ := json.MarshalIndent(tokens, "", "  ")
+		commonargs.PrintSuccess(string(data))
+		return
+	}
+	for _, token := range tokens {
+		if token.Stale || token.Error != "" {
+			commonargs.PrintWarning(a.describeCachedToken(token))
+			continue
+		}
+		commonargs.PrintSuccess(a.describeCachedToken(token))
+	}
+}
+
+// runListCacheAction lists the cached tokens, with their expiry, refresh token presence and backend.
+//
+// Supported flags:
+//   - profile-name: Profile to list the cached tokens of, all of them if not given
+//   - service-name: Service name to filter the cached tokens with
+//   - json: Whether to output the cached tokens as JSON
+func (a *ArkCacheAction) runListCacheAction(cmd *cobra.Command, args []string) {
+	tokens, err := a.listCachedTokens(cmd)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to list cached tokens: %s", err))
+		return
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if len(tokens) == 0 && !asJSON {
+		commonargs.PrintWarning("No cached tokens were found")
+		return
+	}
+	a.printCachedTokens(tokens, asJSON)
+}
+
+// runShowCacheAction shows the details of the cached tokens of a profile as JSON, without their secrets.
+//
+// Supported flags:
+//   - profile-name: Profile to show the cached tokens of
+//   - service-name: Service name of the cached tokens to show
+//   - postfix: Postfix of the cached tokens to show
+func (a *ArkCacheAction) runShowCacheAction(cmd *cobra.Command, args []string) {
+	tokens, err := a.listCachedTokens(cmd)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to list cached tokens: %s", err))
+		return
+	}
+	if len(tokens) == 0 {
+		profileName, _ := cmd.Flags().GetString("profile-name")
+		commonargs.PrintWarning(fmt.Sprintf("No cached tokens were found for profile %s", profileName))
+		return
+	}
+	a.printCachedTokens(tokens, true)
+}
+
+// runDeleteCacheAction deletes the selected cached tokens of a profile, after confirmation.
+//
+// Supported flags:
+//   - profile-name: Profile to delete the cached tokens of
+//   - service-name: Service name of the cached tokens to delete, all of them if not given
+//   - postfix: Postfix of the cached tokens to delete, all of them if not given
+//   - yes: Skip confirmation prompt for non-interactive deletion
+func (a *ArkCacheAction) runDeleteCacheAction(cmd *cobra.Command, args []string) {
+	tokens, err := a.listCachedTokens(cmd)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to list cached tokens: %s", err))
+		return
+	}
+	profileName, _ := cmd.Flags().GetString("profile-name")
+	if len(tokens) == 0 {
+		commonargs.PrintWarning(fmt.Sprintf("No cached tokens were found for profile %s", profileName))
+		return
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		confirm := false
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf("Are you sure you want to delete %d cached tokens of profile %s?", len(tokens), profileName),
+		}
+		err := survey.AskOne(prompt, &confirm)
+		if err != nil || !confirm {
+			return
+		}
+	}
+	for _, token := range tokens {
+		if err := keyring.NewArkKeyring(token.ServiceName).DeleteToken(token); err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("Failed to delete cached token [%s]: %s", a.describeCachedToken(token), err))
+			continue
+		}
+		commonargs.PrintSuccess(fmt.Sprintf("Deleted cached token [%s]", a.describeCachedToken(token)))
+	}
+}
+
+// runPruneCacheAction deletes the cached tokens which are expired and cannot be refreshed anymore.
+//
+// Supported flags:
+//   - profile-name: Profile to prune the cached tokens of, all of them if not given
+//   - include-refreshable: Whether to also delete expired tokens which could still be refreshed
+//   - json: Whether to output the pruned tokens as JSON
+func (a *ArkCacheAction) runPruneCacheAction(cmd *cobra.Command, args []string) {
+	profileNames, err := a.cacheProfileNames(cmd)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to load profiles: %s", err))
+		return
+	}
+	includeRefreshable, _ := cmd.Flags().GetBool("include-refreshable")
+	var pruned []*keyring.ArkCachedToken
+	if len(profileNames) > 0 {
+		for _, serviceName := range a.cacheServiceNames() {
+			servicePruned, err := keyring.NewArkKeyring(serviceName).PruneTokens(includeRefreshable, profileNames...)
+			pruned = append(pruned, servicePruned...)
+			if err != nil {
+				commonargs.PrintFailure(fmt.Sprintf("Failed to prune cached tokens: %s", err))
+				return
+			}
+		}
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if len(pruned) == 0 && !asJSON {
+		commonargs.PrintSuccess("No stale cached tokens were found")
+		return
+	}
+	a.printCachedTokens(pruned, asJSON)
+}
+
+// runExportCacheAction exports the description of the cached tokens as a JSON document, without their secrets.
+//
+// Supported flags:
+//   - profile-name: Profile to export the cached tokens of, all of them if not given
+//   - output-path: File to write the export to, stdout if not given
+func (a *ArkCacheAction) runExportCacheAction(cmd *cobra.Command, args []string) {
+	tokens, err := a.listCachedTokens(cmd)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to list cached tokens: %s", err))
+		return
+	}
+	if tokens == nil {
+		tokens = []*keyring.ArkCachedToken{}
+	}
+	data, _ := json.MarshalIndent(&arkCacheExport{ExportedAt: time.Now().UTC(), Tokens: tokens}, "", "  ")
+	outputPath, _ := cmd.Flags().GetString("output-path")
+	if outputPath == "" {
+		_, _ = fmt.Fprintln(os.Stdout, string(data))
+		return
+	}
+	if err := os.WriteFile(outputPath, append(data, '\n'), 0600); err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to write cache export: %s", err))
+		return
+	}
+	commonargs.PrintSuccess(fmt.Sprintf("Exported %d cached tokens to %s", len(tokens), outputPath))
+}
+
 // runClearCacheAction clears cached credentials and profile data for basic keyring implementations.
 //
 // runClearCacheAction attempts to clear cached data by removing the keyring and MAC files
@@ -1,12 +1,19 @@
 package actions
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
 	"github.com/cyberark/ark-sdk-golang/pkg/common/keyring"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
 	"github.com/spf13/cobra"
 )
 
@@ -52,7 +59,7 @@ func TestNewArkCacheAction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			action := NewArkCacheAction()
+			action := NewArkCacheAction(testutils.NewMockProfileLoader().AsProfileLoader())
 
 			if tt.validateFunc != nil {
 				tt.validateFunc(t, action)
@@ -157,7 +164,7 @@ func TestArkCacheAction_DefineAction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			action := NewArkCacheAction()
+			action := NewArkCacheAction(testutils.NewMockProfileLoader().AsProfileLoader())
 			rootCmd := &cobra.Command{Use: "test"}
 
 			action.DefineAction(rootCmd)
@@ -350,7 +357,7 @@ func TestArkCacheAction_runClearCacheAction(t *testing.T) {
 				}
 			}
 
-			action := NewArkCacheAction()
+			action := NewArkCacheAction(testutils.NewMockProfileLoader().AsProfileLoader())
 			cmd := &cobra.Command{}
 
 			// Execute the function - should not panic
@@ -413,7 +420,7 @@ func TestArkCacheAction_StructFields(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			action := NewArkCacheAction()
+			action := NewArkCacheAction(testutils.NewMockProfileLoader().AsProfileLoader())
 
 			if tt.validateFunc != nil {
 				tt.validateFunc(t, action)
@@ -468,7 +475,7 @@ func TestArkCacheAction_IntegrationWithArkAction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			action := NewArkCacheAction()
+			action := NewArkCacheAction(testutils.NewMockProfileLoader().AsProfileLoader())
 
 			if tt.validateFunc != nil {
 				tt.validateFunc(t, action)
@@ -476,3 +483,154 @@ func TestArkCacheAction_IntegrationWithArkAction(t *testing.T) {
 		})
 	}
 }
+
+// setupCacheActionTokens caches tokens of the ISP authenticator for two profiles in a basic keyring in a temporary folder.
+func setupCacheActionTokens(t *testing.T) *ArkCacheAction {
+	t.Helper()
+	t.Setenv(keyring.ArkBasicKeyringOverrideEnvVar, "true")
+	t.Setenv(keyring.ArkBasicKeyringFolderEnvVar, t.TempDir())
+	t.Setenv(keyring.ArkKeyringPassphraseEnvVar, "")
+	for profileName, expiresIn := range map[string]time.Duration{"profile1": time.Hour, "profile2": -2 * time.Hour} {
+		token := &authmodels.ArkToken{
+			Token:     "secret-token",
+			TokenType: authmodels.JWT,
+			ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(expiresIn)),
+		}
+		if err := keyring.NewArkKeyring("ArkISPAuth").SaveToken(&models.ArkProfile{ProfileName: profileName}, token, "user", false); err != nil {
+			t.Fatalf("Failed to save token: %v", err)
+		}
+	}
+	loader := testutils.NewMockProfileLoader()
+	loader.LoadAllProfilesFunc = func() ([]*models.ArkProfile, error) {
+		return []*models.ArkProfile{{ProfileName: "profile1"}, {ProfileName: "profile2"}}, nil
+	}
+	return NewArkCacheAction(loader.AsProfileLoader())
+}
+
+func TestArkCacheAction_DefineAction_Subcommands(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "test"}
+	NewArkCacheAction(testutils.NewMockProfileLoader().AsProfileLoader()).DefineAction(rootCmd)
+	for _, name := range []string{"list", "show", "delete", "prune", "export", "clear"} {
+		subCmd, _, err := rootCmd.Find([]string{"cache", name})
+		if err != nil || subCmd.Name() != name {
+			t.Errorf("Expected to find cache %s command, got %v", name, err)
+		}
+	}
+}
+
+func TestArkCacheAction_listCachedTokens(t *testing.T) {
+	tests := []struct {
+		name             string
+		flags            map[string]string
+		expectedProfiles []string
+	}{
+		{
+			name:             "success_lists_tokens_of_all_profiles",
+			expectedProfiles: []string{"profile1", "profile2"},
+		},
+		{
+			name:             "success_lists_tokens_of_profile",
+			flags:            map[string]string{"profile-name": "profile2"},
+			expectedProfiles: []string{"profile2"},
+		},
+		{
+			name:  "success_filters_by_service_name",
+			flags: map[string]string{"service-name": "sia-sso"},
+		},
+		{
+			name:  "success_filters_by_postfix",
+			flags: map[string]string{"postfix": "other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := setupCacheActionTokens(t)
+			cmd := &cobra.Command{}
+			cmd.Flags().String("profile-name", "", "")
+			cmd.Flags().String("service-name", "", "")
+			cmd.Flags().String("postfix", "", "")
+			for name, value := range tt.flags {
+				_ = cmd.Flags().Set(name, value)
+			}
+
+			tokens, err := action.listCachedTokens(cmd)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var profileNames []string
+			for _, token := range tokens {
+				if token.ServiceName != "ArkISPAuth" || token.Postfix != "user" || token.Backend != keyring.ArkKeyringBackendBasic {
+					t.Errorf("Expected the ISP token of the basic keyring, got %+v", token)
+				}
+				profileNames = append(profileNames, token.Profile)
+			}
+			if !reflect.DeepEqual(profileNames, tt.expectedProfiles) {
+				t.Errorf("Expected tokens of %v, got %v", tt.expectedProfiles, profileNames)
+			}
+		})
+	}
+}
+
+func TestArkCacheAction_runPruneCacheAction(t *testing.T) {
+	action := setupCacheActionTokens(t)
+	rootCmd := &cobra.Command{Use: "test"}
+	action.DefineAction(rootCmd)
+	pruneCmd, _, _ := rootCmd.Find([]string{"cache", "prune"})
+
+	action.runPruneCacheAction(pruneCmd, []string{})
+
+	tokens, err := keyring.NewArkKeyring("ArkISPAuth").ListTokens("profile1", "profile2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Profile != "profile1" {
+		t.Errorf("Expected only the valid token to remain, got %+v", tokens)
+	}
+}
+
+func TestArkCacheAction_runDeleteCacheAction(t *testing.T) {
+	action := setupCacheActionTokens(t)
+	rootCmd := &cobra.Command{Use: "test"}
+	action.DefineAction(rootCmd)
+	deleteCmd, _, _ := rootCmd.Find([]string{"cache", "delete"})
+	_ = deleteCmd.Flags().Set("profile-name", "profile1")
+	_ = deleteCmd.Flags().Set("service-name", "ArkISPAuth")
+	_ = deleteCmd.Flags().Set("yes", "true")
+
+	action.runDeleteCacheAction(deleteCmd, []string{})
+
+	tokens, err := keyring.NewArkKeyring("ArkISPAuth").ListTokens("profile1", "profile2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Profile != "profile2" {
+		t.Errorf("Expected only the token of the other profile to remain, got %+v", tokens)
+	}
+}
+
+func TestArkCacheAction_runExportCacheAction(t *testing.T) {
+	action := setupCacheActionTokens(t)
+	rootCmd := &cobra.Command{Use: "test"}
+	action.DefineAction(rootCmd)
+	exportCmd, _, _ := rootCmd.Find([]string{"cache", "export"})
+	outputPath := filepath.Join(t.TempDir(), "cache.json")
+	_ = exportCmd.Flags().Set("output-path", outputPath)
+
+	action.runExportCacheAction(exportCmd, []string{})
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Expected the export to be written, got %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("Expected the export not to contain secrets, got %s", data)
+	}
+	var export arkCacheExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("Expected the export to be JSON, got %v", err)
+	}
+	if len(export.Tokens) != 2 || !export.Tokens[1].Expired || export.Tokens[0].Expired {
+		t.Errorf("Expected the valid and expired tokens, got %+v", export.Tokens)
+	}
+}
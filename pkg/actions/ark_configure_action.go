@@ -22,6 +22,9 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
 )
 
+// transportFlagsPrefix is the prefix of the flags that configure the profile transport settings.
+const transportFlagsPrefix = "transport-"
+
 // ArkConfigureAction is a struct that implements the ArkAction interface for configuring the CLI profiles.
 //
 // ArkConfigureAction provides functionality for managing CLI configuration profiles,
@@ -110,6 +113,14 @@ func (a *ArkConfigureAction) hasAuthenticatorKey(authName string, key string, it
 	return false
 }
 
+// validateSecretRef checks that the secret reference of an auth profile, if any, is a reference rather than a secret.
+func validateSecretRef(authenticator auth.ArkAuth, authProfile *authmodels.ArkAuthProfile) error {
+	if authProfile.SecretRef == "" || common.IsArkSecretReference(authProfile.SecretRef) {
+		return nil
+	}
+	return fmt.Errorf("secret reference of authenticator [%s] must start with one of the schemes [%s]", authenticator.AuthenticatorHumanReadableName(), strings.Join(common.ArkSecretReferenceSchemes(), ", "))
+}
+
 // DefineAction defines the CLI configure action and adds configuration management commands.
 //
 // DefineAction creates a "configure" command that allows users to set up and modify
@@ -157,9 +168,14 @@ func (a *ArkConfigureAction) DefineAction(cmd *cobra.Command) {
 		a.logger.Error("Error parsing flags to ArkProfile %v", err)
 		panic(err)
 	}
+	err = gpflag.ParseTo(&common.ArkTransportConfig{}, confCmd.Flags(), sflags.Prefix(transportFlagsPrefix))
+	if err != nil {
+		a.logger.Error("Error parsing flags to ArkTransportConfig %v", err)
+		panic(err)
+	}
 
 	// Add the supported authenticator settings and whether to work with them or not
-	for _, authenticator := range auth.SupportedAuthenticatorsList {
+	for _, authenticator := range auth.Authenticators() {
 		confCmd.Flags().Bool(
 			"work-with-"+strings.Replace(authenticator.AuthenticatorName(), "_", "-", -1),
 			false,
@@ -217,6 +233,39 @@ func (a *ArkConfigureAction) DefineAction(cmd *cobra.Command) {
 	cmd.AddCommand(confCmd)
 }
 
+// applyTransportFlags merges the transport flags that were set on the command line into the profile transport config.
+//
+// Only changed flags are applied, so settings persisted earlier in the profile are
+// kept unless explicitly overridden.
+func (a *ArkConfigureAction) applyTransportFlags(cmd *cobra.Command, profile *models.ArkProfile) error {
+	transportFlags := map[string]interface{}{}
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed || !strings.HasPrefix(f.Name, transportFlagsPrefix) {
+			return
+		}
+		key := strings.Replace(strings.TrimPrefix(f.Name, transportFlagsPrefix), "-", "_", -1)
+		if f.Value.Type() == "stringSlice" {
+			transportFlags[key], _ = cmd.Flags().GetStringSlice(f.Name)
+		} else {
+			transportFlags[key] = f.Value.String()
+		}
+	})
+	if len(transportFlags) == 0 {
+		return nil
+	}
+	if profile.TransportConfig == nil {
+		profile.TransportConfig = &common.ArkTransportConfig{}
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           profile.TransportConfig,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(transportFlags)
+}
+
 // runInteractiveConfigureAction handles interactive profile configuration through prompts.
 //
 // runInteractiveConfigureAction provides an interactive mode for configuring CLI profiles
@@ -295,31 +344,36 @@ func (a *ArkConfigureAction) runInteractiveConfigureAction(cmd *cobra.Command, c
 		answers[strings.Replace(flag.Name, "-", "_", -1)] = val
 	}
 	err = mapstructure.Decode(answers, &profile)
+	err = a.applyTransportFlags(cmd, profile)
+	if err != nil {
+		return nil, err
+	}
 
+	authenticators := auth.Authenticators()
 	var workWithAuthenticators []string
-	if len(auth.SupportedAuthenticatorsList) == 1 {
-		workWithAuthenticators = []string{auth.SupportedAuthenticatorsList[0].AuthenticatorHumanReadableName()}
+	if len(authenticators) == 1 {
+		workWithAuthenticators = []string{authenticators[0].AuthenticatorHumanReadableName()}
 	} else {
 		workWithAuthenticators, err = args.GetCheckboxArgs(
 			cmd,
 			func() []string {
-				keys := make([]string, len(auth.SupportedAuthenticatorsList))
-				for i, a := range auth.SupportedAuthenticatorsList {
+				keys := make([]string, len(authenticators))
+				for i, a := range authenticators {
 					keys[i] = fmt.Sprintf("work_with_%s", strings.Replace(a.AuthenticatorName(), "-", "_", -1))
 				}
 				return keys
 			}(),
 			"Which authenticators would you like to connect to",
 			func() []string {
-				names := make([]string, len(auth.SupportedAuthenticatorsList))
-				for i, a := range auth.SupportedAuthenticatorsList {
+				names := make([]string, len(authenticators))
+				for i, a := range authenticators {
 					names[i] = a.AuthenticatorHumanReadableName()
 				}
 				return names
 			}(),
 			func() map[string]string {
 				existingVals := make(map[string]string)
-				for _, a := range auth.SupportedAuthenticatorsList {
+				for _, a := range authenticators {
 					if _, exists := profile.AuthProfiles[a.AuthenticatorName()]; exists {
 						existingVals[fmt.Sprintf("work_with_%s", strings.Replace(a.AuthenticatorName(), "-", "_", -1))] = a.AuthenticatorHumanReadableName()
 					}
@@ -332,7 +386,7 @@ func (a *ArkConfigureAction) runInteractiveConfigureAction(cmd *cobra.Command, c
 			return nil, err
 		}
 	}
-	for _, authenticator := range auth.SupportedAuthenticatorsList {
+	for _, authenticator := range authenticators {
 		authProfile, ok := profile.AuthProfiles[authenticator.AuthenticatorName()]
 		if !ok || authProfile == nil {
 			authProfile = &authmodels.ArkAuthProfile{}
@@ -406,7 +460,7 @@ func (a *ArkConfigureAction) runInteractiveConfigureAction(cmd *cobra.Command, c
 					val,
 					false,
 					true,
-					false,
+					a.hasAuthenticatorKey(authenticator.AuthenticatorName(), flag.Name, actions.ConfigurationAllowedEmptyValues),
 				)
 				authProfileAnswers[strings.Replace(strings.TrimPrefix(flag.Name, authPrefix), "-", "_", -1)] = val
 			}
@@ -414,6 +468,10 @@ func (a *ArkConfigureAction) runInteractiveConfigureAction(cmd *cobra.Command, c
 			if err != nil {
 				return nil, err
 			}
+			err = validateSecretRef(authenticator, authProfile)
+			if err != nil {
+				return nil, err
+			}
 			var methodSettings interface{}
 			if authMethod != authProfile.AuthMethod {
 				methodSettings = authmodels.ArkAuthMethodSettingsMap[authMethod]
@@ -485,6 +543,9 @@ func (a *ArkConfigureAction) runInteractiveConfigureAction(cmd *cobra.Command, c
 
 			authProfile.AuthMethod = authMethod
 			authProfile.AuthMethodSettings = methodSettings
+			if authProfile.RequiresUsername() && authProfile.Username == "" {
+				return nil, fmt.Errorf("missing username for authenticator [%s]", authenticator.AuthenticatorHumanReadableName())
+			}
 			profile.AuthProfiles[authenticator.AuthenticatorName()] = authProfile
 		} else if _, exists := profile.AuthProfiles[authenticator.AuthenticatorName()]; exists {
 			delete(profile.AuthProfiles, authenticator.AuthenticatorName())
@@ -543,9 +604,13 @@ func (a *ArkConfigureAction) runSilentConfigureAction(cmd *cobra.Command, args [
 	if err != nil {
 		return nil, err
 	}
+	err = a.applyTransportFlags(cmd, profile)
+	if err != nil {
+		return nil, err
+	}
 
 	// Load the authenticators
-	for _, authenticator := range auth.SupportedAuthenticatorsList {
+	for _, authenticator := range auth.Authenticators() {
 		authProfile, ok := profile.AuthProfiles[authenticator.AuthenticatorName()]
 		if !ok || authProfile == nil {
 			authProfile = &authmodels.ArkAuthProfile{}
@@ -581,8 +646,9 @@ func (a *ArkConfigureAction) runSilentConfigureAction(cmd *cobra.Command, args [
 			if err != nil {
 				return nil, err
 			}
-			if slices.Contains(authmodels.ArkAuthMethodsRequireCredentials, authMethod) && authProfile.Username == "" {
-				return nil, fmt.Errorf("missing username for authenticator [%s]", authenticator.AuthenticatorHumanReadableName())
+			err = validateSecretRef(authenticator, authProfile)
+			if err != nil {
+				return nil, err
 			}
 
 			var methodSettings interface{}
@@ -602,6 +668,9 @@ func (a *ArkConfigureAction) runSilentConfigureAction(cmd *cobra.Command, args [
 
 			authProfile.AuthMethod = authMethod
 			authProfile.AuthMethodSettings = methodSettings
+			if authProfile.RequiresUsername() && authProfile.Username == "" {
+				return nil, fmt.Errorf("missing username for authenticator [%s]", authenticator.AuthenticatorHumanReadableName())
+			}
 			profile.AuthProfiles[authenticator.AuthenticatorName()] = authProfile
 		} else if _, exists := profile.AuthProfiles[authenticator.AuthenticatorName()]; exists {
 			delete(profile.AuthProfiles, authenticator.AuthenticatorName())
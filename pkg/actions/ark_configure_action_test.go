@@ -1,13 +1,19 @@
 package actions
 
 import (
+	"encoding/json"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/models"
 	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/gen/gpflag"
 	"github.com/spf13/cobra"
 )
 
@@ -242,6 +248,50 @@ func TestArkConfigureAction_runSilentConfigureAction(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "success_merges_transport_flags_into_existing_config",
+			setupLoader: func() profiles.ProfileLoader {
+				existingProfile := &models.ArkProfile{
+					ProfileName:  "test-profile",
+					AuthProfiles: map[string]*authmodels.ArkAuthProfile{},
+					TransportConfig: &common.ArkTransportConfig{
+						ProxyURL:              "http://old-proxy:3128",
+						RequestTimeoutSeconds: 30,
+					},
+				}
+				mock := testutils.NewMockProfileLoader()
+				mock.LoadProfileFunc = func(name string) (*models.ArkProfile, error) {
+					return existingProfile, nil
+				}
+				return mock
+			},
+			setupFlags: func(cmd *cobra.Command) {
+				_ = cmd.Flags().String("profile-name", "", "Profile name")
+				_ = gpflag.ParseTo(&common.ArkTransportConfig{}, cmd.Flags(), sflags.Prefix(transportFlagsPrefix))
+				_ = cmd.Flags().Set("profile-name", "test-profile")
+				_ = cmd.Flags().Set("transport-proxy-url", "http://proxy:3128")
+				_ = cmd.Flags().Set("transport-no-proxy", "localhost,.internal")
+				_ = cmd.Flags().Set("transport-max-conns-per-host", "8")
+				_ = cmd.Flags().Set("transport-exclude-system-cas", "true")
+			},
+			expectedError: false,
+			validateFunc: func(t *testing.T, profile *models.ArkProfile, err error) {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+					return
+				}
+				expected := &common.ArkTransportConfig{
+					ProxyURL:              "http://proxy:3128",
+					NoProxy:               []string{"localhost", ".internal"},
+					ExcludeSystemCAs:      true,
+					RequestTimeoutSeconds: 30,
+					MaxConnsPerHost:       8,
+				}
+				if !reflect.DeepEqual(profile.TransportConfig, expected) {
+					t.Errorf("Expected transport config %+v, got %+v", expected, profile.TransportConfig)
+				}
+			},
+		},
 		{
 			name: "edge_case_handles_flag_parsing_errors",
 			setupLoader: func() profiles.ProfileLoader {
@@ -404,3 +454,118 @@ func TestArkConfigureAction_ArkActionInterface(t *testing.T) {
 		})
 	}
 }
+
+type testBrokerAuthMethodSettings struct {
+	BrokerURL string `json:"broker_url" mapstructure:"broker_url" flag:"broker-url" desc:"Token broker URL"`
+}
+
+var registerTestBrokerOnce sync.Once
+
+// registerTestBroker registers a custom authenticator with an auth method of its own, once per test binary.
+func registerTestBroker(t *testing.T) {
+	t.Helper()
+	registerTestBrokerOnce.Do(func() {
+		if err := authmodels.RegisterArkAuthMethod("test_broker", "Test Token Broker", &testBrokerAuthMethodSettings{}, true); err != nil {
+			t.Fatalf("failed to register auth method: %v", err)
+		}
+		if err := auth.RegisterAuthenticator(testutils.NewMockAuthenticator("broker", "test_broker")); err != nil {
+			t.Fatalf("failed to register authenticator: %v", err)
+		}
+	})
+}
+
+func TestArkConfigureAction_RegisteredAuthenticator(t *testing.T) {
+	registerTestBroker(t)
+	if err := auth.RegisterAuthenticator(testutils.NewMockAuthenticator("broker", "test_broker")); err == nil {
+		t.Error("Expected registering the same authenticator name twice to fail")
+	}
+
+	mock := testutils.NewMockProfileLoader()
+	mock.LoadProfileFunc = func(name string) (*models.ArkProfile, error) {
+		return nil, nil
+	}
+	loader := mock.AsProfileLoader()
+	action := NewArkConfigureAction(loader)
+	rootCmd := &cobra.Command{Use: "ark"}
+	action.DefineAction(rootCmd)
+	configureCmd, _, err := rootCmd.Find([]string{"configure"})
+	if err != nil {
+		t.Fatalf("Expected configure command, got %v", err)
+	}
+	for _, flagName := range []string{"work-with-broker", "broker-username", "broker-broker-url", "work-with-isp"} {
+		if configureCmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Expected flag '%s' to be defined", flagName)
+		}
+	}
+
+	NewArkLoginAction(loader).DefineAction(rootCmd)
+	loginCmd, _, _ := rootCmd.Find([]string{"login"})
+	if loginCmd == nil || loginCmd.Flags().Lookup("broker-username") == nil || loginCmd.Flags().Lookup("broker-secret") == nil {
+		t.Error("Expected login flags for the registered authenticator")
+	}
+
+	_ = configureCmd.Flags().Set("profile-name", "broker-profile")
+	_ = configureCmd.Flags().Set("work-with-broker", "true")
+	_ = configureCmd.Flags().Set("broker-username", "svc@example.com")
+	_ = configureCmd.Flags().Set("broker-broker-url", "https://broker.example.com")
+	savedProfile, err := action.runSilentConfigureAction(configureCmd, []string{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	authProfile, ok := savedProfile.AuthProfiles["broker"]
+	if !ok {
+		t.Fatal("Expected the broker auth profile to be configured")
+	}
+	if authProfile.AuthMethod != "test_broker" || authProfile.Username != "svc@example.com" {
+		t.Errorf("Unexpected auth profile %+v", authProfile)
+	}
+	settings, ok := authProfile.AuthMethodSettings.(*testBrokerAuthMethodSettings)
+	if !ok || settings.BrokerURL != "https://broker.example.com" {
+		t.Errorf("Unexpected auth method settings %+v", authProfile.AuthMethodSettings)
+	}
+
+	data, err := json.Marshal(authProfile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var loadedProfile authmodels.ArkAuthProfile
+	if err := json.Unmarshal(data, &loadedProfile); err != nil {
+		t.Fatalf("Expected the registered auth method to be loaded, got %v", err)
+	}
+	if loaded, ok := loadedProfile.AuthMethodSettings.(*testBrokerAuthMethodSettings); !ok || loaded.BrokerURL != "https://broker.example.com" {
+		t.Errorf("Unexpected loaded auth method settings %+v", loadedProfile.AuthMethodSettings)
+	}
+}
+
+func TestMockAuthenticator_ArkAuthBaseFlow(t *testing.T) {
+	authenticator := testutils.NewMockAuthenticator("mock", authmodels.Other)
+	profile := &models.ArkProfile{
+		ProfileName: "mock-profile",
+		AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+			"mock": {Username: "user", AuthMethod: authmodels.Other},
+		},
+	}
+
+	token, err := authenticator.Authenticate(profile, nil, nil, false, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token.Token != "mock-token" || authenticator.AuthenticateCount != 1 {
+		t.Errorf("Expected PerformAuthentication to issue the token, got %+v", token)
+	}
+	if !authenticator.IsAuthenticated(profile) {
+		t.Error("Expected the authenticator to be authenticated")
+	}
+	if _, err := authenticator.Authenticate(profile, &authmodels.ArkAuthProfile{Username: "user", AuthMethod: authmodels.Identity}, nil, false, false); err == nil {
+		t.Error("Expected an unsupported auth method to be rejected")
+	}
+	if err := authenticator.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if authenticator.IsAuthenticated(profile) || authenticator.Token != nil {
+		t.Error("Expected the token to be dropped on close")
+	}
+	if token.Token != "mock-token" {
+		t.Errorf("Expected the token returned to the caller to be left untouched, got %+v", token)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/cli"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/common/args"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
 	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
 )
 
@@ -180,25 +181,17 @@ func (a *ArkBaseExecAction) runExecAction(cmd *cobra.Command, execArgs []string)
 		args.PrintFailure("Failed to find exec command")
 		return
 	}
-	profileName, _ := execCmd.Flags().GetString("profile-name")
-	profile, err := (*a.profilesLoader).LoadProfile(profiles.DeduceProfileName(profileName))
-	if err != nil || profile == nil {
-		args.PrintFailure("Please configure a profile before trying to login")
+	// A session exported by "ark login --export" takes the place of the profile and its cached tokens
+	profile, authenticators, err := auth.ImportArkSessionFromEnv()
+	if err != nil {
+		args.PrintFailure(fmt.Sprintf("Failed to import session from %s: %s", auth.ArkSessionEnvVar, err))
 		return
 	}
-
-	var authenticators []auth.ArkAuth
-	for authenticatorName := range profile.AuthProfiles {
-		authenticator := auth.SupportedAuthenticators[authenticatorName]
-		refreshAuth, _ := cmd.Flags().GetBool("refresh-auth")
-		token, err := authenticator.LoadAuthentication(profile, refreshAuth)
-		if err != nil || token == nil {
-			continue
+	if profile == nil {
+		profile, authenticators = a.loadAuthenticators(cmd, execCmd)
+		if profile == nil {
+			return
 		}
-		if time.Now().After(time.Time(token.ExpiresIn)) {
-			continue
-		}
-		authenticators = append(authenticators, authenticator)
 	}
 
 	if len(authenticators) == 0 {
@@ -229,3 +222,34 @@ func (a *ArkBaseExecAction) runExecAction(cmd *cobra.Command, execArgs []string)
 		args.PrintFailure(fmt.Sprintf("Failed to execute action: %s", err))
 	}
 }
+
+// loadAuthenticators loads the profile of the exec command, and the authenticators it is logged in to with unexpired tokens.
+//
+// Returns a nil profile if the profile could not be loaded, after printing the failure.
+func (a *ArkBaseExecAction) loadAuthenticators(cmd *cobra.Command, execCmd *cobra.Command) (*models.ArkProfile, []auth.ArkAuth) {
+	profileName, _ := execCmd.Flags().GetString("profile-name")
+	profile, err := profiles.LoadEffectiveProfile(*a.profilesLoader, profiles.DeduceProfileName(profileName))
+	if err != nil || profile == nil {
+		args.PrintFailure("Please configure a profile before trying to login")
+		return nil, nil
+	}
+
+	var authenticators []auth.ArkAuth
+	for authenticatorName := range profile.AuthProfiles {
+		authenticator, err := auth.GetAuthenticator(authenticatorName)
+		if err != nil {
+			a.logger.Warning("Skipping authenticator %s: %v", authenticatorName, err)
+			continue
+		}
+		refreshAuth, _ := cmd.Flags().GetBool("refresh-auth")
+		token, err := authenticator.LoadAuthentication(profile, refreshAuth)
+		if err != nil || token == nil {
+			continue
+		}
+		if time.Now().After(time.Time(token.ExpiresIn)) {
+			continue
+		}
+		authenticators = append(authenticators, authenticator)
+	}
+	return profile, authenticators
+}
@@ -3,6 +3,7 @@ package actions
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"slices"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
@@ -70,6 +71,8 @@ func NewArkLoginAction(profilesLoader *profiles.ProfileLoader) *ArkLoginAction {
 //   - no-shared-secrets: Disables credential sharing between authenticators
 //   - show-tokens: Displays authentication tokens in output
 //   - refresh-auth: Attempts to refresh existing tokens from cache
+//   - export: Prints an encrypted session bundle of the login, to be handed to other processes in ARK_SESSION
+//   - export-ttl: How long the exported session bundle can be imported for
 //   - [authenticator]-username: Username for specific authenticators
 //   - [authenticator]-secret: Secret/password for specific authenticators
 //
@@ -100,8 +103,10 @@ func (a *ArkLoginAction) DefineAction(cmd *cobra.Command) {
 	loginCmd.Flags().Bool("no-shared-secrets", false, "Do not share secrets between different authenticators with the same username")
 	loginCmd.Flags().Bool("show-tokens", false, "Print out tokens as well if not silent")
 	loginCmd.Flags().Bool("refresh-auth", false, "If a cache exists, will also try to refresh it")
+	loginCmd.Flags().Bool("export", false, fmt.Sprintf("Print an encrypted session bundle of the login for %s, encrypted with the passphrase of %s", auth.ArkSessionEnvVar, auth.ArkSessionKeyEnvVar))
+	loginCmd.Flags().Duration("export-ttl", auth.DefaultSessionTTL, "How long the exported session bundle can be imported for")
 
-	for _, authenticator := range auth.SupportedAuthenticatorsList {
+	for _, authenticator := range auth.Authenticators() {
 		loginCmd.Flags().String(fmt.Sprintf("%s-username", authenticator.AuthenticatorName()), "", fmt.Sprintf("Username to authenticate with to %s", authenticator.AuthenticatorHumanReadableName()))
 		loginCmd.Flags().String(fmt.Sprintf("%s-secret", authenticator.AuthenticatorName()), "", fmt.Sprintf("Secret to authenticate with to %s", authenticator.AuthenticatorHumanReadableName()))
 	}
@@ -145,7 +150,7 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 	a.CommonActionsExecution(cmd, loginArgs)
 
 	profileName, _ := cmd.Flags().GetString("profile-name")
-	profile, err := (*a.profilesLoader).LoadProfile(profiles.DeduceProfileName(profileName))
+	profile, err := profiles.LoadEffectiveProfile(*a.profilesLoader, profiles.DeduceProfileName(profileName))
 	if err != nil || profile == nil {
 		args.PrintFailure("Please configure a profile before trying to login")
 		return
@@ -153,9 +158,14 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 
 	sharedSecretsMap := make(map[authmodels.ArkAuthMethod][][2]string)
 	tokensMap := make(map[string]*authmodels.ArkToken)
+	var authenticated []auth.ArkAuth
 
 	for authenticatorName, authProfile := range profile.AuthProfiles {
-		authenticator := auth.SupportedAuthenticators[authenticatorName]
+		authenticator, err := auth.GetAuthenticator(authenticatorName)
+		if err != nil {
+			args.PrintWarning(fmt.Sprintf("Skipping %s: %s", authenticatorName, err))
+			continue
+		}
 		force, _ := cmd.Flags().GetBool("force")
 		refreshAuth, _ := cmd.Flags().GetBool("refresh-auth")
 		if authenticator.IsAuthenticated(profile) && !force {
@@ -163,11 +173,13 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 				_, err := authenticator.LoadAuthentication(profile, true)
 				if err == nil {
 					args.PrintSuccess(fmt.Sprintf("%s Authentication Refreshed", authenticator.AuthenticatorHumanReadableName()))
+					authenticated = append(authenticated, authenticator)
 					continue
 				}
 				a.logger.Info("%s Failed to refresh token, performing normal login [%s]", authenticator.AuthenticatorHumanReadableName(), err.Error())
 			} else {
 				args.PrintSuccess(fmt.Sprintf("%s Already Authenticated", authenticator.AuthenticatorHumanReadableName()))
+				authenticated = append(authenticated, authenticator)
 				continue
 			}
 		}
@@ -177,7 +189,7 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 		if userName == "" {
 			userName = authProfile.Username
 		}
-		if common.IsInteractive() && slices.Contains(authmodels.ArkAuthMethodsRequireCredentials, authProfile.AuthMethod) {
+		if common.IsInteractive() && authProfile.RequiresSecret() {
 			authProfile.Username, err = args.GetArg(
 				cmd,
 				fmt.Sprintf("%s-username", authenticatorName),
@@ -201,6 +213,9 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 						authProfile.AuthMethodSettings.(*authmodels.IdentityArkAuthMethodSettings).IdentityURL,
 						authProfile.AuthMethodSettings.(*authmodels.IdentityArkAuthMethodSettings).IdentityTenantSubdomain) {
 					secret = &authmodels.ArkSecret{Secret: ""}
+				} else if secretStr == "" && authProfile.SecretRef != "" {
+					// The secret reference of the profile is resolved on authentication
+					secret = &authmodels.ArkSecret{Secret: ""}
 				} else {
 					secretStr, err = args.GetArg(
 						cmd,
@@ -218,7 +233,7 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 					secret = &authmodels.ArkSecret{Secret: secretStr}
 				}
 			}
-		} else if !common.IsInteractive() && slices.Contains(authmodels.ArkAuthMethodsRequireCredentials, authProfile.AuthMethod) && secret.Secret == "" {
+		} else if !common.IsInteractive() && authProfile.RequiresSecret() && secret.Secret == "" && authProfile.SecretRef == "" {
 			args.PrintFailure(fmt.Sprintf("%s-secret argument is required if authenticating to %s", authenticatorName, authenticator.AuthenticatorHumanReadableName()))
 			return
 		}
@@ -230,10 +245,21 @@ func (a *ArkLoginAction) runLoginAction(cmd *cobra.Command, loginArgs []string)
 		}
 
 		noSharedSecrets, _ := cmd.Flags().GetBool("no-shared-secrets")
-		if !noSharedSecrets && slices.Contains(authmodels.ArkAuthMethodSharableCredentials, authProfile.AuthMethod) {
+		if !noSharedSecrets && secret.Secret != "" && slices.Contains(authmodels.ArkAuthMethodSharableCredentials, authProfile.AuthMethod) {
 			sharedSecretsMap[authProfile.AuthMethod] = append(sharedSecretsMap[authProfile.AuthMethod], [2]string{authProfile.Username, secret.Secret})
 		}
 		tokensMap[authenticator.AuthenticatorHumanReadableName()] = token
+		authenticated = append(authenticated, authenticator)
+	}
+	if export, _ := cmd.Flags().GetBool("export"); export {
+		exportTTL, _ := cmd.Flags().GetDuration("export-ttl")
+		bundle, err := auth.ExportArkSession(profile, os.Getenv(auth.ArkSessionKeyEnvVar), exportTTL, authenticated...)
+		if err != nil {
+			args.PrintFailure(fmt.Sprintf("Failed to export session: %s", err))
+			return
+		}
+		// The bundle is the output of the command, so it is printed even when silent
+		_, _ = fmt.Fprintln(os.Stdout, bundle)
 	}
 	showTokens, _ := cmd.Flags().GetBool("show-tokens")
 	if !showTokens && len(tokensMap) > 0 {
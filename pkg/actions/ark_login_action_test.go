@@ -394,6 +394,24 @@ func TestArkLoginAction_runLoginAction(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "error_client_credentials_missing_client_secret",
+			setupAction: func() *ArkLoginAction {
+				return clientCredentialsLoginAction(authmodels.ClientSecretAuth)
+			},
+			setupCmd:       clientCredentialsLoginCmd,
+			loginArgs:      []string{},
+			expectedOutput: "isp-secret argument is required",
+		},
+		{
+			name: "success_client_credentials_private_key_jwt_without_secret",
+			setupAction: func() *ArkLoginAction {
+				return clientCredentialsLoginAction(authmodels.PrivateKeyJWTAuth)
+			},
+			setupCmd:       clientCredentialsLoginCmd,
+			loginArgs:      []string{},
+			expectedOutput: "private key file is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -499,3 +517,37 @@ func TestArkLoginAction_Integration(t *testing.T) {
 		})
 	}
 }
+
+func clientCredentialsLoginAction(clientAuthMethod string) *ArkLoginAction {
+	mockLoader := testutils.NewMockProfileLoader()
+	mockLoader.LoadProfileFunc = func(name string) (*models.ArkProfile, error) {
+		return &models.ArkProfile{
+			ProfileName: "client-credentials-login-test",
+			AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+				"isp": {
+					Username:   "client-id",
+					AuthMethod: authmodels.ClientCredentials,
+					AuthMethodSettings: &authmodels.ClientCredentialsArkAuthMethodSettings{
+						TokenEndpoint:    "https://127.0.0.1:1/oauth2/token",
+						ClientAuthMethod: clientAuthMethod,
+					},
+				},
+			},
+		}, nil
+	}
+	return NewArkLoginAction(mockLoader.AsProfileLoader())
+}
+
+func clientCredentialsLoginCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "login"}
+	cmd.Flags().String("profile-name", "client-credentials-login-test", "Profile name")
+	cmd.Flags().Bool("force", true, "Force login")
+	cmd.Flags().Bool("refresh-auth", false, "Refresh auth")
+	cmd.Flags().Bool("no-shared-secrets", false, "No shared secrets")
+	cmd.Flags().Bool("show-tokens", false, "Show tokens")
+	cmd.Flags().Bool("silent", true, "Silent")
+	cmd.Flags().Bool("allow-output", true, "Allow output")
+	cmd.Flags().String("isp-username", "", "ISP username")
+	cmd.Flags().String("isp-secret", "", "ISP secret")
+	return cmd
+}
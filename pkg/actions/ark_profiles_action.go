@@ -2,6 +2,7 @@ package actions
 
 import (
 	"bytes"
+	"crypto"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -31,6 +32,7 @@ import (
 //   - Clone profiles with automatic or custom naming
 //   - Add profiles from file paths
 //   - Edit profiles interactively using an external editor
+//   - Export and import signed bundles of several profiles
 type ArkProfilesAction struct {
 	// ArkBaseAction provides common action functionality
 	*ArkBaseAction
@@ -76,6 +78,8 @@ func NewArkProfilesAction(profilesLoader *profiles.ProfileLoader) *ArkProfilesAc
 //   - clone: Clones an existing profile with optional renaming
 //   - add: Adds a profile from a file path
 //   - edit: Opens a profile for interactive editing
+//   - export: Exports profiles to a bundle file, optionally signed
+//   - import: Imports the profiles of a bundle file, verifying its signature
 //
 // Parameters:
 //   - cmd: The parent cobra command to which the profiles command will be added
@@ -87,7 +91,7 @@ func NewArkProfilesAction(profilesLoader *profiles.ProfileLoader) *ArkProfilesAc
 //
 //	profilesAction := NewArkProfilesAction(loader)
 //	profilesAction.DefineAction(rootCmd)
-//	// This adds: myapp profiles [list|show|delete|clear|clone|add|edit] [flags]
+//	// This adds: myapp profiles [list|show|delete|clear|clone|add|edit|export|import] [flags]
 func (a *ArkProfilesAction) DefineAction(cmd *cobra.Command) {
 	profileCmd := &cobra.Command{
 		Use:   "profiles",
@@ -113,6 +117,7 @@ func (a *ArkProfilesAction) DefineAction(cmd *cobra.Command) {
 		Run:   a.runShowAction,
 	}
 	showCmd.Flags().StringP("profile-name", "", "", "Profile name to show, if not given, shows the current one")
+	showCmd.Flags().BoolP("effective", "", false, "Whether to show the effective profile, merged over the profiles it extends and with the environment overrides applied")
 
 	deleteCmd := &cobra.Command{
 		Use:   "delete",
@@ -152,7 +157,26 @@ func (a *ArkProfilesAction) DefineAction(cmd *cobra.Command) {
 	}
 	editCmd.Flags().StringP("profile-name", "", "", "Profile name to edit, if not given, edits the current one")
 
-	profileCmd.AddCommand(listCmd, showCmd, deleteCmd, clearCmd, cloneCmd, addCmd, editCmd)
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export profiles to a bundle",
+		Run:   a.runExportAction,
+	}
+	exportCmd.Flags().StringSlice("profile-names", nil, "Profile names to export, if not given, exports all of them")
+	exportCmd.Flags().String("output-path", "", "File to write the bundle to, if not given, writes it to stdout")
+	exportCmd.Flags().String("signing-key-path", "", "PEM file of the Ed25519, ECDSA or RSA private key to sign the bundle with")
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import the profiles of a bundle",
+		Run:   a.runImportAction,
+	}
+	importCmd.Flags().String("bundle-path", "", "Bundle file to import the profiles of")
+	importCmd.Flags().String("public-key-path", "", "PEM file of the public key or certificate the bundle must be signed with")
+	importCmd.Flags().Bool("allow-unsigned", false, "Whether to import the bundle without verifying its signature")
+	importCmd.Flags().BoolP("yes", "", false, "Whether to override existing profiles without prompting")
+
+	profileCmd.AddCommand(listCmd, showCmd, deleteCmd, clearCmd, cloneCmd, addCmd, editCmd, exportCmd, importCmd)
 	cmd.AddCommand(profileCmd)
 }
 
@@ -230,7 +254,9 @@ func (a *ArkProfilesAction) runListAction(cmd *cobra.Command, args []string) {
 //
 // runShowAction displays detailed information for a specific profile. If no profile
 // name is provided, it uses the default profile name deduction logic to determine
-// which profile to show.
+// which profile to show. With the effective flag, the profile is shown merged over
+// the profiles it extends and with the ARK_ environment overrides applied, as it is
+// used by the other commands.
 //
 // Parameters:
 //   - cmd: The cobra command containing the profile-name flag
@@ -238,6 +264,7 @@ func (a *ArkProfilesAction) runListAction(cmd *cobra.Command, args []string) {
 //
 // Supported flags:
 //   - profile-name: Name of the profile to show (optional, defaults to current profile)
+//   - effective: Show the effective profile instead of the stored one
 //
 // The function prints a warning if the specified profile is not found, otherwise
 // it outputs the profile data in JSON format.
@@ -253,6 +280,15 @@ func (a *ArkProfilesAction) runShowAction(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	effective, _ := cmd.Flags().GetBool("effective")
+	if effective && profile != nil {
+		profile, err = profiles.ResolveProfile(*a.profilesLoader, profile)
+		if err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("Failed to resolve the effective profile: %s", err))
+			return
+		}
+	}
+
 	data, _ := json.MarshalIndent(profile, "", "  ")
 	commonargs.PrintSuccess(string(data))
 }
@@ -481,3 +517,134 @@ func (a *ArkProfilesAction) runEditAction(cmd *cobra.Command, args []string) {
 		return
 	}
 }
+
+// runExportAction handles the profiles export command execution.
+//
+// runExportAction writes the given profiles, or all of them, to a bundle, signed with
+// the given private key. Profiles are exported as stored, so profiles extending a base
+// profile which is not exported are reported, as they cannot be resolved without it.
+//
+// Supported flags:
+//   - profile-names: Names of the profiles to export (optional, defaults to all profiles)
+//   - output-path: File to write the bundle to (optional, defaults to stdout)
+//   - signing-key-path: PEM file of the private key to sign the bundle with (optional)
+func (a *ArkProfilesAction) runExportAction(cmd *cobra.Command, args []string) {
+	profileNames, _ := cmd.Flags().GetStringSlice("profile-names")
+	var exportedProfiles []*models.ArkProfile
+	if len(profileNames) == 0 {
+		loadedProfiles, err := (*a.profilesLoader).LoadAllProfiles()
+		if err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("Failed to load profiles: %s", err))
+			return
+		}
+		exportedProfiles = loadedProfiles
+	}
+	for _, profileName := range profileNames {
+		profile, err := (*a.profilesLoader).LoadProfile(profileName)
+		if err != nil || profile == nil {
+			commonargs.PrintFailure(fmt.Sprintf("No profile was found for the name %s", profileName))
+			return
+		}
+		exportedProfiles = append(exportedProfiles, profile)
+	}
+	exportedNames := map[string]bool{}
+	for _, profile := range exportedProfiles {
+		exportedNames[profile.ProfileName] = true
+	}
+	for _, profile := range exportedProfiles {
+		if profile.Extends != "" && !exportedNames[profile.Extends] {
+			commonargs.PrintWarning(fmt.Sprintf("Profile %s extends %s, which is not exported", profile.ProfileName, profile.Extends))
+		}
+	}
+
+	var signer crypto.Signer
+	signingKeyPath, _ := cmd.Flags().GetString("signing-key-path")
+	if signingKeyPath != "" {
+		var err error
+		if signer, err = profiles.ReadProfilesBundleSigningKey(signingKeyPath); err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("Failed to read signing key: %s", err))
+			return
+		}
+	}
+	data, err := profiles.MarshalProfilesBundle(profiles.NewArkProfilesBundle(exportedProfiles), signer)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to export profiles: %s", err))
+		return
+	}
+	outputPath, _ := cmd.Flags().GetString("output-path")
+	if outputPath == "" {
+		_, _ = fmt.Fprintln(os.Stdout, string(data))
+		return
+	}
+	if err := os.WriteFile(outputPath, append(data, '\n'), 0644); err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Failed to write profiles bundle: %s", err))
+		return
+	}
+	commonargs.PrintSuccess(fmt.Sprintf("Exported %d profiles to %s", len(exportedProfiles), outputPath))
+}
+
+// runImportAction handles the profiles import command execution.
+//
+// runImportAction saves the profiles of a bundle, after verifying that it is signed by the
+// private key of the given public key. Bundles are only imported without verification when
+// explicitly allowed. Existing profiles are overridden after confirmation.
+//
+// Supported flags:
+//   - bundle-path: Bundle file to import (required)
+//   - public-key-path: PEM file of the public key the bundle must be signed with
+//   - allow-unsigned: Import the bundle without verifying its signature
+//   - yes: Override existing profiles without prompting
+func (a *ArkProfilesAction) runImportAction(cmd *cobra.Command, args []string) {
+	bundlePath, _ := cmd.Flags().GetString("bundle-path")
+	publicKeyPath, _ := cmd.Flags().GetString("public-key-path")
+	allowUnsigned, _ := cmd.Flags().GetBool("allow-unsigned")
+	if publicKeyPath == "" && !allowUnsigned {
+		commonargs.PrintFailure("A public key is required to verify the bundle, use --allow-unsigned to import it without verification")
+		return
+	}
+	var publicKey crypto.PublicKey
+	if publicKeyPath != "" {
+		var err error
+		if publicKey, err = profiles.ReadProfilesBundleVerificationKey(publicKeyPath); err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("Failed to read public key: %s", err))
+			return
+		}
+	}
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Bundle path [%s] failed to be read, aborting", bundlePath))
+		return
+	}
+	bundle, signature, err := profiles.UnmarshalProfilesBundle(data, profiles.ProfileFormatFromPath(bundlePath), publicKey)
+	if err != nil {
+		commonargs.PrintFailure(fmt.Sprintf("Bundle path [%s] failed to be verified or parsed: %s", bundlePath, err))
+		return
+	}
+	if publicKey == nil {
+		if signature != nil {
+			commonargs.PrintWarning(fmt.Sprintf("Importing bundle signed by key %s without verifying it", signature.KeyID))
+		} else {
+			commonargs.PrintWarning("Importing unsigned bundle")
+		}
+	}
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	imported := 0
+	for _, profile := range bundle.Profiles {
+		if (*a.profilesLoader).ProfileExists(profile.ProfileName) && !yes {
+			confirm := false
+			prompt := &survey.Confirm{
+				Message: fmt.Sprintf("Profile %s already exists, do you want to override it?", profile.ProfileName),
+			}
+			if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+				continue
+			}
+		}
+		if err := (*a.profilesLoader).SaveProfile(profile); err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("Failed to save profile %s: %s", profile.ProfileName, err))
+			return
+		}
+		imported++
+	}
+	commonargs.PrintSuccess(fmt.Sprintf("Imported %d profiles from %s", imported, bundlePath))
+}
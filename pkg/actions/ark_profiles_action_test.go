@@ -1,7 +1,13 @@
 package actions
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -95,7 +101,7 @@ func TestArkProfilesAction_DefineAction(t *testing.T) {
 				}
 
 				// Check for expected subcommands
-				expectedSubcommands := []string{"list", "show", "delete", "clear", "clone", "add", "edit"}
+				expectedSubcommands := []string{"list", "show", "delete", "clear", "clone", "add", "edit", "export", "import"}
 				actualSubcommands := make([]string, len(profilesCmd.Commands()))
 				for i, subcmd := range profilesCmd.Commands() {
 					actualSubcommands[i] = subcmd.Use
@@ -476,6 +482,54 @@ func TestArkProfilesAction_runShowAction(t *testing.T) {
 				// Function should handle profile not found gracefully
 			},
 		},
+		{
+			name: "success_shows_effective_profile",
+			setupLoader: func() profiles.ProfileLoader {
+				child := testutils.CreateTestProfile("child")
+				child.Extends = "base"
+				mock := testutils.NewMockProfileLoader()
+				mock.LoadProfileFunc = func(name string) (*models.ArkProfile, error) {
+					if name == "base" {
+						return testutils.CreateTestProfile("base"), nil
+					}
+					return child, nil
+				}
+				return mock
+			},
+			setupFlags: func(cmd *cobra.Command) {
+				_ = cmd.Flags().String("profile-name", "", "Profile name")
+				_ = cmd.Flags().Bool("effective", false, "Effective profile")
+				_ = cmd.Flags().Set("profile-name", "child")
+				_ = cmd.Flags().Set("effective", "true")
+			},
+			validateFunc: func(t *testing.T, loader profiles.ProfileLoader) {
+				// Function should resolve the base profile of the child profile
+			},
+		},
+		{
+			name: "error_handles_missing_base_profile",
+			setupLoader: func() profiles.ProfileLoader {
+				child := testutils.CreateTestProfile("child")
+				child.Extends = "missing"
+				mock := testutils.NewMockProfileLoader()
+				mock.LoadProfileFunc = func(name string) (*models.ArkProfile, error) {
+					if name == "child" {
+						return child, nil
+					}
+					return nil, nil
+				}
+				return mock
+			},
+			setupFlags: func(cmd *cobra.Command) {
+				_ = cmd.Flags().String("profile-name", "", "Profile name")
+				_ = cmd.Flags().Bool("effective", false, "Effective profile")
+				_ = cmd.Flags().Set("profile-name", "child")
+				_ = cmd.Flags().Set("effective", "true")
+			},
+			validateFunc: func(t *testing.T, loader profiles.ProfileLoader) {
+				// Function should report the missing base profile gracefully
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -906,3 +960,128 @@ func TestArkProfilesAction_ArkActionInterface(t *testing.T) {
 		})
 	}
 }
+
+// writeProfilesBundleKeys writes an Ed25519 key pair as PEM files, and returns the paths of the private and public keys.
+func writeProfilesBundleKeys(t *testing.T) (string, string) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	publicDER, _ := x509.MarshalPKIXPublicKey(publicKey)
+	folder := t.TempDir()
+	privatePath := filepath.Join(folder, "signing.pem")
+	publicPath := filepath.Join(folder, "signing.pub")
+	_ = os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600)
+	_ = os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0644)
+	return privatePath, publicPath
+}
+
+func TestArkProfilesAction_ExportImport(t *testing.T) {
+	privatePath, publicPath := writeProfilesBundleKeys(t)
+	_, otherPublicPath := writeProfilesBundleKeys(t)
+	bundlePath := filepath.Join(t.TempDir(), "profiles.json")
+
+	var sourceLoader profiles.ProfileLoader
+	sourceLoader, err := profiles.NewInMemoryProfilesLoader(
+		testutils.CreateTestProfile("first"),
+		testutils.CreateTestProfile("second"),
+		testutils.CreateTestProfile("third"),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	exportAction := NewArkProfilesAction(&sourceLoader)
+	exportCmd := &cobra.Command{}
+	exportCmd.Flags().StringSlice("profile-names", nil, "")
+	exportCmd.Flags().String("output-path", "", "")
+	exportCmd.Flags().String("signing-key-path", "", "")
+	_ = exportCmd.Flags().Set("profile-names", "first,second")
+	_ = exportCmd.Flags().Set("output-path", bundlePath)
+	_ = exportCmd.Flags().Set("signing-key-path", privatePath)
+	exportAction.runExportAction(exportCmd, []string{})
+
+	tests := []struct {
+		name             string
+		publicKeyPath    string
+		allowUnsigned    bool
+		expectedProfiles []string
+	}{
+		{
+			name:             "success_imports_verified_bundle",
+			publicKeyPath:    publicPath,
+			expectedProfiles: []string{"first", "second"},
+		},
+		{
+			name:             "success_imports_unverified_bundle_when_allowed",
+			allowUnsigned:    true,
+			expectedProfiles: []string{"first", "second"},
+		},
+		{
+			name:          "error_rejects_bundle_signed_by_another_key",
+			publicKeyPath: otherPublicPath,
+		},
+		{
+			name: "error_requires_public_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var targetLoader profiles.ProfileLoader
+			targetLoader, _ = profiles.NewInMemoryProfilesLoader()
+			importAction := NewArkProfilesAction(&targetLoader)
+			importCmd := &cobra.Command{}
+			importCmd.Flags().String("bundle-path", "", "")
+			importCmd.Flags().String("public-key-path", "", "")
+			importCmd.Flags().Bool("allow-unsigned", false, "")
+			importCmd.Flags().Bool("yes", false, "")
+			_ = importCmd.Flags().Set("bundle-path", bundlePath)
+			_ = importCmd.Flags().Set("public-key-path", tt.publicKeyPath)
+			_ = importCmd.Flags().Set("allow-unsigned", fmt.Sprintf("%t", tt.allowUnsigned))
+			_ = importCmd.Flags().Set("yes", "true")
+			importAction.runImportAction(importCmd, []string{})
+
+			imported, _ := targetLoader.LoadAllProfiles()
+			importedNames := []string{}
+			for _, profile := range imported {
+				importedNames = append(importedNames, profile.ProfileName)
+			}
+			if len(importedNames) != len(tt.expectedProfiles) || (len(importedNames) > 0 && !reflect.DeepEqual(importedNames, tt.expectedProfiles)) {
+				t.Errorf("Expected imported profiles %v, got %v", tt.expectedProfiles, importedNames)
+			}
+			for _, profile := range imported {
+				if !reflect.DeepEqual(profile.AuthProfiles, testutils.CreateTestProfile(profile.ProfileName).AuthProfiles) {
+					t.Errorf("Expected the auth profiles of %s to be imported, got %+v", profile.ProfileName, profile.AuthProfiles)
+				}
+			}
+		})
+	}
+}
+
+func TestArkProfilesAction_ImportRejectsPathProfileNames(t *testing.T) {
+	root := t.TempDir()
+	folder := filepath.Join(root, "profiles")
+	t.Setenv("ARK_PROFILES_FOLDER", folder)
+	bundlePath := filepath.Join(root, "profiles.json")
+	if err := os.WriteFile(bundlePath, []byte(`{"profiles": [{"profile_name": "../escaped"}]}`), 0644); err != nil {
+		t.Fatalf("Failed to write bundle: %v", err)
+	}
+
+	var loader profiles.ProfileLoader = &profiles.FileSystemProfilesLoader{}
+	importAction := NewArkProfilesAction(&loader)
+	importCmd := &cobra.Command{}
+	importCmd.Flags().String("bundle-path", "", "")
+	importCmd.Flags().String("public-key-path", "", "")
+	importCmd.Flags().Bool("allow-unsigned", false, "")
+	importCmd.Flags().Bool("yes", false, "")
+	_ = importCmd.Flags().Set("bundle-path", bundlePath)
+	_ = importCmd.Flags().Set("allow-unsigned", "true")
+	_ = importCmd.Flags().Set("yes", "true")
+	importAction.runImportAction(importCmd, []string{})
+
+	if _, err := os.Stat(filepath.Join(root, "escaped")); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be written outside of the profiles folder, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	commonargs "github.com/cyberark/ark-sdk-golang/pkg/common/args"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+// tokenClaimsVerifier is implemented by authenticators that can verify the claims of their tokens, such as auth.ArkISPAuth.
+type tokenClaimsVerifier interface {
+	VerifyTokenClaims(token *authmodels.ArkToken, verify bool) (*authmodels.ArkTokenClaims, error)
+}
+
+// ArkTokenAction is a struct that implements the ArkAction interface for token introspection.
+//
+// ArkTokenAction prints the claims of the tokens the profile is logged in with, such as
+// the tenant, username, scopes and expiration, optionally verifying their signatures.
+type ArkTokenAction struct {
+	*ArkBaseAction
+	profilesLoader *profiles.ProfileLoader
+}
+
+// NewArkTokenAction creates a new instance of ArkTokenAction.
+//
+// Parameters:
+//   - profilesLoader: A ProfileLoader interface for loading the profile to show the tokens of
+//
+// Example:
+//
+//	loader := profiles.DefaultProfilesLoader()
+//	action := NewArkTokenAction(loader)
+//	action.DefineAction(rootCmd)
+func NewArkTokenAction(profilesLoader *profiles.ProfileLoader) *ArkTokenAction {
+	return &ArkTokenAction{
+		ArkBaseAction:  NewArkBaseAction(),
+		profilesLoader: profilesLoader,
+	}
+}
+
+// DefineAction defines the CLI `token` action, and adds the show subcommand.
+//
+// Parameters:
+//   - cmd: The parent cobra command to attach the token command to
+//
+// Example:
+//
+//	action := NewArkTokenAction(loader)
+//	action.DefineAction(rootCmd)
+//	// Now 'ark token show' command is available
+func (a *ArkTokenAction) DefineAction(cmd *cobra.Command) {
+	tokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "Inspect login tokens",
+	}
+	tokenCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		a.CommonActionsExecution(cmd, args)
+	}
+	a.CommonActionsConfiguration(tokenCmd)
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the claims of the login tokens",
+		Run:   a.runShowAction,
+	}
+	showCmd.Flags().String("profile-name", profiles.DefaultProfileName(), "Profile name to load")
+	showCmd.Flags().String("authenticator", "", "Authenticator to show the token of, if not given, shows all of them")
+	showCmd.Flags().Bool("verify", false, "Whether to verify the signature of the tokens against the keys of their issuer")
+
+	tokenCmd.AddCommand(showCmd)
+	cmd.AddCommand(tokenCmd)
+}
+
+// runShowAction prints the claims of the cached tokens of the profile, by authenticator.
+//
+// Tokens are loaded from the cache as is, without refreshing them. Authenticators the profile
+// is not logged in to are reported and skipped.
+func (a *ArkTokenAction) runShowAction(cmd *cobra.Command, showArgs []string) {
+	profileName, _ := cmd.Flags().GetString("profile-name")
+	authenticatorName, _ := cmd.Flags().GetString("authenticator")
+	verify, _ := cmd.Flags().GetBool("verify")
+	profile, err := profiles.LoadEffectiveProfile(*a.profilesLoader, profiles.DeduceProfileName(profileName))
+	if err != nil || profile == nil {
+		commonargs.PrintFailure("Please configure a profile and login before trying to show tokens")
+		return
+	}
+	if authenticatorName != "" {
+		if _, ok := profile.AuthProfiles[authenticatorName]; !ok {
+			commonargs.PrintFailure(fmt.Sprintf("Profile [%s] has no [%s] authenticator", profile.ProfileName, authenticatorName))
+			return
+		}
+	}
+	authenticatorNames := make([]string, 0, len(profile.AuthProfiles))
+	for name := range profile.AuthProfiles {
+		if authenticatorName == "" || name == authenticatorName {
+			authenticatorNames = append(authenticatorNames, name)
+		}
+	}
+	sort.Strings(authenticatorNames)
+	for _, name := range authenticatorNames {
+		authenticator, err := auth.GetAuthenticator(name)
+		if err != nil {
+			commonargs.PrintWarning(fmt.Sprintf("Skipping %s: %s", name, err))
+			continue
+		}
+		claims, err := a.tokenClaims(authenticator, profile, verify)
+		if err != nil {
+			commonargs.PrintFailure(fmt.Sprintf("%s: %s", authenticator.AuthenticatorHumanReadableName(), err))
+			continue
+		}
+		data, _ := json.MarshalIndent(claims, "", "  ")
+		commonargs.PrintSuccess(fmt.Sprintf("%s Token Claims\n%s", authenticator.AuthenticatorHumanReadableName(), data))
+	}
+}
+
+func (a *ArkTokenAction) tokenClaims(authenticator auth.ArkAuth, profile *models.ArkProfile, verify bool) (*authmodels.ArkTokenClaims, error) {
+	token, err := authenticator.LoadAuthentication(profile, false)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, fmt.Errorf("not logged in, run ark login first")
+	}
+	if claimsVerifier, ok := authenticator.(tokenClaimsVerifier); ok {
+		return claimsVerifier.VerifyTokenClaims(token, verify)
+	}
+	if verify {
+		return nil, fmt.Errorf("verifying tokens is not supported by this authenticator")
+	}
+	return token.Claims()
+}
@@ -0,0 +1,110 @@
+package actions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+)
+
+func TestNewArkTokenAction(t *testing.T) {
+	action := NewArkTokenAction(testutils.NewMockProfileLoader().AsProfileLoader())
+	if action == nil {
+		t.Fatal("Expected non-nil result")
+	}
+	if action.ArkBaseAction == nil {
+		t.Error("Expected ArkBaseAction to be initialized")
+	}
+	if action.profilesLoader == nil {
+		t.Error("Expected profilesLoader to be set")
+	}
+}
+
+func TestArkTokenAction_DefineAction(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "ark"}
+	NewArkTokenAction(testutils.NewMockProfileLoader().AsProfileLoader()).DefineAction(rootCmd)
+
+	showCmd, _, err := rootCmd.Find([]string{"token", "show"})
+	if err != nil || showCmd == nil || showCmd.Use != "show" {
+		t.Fatalf("Expected to find token show command, got %v", err)
+	}
+	for _, flagName := range []string{"profile-name", "authenticator", "verify"} {
+		if showCmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Expected flag '%s' to be defined", flagName)
+		}
+	}
+}
+
+func TestArkTokenAction_tokenClaims(t *testing.T) {
+	jwtToken, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"unique_name": "user@cyberark.cloud.12345",
+		"tenant_id":   "ABC1234",
+		"exp":         time.Now().Add(time.Hour).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authenticated bool
+		verify        bool
+		expectedError string
+	}{
+		{
+			name:          "success_unverified_claims",
+			authenticated: true,
+		},
+		{
+			name:          "error_not_logged_in",
+			expectedError: "not logged in",
+		},
+		{
+			name:          "error_verify_unsupported",
+			authenticated: true,
+			verify:        true,
+			expectedError: "not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authenticator := testutils.NewMockAuthenticator("mock", authmodels.Other)
+			authenticator.AuthenticateFunc = func(authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret) (*authmodels.ArkToken, error) {
+				return &authmodels.ArkToken{Token: jwtToken, TokenType: authmodels.JWT, ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour))}, nil
+			}
+			profile := &models.ArkProfile{
+				ProfileName:  "test",
+				AuthProfiles: map[string]*authmodels.ArkAuthProfile{"mock": {AuthMethod: authmodels.Other}},
+			}
+			if tt.authenticated {
+				if _, err := authenticator.Authenticate(profile, nil, nil, false, false); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			action := NewArkTokenAction(testutils.NewMockProfileLoader().AsProfileLoader())
+			claims, err := action.tokenClaims(authenticator, profile, tt.verify)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if claims.TenantID != "ABC1234" || claims.Username != "user@cyberark.cloud.12345" {
+				t.Errorf("Unexpected claims %+v", claims)
+			}
+		})
+	}
+}
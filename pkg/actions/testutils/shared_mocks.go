@@ -1,7 +1,12 @@
 package testutils
 
 import (
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
 )
 
@@ -115,3 +120,98 @@ func (m *MockProfileLoader) AsProfileLoader() *profiles.ProfileLoader {
 	var loader profiles.ProfileLoader = m
 	return &loader
 }
+
+// MockAuthenticator is a fake authenticator built on auth.ArkAuthBase.
+//
+// MockAuthenticator issues tokens without contacting any platform, so tests can
+// exercise the authenticator registration, caching and refresh flows. The token
+// issued by PerformAuthentication can be customized through AuthenticateFunc, and
+// the number of authentications and refreshes is counted.
+type MockAuthenticator struct {
+	auth.ArkAuth
+	*auth.ArkAuthBase
+	Name              string
+	AuthMethods       []authmodels.ArkAuthMethod
+	AuthenticateFunc  func(*authmodels.ArkAuthProfile, *authmodels.ArkSecret) (*authmodels.ArkToken, error)
+	AuthenticateCount int
+	RefreshCount      int
+}
+
+// NewMockAuthenticator creates a MockAuthenticator with the given name, supporting the given auth methods.
+//
+// The first auth method is the default one, and tokens are not cached in the keyring.
+//
+// Example:
+//
+//	authenticator := NewMockAuthenticator("mock", authmodels.Other)
+//	err := auth.RegisterAuthenticator(authenticator)
+func NewMockAuthenticator(name string, authMethods ...authmodels.ArkAuthMethod) *MockAuthenticator {
+	authenticator := &MockAuthenticator{
+		Name:        name,
+		AuthMethods: authMethods,
+	}
+	authenticator.ArkAuthBase = auth.NewArkAuthBase(false, name, authenticator)
+	return authenticator
+}
+
+// AuthenticatorName returns the name of the mock authenticator.
+func (m *MockAuthenticator) AuthenticatorName() string {
+	return m.Name
+}
+
+// AuthenticatorHumanReadableName returns the human-readable name of the mock authenticator.
+func (m *MockAuthenticator) AuthenticatorHumanReadableName() string {
+	return "Mock " + m.Name
+}
+
+// SupportedAuthMethods returns the auth methods the mock authenticator was created with.
+func (m *MockAuthenticator) SupportedAuthMethods() []authmodels.ArkAuthMethod {
+	return m.AuthMethods
+}
+
+// DefaultAuthMethod returns the first auth method of the mock authenticator, with its registered settings.
+func (m *MockAuthenticator) DefaultAuthMethod() (authmodels.ArkAuthMethod, authmodels.ArkAuthMethodSettings) {
+	return m.AuthMethods[0], authmodels.ArkAuthMethodSettingsMap[m.AuthMethods[0]]
+}
+
+// Authenticate performs the authentication through auth.ArkAuthBase.
+func (m *MockAuthenticator) Authenticate(profile *models.ArkProfile, authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret, force bool, refreshAuth bool) (*authmodels.ArkToken, error) {
+	return m.ArkAuthBase.Authenticate(profile, authProfile, secret, force, refreshAuth)
+}
+
+// LoadAuthentication loads the authentication through auth.ArkAuthBase.
+func (m *MockAuthenticator) LoadAuthentication(profile *models.ArkProfile, refreshAuth bool) (*authmodels.ArkToken, error) {
+	return m.ArkAuthBase.LoadAuthentication(profile, refreshAuth)
+}
+
+// IsAuthenticated checks the authentication through auth.ArkAuthBase.
+func (m *MockAuthenticator) IsAuthenticated(profile *models.ArkProfile) bool {
+	return m.ArkAuthBase.IsAuthenticated(profile)
+}
+
+// PerformAuthentication issues a new token, valid for an hour unless AuthenticateFunc is set.
+func (m *MockAuthenticator) PerformAuthentication(profile *models.ArkProfile, authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret, force bool) (*authmodels.ArkToken, error) {
+	m.AuthenticateCount++
+	if m.AuthenticateFunc != nil {
+		return m.AuthenticateFunc(authProfile, secret)
+	}
+	return &authmodels.ArkToken{
+		Token:        "mock-token",
+		Username:     authProfile.Username,
+		TokenType:    authmodels.Token,
+		AuthMethod:   authProfile.AuthMethod,
+		ExpiresIn:    commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour)),
+		RefreshToken: "mock-refresh-token",
+	}, nil
+}
+
+// PerformRefreshAuthentication extends the given token by an hour.
+func (m *MockAuthenticator) PerformRefreshAuthentication(profile *models.ArkProfile, authProfile *authmodels.ArkAuthProfile, token *authmodels.ArkToken) (*authmodels.ArkToken, error) {
+	m.RefreshCount++
+	if token == nil {
+		return nil, nil
+	}
+	refreshed := *token
+	refreshed.ExpiresIn = commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour))
+	return &refreshed, nil
+}
@@ -12,7 +12,8 @@
 //
 // Key features:
 //   - Centralized access to all ARK services
-//   - Lazy loading and caching of service instances
+//   - Lazy loading and caching of service instances, safe for concurrent use
+//   - Explicit lifecycle, with invalidation of single services and Close
 //   - Authenticator management and distribution to services
 //   - Profile-based configuration management
 //   - Service dependency injection with authenticators
@@ -46,10 +47,16 @@
 //	if err != nil {
 //		// handle error
 //	}
+//
+//	// Release the connections and tokens held by the API
+//	defer api.Close()
 package api
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/models"
@@ -100,12 +107,22 @@ import (
 // automatically distributed to services based on their configuration requirements.
 // Each service specifies required and optional authenticators, and ArkAPI ensures
 // the appropriate authenticators are provided during initialization.
+//
+// ArkAPI is safe for concurrent use. Each service is created once, even when it is
+// first accessed by several goroutines at the same time, and different services are
+// created independently of each other.
 type ArkAPI struct {
 	authenticators []auth.ArkAuth
 	services       map[string]*services.ArkService
 	profile        *models.ArkProfile
+	mutex          sync.Mutex
+	serviceMutexes map[string]*sync.Mutex
+	closed         bool
 }
 
+// ErrArkAPIClosed is returned when accessing a service of an ArkAPI that was closed.
+var ErrArkAPIClosed = errors.New("ark api is closed")
+
 // NewArkAPI creates a new ArkAPI instance with the provided authenticators and profile.
 //
 // Initializes a new ArkAPI instance that serves as the central access point for all
@@ -237,353 +254,256 @@ func (api *ArkAPI) Profile() *models.ArkProfile {
 	return api.profile
 }
 
-func (api *ArkAPI) Cmgr() (*cmgr.ArkCmgrService, error) {
-	if serviceIfs, ok := api.services[cmgr.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*cmgr.ArkCmgrService), nil
-	}
-	service, err := cmgr.ServiceGenerator(api.loadServiceAuthenticators(cmgr.ServiceConfig)...)
-	if err != nil {
-		return nil, err
+// InvalidateService discards the cached instance of a service, so that it is created again on next access.
+//
+// This is meant for credential rotation, where a service has to pick up the new
+// credentials of its authenticators. The idle connections and tokens of the
+// discarded instance are released. Invalidating a service that was not created
+// yet does nothing.
+//
+// Parameters:
+//   - serviceName: The name of the service, as in its ServiceConfig
+//
+// Returns an error if the discarded instance failed to close.
+//
+// Example:
+//
+//	_, err := ispAuth.Authenticate(nil, authProfile, rotatedSecret, true, false)
+//	if err != nil {
+//		// handle error
+//	}
+//	err = api.InvalidateService(accounts.ServiceConfig.ServiceName)
+//	accountsService, err := api.PcloudAccounts() // Created with the new token
+func (api *ArkAPI) InvalidateService(serviceName string) error {
+	serviceMutex := api.serviceMutex(serviceName)
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	api.mutex.Lock()
+	service, ok := api.services[serviceName]
+	delete(api.services, serviceName)
+	api.mutex.Unlock()
+	if !ok {
+		return nil
 	}
-	var baseService services.ArkService = service
-	api.services[cmgr.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return closeService(*service)
 }
 
-func (api *ArkAPI) IdentityDirectories() (*directories.ArkIdentityDirectoriesService, error) {
-	if serviceIfs, ok := api.services[directories.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*directories.ArkIdentityDirectoriesService), nil
+// Close releases the resources held by the API and its services.
+//
+// The idle connections of the services are closed, and the clients of the services are
+// detached from the authenticators. The authenticators themselves are not closed, as they
+// are usually shared with other APIs, such as the ones of auth.GetAuthenticator, and their
+// tokens and background refresh stay in use. Close them explicitly when they are no longer
+// needed. Once closed, accessing a service returns ErrArkAPIClosed. Calling Close more than
+// once does nothing.
+//
+// Returns the errors of the services that failed to close, joined.
+//
+// Example:
+//
+//	api, err := NewArkAPI(authenticators, nil)
+//	if err != nil {
+//		// handle error
+//	}
+//	defer api.Close()
+func (api *ArkAPI) Close() error {
+	api.mutex.Lock()
+	if api.closed {
+		api.mutex.Unlock()
+		return nil
 	}
-	service, err := directories.ServiceGenerator(api.loadServiceAuthenticators(directories.ServiceConfig)...)
-	if err != nil {
-		return nil, err
+	api.closed = true
+	cachedServices := api.services
+	api.services = make(map[string]*services.ArkService)
+	api.mutex.Unlock()
+
+	var errs []error
+	for _, service := range cachedServices {
+		errs = append(errs, closeService(*service))
 	}
-	var baseService services.ArkService = service
-	api.services[directories.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return errors.Join(errs...)
 }
 
-func (api *ArkAPI) IdentityRoles() (*roles.ArkIdentityRolesService, error) {
-	if serviceIfs, ok := api.services[roles.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*roles.ArkIdentityRolesService), nil
+// serviceMutex returns the mutex serializing the creation and invalidation of a service.
+func (api *ArkAPI) serviceMutex(serviceName string) *sync.Mutex {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if api.serviceMutexes == nil {
+		api.serviceMutexes = make(map[string]*sync.Mutex)
 	}
-	service, err := roles.ServiceGenerator(api.loadServiceAuthenticators(roles.ServiceConfig)...)
-	if err != nil {
-		return nil, err
+	serviceMutex, ok := api.serviceMutexes[serviceName]
+	if !ok {
+		serviceMutex = &sync.Mutex{}
+		api.serviceMutexes[serviceName] = serviceMutex
 	}
-	var baseService services.ArkService = service
-	api.services[roles.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return serviceMutex
 }
 
-func (api *ArkAPI) IdentityUsers() (*users.ArkIdentityUsersService, error) {
-	if serviceIfs, ok := api.services[users.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*users.ArkIdentityUsersService), nil
+// cachedService returns the cached instance of a service, if any.
+func (api *ArkAPI) cachedService(serviceName string) (services.ArkService, bool, error) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if api.closed {
+		return nil, false, ErrArkAPIClosed
 	}
-	service, err := users.ServiceGenerator(api.loadServiceAuthenticators(users.ServiceConfig)...)
-	if err != nil {
-		return nil, err
+	service, ok := api.services[serviceName]
+	if !ok {
+		return nil, false, nil
 	}
-	var baseService services.ArkService = service
-	api.services[users.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return *service, true, nil
 }
 
-func (api *ArkAPI) PcloudAccounts() (*accounts.ArkPCloudAccountsService, error) {
-	if serviceIfs, ok := api.services[accounts.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*accounts.ArkPCloudAccountsService), nil
+// loadService returns the cached instance of a service, creating it with its generator on first access.
+//
+// Concurrent first accesses to the same service wait for a single creation. A
+// failed creation is not cached, so the next access tries again.
+func loadService[T services.ArkService](api *ArkAPI, config services.ArkServiceConfig, generator func(...auth.ArkAuth) (T, error)) (T, error) {
+	var empty T
+	if service, ok, err := api.cachedService(config.ServiceName); err != nil || ok {
+		if err != nil {
+			return empty, err
+		}
+		return service.(T), nil
 	}
-	service, err := accounts.ServiceGenerator(api.loadServiceAuthenticators(accounts.ServiceConfig)...)
+	serviceMutex := api.serviceMutex(config.ServiceName)
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	if service, ok, err := api.cachedService(config.ServiceName); err != nil || ok {
+		if err != nil {
+			return empty, err
+		}
+		return service.(T), nil
+	}
+	service, err := generator(api.loadServiceAuthenticators(config)...)
 	if err != nil {
-		return nil, err
+		return empty, err
 	}
 	var baseService services.ArkService = service
-	api.services[accounts.ServiceConfig.ServiceName] = &baseService
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if api.closed {
+		_ = closeService(baseService)
+		return empty, ErrArkAPIClosed
+	}
+	if api.services == nil {
+		api.services = make(map[string]*services.ArkService)
+	}
+	api.services[config.ServiceName] = &baseService
 	return service, nil
 }
 
-func (api *ArkAPI) PcloudSafes() (*safes.ArkPCloudSafesService, error) {
-	if serviceIfs, ok := api.services[safes.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*safes.ArkPCloudSafesService), nil
+// closeService closes a service that holds resources to release.
+func closeService(service services.ArkService) error {
+	if closer, ok := service.(io.Closer); ok {
+		return closer.Close()
 	}
-	service, err := safes.ServiceGenerator(api.loadServiceAuthenticators(safes.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[safes.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return nil
+}
+
+func (api *ArkAPI) Cmgr() (*cmgr.ArkCmgrService, error) {
+	return loadService(api, cmgr.ServiceConfig, cmgr.ServiceGenerator)
+}
+
+func (api *ArkAPI) IdentityDirectories() (*directories.ArkIdentityDirectoriesService, error) {
+	return loadService(api, directories.ServiceConfig, directories.ServiceGenerator)
+}
+
+func (api *ArkAPI) IdentityRoles() (*roles.ArkIdentityRolesService, error) {
+	return loadService(api, roles.ServiceConfig, roles.ServiceGenerator)
+}
+
+func (api *ArkAPI) IdentityUsers() (*users.ArkIdentityUsersService, error) {
+	return loadService(api, users.ServiceConfig, users.ServiceGenerator)
+}
+
+func (api *ArkAPI) PcloudAccounts() (*accounts.ArkPCloudAccountsService, error) {
+	return loadService(api, accounts.ServiceConfig, accounts.ServiceGenerator)
+}
+
+func (api *ArkAPI) PcloudSafes() (*safes.ArkPCloudSafesService, error) {
+	return loadService(api, safes.ServiceConfig, safes.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubConfiguration() (*configuration.ArkSecHubConfigurationService, error) {
-	if serviceIfs, ok := api.services[configuration.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*configuration.ArkSecHubConfigurationService), nil
-	}
-	service, err := configuration.ServiceGenerator(api.loadServiceAuthenticators(configuration.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[configuration.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, configuration.ServiceConfig, configuration.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubFilters() (*filters.ArkSecHubFiltersService, error) {
-	if serviceIfs, ok := api.services[filters.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*filters.ArkSecHubFiltersService), nil
-	}
-	service, err := filters.ServiceGenerator(api.loadServiceAuthenticators(filters.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[filters.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, filters.ServiceConfig, filters.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubScans() (*scans.ArkSecHubScansService, error) {
-	if serviceIfs, ok := api.services[scans.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*scans.ArkSecHubScansService), nil
-	}
-	service, err := scans.ServiceGenerator(api.loadServiceAuthenticators(scans.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[scans.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, scans.ServiceConfig, scans.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubSecrets() (*secrets.ArkSecHubSecretsService, error) {
-	if serviceIfs, ok := api.services[secrets.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*secrets.ArkSecHubSecretsService), nil
-	}
-	service, err := secrets.ServiceGenerator(api.loadServiceAuthenticators(secrets.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[secrets.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, secrets.ServiceConfig, secrets.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubSecretstores() (*secretstores.ArkSecHubSecretStoresService, error) {
-	if serviceIfs, ok := api.services[secretstores.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*secretstores.ArkSecHubSecretStoresService), nil
-	}
-	service, err := secretstores.ServiceGenerator(api.loadServiceAuthenticators(secretstores.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[secretstores.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, secretstores.ServiceConfig, secretstores.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubServiceinfo() (*serviceinfo.ArkSecHubServiceInfoService, error) {
-	if serviceIfs, ok := api.services[serviceinfo.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*serviceinfo.ArkSecHubServiceInfoService), nil
-	}
-	service, err := serviceinfo.ServiceGenerator(api.loadServiceAuthenticators(serviceinfo.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[serviceinfo.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, serviceinfo.ServiceConfig, serviceinfo.ServiceGenerator)
 }
 
 func (api *ArkAPI) SechubSyncpolicies() (*syncpolicies.ArkSecHubSyncPoliciesService, error) {
-	if serviceIfs, ok := api.services[syncpolicies.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*syncpolicies.ArkSecHubSyncPoliciesService), nil
-	}
-	service, err := syncpolicies.ServiceGenerator(api.loadServiceAuthenticators(syncpolicies.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[syncpolicies.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, syncpolicies.ServiceConfig, syncpolicies.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaAccess() (*access.ArkSIAAccessService, error) {
-	if serviceIfs, ok := api.services[access.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*access.ArkSIAAccessService), nil
-	}
-	service, err := access.ServiceGenerator(api.loadServiceAuthenticators(access.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[access.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, access.ServiceConfig, access.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaDb() (*db.ArkSIADBService, error) {
-	if serviceIfs, ok := api.services[db.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*db.ArkSIADBService), nil
-	}
-	service, err := db.ServiceGenerator(api.loadServiceAuthenticators(db.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[db.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, db.ServiceConfig, db.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaK8s() (*k8s.ArkSIAK8SService, error) {
-	if serviceIfs, ok := api.services[k8s.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*k8s.ArkSIAK8SService), nil
-	}
-	service, err := k8s.ServiceGenerator(api.loadServiceAuthenticators(k8s.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[k8s.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, k8s.ServiceConfig, k8s.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaSecretsDb() (*dbsecrets.ArkSIASecretsDBService, error) {
-	if serviceIfs, ok := api.services[dbsecrets.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*dbsecrets.ArkSIASecretsDBService), nil
-	}
-	service, err := dbsecrets.ServiceGenerator(api.loadServiceAuthenticators(dbsecrets.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[dbsecrets.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, dbsecrets.ServiceConfig, dbsecrets.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaSecretsVm() (*vmsecrets.ArkSIASecretsVMService, error) {
-	if serviceIfs, ok := api.services[vmsecrets.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*vmsecrets.ArkSIASecretsVMService), nil
-	}
-	service, err := vmsecrets.ServiceGenerator(api.loadServiceAuthenticators(vmsecrets.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[vmsecrets.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, vmsecrets.ServiceConfig, vmsecrets.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaSshca() (*sshca.ArkSIASSHCAService, error) {
-	if serviceIfs, ok := api.services[sshca.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*sshca.ArkSIASSHCAService), nil
-	}
-	service, err := sshca.ServiceGenerator(api.loadServiceAuthenticators(sshca.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[sshca.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, sshca.ServiceConfig, sshca.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaSso() (*sso.ArkSIASSOService, error) {
-	if serviceIfs, ok := api.services[sso.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*sso.ArkSIASSOService), nil
-	}
-	service, err := sso.ServiceGenerator(api.loadServiceAuthenticators(sso.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[sso.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, sso.ServiceConfig, sso.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaWorkspacesDb() (*db2.ArkSIAWorkspacesDBService, error) {
-	if serviceIfs, ok := api.services[db2.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*db2.ArkSIAWorkspacesDBService), nil
-	}
-	service, err := db2.ServiceGenerator(api.loadServiceAuthenticators(db2.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[db2.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, db2.ServiceConfig, db2.ServiceGenerator)
 }
 
 func (api *ArkAPI) SiaWorkspacesTargetsets() (*targetsets.ArkSIAWorkspacesTargetSetsService, error) {
-	if serviceIfs, ok := api.services[targetsets.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*targetsets.ArkSIAWorkspacesTargetSetsService), nil
-	}
-	service, err := targetsets.ServiceGenerator(api.loadServiceAuthenticators(targetsets.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[targetsets.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, targetsets.ServiceConfig, targetsets.ServiceGenerator)
 }
 
 func (api *ArkAPI) Sm() (*sm.ArkSMService, error) {
-	if serviceIfs, ok := api.services[sm.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*sm.ArkSMService), nil
-	}
-	service, err := sm.ServiceGenerator(api.loadServiceAuthenticators(sm.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[sm.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, sm.ServiceConfig, sm.ServiceGenerator)
 }
 
 func (api *ArkAPI) Uap() (*uap.ArkUAPService, error) {
-	if serviceIfs, ok := api.services[uap.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*uap.ArkUAPService), nil
-	}
-	service, err := uap.ServiceGenerator(api.loadServiceAuthenticators(uap.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[uap.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, uap.ServiceConfig, uap.ServiceGenerator)
 }
 
 func (api *ArkAPI) UapDb() (*db3.ArkUAPSIADBService, error) {
-	if serviceIfs, ok := api.services[db3.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*db3.ArkUAPSIADBService), nil
-	}
-	service, err := db3.ServiceGenerator(api.loadServiceAuthenticators(db3.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[db3.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, db3.ServiceConfig, db3.ServiceGenerator)
 }
 
 func (api *ArkAPI) UapSca() (*sca.ArkUAPSCAService, error) {
-	if serviceIfs, ok := api.services[sca.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*sca.ArkUAPSCAService), nil
-	}
-	service, err := sca.ServiceGenerator(api.loadServiceAuthenticators(sca.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[sca.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, sca.ServiceConfig, sca.ServiceGenerator)
 }
 
 func (api *ArkAPI) UapVm() (*vm.ArkUAPSIAVMService, error) {
-	if serviceIfs, ok := api.services[vm.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*vm.ArkUAPSIAVMService), nil
-	}
-	service, err := vm.ServiceGenerator(api.loadServiceAuthenticators(vm.ServiceConfig)...)
-	if err != nil {
-		return nil, err
-	}
-	var baseService services.ArkService = service
-	api.services[vm.ServiceConfig.ServiceName] = &baseService
-	return service, nil
+	return loadService(api, vm.ServiceConfig, vm.ServiceGenerator)
 }
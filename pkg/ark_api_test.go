@@ -1,9 +1,16 @@
 package api
 
 import (
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 )
 
@@ -391,3 +398,175 @@ func TestArkAPI_ServiceCaching_Pattern(t *testing.T) {
 		})
 	}
 }
+
+type testClosableService struct {
+	closed atomic.Int32
+}
+
+func (s *testClosableService) ServiceConfig() services.ArkServiceConfig {
+	return services.ArkServiceConfig{ServiceName: "test-service"}
+}
+
+func (s *testClosableService) Close() error {
+	s.closed.Add(1)
+	return nil
+}
+
+type testClosableAuth struct {
+	auth.ArkAuth
+	closed atomic.Int32
+}
+
+func (a *testClosableAuth) Close() error {
+	a.closed.Add(1)
+	return nil
+}
+
+func TestArkAPI_loadService_Concurrent(t *testing.T) {
+	api := &ArkAPI{services: make(map[string]*services.ArkService)}
+	config := services.ArkServiceConfig{ServiceName: "test-service"}
+	var created atomic.Int32
+	generator := func(authenticators ...auth.ArkAuth) (*testClosableService, error) {
+		created.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &testClosableService{}, nil
+	}
+
+	results := make([]*testClosableService, 20)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			service, err := loadService(api, config, generator)
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			results[i] = service
+		}(i)
+	}
+	wg.Wait()
+
+	if created.Load() != 1 {
+		t.Errorf("Expected the service to be created once, got %d", created.Load())
+	}
+	for _, result := range results {
+		if result != results[0] {
+			t.Error("Expected all callers to get the same service instance")
+		}
+	}
+}
+
+func TestArkAPI_loadService_ErrorNotCached(t *testing.T) {
+	api := &ArkAPI{services: make(map[string]*services.ArkService)}
+	config := services.ArkServiceConfig{ServiceName: "test-service"}
+	var calls int
+	generator := func(authenticators ...auth.ArkAuth) (*testClosableService, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("failed to create service")
+		}
+		return &testClosableService{}, nil
+	}
+
+	if _, err := loadService(api, config, generator); err == nil {
+		t.Fatal("Expected the creation error")
+	}
+	if _, exists := api.services["test-service"]; exists {
+		t.Error("Expected the failed service not to be cached")
+	}
+	if service, err := loadService(api, config, generator); err != nil || service == nil {
+		t.Errorf("Expected the service to be created on retry, got %v", err)
+	}
+}
+
+func TestArkAPI_InvalidateService(t *testing.T) {
+	api := &ArkAPI{services: make(map[string]*services.ArkService)}
+	config := services.ArkServiceConfig{ServiceName: "test-service"}
+	generator := func(authenticators ...auth.ArkAuth) (*testClosableService, error) {
+		return &testClosableService{}, nil
+	}
+
+	first, err := loadService(api, config, generator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := api.InvalidateService("test-service"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.closed.Load() != 1 {
+		t.Error("Expected the invalidated service to be closed")
+	}
+	second, err := loadService(api, config, generator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if second == first {
+		t.Error("Expected a new service instance after invalidation")
+	}
+	if err := api.InvalidateService("missing-service"); err != nil {
+		t.Errorf("Expected invalidating a missing service to do nothing, got %v", err)
+	}
+}
+
+func TestArkAPI_Close(t *testing.T) {
+	authenticator := &testClosableAuth{}
+	api := &ArkAPI{
+		authenticators: []auth.ArkAuth{authenticator},
+		services:       make(map[string]*services.ArkService),
+	}
+	config := services.ArkServiceConfig{ServiceName: "test-service"}
+	generator := func(authenticators ...auth.ArkAuth) (*testClosableService, error) {
+		return &testClosableService{}, nil
+	}
+	service, err := loadService(api, config, generator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := api.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := api.Close(); err != nil {
+		t.Fatalf("Expected closing twice to do nothing, got %v", err)
+	}
+	if service.closed.Load() != 1 {
+		t.Errorf("Expected the service to be closed once, got %d", service.closed.Load())
+	}
+	if authenticator.closed.Load() != 0 {
+		t.Errorf("Expected the shared authenticator to be left open, got %d closes", authenticator.closed.Load())
+	}
+	if _, err := loadService(api, config, generator); !errors.Is(err, ErrArkAPIClosed) {
+		t.Errorf("Expected ErrArkAPIClosed, got %v", err)
+	}
+}
+
+func TestArkAPI_Close_SharedAuthenticator(t *testing.T) {
+	authenticator := testutils.NewMockAuthenticator("mock", authmodels.Other)
+	profile := testutils.CreateTestProfile("shared")
+	profile.AuthProfiles = map[string]*authmodels.ArkAuthProfile{"mock": {Username: "user", AuthMethod: authmodels.Other}}
+	if _, err := authenticator.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: "secret"}, false, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = authenticator.Close() })
+	token := authenticator.Token
+
+	first, err := NewArkAPI([]auth.ArkAuth{authenticator}, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, err := NewArkAPI([]auth.ArkAuth{authenticator}, profile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if authenticator.Token != token || token.Token == "" {
+		t.Errorf("Expected the token of the shared authenticator to be kept, got %+v", authenticator.Token)
+	}
+	if _, err := second.Authenticator("mock"); err != nil {
+		t.Errorf("Expected the other API to keep using the authenticator, got %v", err)
+	}
+}
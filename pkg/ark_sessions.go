@@ -0,0 +1,416 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+var (
+	// ErrArkSessionNotFound is returned when accessing a session that was not added to an ArkSessionManager.
+	ErrArkSessionNotFound = errors.New("session not found")
+
+	// ErrArkSessionManagerClosed is returned when using an ArkSessionManager that was closed.
+	ErrArkSessionManagerClosed = errors.New("session manager is closed")
+)
+
+// ArkSessionManagerConfig configures an ArkSessionManager.
+//
+// Fields:
+//   - MaxConcurrentOperations: Maximum number of operations running at the same time, across all sessions (0 for no cap)
+//   - MaxConcurrentOperationsPerSession: Maximum number of operations running at the same time in each session,
+//     unless the session sets its own (0 for no cap)
+type ArkSessionManagerConfig struct {
+	MaxConcurrentOperations           int `json:"max_concurrent_operations" mapstructure:"max_concurrent_operations"`
+	MaxConcurrentOperationsPerSession int `json:"max_concurrent_operations_per_session" mapstructure:"max_concurrent_operations_per_session"`
+}
+
+// ArkSessionConfig configures a session of an ArkSessionManager.
+//
+// Fields:
+//   - Profile: The profile of the tenant, with an auth profile for each authenticator of the session
+//   - Secrets: Secrets to authenticate with, by authenticator name
+//   - CacheAuthentication: Whether to cache the tokens of the session in the keyring, under the name of its profile
+//   - MaxConcurrentOperations: Maximum number of operations running at the same time in the session
+//     (0 for the default of the manager)
+//   - ClientOptions: Options of the clients of the services of the session, such as a rate limiter of the tenant
+type ArkSessionConfig struct {
+	Profile                 *models.ArkProfile
+	Secrets                 map[string]*authmodels.ArkSecret
+	CacheAuthentication     bool
+	MaxConcurrentOperations int
+	ClientOptions           []common.ArkClientOption
+}
+
+// ArkSession is an authenticated session of a single tenant, managed by an ArkSessionManager.
+//
+// Each session has authenticators of its own, so its tokens are never shared with other
+// sessions or with the authenticators registered for the process, and an ArkAPI built
+// for its profile.
+type ArkSession struct {
+	key            string
+	profile        *models.ArkProfile
+	api            *ArkAPI
+	authenticators []auth.ArkAuth
+	semaphore      chan struct{}
+}
+
+// Key returns the key the session was added with.
+func (s *ArkSession) Key() string {
+	return s.key
+}
+
+// Profile returns the profile of the session.
+func (s *ArkSession) Profile() *models.ArkProfile {
+	return s.profile
+}
+
+// API returns the ArkAPI of the session, which gives access to the services of its tenant.
+func (s *ArkSession) API() *ArkAPI {
+	return s.api
+}
+
+// ArkSessionManager keeps isolated authenticated sessions of several tenants in a single process.
+//
+// Sessions are keyed by a name of the caller's choosing, such as the tenant or profile name.
+// Each session authenticates with authenticators of its own, created with auth.NewAuthenticator,
+// and is given the explicit profile it was added with. The default profile is never loaded.
+//
+// Operations run on sessions with Run and RunAll, which cap the number of operations
+// running at the same time, both overall and per session.
+//
+// ArkSessionManager is safe for concurrent use.
+//
+// Example:
+//
+//	manager := api.NewArkSessionManager(&api.ArkSessionManagerConfig{MaxConcurrentOperations: 10})
+//	defer manager.Close()
+//	for _, tenant := range tenants {
+//		_, err := manager.AddSession(tenant.Name, &api.ArkSessionConfig{
+//			Profile: tenant.Profile,
+//			Secrets: map[string]*authmodels.ArkSecret{"isp": {Secret: tenant.Secret}},
+//		})
+//		if err != nil {
+//			// handle error
+//		}
+//	}
+//	errs := manager.RunAll(ctx, func(ctx context.Context, session *api.ArkSession) error {
+//		safesService, err := session.API().PcloudSafes()
+//		if err != nil {
+//			return err
+//		}
+//		// use safesService
+//		return nil
+//	})
+type ArkSessionManager struct {
+	config    ArkSessionManagerConfig
+	mutex     sync.RWMutex
+	sessions  map[string]*ArkSession
+	adding    map[string]string
+	semaphore chan struct{}
+	closed    bool
+}
+
+// NewArkSessionManager creates a new ArkSessionManager without sessions.
+//
+// Parameters:
+//   - config: The limits of the manager (nil for no limits)
+func NewArkSessionManager(config *ArkSessionManagerConfig) *ArkSessionManager {
+	manager := &ArkSessionManager{
+		sessions: make(map[string]*ArkSession),
+		adding:   make(map[string]string),
+	}
+	if config != nil {
+		manager.config = *config
+	}
+	manager.semaphore = newSemaphore(manager.config.MaxConcurrentOperations)
+	return manager
+}
+
+// AddSession authenticates to a tenant and adds its session to the manager.
+//
+// An authenticator is created for each auth profile of the profile, and authenticated
+// with the secret of its name. Tokens cached in the keyring are used when the session
+// caches authentication. Since cached tokens are keyed by profile name, the profile
+// name of each session must be unique.
+//
+// Parameters:
+//   - key: The key of the session, such as the tenant name
+//   - config: The profile and credentials of the session
+//
+// Returns the session, or an error if the key or profile name is already used, or if any
+// authenticator fails to authenticate, in which case no session is added.
+func (m *ArkSessionManager) AddSession(key string, config *ArkSessionConfig) (*ArkSession, error) {
+	if key == "" {
+		return nil, errors.New("session key must not be empty")
+	}
+	if config == nil || config.Profile == nil {
+		return nil, fmt.Errorf("session %s requires a profile", key)
+	}
+	if len(config.Profile.AuthProfiles) == 0 {
+		return nil, fmt.Errorf("profile %s of session %s has no auth profiles", config.Profile.ProfileName, key)
+	}
+	if err := m.reserve(key, config.Profile.ProfileName); err != nil {
+		return nil, err
+	}
+	session, err := m.newSession(key, config)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.adding, key)
+	if err != nil {
+		return nil, err
+	}
+	if m.closed {
+		_ = session.close()
+		return nil, ErrArkSessionManagerClosed
+	}
+	m.sessions[key] = session
+	return session, nil
+}
+
+// Session returns the session of a key.
+func (m *ArkSessionManager) Session(key string) (*ArkSession, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.closed {
+		return nil, ErrArkSessionManagerClosed
+	}
+	session, ok := m.sessions[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrArkSessionNotFound, key)
+	}
+	return session, nil
+}
+
+// Sessions returns the keys of the sessions of the manager, sorted.
+func (m *ArkSessionManager) Sessions() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	keys := make([]string, 0, len(m.sessions))
+	for key := range m.sessions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RemoveSession removes a session from the manager, and closes its API and authenticators.
+//
+// Operations already running on the session are not interrupted, but may fail once its services are closed.
+func (m *ArkSessionManager) RemoveSession(key string) error {
+	m.mutex.Lock()
+	session, ok := m.sessions[key]
+	delete(m.sessions, key)
+	m.mutex.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrArkSessionNotFound, key)
+	}
+	return session.close()
+}
+
+// Run runs an operation on the session of a key, once the limits of the manager and the session allow it.
+//
+// Returns the error of the operation, or an error if the session is not found or the
+// context is done before the operation could start.
+//
+// Example:
+//
+//	err := manager.Run(ctx, "tenant-a", func(ctx context.Context, session *api.ArkSession) error {
+//		usersService, err := session.API().IdentityUsers()
+//		if err != nil {
+//			return err
+//		}
+//		// use usersService
+//		return nil
+//	})
+func (m *ArkSessionManager) Run(ctx context.Context, key string, operation func(ctx context.Context, session *ArkSession) error) error {
+	session, err := m.Session(key)
+	if err != nil {
+		return err
+	}
+	release, err := m.acquire(ctx, session)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return operation(ctx, session)
+}
+
+// RunAll runs an operation on every session of the manager concurrently, within the limits of the manager and the sessions.
+//
+// Returns the error of the operation by session key, with a nil error for the sessions the operation succeeded on.
+func (m *ArkSessionManager) RunAll(ctx context.Context, operation func(ctx context.Context, session *ArkSession) error) map[string]error {
+	keys := m.Sessions()
+	results := make(map[string]error, len(keys))
+	var resultsMutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			err := m.Run(ctx, key, operation)
+			resultsMutex.Lock()
+			results[key] = err
+			resultsMutex.Unlock()
+		}(key)
+	}
+	wg.Wait()
+	return results
+}
+
+// Close removes all the sessions of the manager, and closes their APIs and authenticators.
+// Calling Close more than once does nothing.
+//
+// Returns the errors of the sessions that failed to close, joined.
+func (m *ArkSessionManager) Close() error {
+	m.mutex.Lock()
+	if m.closed {
+		m.mutex.Unlock()
+		return nil
+	}
+	m.closed = true
+	sessions := m.sessions
+	m.sessions = make(map[string]*ArkSession)
+	m.mutex.Unlock()
+
+	var errs []error
+	for _, session := range sessions {
+		errs = append(errs, session.close())
+	}
+	return errors.Join(errs...)
+}
+
+// reserve checks that a session key and profile name are free, and holds them while the session authenticates.
+func (m *ArkSessionManager) reserve(key string, profileName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.closed {
+		return ErrArkSessionManagerClosed
+	}
+	if _, ok := m.sessions[key]; ok {
+		return fmt.Errorf("session %s already exists", key)
+	}
+	if _, ok := m.adding[key]; ok {
+		return fmt.Errorf("session %s already exists", key)
+	}
+	for otherKey, session := range m.sessions {
+		if session.profile.ProfileName == profileName {
+			return fmt.Errorf("profile %s is already used by session %s", profileName, otherKey)
+		}
+	}
+	for otherKey, otherProfileName := range m.adding {
+		if otherProfileName == profileName {
+			return fmt.Errorf("profile %s is already used by session %s", profileName, otherKey)
+		}
+	}
+	m.adding[key] = profileName
+	return nil
+}
+
+// newSession creates and authenticates the authenticators of a session, and builds its API.
+func (m *ArkSessionManager) newSession(key string, config *ArkSessionConfig) (*ArkSession, error) {
+	names := make([]string, 0, len(config.Profile.AuthProfiles))
+	for name := range config.Profile.AuthProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	authenticators := make([]auth.ArkAuth, 0, len(names))
+	closeAuthenticators := func() {
+		for _, authenticator := range authenticators {
+			if closer, ok := authenticator.(io.Closer); ok {
+				_ = closer.Close()
+			}
+		}
+	}
+	for _, name := range names {
+		authenticator, err := auth.NewAuthenticator(name, config.CacheAuthentication)
+		if err != nil {
+			closeAuthenticators()
+			return nil, fmt.Errorf("session %s: %w", key, err)
+		}
+		authenticators = append(authenticators, authenticator)
+		if setter, ok := authenticator.(interface {
+			SetClientOptions(options ...common.ArkClientOption)
+		}); ok && len(config.ClientOptions) > 0 {
+			setter.SetClientOptions(config.ClientOptions...)
+		}
+		if _, err = authenticator.Authenticate(config.Profile, nil, config.Secrets[name], false, true); err != nil {
+			closeAuthenticators()
+			return nil, fmt.Errorf("session %s failed to authenticate with %s: %w", key, name, err)
+		}
+	}
+	sessionAPI, err := NewArkAPI(authenticators, config.Profile)
+	if err != nil {
+		closeAuthenticators()
+		return nil, err
+	}
+	maxConcurrentOperations := config.MaxConcurrentOperations
+	if maxConcurrentOperations == 0 {
+		maxConcurrentOperations = m.config.MaxConcurrentOperationsPerSession
+	}
+	return &ArkSession{
+		key:            key,
+		profile:        config.Profile,
+		api:            sessionAPI,
+		authenticators: authenticators,
+		semaphore:      newSemaphore(maxConcurrentOperations),
+	}, nil
+}
+
+// close closes the API of the session, then the authenticators the session created for it.
+func (s *ArkSession) close() error {
+	errs := []error{s.api.Close()}
+	for _, authenticator := range s.authenticators {
+		if closer, ok := authenticator.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// acquire waits for a slot of the manager and of the session, in that order, and returns the function releasing them.
+func (m *ArkSessionManager) acquire(ctx context.Context, session *ArkSession) (func(), error) {
+	releaseManager, err := acquireSemaphore(ctx, m.semaphore)
+	if err != nil {
+		return nil, err
+	}
+	releaseSession, err := acquireSemaphore(ctx, session.semaphore)
+	if err != nil {
+		releaseManager()
+		return nil, err
+	}
+	return func() {
+		releaseSession()
+		releaseManager()
+	}, nil
+}
+
+func newSemaphore(size int) chan struct{} {
+	if size <= 0 {
+		return nil
+	}
+	return make(chan struct{}, size)
+}
+
+func acquireSemaphore(ctx context.Context, semaphore chan struct{}) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if semaphore == nil {
+		return func() {}, nil
+	}
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
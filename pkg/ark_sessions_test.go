@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+const sessionTestAuthenticatorName = "session-mock"
+
+var registerSessionTestAuthenticator sync.Once
+
+// newSessionTestManager registers a factory of mock authenticators issuing tokens for the username of the auth profile,
+// and failing to authenticate users named "invalid".
+func newSessionTestManager(t *testing.T, config *ArkSessionManagerConfig) *ArkSessionManager {
+	registerSessionTestAuthenticator.Do(func() {
+		err := auth.RegisterAuthenticatorFactory(sessionTestAuthenticatorName, func(cacheAuthentication bool) auth.ArkAuth {
+			authenticator := testutils.NewMockAuthenticator(sessionTestAuthenticatorName, authmodels.Other)
+			authenticator.AuthenticateFunc = func(authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret) (*authmodels.ArkToken, error) {
+				if authProfile.Username == "invalid" {
+					return nil, errors.New("invalid credentials")
+				}
+				return &authmodels.ArkToken{
+					Token:      "token-" + authProfile.Username,
+					Username:   authProfile.Username,
+					TokenType:  authmodels.Token,
+					AuthMethod: authProfile.AuthMethod,
+					ExpiresIn:  commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour)),
+				}, nil
+			}
+			return authenticator
+		})
+		if err != nil {
+			t.Fatalf("failed to register factory: %v", err)
+		}
+	})
+	manager := NewArkSessionManager(config)
+	t.Cleanup(func() { _ = manager.Close() })
+	return manager
+}
+
+func sessionTestConfig(profileName string, username string) *ArkSessionConfig {
+	return &ArkSessionConfig{
+		Profile: &models.ArkProfile{
+			ProfileName: profileName,
+			AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+				sessionTestAuthenticatorName: {Username: username, AuthMethod: authmodels.Other},
+			},
+		},
+	}
+}
+
+func TestArkSessionManager_AddSession(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(t *testing.T, manager *ArkSessionManager)
+		key           string
+		config        *ArkSessionConfig
+		expectedError string
+	}{
+		{
+			name:   "success_isolated_session",
+			key:    "tenant-b",
+			config: sessionTestConfig("profile-b", "user-b"),
+			setup: func(t *testing.T, manager *ArkSessionManager) {
+				if _, err := manager.AddSession("tenant-a", sessionTestConfig("profile-a", "user-a")); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			},
+		},
+		{
+			name:          "error_empty_key",
+			config:        sessionTestConfig("profile-a", "user-a"),
+			expectedError: "must not be empty",
+		},
+		{
+			name:          "error_no_profile",
+			key:           "tenant-a",
+			config:        &ArkSessionConfig{},
+			expectedError: "requires a profile",
+		},
+		{
+			name:          "error_duplicate_key",
+			key:           "tenant-a",
+			config:        sessionTestConfig("profile-b", "user-b"),
+			expectedError: "already exists",
+			setup: func(t *testing.T, manager *ArkSessionManager) {
+				if _, err := manager.AddSession("tenant-a", sessionTestConfig("profile-a", "user-a")); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			},
+		},
+		{
+			name:          "error_duplicate_profile",
+			key:           "tenant-b",
+			config:        sessionTestConfig("profile-a", "user-b"),
+			expectedError: "already used by session tenant-a",
+			setup: func(t *testing.T, manager *ArkSessionManager) {
+				if _, err := manager.AddSession("tenant-a", sessionTestConfig("profile-a", "user-a")); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			},
+		},
+		{
+			name:          "error_authentication_failed",
+			key:           "tenant-a",
+			config:        sessionTestConfig("profile-a", "invalid"),
+			expectedError: "invalid credentials",
+		},
+		{
+			name: "error_unknown_authenticator",
+			key:  "tenant-a",
+			config: &ArkSessionConfig{
+				Profile: &models.ArkProfile{
+					ProfileName:  "profile-a",
+					AuthProfiles: map[string]*authmodels.ArkAuthProfile{"unknown": {AuthMethod: authmodels.Other}},
+				},
+			},
+			expectedError: "no factory registered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newSessionTestManager(t, nil)
+			if tt.setup != nil {
+				tt.setup(t, manager)
+			}
+			sessionsBefore := len(manager.Sessions())
+			session, err := manager.AddSession(tt.key, tt.config)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				if len(manager.Sessions()) != sessionsBefore {
+					t.Error("Expected no session to be added")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if session.Key() != tt.key || session.Profile() != tt.config.Profile || session.API().Profile() != tt.config.Profile {
+				t.Error("Expected the session to be built for its key and profile")
+			}
+			authenticator, err := session.API().Authenticator(sessionTestAuthenticatorName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			token, _ := authenticator.LoadAuthentication(nil, false)
+			if token == nil || token.Token != "token-user-b" {
+				t.Errorf("Expected the session to hold its own token, got %+v", token)
+			}
+			other, _ := manager.Session("tenant-a")
+			otherAuthenticator, _ := other.API().Authenticator(sessionTestAuthenticatorName)
+			if otherAuthenticator == authenticator {
+				t.Error("Expected sessions to have authenticators of their own")
+			}
+		})
+	}
+}
+
+func TestArkSessionManager_Run_Limits(t *testing.T) {
+	manager := newSessionTestManager(t, &ArkSessionManagerConfig{MaxConcurrentOperations: 3, MaxConcurrentOperationsPerSession: 1})
+	for _, key := range []string{"a", "b", "c", "d"} {
+		if _, err := manager.AddSession(key, sessionTestConfig("profile-"+key, "user-"+key)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	var running, maxRunning atomic.Int32
+	perSession := make(map[string]*atomic.Int32)
+	for _, key := range manager.Sessions() {
+		perSession[key] = &atomic.Int32{}
+	}
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results := manager.RunAll(context.Background(), func(ctx context.Context, session *ArkSession) error {
+				if perSession[session.Key()].Add(1) > 1 {
+					failures.Add(1)
+				}
+				current := running.Add(1)
+				for {
+					observed := maxRunning.Load()
+					if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				perSession[session.Key()].Add(-1)
+				return nil
+			})
+			for key, err := range results {
+				if err != nil {
+					t.Errorf("Unexpected error for %s: %v", key, err)
+				}
+			}
+			if len(results) != 4 {
+				t.Errorf("Expected results for 4 sessions, got %d", len(results))
+			}
+		}()
+	}
+	wg.Wait()
+	if failures.Load() != 0 {
+		t.Error("Expected at most one operation per session at a time")
+	}
+	if maxRunning.Load() > 3 {
+		t.Errorf("Expected at most 3 operations at a time, got %d", maxRunning.Load())
+	}
+}
+
+func TestArkSessionManager_Run_Errors(t *testing.T) {
+	manager := newSessionTestManager(t, &ArkSessionManagerConfig{MaxConcurrentOperationsPerSession: 1})
+	if _, err := manager.AddSession("a", sessionTestConfig("profile-a", "user-a")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := manager.Run(context.Background(), "missing", func(ctx context.Context, session *ArkSession) error { return nil }); !errors.Is(err, ErrArkSessionNotFound) {
+		t.Errorf("Expected ErrArkSessionNotFound, got %v", err)
+	}
+	operationErr := errors.New("operation failed")
+	if err := manager.Run(context.Background(), "a", func(ctx context.Context, session *ArkSession) error { return operationErr }); !errors.Is(err, operationErr) {
+		t.Errorf("Expected the operation error, got %v", err)
+	}
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_ = manager.Run(context.Background(), "a", func(ctx context.Context, session *ArkSession) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := manager.Run(ctx, "a", func(ctx context.Context, session *ArkSession) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the operation to wait for the session until the deadline, got %v", err)
+	}
+	close(done)
+}
+
+func TestArkSessionManager_RemoveSession_Close(t *testing.T) {
+	manager := newSessionTestManager(t, nil)
+	session, err := manager.AddSession("a", sessionTestConfig("profile-a", "user-a"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := manager.AddSession("b", sessionTestConfig("profile-b", "user-b")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := manager.RemoveSession("a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := session.API().SiaSso(); !errors.Is(err, ErrArkAPIClosed) {
+		t.Errorf("Expected the API of the removed session to be closed, got %v", err)
+	}
+	if err := manager.RemoveSession("a"); !errors.Is(err, ErrArkSessionNotFound) {
+		t.Errorf("Expected ErrArkSessionNotFound, got %v", err)
+	}
+	if _, err := manager.AddSession("a", sessionTestConfig("profile-a", "user-a")); err != nil {
+		t.Errorf("Expected the key and profile of a removed session to be reusable, got %v", err)
+	}
+
+	if err := manager.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := manager.Session("b"); !errors.Is(err, ErrArkSessionManagerClosed) {
+		t.Errorf("Expected ErrArkSessionManagerClosed, got %v", err)
+	}
+	if _, err := manager.AddSession("c", sessionTestConfig("profile-c", "user-c")); !errors.Is(err, ErrArkSessionManagerClosed) {
+		t.Errorf("Expected ErrArkSessionManagerClosed, got %v", err)
+	}
+	if err := manager.Close(); err != nil {
+		t.Errorf("Expected closing twice to do nothing, got %v", err)
+	}
+}
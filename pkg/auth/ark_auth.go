@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/common/keyring"
@@ -40,44 +42,105 @@ type ArkAuth interface {
 	// If refreshAuth is true, it will attempt to refresh the token if it is expired
 	// It returns the authentication token and an error if any occurred.
 	Authenticate(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret, force bool, refreshAuth bool) (*auth.ArkToken, error)
-
-	performAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret, force bool) (*auth.ArkToken, error)
-	performRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error)
+	// PerformAuthentication authenticates against the platform with the auth method of the auth profile, and returns a new token.
+	// It is called by ArkAuthBase when no valid token is cached, and should not be called directly.
+	PerformAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret, force bool) (*auth.ArkToken, error)
+	// PerformRefreshAuthentication refreshes the given token, and returns the refreshed token.
+	// It is called by ArkAuthBase when a token is expired or about to expire, and should not be called directly.
+	// Authenticators that cannot refresh tokens return the given token as is.
+	PerformRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error)
 }
 
 // ArkAuthBase is a struct that implements the ArkAuth interface and provides common functionality for authentication.
+//
+// Authenticators embed ArkAuthBase and implement PerformAuthentication and
+// PerformRefreshAuthentication, while ArkAuthBase takes care of resolving the auth
+// profile, caching tokens in the keyring and refreshing them. See RegisterAuthenticator
+// for a complete authenticator.
+//
+// Tokens are cached in the TokenCache of the authenticator, which is the OS keyring or the
+// basic keyring by default, see SetTokenCache for other caches.
+//
+// Tokens can also be renewed in the background before they expire, see StartBackgroundRefresh.
 type ArkAuthBase struct {
 	Authenticator       ArkAuth
 	Logger              *common.ArkLogger
 	CacheAuthentication bool
-	CacheKeyring        *keyring.ArkKeyring
-	Token               *auth.ArkToken
-	ActiveProfile       *models.ArkProfile
-	ActiveAuthProfile   *auth.ArkAuthProfile
+	// Deprecated: Use TokenCache. CacheKeyring is only used when TokenCache is nil.
+	CacheKeyring      *keyring.ArkKeyring
+	TokenCache        ArkTokenCache
+	Token             *auth.ArkToken
+	ActiveProfile     *models.ArkProfile
+	ActiveAuthProfile *auth.ArkAuthProfile
+	refreshMutex      sync.Mutex
+	stateMutex        sync.Mutex
+	refresher         *backgroundRefresher
+	clients           []*common.ArkClient
+	listeners         map[int]func(event *ArkTokenRefreshEvent)
+	listenersCount    int
+	cacheNamespace    string
 }
 
 // NewArkAuthBase creates a new instance of ArkAuthBase.
 func NewArkAuthBase(cacheAuthentication bool, name string, authenticator ArkAuth) *ArkAuthBase {
 	logger := common.GetLogger(name, common.Unknown)
 	var cacheKeyring *keyring.ArkKeyring
+	var tokenCache ArkTokenCache
 	if cacheAuthentication {
 		cacheKeyring = keyring.NewArkKeyring(name)
+		tokenCache = NewArkKeyringTokenCache()
 	}
 	return &ArkAuthBase{
 		Authenticator:       authenticator,
 		Logger:              logger,
 		CacheAuthentication: cacheAuthentication,
 		CacheKeyring:        cacheKeyring,
+		TokenCache:          tokenCache,
+		cacheNamespace:      name,
+	}
+}
+
+// SetTokenCache sets the cache the tokens of the authenticator are stored in, replacing the keyring.
+//
+// Setting a cache enables caching, and setting nil disables it. It should be called before authenticating.
+//
+// Example:
+//
+//	ispAuth := auth.NewArkISPAuth(false).(*auth.ArkISPAuth)
+//	ispAuth.SetTokenCache(auth.NewArkMemoryTokenCache())
+func (a *ArkAuthBase) SetTokenCache(tokenCache ArkTokenCache) {
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
+	a.TokenCache = tokenCache
+	a.CacheKeyring = nil
+	a.CacheAuthentication = tokenCache != nil
+}
+
+// CacheNamespace returns the namespace the tokens of the authenticator are cached with, which is the name it was created with.
+func (a *ArkAuthBase) CacheNamespace() string {
+	if a.cacheNamespace == "" && a.Authenticator != nil {
+		return a.Authenticator.AuthenticatorName()
+	}
+	return a.cacheNamespace
+}
+
+// tokenCache returns the cache of the tokens of the authenticator, or nil if it has none.
+func (a *ArkAuthBase) tokenCache() ArkTokenCache {
+	if a.TokenCache != nil {
+		return a.TokenCache
 	}
+	if a.CacheKeyring != nil {
+		return &arkKeyringTokenCache{keyring: a.CacheKeyring}
+	}
+	return nil
 }
 
 // ResolveCachePostfix resolves the cache postfix for the authentication profile.
 func (a *ArkAuthBase) ResolveCachePostfix(authProfile *auth.ArkAuthProfile) string {
 	postfix := authProfile.Username
-	if authProfile.AuthMethod == auth.Direct && authProfile.AuthMethodSettings != nil {
-		directMethodSettings := authProfile.AuthMethodSettings.(auth.DirectArkAuthMethodSettings)
-		if directMethodSettings.Endpoint != "" {
-			parsedURL, _ := url.Parse(directMethodSettings.Endpoint)
+	if authProfile.AuthMethod == auth.Direct {
+		if methodSettings, err := directMethodSettings(authProfile); err == nil && methodSettings.Endpoint != "" {
+			parsedURL, _ := url.Parse(methodSettings.Endpoint)
 			postfix = postfix + "_" + parsedURL.Host
 		}
 	}
@@ -106,20 +169,23 @@ func (a *ArkAuthBase) Authenticate(profile *models.ArkProfile, authProfile *auth
 	if authProfile.AuthMethod == auth.Default {
 		authProfile.AuthMethod, authProfile.AuthMethodSettings = a.Authenticator.DefaultAuthMethod()
 	}
-	if slices.Contains(auth.ArkAuthMethodsRequireCredentials, authProfile.AuthMethod) && authProfile.Username == "" {
+	if authProfile.RequiresUsername() && authProfile.Username == "" {
 		return nil, errors.New(a.Authenticator.AuthenticatorHumanReadableName() + " requires a username and optionally a secret")
 	}
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
 	var token *auth.ArkToken
 	var err error
 	tokenRefreshed := false
-	if a.CacheAuthentication && a.CacheKeyring != nil && !force {
-		token, err = a.CacheKeyring.LoadToken(profile, a.ResolveCachePostfix(authProfile), false)
+	tokenCache := a.tokenCache()
+	if a.CacheAuthentication && tokenCache != nil && !force {
+		token, err = tokenCache.LoadToken(profile, a.CacheNamespace(), a.ResolveCachePostfix(authProfile))
 		if err != nil {
 			return nil, err
 		}
 		if token != nil && time.Time(token.ExpiresIn).Before(time.Now()) {
 			if refreshAuth && token.RefreshToken != "" {
-				token, _ = a.Authenticator.performRefreshAuthentication(profile, authProfile, token)
+				token, _ = a.Authenticator.PerformRefreshAuthentication(profile, authProfile, token)
 				if token != nil {
 					tokenRefreshed = true
 				} else {
@@ -131,23 +197,27 @@ func (a *ArkAuthBase) Authenticate(profile *models.ArkProfile, authProfile *auth
 		}
 	}
 	if token == nil {
-		token, err = a.Authenticator.performAuthentication(profile, authProfile, secret, force)
+		secret, err = resolveSecret(authProfile, secret)
 		if err != nil {
 			return nil, err
 		}
-		if token != nil && a.CacheAuthentication && a.CacheKeyring != nil {
-			err := a.CacheKeyring.SaveToken(profile, token, a.ResolveCachePostfix(authProfile), false)
+		token, err = a.Authenticator.PerformAuthentication(profile, authProfile, secret, force)
+		if err != nil {
+			return nil, err
+		}
+		if token != nil && a.CacheAuthentication && tokenCache != nil {
+			err := tokenCache.SaveToken(profile, token, a.CacheNamespace(), a.ResolveCachePostfix(authProfile))
 			if err != nil {
 				return nil, err
 			}
 		}
 	} else if refreshAuth && !tokenRefreshed {
-		token, err = a.Authenticator.performRefreshAuthentication(profile, authProfile, token)
+		token, err = a.Authenticator.PerformRefreshAuthentication(profile, authProfile, token)
 		if err != nil {
 			return nil, err
 		}
-		if token != nil && a.CacheAuthentication && a.CacheKeyring != nil {
-			err := a.CacheKeyring.SaveToken(profile, token, a.ResolveCachePostfix(authProfile), false)
+		if token != nil && a.CacheAuthentication && tokenCache != nil {
+			err := tokenCache.SaveToken(profile, token, a.CacheNamespace(), a.ResolveCachePostfix(authProfile))
 			if err != nil {
 				return nil, err
 			}
@@ -159,16 +229,43 @@ func (a *ArkAuthBase) Authenticate(profile *models.ArkProfile, authProfile *auth
 	return token, nil
 }
 
+// resolveSecret returns the secret to authenticate with, which is the given secret as is, or the
+// secret reference of the auth profile when no secret is given, resolved with common.ResolveArkSecret.
+// Given secrets are never resolved, so passwords that happen to look like a reference are kept.
+// References are resolved on every authentication, so rotated secrets are picked up.
+func resolveSecret(authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret) (*auth.ArkSecret, error) {
+	if secret != nil && secret.Secret != "" {
+		return secret, nil
+	}
+	if authProfile == nil || authProfile.SecretRef == "" {
+		return secret, nil
+	}
+	if !common.IsArkSecretReference(authProfile.SecretRef) {
+		return nil, errors.New("secret reference of the auth profile is not a secret reference")
+	}
+	resolved, err := common.ResolveArkSecret(context.Background(), authProfile.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.ArkSecret{Secret: resolved}, nil
+}
+
 // IsAuthenticated checks if the authentication is already loaded for the specified profile.
 func (a *ArkAuthBase) IsAuthenticated(profile *models.ArkProfile) bool {
 	var err error
 	a.Logger.Info("Checking if [%s] is authenticated", a.Authenticator.AuthenticatorName())
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
 	if a.Token != nil {
 		a.Logger.Info("Token is already loaded")
 		return true
 	}
-	if ap, ok := profile.AuthProfiles[a.Authenticator.AuthenticatorName()]; ok && a.CacheKeyring != nil {
-		a.Token, err = a.CacheKeyring.LoadToken(profile, ap.Username, false)
+	if tokenCache := a.tokenCache(); tokenCache != nil {
+		ap, ok := profile.AuthProfiles[a.Authenticator.AuthenticatorName()]
+		if !ok {
+			return false
+		}
+		a.Token, err = tokenCache.LoadToken(profile, a.CacheNamespace(), a.ResolveCachePostfix(ap))
 		if err != nil {
 			return false
 		}
@@ -183,8 +280,22 @@ func (a *ArkAuthBase) IsAuthenticated(profile *models.ArkProfile) bool {
 }
 
 // LoadAuthentication loads the authentication token for the specified profile and refreshes it if necessary.
+//
+// Concurrent calls are serialized, so when several clients get a 401 at once, the token is
+// refreshed once and the refreshed token is applied to all the clients attached to the authenticator.
 func (a *ArkAuthBase) LoadAuthentication(profile *models.ArkProfile, refreshAuth bool) (*auth.ArkToken, error) {
+	token, refreshed, err := a.loadAuthentication(profile, refreshAuth)
+	if refreshed && token != nil {
+		a.publishTokenRefresh(&ArkTokenRefreshEvent{Token: token})
+	}
+	return token, err
+}
+
+func (a *ArkAuthBase) loadAuthentication(profile *models.ArkProfile, refreshAuth bool) (*auth.ArkToken, bool, error) {
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
 	var err error
+	refreshed := false
 	a.Logger.Info("Trying to load [%s] authentication", a.Authenticator.AuthenticatorName())
 	if profile == nil {
 		if a.ActiveProfile != nil {
@@ -202,10 +313,11 @@ func (a *ArkAuthBase) LoadAuthentication(profile *models.ArkProfile, refreshAuth
 	}
 	if authProfile != nil {
 		a.Logger.Info("Loading authentication for profile [%s] and auth profile [%s] of type [%s]", profile.ProfileName, a.Authenticator.AuthenticatorName(), string(authProfile.AuthMethod))
-		if a.CacheKeyring != nil {
-			a.Token, err = a.CacheKeyring.LoadToken(profile, a.ResolveCachePostfix(authProfile), false)
+		tokenCache := a.tokenCache()
+		if tokenCache != nil {
+			a.Token, err = tokenCache.LoadToken(profile, a.CacheNamespace(), a.ResolveCachePostfix(authProfile))
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 		}
 		if refreshAuth {
@@ -213,14 +325,16 @@ func (a *ArkAuthBase) LoadAuthentication(profile *models.ArkProfile, refreshAuth
 				a.Logger.Info("Token did not pass grace expiration, no need to refresh")
 			} else {
 				a.Logger.Info("Trying to refresh token authentication")
-				a.Token, _ = a.Authenticator.performRefreshAuthentication(profile, authProfile, a.Token)
+				previousToken := a.Token
+				a.Token, _ = a.Authenticator.PerformRefreshAuthentication(profile, authProfile, a.Token)
 				if a.Token != nil && time.Time(a.Token.ExpiresIn).After(time.Now()) {
 					a.Logger.Info("Token refreshed")
+					refreshed = a.Token != previousToken
 				}
-				if a.Token != nil && a.CacheAuthentication && a.CacheKeyring != nil {
-					err = a.CacheKeyring.SaveToken(profile, a.Token, a.ResolveCachePostfix(authProfile), false)
+				if a.Token != nil && a.CacheAuthentication && tokenCache != nil {
+					err = tokenCache.SaveToken(profile, a.Token, a.CacheNamespace(), a.ResolveCachePostfix(authProfile))
 					if err != nil {
-						return nil, err
+						return nil, false, err
 					}
 				}
 			}
@@ -232,7 +346,25 @@ func (a *ArkAuthBase) LoadAuthentication(profile *models.ArkProfile, refreshAuth
 			a.ActiveProfile = profile
 			a.ActiveAuthProfile = authProfile
 		}
-		return a.Token, nil
+		return a.Token, refreshed, nil
 	}
-	return nil, nil
+	return nil, false, nil
+}
+
+// Close stops the background refresh and releases the in-memory token of the authenticator.
+//
+// The authenticator drops its reference to the token, and the clients attached to it are
+// detached. The token itself is left untouched, so copies returned by Authenticate and
+// LoadAuthentication stay usable by their holders; Go strings cannot be wiped in memory,
+// so the token is only reclaimed once nothing references it. Tokens cached in the keyring
+// are kept, and the authentication has to be loaded or performed again before use.
+func (a *ArkAuthBase) Close() error {
+	a.StopBackgroundRefresh()
+	a.stateMutex.Lock()
+	a.clients = nil
+	a.stateMutex.Unlock()
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
+	a.Token = nil
+	return nil
 }
@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+const (
+	defaultBackgroundRefreshGracePeriod   = 5 * time.Minute
+	defaultBackgroundRefreshRetryInterval = 30 * time.Second
+)
+
+// ArkBackgroundRefreshConfig configures the background refresh of an authenticator.
+type ArkBackgroundRefreshConfig struct {
+	// GracePeriod is how long before the expiration of the token it is renewed. Defaults to 5 minutes.
+	GracePeriod time.Duration
+	// RetryInterval is how long to wait before trying again when a renewal fails. Defaults to 30 seconds.
+	RetryInterval time.Duration
+	// Secret is used to authenticate again when the token cannot be refreshed, such as for service users.
+	// Without it, auth methods requiring credentials are only refreshed.
+	Secret *auth.ArkSecret
+}
+
+// ArkTokenRefreshEvent describes a renewal of the token of an authenticator.
+type ArkTokenRefreshEvent struct {
+	// AuthenticatorName is the name of the authenticator whose token was renewed.
+	AuthenticatorName string
+	// Token is the renewed token, or nil when the renewal failed.
+	Token *auth.ArkToken
+	// Reauthenticated is true when the token was renewed by authenticating again rather than by a refresh.
+	Reauthenticated bool
+	// Background is true when the renewal was made by the background refresh.
+	Background bool
+	// Err is the error the renewal failed with, if any.
+	Err error
+	// Time is when the renewal ended.
+	Time time.Time
+}
+
+// backgroundRefresher is a running background refresh of an authenticator.
+type backgroundRefresher struct {
+	config *ArkBackgroundRefreshConfig
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// StartBackgroundRefresh starts renewing the token of the authenticator in the background, a grace period before it expires.
+//
+// The token is refreshed with PerformRefreshAuthentication. When it cannot be refreshed, such as
+// for service users which get no refresh token, the authenticator authenticates again with the
+// secret of the config. Renewed tokens are cached in the keyring, applied to the clients attached
+// with AttachClient, and published to the listeners added with OnTokenRefresh. Failed renewals
+// are retried until the token expires, and are published as well.
+//
+// The authenticator must be authenticated first. The refresh runs until StopBackgroundRefresh or
+// Close is called. A nil config uses the defaults.
+//
+// Example:
+//
+//	_, err := ispAuth.Authenticate(nil, authProfile, secret, false, false)
+//	if err != nil {
+//		// handle error
+//	}
+//	err = ispAuth.(*auth.ArkISPAuth).StartBackgroundRefresh(&auth.ArkBackgroundRefreshConfig{
+//		GracePeriod: 10 * time.Minute,
+//		Secret:      secret,
+//	})
+//	defer ispAuth.(*auth.ArkISPAuth).Close()
+func (a *ArkAuthBase) StartBackgroundRefresh(config *ArkBackgroundRefreshConfig) error {
+	resolvedConfig := ArkBackgroundRefreshConfig{}
+	if config != nil {
+		resolvedConfig = *config
+	}
+	if resolvedConfig.GracePeriod <= 0 {
+		resolvedConfig.GracePeriod = defaultBackgroundRefreshGracePeriod
+	}
+	if resolvedConfig.RetryInterval <= 0 {
+		resolvedConfig.RetryInterval = defaultBackgroundRefreshRetryInterval
+	}
+	a.refreshMutex.Lock()
+	authenticated := a.Token != nil && a.ActiveAuthProfile != nil
+	a.refreshMutex.Unlock()
+	if !authenticated {
+		return errors.New("authenticator must be authenticated before starting the background refresh")
+	}
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+	if a.refresher != nil {
+		return errors.New("background refresh is already running")
+	}
+	refresher := &backgroundRefresher{
+		config: &resolvedConfig,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	a.refresher = refresher
+	go a.runBackgroundRefresh(refresher)
+	return nil
+}
+
+// StopBackgroundRefresh stops the background refresh of the authenticator, and waits for it to end.
+// It does nothing if the background refresh is not running.
+func (a *ArkAuthBase) StopBackgroundRefresh() {
+	a.stateMutex.Lock()
+	refresher := a.refresher
+	a.refresher = nil
+	a.stateMutex.Unlock()
+	if refresher == nil {
+		return
+	}
+	close(refresher.stop)
+	<-refresher.done
+}
+
+// IsBackgroundRefreshRunning returns whether the background refresh of the authenticator is running.
+func (a *ArkAuthBase) IsBackgroundRefreshRunning() bool {
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+	return a.refresher != nil
+}
+
+// AttachClient attaches a client to the authenticator, so that tokens renewed by the authenticator are applied to it.
+//
+// Clients built from an authenticator, such as with isp.FromISPAuth, are attached to it, so a token
+// renewed for one of them, or by the background refresh, is shared by all of them.
+func (a *ArkAuthBase) AttachClient(client *common.ArkClient) {
+	if client == nil {
+		return
+	}
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+	if !slices.Contains(a.clients, client) {
+		a.clients = append(a.clients, client)
+	}
+}
+
+// DetachClient detaches a client from the authenticator.
+func (a *ArkAuthBase) DetachClient(client *common.ArkClient) {
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+	a.clients = slices.DeleteFunc(a.clients, func(attached *common.ArkClient) bool {
+		return attached == client
+	})
+}
+
+// OnTokenRefresh adds a listener called with every renewal of the token of the authenticator.
+//
+// Listeners are called in the goroutine renewing the token, one after the other, and should not block.
+// The returned function removes the listener.
+func (a *ArkAuthBase) OnTokenRefresh(listener func(event *ArkTokenRefreshEvent)) func() {
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+	a.listenersCount++
+	id := a.listenersCount
+	if a.listeners == nil {
+		a.listeners = make(map[int]func(event *ArkTokenRefreshEvent))
+	}
+	a.listeners[id] = listener
+	return func() {
+		a.stateMutex.Lock()
+		defer a.stateMutex.Unlock()
+		delete(a.listeners, id)
+	}
+}
+
+// UpdateClientToken applies a token and the cookies stored in its metadata to a client.
+func UpdateClientToken(client *common.ArkClient, token *auth.ArkToken) {
+	client.UpdateToken(token.Token, client.GetTokenType())
+	cookieJar := make(map[string]string)
+	if cookies, ok := token.Metadata["cookies"].(string); ok {
+		decoded, _ := base64.StdEncoding.DecodeString(cookies)
+		_ = json.Unmarshal(decoded, &cookieJar)
+	}
+	client.UpdateCookies(cookieJar)
+}
+
+// publishTokenRefresh applies a renewed token to the attached clients, and publishes the renewal to the listeners.
+func (a *ArkAuthBase) publishTokenRefresh(event *ArkTokenRefreshEvent) {
+	event.AuthenticatorName = a.Authenticator.AuthenticatorName()
+	event.Time = time.Now()
+	a.stateMutex.Lock()
+	clients := slices.Clone(a.clients)
+	listeners := make([]func(event *ArkTokenRefreshEvent), 0, len(a.listeners))
+	for id := 1; id <= a.listenersCount; id++ {
+		if listener, ok := a.listeners[id]; ok {
+			listeners = append(listeners, listener)
+		}
+	}
+	a.stateMutex.Unlock()
+	if event.Token != nil {
+		for _, client := range clients {
+			UpdateClientToken(client, event.Token)
+		}
+	}
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
+
+// runBackgroundRefresh renews the token until the refresher is stopped.
+func (a *ArkAuthBase) runBackgroundRefresh(refresher *backgroundRefresher) {
+	defer close(refresher.done)
+	attempted := false
+	for {
+		a.refreshMutex.Lock()
+		token := a.Token
+		a.refreshMutex.Unlock()
+		var timer *time.Timer
+		var wait <-chan time.Time
+		if token != nil && !time.Time(token.ExpiresIn).IsZero() {
+			delay := time.Until(time.Time(token.ExpiresIn).Add(-refresher.config.GracePeriod))
+			if attempted && delay < refresher.config.RetryInterval {
+				// The last renewal failed, or did not get a token out of the grace period
+				delay = refresher.config.RetryInterval
+			}
+			timer = time.NewTimer(delay)
+			wait = timer.C
+		}
+		select {
+		case <-refresher.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-wait:
+		}
+		attempted = true
+		renewed, reauthenticated, err := a.renewToken(refresher.config.Secret)
+		if err != nil {
+			a.Logger.Warning("Background refresh of [%s] failed: %v", a.Authenticator.AuthenticatorName(), err)
+		} else {
+			a.Logger.Info("Background refresh of [%s] renewed the token until [%s]", a.Authenticator.AuthenticatorName(), time.Time(renewed.ExpiresIn).Format(time.RFC3339))
+		}
+		a.publishTokenRefresh(&ArkTokenRefreshEvent{
+			Token:           renewed,
+			Reauthenticated: reauthenticated,
+			Background:      true,
+			Err:             err,
+		})
+	}
+}
+
+// renewToken refreshes the token of the authenticator, or authenticates again when it cannot be refreshed.
+func (a *ArkAuthBase) renewToken(secret *auth.ArkSecret) (*auth.ArkToken, bool, error) {
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
+	profile, authProfile, token := a.ActiveProfile, a.ActiveAuthProfile, a.Token
+	if token == nil || authProfile == nil {
+		return nil, false, errors.New("authenticator is not authenticated")
+	}
+	reauthenticated := false
+	renewed, err := a.Authenticator.PerformRefreshAuthentication(profile, authProfile, token)
+	if err != nil || renewed == nil || !time.Time(renewed.ExpiresIn).After(time.Time(token.ExpiresIn)) {
+		if secret == nil && authProfile.SecretRef == "" && authProfile.RequiresSecret() {
+			if err == nil {
+				err = errors.New("token cannot be refreshed, and no secret was given to authenticate again")
+			}
+			return nil, false, err
+		}
+		a.Logger.Info("Token of [%s] could not be refreshed, authenticating again", a.Authenticator.AuthenticatorName())
+		secret, err = resolveSecret(authProfile, secret)
+		if err != nil {
+			return nil, false, err
+		}
+		renewed, err = a.Authenticator.PerformAuthentication(profile, authProfile, secret, true)
+		if err != nil {
+			return nil, false, err
+		}
+		if renewed == nil {
+			return nil, false, errors.New("authentication returned no token")
+		}
+		reauthenticated = true
+	}
+	if tokenCache := a.tokenCache(); a.CacheAuthentication && tokenCache != nil {
+		if err := tokenCache.SaveToken(profile, renewed, a.CacheNamespace(), a.ResolveCachePostfix(authProfile)); err != nil {
+			return nil, false, err
+		}
+	}
+	a.Token = renewed
+	return renewed, reauthenticated, nil
+}
@@ -0,0 +1,264 @@
+package auth_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+// unrefreshableAuthenticator is a mock authenticator which returns tokens as is on refresh, like service users.
+type unrefreshableAuthenticator struct {
+	*testutils.MockAuthenticator
+}
+
+func (a *unrefreshableAuthenticator) PerformRefreshAuthentication(profile *models.ArkProfile, authProfile *authmodels.ArkAuthProfile, token *authmodels.ArkToken) (*authmodels.ArkToken, error) {
+	a.RefreshCount++
+	return token, nil
+}
+
+func newRefresherTestAuthenticator(t *testing.T, authMethod authmodels.ArkAuthMethod, lifetime time.Duration, refreshable bool) (*testutils.MockAuthenticator, *models.ArkProfile) {
+	authenticator := testutils.NewMockAuthenticator("mock", authMethod)
+	if !refreshable {
+		authenticator.ArkAuthBase.Authenticator = &unrefreshableAuthenticator{authenticator}
+	}
+	authenticator.AuthenticateFunc = func(authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret) (*authmodels.ArkToken, error) {
+		return &authmodels.ArkToken{
+			Token:      "token-" + time.Now().Format(time.RFC3339Nano),
+			Username:   authProfile.Username,
+			TokenType:  authmodels.Token,
+			AuthMethod: authProfile.AuthMethod,
+			ExpiresIn:  commonmodels.ArkRFC3339Time(time.Now().Add(lifetime)),
+		}, nil
+	}
+	profile := &models.ArkProfile{
+		ProfileName: "mock-profile",
+		AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+			"mock": {Username: "user", AuthMethod: authMethod},
+		},
+	}
+	if _, err := authenticator.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: "secret"}, false, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = authenticator.Close() })
+	return authenticator, profile
+}
+
+func waitForTokenRefresh(t *testing.T, events <-chan *auth.ArkTokenRefreshEvent) *auth.ArkTokenRefreshEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a token refresh event")
+		return nil
+	}
+}
+
+func TestArkAuthBase_BackgroundRefresh(t *testing.T) {
+	tests := []struct {
+		name                    string
+		authMethod              authmodels.ArkAuthMethod
+		refreshable             bool
+		config                  *auth.ArkBackgroundRefreshConfig
+		expectedError           bool
+		expectedReauthenticated bool
+	}{
+		{
+			name:        "success_refreshes_token",
+			authMethod:  authmodels.Other,
+			refreshable: true,
+		},
+		{
+			name:                    "success_reauthenticates_service_user_with_secret",
+			authMethod:              authmodels.IdentityServiceUser,
+			config:                  &auth.ArkBackgroundRefreshConfig{Secret: &authmodels.ArkSecret{Secret: "secret"}},
+			expectedReauthenticated: true,
+		},
+		{
+			name:                    "success_reauthenticates_without_credentials",
+			authMethod:              authmodels.Other,
+			expectedReauthenticated: true,
+		},
+		{
+			name:          "error_service_user_without_secret",
+			authMethod:    authmodels.IdentityServiceUser,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator, _ := newRefresherTestAuthenticator(t, tt.authMethod, time.Minute, tt.refreshable)
+			previousToken := authenticator.Token
+			client := common.NewSimpleArkClient("https://example.com")
+			client.UpdateToken("stale", "Bearer")
+			authenticator.AttachClient(client)
+			events := make(chan *auth.ArkTokenRefreshEvent, 10)
+			authenticator.OnTokenRefresh(func(event *auth.ArkTokenRefreshEvent) {
+				events <- event
+			})
+
+			if err := authenticator.StartBackgroundRefresh(tt.config); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			event := waitForTokenRefresh(t, events)
+			authenticator.StopBackgroundRefresh()
+
+			if event.AuthenticatorName != "mock" || !event.Background {
+				t.Errorf("Unexpected event %+v", event)
+			}
+			if tt.expectedError {
+				if event.Err == nil || event.Token != nil {
+					t.Errorf("Expected a failed refresh, got %+v", event)
+				}
+				if client.GetToken() != "stale" {
+					t.Error("Expected the client token to be kept on failure")
+				}
+				return
+			}
+			if event.Err != nil {
+				t.Fatalf("Expected no error, got %v", event.Err)
+			}
+			if event.Reauthenticated != tt.expectedReauthenticated {
+				t.Errorf("Expected reauthenticated %v, got %v", tt.expectedReauthenticated, event.Reauthenticated)
+			}
+			if !time.Time(event.Token.ExpiresIn).After(time.Time(previousToken.ExpiresIn)) {
+				t.Error("Expected the renewed token to expire later")
+			}
+			if authenticator.Token != event.Token {
+				t.Error("Expected the authenticator to hold the renewed token")
+			}
+			if client.GetToken() != event.Token.Token || client.GetTokenType() != "Bearer" {
+				t.Errorf("Expected the attached client to get the renewed token, got %s", client.GetToken())
+			}
+		})
+	}
+}
+
+func TestArkAuthBase_BackgroundRefreshLifecycle(t *testing.T) {
+	authenticator := testutils.NewMockAuthenticator("mock", authmodels.Other)
+	if err := authenticator.StartBackgroundRefresh(nil); err == nil {
+		t.Error("Expected an error when not authenticated")
+	}
+
+	authenticator, _ = newRefresherTestAuthenticator(t, authmodels.Other, time.Hour, true)
+	if err := authenticator.StartBackgroundRefresh(nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := authenticator.StartBackgroundRefresh(nil); err == nil {
+		t.Error("Expected an error when already running")
+	}
+	if !authenticator.IsBackgroundRefreshRunning() {
+		t.Error("Expected the background refresh to run")
+	}
+	if err := authenticator.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if authenticator.IsBackgroundRefreshRunning() {
+		t.Error("Expected close to stop the background refresh")
+	}
+	if authenticator.RefreshCount != 0 {
+		t.Errorf("Expected no refresh before the grace period, got %d", authenticator.RefreshCount)
+	}
+}
+
+func TestArkAuthBase_BackgroundRefreshRetries(t *testing.T) {
+	authenticator, _ := newRefresherTestAuthenticator(t, authmodels.Other, time.Minute, false)
+	attempts := 0
+	authenticator.AuthenticateFunc = func(authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret) (*authmodels.ArkToken, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("identity is unavailable")
+		}
+		return &authmodels.ArkToken{
+			Token:     "renewed",
+			ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour)),
+		}, nil
+	}
+	events := make(chan *auth.ArkTokenRefreshEvent, 10)
+	unsubscribe := authenticator.OnTokenRefresh(func(event *auth.ArkTokenRefreshEvent) {
+		events <- event
+	})
+	defer unsubscribe()
+
+	if err := authenticator.StartBackgroundRefresh(&auth.ArkBackgroundRefreshConfig{RetryInterval: 10 * time.Millisecond}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event := waitForTokenRefresh(t, events); event.Err == nil {
+		t.Error("Expected the first refresh to fail")
+	}
+	if event := waitForTokenRefresh(t, events); event.Err != nil || event.Token.Token != "renewed" {
+		t.Errorf("Expected the retried refresh to succeed, got %+v", event)
+	}
+}
+
+func TestArkAuthBase_LoadAuthenticationConcurrentRefresh(t *testing.T) {
+	authenticator, profile := newRefresherTestAuthenticator(t, authmodels.Other, 30*time.Second, true)
+	clients := []*common.ArkClient{common.NewSimpleArkClient("https://a.example.com"), common.NewSimpleArkClient("https://b.example.com")}
+	for _, client := range clients {
+		authenticator.AttachClient(client)
+	}
+	authenticator.DetachClient(clients[1])
+	var refreshEvents atomic.Int32
+	authenticator.OnTokenRefresh(func(event *auth.ArkTokenRefreshEvent) {
+		refreshEvents.Add(1)
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := authenticator.LoadAuthentication(profile, true); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if authenticator.RefreshCount != 1 {
+		t.Errorf("Expected the token to be refreshed once, got %d", authenticator.RefreshCount)
+	}
+	if refreshEvents.Load() != 1 {
+		t.Errorf("Expected one refresh event, got %d", refreshEvents.Load())
+	}
+	if clients[0].GetToken() != authenticator.Token.Token {
+		t.Error("Expected the attached client to get the refreshed token")
+	}
+	if clients[1].GetToken() != "" {
+		t.Error("Expected the detached client to be left as is")
+	}
+}
+
+func TestArkAuthBase_Close(t *testing.T) {
+	authenticator, profile := newRefresherTestAuthenticator(t, authmodels.Other, time.Hour, true)
+	token, err := authenticator.LoadAuthentication(profile, false)
+	if err != nil || token == nil {
+		t.Fatalf("Expected the token, got %v, %v", token, err)
+	}
+	issued := token.Token
+	client := common.NewSimpleArkClient("https://example.com")
+	authenticator.AttachClient(client)
+	if err := authenticator.StartBackgroundRefresh(nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := authenticator.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if authenticator.Token != nil || authenticator.IsBackgroundRefreshRunning() {
+		t.Error("Expected the authenticator to release its token and stop refreshing")
+	}
+	if token.Token != issued {
+		t.Errorf("Expected the token held by the caller to be left untouched, got %q", token.Token)
+	}
+}
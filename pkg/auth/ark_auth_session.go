@@ -0,0 +1,362 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Env vars and defaults of exported sessions
+const (
+	ArkSessionEnvVar    = "ARK_SESSION"
+	ArkSessionKeyEnvVar = "ARK_SESSION_KEY"
+	DefaultSessionTTL   = 15 * time.Minute
+)
+
+const (
+	sessionBundlePrefix = "arksession.v1."
+	sessionSaltSize     = 16
+	sessionKeySize      = 32
+	sessionScryptN      = 1 << 15
+	sessionScryptR      = 8
+	sessionScryptP      = 1
+)
+
+// ArkSessionEnvVars are the env vars exported with a session bundle, so that processes importing
+// it reach the same tenant environment with the same certificate verification as the exporter.
+var ArkSessionEnvVars = []string{
+	common.ArkDisableCertificateVerificationEnvVar,
+	"DEPLOY_ENV",
+}
+
+// ErrArkSessionExpired is returned when importing a session bundle after its expiration.
+var ErrArkSessionExpired = errors.New("session bundle has expired")
+
+// ArkSessionBundle is the authenticated state of a profile, handed to other processes as an encrypted string.
+//
+// A bundle holds the profile and, for every authenticator that was exported, the auth profile
+// and token it authenticated with, including its refresh token and cookies, along with the
+// ArkSessionEnvVars that were set when it was exported. It can only be imported until ExpiresAt,
+// regardless of the expiration of the tokens it holds.
+type ArkSessionBundle struct {
+	Profile   *models.ArkProfile                `json:"profile"`
+	Sessions  map[string]*ArkSessionBundleEntry `json:"sessions"`
+	Env       map[string]string                 `json:"env,omitempty"`
+	IssuedAt  commonmodels.ArkRFC3339Time       `json:"issued_at"`
+	ExpiresAt commonmodels.ArkRFC3339Time       `json:"expires_at"`
+}
+
+// ArkSessionBundleEntry is the authenticated state of a single authenticator within an ArkSessionBundle.
+type ArkSessionBundleEntry struct {
+	AuthProfile *auth.ArkAuthProfile `json:"auth_profile"`
+	Token       *auth.ArkToken       `json:"token"`
+}
+
+// sessionExporter is implemented by authenticators embedding ArkAuthBase.
+type sessionExporter interface {
+	ExportSession(profile *models.ArkProfile) (*ArkSessionBundleEntry, error)
+}
+
+// sessionImporter is implemented by authenticators embedding ArkAuthBase.
+type sessionImporter interface {
+	ImportSession(profile *models.ArkProfile, entry *ArkSessionBundleEntry) error
+}
+
+// ExportSession returns the authenticated state of the authenticator, to be added to an ArkSessionBundle.
+//
+// The auth profile is the one the authenticator authenticated with, or the one of the given
+// profile if the token was loaded from the cache. An error is returned if the authenticator
+// is not authenticated, or its token has expired.
+func (a *ArkAuthBase) ExportSession(profile *models.ArkProfile) (*ArkSessionBundleEntry, error) {
+	a.refreshMutex.Lock()
+	defer a.refreshMutex.Unlock()
+	if a.Token == nil || time.Time(a.Token.ExpiresIn).Before(time.Now()) {
+		return nil, fmt.Errorf("%s is not authenticated", a.Authenticator.AuthenticatorHumanReadableName())
+	}
+	authProfile := a.ActiveAuthProfile
+	if authProfile == nil && profile != nil {
+		authProfile = profile.AuthProfiles[a.Authenticator.AuthenticatorName()]
+	}
+	if authProfile == nil {
+		return nil, fmt.Errorf("%s has no auth profile to export", a.Authenticator.AuthenticatorHumanReadableName())
+	}
+	token := *a.Token
+	token.Metadata = maps.Clone(a.Token.Metadata)
+	return &ArkSessionBundleEntry{
+		AuthProfile: authProfile,
+		Token:       &token,
+	}, nil
+}
+
+// ImportSession sets the authenticated state of the authenticator to an entry of an ArkSessionBundle.
+//
+// The token is kept in memory and is not cached in the keyring, so processes without a
+// keyring, such as short-lived containers, can use it. It is refreshed as any other token.
+// Authenticators caching their authentication load their token from the keyring instead,
+// so sessions are imported into authenticators created without caching.
+func (a *ArkAuthBase) ImportSession(profile *models.ArkProfile, entry *ArkSessionBundleEntry) error {
+	if entry == nil || entry.Token == nil || entry.AuthProfile == nil {
+		return errors.New("session entry must have a token and an auth profile")
+	}
+	if !time.Time(entry.Token.ExpiresIn).After(time.Now()) && entry.Token.RefreshToken == "" {
+		return fmt.Errorf("token of %s in the session has expired", a.Authenticator.AuthenticatorHumanReadableName())
+	}
+	a.refreshMutex.Lock()
+	a.Token = entry.Token
+	a.ActiveProfile = profile
+	a.ActiveAuthProfile = entry.AuthProfile
+	a.refreshMutex.Unlock()
+	a.publishTokenRefresh(&ArkTokenRefreshEvent{Token: entry.Token})
+	return nil
+}
+
+// ExportArkSession exports the authenticated state of authenticators as an encrypted, time-limited session bundle.
+//
+// The bundle is encrypted with AES-GCM, with a key derived from the passphrase with scrypt,
+// and can be imported with ImportArkSession, or by the CLI through the ARK_SESSION env var,
+// until the ttl has passed. Anyone holding the bundle and the passphrase can act as the
+// authenticated user until then, so both should be handled as secrets.
+//
+// Parameters:
+//   - profile: The profile the authenticators authenticated with
+//   - passphrase: The passphrase to encrypt the bundle with
+//   - ttl: How long the bundle can be imported for, DefaultSessionTTL if not positive
+//   - authenticators: The authenticated authenticators to export
+//
+// Returns the encrypted bundle, or an error if an authenticator is not authenticated.
+//
+// Example:
+//
+//	bundle, err := auth.ExportArkSession(profile, os.Getenv(auth.ArkSessionKeyEnvVar), 10*time.Minute, ispAuth)
+//	if err != nil {
+//		// handle error
+//	}
+//	workerCmd.Env = append(os.Environ(), auth.ArkSessionEnvVar+"="+bundle)
+func ExportArkSession(profile *models.ArkProfile, passphrase string, ttl time.Duration, authenticators ...ArkAuth) (string, error) {
+	if profile == nil {
+		return "", errors.New("profile must be given to export a session")
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase must be given to export a session")
+	}
+	if len(authenticators) == 0 {
+		return "", errors.New("no authenticators to export")
+	}
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	now := time.Now()
+	bundle := &ArkSessionBundle{
+		Profile:   profile,
+		Sessions:  make(map[string]*ArkSessionBundleEntry, len(authenticators)),
+		IssuedAt:  commonmodels.ArkRFC3339Time(now),
+		ExpiresAt: commonmodels.ArkRFC3339Time(now.Add(ttl)),
+	}
+	for _, name := range ArkSessionEnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			if bundle.Env == nil {
+				bundle.Env = make(map[string]string)
+			}
+			bundle.Env[name] = value
+		}
+	}
+	for _, authenticator := range authenticators {
+		exporter, ok := authenticator.(sessionExporter)
+		if !ok {
+			return "", fmt.Errorf("%s does not support exporting sessions", authenticator.AuthenticatorHumanReadableName())
+		}
+		entry, err := exporter.ExportSession(profile)
+		if err != nil {
+			return "", err
+		}
+		bundle.Sessions[authenticator.AuthenticatorName()] = entry
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+	return sealSessionBundle(data, passphrase)
+}
+
+// DecryptArkSession decrypts a session bundle exported with ExportArkSession.
+//
+// Returns ErrArkSessionExpired if the bundle has expired, or an error if the passphrase is wrong.
+func DecryptArkSession(encrypted string, passphrase string) (*ArkSessionBundle, error) {
+	data, err := openSessionBundle(encrypted, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var bundle ArkSessionBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse session bundle: %w", err)
+	}
+	if !time.Time(bundle.ExpiresAt).After(time.Now()) {
+		return nil, ErrArkSessionExpired
+	}
+	if bundle.Profile == nil || len(bundle.Sessions) == 0 {
+		return nil, errors.New("session bundle has no authenticated sessions")
+	}
+	return &bundle, nil
+}
+
+// ImportArkSession imports a session bundle exported with ExportArkSession into new authenticators.
+//
+// Authenticators are created with NewAuthenticator without caching, so they do not share the
+// state of the registered authenticators and do not write to the keyring.
+//
+// Returns the profile of the bundle and its authenticators, ready to be given to NewArkAPI.
+//
+// Example:
+//
+//	profile, authenticators, err := auth.ImportArkSession(os.Getenv(auth.ArkSessionEnvVar), os.Getenv(auth.ArkSessionKeyEnvVar))
+//	if err != nil {
+//		// handle error
+//	}
+//	api, err := ark.NewArkAPI(authenticators, profile)
+func ImportArkSession(encrypted string, passphrase string) (*models.ArkProfile, []ArkAuth, error) {
+	bundle, err := DecryptArkSession(encrypted, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	return importArkSessionBundle(bundle)
+}
+
+func importArkSessionBundle(bundle *ArkSessionBundle) (*models.ArkProfile, []ArkAuth, error) {
+	authenticators := make([]ArkAuth, 0, len(bundle.Sessions))
+	for name, entry := range bundle.Sessions {
+		authenticator, err := NewAuthenticator(name, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		importer, ok := authenticator.(sessionImporter)
+		if !ok {
+			return nil, nil, fmt.Errorf("%s does not support importing sessions", authenticator.AuthenticatorHumanReadableName())
+		}
+		if err := importer.ImportSession(bundle.Profile, entry); err != nil {
+			return nil, nil, err
+		}
+		authenticators = append(authenticators, authenticator)
+	}
+	return bundle.Profile, authenticators, nil
+}
+
+// RestoreEnv sets the env vars of the bundle in the current process.
+//
+// Env vars that are already set in the process are kept, so a worker can still override them.
+//
+// Returns an error if an env var fails to be set.
+func (b *ArkSessionBundle) RestoreEnv() error {
+	for name, value := range b.Env {
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to restore env var %s of the session: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ImportArkSessionFromEnv imports the session bundle of the ARK_SESSION env var, decrypted with the ARK_SESSION_KEY env var.
+//
+// The env vars of the bundle are restored with RestoreEnv before its authenticators are created.
+//
+// Returns a nil profile and no error if ARK_SESSION is not set.
+func ImportArkSessionFromEnv() (*models.ArkProfile, []ArkAuth, error) {
+	encrypted := os.Getenv(ArkSessionEnvVar)
+	if encrypted == "" {
+		return nil, nil, nil
+	}
+	passphrase := os.Getenv(ArkSessionKeyEnvVar)
+	if passphrase == "" {
+		return nil, nil, fmt.Errorf("%s must be set to import the session of %s", ArkSessionKeyEnvVar, ArkSessionEnvVar)
+	}
+	bundle, err := DecryptArkSession(encrypted, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := bundle.RestoreEnv(); err != nil {
+		return nil, nil, err
+	}
+	return importArkSessionBundle(bundle)
+}
+
+func sessionKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, sessionScryptN, sessionScryptR, sessionScryptP, sessionKeySize)
+}
+
+// sealSessionBundle encrypts the bundle, prefixed with its version, followed by the salt, nonce and ciphertext encoded as base64.
+func sealSessionBundle(data []byte, passphrase string) (string, error) {
+	salt := make([]byte, sessionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := sessionKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	aesGCM, err := newSessionCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := append(salt, nonce...)
+	sealed = aesGCM.Seal(sealed, nonce, data, []byte(sessionBundlePrefix))
+	return sessionBundlePrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func openSessionBundle(encrypted string, passphrase string) ([]byte, error) {
+	encrypted = strings.TrimSpace(encrypted)
+	if !strings.HasPrefix(encrypted, sessionBundlePrefix) {
+		return nil, errors.New("not a session bundle")
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(encrypted, sessionBundlePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode session bundle: %w", err)
+	}
+	if len(sealed) < sessionSaltSize {
+		return nil, errors.New("session bundle is too short")
+	}
+	key, err := sessionKey(passphrase, sealed[:sessionSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	aesGCM, err := newSessionCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[sessionSaltSize:]
+	if len(sealed) < aesGCM.NonceSize() {
+		return nil, errors.New("session bundle is too short")
+	}
+	data, err := aesGCM.Open(nil, sealed[:aesGCM.NonceSize()], sealed[aesGCM.NonceSize():], []byte(sessionBundlePrefix))
+	if err != nil {
+		return nil, errors.New("failed to decrypt session bundle, the passphrase may be wrong")
+	}
+	return data, nil
+}
+
+func newSessionCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
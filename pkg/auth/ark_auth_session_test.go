@@ -0,0 +1,236 @@
+package auth_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+const (
+	sessionBundleTestAuthenticatorName = "session-bundle-mock"
+	sessionBundleTestAuthMethod        = authmodels.ArkAuthMethod("session_bundle_test")
+)
+
+// sessionBundleTestAuthMethodSettings are the settings of the auth method of the mock authenticator, registered so that
+// the auth profiles of bundles can be parsed.
+type sessionBundleTestAuthMethodSettings struct {
+	Audience string `json:"audience" mapstructure:"audience" flag:"audience" desc:"Audience"`
+}
+
+var registerSessionBundleTestAuthenticator sync.Once
+
+// newSessionBundleTestAuthenticator returns a mock authenticator, registered with a factory for the import of sessions,
+// authenticated with a token that has a refresh token and cookies.
+func newSessionBundleTestAuthenticator(t *testing.T) (*testutils.MockAuthenticator, *models.ArkProfile) {
+	registerSessionBundleTestAuthenticator.Do(func() {
+		err := authmodels.RegisterArkAuthMethod(sessionBundleTestAuthMethod, "Session Bundle Test", &sessionBundleTestAuthMethodSettings{}, false)
+		if err != nil {
+			t.Fatalf("failed to register auth method: %v", err)
+		}
+		err = auth.RegisterAuthenticatorFactory(sessionBundleTestAuthenticatorName, func(cacheAuthentication bool) auth.ArkAuth {
+			return testutils.NewMockAuthenticator(sessionBundleTestAuthenticatorName, sessionBundleTestAuthMethod)
+		})
+		if err != nil {
+			t.Fatalf("failed to register factory: %v", err)
+		}
+	})
+	authenticator := testutils.NewMockAuthenticator(sessionBundleTestAuthenticatorName, sessionBundleTestAuthMethod)
+	authenticator.AuthenticateFunc = func(authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret) (*authmodels.ArkToken, error) {
+		return &authmodels.ArkToken{
+			Token:        "token",
+			Username:     authProfile.Username,
+			TokenType:    authmodels.JWT,
+			AuthMethod:   authProfile.AuthMethod,
+			ExpiresIn:    commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour)),
+			RefreshToken: "refresh-token",
+			Metadata:     map[string]interface{}{"env": "prod", "cookies": "Y29va2llcw=="},
+		}, nil
+	}
+	profile := &models.ArkProfile{
+		ProfileName: "controller",
+		AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+			sessionBundleTestAuthenticatorName: {
+				Username:           "user@cyberark.cloud.12345",
+				AuthMethod:         sessionBundleTestAuthMethod,
+				AuthMethodSettings: &sessionBundleTestAuthMethodSettings{Audience: "workers"},
+			},
+		},
+	}
+	if _, err := authenticator.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: "secret"}, false, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return authenticator, profile
+}
+
+func TestExportImportArkSession(t *testing.T) {
+	authenticator, profile := newSessionBundleTestAuthenticator(t)
+
+	bundle, err := auth.ExportArkSession(profile, "passphrase", time.Minute, authenticator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(bundle, "refresh-token") || strings.Contains(bundle, "user@cyberark") {
+		t.Error("Expected the bundle to be encrypted")
+	}
+
+	importedProfile, authenticators, err := auth.ImportArkSession(bundle, "passphrase")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if importedProfile.ProfileName != "controller" {
+		t.Errorf("Expected profile controller, got %s", importedProfile.ProfileName)
+	}
+	if len(authenticators) != 1 {
+		t.Fatalf("Expected 1 authenticator, got %d", len(authenticators))
+	}
+	imported := authenticators[0].(*testutils.MockAuthenticator)
+	if imported == authenticator {
+		t.Error("Expected a new authenticator instance")
+	}
+	token, err := imported.LoadAuthentication(nil, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token == nil || token.Token != "token" || token.RefreshToken != "refresh-token" {
+		t.Fatalf("Expected the exported token, got %+v", token)
+	}
+	if token.Metadata["cookies"] != "Y29va2llcw==" || token.Metadata["env"] != "prod" {
+		t.Errorf("Expected the metadata of the token, got %v", token.Metadata)
+	}
+	if imported.ActiveAuthProfile == nil || imported.ActiveAuthProfile.Username != "user@cyberark.cloud.12345" {
+		t.Fatalf("Expected the auth profile to be imported, got %+v", imported.ActiveAuthProfile)
+	}
+	if settings, ok := imported.ActiveAuthProfile.AuthMethodSettings.(*sessionBundleTestAuthMethodSettings); !ok || settings.Audience != "workers" {
+		t.Errorf("Expected the auth method settings to be imported, got %+v", imported.ActiveAuthProfile.AuthMethodSettings)
+	}
+}
+
+func TestImportArkSession_Errors(t *testing.T) {
+	authenticator, profile := newSessionBundleTestAuthenticator(t)
+	bundle, err := auth.ExportArkSession(profile, "passphrase", time.Minute, authenticator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("wrong_passphrase", func(t *testing.T) {
+		if _, _, err := auth.ImportArkSession(bundle, "wrong"); err == nil || !strings.Contains(err.Error(), "passphrase") {
+			t.Errorf("Expected a passphrase error, got %v", err)
+		}
+	})
+	t.Run("tampered", func(t *testing.T) {
+		tampered := bundle[:len(bundle)-2] + "AA"
+		if tampered == bundle {
+			tampered = bundle[:len(bundle)-2] + "BB"
+		}
+		if _, _, err := auth.ImportArkSession(tampered, "passphrase"); err == nil {
+			t.Error("Expected an error for a tampered bundle")
+		}
+	})
+	t.Run("not_a_bundle", func(t *testing.T) {
+		if _, _, err := auth.ImportArkSession("token", "passphrase"); err == nil {
+			t.Error("Expected an error for a value that is not a bundle")
+		}
+	})
+	t.Run("expired", func(t *testing.T) {
+		expired, err := auth.ExportArkSession(profile, "passphrase", time.Millisecond, authenticator)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if _, _, err := auth.ImportArkSession(expired, "passphrase"); !errors.Is(err, auth.ErrArkSessionExpired) {
+			t.Errorf("Expected ErrArkSessionExpired, got %v", err)
+		}
+	})
+}
+
+func TestExportArkSession_Errors(t *testing.T) {
+	authenticator, profile := newSessionBundleTestAuthenticator(t)
+	unauthenticated := testutils.NewMockAuthenticator(sessionBundleTestAuthenticatorName, sessionBundleTestAuthMethod)
+
+	tests := []struct {
+		name           string
+		passphrase     string
+		authenticators []auth.ArkAuth
+		expectedError  string
+	}{
+		{
+			name:           "error_no_passphrase",
+			authenticators: []auth.ArkAuth{authenticator},
+			expectedError:  "passphrase",
+		},
+		{
+			name:          "error_no_authenticators",
+			passphrase:    "passphrase",
+			expectedError: "no authenticators",
+		},
+		{
+			name:           "error_not_authenticated",
+			passphrase:     "passphrase",
+			authenticators: []auth.ArkAuth{unauthenticated},
+			expectedError:  "not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.ExportArkSession(profile, tt.passphrase, time.Minute, tt.authenticators...)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestImportArkSessionFromEnv(t *testing.T) {
+	authenticator, profile := newSessionBundleTestAuthenticator(t)
+	t.Setenv("DEPLOY_ENV", "gov-prod")
+	t.Setenv(common.ArkDisableCertificateVerificationEnvVar, "true")
+	bundle, err := auth.ExportArkSession(profile, "passphrase", time.Minute, authenticator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Setenv(auth.ArkSessionEnvVar, "")
+	importedProfile, _, err := auth.ImportArkSessionFromEnv()
+	if err != nil || importedProfile != nil {
+		t.Fatalf("Expected nothing to be imported without %s, got %v, %v", auth.ArkSessionEnvVar, importedProfile, err)
+	}
+
+	t.Setenv(auth.ArkSessionEnvVar, bundle)
+	os.Unsetenv(auth.ArkSessionKeyEnvVar)
+	if _, _, err := auth.ImportArkSessionFromEnv(); err == nil || !strings.Contains(err.Error(), auth.ArkSessionKeyEnvVar) {
+		t.Errorf("Expected an error about %s, got %v", auth.ArkSessionKeyEnvVar, err)
+	}
+
+	t.Setenv(auth.ArkSessionKeyEnvVar, "passphrase")
+	importedProfile, authenticators, err := auth.ImportArkSessionFromEnv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if importedProfile == nil || len(authenticators) != 1 {
+		t.Errorf("Expected the profile and authenticator of the bundle, got %v, %v", importedProfile, authenticators)
+	}
+
+	t.Setenv("DEPLOY_ENV", "prod")
+	os.Unsetenv("DEPLOY_ENV")
+	t.Setenv(common.ArkDisableCertificateVerificationEnvVar, "")
+	if _, _, err := auth.ImportArkSessionFromEnv(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if os.Getenv("DEPLOY_ENV") != "gov-prod" {
+		t.Errorf("Expected DEPLOY_ENV to be restored from the bundle, got %q", os.Getenv("DEPLOY_ENV"))
+	}
+	if os.Getenv(common.ArkDisableCertificateVerificationEnvVar) != "" {
+		t.Errorf("Expected the env of the worker to be kept, got %q", os.Getenv(common.ArkDisableCertificateVerificationEnvVar))
+	}
+}
@@ -0,0 +1,79 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+func TestArkAuthBase_Authenticate_SecretReferences(t *testing.T) {
+	t.Setenv("ARK_AUTH_TEST_SECRET", "resolved-secret")
+	tests := []struct {
+		name           string
+		secret         string
+		secretRef      string
+		expectedSecret string
+		expectedError  string
+	}{
+		{
+			name:           "success_given_secret_kept_as_is",
+			secret:         "env:ARK_AUTH_TEST_SECRET",
+			expectedSecret: "env:ARK_AUTH_TEST_SECRET",
+		},
+		{
+			name:           "success_given_secret_preferred_to_secret_ref",
+			secret:         "exec:echo typed",
+			secretRef:      "env:ARK_AUTH_TEST_SECRET",
+			expectedSecret: "exec:echo typed",
+		},
+		{
+			name:           "success_secret_ref_resolved",
+			secretRef:      "env:ARK_AUTH_TEST_SECRET",
+			expectedSecret: "resolved-secret",
+		},
+		{
+			name:          "error_secret_ref_not_a_reference",
+			secretRef:     "plaintext-secret",
+			expectedError: "not a secret reference",
+		},
+		{
+			name:          "error_secret_ref_unresolved",
+			secretRef:     "env:ARK_AUTH_TEST_MISSING",
+			expectedError: "is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received string
+			authenticator := testutils.NewMockAuthenticator("mock", authmodels.Other)
+			authenticator.AuthenticateFunc = func(authProfile *authmodels.ArkAuthProfile, secret *authmodels.ArkSecret) (*authmodels.ArkToken, error) {
+				received = secret.Secret
+				return &authmodels.ArkToken{Token: "mock-token"}, nil
+			}
+			profile := &models.ArkProfile{
+				ProfileName: "secret-refs",
+				AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+					"mock": {Username: "user", AuthMethod: authmodels.Other, SecretRef: tt.secretRef},
+				},
+			}
+
+			_, err := authenticator.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: tt.secret}, false, false)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if received != tt.expectedSecret {
+				t.Errorf("Expected secret %q, got %q", tt.expectedSecret, received)
+			}
+		})
+	}
+}
@@ -1,36 +1,175 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
 	"slices"
+	"sync"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 )
 
 var (
 	// SupportedAuthenticatorsList is a list of supported authenticators.
-	SupportedAuthenticatorsList = []ArkAuth{
-		NewArkISPAuth(true),
-	}
+	//
+	// Deprecated: Use Authenticators, which includes the authenticators added with RegisterAuthenticator.
+	SupportedAuthenticatorsList = []ArkAuth{}
 
 	// SupportedAuthenticators is a map of supported authenticators.
-	SupportedAuthenticators = func() map[string]ArkAuth {
-		authenticators := make(map[string]ArkAuth)
-		for _, auth := range SupportedAuthenticatorsList {
-			authenticators[auth.AuthenticatorName()] = auth
-		}
-		return authenticators
-	}()
+	//
+	// Deprecated: Use GetAuthenticator, which includes the authenticators added with RegisterAuthenticator.
+	SupportedAuthenticators = map[string]ArkAuth{}
 
 	// SupportedAuthMethods is a list of supported authentication methods.
-	SupportedAuthMethods = func() []auth.ArkAuthMethod {
-		authMethods := make([]auth.ArkAuthMethod, 0)
-		for _, auth := range SupportedAuthenticatorsList {
-			for _, method := range auth.SupportedAuthMethods() {
-				if !slices.Contains(authMethods, method) {
-					authMethods = append(authMethods, method)
-				}
-			}
-		}
-		return authMethods
-	}()
+	//
+	// Deprecated: Use AuthMethods, which includes the methods of the authenticators added with RegisterAuthenticator.
+	SupportedAuthMethods = []auth.ArkAuthMethod{}
 )
+
+var (
+	authenticatorsMutex    sync.RWMutex
+	authenticatorFactories = map[string]func(cacheAuthentication bool) ArkAuth{}
+)
+
+func init() {
+	if err := RegisterAuthenticator(NewArkISPAuth(true)); err != nil {
+		panic(err)
+	}
+	if err := RegisterAuthenticatorFactory("isp", NewArkISPAuth); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterAuthenticator registers an authenticator, making it available to the CLI and to services.
+//
+// Registered authenticators get their own settings in "ark configure" and their
+// own credentials in "ark login", and are loaded by "ark exec". Services that list
+// the authenticator name in RequiredAuthenticatorNames or OptionalAuthenticatorNames
+// of their ArkServiceConfig are given the authenticator by ArkAPI.
+//
+// Authenticators implement ArkAuth by embedding ArkAuthBase, which handles token
+// caching and refresh, and implementing the remaining methods. Auth methods of
+// their own are registered with auth.RegisterArkAuthMethod of the models package.
+// Registration is expected to happen at init time, before the CLI or ArkAPI is set up.
+//
+// Parameters:
+//   - authenticator: The authenticator to register, with a unique name
+//
+// Returns an error if the authenticator has no name or if its name is already registered.
+//
+// Example:
+//
+//	type ArkSTSAuth struct {
+//		auth.ArkAuth
+//		*auth.ArkAuthBase
+//	}
+//
+//	func NewArkSTSAuth(cacheAuthentication bool) auth.ArkAuth {
+//		authenticator := &ArkSTSAuth{}
+//		authenticator.ArkAuthBase = auth.NewArkAuthBase(cacheAuthentication, "ArkSTSAuth", authenticator)
+//		return authenticator
+//	}
+//
+//	// AuthenticatorName, AuthenticatorHumanReadableName, SupportedAuthMethods, DefaultAuthMethod,
+//	// PerformAuthentication and PerformRefreshAuthentication are implemented by ArkSTSAuth,
+//	// while Authenticate, LoadAuthentication and IsAuthenticated are forwarded to ArkAuthBase.
+//
+//	func init() {
+//		if err := auth.RegisterAuthenticator(NewArkSTSAuth(true)); err != nil {
+//			panic(err)
+//		}
+//	}
+func RegisterAuthenticator(authenticator ArkAuth) error {
+	if authenticator == nil {
+		return errors.New("authenticator must not be nil")
+	}
+	name := authenticator.AuthenticatorName()
+	if name == "" {
+		return errors.New("authenticator name must not be empty")
+	}
+	authenticatorsMutex.Lock()
+	defer authenticatorsMutex.Unlock()
+	if _, exists := SupportedAuthenticators[name]; exists {
+		return fmt.Errorf("authenticator %s already registered", name)
+	}
+	SupportedAuthenticatorsList = append(SupportedAuthenticatorsList, authenticator)
+	SupportedAuthenticators[name] = authenticator
+	for _, method := range authenticator.SupportedAuthMethods() {
+		if !slices.Contains(SupportedAuthMethods, method) {
+			SupportedAuthMethods = append(SupportedAuthMethods, method)
+		}
+	}
+	return nil
+}
+
+// GetAuthenticator retrieves a registered authenticator by name.
+func GetAuthenticator(name string) (ArkAuth, error) {
+	authenticatorsMutex.RLock()
+	defer authenticatorsMutex.RUnlock()
+	if authenticator, exists := SupportedAuthenticators[name]; exists {
+		return authenticator, nil
+	}
+	return nil, fmt.Errorf("authenticator %s not registered", name)
+}
+
+// Authenticators returns the registered authenticators, in registration order.
+func Authenticators() []ArkAuth {
+	authenticatorsMutex.RLock()
+	defer authenticatorsMutex.RUnlock()
+	return slices.Clone(SupportedAuthenticatorsList)
+}
+
+// AuthMethods returns the authentication methods supported by the registered authenticators.
+func AuthMethods() []auth.ArkAuthMethod {
+	authenticatorsMutex.RLock()
+	defer authenticatorsMutex.RUnlock()
+	return slices.Clone(SupportedAuthMethods)
+}
+
+// RegisterAuthenticatorFactory registers the constructor of a registered authenticator.
+//
+// Registered authenticators are shared by the whole process. The factory is used by
+// NewAuthenticator to create instances of the authenticator of their own, such as for
+// the sessions of an ArkSessionManager, each holding the token of a different tenant.
+//
+// Parameters:
+//   - name: The name of the authenticator, as returned by its AuthenticatorName
+//   - factory: The constructor of the authenticator
+//
+// Returns an error if the name is empty, the factory is nil, or a factory is already registered for the name.
+//
+// Example:
+//
+//	func init() {
+//		if err := auth.RegisterAuthenticatorFactory("sts", NewArkSTSAuth); err != nil {
+//			panic(err)
+//		}
+//	}
+func RegisterAuthenticatorFactory(name string, factory func(cacheAuthentication bool) ArkAuth) error {
+	if name == "" {
+		return errors.New("authenticator name must not be empty")
+	}
+	if factory == nil {
+		return errors.New("authenticator factory must not be nil")
+	}
+	authenticatorsMutex.Lock()
+	defer authenticatorsMutex.Unlock()
+	if _, exists := authenticatorFactories[name]; exists {
+		return fmt.Errorf("authenticator factory %s already registered", name)
+	}
+	authenticatorFactories[name] = factory
+	return nil
+}
+
+// NewAuthenticator creates a new instance of an authenticator, with the factory registered for its name.
+//
+// Unlike GetAuthenticator, the instance is not shared, and holds a token of its own.
+func NewAuthenticator(name string, cacheAuthentication bool) (ArkAuth, error) {
+	authenticatorsMutex.RLock()
+	factory, exists := authenticatorFactories[name]
+	authenticatorsMutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("no factory registered for authenticator %s", name)
+	}
+	return factory(cacheAuthentication), nil
+}
@@ -1,8 +1,13 @@
 package auth
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth/identity"
@@ -23,7 +28,7 @@ const (
 )
 
 var (
-	ispAuthMethods               = []auth.ArkAuthMethod{auth.Identity, auth.IdentityServiceUser}
+	ispAuthMethods               = []auth.ArkAuthMethod{auth.Identity, auth.IdentityServiceUser, auth.Direct, auth.ClientCredentials, auth.PreIssuedToken, auth.OIDC}
 	ispDefaultAuthMethod         = auth.Identity
 	ispDefaultAuthMethodSettings = auth.IdentityArkAuthMethodSettings{}
 )
@@ -32,6 +37,10 @@ var (
 type ArkISPAuth struct {
 	ArkAuth
 	*ArkAuthBase
+	mfaProvider    identity.MFAProvider
+	clientOptions  []common.ArkClientOption
+	verifiers      map[string]*identity.ArkJWKSVerifier
+	verifiersMutex sync.Mutex
 }
 
 // NewArkISPAuth creates a new instance of ArkISPAuth.
@@ -43,6 +52,66 @@ func NewArkISPAuth(cacheAuthentication bool) ArkAuth {
 	return authInterface
 }
 
+// SetMFAProvider sets the provider answering the MFA challenges of the identity auth method.
+// It takes precedence over the TOTP seed and code file of the method settings.
+//
+// Example:
+//
+//	provider, err := identity.NewArkCallbackMFAProvider("sms", func(mechanism *identitymodels.Mechanism, _ string) (string, error) {
+//		return readCodeFromTestInbox()
+//	})
+//	ispAuth.(*auth.ArkISPAuth).SetMFAProvider(provider)
+func (a *ArkISPAuth) SetMFAProvider(provider identity.MFAProvider) {
+	a.mfaProvider = provider
+}
+
+// SetClientOptions sets the options of the clients of the services built from the authenticator.
+// They are applied after the transport settings of the profile, so that, for example, the
+// services of each tenant of a process can be given a rate limiter of their own.
+//
+// Example:
+//
+//	limiter := common.NewArkTokenBucketRateLimiter(&common.ArkRateLimiterConfig{
+//		Default: common.ArkRateLimit{RequestsPerSecond: 5, Burst: 10},
+//	})
+//	ispAuth.(*auth.ArkISPAuth).SetClientOptions(common.WithRateLimiter(limiter))
+func (a *ArkISPAuth) SetClientOptions(options ...common.ArkClientOption) {
+	a.clientOptions = options
+}
+
+// ClientOptions returns the options of the clients of the services built from the authenticator, see SetClientOptions.
+func (a *ArkISPAuth) ClientOptions() []common.ArkClientOption {
+	return a.clientOptions
+}
+
+// identityMFAProvider returns the MFA provider of the authenticator, or the one configured by the method settings, if any.
+//
+// The TOTP seed of the settings is resolved with common.ResolveArkSecret, so profiles can reference it rather than hold it.
+func (a *ArkISPAuth) identityMFAProvider(methodSettings *auth.IdentityArkAuthMethodSettings) (identity.MFAProvider, error) {
+	if a.mfaProvider != nil {
+		return a.mfaProvider, nil
+	}
+	if methodSettings.IdentityMFATOTPSeed != "" {
+		seed, err := common.ResolveArkSecret(context.Background(), methodSettings.IdentityMFATOTPSeed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the totp seed: %w", err)
+		}
+		provider, err := identity.NewArkTOTPMFAProvider(seed)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}
+	if methodSettings.IdentityMFACodeFile != "" {
+		provider, err := identity.NewArkFileMFAProvider(methodSettings.IdentityMFAMethod, methodSettings.IdentityMFACodeFile, 0)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}
+	return nil, nil
+}
+
 func (a *ArkISPAuth) performIdentityAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret, force bool) (*auth.ArkToken, error) {
 	methodSettings := authProfile.AuthMethodSettings.(*auth.IdentityArkAuthMethodSettings)
 	identityAuth, err := identity.NewArkIdentity(
@@ -60,6 +129,14 @@ func (a *ArkISPAuth) performIdentityAuthentication(profile *models.ArkProfile, a
 		a.Logger.Error("Failed to create identity security platform object: %v", err)
 		return nil, err
 	}
+	mfaProvider, err := a.identityMFAProvider(methodSettings)
+	if err != nil {
+		a.Logger.Error("Failed to create identity MFA provider: %v", err)
+		return nil, err
+	}
+	if mfaProvider != nil {
+		identityAuth.SetMFAProvider(mfaProvider)
+	}
 	err = identityAuth.AuthIdentity(profile, common.IsInteractive() && methodSettings.IdentityMFAInteractive, force)
 	if err != nil {
 		a.Logger.Error("Failed to authenticate to identity security platform: %v", err)
@@ -107,6 +184,14 @@ func (a *ArkISPAuth) performIdentityRefreshAuthentication(profile *models.ArkPro
 		a.Logger.Error("Failed to create identity security platform object: %v", err)
 		return nil, err
 	}
+	mfaProvider, err := a.identityMFAProvider(methodSettings)
+	if err != nil {
+		a.Logger.Error("Failed to create identity MFA provider: %v", err)
+		return nil, err
+	}
+	if mfaProvider != nil {
+		identityAuth.SetMFAProvider(mfaProvider)
+	}
 	err = identityAuth.RefreshAuthIdentity(profile, methodSettings.IdentityMFAInteractive, false)
 	if err != nil {
 		a.Logger.Error("Failed to refresh authentication to identity security platform: %v", err)
@@ -182,24 +267,355 @@ func (a *ArkISPAuth) performIdentityServiceUserAuthentication(profile *models.Ar
 	}, nil
 }
 
-// performAuthentication performs authentication to the ISP using the specified auth method.
-func (a *ArkISPAuth) performAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret, force bool) (*auth.ArkToken, error) {
+// directMethodSettings returns the direct auth method settings of the auth profile, stored either by value or by pointer.
+func directMethodSettings(authProfile *auth.ArkAuthProfile) (*auth.DirectArkAuthMethodSettings, error) {
+	switch settings := authProfile.AuthMethodSettings.(type) {
+	case *auth.DirectArkAuthMethodSettings:
+		return settings, nil
+	case auth.DirectArkAuthMethodSettings:
+		return &settings, nil
+	default:
+		return nil, errors.New("direct auth method requires direct auth method settings")
+	}
+}
+
+func (a *ArkISPAuth) directToken(identityAuth *identity.ArkIdentityDirect, authProfile *auth.ArkAuthProfile) *auth.ArkToken {
+	return &auth.ArkToken{
+		Token:        identityAuth.SessionToken(),
+		Username:     authProfile.Username,
+		Endpoint:     identityAuth.IdentityURL(),
+		TokenType:    auth.JWT,
+		AuthMethod:   auth.Direct,
+		ExpiresIn:    identityAuth.SessionExpiration(DefaultTokenLifetime),
+		RefreshToken: identityAuth.RefreshToken(),
+		Metadata: map[string]interface{}{
+			"env": string(commonmodels.GetDeployEnv()),
+		},
+	}
+}
+
+func (a *ArkISPAuth) performDirectAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret) (*auth.ArkToken, error) {
+	methodSettings, err := directMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	secretValue := ""
+	if secret != nil {
+		secretValue = secret.Secret
+	}
+	identityAuth, err := identity.NewArkIdentityDirect(
+		authProfile.Username,
+		secretValue,
+		methodSettings.Endpoint,
+		methodSettings.Interactive,
+		a.Logger,
+		profile,
+	)
+	if err != nil {
+		a.Logger.Error("Failed to create direct identity security platform object: %v", err)
+		return nil, err
+	}
+	err = identityAuth.AuthIdentity(common.IsInteractive())
+	if err != nil {
+		a.Logger.Error("Failed to authenticate directly to identity security platform: %v", err)
+		return nil, err
+	}
+	return a.directToken(identityAuth, authProfile), nil
+}
+
+// performDirectRefreshAuthentication refreshes a direct token with its refresh token.
+// Tokens issued without one are renewed by authenticating again with the secret reference of the auth profile.
+func (a *ArkISPAuth) performDirectRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error) {
+	methodSettings, err := directMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken := ""
+	if token != nil {
+		refreshToken = token.RefreshToken
+	}
+	secret := ""
+	if refreshToken == "" {
+		if authProfile.SecretRef == "" {
+			return nil, errors.New("no refresh token or secret reference to refresh the direct authentication with")
+		}
+		resolved, err := resolveSecret(authProfile, nil)
+		if err != nil {
+			return nil, err
+		}
+		secret = resolved.Secret
+	}
+	identityAuth, err := identity.NewArkIdentityDirect(
+		authProfile.Username,
+		secret,
+		methodSettings.Endpoint,
+		methodSettings.Interactive,
+		a.Logger,
+		profile,
+	)
+	if err != nil {
+		a.Logger.Error("Failed to create direct identity security platform object: %v", err)
+		return nil, err
+	}
+	err = identityAuth.RefreshAuthIdentity(refreshToken)
+	if err != nil {
+		a.Logger.Error("Failed to refresh direct authentication to identity security platform: %v", err)
+		return nil, err
+	}
+	return a.directToken(identityAuth, authProfile), nil
+}
+
+// clientCredentialsMethodSettings returns the client credentials auth method settings of the auth profile, stored either by value or by pointer.
+func clientCredentialsMethodSettings(authProfile *auth.ArkAuthProfile) (*auth.ClientCredentialsArkAuthMethodSettings, error) {
+	switch settings := authProfile.AuthMethodSettings.(type) {
+	case *auth.ClientCredentialsArkAuthMethodSettings:
+		return settings, nil
+	case auth.ClientCredentialsArkAuthMethodSettings:
+		return &settings, nil
+	default:
+		return nil, errors.New("client credentials auth method requires client credentials auth method settings")
+	}
+}
+
+func (a *ArkISPAuth) clientCredentialsToken(identityAuth *identity.ArkIdentityClientCredentials, authProfile *auth.ArkAuthProfile) *auth.ArkToken {
+	return &auth.ArkToken{
+		Token:        identityAuth.SessionToken(),
+		Username:     authProfile.Username,
+		Endpoint:     identityAuth.IdentityURL(),
+		TokenType:    auth.JWT,
+		AuthMethod:   auth.ClientCredentials,
+		ExpiresIn:    identityAuth.SessionExpiration(DefaultTokenLifetime),
+		RefreshToken: identityAuth.RefreshToken(),
+		Metadata: map[string]interface{}{
+			"env": string(commonmodels.GetDeployEnv()),
+		},
+	}
+}
+
+func (a *ArkISPAuth) performClientCredentialsAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret) (*auth.ArkToken, error) {
+	methodSettings, err := clientCredentialsMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret := ""
+	if secret != nil {
+		clientSecret = secret.Secret
+	}
+	identityAuth, err := identity.NewArkIdentityClientCredentials(authProfile.Username, clientSecret, methodSettings, a.Logger, profile)
+	if err != nil {
+		a.Logger.Error("Failed to create client credentials identity security platform object: %v", err)
+		return nil, err
+	}
+	err = identityAuth.AuthIdentity()
+	if err != nil {
+		a.Logger.Error("Failed to authenticate with client credentials to identity security platform: %v", err)
+		return nil, err
+	}
+	return a.clientCredentialsToken(identityAuth, authProfile), nil
+}
+
+func (a *ArkISPAuth) performClientCredentialsRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error) {
+	methodSettings, err := clientCredentialsMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	identityAuth, err := identity.NewArkIdentityClientCredentials(authProfile.Username, "", methodSettings, a.Logger, profile)
+	if err != nil {
+		a.Logger.Error("Failed to create client credentials identity security platform object: %v", err)
+		return nil, err
+	}
+	refreshToken := ""
+	if token != nil {
+		refreshToken = token.RefreshToken
+	}
+	err = identityAuth.RefreshAuthIdentity(refreshToken)
+	if err != nil {
+		a.Logger.Error("Failed to refresh client credentials authentication to identity security platform: %v", err)
+		return nil, err
+	}
+	return a.clientCredentialsToken(identityAuth, authProfile), nil
+}
+
+// preIssuedTokenMethodSettings returns the pre-issued token auth method settings of the auth profile, stored either by value or by pointer.
+func preIssuedTokenMethodSettings(authProfile *auth.ArkAuthProfile) (*auth.PreIssuedTokenArkAuthMethodSettings, error) {
+	switch settings := authProfile.AuthMethodSettings.(type) {
+	case *auth.PreIssuedTokenArkAuthMethodSettings:
+		return settings, nil
+	case auth.PreIssuedTokenArkAuthMethodSettings:
+		return &settings, nil
+	default:
+		return nil, errors.New("pre-issued token auth method requires pre-issued token auth method settings")
+	}
+}
+
+// readPreIssuedToken reads the token from the first source set in the settings.
+func readPreIssuedToken(methodSettings *auth.PreIssuedTokenArkAuthMethodSettings) (string, error) {
+	var token string
+	switch {
+	case methodSettings.TokenCallback != nil:
+		callbackToken, err := methodSettings.TokenCallback()
+		if err != nil {
+			return "", fmt.Errorf("token callback failed: %w", err)
+		}
+		token = callbackToken
+	case methodSettings.TokenEnvVar != "":
+		token = os.Getenv(methodSettings.TokenEnvVar)
+		if token == "" {
+			return "", fmt.Errorf("environment variable %s holds no token", methodSettings.TokenEnvVar)
+		}
+	case methodSettings.TokenFile != "":
+		data, err := os.ReadFile(methodSettings.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		token = string(data)
+	default:
+		return "", errors.New("pre-issued token auth method requires a token callback, environment variable or file")
+	}
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	if token == "" {
+		return "", errors.New("the pre-issued token is empty")
+	}
+	return token, nil
+}
+
+// performPreIssuedTokenAuthentication loads a token obtained outside of the SDK, such as from a token broker.
+// It is called on refresh as well, reading the token again from its source.
+func (a *ArkISPAuth) performPreIssuedTokenAuthentication(authProfile *auth.ArkAuthProfile) (*auth.ArkToken, error) {
+	methodSettings, err := preIssuedTokenMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	token, err := readPreIssuedToken(methodSettings)
+	if err != nil {
+		a.Logger.Error("Failed to load pre-issued token: %v", err)
+		return nil, err
+	}
+	expiresIn := identity.TokenExpiration(token, 0, DefaultTokenLifetime)
+	if time.Time(expiresIn).Before(time.Now()) {
+		return nil, errors.New("the pre-issued token is expired")
+	}
+	username := authProfile.Username
+	if username == "" {
+		username = identity.TokenUsername(token)
+	}
+	return &auth.ArkToken{
+		Token:      token,
+		Username:   username,
+		TokenType:  auth.JWT,
+		AuthMethod: auth.PreIssuedToken,
+		ExpiresIn:  expiresIn,
+		Metadata: map[string]interface{}{
+			"env": string(commonmodels.GetDeployEnv()),
+		},
+	}, nil
+}
+
+// oidcMethodSettings returns the OIDC auth method settings of the auth profile, stored either by value or by pointer.
+func oidcMethodSettings(authProfile *auth.ArkAuthProfile) (*auth.OIDCArkAuthMethodSettings, error) {
+	switch settings := authProfile.AuthMethodSettings.(type) {
+	case *auth.OIDCArkAuthMethodSettings:
+		return settings, nil
+	case auth.OIDCArkAuthMethodSettings:
+		return &settings, nil
+	default:
+		return nil, errors.New("oidc auth method requires oidc auth method settings")
+	}
+}
+
+func (a *ArkISPAuth) oidcToken(identityAuth *identity.ArkIdentityOIDC) *auth.ArkToken {
+	return &auth.ArkToken{
+		Token:        identityAuth.SessionToken(),
+		Username:     identityAuth.Username(),
+		Endpoint:     identityAuth.IdentityURL(),
+		TokenType:    auth.JWT,
+		AuthMethod:   auth.OIDC,
+		ExpiresIn:    identityAuth.SessionExpiration(DefaultTokenLifetime),
+		RefreshToken: identityAuth.RefreshToken(),
+		Metadata: map[string]interface{}{
+			"env": string(commonmodels.GetDeployEnv()),
+		},
+	}
+}
+
+func (a *ArkISPAuth) performOIDCAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile) (*auth.ArkToken, error) {
+	methodSettings, err := oidcMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	identityAuth, err := identity.NewArkIdentityOIDC(authProfile.Username, methodSettings, a.Logger, profile)
+	if err != nil {
+		a.Logger.Error("Failed to create oidc identity security platform object: %v", err)
+		return nil, err
+	}
+	err = identityAuth.AuthIdentity(common.IsInteractive())
+	if err != nil {
+		a.Logger.Error("Failed to log in with oidc to identity security platform: %v", err)
+		return nil, err
+	}
+	return a.oidcToken(identityAuth), nil
+}
+
+func (a *ArkISPAuth) performOIDCRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error) {
+	methodSettings, err := oidcMethodSettings(authProfile)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.RefreshToken == "" {
+		return nil, errors.New("no refresh token to refresh the oidc authentication with")
+	}
+	username := authProfile.Username
+	if username == "" {
+		username = token.Username
+	}
+	identityAuth, err := identity.NewArkIdentityOIDC(username, methodSettings, a.Logger, profile)
+	if err != nil {
+		a.Logger.Error("Failed to create oidc identity security platform object: %v", err)
+		return nil, err
+	}
+	err = identityAuth.RefreshAuthIdentity(token.RefreshToken)
+	if err != nil {
+		a.Logger.Error("Failed to refresh oidc authentication to identity security platform: %v", err)
+		return nil, err
+	}
+	return a.oidcToken(identityAuth), nil
+}
+
+// PerformAuthentication performs authentication to the ISP using the specified auth method.
+func (a *ArkISPAuth) PerformAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, secret *auth.ArkSecret, force bool) (*auth.ArkToken, error) {
 	a.Logger.Info("Performing authentication to ISP")
 	switch authProfile.AuthMethod {
 	case auth.Identity, auth.Default:
 		return a.performIdentityAuthentication(profile, authProfile, secret, force)
 	case auth.IdentityServiceUser:
 		return a.performIdentityServiceUserAuthentication(profile, authProfile, secret, force)
+	case auth.Direct:
+		return a.performDirectAuthentication(profile, authProfile, secret)
+	case auth.ClientCredentials:
+		return a.performClientCredentialsAuthentication(profile, authProfile, secret)
+	case auth.PreIssuedToken:
+		return a.performPreIssuedTokenAuthentication(authProfile)
+	case auth.OIDC:
+		return a.performOIDCAuthentication(profile, authProfile)
 	default:
 		return nil, errors.New("given auth method is not supported")
 	}
 }
 
 // PerformRefreshAuthentication performs refresh authentication to the ISP.
-func (a *ArkISPAuth) performRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error) {
+func (a *ArkISPAuth) PerformRefreshAuthentication(profile *models.ArkProfile, authProfile *auth.ArkAuthProfile, token *auth.ArkToken) (*auth.ArkToken, error) {
 	a.Logger.Info("Performing refresh authentication to ISP")
-	if authProfile.AuthMethod == auth.Identity || authProfile.AuthMethod == auth.Default {
+	switch authProfile.AuthMethod {
+	case auth.Identity, auth.Default:
 		return a.performIdentityRefreshAuthentication(profile, authProfile, token)
+	case auth.Direct:
+		return a.performDirectRefreshAuthentication(profile, authProfile, token)
+	case auth.ClientCredentials:
+		return a.performClientCredentialsRefreshAuthentication(profile, authProfile, token)
+	case auth.PreIssuedToken:
+		return a.performPreIssuedTokenAuthentication(authProfile)
+	case auth.OIDC:
+		return a.performOIDCRefreshAuthentication(profile, authProfile, token)
 	}
 	return token, nil
 }
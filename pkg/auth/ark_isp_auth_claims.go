@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/auth/identity"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+// TokenClaims returns the claims of the token of the authenticator.
+//
+// When verify is true, the signature of the token is verified against the JWKS of the identity
+// tenant that issued it, and its issuer and expiration are checked. The issuer must be the identity
+// URL the token was obtained from, or a host of the platform domains.
+//
+// Example:
+//
+//	claims, err := ispAuth.(*auth.ArkISPAuth).TokenClaims(true)
+//	if err != nil {
+//		// handle error
+//	}
+//	fmt.Printf("Tenant %s of %s\n", claims.TenantID, claims.Username)
+func (a *ArkISPAuth) TokenClaims(verify bool) (*auth.ArkTokenClaims, error) {
+	if a.Token == nil {
+		return nil, errors.New("authenticator is not authenticated")
+	}
+	return a.VerifyTokenClaims(a.Token, verify)
+}
+
+// VerifyTokenClaims returns the claims of the given token, verifying its signature when verify is true, as TokenClaims does.
+func (a *ArkISPAuth) VerifyTokenClaims(token *auth.ArkToken, verify bool) (*auth.ArkTokenClaims, error) {
+	claims, err := token.Claims()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+	if !verify {
+		return claims, nil
+	}
+	if !trustedIssuer(claims.Issuer, token.Endpoint) {
+		return nil, fmt.Errorf("token issuer [%s] is not trusted", claims.Issuer)
+	}
+	verifier, err := a.jwksVerifier(claims.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	verifiedClaims, err := verifier.Verify(token.Token)
+	if err != nil {
+		return nil, err
+	}
+	claims = auth.NewArkTokenClaims(verifiedClaims)
+	claims.Verified = true
+	return claims, nil
+}
+
+// jwksVerifier returns the verifier of the tokens of an issuer, which caches the JWKS of the issuer.
+func (a *ArkISPAuth) jwksVerifier(issuer string) (*identity.ArkJWKSVerifier, error) {
+	a.verifiersMutex.Lock()
+	defer a.verifiersMutex.Unlock()
+	if verifier, ok := a.verifiers[issuer]; ok {
+		return verifier, nil
+	}
+	var options []common.ArkClientOption
+	if a.ActiveProfile != nil && a.ActiveProfile.TransportConfig != nil {
+		options = append(options, common.WithTransportConfig(a.ActiveProfile.TransportConfig))
+	}
+	verifier, err := identity.NewArkJWKSVerifier(issuer, "", a.Logger, options...)
+	if err != nil {
+		return nil, err
+	}
+	if a.verifiers == nil {
+		a.verifiers = make(map[string]*identity.ArkJWKSVerifier)
+	}
+	a.verifiers[issuer] = verifier
+	return verifier, nil
+}
+
+// trustedIssuer returns whether tokens of the issuer can be verified against its JWKS.
+// The issuer must be served over https, from the host of the endpoint the token was obtained from or a platform host.
+func trustedIssuer(issuer string, endpoint string) bool {
+	issuerURL, err := url.Parse(issuer)
+	if err != nil || issuerURL.Scheme != "https" || issuerURL.Hostname() == "" {
+		return false
+	}
+	host := strings.ToLower(issuerURL.Hostname())
+	if endpoint != "" {
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		if endpointURL, err := url.Parse(endpoint); err == nil && strings.EqualFold(endpointURL.Hostname(), host) {
+			return true
+		}
+	}
+	for _, domains := range []map[commonmodels.AwsEnv]string{commonmodels.RootDomain, commonmodels.IdentityEnvUrls} {
+		for _, domain := range domains {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
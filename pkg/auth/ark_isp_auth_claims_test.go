@@ -0,0 +1,225 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksTestServer is an identity issuer serving a discovery document and the JWKS of a single RSA key.
+type jwksTestServer struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey
+	caFile     string
+	keyFetches atomic.Int32
+}
+
+func newJWKSTestServer(t *testing.T) *jwksTestServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	s := &jwksTestServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"jwks_uri": s.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		s.keyFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	s.server = httptest.NewTLSServer(mux)
+	t.Cleanup(s.server.Close)
+	s.caFile = filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw})
+	if err := os.WriteFile(s.caFile, caPEM, 0600); err != nil {
+		t.Fatalf("failed to write ca: %v", err)
+	}
+	return s
+}
+
+func (s *jwksTestServer) issuer() string {
+	return s.server.URL + "/app/"
+}
+
+func (s *jwksTestServer) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func (s *jwksTestServer) claims(issuer string, expiresIn time.Duration) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":         issuer,
+		"sub":         "user-id",
+		"unique_name": "user@cyberark.cloud.12345",
+		"tenant_id":   "ABC1234",
+		"subdomain":   "tenant",
+		"scope":       "all read",
+		"iat":         time.Now().Add(-time.Minute).Unix(),
+		"exp":         time.Now().Add(expiresIn).Unix(),
+	}
+}
+
+func newClaimsTestAuth(s *jwksTestServer) *auth.ArkISPAuth {
+	ispAuth := auth.NewArkISPAuth(false).(*auth.ArkISPAuth)
+	ispAuth.ActiveProfile = &models.ArkProfile{
+		ProfileName:     "test",
+		TransportConfig: &common.ArkTransportConfig{CABundleFiles: []string{s.caFile}},
+	}
+	return ispAuth
+}
+
+func TestArkISPAuth_VerifyTokenClaims(t *testing.T) {
+	s := newJWKSTestServer(t)
+	endpoint := strings.TrimPrefix(s.server.URL, "https://")
+
+	tests := []struct {
+		name          string
+		token         func() *authmodels.ArkToken
+		verify        bool
+		expectedError string
+		validateFunc  func(t *testing.T, claims *authmodels.ArkTokenClaims)
+	}{
+		{
+			name: "success_unverified_claims",
+			token: func() *authmodels.ArkToken {
+				return &authmodels.ArkToken{Token: s.sign(t, "unknown", s.claims("https://other.example.com", time.Hour))}
+			},
+			validateFunc: func(t *testing.T, claims *authmodels.ArkTokenClaims) {
+				if claims.Verified {
+					t.Error("Expected claims not to be verified")
+				}
+				if claims.TenantID != "ABC1234" || claims.Username != "user@cyberark.cloud.12345" {
+					t.Errorf("Unexpected claims %+v", claims)
+				}
+			},
+		},
+		{
+			name:   "success_verified_claims",
+			verify: true,
+			token: func() *authmodels.ArkToken {
+				return &authmodels.ArkToken{Token: s.sign(t, "key-1", s.claims(s.issuer(), time.Hour)), Endpoint: endpoint}
+			},
+			validateFunc: func(t *testing.T, claims *authmodels.ArkTokenClaims) {
+				if !claims.Verified {
+					t.Error("Expected claims to be verified")
+				}
+				if claims.Subdomain != "tenant" || len(claims.Scopes) != 2 {
+					t.Errorf("Unexpected claims %+v", claims)
+				}
+			},
+		},
+		{
+			name:          "error_untrusted_issuer",
+			verify:        true,
+			expectedError: "is not trusted",
+			token: func() *authmodels.ArkToken {
+				return &authmodels.ArkToken{Token: s.sign(t, "key-1", s.claims("https://attacker.example.com/app/", time.Hour)), Endpoint: endpoint}
+			},
+		},
+		{
+			name:          "error_expired_token",
+			verify:        true,
+			expectedError: "expired",
+			token: func() *authmodels.ArkToken {
+				return &authmodels.ArkToken{Token: s.sign(t, "key-1", s.claims(s.issuer(), -time.Hour)), Endpoint: endpoint}
+			},
+		},
+		{
+			name:          "error_unknown_key",
+			verify:        true,
+			expectedError: "no key [other-key]",
+			token: func() *authmodels.ArkToken {
+				return &authmodels.ArkToken{Token: s.sign(t, "other-key", s.claims(s.issuer(), time.Hour)), Endpoint: endpoint}
+			},
+		},
+		{
+			name:          "error_tampered_token",
+			verify:        true,
+			expectedError: "failed to verify token",
+			token: func() *authmodels.ArkToken {
+				parts := strings.Split(s.sign(t, "key-1", s.claims(s.issuer(), time.Hour)), ".")
+				tampered := s.claims(s.issuer(), time.Hour)
+				tampered["tenant_id"] = "OTHER"
+				payload, _ := json.Marshal(tampered)
+				parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+				return &authmodels.ArkToken{Token: strings.Join(parts, "."), Endpoint: endpoint}
+			},
+		},
+		{
+			name:          "error_invalid_token",
+			expectedError: "failed to parse token claims",
+			token: func() *authmodels.ArkToken {
+				return &authmodels.ArkToken{Token: "not-a-jwt"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := newClaimsTestAuth(s).VerifyTokenClaims(tt.token(), tt.verify)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.validateFunc != nil {
+				tt.validateFunc(t, claims)
+			}
+		})
+	}
+}
+
+func TestArkISPAuth_TokenClaims_CachesKeys(t *testing.T) {
+	s := newJWKSTestServer(t)
+	ispAuth := newClaimsTestAuth(s)
+	if _, err := ispAuth.TokenClaims(false); err == nil {
+		t.Fatal("Expected an error when not authenticated")
+	}
+	ispAuth.Token = &authmodels.ArkToken{
+		Token:    s.sign(t, "key-1", s.claims(s.issuer(), time.Hour)),
+		Endpoint: s.server.URL,
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := ispAuth.TokenClaims(true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if fetches := s.keyFetches.Load(); fetches != 1 {
+		t.Errorf("Expected the jwks to be fetched once, got %d", fetches)
+	}
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+func TestArkISPAuth_PerformDirectRefreshAuthentication(t *testing.T) {
+	t.Setenv("ARK_DIRECT_TEST_SECRET", "secret")
+	var grants []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		grants = append(grants, r.PostForm.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "renewed-token", "expires_in": 600})
+	}))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tests := []struct {
+		name          string
+		refreshToken  string
+		secretRef     string
+		expectedGrant string
+		expectedError string
+	}{
+		{name: "success_refresh_token", refreshToken: "refresh-token", expectedGrant: "refresh_token"},
+		{name: "success_authenticates_again_with_secret_ref", secretRef: "env:ARK_DIRECT_TEST_SECRET", expectedGrant: "client_credentials"},
+		{name: "error_no_refresh_token_or_secret_ref", expectedError: "no refresh token or secret reference"},
+		{name: "error_secret_ref_unresolved", secretRef: "env:ARK_DIRECT_TEST_MISSING", expectedError: "is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants = nil
+			profile := &models.ArkProfile{
+				ProfileName:     "direct-refresh",
+				TransportConfig: &common.ArkTransportConfig{CABundleFiles: []string{caFile}},
+			}
+			authProfile := &authmodels.ArkAuthProfile{
+				Username:           "client@cyberark.cloud.12345",
+				SecretRef:          tt.secretRef,
+				AuthMethod:         authmodels.Direct,
+				AuthMethodSettings: &authmodels.DirectArkAuthMethodSettings{Endpoint: server.URL + "/OAuth2/Token/app"},
+			}
+			ispAuth := NewArkISPAuth(false).(*ArkISPAuth)
+
+			token, err := ispAuth.performDirectRefreshAuthentication(profile, authProfile, &authmodels.ArkToken{Token: "expired-token", RefreshToken: tt.refreshToken})
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if token.Token != "renewed-token" {
+				t.Errorf("Expected the renewed token, got %s", token.Token)
+			}
+			if len(grants) != 1 || grants[0] != tt.expectedGrant {
+				t.Errorf("Expected a %s grant, got %v", tt.expectedGrant, grants)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/auth/identity"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+func TestArkISPAuth_IdentityMFAProvider_TOTPSeed(t *testing.T) {
+	const seed = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	t.Setenv("ARK_TEST_TOTP_SEED", seed)
+	expected, err := identity.NewArkTOTPMFAProvider(seed)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tests := []struct {
+		name          string
+		totpSeed      string
+		expectedError string
+	}{
+		{name: "success_raw_seed", totpSeed: seed},
+		{name: "success_env_reference", totpSeed: "env:ARK_TEST_TOTP_SEED"},
+		{name: "error_unresolved_reference", totpSeed: "env:ARK_TEST_MISSING_TOTP_SEED", expectedError: "failed to resolve the totp seed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ispAuth := NewArkISPAuth(false).(*ArkISPAuth)
+			provider, err := ispAuth.identityMFAProvider(&authmodels.IdentityArkAuthMethodSettings{IdentityMFATOTPSeed: tt.totpSeed})
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			totpProvider, ok := provider.(*identity.ArkTOTPMFAProvider)
+			if !ok {
+				t.Fatalf("Expected a totp provider, got %T", provider)
+			}
+			now := time.Unix(1234567890, 0)
+			if totpProvider.Code(now) != expected.Code(now) {
+				t.Errorf("Expected the codes of the seed, got %s", totpProvider.Code(now))
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common/keyring"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+// ArkTokenCache is the cache ArkAuthBase stores the tokens of authenticators in.
+//
+// Tokens are identified by the profile, the namespace, which is the name of the authenticator, and the
+// postfix resolved from the auth profile with ResolveCachePostfix. Implementations discard tokens which
+// are expired and cannot be refreshed anymore when loading them, and return nil when no token is cached.
+//
+// The SDK ships with NewArkKeyringTokenCache, which caches tokens in the OS keyring or the basic keyring,
+// NewArkMemoryTokenCache, NewArkFileTokenCache and NewArkStoreTokenCache, which caches tokens in an
+// external store such as Redis, so that several processes share the same tokens.
+type ArkTokenCache interface {
+	// LoadToken loads the token of the profile, namespace and postfix, or returns nil if there is none.
+	LoadToken(profile *models.ArkProfile, namespace string, postfix string) (*auth.ArkToken, error)
+	// SaveToken saves the token of the profile, namespace and postfix.
+	SaveToken(profile *models.ArkProfile, token *auth.ArkToken, namespace string, postfix string) error
+	// RemoveToken removes the token of the profile, namespace and postfix, if there is one.
+	RemoveToken(profile *models.ArkProfile, namespace string, postfix string) error
+}
+
+// ArkTokenStore is a key-value store tokens can be cached in, such as Redis or memcached.
+//
+// Get returns nil without an error when the key does not exist. Values are the serialized tokens,
+// including their refresh tokens, so stores shared between processes should be access controlled.
+// See NewArkStoreTokenCache.
+type ArkTokenStore interface {
+	// Get returns the value of the key, or nil if it does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set sets the value of the key.
+	Set(ctx context.Context, key string, value []byte) error
+	// Delete deletes the key, if it exists.
+	Delete(ctx context.Context, key string) error
+}
+
+// ArkKeyringTokenCache is an ArkTokenCache which caches tokens with keyring.ArkKeyring.
+//
+// Depending on how it was created, tokens are stored in the OS keyring with a fallback to the
+// basic keyring, in memory, in an encrypted file, or in an external store. Expired tokens are
+// discarded as described in keyring.ArkKeyring.LoadToken.
+type ArkKeyringTokenCache struct {
+	impl keyring.ArkKeyringImpl
+}
+
+// NewArkKeyringTokenCache creates a token cache which stores tokens in the OS keyring, falling back to the basic keyring.
+//
+// This is the token cache of authenticators created with caching enabled.
+//
+// Example:
+//
+//	cache := auth.NewArkKeyringTokenCache()
+func NewArkKeyringTokenCache() *ArkKeyringTokenCache {
+	return &ArkKeyringTokenCache{}
+}
+
+// NewArkMemoryTokenCache creates a token cache which keeps tokens in the memory of the process.
+//
+// Tokens are never written to disk, and are lost when the process exits. A single cache can be
+// shared by several authenticators.
+//
+// Example:
+//
+//	ispAuth := auth.NewArkISPAuth(false)
+//	ispAuth.(*auth.ArkISPAuth).SetTokenCache(auth.NewArkMemoryTokenCache())
+func NewArkMemoryTokenCache() *ArkKeyringTokenCache {
+	return &ArkKeyringTokenCache{impl: keyring.NewArkMemoryKeyring()}
+}
+
+// NewArkFileTokenCache creates a token cache which stores tokens in an encrypted file in the given folder.
+//
+// The folder holds a basic keyring, see keyring.ArkBasicKeyring, whose key is derived from the given
+// passphrase, or is a random key stored in the folder when the passphrase is empty. Processes sharing
+// the folder and passphrase share the tokens.
+//
+// Parameters:
+//   - folder: The folder to store the tokens in, created if it does not exist
+//   - passphrase: The passphrase the key of the file is derived from, optional
+//
+// Returns the token cache, or an error if the folder cannot be created.
+//
+// Example:
+//
+//	cache, err := auth.NewArkFileTokenCache("/var/lib/myapp/tokens", os.Getenv("TOKENS_PASSPHRASE"))
+//	if err != nil {
+//	    // handle error
+//	}
+func NewArkFileTokenCache(folder string, passphrase string) (*ArkKeyringTokenCache, error) {
+	if folder == "" {
+		return nil, errors.New("folder of the file token cache must be given")
+	}
+	basicKeyring := keyring.NewArkBasicKeyringAtFolder(folder, passphrase)
+	if basicKeyring == nil {
+		return nil, fmt.Errorf("failed to create token cache folder [%s]", folder)
+	}
+	return &ArkKeyringTokenCache{impl: basicKeyring}, nil
+}
+
+// NewArkStoreTokenCache creates a token cache which stores tokens in an external key-value store.
+//
+// Tokens are stored under keys of the form "ark_sdk_golang:<profile>:<namespace>-<postfix>", so
+// processes using the same profile and auth profile share the same tokens, and a token refreshed
+// by one of them is used by all the others.
+//
+// Parameters:
+//   - store: The store to cache the tokens in
+//
+// Example:
+//
+//	cache := auth.NewArkStoreTokenCache(myRedisStore)
+func NewArkStoreTokenCache(store ArkTokenStore) *ArkKeyringTokenCache {
+	return &ArkKeyringTokenCache{impl: &tokenStoreKeyring{store: store}}
+}
+
+func (c *ArkKeyringTokenCache) keyring(namespace string) *keyring.ArkKeyring {
+	if c.impl == nil {
+		return keyring.NewArkKeyring(namespace)
+	}
+	return keyring.NewArkKeyringWithImpl(namespace, c.impl)
+}
+
+// LoadToken loads the token of the profile, namespace and postfix, or returns nil if there is none.
+func (c *ArkKeyringTokenCache) LoadToken(profile *models.ArkProfile, namespace string, postfix string) (*auth.ArkToken, error) {
+	return c.keyring(namespace).LoadToken(profile, postfix, false)
+}
+
+// SaveToken saves the token of the profile, namespace and postfix.
+func (c *ArkKeyringTokenCache) SaveToken(profile *models.ArkProfile, token *auth.ArkToken, namespace string, postfix string) error {
+	return c.keyring(namespace).SaveToken(profile, token, postfix, false)
+}
+
+// RemoveToken removes the token of the profile, namespace and postfix, if there is one.
+func (c *ArkKeyringTokenCache) RemoveToken(profile *models.ArkProfile, namespace string, postfix string) error {
+	return c.keyring(namespace).RemoveToken(profile, postfix, false)
+}
+
+// arkKeyringTokenCache adapts a keyring.ArkKeyring set in the deprecated CacheKeyring field of ArkAuthBase,
+// whose service name is used instead of the namespace.
+type arkKeyringTokenCache struct {
+	keyring *keyring.ArkKeyring
+}
+
+func (c *arkKeyringTokenCache) LoadToken(profile *models.ArkProfile, namespace string, postfix string) (*auth.ArkToken, error) {
+	return c.keyring.LoadToken(profile, postfix, false)
+}
+
+func (c *arkKeyringTokenCache) SaveToken(profile *models.ArkProfile, token *auth.ArkToken, namespace string, postfix string) error {
+	return c.keyring.SaveToken(profile, token, postfix, false)
+}
+
+func (c *arkKeyringTokenCache) RemoveToken(profile *models.ArkProfile, namespace string, postfix string) error {
+	return c.keyring.RemoveToken(profile, postfix, false)
+}
+
+// tokenStoreKeyring is a keyring.ArkKeyringImpl storing passwords in an ArkTokenStore.
+type tokenStoreKeyring struct {
+	store ArkTokenStore
+}
+
+func (s *tokenStoreKeyring) key(serviceName string, username string) string {
+	return fmt.Sprintf("ark_sdk_golang:%s:%s", serviceName, username)
+}
+
+func (s *tokenStoreKeyring) SetPassword(serviceName string, username string, password string) error {
+	return s.store.Set(context.Background(), s.key(serviceName, username), []byte(password))
+}
+
+func (s *tokenStoreKeyring) GetPassword(serviceName string, username string) (string, error) {
+	value, err := s.store.Get(context.Background(), s.key(serviceName, username))
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func (s *tokenStoreKeyring) DeletePassword(serviceName string, username string) error {
+	return s.store.Delete(context.Background(), s.key(serviceName, username))
+}
+
+func (s *tokenStoreKeyring) ClearAllPasswords() error {
+	return errors.New("token stores cannot be cleared, delete their keys instead")
+}
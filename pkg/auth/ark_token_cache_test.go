@@ -0,0 +1,209 @@
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/actions/testutils"
+	"github.com/cyberark/ark-sdk-golang/pkg/auth"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+// fakeTokenStore is a local fake of an external key-value store, such as Redis.
+type fakeTokenStore struct {
+	mutex  sync.Mutex
+	values map[string][]byte
+}
+
+func newFakeTokenStore() *fakeTokenStore {
+	return &fakeTokenStore{values: map[string][]byte{}}
+}
+
+func (s *fakeTokenStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.values[key], nil
+}
+
+func (s *fakeTokenStore) Set(ctx context.Context, key string, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *fakeTokenStore) Delete(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.values, key)
+	return nil
+}
+
+// newTokenCacheTestAuthenticator returns a mock authenticator caching its tokens in the given cache.
+func newTokenCacheTestAuthenticator(tokenCache auth.ArkTokenCache) (*testutils.MockAuthenticator, *models.ArkProfile) {
+	authenticator := testutils.NewMockAuthenticator("mock", authmodels.Other)
+	authenticator.SetTokenCache(tokenCache)
+	profile := &models.ArkProfile{
+		ProfileName: "mock-profile",
+		AuthProfiles: map[string]*authmodels.ArkAuthProfile{
+			"mock": {Username: "user", AuthMethod: authmodels.Other},
+		},
+	}
+	return authenticator, profile
+}
+
+func TestArkTokenCache_SharedBetweenAuthenticators(t *testing.T) {
+	fileCache, err := auth.NewArkFileTokenCache(t.TempDir(), "passphrase")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tests := []struct {
+		name       string
+		tokenCache auth.ArkTokenCache
+	}{
+		{
+			name:       "success_memory",
+			tokenCache: auth.NewArkMemoryTokenCache(),
+		},
+		{
+			name:       "success_file",
+			tokenCache: fileCache,
+		},
+		{
+			name:       "success_store",
+			tokenCache: auth.NewArkStoreTokenCache(newFakeTokenStore()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, profile := newTokenCacheTestAuthenticator(tt.tokenCache)
+			token, err := first.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: "secret"}, false, false)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			second, _ := newTokenCacheTestAuthenticator(tt.tokenCache)
+			cached, err := second.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: "secret"}, false, false)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if second.AuthenticateCount != 0 {
+				t.Errorf("Expected the token to be loaded from the cache, got %d authentications", second.AuthenticateCount)
+			}
+			if cached.Token != token.Token || cached.RefreshToken != token.RefreshToken {
+				t.Errorf("Expected the cached token, got %+v", cached)
+			}
+			if !second.IsAuthenticated(profile) {
+				t.Error("Expected the authenticator to be authenticated from the cache")
+			}
+
+			if err := tt.tokenCache.RemoveToken(profile, "mock", "user"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			removed, err := tt.tokenCache.LoadToken(profile, "mock", "user")
+			if err != nil || removed != nil {
+				t.Errorf("Expected the token to be removed, got %v, %v", removed, err)
+			}
+		})
+	}
+}
+
+func TestArkTokenCache_Namespaces(t *testing.T) {
+	tokenCache := auth.NewArkMemoryTokenCache()
+	profile := &models.ArkProfile{ProfileName: "mock-profile"}
+	token := &authmodels.ArkToken{Token: "token", TokenType: authmodels.Token, ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour))}
+	if err := tokenCache.SaveToken(profile, token, "first", "user"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded, err := tokenCache.LoadToken(profile, "second", "user"); err != nil || loaded != nil {
+		t.Errorf("Expected no token in another namespace, got %v, %v", loaded, err)
+	}
+	if loaded, err := tokenCache.LoadToken(&models.ArkProfile{ProfileName: "other"}, "first", "user"); err != nil || loaded != nil {
+		t.Errorf("Expected no token of another profile, got %v, %v", loaded, err)
+	}
+	if loaded, err := tokenCache.LoadToken(profile, "first", "user"); err != nil || loaded == nil || loaded.Token != "token" {
+		t.Errorf("Expected the token, got %v, %v", loaded, err)
+	}
+}
+
+func TestArkStoreTokenCache(t *testing.T) {
+	store := newFakeTokenStore()
+	tokenCache := auth.NewArkStoreTokenCache(store)
+	profile := &models.ArkProfile{ProfileName: "mock-profile"}
+
+	expired := &authmodels.ArkToken{Token: "token", TokenType: authmodels.Token, ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(-time.Hour))}
+	if err := tokenCache.SaveToken(profile, expired, "mock", "user"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	value, ok := store.values["ark_sdk_golang:mock-profile:mock-user"]
+	if !ok || !strings.Contains(string(value), `"token":"token"`) {
+		t.Fatalf("Expected the token to be stored under its key, got %v", store.values)
+	}
+
+	loaded, err := tokenCache.LoadToken(profile, "mock", "user")
+	if err != nil || loaded != nil {
+		t.Errorf("Expected the expired token to be discarded, got %v, %v", loaded, err)
+	}
+	if len(store.values) != 0 {
+		t.Errorf("Expected the expired token to be deleted from the store, got %v", store.values)
+	}
+}
+
+func TestNewArkFileTokenCache(t *testing.T) {
+	folder := t.TempDir()
+	tokenCache, err := auth.NewArkFileTokenCache(folder, "passphrase")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	profile := &models.ArkProfile{ProfileName: "mock-profile"}
+	token := &authmodels.ArkToken{Token: "token", TokenType: authmodels.Token, ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour))}
+	if err := tokenCache.SaveToken(profile, token, "mock", "user"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	reopened, err := auth.NewArkFileTokenCache(folder, "passphrase")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded, err := reopened.LoadToken(profile, "mock", "user"); err != nil || loaded == nil || loaded.Token != "token" {
+		t.Errorf("Expected the token to be read from the file, got %v, %v", loaded, err)
+	}
+
+	wrongPassphrase, err := auth.NewArkFileTokenCache(folder, "wrong")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := wrongPassphrase.LoadToken(profile, "mock", "user"); err == nil {
+		t.Error("Expected an error with the wrong passphrase")
+	}
+
+	if _, err := auth.NewArkFileTokenCache("", ""); err == nil {
+		t.Error("Expected an error without a folder")
+	}
+}
+
+func TestArkAuthBase_SetTokenCache(t *testing.T) {
+	authenticator, profile := newTokenCacheTestAuthenticator(auth.NewArkMemoryTokenCache())
+	if !authenticator.CacheAuthentication || authenticator.CacheNamespace() != "mock" {
+		t.Fatalf("Expected caching to be enabled in the mock namespace, got %v, %s", authenticator.CacheAuthentication, authenticator.CacheNamespace())
+	}
+
+	authenticator.SetTokenCache(nil)
+	if authenticator.CacheAuthentication {
+		t.Error("Expected caching to be disabled")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := authenticator.Authenticate(profile, nil, &authmodels.ArkSecret{Secret: "secret"}, false, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if authenticator.AuthenticateCount != 2 {
+		t.Errorf("Expected every authentication to be performed without a cache, got %d", authenticator.AuthenticateCount)
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/models/common/identity"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/toqueteos/webbrowser"
 )
 
@@ -67,6 +66,8 @@ type ArkIdentity struct {
 	keyring             *keyring.ArkKeyring
 	isPolling           bool
 	interactionRoutine  chan string
+	clientOptions       []common.ArkClientOption
+	mfaProvider         MFAProvider
 }
 
 // HasCacheRecord Checks if a cache record exists for the specified profile and username
@@ -136,8 +137,17 @@ func IsPasswordRequired(username string, identityURL string, identityTenantSubdo
 	return resp.Result.IdpRedirectURL == "" && len(resp.Result.Challenges) > 0 && resp.Result.Challenges[0].Mechanisms[0].Name == "UP"
 }
 
+// profileClientOptions returns the client options for the transport settings of the profile, followed by the given options.
+func profileClientOptions(profile *models.ArkProfile, options []common.ArkClientOption) []common.ArkClientOption {
+	if profile == nil || profile.TransportConfig == nil {
+		return options
+	}
+	return append([]common.ArkClientOption{common.WithTransportConfig(profile.TransportConfig)}, options...)
+}
+
 // NewArkIdentity creates a new ArkIdentity instance with the specified parameters.
-func NewArkIdentity(username string, password string, identityURL string, identityTenantSubdomain string, mfaType string, logger *common.ArkLogger, cacheAuthentication bool, loadCache bool, cacheProfile *models.ArkProfile) (*ArkIdentity, error) {
+// The transport settings of the profile and the given client options apply to every request made to Identity.
+func NewArkIdentity(username string, password string, identityURL string, identityTenantSubdomain string, mfaType string, logger *common.ArkLogger, cacheAuthentication bool, loadCache bool, cacheProfile *models.ArkProfile, options ...common.ArkClientOption) (*ArkIdentity, error) {
 	var err error
 	if mfaType == "" {
 		mfaType = "email"
@@ -149,20 +159,21 @@ func NewArkIdentity(username string, password string, identityURL string, identi
 		logger:              logger,
 		cacheAuthentication: cacheAuthentication,
 		isPolling:           false,
+		clientOptions:       profileClientOptions(cacheProfile, options),
 	}
 
 	if identityURL == "" {
 		if identityTenantSubdomain != "" {
-			identityURL, err = ResolveTenantFqdnFromTenantSubdomain(identityTenantSubdomain, commonmodels.GetDeployEnv())
+			identityURL, err = ResolveTenantFqdnFromTenantSubdomain(identityTenantSubdomain, commonmodels.GetDeployEnv(), identityAuth.clientOptions...)
 		} else {
 			tenantSuffix := username[strings.Index(username, "@"):]
-			identityURL, err = ResolveTenantFqdnFromTenantSuffix(tenantSuffix, commonmodels.IdentityEnvUrls[commonmodels.GetDeployEnv()])
+			identityURL, err = ResolveTenantFqdnFromTenantSuffix(tenantSuffix, commonmodels.IdentityEnvUrls[commonmodels.GetDeployEnv()], identityAuth.clientOptions...)
 		}
 	}
 	if err != nil {
 		return nil, err
 	}
-	identityAuth.session = common.NewSimpleArkClient(identityURL)
+	identityAuth.session = common.NewSimpleArkClient(identityURL, identityAuth.clientOptions...)
 	identityAuth.session.SetHeaders(DefaultHeaders())
 
 	if cacheAuthentication {
@@ -198,7 +209,7 @@ func (ai *ArkIdentity) loadCache(profile *models.ArkProfile) bool {
 			if err != nil {
 				return false
 			}
-			ai.session = common.NewSimpleArkClient(session.Endpoint)
+			ai.session = common.NewSimpleArkClient(session.Endpoint, ai.clientOptions...)
 			headers := make(map[string]string)
 			for k, v := range sessionInfo["headers"].(map[string]interface{}) {
 				headers[k] = v.(string)
@@ -483,6 +494,9 @@ func (ai *ArkIdentity) pickMechanism(challenge *identity.Challenge) (*identity.M
 			}
 		}
 	}
+	if ai.mfaProvider != nil {
+		return ai.providerMechanism(supportedMechanisms)
+	}
 	options := make([]string, len(supportedMechanisms))
 	for i, m := range supportedMechanisms {
 		options[i] = factors[strings.ToLower(m.Name)]
@@ -522,6 +536,28 @@ func (ai *ArkIdentity) pickMechanism(challenge *identity.Challenge) (*identity.M
 	return nil, errors.New("selected MFA method not found in supported mechanisms")
 }
 
+// providerMechanism picks the mechanism of a challenge with the MFA provider.
+// The password is used first when the challenge accepts it, as the provider only answers MFA factors.
+func (ai *ArkIdentity) providerMechanism(mechanisms []*identity.Mechanism) (*identity.Mechanism, error) {
+	if ai.password != "" {
+		if mechanism := selectMechanismByName(mechanisms, userPasswordMechanism); mechanism != nil {
+			return mechanism, nil
+		}
+	}
+	mechanism, err := ai.mfaProvider.SelectMechanism(mechanisms)
+	if err != nil {
+		return nil, err
+	}
+	if mechanism == nil {
+		mechanism = selectMechanismByName(mechanisms, ai.mfaType)
+	}
+	if mechanism == nil {
+		return nil, fmt.Errorf("challenge has no mechanism for mfa method [%s]", ai.mfaType)
+	}
+	ai.mfaType = strings.ToLower(mechanism.Name)
+	return mechanism, nil
+}
+
 func (ai *ArkIdentity) inputRoutine(chanWrite chan string, chanRead chan string, mechanism *identity.Mechanism, oobAdvanceResp *identity.AdvanceAuthMidResponse) {
 	currentTry := 0
 	for {
@@ -530,7 +566,19 @@ func (ai *ArkIdentity) inputRoutine(chanWrite chan string, chanRead chan string,
 			return
 		}
 		var answer string
-		if oobAdvanceResp.Result.GeneratedAuthValue != "" {
+		if ai.mfaProvider != nil {
+			var err error
+			answer, err = ai.mfaProvider.Answer(mechanism, oobAdvanceResp.Result.GeneratedAuthValue)
+			if err != nil {
+				ai.logger.Error("Failed to get an answer from the MFA provider: %v", err)
+				chanWrite <- "ERROR"
+				return
+			}
+			if answer == "" {
+				// The factor is approved out of band, which the authentication polls for
+				return
+			}
+		} else if oobAdvanceResp.Result.GeneratedAuthValue != "" {
 			prompt := &survey.Password{
 				Message: fmt.Sprintf("Sent Mobile Authenticator request to your device with a value of [%s]. Please follow the instructions to proceed with authentication or enter verification code here.", oobAdvanceResp.Result.GeneratedAuthValue),
 			}
@@ -591,10 +639,12 @@ func (ai *ArkIdentity) pollAuthentication(profile *models.ArkProfile, mechanism
 	ai.isPolling = true
 	defer func() { ai.isPolling = false }()
 
+	// Answers are read from the MFA provider when set, and prompted for when interactive
+	answering := isInteractive || ai.mfaProvider != nil
 	chanWrite := make(chan string)
 	chanRead := make(chan string)
 
-	if isInteractive {
+	if answering {
 		if err := ai.startInputRoutine(chanWrite, chanRead, mechanism, oobAdvanceResp); err != nil {
 			return err
 		}
@@ -626,7 +676,7 @@ func (ai *ArkIdentity) pollAuthentication(profile *models.ArkProfile, mechanism
 				if !midResp.Success {
 					flush = false
 					ai.isPolling = false
-					if isInteractive {
+					if answering {
 						ai.stopInputRoutine(flush)
 					}
 					return errors.New("failed to advance authentication")
@@ -634,7 +684,7 @@ func (ai *ArkIdentity) pollAuthentication(profile *models.ArkProfile, mechanism
 				if midResp.Result.Summary == "NewPackage" {
 					flush = false
 					ai.isPolling = false
-					if isInteractive {
+					if answering {
 						ai.stopInputRoutine(flush)
 					}
 					return nil
@@ -651,7 +701,7 @@ func (ai *ArkIdentity) pollAuthentication(profile *models.ArkProfile, mechanism
 		if advanceResp != nil {
 			if _, ok := advanceResp.(*identity.AdvanceAuthResponse); ok {
 				ai.isPolling = false
-				if isInteractive {
+				if answering {
 					ai.stopInputRoutine(flush)
 				}
 				ai.sessionDetails = &advanceResp.(*identity.AdvanceAuthResponse).Result
@@ -670,7 +720,7 @@ func (ai *ArkIdentity) pollAuthentication(profile *models.ArkProfile, mechanism
 			} else if midResp, ok := advanceResp.(*identity.AdvanceAuthMidResponse); ok {
 				if midResp.Result.Summary == "NewPackage" {
 					ai.isPolling = false
-					if isInteractive {
+					if answering {
 						ai.stopInputRoutine(flush)
 					}
 					return nil
@@ -765,8 +815,15 @@ func (ai *ArkIdentity) GetApps() (map[string]interface{}, error) {
 	return result, nil
 }
 
+// SetMFAProvider sets the provider answering MFA challenges in place of the prompts.
+// With a provider, MFA factors other than push notifications can be answered while not interactive.
+func (ai *ArkIdentity) SetMFAProvider(provider MFAProvider) {
+	ai.mfaProvider = provider
+}
+
 // AuthIdentity Authenticates to Identity with the information specified in the constructor.
-// If MFA is configured and `interactive` is enabled, the user is prompted for the MFA secret.
+// If MFA is configured and `interactive` is enabled, the user is prompted for the MFA secret,
+// unless an MFA provider was set, which answers instead.
 // The auth token and other details are stored in the object for future use.
 func (ai *ArkIdentity) AuthIdentity(profile *models.ArkProfile, interactive bool, force bool) error {
 	ai.logger.Debug("Attempting to authenticate to Identity")
@@ -778,7 +835,7 @@ func (ai *ArkIdentity) AuthIdentity(profile *models.ArkProfile, interactive bool
 		}
 	}
 
-	ai.session = common.NewSimpleArkClient(ai.session.BaseURL)
+	ai.session = common.NewSimpleArkClient(ai.session.BaseURL, ai.clientOptions...)
 	ai.session.SetHeaders(DefaultHeaders())
 	var startAuthResponse *identity.StartAuthResponse
 	var err error
@@ -802,9 +859,10 @@ func (ai *ArkIdentity) AuthIdentity(profile *models.ArkProfile, interactive bool
 		return ai.performIdpAuthentication(startAuthResponse, profile, interactive)
 	}
 
+	canAnswerMFA := interactive || ai.mfaProvider != nil
 	currentChallengeIdx := 0
 	var result string
-	if len(startAuthResponse.Result.Challenges[currentChallengeIdx].Mechanisms) > 1 && interactive {
+	if len(startAuthResponse.Result.Challenges[currentChallengeIdx].Mechanisms) > 1 && canAnswerMFA {
 		mechanism, err := ai.pickMechanism(&startAuthResponse.Result.Challenges[currentChallengeIdx])
 		if err != nil {
 			return err
@@ -843,7 +901,7 @@ func (ai *ArkIdentity) AuthIdentity(profile *models.ArkProfile, interactive bool
 			}
 		}
 	}
-	if interactive {
+	if canAnswerMFA {
 		if _, err = ai.pickMechanism(&startAuthResponse.Result.Challenges[currentChallengeIdx]); err != nil {
 			return err
 		}
@@ -861,7 +919,7 @@ func (ai *ArkIdentity) AuthIdentity(profile *models.ArkProfile, interactive bool
 		}
 	}
 
-	if !interactive {
+	if !canAnswerMFA {
 		return errors.New("user interaction is not supported while not interactive and mfa type given was not found")
 	}
 
@@ -893,16 +951,15 @@ func (ai *ArkIdentity) RefreshAuthIdentity(profile *models.ArkProfile, interacti
 	if ai.session != nil {
 		savedCookies = ai.session.GetCookies()
 	}
-	ai.session = common.NewSimpleArkClient(ai.session.BaseURL)
+	ai.session = common.NewSimpleArkClient(ai.session.BaseURL, ai.clientOptions...)
 	ai.session.SetHeaders(DefaultHeaders())
 
 	// Decode the token to get the tenant ID
-	token, _, err := new(jwt.Parser).ParseUnverified(ai.sessionDetails.Token, jwt.MapClaims{})
+	claims, err := auth.ParseArkTokenClaims(ai.sessionDetails.Token)
 	if err != nil {
 		return err
 	}
-	claims := token.Claims.(jwt.MapClaims)
-	platformTenantID := claims["tenant_id"].(string)
+	platformTenantID := claims.TenantID
 
 	refreshCookies := map[string]string{
 		fmt.Sprintf("refreshToken-%s", platformTenantID): ai.sessionDetails.RefreshToken,
@@ -947,14 +1004,14 @@ func (ai *ArkIdentity) RefreshAuthIdentity(profile *models.ArkProfile, interacti
 	ai.sessionDetails.RefreshToken = newRefreshToken
 
 	// Decode the new token to get the expiration time
-	newTokenClaims, _, err := new(jwt.Parser).ParseUnverified(newToken, jwt.MapClaims{})
+	newClaims, err := auth.ParseArkTokenClaims(newToken)
 	if err != nil {
 		return err
 	}
-	newClaims := newTokenClaims.Claims.(jwt.MapClaims)
-	exp := int64(newClaims["exp"].(float64))
-	iat := int64(newClaims["iat"].(float64))
-	ai.sessionDetails.TokenLifetime = int(exp - iat)
+	ai.sessionDetails.TokenLifetime = 0
+	if newClaims.ExpiresAt != nil && newClaims.IssuedAt != nil {
+		ai.sessionDetails.TokenLifetime = int(time.Time(*newClaims.ExpiresAt).Sub(time.Time(*newClaims.IssuedAt)).Seconds())
+	}
 
 	delta := ai.sessionDetails.TokenLifetime
 	if delta == 0 {
@@ -0,0 +1,261 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultClientCredentialsApplication = "__idaptive_cybr_user_oidc"
+	defaultClientCredentialsScope       = "api"
+	clientAssertionType                 = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime             = 5 * time.Minute
+)
+
+// ArkIdentityClientCredentials is a struct that represents OAuth2 client credentials authentication to identity.
+//
+// The client authenticates either with its client secret, sent with basic authentication,
+// or with a private_key_jwt assertion signed by its private key. Tokens are not cached by
+// ArkIdentityClientCredentials itself, the authenticator caches them.
+type ArkIdentityClientCredentials struct {
+	clientID         string
+	clientSecret     string
+	tokenEndpoint    string
+	scope            string
+	clientAuthMethod string
+	privateKeyFile   string
+	keyID            string
+	logger           *common.ArkLogger
+	session          *common.ArkClient
+	sessionToken     string
+	refreshToken     string
+	tokenLifetime    int
+}
+
+// NewArkIdentityClientCredentials creates a new instance of ArkIdentityClientCredentials.
+//
+// The token endpoint is taken from the settings when set. Otherwise it is the token endpoint
+// of the authorization application on the Identity URL, which is resolved from the tenant
+// subdomain or from the suffix of the client ID when not set either.
+// The transport settings of the profile and the given client options apply to every request made to Identity.
+func NewArkIdentityClientCredentials(clientID string, clientSecret string, settings *auth.ClientCredentialsArkAuthMethodSettings, logger *common.ArkLogger, cacheProfile *models.ArkProfile, options ...common.ArkClientOption) (*ArkIdentityClientCredentials, error) {
+	if clientID == "" {
+		return nil, errors.New("client id is required for client credentials authentication")
+	}
+	if settings == nil {
+		settings = &auth.ClientCredentialsArkAuthMethodSettings{}
+	}
+	clientOptions := profileClientOptions(cacheProfile, options)
+	identityClientAuth := &ArkIdentityClientCredentials{
+		clientID:         clientID,
+		clientSecret:     clientSecret,
+		tokenEndpoint:    settings.TokenEndpoint,
+		scope:            settings.Scope,
+		clientAuthMethod: settings.ClientAuthMethod,
+		privateKeyFile:   settings.PrivateKeyFile,
+		keyID:            settings.KeyID,
+		logger:           logger,
+	}
+	if identityClientAuth.scope == "" {
+		identityClientAuth.scope = defaultClientCredentialsScope
+	}
+	if identityClientAuth.clientAuthMethod == "" {
+		identityClientAuth.clientAuthMethod = auth.ClientSecretAuth
+	}
+	if identityClientAuth.clientAuthMethod != auth.ClientSecretAuth && identityClientAuth.clientAuthMethod != auth.PrivateKeyJWTAuth {
+		return nil, fmt.Errorf("unsupported client authentication method [%s]", identityClientAuth.clientAuthMethod)
+	}
+	if identityClientAuth.tokenEndpoint == "" {
+		identityURL, err := resolveIdentityURL(settings.IdentityURL, settings.IdentityTenantSubdomain, clientID, clientOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the token endpoint for client credentials authentication: %w", err)
+		}
+		application := settings.IdentityAuthorizationApplication
+		if application == "" {
+			application = defaultClientCredentialsApplication
+		}
+		identityClientAuth.tokenEndpoint = fmt.Sprintf("%s/OAuth2/Token/%s", identityURL, application)
+	}
+	identityClientAuth.session = newOAuth2Client(identityClientAuth.tokenEndpoint, clientOptions)
+	return identityClientAuth, nil
+}
+
+// AuthIdentity requests a token from the token endpoint with the client credentials grant.
+func (ai *ArkIdentityClientCredentials) AuthIdentity() error {
+	ai.logger.Info("Authenticating with client credentials via endpoint [%s]", ai.tokenEndpoint)
+	form := map[string]string{
+		"grant_type": "client_credentials",
+		"scope":      ai.scope,
+	}
+	switch ai.clientAuthMethod {
+	case auth.PrivateKeyJWTAuth:
+		assertion, err := ai.clientAssertion()
+		if err != nil {
+			return err
+		}
+		form["client_id"] = ai.clientID
+		form["client_assertion_type"] = clientAssertionType
+		form["client_assertion"] = assertion
+	default:
+		if ai.clientSecret == "" {
+			return errors.New("client secret is required for client_secret client authentication")
+		}
+		ai.session.UpdateToken(
+			base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", ai.clientID, ai.clientSecret))),
+			"Basic",
+		)
+	}
+	if err := ai.requestToken(form); err != nil {
+		return fmt.Errorf("failed authenticating with client credentials via endpoint [%s]: %w", ai.tokenEndpoint, err)
+	}
+	ai.logger.Info("Created a client credentials session via endpoint [%s] with client [%s] to platform", ai.tokenEndpoint, ai.clientID)
+	return nil
+}
+
+// RefreshAuthIdentity refreshes the authentication of the client.
+//
+// A refresh token is exchanged for a new token when the endpoint issued one. Otherwise,
+// clients using private_key_jwt authenticate again, as their key is at hand, while clients
+// using a client secret cannot refresh.
+func (ai *ArkIdentityClientCredentials) RefreshAuthIdentity(refreshToken string) error {
+	if refreshToken != "" {
+		ai.logger.Info("Refreshing client credentials authentication via endpoint [%s]", ai.tokenEndpoint)
+		err := ai.requestToken(map[string]string{
+			"grant_type":    "refresh_token",
+			"refresh_token": refreshToken,
+			"client_id":     ai.clientID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed refreshing client credentials authentication via endpoint [%s]: %w", ai.tokenEndpoint, err)
+		}
+		if ai.refreshToken == "" {
+			ai.refreshToken = refreshToken
+		}
+		return nil
+	}
+	if ai.clientAuthMethod == auth.PrivateKeyJWTAuth || ai.clientSecret != "" {
+		return ai.AuthIdentity()
+	}
+	return errors.New("no refresh token or credentials to refresh the client credentials authentication with")
+}
+
+func (ai *ArkIdentityClientCredentials) requestToken(form map[string]string) error {
+	tokenResponse, err := requestOAuth2Token(ai.session, ai.logger, form)
+	if err != nil {
+		return err
+	}
+	ai.sessionToken = tokenResponse.AccessToken
+	ai.refreshToken = tokenResponse.RefreshToken
+	ai.tokenLifetime = tokenResponse.ExpiresIn
+	ai.session.UpdateToken(ai.sessionToken, "Bearer")
+	return nil
+}
+
+// clientAssertion signs a private_key_jwt assertion for the token endpoint, as defined by RFC 7523.
+func (ai *ArkIdentityClientCredentials) clientAssertion() (string, error) {
+	if ai.privateKeyFile == "" {
+		return "", errors.New("private key file is required for private_key_jwt client authentication")
+	}
+	privateKey, err := loadPrivateKey(ai.privateKeyFile)
+	if err != nil {
+		return "", err
+	}
+	var signingMethod jwt.SigningMethod
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		signingMethod = jwt.SigningMethodRS256
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P384():
+			signingMethod = jwt.SigningMethodES384
+		case elliptic.P521():
+			signingMethod = jwt.SigningMethodES512
+		default:
+			signingMethod = jwt.SigningMethodES256
+		}
+	case ed25519.PrivateKey:
+		signingMethod = jwt.SigningMethodEdDSA
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+	now := time.Now()
+	assertion := jwt.NewWithClaims(signingMethod, jwt.RegisteredClaims{
+		Issuer:    ai.clientID,
+		Subject:   ai.clientID,
+		Audience:  jwt.ClaimStrings{ai.tokenEndpoint},
+		ID:        uuid.NewString(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(clientAssertionLifetime)),
+	})
+	if ai.keyID != "" {
+		assertion.Header["kid"] = ai.keyID
+	}
+	return assertion.SignedString(privateKey)
+}
+
+// loadPrivateKey reads a PKCS#8, PKCS#1 or SEC 1 PEM encoded private key.
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file [%s]: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found in [%s]", path)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("failed to parse the private key in [%s]", path)
+}
+
+// Session returns the current identity session
+func (ai *ArkIdentityClientCredentials) Session() *common.ArkClient {
+	return ai.session
+}
+
+// SessionToken returns the current access token if logged in
+func (ai *ArkIdentityClientCredentials) SessionToken() string {
+	return ai.sessionToken
+}
+
+// RefreshToken returns the refresh token issued along with the access token, if any
+func (ai *ArkIdentityClientCredentials) RefreshToken() string {
+	return ai.refreshToken
+}
+
+// SessionExpiration returns the expiration time of the access token, as given by the token endpoint
+func (ai *ArkIdentityClientCredentials) SessionExpiration(defaultLifetimeSeconds int) commonmodels.ArkRFC3339Time {
+	return TokenExpiration(ai.sessionToken, ai.tokenLifetime, defaultLifetimeSeconds)
+}
+
+// IdentityURL returns the token endpoint
+func (ai *ArkIdentityClientCredentials) IdentityURL() string {
+	return ai.tokenEndpoint
+}
@@ -0,0 +1,268 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeTestPrivateKey writes a PEM encoded private key, and returns its path.
+func writeTestPrivateKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "client.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write private key: %v", err)
+	}
+	return path
+}
+
+func TestArkIdentityClientCredentials_PrivateKeyJWT(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	_, ed25519Key, _ := ed25519.GenerateKey(rand.Reader)
+	pkcs8 := func(key crypto.Signer) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("Failed to marshal private key: %v", err)
+		}
+		return der
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecdsaKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %v", err)
+	}
+	tests := []struct {
+		name              string
+		keyPath           string
+		publicKey         crypto.PublicKey
+		expectedAlgorithm string
+	}{
+		{
+			name:              "success_rsa_pkcs8_key",
+			keyPath:           writeTestPrivateKey(t, "PRIVATE KEY", pkcs8(rsaKey)),
+			publicKey:         rsaKey.Public(),
+			expectedAlgorithm: "RS256",
+		},
+		{
+			name:              "success_rsa_pkcs1_key",
+			keyPath:           writeTestPrivateKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+			publicKey:         rsaKey.Public(),
+			expectedAlgorithm: "RS256",
+		},
+		{
+			name:              "success_ecdsa_sec1_key",
+			keyPath:           writeTestPrivateKey(t, "EC PRIVATE KEY", sec1),
+			publicKey:         ecdsaKey.Public(),
+			expectedAlgorithm: "ES384",
+		},
+		{
+			name:              "success_ed25519_pkcs8_key",
+			keyPath:           writeTestPrivateKey(t, "PRIVATE KEY", pkcs8(ed25519Key)),
+			publicKey:         ed25519Key.Public(),
+			expectedAlgorithm: "EdDSA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTokenEndpointTestServer(t, http.StatusOK, map[string]interface{}{"access_token": "access-token", "expires_in": 1200})
+			settings := &auth.ClientCredentialsArkAuthMethodSettings{
+				TokenEndpoint:    s.endpoint(),
+				Scope:            "api openid",
+				ClientAuthMethod: auth.PrivateKeyJWTAuth,
+				PrivateKeyFile:   tt.keyPath,
+				KeyID:            "key-1",
+			}
+			clientAuth, err := NewArkIdentityClientCredentials("client-app", "", settings, common.GetLogger("test", common.Unknown), nil, s.clientOption())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if err := clientAuth.AuthIdentity(); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			form := s.forms[0]
+			if form.Get("grant_type") != "client_credentials" || form.Get("scope") != "api openid" || form.Get("client_id") != "client-app" {
+				t.Errorf("Expected a client credentials grant of the client, got %v", form)
+			}
+			if form.Get("client_assertion_type") != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+				t.Errorf("Expected a jwt-bearer client assertion, got %q", form.Get("client_assertion_type"))
+			}
+			if s.authorizations[0] != "" {
+				t.Errorf("Expected no basic authentication with a client assertion, got %q", s.authorizations[0])
+			}
+
+			claims := jwt.RegisteredClaims{}
+			assertion, err := jwt.ParseWithClaims(form.Get("client_assertion"), &claims, func(token *jwt.Token) (interface{}, error) {
+				return tt.publicKey, nil
+			}, jwt.WithValidMethods([]string{tt.expectedAlgorithm}), jwt.WithAudience(s.endpoint()), jwt.WithIssuer("client-app"), jwt.WithSubject("client-app"), jwt.WithExpirationRequired())
+			if err != nil {
+				t.Fatalf("Expected a valid client assertion, got %v", err)
+			}
+			if assertion.Header["kid"] != "key-1" || claims.ID == "" {
+				t.Errorf("Expected the key id and a unique id in the assertion, got %v, %+v", assertion.Header, claims)
+			}
+			if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != 5*time.Minute {
+				t.Errorf("Expected a short lived assertion, got %v", lifetime)
+			}
+			assertExpiresIn(t, time.Time(clientAuth.SessionExpiration(int((4 * time.Hour).Seconds()))), 1200*time.Second)
+		})
+	}
+}
+
+func TestArkIdentityClientCredentials_ClientSecret(t *testing.T) {
+	tests := []struct {
+		name             string
+		clientSecret     string
+		status           int
+		response         map[string]interface{}
+		expectedError    string
+		expectedLifetime time.Duration
+	}{
+		{
+			name:             "success_uses_expires_in_of_endpoint",
+			clientSecret:     "client-secret",
+			status:           http.StatusOK,
+			response:         map[string]interface{}{"access_token": "access-token", "expires_in": 300},
+			expectedLifetime: 300 * time.Second,
+		},
+		{
+			name:          "error_missing_client_secret",
+			status:        http.StatusOK,
+			response:      map[string]interface{}{"access_token": "access-token"},
+			expectedError: "client secret is required",
+		},
+		{
+			name:          "error_rejected_client",
+			clientSecret:  "wrong",
+			status:        http.StatusUnauthorized,
+			response:      map[string]interface{}{"error": "invalid_client"},
+			expectedError: "failed authenticating with client credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTokenEndpointTestServer(t, tt.status, tt.response)
+			settings := &auth.ClientCredentialsArkAuthMethodSettings{TokenEndpoint: s.endpoint()}
+			clientAuth, err := NewArkIdentityClientCredentials("client-app", tt.clientSecret, settings, common.GetLogger("test", common.Unknown), nil, s.clientOption())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			err = clientAuth.AuthIdentity()
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			form := s.forms[0]
+			if form.Get("grant_type") != "client_credentials" || form.Get("scope") != "api" || form.Get("client_assertion") != "" {
+				t.Errorf("Expected a client credentials grant with the default scope, got %v", form)
+			}
+			username, password, ok := (&http.Request{Header: http.Header{"Authorization": {s.authorizations[0]}}}).BasicAuth()
+			if !ok || username != "client-app" || password != tt.clientSecret {
+				t.Errorf("Expected basic authentication with the client credentials, got %q", s.authorizations[0])
+			}
+			assertExpiresIn(t, time.Time(clientAuth.SessionExpiration(int((4 * time.Hour).Seconds()))), tt.expectedLifetime)
+		})
+	}
+}
+
+func TestNewArkIdentityClientCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientID      string
+		settings      *auth.ClientCredentialsArkAuthMethodSettings
+		expectedError string
+	}{
+		{
+			name:     "success_explicit_token_endpoint",
+			clientID: "client-app",
+			settings: &auth.ClientCredentialsArkAuthMethodSettings{TokenEndpoint: "https://abc1234.id.cyberark.cloud/OAuth2/Token/app"},
+		},
+		{
+			name:          "error_missing_client_id",
+			settings:      &auth.ClientCredentialsArkAuthMethodSettings{TokenEndpoint: "https://abc1234.id.cyberark.cloud/OAuth2/Token/app"},
+			expectedError: "client id is required",
+		},
+		{
+			name:     "error_unsupported_client_auth_method",
+			clientID: "client-app",
+			settings: &auth.ClientCredentialsArkAuthMethodSettings{
+				TokenEndpoint:    "https://abc1234.id.cyberark.cloud/OAuth2/Token/app",
+				ClientAuthMethod: "tls_client_auth",
+			},
+			expectedError: "unsupported client authentication method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArkIdentityClientCredentials(tt.clientID, "secret", tt.settings, common.GetLogger("test", common.Unknown), nil)
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedError)) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestArkIdentityClientCredentials_PrivateKeyErrors(t *testing.T) {
+	s := newTokenEndpointTestServer(t, http.StatusOK, map[string]interface{}{"access_token": "access-token"})
+	notPEMPath := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(notPEMPath, []byte("not a key"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	tests := []struct {
+		name          string
+		keyPath       string
+		expectedError string
+	}{
+		{name: "error_missing_private_key_file", expectedError: "private key file is required"},
+		{name: "error_unreadable_private_key_file", keyPath: filepath.Join(t.TempDir(), "missing.pem"), expectedError: "failed to read private key file"},
+		{name: "error_not_pem", keyPath: notPEMPath, expectedError: "no PEM encoded private key"},
+		{name: "error_invalid_key", keyPath: writeTestPrivateKey(t, "PRIVATE KEY", []byte("garbage")), expectedError: "failed to parse the private key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &auth.ClientCredentialsArkAuthMethodSettings{
+				TokenEndpoint:    s.endpoint(),
+				ClientAuthMethod: auth.PrivateKeyJWTAuth,
+				PrivateKeyFile:   tt.keyPath,
+			}
+			clientAuth, err := NewArkIdentityClientCredentials("client-app", "", settings, common.GetLogger("test", common.Unknown), nil, s.clientOption())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if err := clientAuth.AuthIdentity(); err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+			if len(s.forms) != 0 {
+				t.Errorf("Expected no token request without a client assertion, got %v", s.forms)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Iilun/survey/v2"
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+// ArkIdentityDirect is a struct that represents identity authentication against an explicit token endpoint.
+//
+// Unlike ArkIdentity and ArkIdentityServiceUser, the tenant is not discovered from
+// the username or the tenant subdomain. The username and secret are exchanged as client
+// credentials directly with the OAuth2 token endpoint given, which suits deployments that
+// can only reach the tenant through a private link. The grant itself is made by
+// ArkIdentityClientCredentials, direct authentication only validates the endpoint and
+// prompts for the secret.
+type ArkIdentityDirect struct {
+	endpoint          string
+	interactive       bool
+	logger            *common.ArkLogger
+	clientCredentials *ArkIdentityClientCredentials
+}
+
+// NewArkIdentityDirect creates a new instance of ArkIdentityDirect.
+// The endpoint is the full URL of the token endpoint, such as https://abc1234.id.cyberark.cloud/OAuth2/Token/app.
+// The transport settings of the profile and the given client options apply to every request made to the endpoint.
+func NewArkIdentityDirect(username string, secret string, endpoint string, interactive bool, logger *common.ArkLogger, cacheProfile *models.ArkProfile, options ...common.ArkClientOption) (*ArkIdentityDirect, error) {
+	if endpoint == "" {
+		return nil, errors.New("endpoint is required for direct authentication")
+	}
+	parsedEndpoint, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid direct authentication endpoint [%s]: %w", endpoint, err)
+	}
+	if parsedEndpoint.Scheme != "https" || parsedEndpoint.Host == "" {
+		return nil, fmt.Errorf("direct authentication endpoint [%s] must be an https URL", endpoint)
+	}
+	clientCredentials, err := NewArkIdentityClientCredentials(
+		username,
+		secret,
+		&auth.ClientCredentialsArkAuthMethodSettings{TokenEndpoint: endpoint, ClientAuthMethod: auth.ClientSecretAuth},
+		logger,
+		cacheProfile,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ArkIdentityDirect{
+		endpoint:          endpoint,
+		interactive:       interactive,
+		logger:            logger,
+		clientCredentials: clientCredentials,
+	}, nil
+}
+
+// AuthIdentity authenticates to the token endpoint with the client credentials grant.
+// The username and secret are sent as the client id and secret.
+// If no secret was given, it is prompted for when interactive.
+func (ai *ArkIdentityDirect) AuthIdentity(interactive bool) error {
+	ai.logger.Info("Authenticating directly via endpoint [%s]", ai.endpoint)
+	if ai.clientCredentials.clientSecret == "" {
+		if !interactive || !ai.interactive {
+			return errors.New("no secret and not interactive, cannot continue")
+		}
+		var answer string
+		prompt := &survey.Password{
+			Message: "Identity Security Platform Secret",
+		}
+		if err := survey.AskOne(prompt, &answer); err != nil {
+			return err
+		}
+		if answer == "" {
+			return errors.New("empty response by user")
+		}
+		ai.clientCredentials.clientSecret = answer
+	}
+	return ai.clientCredentials.AuthIdentity()
+}
+
+// RefreshAuthIdentity refreshes the direct authentication.
+//
+// The refresh token is exchanged for a new token when given. Otherwise the client
+// credentials grant is made again with the secret, failing when there is none.
+func (ai *ArkIdentityDirect) RefreshAuthIdentity(refreshToken string) error {
+	ai.logger.Info("Refreshing direct authentication via endpoint [%s]", ai.endpoint)
+	return ai.clientCredentials.RefreshAuthIdentity(refreshToken)
+}
+
+// Session returns the current identity session
+func (ai *ArkIdentityDirect) Session() *common.ArkClient {
+	return ai.clientCredentials.Session()
+}
+
+// SessionToken returns the current access token if logged in
+func (ai *ArkIdentityDirect) SessionToken() string {
+	return ai.clientCredentials.SessionToken()
+}
+
+// RefreshToken returns the refresh token issued along with the access token, if any
+func (ai *ArkIdentityDirect) RefreshToken() string {
+	return ai.clientCredentials.RefreshToken()
+}
+
+// SessionExpiration returns the expiration time of the access token, using the given lifetime if the endpoint did not set one
+func (ai *ArkIdentityDirect) SessionExpiration(defaultLifetimeSeconds int) commonmodels.ArkRFC3339Time {
+	return ai.clientCredentials.SessionExpiration(defaultLifetimeSeconds)
+}
+
+// IdentityURL returns the token endpoint
+func (ai *ArkIdentityDirect) IdentityURL() string {
+	return ai.endpoint
+}
@@ -0,0 +1,222 @@
+package identity
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+)
+
+// tokenEndpointTestServer is an OAuth2 token endpoint recording the requests it receives.
+type tokenEndpointTestServer struct {
+	server   *httptest.Server
+	status   int
+	response map[string]interface{}
+
+	mu             sync.Mutex
+	forms          []url.Values
+	authorizations []string
+}
+
+func newTokenEndpointTestServer(t *testing.T, status int, response map[string]interface{}) *tokenEndpointTestServer {
+	s := &tokenEndpointTestServer{status: status, response: response}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		s.mu.Lock()
+		s.forms = append(s.forms, r.PostForm)
+		s.authorizations = append(s.authorizations, r.Header.Get("Authorization"))
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(s.status)
+		_ = json.NewEncoder(w).Encode(s.response)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *tokenEndpointTestServer) endpoint() string {
+	return s.server.URL + "/OAuth2/Token/app"
+}
+
+func (s *tokenEndpointTestServer) clientOption() common.ArkClientOption {
+	return common.WithRoundTripper(s.server.Client().Transport)
+}
+
+// assertExpiresIn checks that an expiration is the given lifetime from now.
+func assertExpiresIn(t *testing.T, expiration time.Time, lifetime time.Duration) {
+	t.Helper()
+	if delta := time.Until(expiration) - lifetime; delta > 5*time.Second || delta < -5*time.Second {
+		t.Errorf("Expected the token to expire in %v, expires in %v", lifetime, time.Until(expiration))
+	}
+}
+
+func TestArkIdentityDirect_AuthIdentity(t *testing.T) {
+	tests := []struct {
+		name             string
+		secret           string
+		status           int
+		response         map[string]interface{}
+		expectedError    string
+		expectedLifetime time.Duration
+	}{
+		{
+			name:             "success_uses_expires_in_of_endpoint",
+			secret:           "secret",
+			status:           http.StatusOK,
+			response:         map[string]interface{}{"access_token": "access-token", "refresh_token": "refresh-token", "expires_in": 900},
+			expectedLifetime: 900 * time.Second,
+		},
+		{
+			name:             "success_defaults_lifetime_without_expires_in",
+			secret:           "secret",
+			status:           http.StatusOK,
+			response:         map[string]interface{}{"access_token": "access-token"},
+			expectedLifetime: 4 * time.Hour,
+		},
+		{
+			name:          "error_rejected_credentials",
+			secret:        "wrong",
+			status:        http.StatusUnauthorized,
+			response:      map[string]interface{}{"error": "invalid_client"},
+			expectedError: "failed authenticating with client credentials",
+		},
+		{
+			name:          "error_no_access_token",
+			secret:        "secret",
+			status:        http.StatusOK,
+			response:      map[string]interface{}{"token_type": "Bearer"},
+			expectedError: "access token not found",
+		},
+		{
+			name:          "error_no_secret_not_interactive",
+			status:        http.StatusOK,
+			response:      map[string]interface{}{"access_token": "access-token"},
+			expectedError: "no secret and not interactive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTokenEndpointTestServer(t, tt.status, tt.response)
+			directAuth, err := NewArkIdentityDirect("client@cyberark.cloud.12345", tt.secret, s.endpoint(), false, common.GetLogger("test", common.Unknown), nil, s.clientOption())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			err = directAuth.AuthIdentity(false)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(s.forms) != 1 || s.forms[0].Get("grant_type") != "client_credentials" || s.forms[0].Get("scope") != "api" {
+				t.Errorf("Expected a client credentials grant, got %v", s.forms)
+			}
+			username, password, ok := (&http.Request{Header: http.Header{"Authorization": {s.authorizations[0]}}}).BasicAuth()
+			if !ok || username != "client@cyberark.cloud.12345" || password != tt.secret {
+				t.Errorf("Expected basic authentication with the username and secret, got %q", s.authorizations[0])
+			}
+			if directAuth.SessionToken() != "access-token" || directAuth.IdentityURL() != s.endpoint() {
+				t.Errorf("Expected the access token of the endpoint, got %s", directAuth.SessionToken())
+			}
+			assertExpiresIn(t, time.Time(directAuth.SessionExpiration(int((4 * time.Hour).Seconds()))), tt.expectedLifetime)
+		})
+	}
+}
+
+func TestArkIdentityDirect_RefreshAuthIdentity(t *testing.T) {
+	tests := []struct {
+		name                 string
+		secret               string
+		refreshToken         string
+		expectedGrant        string
+		expectedRefreshToken string
+		expectedError        string
+	}{
+		{
+			name:                 "success_refresh_token_grant",
+			refreshToken:         "refresh-token",
+			expectedGrant:        "refresh_token",
+			expectedRefreshToken: "refresh-token",
+		},
+		{
+			name:          "success_authenticates_again_with_secret",
+			secret:        "secret",
+			expectedGrant: "client_credentials",
+		},
+		{
+			name:          "error_no_refresh_token_or_secret",
+			expectedError: "no refresh token or credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTokenEndpointTestServer(t, http.StatusOK, map[string]interface{}{"access_token": "refreshed-token", "expires_in": 600})
+			directAuth, err := NewArkIdentityDirect("client@cyberark.cloud.12345", tt.secret, s.endpoint(), false, common.GetLogger("test", common.Unknown), nil, s.clientOption())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			err = directAuth.RefreshAuthIdentity(tt.refreshToken)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				if len(s.forms) != 0 {
+					t.Errorf("Expected no request to the endpoint, got %v", s.forms)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			form := s.forms[0]
+			if form.Get("grant_type") != tt.expectedGrant {
+				t.Errorf("Expected a %s grant, got %v", tt.expectedGrant, form)
+			}
+			if tt.refreshToken != "" && (form.Get("refresh_token") != tt.refreshToken || form.Get("client_id") != "client@cyberark.cloud.12345") {
+				t.Errorf("Expected the refresh token of the client, got %v", form)
+			}
+			if directAuth.SessionToken() != "refreshed-token" || directAuth.RefreshToken() != tt.expectedRefreshToken {
+				t.Errorf("Expected the new access token and refresh token %q, got %s, %s", tt.expectedRefreshToken, directAuth.SessionToken(), directAuth.RefreshToken())
+			}
+			assertExpiresIn(t, time.Time(directAuth.SessionExpiration(int((4 * time.Hour).Seconds()))), 600*time.Second)
+		})
+	}
+}
+
+func TestNewArkIdentityDirect(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoint      string
+		expectedError string
+	}{
+		{name: "success_https_endpoint", endpoint: "https://abc1234.id.cyberark.cloud/OAuth2/Token/app"},
+		{name: "error_empty_endpoint", endpoint: "", expectedError: "endpoint is required"},
+		{name: "error_http_endpoint", endpoint: "http://abc1234.id.cyberark.cloud/OAuth2/Token/app", expectedError: "must be an https URL"},
+		{name: "error_endpoint_without_host", endpoint: "https:///OAuth2/Token/app", expectedError: "must be an https URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArkIdentityDirect("client", "secret", tt.endpoint, false, common.GetLogger("test", common.Unknown), nil)
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedError)) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
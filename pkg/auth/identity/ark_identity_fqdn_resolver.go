@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -37,12 +38,34 @@ func DefaultSystemHeaders() map[string]string {
 	}
 }
 
+// resolveIdentityURL returns the https URL of the Identity tenant, which is either given, or resolved
+// from the tenant subdomain, or from the suffix of the username.
+func resolveIdentityURL(identityURL string, identityTenantSubdomain string, username string, options []common.ArkClientOption) (string, error) {
+	var err error
+	if identityURL == "" {
+		if identityTenantSubdomain != "" {
+			identityURL, err = ResolveTenantFqdnFromTenantSubdomain(identityTenantSubdomain, commonmodels.GetDeployEnv(), options...)
+		} else if strings.Contains(username, "@") {
+			identityURL, err = ResolveTenantFqdnFromTenantSuffix(username[strings.Index(username, "@"):], commonmodels.IdentityEnvUrls[commonmodels.GetDeployEnv()], options...)
+		} else {
+			err = errors.New("no identity url, tenant subdomain or username with a tenant suffix to resolve the identity tenant from")
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(identityURL, "https://") {
+		identityURL = "https://" + identityURL
+	}
+	return strings.TrimSuffix(identityURL, "/"), nil
+}
+
 // ResolveTenantFqdnFromTenantSubdomain resolves the tenant's FQDN URL from its subdomain.
 // The resolved URL is based on the current working environment, which is provided in the `tenantSubdomain` argument.
-func ResolveTenantFqdnFromTenantSubdomain(tenantSubdomain string, env commonmodels.AwsEnv) (string, error) {
+func ResolveTenantFqdnFromTenantSubdomain(tenantSubdomain string, env commonmodels.AwsEnv, options ...common.ArkClientOption) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout*time.Second)
 	defer cancel()
-	client := common.NewSimpleArkClient(fmt.Sprintf("https://%s.%s", discoveryServiceDomainName, commonmodels.RootDomain[env]))
+	client := common.NewSimpleArkClient(fmt.Sprintf("https://%s.%s", discoveryServiceDomainName, commonmodels.RootDomain[env]), options...)
 	client.SetHeaders(map[string]string{
 		"Content-Type": "application/json",
 	})
@@ -63,15 +86,15 @@ func ResolveTenantFqdnFromTenantSubdomain(tenantSubdomain string, env commonmode
 		}
 		return parsedResponse.Endpoint, nil
 	}
-	return "", fmt.Errorf("getting tenant FQDN failed from platform discovery [%d] - [%s]", response.StatusCode, response.Status)
+	return "", common.NewArkAPIError(response, "getting tenant FQDN failed from platform discovery")
 }
 
 // ResolveTenantFqdnFromTenantSuffix resolves the tenant's FQDN URL from its suffix.
-func ResolveTenantFqdnFromTenantSuffix(tenantSuffix string, identityEnvURL string) (string, error) {
+func ResolveTenantFqdnFromTenantSuffix(tenantSuffix string, identityEnvURL string, options ...common.ArkClientOption) (string, error) {
 	if identityEnvURL == "" {
 		identityEnvURL = commonmodels.IdentityEnvUrls[commonmodels.GetDeployEnv()]
 	}
-	client := common.NewSimpleArkClient(fmt.Sprintf("https://pod0.%s", identityEnvURL))
+	client := common.NewSimpleArkClient(fmt.Sprintf("https://pod0.%s", identityEnvURL), options...)
 	client.SetHeaders(map[string]string{
 		"Content-Type":          "application/json",
 		"X-IDAP-NATIVE-CLIENT'": "true",
@@ -100,5 +123,5 @@ func ResolveTenantFqdnFromTenantSuffix(tenantSuffix string, identityEnvURL strin
 		}
 		return fqdn, nil
 	}
-	return "", fmt.Errorf("getting tenant FQDN failed from identity [%d] - [%s]", response.StatusCode, response.Status)
+	return "", common.NewArkAPIError(response, "getting tenant FQDN failed from identity")
 }
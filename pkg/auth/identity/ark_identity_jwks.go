@@ -0,0 +1,263 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	jwksCacheLifetime     = time.Hour
+	jwksMinRefetchPeriod  = time.Minute
+	jwksFallbackKeysRoute = "OAuth2/Keys"
+	jwksClockLeeway       = time.Minute
+)
+
+var jwksSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// jsonWebKey is a public key of a JWKS, as defined by RFC 7517.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ArkJWKSVerifier verifies the signature of tokens issued by an identity issuer, against the keys the issuer publishes.
+//
+// The keys are fetched from the JWKS of the issuer once, and fetched again when a token
+// is signed by a key that is not known yet, such as after a key rotation.
+type ArkJWKSVerifier struct {
+	issuer        string
+	jwksURL       string
+	clientOptions []common.ArkClientOption
+	logger        *common.ArkLogger
+	mutex         sync.Mutex
+	keys          map[string]crypto.PublicKey
+	fetchedAt     time.Time
+}
+
+// NewArkJWKSVerifier creates a new instance of ArkJWKSVerifier for the given issuer.
+//
+// The JWKS URL is taken from the OIDC discovery document of the issuer when empty, or is
+// the OAuth2 keys endpoint of the identity application of the issuer when there is none.
+func NewArkJWKSVerifier(issuer string, jwksURL string, logger *common.ArkLogger, options ...common.ArkClientOption) (*ArkJWKSVerifier, error) {
+	if issuer == "" {
+		return nil, errors.New("issuer is required to verify tokens")
+	}
+	if logger == nil {
+		logger = common.GetLogger("ArkJWKSVerifier", common.Unknown)
+	}
+	return &ArkJWKSVerifier{
+		issuer:        issuer,
+		jwksURL:       jwksURL,
+		clientOptions: options,
+		logger:        logger,
+	}, nil
+}
+
+// Verify verifies the signature, issuer and expiration of a token, and returns its claims.
+func (v *ArkJWKSVerifier) Verify(token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(jwksSigningMethods), jwt.WithExpirationRequired(), jwt.WithIssuedAt(), jwt.WithLeeway(jwksClockLeeway))
+	if _, err := parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	issuer, _ := claims.GetIssuer()
+	if strings.TrimSuffix(issuer, "/") != strings.TrimSuffix(v.issuer, "/") {
+		return nil, fmt.Errorf("token was issued by [%s] rather than [%s]", issuer, v.issuer)
+	}
+	return claims, nil
+}
+
+// key returns the public key a token was signed with, by the kid of the token.
+func (v *ArkJWKSVerifier) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if v.keys == nil || time.Since(v.fetchedAt) > jwksCacheLifetime {
+		if err := v.fetchKeys(); err != nil {
+			return nil, err
+		}
+	}
+	if key, ok := v.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(v.fetchedAt) > jwksMinRefetchPeriod {
+		if err := v.fetchKeys(); err != nil {
+			return nil, err
+		}
+		if key, ok := v.lookupKey(kid); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no key [%s] in the jwks of [%s]", kid, v.issuer)
+}
+
+func (v *ArkJWKSVerifier) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+func (v *ArkJWKSVerifier) fetchKeys() error {
+	if v.jwksURL == "" {
+		jwksURL, err := v.discoverJWKSURL()
+		if err != nil {
+			return err
+		}
+		v.jwksURL = jwksURL
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(v.jwksURL, "failed to get the jwks", &jwks); err != nil {
+		return err
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, webKey := range jwks.Keys {
+		if webKey.Use != "" && webKey.Use != "sig" {
+			continue
+		}
+		key, err := webKey.publicKey()
+		if err != nil {
+			v.logger.Warning("Skipping key [%s] of the jwks of [%s]: %v", webKey.Kid, v.issuer, err)
+			continue
+		}
+		keys[webKey.Kid] = key
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no signing keys found in the jwks of [%s]", v.issuer)
+	}
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	return nil
+}
+
+// discoverJWKSURL returns the jwks_uri of the discovery document of the issuer, or the
+// OAuth2 keys endpoint of its identity application when the issuer has no discovery document.
+func (v *ArkJWKSVerifier) discoverJWKSURL() (string, error) {
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	err := v.getJSON(strings.TrimSuffix(v.issuer, "/")+"/"+oidcDiscoveryPath, "failed to discover the jwks", &discovery)
+	if err == nil && discovery.JWKSURI != "" {
+		return discovery.JWKSURI, nil
+	}
+	v.logger.Info("Failed to discover the jwks of [%s], using the identity keys endpoint: %v", v.issuer, err)
+	issuerURL, parseErr := url.Parse(v.issuer)
+	if parseErr != nil || issuerURL.Host == "" {
+		return "", fmt.Errorf("invalid issuer [%s]", v.issuer)
+	}
+	application := strings.Trim(issuerURL.Path, "/")
+	if application == "" {
+		return "", fmt.Errorf("failed to resolve the jwks of [%s]: %w", v.issuer, err)
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", issuerURL.Scheme, issuerURL.Host, jwksFallbackKeysRoute, application), nil
+}
+
+func (v *ArkJWKSVerifier) getJSON(endpoint string, errorMessage string, result interface{}) error {
+	client := common.NewSimpleArkClient(endpoint, v.clientOptions...)
+	client.SetHeaders(DefaultSystemHeaders())
+	response, err := client.Get(context.Background(), "", nil)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			v.logger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return common.NewArkAPIError(response, errorMessage)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// publicKey returns the public key of a JWK of type RSA, EC or OKP.
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve [%s]", k.Crv)
+		}
+		x, err := decodeJWKInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJWKInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("ec point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve [%s]", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type [%s]", k.Kty)
+	}
+}
+
+func decodeJWKInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid jwk integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
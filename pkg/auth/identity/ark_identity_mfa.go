@@ -0,0 +1,324 @@
+package identity
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models/common/identity"
+)
+
+const (
+	totpDefaultDigits      = 6
+	totpDefaultPeriod      = 30 * time.Second
+	fileMFAPollInterval    = 500 * time.Millisecond
+	oathMechanismName      = "oath"
+	userPasswordMechanism  = "up"
+	defaultFileMFATimeout  = pollTimeSeconds
+	otpAuthURIScheme       = "otpauth"
+	otpAuthTOTPURIHostname = "totp"
+)
+
+// totpNow and totpSleep read and wait for the clock TOTP codes are generated with, so that tests can drive it.
+var (
+	totpNow   = time.Now
+	totpSleep = time.Sleep
+)
+
+// MFAProvider answers the MFA challenges of identity authentication without user interaction.
+//
+// When set on ArkIdentity, the provider replaces the prompts, both to choose the
+// mechanism of each challenge and to supply the answer of the chosen mechanism.
+// Password challenges are still answered with the password of ArkIdentity.
+type MFAProvider interface {
+	// SelectMechanism picks the mechanism to answer a challenge with, out of the mechanisms supported by the SDK.
+	// Returning nil picks the mechanism of the MFA method ArkIdentity was created with.
+	SelectMechanism(mechanisms []*identity.Mechanism) (*identity.Mechanism, error)
+	// Answer returns the answer of the chosen mechanism, such as an OTP code.
+	// The generated auth value is the number shown along with push notifications, if any.
+	// Returning an empty answer leaves the factor to be approved out of band, such as with a push notification.
+	Answer(mechanism *identity.Mechanism, generatedAuthValue string) (string, error)
+}
+
+// selectMechanismByName returns the mechanism with the given name, or nil if there is none.
+func selectMechanismByName(mechanisms []*identity.Mechanism, name string) *identity.Mechanism {
+	for _, mechanism := range mechanisms {
+		if strings.EqualFold(mechanism.Name, name) {
+			return mechanism
+		}
+	}
+	return nil
+}
+
+// ArkTOTPMFAProvider answers OATH challenges with time-based one-time passwords generated from a seed, as defined by RFC 6238.
+type ArkTOTPMFAProvider struct {
+	key        []byte
+	digits     int
+	period     time.Duration
+	algorithm  func() hash.Hash
+	mutex      sync.Mutex
+	lastCode   string
+	lastWindow int64
+}
+
+// NewArkTOTPMFAProvider creates a TOTP provider from a seed.
+//
+// The seed is either the base32 secret shown when enrolling the OATH authenticator,
+// or the otpauth://totp URI of its QR code, whose digits, period and algorithm are used.
+// Otherwise, 6 digit codes are generated every 30 seconds with HMAC-SHA1.
+func NewArkTOTPMFAProvider(seed string) (*ArkTOTPMFAProvider, error) {
+	provider := &ArkTOTPMFAProvider{
+		digits:    totpDefaultDigits,
+		period:    totpDefaultPeriod,
+		algorithm: sha1.New,
+	}
+	secret := seed
+	if strings.HasPrefix(strings.ToLower(seed), otpAuthURIScheme+"://") {
+		var err error
+		if secret, err = provider.parseOTPAuthURI(seed); err != nil {
+			return nil, err
+		}
+	}
+	secret = strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "=", "").Replace(secret))
+	if secret == "" {
+		return nil, errors.New("totp seed must not be empty")
+	}
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("totp seed is not valid base32: %w", err)
+	}
+	provider.key = key
+	return provider, nil
+}
+
+func (p *ArkTOTPMFAProvider) parseOTPAuthURI(uri string) (string, error) {
+	parsedURI, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid totp uri: %w", err)
+	}
+	if !strings.EqualFold(parsedURI.Host, otpAuthTOTPURIHostname) {
+		return "", fmt.Errorf("unsupported otp uri type [%s], only totp is supported", parsedURI.Host)
+	}
+	query := parsedURI.Query()
+	if digits := query.Get("digits"); digits != "" {
+		if p.digits, err = strconv.Atoi(digits); err != nil || p.digits < 6 || p.digits > 10 {
+			return "", fmt.Errorf("invalid totp digits [%s]", digits)
+		}
+	}
+	if period := query.Get("period"); period != "" {
+		seconds, err := strconv.Atoi(period)
+		if err != nil || seconds <= 0 {
+			return "", fmt.Errorf("invalid totp period [%s]", period)
+		}
+		p.period = time.Duration(seconds) * time.Second
+	}
+	switch strings.ToUpper(query.Get("algorithm")) {
+	case "", "SHA1":
+		p.algorithm = sha1.New
+	case "SHA256":
+		p.algorithm = sha256.New
+	case "SHA512":
+		p.algorithm = sha512.New
+	default:
+		return "", fmt.Errorf("unsupported totp algorithm [%s]", query.Get("algorithm"))
+	}
+	return query.Get("secret"), nil
+}
+
+// Code returns the TOTP code at the given time.
+func (p *ArkTOTPMFAProvider) Code(at time.Time) string {
+	return p.code(at.Unix() / int64(p.period/time.Second))
+}
+
+func (p *ArkTOTPMFAProvider) code(window int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(window))
+	mac := hmac.New(p.algorithm, p.key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := int64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+	modulo := int64(1)
+	for i := 0; i < p.digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", p.digits, value%modulo)
+}
+
+// SelectMechanism picks the OATH mechanism.
+func (p *ArkTOTPMFAProvider) SelectMechanism(mechanisms []*identity.Mechanism) (*identity.Mechanism, error) {
+	if mechanism := selectMechanismByName(mechanisms, oathMechanismName); mechanism != nil {
+		return mechanism, nil
+	}
+	return nil, errors.New("challenge has no OATH mechanism to answer with a TOTP code")
+}
+
+// Answer returns the current TOTP code.
+// A code is not given twice, when asked again within the same period, it waits for the next code.
+func (p *ArkTOTPMFAProvider) Answer(mechanism *identity.Mechanism, generatedAuthValue string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	periodSeconds := int64(p.period / time.Second)
+	window := totpNow().Unix() / periodSeconds
+	if p.lastCode != "" && window <= p.lastWindow {
+		totpSleep(time.Unix((p.lastWindow+1)*periodSeconds, 0).Sub(totpNow()))
+		window = p.lastWindow + 1
+	}
+	p.lastWindow = window
+	p.lastCode = p.code(window)
+	return p.lastCode, nil
+}
+
+// ArkCallbackMFAProvider answers MFA challenges with a Go callback.
+type ArkCallbackMFAProvider struct {
+	mechanismName string
+	callback      func(mechanism *identity.Mechanism, generatedAuthValue string) (string, error)
+}
+
+// NewArkCallbackMFAProvider creates a provider answering the mechanism of the given name with the callback.
+// An empty mechanism name uses the MFA method of ArkIdentity.
+func NewArkCallbackMFAProvider(mechanismName string, callback func(mechanism *identity.Mechanism, generatedAuthValue string) (string, error)) (*ArkCallbackMFAProvider, error) {
+	if callback == nil {
+		return nil, errors.New("mfa callback must not be nil")
+	}
+	return &ArkCallbackMFAProvider{
+		mechanismName: mechanismName,
+		callback:      callback,
+	}, nil
+}
+
+// SelectMechanism picks the mechanism of the provider.
+func (p *ArkCallbackMFAProvider) SelectMechanism(mechanisms []*identity.Mechanism) (*identity.Mechanism, error) {
+	if p.mechanismName == "" {
+		return nil, nil
+	}
+	if mechanism := selectMechanismByName(mechanisms, p.mechanismName); mechanism != nil {
+		return mechanism, nil
+	}
+	return nil, fmt.Errorf("challenge has no [%s] mechanism", p.mechanismName)
+}
+
+// Answer returns the answer given by the callback.
+func (p *ArkCallbackMFAProvider) Answer(mechanism *identity.Mechanism, generatedAuthValue string) (string, error) {
+	return p.callback(mechanism, generatedAuthValue)
+}
+
+// ArkFileMFAProvider answers MFA challenges with codes read from a file or a named pipe.
+//
+// A regular file is polled until it holds a code, and is emptied once the code is read,
+// so that a code is not sent twice. A named pipe is read until its writer closes it.
+// The first non-empty line is used as the code.
+type ArkFileMFAProvider struct {
+	mechanismName string
+	path          string
+	timeout       time.Duration
+}
+
+// NewArkFileMFAProvider creates a provider answering the mechanism of the given name with codes read from the path.
+// An empty mechanism name uses the MFA method of ArkIdentity, and a zero timeout waits as long as identity polls for answers.
+func NewArkFileMFAProvider(mechanismName string, path string, timeout time.Duration) (*ArkFileMFAProvider, error) {
+	if path == "" {
+		return nil, errors.New("mfa code file path must not be empty")
+	}
+	if timeout <= 0 {
+		timeout = defaultFileMFATimeout
+	}
+	return &ArkFileMFAProvider{
+		mechanismName: mechanismName,
+		path:          path,
+		timeout:       timeout,
+	}, nil
+}
+
+// SelectMechanism picks the mechanism of the provider.
+func (p *ArkFileMFAProvider) SelectMechanism(mechanisms []*identity.Mechanism) (*identity.Mechanism, error) {
+	if p.mechanismName == "" {
+		return nil, nil
+	}
+	if mechanism := selectMechanismByName(mechanisms, p.mechanismName); mechanism != nil {
+		return mechanism, nil
+	}
+	return nil, fmt.Errorf("challenge has no [%s] mechanism", p.mechanismName)
+}
+
+// Answer waits for a code to be written to the file or pipe, and returns it.
+func (p *ArkFileMFAProvider) Answer(mechanism *identity.Mechanism, generatedAuthValue string) (string, error) {
+	deadline := time.Now().Add(p.timeout)
+	for {
+		info, err := os.Stat(p.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to access mfa code file [%s]: %w", p.path, err)
+		}
+		if err == nil && info.Mode()&os.ModeNamedPipe != 0 {
+			return p.readPipe(time.Until(deadline))
+		}
+		if err == nil && info.Mode().IsRegular() {
+			data, err := os.ReadFile(p.path)
+			if err != nil {
+				return "", fmt.Errorf("failed to read mfa code file [%s]: %w", p.path, err)
+			}
+			if code := firstLine(string(data)); code != "" {
+				if err := os.Truncate(p.path, 0); err != nil {
+					return "", fmt.Errorf("failed to empty mfa code file [%s]: %w", p.path, err)
+				}
+				return code, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for an mfa code in [%s]", p.path)
+		}
+		time.Sleep(fileMFAPollInterval)
+	}
+}
+
+func (p *ArkFileMFAProvider) readPipe(timeout time.Duration) (string, error) {
+	type pipeResult struct {
+		data []byte
+		err  error
+	}
+	results := make(chan pipeResult, 1)
+	go func() {
+		data, err := os.ReadFile(p.path)
+		results <- pipeResult{data, err}
+	}()
+	select {
+	case result := <-results:
+		if result.err != nil {
+			return "", fmt.Errorf("failed to read mfa code pipe [%s]: %w", p.path, result.err)
+		}
+		if code := firstLine(string(result.data)); code != "" {
+			return code, nil
+		}
+		return "", fmt.Errorf("no mfa code was written to pipe [%s]", p.path)
+	case <-time.After(timeout):
+		// Open the pipe for writing to release the pending read
+		if writer, err := os.OpenFile(p.path, os.O_WRONLY|syscall.O_NONBLOCK, 0); err == nil {
+			_ = writer.Close()
+		}
+		return "", fmt.Errorf("timed out waiting for an mfa code in pipe [%s]", p.path)
+	}
+}
+
+func firstLine(text string) string {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			return line
+		}
+	}
+	return ""
+}
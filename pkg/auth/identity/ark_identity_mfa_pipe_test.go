@@ -0,0 +1,80 @@
+//go:build !windows
+
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models/common/identity"
+)
+
+func TestArkFileMFAProvider_AnswerPipe(t *testing.T) {
+	tests := []struct {
+		name          string
+		write         string
+		timeout       time.Duration
+		expectedCode  string
+		expectedError string
+	}{
+		{
+			name:         "success_reads_code_written_to_pipe",
+			write:        "\n445566\n",
+			timeout:      5 * time.Second,
+			expectedCode: "445566",
+		},
+		{
+			name:          "error_pipe_closed_without_code",
+			write:         "\n",
+			timeout:       5 * time.Second,
+			expectedError: "no mfa code",
+		},
+		{
+			name:          "error_times_out_without_writer",
+			timeout:       200 * time.Millisecond,
+			expectedError: "timed out waiting for an mfa code in pipe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mfa-pipe")
+			if err := syscall.Mkfifo(path, 0600); err != nil {
+				t.Skipf("Named pipes are not supported: %v", err)
+			}
+			if tt.write != "" {
+				go func() {
+					writer, err := os.OpenFile(path, os.O_WRONLY, 0)
+					if err != nil {
+						return
+					}
+					_, _ = writer.WriteString(tt.write)
+					_ = writer.Close()
+				}()
+			}
+			provider, err := NewArkFileMFAProvider("", path, tt.timeout)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			start := time.Now()
+			code, err := provider.Answer(&identity.Mechanism{Name: "OATH"}, "")
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				if elapsed := time.Since(start); elapsed > tt.timeout+2*time.Second {
+					t.Errorf("Expected the pipe read to be released after %v, took %v", tt.timeout, elapsed)
+				}
+				return
+			}
+			if err != nil || code != tt.expectedCode {
+				t.Errorf("Expected code %s, got %s, %v", tt.expectedCode, code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package identity
+
+import (
+	"encoding/base32"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models/common/identity"
+)
+
+// rfc6238Seeds are the seeds of the test vectors of RFC 6238 appendix B, per algorithm.
+var rfc6238Seeds = map[string]string{
+	"SHA1":   "12345678901234567890",
+	"SHA256": "12345678901234567890123456789012",
+	"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+}
+
+func rfc6238URI(algorithm string) string {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(rfc6238Seeds[algorithm]))
+	return fmt.Sprintf("otpauth://totp/ark:user?secret=%s&digits=8&period=30&algorithm=%s", secret, algorithm)
+}
+
+func TestArkTOTPMFAProvider_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unixTime int64
+		codes    map[string]string
+	}{
+		{unixTime: 59, codes: map[string]string{"SHA1": "94287082", "SHA256": "46119246", "SHA512": "90693936"}},
+		{unixTime: 1111111109, codes: map[string]string{"SHA1": "07081804", "SHA256": "68084774", "SHA512": "25091201"}},
+		{unixTime: 1111111111, codes: map[string]string{"SHA1": "14050471", "SHA256": "67062674", "SHA512": "99943326"}},
+		{unixTime: 1234567890, codes: map[string]string{"SHA1": "89005924", "SHA256": "91819424", "SHA512": "93441116"}},
+		{unixTime: 2000000000, codes: map[string]string{"SHA1": "69279037", "SHA256": "90698825", "SHA512": "38618901"}},
+		{unixTime: 20000000000, codes: map[string]string{"SHA1": "65353130", "SHA256": "77737706", "SHA512": "47863826"}},
+	}
+
+	for _, algorithm := range []string{"SHA1", "SHA256", "SHA512"} {
+		t.Run(strings.ToLower(algorithm), func(t *testing.T) {
+			provider, err := NewArkTOTPMFAProvider(rfc6238URI(algorithm))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for _, tt := range tests {
+				if code := provider.Code(time.Unix(tt.unixTime, 0)); code != tt.codes[algorithm] {
+					t.Errorf("Expected code %s at %d, got %s", tt.codes[algorithm], tt.unixTime, code)
+				}
+			}
+		})
+	}
+}
+
+func TestNewArkTOTPMFAProvider(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte(rfc6238Seeds["SHA1"]))
+	tests := []struct {
+		name           string
+		seed           string
+		expectedDigits int
+		expectedPeriod time.Duration
+		expectedError  string
+	}{
+		{
+			name:           "success_base32_secret_with_defaults",
+			seed:           secret,
+			expectedDigits: 6,
+			expectedPeriod: 30 * time.Second,
+		},
+		{
+			name:           "success_lowercase_grouped_secret",
+			seed:           strings.ToLower(secret[:8] + " " + secret[8:16] + "-" + secret[16:]),
+			expectedDigits: 6,
+			expectedPeriod: 30 * time.Second,
+		},
+		{
+			name:           "success_uri_digits_and_period",
+			seed:           "otpauth://totp/ark:user?secret=" + secret + "&digits=8&period=60",
+			expectedDigits: 8,
+			expectedPeriod: 60 * time.Second,
+		},
+		{
+			name:           "success_uri_lowercase_algorithm",
+			seed:           "otpauth://totp/ark:user?secret=" + secret + "&algorithm=sha256",
+			expectedDigits: 6,
+			expectedPeriod: 30 * time.Second,
+		},
+		{
+			name:          "error_empty_seed",
+			seed:          "",
+			expectedError: "must not be empty",
+		},
+		{
+			name:          "error_invalid_base32",
+			seed:          "not-base32!",
+			expectedError: "not valid base32",
+		},
+		{
+			name:          "error_hotp_uri",
+			seed:          "otpauth://hotp/ark:user?secret=" + secret + "&counter=1",
+			expectedError: "only totp is supported",
+		},
+		{
+			name:          "error_too_few_digits",
+			seed:          "otpauth://totp/ark:user?secret=" + secret + "&digits=5",
+			expectedError: "invalid totp digits",
+		},
+		{
+			name:          "error_too_many_digits",
+			seed:          "otpauth://totp/ark:user?secret=" + secret + "&digits=11",
+			expectedError: "invalid totp digits",
+		},
+		{
+			name:          "error_non_numeric_digits",
+			seed:          "otpauth://totp/ark:user?secret=" + secret + "&digits=eight",
+			expectedError: "invalid totp digits",
+		},
+		{
+			name:          "error_zero_period",
+			seed:          "otpauth://totp/ark:user?secret=" + secret + "&period=0",
+			expectedError: "invalid totp period",
+		},
+		{
+			name:          "error_negative_period",
+			seed:          "otpauth://totp/ark:user?secret=" + secret + "&period=-30",
+			expectedError: "invalid totp period",
+		},
+		{
+			name:          "error_unsupported_algorithm",
+			seed:          "otpauth://totp/ark:user?secret=" + secret + "&algorithm=MD5",
+			expectedError: "unsupported totp algorithm",
+		},
+		{
+			name:          "error_uri_without_secret",
+			seed:          "otpauth://totp/ark:user?digits=6",
+			expectedError: "must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewArkTOTPMFAProvider(tt.seed)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if provider.digits != tt.expectedDigits || provider.period != tt.expectedPeriod {
+				t.Errorf("Expected %d digits every %v, got %d digits every %v", tt.expectedDigits, tt.expectedPeriod, provider.digits, provider.period)
+			}
+			if code := provider.Code(time.Unix(59, 0)); len(code) != tt.expectedDigits {
+				t.Errorf("Expected a %d digit code, got %s", tt.expectedDigits, code)
+			}
+		})
+	}
+}
+
+func TestArkTOTPMFAProvider_Answer(t *testing.T) {
+	tests := []struct {
+		name           string
+		answerTimes    []int64
+		expectedSleeps []time.Duration
+		expectedTimes  []int64
+	}{
+		{
+			name:          "success_answers_current_code",
+			answerTimes:   []int64{1111111109},
+			expectedTimes: []int64{1111111109},
+		},
+		{
+			name:           "success_waits_for_next_window_within_same_period",
+			answerTimes:    []int64{1111111111, 1111111120},
+			expectedSleeps: []time.Duration{20 * time.Second},
+			expectedTimes:  []int64{1111111111, 1111111140},
+		},
+		{
+			name:          "success_does_not_wait_in_next_period",
+			answerTimes:   []int64{1111111109, 1111111111},
+			expectedTimes: []int64{1111111109, 1111111111},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewArkTOTPMFAProvider(rfc6238URI("SHA1"))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var now time.Time
+			var sleeps []time.Duration
+			originalNow, originalSleep := totpNow, totpSleep
+			totpNow = func() time.Time { return now }
+			totpSleep = func(d time.Duration) {
+				sleeps = append(sleeps, d)
+				now = now.Add(d)
+			}
+			t.Cleanup(func() { totpNow, totpSleep = originalNow, originalSleep })
+
+			for i, answerTime := range tt.answerTimes {
+				now = time.Unix(answerTime, 0)
+				code, err := provider.Answer(&identity.Mechanism{Name: "OATH"}, "")
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if expected := provider.Code(time.Unix(tt.expectedTimes[i], 0)); code != expected {
+					t.Errorf("Expected answer %d to be the code %s, got %s", i, expected, code)
+				}
+			}
+			if len(sleeps) != len(tt.expectedSleeps) {
+				t.Fatalf("Expected waits %v, got %v", tt.expectedSleeps, sleeps)
+			}
+			for i := range sleeps {
+				if sleeps[i] != tt.expectedSleeps[i] {
+					t.Errorf("Expected waits %v, got %v", tt.expectedSleeps, sleeps)
+				}
+			}
+		})
+	}
+}
+
+func TestArkTOTPMFAProvider_SelectMechanism(t *testing.T) {
+	provider, err := NewArkTOTPMFAProvider(rfc6238URI("SHA1"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	oath := &identity.Mechanism{Name: "OATH", MechanismID: "oath-id"}
+	if mechanism, err := provider.SelectMechanism([]*identity.Mechanism{{Name: "SMS"}, oath}); err != nil || mechanism != oath {
+		t.Errorf("Expected the OATH mechanism, got %+v, %v", mechanism, err)
+	}
+	if _, err := provider.SelectMechanism([]*identity.Mechanism{{Name: "SMS"}}); err == nil {
+		t.Error("Expected an error without an OATH mechanism")
+	}
+}
+
+func TestArkFileMFAProvider_Answer(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       *string
+		writeAfter    string
+		timeout       time.Duration
+		expectedCode  string
+		expectedError string
+	}{
+		{
+			name:         "success_reads_first_non_empty_line",
+			content:      ptr("\n  123456  \n654321\n"),
+			timeout:      time.Second,
+			expectedCode: "123456",
+		},
+		{
+			name:         "success_waits_for_code_to_be_written",
+			content:      ptr(""),
+			writeAfter:   "778899\n",
+			timeout:      5 * time.Second,
+			expectedCode: "778899",
+		},
+		{
+			name:         "success_waits_for_file_to_be_created",
+			writeAfter:   "112233",
+			timeout:      5 * time.Second,
+			expectedCode: "112233",
+		},
+		{
+			name:          "error_times_out_on_empty_file",
+			content:       ptr("\n\n"),
+			timeout:       100 * time.Millisecond,
+			expectedError: "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mfa-code")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0600); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+			if tt.writeAfter != "" {
+				go func() {
+					time.Sleep(200 * time.Millisecond)
+					_ = os.WriteFile(path, []byte(tt.writeAfter), 0600)
+				}()
+			}
+			provider, err := NewArkFileMFAProvider("", path, tt.timeout)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			code, err := provider.Answer(&identity.Mechanism{Name: "OATH"}, "")
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil || code != tt.expectedCode {
+				t.Fatalf("Expected code %s, got %s, %v", tt.expectedCode, code, err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil || len(data) != 0 {
+				t.Errorf("Expected the file to be emptied after the code is read, got %q, %v", data, err)
+			}
+		})
+	}
+}
+
+func TestNewArkFileMFAProvider(t *testing.T) {
+	if _, err := NewArkFileMFAProvider("oath", "", 0); err == nil {
+		t.Error("Expected an error for an empty path")
+	}
+	provider, err := NewArkFileMFAProvider("oath", "/tmp/mfa-code", 0)
+	if err != nil || provider.timeout != defaultFileMFATimeout {
+		t.Errorf("Expected the default timeout, got %+v, %v", provider, err)
+	}
+	if _, err := provider.SelectMechanism([]*identity.Mechanism{{Name: "SMS"}}); err == nil {
+		t.Error("Expected an error without the mechanism of the provider")
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+// oauth2TokenResponse is the response of an OAuth2 token endpoint.
+type oauth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+}
+
+// newOAuth2Client creates a client posting forms to an OAuth2 endpoint.
+func newOAuth2Client(endpoint string, options []common.ArkClientOption) *common.ArkClient {
+	client := common.NewSimpleArkClient(endpoint, options...)
+	client.SetHeaders(DefaultSystemHeaders())
+	client.SetHeader("Content-Type", "application/x-www-form-urlencoded")
+	return client
+}
+
+// requestOAuth2Token posts a form to the token endpoint the session points at, and returns the issued token.
+func requestOAuth2Token(session *common.ArkClient, logger *common.ArkLogger, form map[string]string) (*oauth2TokenResponse, error) {
+	response, err := session.Post(context.Background(), "", form)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			logger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, common.NewArkAPIError(response, "token endpoint rejected the request")
+	}
+	var tokenResponse oauth2TokenResponse
+	if err := json.NewDecoder(response.Body).Decode(&tokenResponse); err != nil {
+		return nil, err
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, errors.New("access token not found in the token endpoint response")
+	}
+	return &tokenResponse, nil
+}
+
+// oauth2ErrorCode returns the OAuth2 error code of a rejected token request, such as authorization_pending, if any.
+func oauth2ErrorCode(err error) string {
+	apiErr, ok := common.AsArkAPIError(err)
+	if !ok {
+		return ""
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal([]byte(apiErr.Body), &body) != nil {
+		return ""
+	}
+	return body.Error
+}
+
+// TokenUsername returns the username a JWT was issued to, from its unique_name, preferred_username or sub claims.
+// An empty string is returned if the token is not a JWT or has none of these claims.
+func TokenUsername(token string) string {
+	claims, err := auth.ParseArkTokenClaims(token)
+	if err != nil {
+		return ""
+	}
+	return claims.Username
+}
+
+// TokenExpiration returns when an issued token expires.
+//
+// The lifetime given by the token endpoint is used first, then the exp claim of the
+// token when it is a JWT, and the default lifetime otherwise.
+func TokenExpiration(token string, lifetimeSeconds int, defaultLifetimeSeconds int) commonmodels.ArkRFC3339Time {
+	if lifetimeSeconds > 0 {
+		return commonmodels.ArkRFC3339Time(time.Now().Add(time.Duration(lifetimeSeconds) * time.Second))
+	}
+	if claims, err := auth.ParseArkTokenClaims(token); err == nil && claims.ExpiresAt != nil {
+		return *claims.ExpiresAt
+	}
+	return commonmodels.ArkRFC3339Time(time.Now().Add(time.Duration(defaultLifetimeSeconds) * time.Second))
+}
@@ -0,0 +1,524 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/toqueteos/webbrowser"
+)
+
+const (
+	defaultOIDCApplication      = "__idaptive_cybr_user_oidc"
+	defaultOIDCScope            = "openid profile offline_access"
+	defaultOIDCCallbackPath     = "/callback"
+	oidcLoginTimeout            = pollTimeSeconds
+	oidcDiscoveryPath           = ".well-known/openid-configuration"
+	deviceCodeGrantType         = "urn:ietf:params:oauth:grant-type:device_code"
+	defaultDevicePollInterval   = 5 * time.Second
+	deviceSlowDownIncrement     = 5 * time.Second
+	oidcCallbackShutdownTimeout = 5 * time.Second
+)
+
+// openBrowser opens a URL in the browser of the user, and devicePollSleep waits between two polls
+// of the device authorization flow. Both are variables so that tests can drive the flows.
+var (
+	openBrowser     = webbrowser.Open
+	devicePollSleep = time.Sleep
+)
+
+const oidcCallbackPage = `<html><head><title>Identity Security Platform</title></head>
+<body><p>%s</p><p>You may close this window.</p></body></html>`
+
+// oidcProviderMetadata holds the endpoints of an OIDC provider, as given by its discovery document.
+type oidcProviderMetadata struct {
+	Issuer                      string `json:"issuer"`
+	AuthorizationEndpoint       string `json:"authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// deviceAuthorizationResponse is the response of a device authorization endpoint, as defined by RFC 8628.
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type oidcCallbackResult struct {
+	code string
+	err  error
+}
+
+// ArkIdentityOIDC is a struct that represents an OIDC login to identity, through the browser of the user.
+//
+// The authorization code flow is used with PKCE, and the code is received by a listener on the
+// loopback interface, as recommended for native apps by RFC 8252. The state and the nonce of the
+// request are checked against the redirect and the ID token. Without a browser, the device
+// authorization flow of RFC 8628 is used instead, where the user logs in from another device.
+type ArkIdentityOIDC struct {
+	username      string
+	clientID      string
+	scope         string
+	redirectURI   string
+	deviceCode    bool
+	provider      *oidcProviderMetadata
+	logger        *common.ArkLogger
+	clientOptions []common.ArkClientOption
+	session       *common.ArkClient
+	sessionToken  string
+	refreshToken  string
+	idToken       string
+	tokenLifetime int
+}
+
+// NewArkIdentityOIDC creates a new instance of ArkIdentityOIDC.
+//
+// The endpoints are discovered from the issuer of the settings, or from the identity application,
+// whose tenant is resolved from the settings or from the suffix of the username. When the
+// application has no discovery document, the OAuth2 endpoints of identity are used.
+// The transport settings of the profile and the given client options apply to every request made to identity.
+func NewArkIdentityOIDC(username string, settings *auth.OIDCArkAuthMethodSettings, logger *common.ArkLogger, cacheProfile *models.ArkProfile, options ...common.ArkClientOption) (*ArkIdentityOIDC, error) {
+	if settings == nil {
+		settings = &auth.OIDCArkAuthMethodSettings{}
+	}
+	application := settings.IdentityAuthorizationApplication
+	if application == "" {
+		application = defaultOIDCApplication
+	}
+	identityOIDCAuth := &ArkIdentityOIDC{
+		username:      username,
+		clientID:      settings.ClientID,
+		scope:         settings.Scope,
+		redirectURI:   settings.RedirectURI,
+		deviceCode:    settings.DeviceCode,
+		logger:        logger,
+		clientOptions: profileClientOptions(cacheProfile, options),
+	}
+	if identityOIDCAuth.clientID == "" {
+		identityOIDCAuth.clientID = application
+	}
+	if identityOIDCAuth.scope == "" {
+		identityOIDCAuth.scope = defaultOIDCScope
+	}
+	if identityOIDCAuth.redirectURI != "" {
+		if _, _, err := loopbackRedirect(identityOIDCAuth.redirectURI); err != nil {
+			return nil, err
+		}
+	}
+	issuer := settings.Issuer
+	identityURL := ""
+	if issuer == "" {
+		var err error
+		identityURL, err = resolveIdentityURL(settings.IdentityURL, settings.IdentityTenantSubdomain, username, identityOIDCAuth.clientOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the identity tenant for oidc authentication: %w", err)
+		}
+		issuer = fmt.Sprintf("%s/%s/", identityURL, application)
+	}
+	provider, err := identityOIDCAuth.discoverProvider(issuer)
+	if err != nil {
+		if identityURL == "" {
+			return nil, err
+		}
+		logger.Debug("Falling back to the identity OAuth2 endpoints, discovery failed: %v", err)
+		provider = &oidcProviderMetadata{
+			Issuer:                issuer,
+			AuthorizationEndpoint: fmt.Sprintf("%s/OAuth2/Authorize/%s", identityURL, application),
+			TokenEndpoint:         fmt.Sprintf("%s/OAuth2/Token/%s", identityURL, application),
+		}
+	}
+	identityOIDCAuth.provider = provider
+	identityOIDCAuth.session = newOAuth2Client(provider.TokenEndpoint, identityOIDCAuth.clientOptions)
+	return identityOIDCAuth, nil
+}
+
+// discoverProvider reads the OIDC discovery document of the issuer.
+func (ai *ArkIdentityOIDC) discoverProvider(issuer string) (*oidcProviderMetadata, error) {
+	client := common.NewSimpleArkClient(strings.TrimSuffix(issuer, "/"), ai.clientOptions...)
+	client.SetHeaders(DefaultSystemHeaders())
+	response, err := client.Get(context.Background(), oidcDiscoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			ai.logger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, common.NewArkAPIError(response, "failed to discover the oidc provider")
+	}
+	var provider oidcProviderMetadata
+	if err := json.NewDecoder(response.Body).Decode(&provider); err != nil {
+		return nil, fmt.Errorf("invalid oidc discovery document of [%s]: %w", issuer, err)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" {
+		return nil, fmt.Errorf("oidc discovery document of [%s] lacks the authorization or token endpoint", issuer)
+	}
+	return &provider, nil
+}
+
+// AuthIdentity logs the user in through the browser, or with a device code when no browser can be opened.
+// Both flows need the user, so they are only run when interactive.
+func (ai *ArkIdentityOIDC) AuthIdentity(interactive bool) error {
+	if !interactive {
+		return errors.New("oidc login requires user interaction, cannot continue while not interactive")
+	}
+	if ai.deviceCode || !browserAvailable() {
+		return ai.authDeviceCode()
+	}
+	return ai.authCode()
+}
+
+// authCode runs the authorization code flow with PKCE, receiving the code on a loopback listener.
+func (ai *ArkIdentityOIDC) authCode() error {
+	ai.logger.Info("Logging in with oidc via issuer [%s]", ai.provider.Issuer)
+	listenAddress, callbackPath := "127.0.0.1:0", defaultOIDCCallbackPath
+	if ai.redirectURI != "" {
+		var err error
+		if listenAddress, callbackPath, err = loopbackRedirect(ai.redirectURI); err != nil {
+			return err
+		}
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the oidc redirect on [%s]: %w", listenAddress, err)
+	}
+	redirectURI := ai.redirectURI
+	if redirectURI == "" {
+		redirectURI = fmt.Sprintf("http://%s%s", listener.Addr().String(), callbackPath)
+	}
+	verifier, err := randomURLSafeString()
+	if err != nil {
+		return err
+	}
+	state, err := randomURLSafeString()
+	if err != nil {
+		return err
+	}
+	nonce, err := randomURLSafeString()
+	if err != nil {
+		return err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	results := make(chan oidcCallbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var result oidcCallbackResult
+		switch {
+		case query.Get("state") != state:
+			result.err = errors.New("oidc redirect state does not match the login request")
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("oidc login failed [%s]: %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			result.err = errors.New("oidc redirect has no authorization code")
+		default:
+			result.code = query.Get("code")
+		}
+		message := "Logged in to the Identity Security Platform."
+		if result.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			message = "Login to the Identity Security Platform failed."
+		}
+		_, _ = fmt.Fprintf(w, oidcCallbackPage, message)
+		select {
+		case results <- result:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), oidcCallbackShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}()
+
+	authorizationURL, err := url.Parse(ai.provider.AuthorizationEndpoint)
+	if err != nil {
+		return fmt.Errorf("invalid oidc authorization endpoint [%s]: %w", ai.provider.AuthorizationEndpoint, err)
+	}
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", ai.clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", ai.scope)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authorizationURL.RawQuery = query.Encode()
+
+	fmt.Printf("\nYou are now being redirected to your browser to log in\n"+
+		"If the browser did not open, you may also click the following URL to log in\n\n"+
+		"%s\n", authorizationURL.String())
+	if err := openBrowser(authorizationURL.String()); err != nil {
+		ai.logger.Debug("Failed to open the browser: %v", err)
+	}
+
+	var result oidcCallbackResult
+	select {
+	case result = <-results:
+	case <-time.After(oidcLoginTimeout):
+		return errors.New("timeout reached while waiting for the oidc login")
+	}
+	if result.err != nil {
+		return result.err
+	}
+	err = ai.requestToken(map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          result.code,
+		"redirect_uri":  redirectURI,
+		"client_id":     ai.clientID,
+		"code_verifier": verifier,
+	})
+	if err != nil {
+		return fmt.Errorf("failed exchanging the oidc authorization code via issuer [%s]: %w", ai.provider.Issuer, err)
+	}
+	if err := ai.validateIDToken(nonce); err != nil {
+		return err
+	}
+	ai.logger.Info("Logged in with oidc via issuer [%s] as user [%s]", ai.provider.Issuer, ai.Username())
+	return nil
+}
+
+// authDeviceCode runs the device authorization flow, polling the token endpoint while the user logs in from another device.
+func (ai *ArkIdentityOIDC) authDeviceCode() error {
+	if ai.provider.DeviceAuthorizationEndpoint == "" {
+		return fmt.Errorf("oidc issuer [%s] does not support the device authorization flow, which is needed without a browser", ai.provider.Issuer)
+	}
+	ai.logger.Info("Logging in with a device code via issuer [%s]", ai.provider.Issuer)
+	deviceClient := newOAuth2Client(ai.provider.DeviceAuthorizationEndpoint, ai.clientOptions)
+	response, err := deviceClient.Post(context.Background(), "", map[string]string{
+		"client_id": ai.clientID,
+		"scope":     ai.scope,
+	})
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			ai.logger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return common.NewArkAPIError(response, "device authorization endpoint rejected the request")
+	}
+	var deviceAuthorization deviceAuthorizationResponse
+	if err := json.NewDecoder(response.Body).Decode(&deviceAuthorization); err != nil {
+		return err
+	}
+	if deviceAuthorization.DeviceCode == "" || deviceAuthorization.UserCode == "" {
+		return errors.New("device code not found in the device authorization endpoint response")
+	}
+
+	fmt.Printf("\nTo log in, open the following URL on a device with a browser and enter the code [%s]\n\n%s\n",
+		deviceAuthorization.UserCode, deviceAuthorization.VerificationURI)
+	if deviceAuthorization.VerificationURIComplete != "" {
+		fmt.Printf("\nOr open the following URL, which holds the code\n\n%s\n", deviceAuthorization.VerificationURIComplete)
+	}
+
+	interval := defaultDevicePollInterval
+	if deviceAuthorization.Interval > 0 {
+		interval = time.Duration(deviceAuthorization.Interval) * time.Second
+	}
+	timeout := oidcLoginTimeout
+	if deviceAuthorization.ExpiresIn > 0 {
+		timeout = time.Duration(deviceAuthorization.ExpiresIn) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		devicePollSleep(interval)
+		err := ai.requestToken(map[string]string{
+			"grant_type":  deviceCodeGrantType,
+			"device_code": deviceAuthorization.DeviceCode,
+			"client_id":   ai.clientID,
+		})
+		if err == nil {
+			ai.logger.Info("Logged in with a device code via issuer [%s] as user [%s]", ai.provider.Issuer, ai.Username())
+			return nil
+		}
+		switch oauth2ErrorCode(err) {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += deviceSlowDownIncrement
+			continue
+		case "access_denied":
+			return errors.New("the device login was denied")
+		case "expired_token":
+			return errors.New("the device code expired before the login completed")
+		default:
+			return fmt.Errorf("failed logging in with a device code via issuer [%s]: %w", ai.provider.Issuer, err)
+		}
+	}
+	return errors.New("timeout reached while waiting for the device login")
+}
+
+// RefreshAuthIdentity exchanges a refresh token for a new token at the token endpoint.
+func (ai *ArkIdentityOIDC) RefreshAuthIdentity(refreshToken string) error {
+	ai.logger.Info("Refreshing oidc authentication via issuer [%s]", ai.provider.Issuer)
+	if refreshToken == "" {
+		return errors.New("no refresh token to refresh the oidc authentication with")
+	}
+	err := ai.requestToken(map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"client_id":     ai.clientID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed refreshing oidc authentication via issuer [%s]: %w", ai.provider.Issuer, err)
+	}
+	if ai.refreshToken == "" {
+		ai.refreshToken = refreshToken
+	}
+	return nil
+}
+
+func (ai *ArkIdentityOIDC) requestToken(form map[string]string) error {
+	tokenResponse, err := requestOAuth2Token(ai.session, ai.logger, form)
+	if err != nil {
+		return err
+	}
+	ai.sessionToken = tokenResponse.AccessToken
+	ai.refreshToken = tokenResponse.RefreshToken
+	ai.idToken = tokenResponse.IDToken
+	ai.tokenLifetime = tokenResponse.ExpiresIn
+	ai.session.UpdateToken(ai.sessionToken, "Bearer")
+	return nil
+}
+
+// validateIDToken checks that the ID token was issued for this login, by its nonce and audience.
+// The token comes straight from the token endpoint over TLS, which OIDC accepts in place of checking its signature.
+func (ai *ArkIdentityOIDC) validateIDToken(nonce string) error {
+	if ai.idToken == "" {
+		ai.logger.Debug("No ID token was issued, skipping its validation")
+		return nil
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(ai.idToken, claims); err != nil {
+		return fmt.Errorf("invalid oidc id token: %w", err)
+	}
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return errors.New("oidc id token nonce does not match the login request")
+	}
+	audience, err := claims.GetAudience()
+	if err != nil || !slices.Contains(audience, ai.clientID) {
+		return errors.New("oidc id token was not issued to the client")
+	}
+	return nil
+}
+
+// loopbackRedirect returns the address to listen on and the path of a loopback redirect URI.
+func loopbackRedirect(redirectURI string) (string, string, error) {
+	parsedURI, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid oidc redirect uri [%s]: %w", redirectURI, err)
+	}
+	host := parsedURI.Hostname()
+	if parsedURI.Scheme != "http" || (host != "localhost" && !net.ParseIP(host).IsLoopback()) {
+		return "", "", fmt.Errorf("oidc redirect uri [%s] must be an http URL on the loopback interface", redirectURI)
+	}
+	if host == "localhost" {
+		host = "127.0.0.1"
+	}
+	port := parsedURI.Port()
+	if port == "" {
+		port = "0"
+	}
+	path := parsedURI.Path
+	if path == "" {
+		path = "/"
+	}
+	return net.JoinHostPort(host, port), path, nil
+}
+
+// browserAvailable tells whether a browser can be opened for the user, which is not the case
+// in SSH sessions and on Linux hosts without a display.
+func browserAvailable() bool {
+	hasDisplay := os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return hasDisplay
+	}
+	if runtime.GOOS == "linux" || runtime.GOOS == "freebsd" {
+		return hasDisplay
+	}
+	return true
+}
+
+func randomURLSafeString() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Session returns the current identity session
+func (ai *ArkIdentityOIDC) Session() *common.ArkClient {
+	return ai.session
+}
+
+// SessionToken returns the current access token if logged in
+func (ai *ArkIdentityOIDC) SessionToken() string {
+	return ai.sessionToken
+}
+
+// RefreshToken returns the refresh token issued along with the access token, if any
+func (ai *ArkIdentityOIDC) RefreshToken() string {
+	return ai.refreshToken
+}
+
+// IDToken returns the ID token of the login, if any
+func (ai *ArkIdentityOIDC) IDToken() string {
+	return ai.idToken
+}
+
+// Username returns the username given to the constructor, or the one the tokens were issued to
+func (ai *ArkIdentityOIDC) Username() string {
+	if ai.username != "" {
+		return ai.username
+	}
+	if username := TokenUsername(ai.idToken); username != "" {
+		return username
+	}
+	return TokenUsername(ai.sessionToken)
+}
+
+// SessionExpiration returns the expiration time of the access token, using the given lifetime if the endpoint did not set one
+func (ai *ArkIdentityOIDC) SessionExpiration(defaultLifetimeSeconds int) commonmodels.ArkRFC3339Time {
+	return TokenExpiration(ai.sessionToken, ai.tokenLifetime, defaultLifetimeSeconds)
+}
+
+// IdentityURL returns the issuer
+func (ai *ArkIdentityOIDC) IdentityURL() string {
+	return ai.provider.Issuer
+}
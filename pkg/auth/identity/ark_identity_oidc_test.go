@@ -0,0 +1,337 @@
+package identity
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const oidcTestClientID = "ark-cli"
+
+// oidcTestProvider is an OIDC provider serving a discovery document, a token endpoint and a device authorization endpoint.
+type oidcTestProvider struct {
+	server *httptest.Server
+
+	mu                 sync.Mutex
+	tokenForms         []url.Values
+	tokenResponses     []func(w http.ResponseWriter, form url.Values)
+	authorizationQuery url.Values
+}
+
+func newOIDCTestProvider(t *testing.T) *oidcTestProvider {
+	p := &oidcTestProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        p.server.URL + "/app/",
+			"authorization_endpoint":        p.server.URL + "/authorize",
+			"token_endpoint":                p.server.URL + "/token",
+			"device_authorization_endpoint": p.server.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "device-code",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": p.server.URL + "/activate",
+			"expires_in":       600,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		p.mu.Lock()
+		p.tokenForms = append(p.tokenForms, r.PostForm)
+		respond := p.tokenResponses[0]
+		if len(p.tokenResponses) > 1 {
+			p.tokenResponses = p.tokenResponses[1:]
+		}
+		p.mu.Unlock()
+		respond(w, r.PostForm)
+	})
+	p.server = httptest.NewTLSServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// newAuth creates an oidc authentication against the provider, discovering its endpoints.
+func (p *oidcTestProvider) newAuth(t *testing.T) *ArkIdentityOIDC {
+	settings := &auth.OIDCArkAuthMethodSettings{Issuer: p.server.URL + "/app/", ClientID: oidcTestClientID}
+	oidcAuth, err := NewArkIdentityOIDC("", settings, common.GetLogger("test", common.Unknown), nil, common.WithRoundTripper(p.server.Client().Transport))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return oidcAuth
+}
+
+// respondWith queues the responses of the token endpoint, the last one being repeated.
+func (p *oidcTestProvider) respondWith(responses ...func(w http.ResponseWriter, form url.Values)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokenResponses = responses
+}
+
+// browser returns a browser which records the authorization request and follows the redirect with the given query.
+func (p *oidcTestProvider) browser(t *testing.T, redirectQuery func(authorizationQuery url.Values) url.Values) func(string) error {
+	return func(authorizationURL string) error {
+		parsedURL, err := url.Parse(authorizationURL)
+		if err != nil {
+			t.Errorf("Invalid authorization URL: %v", err)
+			return err
+		}
+		query := parsedURL.Query()
+		p.mu.Lock()
+		p.authorizationQuery = query
+		p.mu.Unlock()
+		go func() {
+			response, err := http.Get(query.Get("redirect_uri") + "?" + redirectQuery(query).Encode())
+			if err == nil {
+				_ = response.Body.Close()
+			}
+		}()
+		return nil
+	}
+}
+
+func oidcTokenResponse(t *testing.T, idTokenClaims jwt.MapClaims) func(w http.ResponseWriter, form url.Values) {
+	return func(w http.ResponseWriter, form url.Values) {
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, idTokenClaims).SignedString([]byte("test-key"))
+		if err != nil {
+			t.Errorf("Failed to sign id token: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-token",
+			"refresh_token": "refresh-token",
+			"id_token":      idToken,
+			"expires_in":    3600,
+		})
+	}
+}
+
+func oauth2ErrorResponse(code string) func(w http.ResponseWriter, form url.Values) {
+	return func(w http.ResponseWriter, form url.Values) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+	}
+}
+
+func withOpenBrowser(t *testing.T, browser func(string) error) {
+	original := openBrowser
+	openBrowser = browser
+	t.Cleanup(func() { openBrowser = original })
+}
+
+func TestArkIdentityOIDC_AuthCode(t *testing.T) {
+	echoState := func(query url.Values) url.Values {
+		return url.Values{"state": {query.Get("state")}, "code": {"auth-code"}}
+	}
+	tests := []struct {
+		name          string
+		redirectQuery func(query url.Values) url.Values
+		idTokenClaims func(query url.Values) jwt.MapClaims
+		expectedError string
+	}{
+		{
+			name:          "success_exchanges_code_with_pkce",
+			redirectQuery: echoState,
+			idTokenClaims: func(query url.Values) jwt.MapClaims {
+				return jwt.MapClaims{"nonce": query.Get("nonce"), "aud": oidcTestClientID, "preferred_username": "tina@cyberark.cloud"}
+			},
+		},
+		{
+			name: "error_state_mismatch",
+			redirectQuery: func(query url.Values) url.Values {
+				return url.Values{"state": {"forged-state"}, "code": {"auth-code"}}
+			},
+			expectedError: "state does not match",
+		},
+		{
+			name: "error_redirect_error",
+			redirectQuery: func(query url.Values) url.Values {
+				return url.Values{"state": {query.Get("state")}, "error": {"access_denied"}}
+			},
+			expectedError: "access_denied",
+		},
+		{
+			name:          "error_nonce_mismatch",
+			redirectQuery: echoState,
+			idTokenClaims: func(query url.Values) jwt.MapClaims {
+				return jwt.MapClaims{"nonce": "replayed-nonce", "aud": oidcTestClientID}
+			},
+			expectedError: "nonce does not match",
+		},
+		{
+			name:          "error_audience_mismatch",
+			redirectQuery: echoState,
+			idTokenClaims: func(query url.Values) jwt.MapClaims {
+				return jwt.MapClaims{"nonce": query.Get("nonce"), "aud": "another-client"}
+			},
+			expectedError: "not issued to the client",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newOIDCTestProvider(t)
+			provider.respondWith(func(w http.ResponseWriter, form url.Values) {
+				provider.mu.Lock()
+				query := provider.authorizationQuery
+				provider.mu.Unlock()
+				claims := jwt.MapClaims{}
+				if tt.idTokenClaims != nil {
+					claims = tt.idTokenClaims(query)
+				}
+				oidcTokenResponse(t, claims)(w, form)
+			})
+			withOpenBrowser(t, provider.browser(t, tt.redirectQuery))
+			oidcAuth := provider.newAuth(t)
+
+			err := oidcAuth.authCode()
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			query := provider.authorizationQuery
+			if query.Get("code_challenge_method") != "S256" || query.Get("client_id") != oidcTestClientID || query.Get("response_type") != "code" {
+				t.Errorf("Expected a PKCE authorization request, got %v", query)
+			}
+			if len(provider.tokenForms) != 1 {
+				t.Fatalf("Expected a single token request, got %d", len(provider.tokenForms))
+			}
+			form := provider.tokenForms[0]
+			challenge := sha256.Sum256([]byte(form.Get("code_verifier")))
+			if form.Get("code_verifier") == "" || query.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+				t.Errorf("Expected the code challenge to be the S256 of the verifier %q, got %q", form.Get("code_verifier"), query.Get("code_challenge"))
+			}
+			if form.Get("grant_type") != "authorization_code" || form.Get("code") != "auth-code" || form.Get("redirect_uri") != query.Get("redirect_uri") {
+				t.Errorf("Expected the code to be exchanged with the redirect uri of the request, got %v", form)
+			}
+			if oidcAuth.SessionToken() != "access-token" || oidcAuth.RefreshToken() != "refresh-token" || oidcAuth.Username() != "tina@cyberark.cloud" {
+				t.Errorf("Expected the tokens of the response, got %s, %s, %s", oidcAuth.SessionToken(), oidcAuth.RefreshToken(), oidcAuth.Username())
+			}
+		})
+	}
+}
+
+func TestArkIdentityOIDC_AuthDeviceCode(t *testing.T) {
+	tests := []struct {
+		name           string
+		responses      []string
+		expectedSleeps []time.Duration
+		expectedError  string
+	}{
+		{
+			name:           "success_polls_while_pending",
+			responses:      []string{"authorization_pending", "authorization_pending", ""},
+			expectedSleeps: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:           "success_backs_off_on_slow_down",
+			responses:      []string{"authorization_pending", "slow_down", "slow_down", ""},
+			expectedSleeps: []time.Duration{time.Second, time.Second, 6 * time.Second, 11 * time.Second},
+		},
+		{
+			name:           "error_access_denied",
+			responses:      []string{"authorization_pending", "access_denied"},
+			expectedSleeps: []time.Duration{time.Second, time.Second},
+			expectedError:  "denied",
+		},
+		{
+			name:           "error_expired_token",
+			responses:      []string{"expired_token"},
+			expectedSleeps: []time.Duration{time.Second},
+			expectedError:  "expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newOIDCTestProvider(t)
+			var responses []func(w http.ResponseWriter, form url.Values)
+			for _, code := range tt.responses {
+				if code == "" {
+					responses = append(responses, oidcTokenResponse(t, jwt.MapClaims{}))
+				} else {
+					responses = append(responses, oauth2ErrorResponse(code))
+				}
+			}
+			provider.respondWith(responses...)
+			var sleeps []time.Duration
+			original := devicePollSleep
+			devicePollSleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+			t.Cleanup(func() { devicePollSleep = original })
+			oidcAuth := provider.newAuth(t)
+
+			err := oidcAuth.authDeviceCode()
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+			} else if err != nil || oidcAuth.SessionToken() != "access-token" {
+				t.Errorf("Expected the device login to succeed, got %v", err)
+			}
+			if len(sleeps) != len(tt.expectedSleeps) {
+				t.Fatalf("Expected polls after %v, got %v", tt.expectedSleeps, sleeps)
+			}
+			for i := range sleeps {
+				if sleeps[i] != tt.expectedSleeps[i] {
+					t.Errorf("Expected polls after %v, got %v", tt.expectedSleeps, sleeps)
+					break
+				}
+			}
+			for _, form := range provider.tokenForms {
+				if form.Get("grant_type") != deviceCodeGrantType || form.Get("device_code") != "device-code" || form.Get("client_id") != oidcTestClientID {
+					t.Errorf("Expected device code token requests, got %v", form)
+				}
+			}
+		})
+	}
+}
+
+func TestLoopbackRedirect(t *testing.T) {
+	tests := []struct {
+		name            string
+		redirectURI     string
+		expectedAddress string
+		expectedPath    string
+		expectedError   bool
+	}{
+		{name: "success_localhost_with_port", redirectURI: "http://localhost:8400/callback", expectedAddress: "127.0.0.1:8400", expectedPath: "/callback"},
+		{name: "success_ipv6_loopback", redirectURI: "http://[::1]/", expectedAddress: "[::1]:0", expectedPath: "/"},
+		{name: "error_https_scheme", redirectURI: "https://127.0.0.1/callback", expectedError: true},
+		{name: "error_remote_host", redirectURI: "http://example.com/callback", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, path, err := loopbackRedirect(tt.redirectURI)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected an error for %s", tt.redirectURI)
+				}
+				return
+			}
+			if err != nil || address != tt.expectedAddress || path != tt.expectedPath {
+				t.Errorf("Expected %s %s, got %s %s, %v", tt.expectedAddress, tt.expectedPath, address, path, err)
+			}
+		})
+	}
+}
@@ -30,10 +30,11 @@ type ArkIdentityServiceUser struct {
 	session             *common.ArkClient
 	sessionToken        string
 	sessionExp          commonmodels.ArkRFC3339Time
+	clientOptions       []common.ArkClientOption
 }
 
 // NewArkIdentityServiceUser creates a new instance of ArkIdentityServiceUser.
-func NewArkIdentityServiceUser(username string, token string, appName string, identityURL string, identityTenantSubdomain string, logger *common.ArkLogger, cacheAuthentication bool, loadCache bool, cacheProfile *models.ArkProfile) (*ArkIdentityServiceUser, error) {
+func NewArkIdentityServiceUser(username string, token string, appName string, identityURL string, identityTenantSubdomain string, logger *common.ArkLogger, cacheAuthentication bool, loadCache bool, cacheProfile *models.ArkProfile, options ...common.ArkClientOption) (*ArkIdentityServiceUser, error) {
 	identityServiceAuth := &ArkIdentityServiceUser{
 		username:            username,
 		token:               token,
@@ -41,20 +42,21 @@ func NewArkIdentityServiceUser(username string, token string, appName string, id
 		identityURL:         identityURL,
 		logger:              logger,
 		cacheAuthentication: cacheAuthentication,
+		clientOptions:       profileClientOptions(cacheProfile, options),
 	}
 	var err error
 	if identityURL == "" {
 		if identityTenantSubdomain != "" {
-			identityURL, err = ResolveTenantFqdnFromTenantSubdomain(identityTenantSubdomain, commonmodels.GetDeployEnv())
+			identityURL, err = ResolveTenantFqdnFromTenantSubdomain(identityTenantSubdomain, commonmodels.GetDeployEnv(), identityServiceAuth.clientOptions...)
 		} else {
 			tenantSuffix := username[strings.Index(username, "@"):]
-			identityURL, err = ResolveTenantFqdnFromTenantSuffix(tenantSuffix, commonmodels.IdentityEnvUrls[commonmodels.GetDeployEnv()])
+			identityURL, err = ResolveTenantFqdnFromTenantSuffix(tenantSuffix, commonmodels.IdentityEnvUrls[commonmodels.GetDeployEnv()], identityServiceAuth.clientOptions...)
 		}
 	}
 	if err != nil {
 		return nil, err
 	}
-	identityServiceAuth.session = common.NewSimpleArkClient(identityURL)
+	identityServiceAuth.session = common.NewSimpleArkClient(identityURL, identityServiceAuth.clientOptions...)
 	identityServiceAuth.session.SetHeaders(DefaultSystemHeaders())
 	identityServiceAuth.session.SetHeader("Content-Type", "application/x-www-form-urlencoded")
 
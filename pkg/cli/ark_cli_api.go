@@ -8,7 +8,7 @@ import (
 
 // ArkCLIAPI is a struct that represents the Ark CLI API client.
 type ArkCLIAPI struct {
-	api.ArkAPI
+	*api.ArkAPI
 }
 
 // NewArkCLIAPI creates a new instance of ArkCLIAPI.
@@ -18,6 +18,6 @@ func NewArkCLIAPI(authenticators []auth.ArkAuth, profile *models.ArkProfile) (*A
 		return nil, err
 	}
 	return &ArkCLIAPI{
-		ArkAPI: *arkAPI,
+		ArkAPI: arkAPI,
 	}, nil
 }
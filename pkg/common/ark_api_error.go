@@ -0,0 +1,238 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by ArkAPIError through errors.Is according to the response status code.
+var (
+	// ErrBadRequest is matched by API errors with a 400 status code.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is matched by API errors with a 401 status code.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is matched by API errors with a 403 status code.
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound is matched by API errors with a 404 status code.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by API errors with a 409 status code.
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited is matched by API errors with a 429 status code.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError is matched by API errors with a 5xx status code.
+	ErrServerError = errors.New("server error")
+)
+
+// requestIDHeaders are the response headers checked, in order, for a request or correlation ID.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+	"X-Amzn-Requestid",
+	"X-Amz-Request-Id",
+	"Request-Id",
+}
+
+// Keys looked up, case insensitively, in an error response body.
+var (
+	errorBodyCodeKeys    = []string{"code", "errorcode", "error_code", "messageid", "message_id"}
+	errorBodyMessageKeys = []string{"message", "errormessage", "error_message", "error_description", "description", "detail"}
+	errorBodyDetailsKeys = []string{"details", "errordetails", "error_details", "errors"}
+)
+
+// arkServiceNameContextKey is the context key used by ArkClient to tag requests with the name of the calling service.
+type arkServiceNameContextKey struct{}
+
+// ArkAPIError is the error returned when an Ark service responds with an unexpected status code.
+//
+// ArkAPIError keeps the details of the failed request and response, so callers can
+// react to specific failures without parsing error strings. It matches the sentinel
+// errors of this package through errors.Is, according to its status code.
+//
+// Fields:
+//   - Operation: Description of the operation that failed, such as "failed to list accounts"
+//   - StatusCode: The HTTP status code of the response
+//   - Method: The HTTP method of the request
+//   - Route: The URL path of the request
+//   - ServiceName: The name of the service the request was made to, if known
+//   - RequestID: The request or correlation ID header of the response, if any
+//   - Code: The error code parsed from the response body, if any
+//   - Message: The error message parsed from the response body, if any
+//   - Details: The error details parsed from the response body, if any
+//   - Body: The response body, as returned by SerializeResponseToJSON
+//
+// Secrets found in the response body are redacted from the details and the body.
+//
+// Example:
+//
+//	_, err := safesService.AddSafe(addSafe)
+//	if errors.Is(err, common.ErrConflict) {
+//	    // The safe already exists
+//	}
+//	var apiErr *common.ArkAPIError
+//	if errors.As(err, &apiErr) {
+//	    fmt.Println(apiErr.StatusCode, apiErr.RequestID)
+//	}
+type ArkAPIError struct {
+	Operation   string      `json:"operation"`
+	StatusCode  int         `json:"status_code"`
+	Method      string      `json:"method,omitempty"`
+	Route       string      `json:"route,omitempty"`
+	ServiceName string      `json:"service_name,omitempty"`
+	RequestID   string      `json:"request_id,omitempty"`
+	Code        string      `json:"code,omitempty"`
+	Message     string      `json:"message,omitempty"`
+	Details     interface{} `json:"details,omitempty"`
+	Body        string      `json:"body,omitempty"`
+}
+
+// NewArkAPIError creates an ArkAPIError from an HTTP response with an unexpected status code.
+//
+// The response body is consumed in order to parse the error code, message and
+// details out of it, the caller is still responsible for closing it. When the
+// request was made within a service call span, the error is recorded on it.
+//
+// Parameters:
+//   - response: The HTTP response that failed
+//   - operation: Description of the operation that failed, such as "failed to list accounts"
+//
+// Returns the ArkAPIError describing the failure.
+//
+// Example:
+//
+//	if response.StatusCode != http.StatusOK {
+//	    return nil, common.NewArkAPIError(response, "failed to list accounts")
+//	}
+func NewArkAPIError(response *http.Response, operation string) *ArkAPIError {
+	apiErr := &ArkAPIError{
+		Operation:  operation,
+		StatusCode: response.StatusCode,
+	}
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+		if response.Request.URL != nil {
+			apiErr.Route = response.Request.URL.Path
+		}
+		apiErr.ServiceName = ArkServiceNameFromContext(response.Request.Context())
+	}
+	for _, header := range requestIDHeaders {
+		if requestID := response.Header.Get(header); requestID != "" {
+			apiErr.RequestID = requestID
+			break
+		}
+	}
+	if response.Body != nil {
+		data, err := io.ReadAll(response.Body)
+		if err == nil {
+			apiErr.Body = SerializeResponseToJSON(io.NopCloser(strings.NewReader(string(data))))
+			apiErr.parseBody(RedactArkJSON(data))
+		}
+	}
+	if response.Request != nil {
+		recordArkServiceError(response.Request.Context(), apiErr)
+	}
+	return apiErr
+}
+
+// parseBody fills the code, message and details of the error from a JSON error body.
+func (e *ArkAPIError) parseBody(data []byte) {
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return
+	}
+	if nested, ok := lookupErrorBodyKey(body, []string{"error"}).(map[string]interface{}); ok {
+		body = nested
+	}
+	if code := lookupErrorBodyKey(body, errorBodyCodeKeys); code != nil {
+		e.Code = fmt.Sprintf("%v", code)
+	}
+	if message, ok := lookupErrorBodyKey(body, errorBodyMessageKeys).(string); ok {
+		e.Message = message
+	}
+	e.Details = lookupErrorBodyKey(body, errorBodyDetailsKeys)
+}
+
+func lookupErrorBodyKey(body map[string]interface{}, keys []string) interface{} {
+	for _, key := range keys {
+		for bodyKey, value := range body {
+			if strings.EqualFold(bodyKey, key) && value != nil {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// Error returns the operation that failed along with the status code and body of the response.
+func (e *ArkAPIError) Error() string {
+	return fmt.Sprintf("%s - [%d] - [%s]", e.Operation, e.StatusCode, e.Body)
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package.
+func (e *ArkAPIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError && e.StatusCode <= 599
+	}
+	return false
+}
+
+// Retryable reports whether the failed request may succeed if sent again.
+//
+// Request timeouts, rate limiting and temporary gateway or availability failures
+// are considered retryable.
+func (e *ArkAPIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// AsArkAPIError returns the ArkAPIError in the chain of the given error, if there is one.
+//
+// Parameters:
+//   - err: The error to examine (can be nil)
+//
+// Returns the ArkAPIError and true if found, or nil and false otherwise.
+//
+// Example:
+//
+//	if apiErr, ok := common.AsArkAPIError(err); ok && apiErr.Retryable() {
+//	    // try again later
+//	}
+func AsArkAPIError(err error) (*ArkAPIError, bool) {
+	var apiErr *ArkAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// contextWithArkServiceName tags the context of a request with the name of the calling service.
+func contextWithArkServiceName(ctx context.Context, serviceName string) context.Context {
+	if serviceName == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, arkServiceNameContextKey{}, serviceName)
+}
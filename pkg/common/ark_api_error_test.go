@@ -0,0 +1,226 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestAPIErrorResponse(statusCode int, body string, headers map[string]string) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/api/accounts", nil)
+	response := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+	for key, value := range headers {
+		response.Header.Set(key, value)
+	}
+	return response
+}
+
+func TestNewArkAPIError(t *testing.T) {
+	tests := []struct {
+		name              string
+		statusCode        int
+		body              string
+		headers           map[string]string
+		expectedCode      string
+		expectedMessage   string
+		expectedDetails   bool
+		expectedRequestID string
+	}{
+		{
+			name:            "success_pcloud_style_body",
+			statusCode:      http.StatusNotFound,
+			body:            `{"ErrorCode":"PASWS018E","ErrorMessage":"Safe was not found"}`,
+			expectedCode:    "PASWS018E",
+			expectedMessage: "Safe was not found",
+		},
+		{
+			name:            "success_lower_case_body_with_details",
+			statusCode:      http.StatusBadRequest,
+			body:            `{"code":"INVALID_ARGUMENT","message":"bad input","details":[{"field":"name"}]}`,
+			expectedCode:    "INVALID_ARGUMENT",
+			expectedMessage: "bad input",
+			expectedDetails: true,
+		},
+		{
+			name:            "success_nested_error_object",
+			statusCode:      http.StatusConflict,
+			body:            `{"error":{"code":409,"message":"already exists"}}`,
+			expectedCode:    "409",
+			expectedMessage: "already exists",
+		},
+		{
+			name:            "success_oauth_style_body",
+			statusCode:      http.StatusUnauthorized,
+			body:            `{"error":"invalid_client","error_description":"client is not allowed"}`,
+			expectedMessage: "client is not allowed",
+		},
+		{
+			name:       "success_non_json_body",
+			statusCode: http.StatusBadGateway,
+			body:       "upstream unavailable",
+		},
+		{
+			name:              "success_request_id_header",
+			statusCode:        http.StatusInternalServerError,
+			body:              `{}`,
+			headers:           map[string]string{"X-Correlation-Id": "corr-1"},
+			expectedRequestID: "corr-1",
+		},
+		{
+			name:              "success_request_id_header_precedence",
+			statusCode:        http.StatusInternalServerError,
+			body:              `{}`,
+			headers:           map[string]string{"X-Correlation-Id": "corr-1", "X-Request-Id": "req-1"},
+			expectedRequestID: "req-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := NewArkAPIError(newTestAPIErrorResponse(tt.statusCode, tt.body, tt.headers), "failed to do something")
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("expected status code %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
+			if apiErr.Method != http.MethodGet || apiErr.Route != "/api/accounts" {
+				t.Errorf("unexpected method and route %s %s", apiErr.Method, apiErr.Route)
+			}
+			if apiErr.Code != tt.expectedCode {
+				t.Errorf("expected code %q, got %q", tt.expectedCode, apiErr.Code)
+			}
+			if apiErr.Message != tt.expectedMessage {
+				t.Errorf("expected message %q, got %q", tt.expectedMessage, apiErr.Message)
+			}
+			if (apiErr.Details != nil) != tt.expectedDetails {
+				t.Errorf("expected details presence %v, got %v", tt.expectedDetails, apiErr.Details)
+			}
+			if apiErr.RequestID != tt.expectedRequestID {
+				t.Errorf("expected request id %q, got %q", tt.expectedRequestID, apiErr.RequestID)
+			}
+			if !strings.HasPrefix(apiErr.Error(), fmt.Sprintf("failed to do something - [%d] - [", tt.statusCode)) {
+				t.Errorf("unexpected error string %q", apiErr.Error())
+			}
+		})
+	}
+}
+
+func TestArkAPIError_Is(t *testing.T) {
+	sentinels := []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrRateLimited, ErrServerError}
+	tests := []struct {
+		name       string
+		statusCode int
+		expected   error
+	}{
+		{name: "success_bad_request", statusCode: http.StatusBadRequest, expected: ErrBadRequest},
+		{name: "success_unauthorized", statusCode: http.StatusUnauthorized, expected: ErrUnauthorized},
+		{name: "success_forbidden", statusCode: http.StatusForbidden, expected: ErrForbidden},
+		{name: "success_not_found", statusCode: http.StatusNotFound, expected: ErrNotFound},
+		{name: "success_conflict", statusCode: http.StatusConflict, expected: ErrConflict},
+		{name: "success_rate_limited", statusCode: http.StatusTooManyRequests, expected: ErrRateLimited},
+		{name: "success_internal_server_error", statusCode: http.StatusInternalServerError, expected: ErrServerError},
+		{name: "success_service_unavailable", statusCode: http.StatusServiceUnavailable, expected: ErrServerError},
+		{name: "error_unmapped_status", statusCode: http.StatusTeapot, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &ArkAPIError{StatusCode: tt.statusCode})
+			for _, sentinel := range sentinels {
+				if errors.Is(err, sentinel) != (sentinel == tt.expected) {
+					t.Errorf("errors.Is(%d, %v) = %v", tt.statusCode, sentinel, !(sentinel == tt.expected))
+				}
+			}
+		})
+	}
+}
+
+func TestArkAPIError_Retryable(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   bool
+	}{
+		{statusCode: http.StatusRequestTimeout, expected: true},
+		{statusCode: http.StatusTooManyRequests, expected: true},
+		{statusCode: http.StatusBadGateway, expected: true},
+		{statusCode: http.StatusServiceUnavailable, expected: true},
+		{statusCode: http.StatusGatewayTimeout, expected: true},
+		{statusCode: http.StatusInternalServerError, expected: false},
+		{statusCode: http.StatusNotFound, expected: false},
+		{statusCode: http.StatusConflict, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			apiErr := &ArkAPIError{StatusCode: tt.statusCode}
+			if apiErr.Retryable() != tt.expected {
+				t.Errorf("expected Retryable() = %v for %d", tt.expected, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAsArkAPIError(t *testing.T) {
+	apiErr := &ArkAPIError{StatusCode: http.StatusNotFound}
+	found, ok := AsArkAPIError(fmt.Errorf("wrapped: %w", apiErr))
+	if !ok || found != apiErr {
+		t.Errorf("expected to find the wrapped ArkAPIError")
+	}
+	if _, ok := AsArkAPIError(errors.New("plain")); ok {
+		t.Errorf("expected no ArkAPIError in a plain error")
+	}
+	if _, ok := AsArkAPIError(nil); ok {
+		t.Errorf("expected no ArkAPIError in a nil error")
+	}
+}
+
+func TestNewArkAPIError_ServiceNameFromClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"missing"}`))
+	}))
+	defer server.Close()
+
+	client := NewSimpleArkClient("")
+	client.BaseURL = server.URL
+	client.SetServiceName("privilegecloud")
+	response, err := client.Get(context.Background(), "api/Safes/missing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	apiErr := NewArkAPIError(response, "failed to get safe")
+	if apiErr.ServiceName != "privilegecloud" {
+		t.Errorf("expected service name privilegecloud, got %q", apiErr.ServiceName)
+	}
+	if apiErr.Route != "/api/Safes/missing" || apiErr.Method != http.MethodGet {
+		t.Errorf("unexpected method and route %s %s", apiErr.Method, apiErr.Route)
+	}
+	if apiErr.RequestID != "abc" || apiErr.Message != "missing" {
+		t.Errorf("unexpected request id %q or message %q", apiErr.RequestID, apiErr.Message)
+	}
+	if !errors.Is(apiErr, ErrNotFound) {
+		t.Errorf("expected the error to match ErrNotFound")
+	}
+}
+
+func TestNewArkAPIError_RedactsSecrets(t *testing.T) {
+	body := `{"code":"INVALID","message":"rejected","details":{"password":"hunter2","field":"password"}}`
+	apiErr := NewArkAPIError(newTestAPIErrorResponse(http.StatusBadRequest, body, nil), "failed to add account")
+	if strings.Contains(apiErr.Error(), "hunter2") || strings.Contains(fmt.Sprintf("%v", apiErr.Details), "hunter2") {
+		t.Errorf("expected the password to be redacted from the error, got %s and %v", apiErr.Error(), apiErr.Details)
+	}
+	if details := apiErr.Details.(map[string]interface{}); details["field"] != "password" {
+		t.Errorf("expected the other details to be kept, got %v", details)
+	}
+}
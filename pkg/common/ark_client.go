@@ -15,11 +15,17 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	cookiejar "github.com/juju/persistent-cookiejar"
@@ -62,9 +68,11 @@ type cookieJSON struct {
 // - Token-based and basic authentication support
 // - Persistent cookie storage with JSON serialization
 // - Automatic retry with token refresh on 401 responses
+// - Automatic retry with backoff on rate limiting and transient failures
+// - Optional client side rate limiting and concurrency control
 // - Configurable headers for all requests
 // - Request/response logging with timing information
-// - TLS configuration support
+// - TLS, proxy, timeout and connection pool configuration through client options
 //
 // The client maintains state including authentication tokens, custom headers,
 // and cookie storage, making it suitable for session-based interactions
@@ -79,6 +87,16 @@ type ArkClient struct {
 	cookieJar                 *cookiejar.Jar
 	refreshConnectionCallback func(*ArkClient) error
 	logger                    *ArkLogger
+	serviceName               string
+	retryPolicy               *ArkRetryPolicy
+	rateLimiter               ArkRateLimiter
+	roundTripper              http.RoundTripper
+	transportConfig           *ArkTransportConfig
+	rootCAs                   *x509.CertPool
+	clientCertificates        []tls.Certificate
+	transportMutex            sync.Mutex
+	transportKey              string
+	headersMutex              sync.RWMutex
 }
 
 // MarshalCookies serializes a cookie jar into a JSON byte array.
@@ -199,6 +217,7 @@ func UnmarshalCookies(cookies []byte, cookieJar *cookiejar.Jar) error {
 //
 // Parameters:
 //   - baseURL: The base URL for the Ark service (HTTPS prefix will be added if missing)
+//   - options: Optional client options, such as WithTransportConfig
 //
 // Returns a configured ArkClient instance ready for basic HTTP operations.
 //
@@ -206,8 +225,8 @@ func UnmarshalCookies(cookies []byte, cookieJar *cookiejar.Jar) error {
 //
 //	client := NewSimpleArkClient("api.example.com")
 //	response, err := client.Get(ctx, "/users", nil)
-func NewSimpleArkClient(baseURL string) *ArkClient {
-	return NewArkClient(baseURL, "", "", "Authorization", nil, nil)
+func NewSimpleArkClient(baseURL string, options ...ArkClientOption) *ArkClient {
+	return NewArkClient(baseURL, "", "", "Authorization", nil, nil, options...)
 }
 
 // NewArkClient creates a new ArkClient instance with comprehensive configuration options.
@@ -224,6 +243,7 @@ func NewSimpleArkClient(baseURL string) *ArkClient {
 //   - authHeaderName: Name of the authorization header (e.g., "Authorization")
 //   - cookieJar: Cookie jar for session management (nil for new jar)
 //   - refreshCallback: Function to call for token refresh on 401 responses (nil to disable)
+//   - options: Optional client options configuring the transport, proxy, TLS, timeouts and more
 //
 // Returns a fully configured ArkClient instance.
 //
@@ -241,7 +261,7 @@ func NewSimpleArkClient(baseURL string) *ArkClient {
 //	        return nil
 //	    },
 //	)
-func NewArkClient(baseURL string, token string, tokenType string, authHeaderName string, cookieJar *cookiejar.Jar, refreshCallback func(*ArkClient) error) *ArkClient {
+func NewArkClient(baseURL string, token string, tokenType string, authHeaderName string, cookieJar *cookiejar.Jar, refreshCallback func(*ArkClient) error, options ...ArkClientOption) *ArkClient {
 	if baseURL != "" && !strings.HasPrefix(baseURL, "https://") {
 		baseURL = "https://" + baseURL
 	}
@@ -258,6 +278,10 @@ func NewArkClient(baseURL string, token string, tokenType string, authHeaderName
 		headers:                   make(map[string]string),
 		refreshConnectionCallback: refreshCallback,
 		logger:                    GetLogger("ArkClient", Unknown),
+		retryPolicy:               DefaultArkRetryPolicy(),
+	}
+	for _, option := range options {
+		option(client)
 	}
 	client.UpdateToken(token, tokenType)
 	client.headers["User-Agent"] = UserAgent()
@@ -279,6 +303,8 @@ func NewArkClient(baseURL string, token string, tokenType string, authHeaderName
 //	client.SetHeader("Content-Type", "application/json")
 //	client.SetHeader("Accept", "application/json")
 func (ac *ArkClient) SetHeader(key string, value string) {
+	ac.headersMutex.Lock()
+	defer ac.headersMutex.Unlock()
 	ac.headers[key] = value
 }
 
@@ -299,6 +325,8 @@ func (ac *ArkClient) SetHeader(key string, value string) {
 //	}
 //	client.SetHeaders(headers)
 func (ac *ArkClient) SetHeaders(headers map[string]string) {
+	ac.headersMutex.Lock()
+	defer ac.headersMutex.Unlock()
 	ac.headers = headers
 }
 
@@ -319,6 +347,8 @@ func (ac *ArkClient) SetHeaders(headers map[string]string) {
 //	}
 //	client.UpdateHeaders(newHeaders)
 func (ac *ArkClient) UpdateHeaders(headers map[string]string) {
+	ac.headersMutex.Lock()
+	defer ac.headersMutex.Unlock()
 	for key, value := range headers {
 		ac.headers[key] = value
 	}
@@ -337,7 +367,9 @@ func (ac *ArkClient) UpdateHeaders(headers map[string]string) {
 //	currentHeaders := client.GetHeaders()
 //	fmt.Printf("Content-Type: %s\n", currentHeaders["Content-Type"])
 func (ac *ArkClient) GetHeaders() map[string]string {
-	return ac.headers
+	ac.headersMutex.RLock()
+	defer ac.headersMutex.RUnlock()
+	return maps.Clone(ac.headers)
 }
 
 // RemoveHeader removes a single HTTP header from the ArkClient.
@@ -353,6 +385,8 @@ func (ac *ArkClient) GetHeaders() map[string]string {
 //	client.RemoveHeader("Authorization")
 //	client.RemoveHeader("X-Custom-Header")
 func (ac *ArkClient) RemoveHeader(key string) {
+	ac.headersMutex.Lock()
+	defer ac.headersMutex.Unlock()
 	delete(ac.headers, key)
 }
 
@@ -539,6 +573,7 @@ func (ac *ArkClient) GetCookieJar() *cookiejar.Jar {
 // - TLS certificate verification based on global settings
 // - Request/response timing logging
 // - Token refresh retry logic on 401 Unauthorized responses
+// - Retries with backoff on transient failures, according to the retry policy
 func (ac *ArkClient) doRequest(ctx context.Context, method string, route string, body interface{}, params map[string]string, refreshRetryCount int) (*http.Response, error) {
 	var err error
 	fullURL := ac.BaseURL
@@ -553,69 +588,198 @@ func (ac *ArkClient) doRequest(ctx context.Context, method string, route string,
 		}
 		fullURL += route
 	}
-	var bodyBytes *bytes.Buffer
+	var bodyBytes []byte
 	if body != nil {
-		if contentType, ok := ac.headers["Content-Type"]; ok && contentType == "application/x-www-form-urlencoded" {
+		ac.headersMutex.RLock()
+		contentType, ok := ac.headers["Content-Type"]
+		ac.headersMutex.RUnlock()
+		if ok && contentType == "application/x-www-form-urlencoded" {
 			if formValues, ok := body.(map[string]string); ok {
 				data := url.Values{}
 				for key, value := range formValues {
 					data.Set(key, value)
 				}
-				bodyBytes = bytes.NewBufferString(data.Encode())
+				bodyBytes = []byte(data.Encode())
 			} else {
 				return nil, fmt.Errorf("body must be of type map[string]string for x-www-form-urlencoded content type")
 			}
 		} else {
-			bodyB, err := json.Marshal(body)
+			bodyBytes, err = json.Marshal(body)
 			if err != nil {
 				return nil, err
 			}
-			bodyBytes = bytes.NewBuffer(bodyB)
 		}
 	}
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyBytes)
-	if err != nil {
-		return nil, err
-	}
-	for key, value := range ac.headers {
-		req.Header.Set(key, value)
-	}
-	if params != nil {
-		urlParams := url.Values{}
-		for key, value := range params {
-			urlParams.Add(key, value)
+	newRequest := func() (*http.Request, error) {
+		var bodyReader io.Reader
+		if bodyBytes != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
 		}
-		req.URL.RawQuery = urlParams.Encode()
-	}
-	if !IsVerifyingCertificates() {
-		ac.client.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		req, err := http.NewRequestWithContext(contextWithArkServiceName(ctx, ac.serviceName), method, fullURL, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+		ac.headersMutex.RLock()
+		for key, value := range ac.headers {
+			req.Header.Set(key, value)
 		}
-	} else {
-		ac.client.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: false},
+		ac.headersMutex.RUnlock()
+		if params != nil {
+			urlParams := url.Values{}
+			for key, value := range params {
+				urlParams.Add(key, value)
+			}
+			req.URL.RawQuery = urlParams.Encode()
 		}
+		return req, nil
 	}
-	ac.logger.Info("Running request to %s", fullURL)
+	if err = ac.updateTransport(); err != nil {
+		return nil, err
+	}
+	requestAttrs := ac.requestLogAttrs(method, fullURL)
+	ac.logger.LogAttrs(ctx, Info, "Running request to "+fullURL, requestAttrs...)
 	startTime := time.Now()
+	status := 0
 	defer func() {
 		duration := time.Since(startTime)
-		ac.logger.Info("Request to %s took %dms", fullURL, duration.Milliseconds())
+		ac.logger.LogAttrs(ctx, Info, fmt.Sprintf("Request to %s took %dms", fullURL, duration.Milliseconds()),
+			append(requestAttrs, slog.Int("status", status), slog.Int64("duration_ms", duration.Milliseconds()))...)
 	}()
-	resp, err := ac.client.Do(req)
+	resp, err := ac.doWithRetries(ctx, method, fullURL, newRequest)
+	if resp != nil {
+		status = resp.StatusCode
+	}
 	if err != nil {
+		recordArkServiceError(ctx, err)
 		return nil, err
 	}
 	if resp.StatusCode == http.StatusUnauthorized && ac.refreshConnectionCallback != nil && refreshRetryCount > 0 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
 		err = ac.refreshConnectionCallback(ac)
 		if err != nil {
+			recordArkServiceError(ctx, err)
 			return nil, err
 		}
+		ctx = telemetry.Load().recordTokenRefresh(ctx, ac.serviceName)
 		return ac.doRequest(ctx, method, route, body, params, refreshRetryCount-1)
 	}
 	return resp, nil
 }
 
+// updateTransport sets up the transport of the client from its transport settings.
+//
+// The transport is only rebuilt when the global certificate settings changed since
+// the last request, so connections are reused and concurrent requests do not race
+// on it. A round tripper given with WithRoundTripper is used as is.
+func (ac *ArkClient) updateTransport() error {
+	ac.transportMutex.Lock()
+	defer ac.transportMutex.Unlock()
+	if ac.roundTripper != nil {
+		ac.client.Transport = ac.roundTripper
+		return nil
+	}
+	transportKey := fmt.Sprintf("%t|%s", IsVerifyingCertificates(), TrustedCertificate())
+	if ac.client.Transport != nil && ac.transportKey == transportKey {
+		return nil
+	}
+	transport, err := newArkHTTPTransport(ac.transportConfig, ac.rootCAs, ac.clientCertificates)
+	if err != nil {
+		return fmt.Errorf("failed to configure client transport: %w", err)
+	}
+	ac.client.Transport = transport
+	ac.transportKey = transportKey
+	return nil
+}
+
+// doLimited sends the request once the rate limiter of the client allows it.
+//
+// The limiter is released when the response body is closed, so the concurrency
+// cap covers the whole time a response is being read.
+func (ac *ArkClient) doLimited(ctx context.Context, req *http.Request, resendCount int) (*http.Response, error) {
+	limiter := ac.rateLimiter
+	if limiter == nil {
+		limiter = GetDefaultArkRateLimiter()
+	}
+	if limiter == nil {
+		return ac.doTraced(req, resendCount)
+	}
+	release, err := limiter.Acquire(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ac.doTraced(req, resendCount)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingReadCloser{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// doTraced sends the request within a span of its own, recording its outcome in the SDK metrics.
+//
+// The span is ended once the response body is closed, so it covers reading the response.
+func (ac *ArkClient) doTraced(req *http.Request, resendCount int) (*http.Response, error) {
+	arkTelemetry := telemetry.Load()
+	req, span := arkTelemetry.startHTTPSpan(req, ac.serviceName, resendCount)
+	started := time.Now()
+	resp, err := ac.client.Do(req)
+	arkTelemetry.endHTTPSpan(req, span, ac.serviceName, resp, err, started)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &releasingReadCloser{ReadCloser: resp.Body, release: func() { span.End() }}
+	return resp, nil
+}
+
+// doWithRetries sends the request built by newRequest, sending a fresh copy of it
+// again according to the retry policy while it fails with a transient error.
+//
+// The body of every response that is retried is drained and closed, the last
+// response or error is returned to the caller.
+func (ac *ArkClient) doWithRetries(ctx context.Context, method string, fullURL string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	policy := retryPolicyFromContext(ctx, ac.retryPolicy)
+	retriable := policy.allowsMethod(ctx, method)
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := ac.doLimited(ctx, req, attempt-1)
+		if !retriable || attempt >= policy.MaxAttempts {
+			return resp, err
+		}
+		if err != nil {
+			if !policy.retriesError(err) {
+				return nil, err
+			}
+			delay, _ := policy.delay(attempt, nil)
+			telemetry.Load().recordRetry(ctx, req, ac.serviceName, "error", delay)
+			ac.logger.Warning("Request to %s failed [%v], retrying in %dms (attempt %d/%d)", fullURL, err, delay.Milliseconds(), attempt, policy.MaxAttempts)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !policy.retriesStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		delay, ok := policy.delay(attempt, resp)
+		if !ok {
+			ac.logger.Warning("Request to %s returned [%d] with a Retry-After longer than allowed, not retrying", fullURL, resp.StatusCode)
+			return resp, nil
+		}
+		telemetry.Load().recordRetry(ctx, req, ac.serviceName, strconv.Itoa(resp.StatusCode), delay)
+		ac.logger.Warning("Request to %s returned [%d], retrying in %dms (attempt %d/%d)", fullURL, resp.StatusCode, delay.Milliseconds(), attempt, policy.MaxAttempts)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
 // Get performs an HTTP GET request to the specified route.
 //
 // This method constructs and executes a GET request using the client's base URL,
@@ -777,6 +941,8 @@ func (ac *ArkClient) Options(ctx context.Context, route string) (*http.Response,
 //	// API key
 //	client.UpdateToken("api-key-value", "API-Key")
 func (ac *ArkClient) UpdateToken(token string, tokenType string) {
+	ac.headersMutex.Lock()
+	defer ac.headersMutex.Unlock()
 	ac.token = token
 	ac.tokenType = tokenType
 	if token != "" {
@@ -784,6 +950,26 @@ func (ac *ArkClient) UpdateToken(token string, tokenType string) {
 	}
 }
 
+// Close releases the idle connections of the client and wipes its authentication token.
+//
+// The client can still be used afterwards, new connections are opened as needed,
+// but requests are no longer authorized until UpdateToken is called.
+//
+// Example:
+//
+//	defer client.Close()
+func (ac *ArkClient) Close() {
+	ac.transportMutex.Lock()
+	if ac.client.Transport != nil {
+		ac.client.CloseIdleConnections()
+	}
+	ac.transportMutex.Unlock()
+	ac.headersMutex.Lock()
+	defer ac.headersMutex.Unlock()
+	ac.token = ""
+	delete(ac.headers, ac.authHeaderName)
+}
+
 // GetToken returns the current authentication token.
 //
 // This method returns the raw token string that was set via UpdateToken().
@@ -798,6 +984,8 @@ func (ac *ArkClient) UpdateToken(token string, tokenType string) {
 //	    // No authentication token is set
 //	}
 func (ac *ArkClient) GetToken() string {
+	ac.headersMutex.RLock()
+	defer ac.headersMutex.RUnlock()
 	return ac.token
 }
 
@@ -813,5 +1001,88 @@ func (ac *ArkClient) GetToken() string {
 //	tokenType := client.GetTokenType()
 //	fmt.Printf("Using %s authentication\n", tokenType)
 func (ac *ArkClient) GetTokenType() string {
+	ac.headersMutex.RLock()
+	defer ac.headersMutex.RUnlock()
 	return ac.tokenType
 }
+
+// SetServiceName sets the name of the service the client makes requests to.
+//
+// The service name is attached to every ArkAPIError created from the responses
+// of the client, making it easier to tell which service a failure came from.
+//
+// Parameters:
+//   - serviceName: The name of the service, such as "privilegecloud"
+//
+// Example:
+//
+//	client.SetServiceName("privilegecloud")
+func (ac *ArkClient) SetServiceName(serviceName string) {
+	ac.serviceName = serviceName
+}
+
+func (ac *ArkClient) requestLogAttrs(method string, fullURL string) []slog.Attr {
+	route := fullURL
+	if parsedURL, err := url.Parse(fullURL); err == nil {
+		route = arkRouteTemplate(parsedURL.Path)
+	}
+	attrs := []slog.Attr{slog.String("method", method), slog.String("route", route)}
+	if ac.serviceName != "" {
+		attrs = append(attrs, slog.String("service", ac.serviceName))
+	}
+	return attrs
+}
+
+// GetServiceName returns the name of the service the client makes requests to.
+//
+// Returns the service name, or an empty string if none was set.
+func (ac *ArkClient) GetServiceName() string {
+	return ac.serviceName
+}
+
+// SetRetryPolicy sets the policy used to retry requests that failed with a transient error.
+//
+// A nil policy disables retries. The policy can be overridden for single calls
+// through the context of the request, see WithArkRetryPolicy.
+//
+// Parameters:
+//   - policy: The retry policy to use for subsequent requests
+//
+// Example:
+//
+//	policy := common.DefaultArkRetryPolicy()
+//	policy.RetryNonIdempotent = true
+//	client.SetRetryPolicy(policy)
+func (ac *ArkClient) SetRetryPolicy(policy *ArkRetryPolicy) {
+	ac.retryPolicy = policy
+}
+
+// GetRetryPolicy returns the policy used to retry requests that failed with a transient error.
+//
+// Returns the retry policy, or nil if retries are disabled.
+func (ac *ArkClient) GetRetryPolicy() *ArkRetryPolicy {
+	return ac.retryPolicy
+}
+
+// SetRateLimiter sets the rate limiter of the client.
+//
+// Clients without a limiter of their own use the one set with SetDefaultArkRateLimiter.
+// Share a single limiter between clients to apply its limits to all of them.
+//
+// Parameters:
+//   - limiter: The rate limiter to use, or nil to fall back to the default limiter
+//
+// Example:
+//
+//	limiter := common.NewArkTokenBucketRateLimiter(common.DefaultArkRateLimiterConfig())
+//	client.SetRateLimiter(limiter)
+func (ac *ArkClient) SetRateLimiter(limiter ArkRateLimiter) {
+	ac.rateLimiter = limiter
+}
+
+// GetRateLimiter returns the rate limiter of the client.
+//
+// Returns the rate limiter set with SetRateLimiter, or nil if the client uses the default limiter.
+func (ac *ArkClient) GetRateLimiter() ArkRateLimiter {
+	return ac.rateLimiter
+}
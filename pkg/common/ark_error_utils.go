@@ -2,6 +2,7 @@ package common
 
 import (
 	"errors"
+	"io"
 	"net"
 	"os"
 	"syscall"
@@ -35,3 +36,30 @@ func IsConnectionRefused(err error) bool {
 	}
 	return errors.Is(err, syscall.ECONNREFUSED)
 }
+
+// IsConnectionReset checks if the error is caused by a connection that was reset or closed by the peer.
+//
+// This covers ECONNRESET and EPIPE at any level of nesting, as well as the
+// unexpected EOF returned by the HTTP client when a server drops a reused
+// keep-alive connection before answering.
+//
+// Parameters:
+//   - err: The error to examine (can be nil)
+//
+// Returns true if the error represents a reset or dropped connection, false otherwise.
+//
+// Example:
+//
+//	response, err := httpClient.Do(req)
+//	if err != nil && IsConnectionReset(err) {
+//	    // safe to send the request again
+//	}
+func IsConnectionReset(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
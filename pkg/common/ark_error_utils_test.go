@@ -2,7 +2,10 @@ package common
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"net"
+	"net/url"
 	"os"
 	"syscall"
 	"testing"
@@ -129,3 +132,63 @@ func TestIsConnectionRefused(t *testing.T) {
 		})
 	}
 }
+
+func TestIsConnectionReset(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedResult bool
+	}{
+		{
+			name:           "nil_error",
+			err:            nil,
+			expectedResult: false,
+		},
+		{
+			name:           "generic_error",
+			err:            errors.New("some random error"),
+			expectedResult: false,
+		},
+		{
+			name:           "connection_refused",
+			err:            syscall.ECONNREFUSED,
+			expectedResult: false,
+		},
+		{
+			name: "net.OpError_with_os.SyscallError_ECONNRESET",
+			err: &net.OpError{
+				Op:  "read",
+				Net: "tcp",
+				Err: &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET},
+			},
+			expectedResult: true,
+		},
+		{
+			name:           "broken_pipe",
+			err:            &os.SyscallError{Syscall: "write", Err: syscall.EPIPE},
+			expectedResult: true,
+		},
+		{
+			name:           "url.Error_with_EOF",
+			err:            &url.Error{Op: "Get", URL: "https://example.com", Err: io.EOF},
+			expectedResult: true,
+		},
+		{
+			name:           "wrapped_unexpected_EOF",
+			err:            fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF),
+			expectedResult: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := IsConnectionReset(tt.err)
+
+			if result != tt.expectedResult {
+				t.Errorf("IsConnectionReset(%v) = %v, want %v", tt.err, result, tt.expectedResult)
+			}
+		})
+	}
+}
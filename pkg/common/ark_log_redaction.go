@@ -0,0 +1,323 @@
+package common
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ArkRedactedValue replaces the secrets found in logged messages, attributes and error bodies.
+const ArkRedactedValue = "[REDACTED]"
+
+// ArkRedactTag is the struct tag marking the fields of a model that hold secrets, used as `redact:"true"`.
+const ArkRedactTag = "redact"
+
+var (
+	// Keys are matched after being lowercased and stripped of "_", "-" and "."
+	defaultRedactedKeys = []string{
+		"password", "newpassword", "oldpassword", "passphrase",
+		"secret", "clientsecret", "secretaccesskey",
+		"token", "accesstoken", "refreshtoken", "idtoken", "sessiontoken", "authtoken", "bearertoken",
+		"privatekey", "privatekeycontents", "apikey", "credentials", "newcredentials",
+		"authorization", "cookie", "setcookie",
+	}
+	defaultRedactedKeySuffixes = []string{"password", "secret", "privatekey", "accesstoken", "refreshtoken", "apikey", "totpseed"}
+	defaultRedactedHeaders     = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token"}
+
+	// "key": "value" in JSON bodies
+	redactJSONPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// Name: value in raw headers
+	redactHeaderLinePattern = regexp.MustCompile(`(?m)^([A-Za-z0-9-]+)(\s*:\s*)[^\r\n]+`)
+	// map[Name:[value]] in printed http.Header values
+	redactHeaderMapPattern = regexp.MustCompile(`\b([A-Za-z0-9-]+):\[[^\]]*\]`)
+	// Bearer value and Basic value anywhere in the text
+	redactSchemePattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+	// key=value in query strings and form bodies
+	redactKeyValuePattern = regexp.MustCompile(`([\w.-]+)=([^&;,\s]+)`)
+)
+
+// arkRedactionRegistry holds the JSON keys and header names whose values are redacted.
+type arkRedactionRegistry struct {
+	mutex       sync.RWMutex
+	keys        map[string]bool
+	keySuffixes []string
+	headers     map[string]bool
+}
+
+var redactionRegistry = newArkRedactionRegistry()
+
+func newArkRedactionRegistry() *arkRedactionRegistry {
+	registry := &arkRedactionRegistry{
+		keys:        map[string]bool{},
+		keySuffixes: append([]string{}, defaultRedactedKeySuffixes...),
+		headers:     map[string]bool{},
+	}
+	registry.registerKeys(defaultRedactedKeys...)
+	registry.registerHeaders(defaultRedactedHeaders...)
+	return registry
+}
+
+func normalizeRedactedKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+}
+
+func (r *arkRedactionRegistry) registerKeys(keys ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, key := range keys {
+		if key = normalizeRedactedKey(key); key != "" {
+			r.keys[key] = true
+		}
+	}
+}
+
+func (r *arkRedactionRegistry) registerHeaders(headers ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, header := range headers {
+		if header != "" {
+			r.headers[http.CanonicalHeaderKey(header)] = true
+		}
+	}
+}
+
+func (r *arkRedactionRegistry) isSensitiveKey(key string) bool {
+	key = normalizeRedactedKey(key)
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.keys[key] {
+		return true
+	}
+	for _, suffix := range r.keySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *arkRedactionRegistry) isSensitiveHeader(header string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.headers[http.CanonicalHeaderKey(header)]
+}
+
+// isSensitiveLogKey tells whether the values of a key are redacted, per the registry.
+func isSensitiveLogKey(key string) bool {
+	return redactionRegistry.isSensitiveKey(key)
+}
+
+// RegisterArkRedactedKeys adds JSON keys whose values are redacted from logs and errors.
+//
+// Keys are matched case insensitively, ignoring "_", "-" and ".", so registering
+// "private_key" also covers "privateKey" and "PrivateKey".
+//
+// Parameters:
+//   - keys: The keys to redact
+//
+// Example:
+//
+//	common.RegisterArkRedactedKeys("wallet", "pem_wallet")
+func RegisterArkRedactedKeys(keys ...string) {
+	redactionRegistry.registerKeys(keys...)
+}
+
+// RegisterArkRedactedHeaders adds headers whose values are redacted from logs and errors.
+//
+// Parameters:
+//   - headers: The header names to redact, matched case insensitively
+func RegisterArkRedactedHeaders(headers ...string) {
+	redactionRegistry.registerHeaders(headers...)
+}
+
+// RegisterArkRedactedModels adds the keys of the model fields tagged with `redact:"true"` to the redacted keys.
+//
+// The JSON and mapstructure names of the tagged fields are registered. Nested and
+// embedded structs are walked as well. Services register their models through the
+// RedactedModels of their service configuration.
+//
+// Parameters:
+//   - models: Values or pointers of the model structs
+//
+// Example:
+//
+//	type ArkPCloudAccountCredentials struct {
+//	    AccountID string `json:"account_id"`
+//	    Password  string `json:"password" redact:"true"`
+//	}
+//	common.RegisterArkRedactedModels(ArkPCloudAccountCredentials{})
+func RegisterArkRedactedModels(models ...interface{}) {
+	for _, model := range models {
+		redactionRegistry.registerKeys(redactedModelKeys(reflect.TypeOf(model), map[reflect.Type]bool{})...)
+	}
+}
+
+func redactedModelKeys(modelType reflect.Type, visited map[reflect.Type]bool) []string {
+	for modelType != nil && (modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array || modelType.Kind() == reflect.Map) {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct || visited[modelType] {
+		return nil
+	}
+	visited[modelType] = true
+	var keys []string
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Tag.Get(ArkRedactTag) == "true" {
+			keys = append(keys, field.Name)
+			for _, tag := range []string{"json", "mapstructure"} {
+				if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+					keys = append(keys, name)
+				}
+			}
+			continue
+		}
+		keys = append(keys, redactedModelKeys(field.Type, visited)...)
+	}
+	return keys
+}
+
+// RedactArkString masks the secrets found in free text, such as log messages and response bodies.
+//
+// The values of redacted keys in JSON and in key=value pairs, the values of
+// redacted headers, and Bearer and Basic credentials are replaced with ArkRedactedValue.
+// Log messages of ArkLogger are redacted the same way.
+//
+// Parameters:
+//   - text: The text to redact
+//
+// Returns the redacted text.
+func RedactArkString(text string) string {
+	return redactArkLogMessage(text)
+}
+
+// redactArkLogMessage masks the secrets found in a log message or any other free text.
+func redactArkLogMessage(text string) string {
+	text = redactJSONPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := redactJSONPattern.FindStringSubmatch(match)
+		if !isSensitiveLogKey(groups[1]) {
+			return match
+		}
+		return `"` + groups[1] + `"` + groups[2] + `"` + ArkRedactedValue + `"`
+	})
+	text = redactHeaderLinePattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := redactHeaderLinePattern.FindStringSubmatch(match)
+		if !redactionRegistry.isSensitiveHeader(groups[1]) {
+			return match
+		}
+		return groups[1] + groups[2] + ArkRedactedValue
+	})
+	text = redactHeaderMapPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := redactHeaderMapPattern.FindStringSubmatch(match)
+		if !redactionRegistry.isSensitiveHeader(groups[1]) {
+			return match
+		}
+		return groups[1] + ":[" + ArkRedactedValue + "]"
+	})
+	text = redactSchemePattern.ReplaceAllString(text, "${1} "+ArkRedactedValue)
+	text = redactKeyValuePattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := redactKeyValuePattern.FindStringSubmatch(match)
+		if !isSensitiveLogKey(groups[1]) || groups[2] == ArkRedactedValue {
+			return match
+		}
+		return groups[1] + "=" + ArkRedactedValue
+	})
+	return text
+}
+
+// RedactArkJSON masks the values of the redacted keys in a JSON document.
+//
+// Data that is not valid JSON is redacted as free text with RedactArkString.
+//
+// Parameters:
+//   - data: The JSON document to redact
+//
+// Returns the redacted document.
+func RedactArkJSON(data []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []byte(redactArkLogMessage(string(data)))
+	}
+	redacted, err := json.Marshal(redactArkJSONValue(value))
+	if err != nil {
+		return []byte(redactArkLogMessage(string(data)))
+	}
+	return redacted
+}
+
+func redactArkJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveLogKey(key) {
+				v[key] = ArkRedactedValue
+			} else {
+				v[key] = redactArkJSONValue(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactArkJSONValue(item)
+		}
+		return v
+	case string:
+		return redactArkLogMessage(v)
+	}
+	return value
+}
+
+// RedactArkHeaders returns a copy of the headers with the values of the redacted headers masked.
+//
+// Parameters:
+//   - headers: The headers to redact, left untouched
+//
+// Returns the redacted copy.
+func RedactArkHeaders(headers http.Header) http.Header {
+	return redactArkHeaders(headers)
+}
+
+// redactArkHeaders returns a copy of the headers with the values of sensitive headers masked.
+func redactArkHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for name := range redacted {
+		if redactionRegistry.isSensitiveHeader(name) {
+			redacted[name] = []string{ArkRedactedValue}
+		}
+	}
+	return redacted
+}
+
+// redactArkLogAttr masks the value of an attribute when its key is redacted,
+// and masks the secrets found in string, header, error and group values otherwise.
+func redactArkLogAttr(attr slog.Attr) slog.Attr {
+	if isSensitiveLogKey(attr.Key) {
+		return slog.String(attr.Key, ArkRedactedValue)
+	}
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, redactArkLogMessage(value.String()))
+	case slog.KindGroup:
+		groupAttrs := value.Group()
+		redacted := make([]slog.Attr, len(groupAttrs))
+		for i, groupAttr := range groupAttrs {
+			redacted[i] = redactArkLogAttr(groupAttr)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case http.Header:
+			return slog.Any(attr.Key, redactArkHeaders(v))
+		case map[string][]string:
+			return slog.Any(attr.Key, map[string][]string(redactArkHeaders(v)))
+		case error:
+			return slog.String(attr.Key, redactArkLogMessage(v.Error()))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
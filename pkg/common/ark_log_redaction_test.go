@@ -0,0 +1,143 @@
+package common
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactArkLogMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{
+			name:     "success_json_fields",
+			message:  `{"user":"admin","password":"p\"ss","refresh_token": "abc"}`,
+			expected: `{"user":"admin","password":"[REDACTED]","refresh_token": "[REDACTED]"}`,
+		},
+		{
+			name:     "success_bearer_token",
+			message:  "Using Bearer eyJhbGciOi.eyJzdWIi.sig for the request",
+			expected: "Using Bearer [REDACTED] for the request",
+		},
+		{
+			name:     "success_header_map",
+			message:  "Headers map[Accept:[application/json] Authorization:[Basic dXNlcjpwYXNz]]",
+			expected: "Headers map[Accept:[application/json] Authorization:[[REDACTED]]]",
+		},
+		{
+			name:     "success_header_lines",
+			message:  "GET /api HTTP/1.1\nCookie: a=b; c=d\nAccept: */*",
+			expected: "GET /api HTTP/1.1\nCookie: [REDACTED]\nAccept: */*",
+		},
+		{
+			name:     "success_query_parameters",
+			message:  "https://tenant.example.com/oauth?client_id=app&client_secret=s3cr3t&scope=all",
+			expected: "https://tenant.example.com/oauth?client_id=app&client_secret=[REDACTED]&scope=all",
+		},
+		{
+			name:     "success_totp_seed",
+			message:  `{"identity_mfa_method":"oath","identity_mfa_totp_seed":"JBSWY3DPEHPK3PXP"}`,
+			expected: `{"identity_mfa_method":"oath","identity_mfa_totp_seed":"[REDACTED]"}`,
+		},
+		{
+			name:     "success_similar_keys_kept",
+			message:  `{"secret_type":"password","secrets_count":3,"next_token":"abc"}`,
+			expected: `{"secret_type":"password","secrets_count":3,"next_token":"abc"}`,
+		},
+		{
+			name:     "success_no_secrets",
+			message:  "Request to https://tenant.example.com/api/Accounts took 12ms",
+			expected: "Request to https://tenant.example.com/api/Accounts took 12ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := redactArkLogMessage(tt.message); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRedactArkLogAttr(t *testing.T) {
+	headers := http.Header{"Set-Cookie": {"session=1"}, "X-Request-Id": {"42"}}
+	redacted := redactArkLogAttr(slog.Any("headers", headers)).Value.Any().(http.Header)
+	if redacted.Get("Set-Cookie") != ArkRedactedValue || redacted.Get("X-Request-Id") != "42" {
+		t.Errorf("unexpected redacted headers %v", redacted)
+	}
+	if headers.Get("Set-Cookie") != "session=1" {
+		t.Errorf("expected the original headers to be left untouched")
+	}
+	if attr := redactArkLogAttr(slog.Int("api_key", 3)); attr.Value.String() != ArkRedactedValue {
+		t.Errorf("expected attributes named after secrets to be redacted, got %v", attr)
+	}
+	if attr := redactArkLogAttr(slog.Int("status", 200)); attr.Value.Int64() != 200 {
+		t.Errorf("expected other attributes to be kept, got %v", attr)
+	}
+}
+
+func TestRedactArkJSON(t *testing.T) {
+	data := []byte(`{"accounts":[{"id":"1","userName":"admin","Password":"p@ss"}],"metadata":{"privateKey":"-----BEGIN","count":1}}`)
+	var redacted map[string]interface{}
+	if err := json.Unmarshal(RedactArkJSON(data), &redacted); err != nil {
+		t.Fatalf("expected valid JSON: %v", err)
+	}
+	account := redacted["accounts"].([]interface{})[0].(map[string]interface{})
+	if account["Password"] != ArkRedactedValue || account["userName"] != "admin" {
+		t.Errorf("unexpected redacted account %v", account)
+	}
+	metadata := redacted["metadata"].(map[string]interface{})
+	if metadata["privateKey"] != ArkRedactedValue || metadata["count"] != float64(1) {
+		t.Errorf("unexpected redacted metadata %v", metadata)
+	}
+	if result := string(RedactArkJSON([]byte("password=hunter2"))); result != "password="+ArkRedactedValue {
+		t.Errorf("expected text to be redacted as well, got %q", result)
+	}
+}
+
+type testRedactedCredentials struct {
+	Host   string `json:"host"`
+	Wallet string `json:"wallet_blob" mapstructure:"wallet_blob" redact:"true"`
+}
+
+type testRedactedModel struct {
+	testRedactedCredentials `mapstructure:",squash"`
+	Nested                  []*struct {
+		Passcode string `json:"passcode" redact:"true"`
+	} `json:"nested"`
+}
+
+func TestRegisterArkRedactedModels(t *testing.T) {
+	data := `{"host":"db.example.com","wallet_blob":"AAAA","nested":[{"passcode":"1234"}]}`
+	if redacted := string(RedactArkJSON([]byte(data))); !strings.Contains(redacted, "AAAA") || !strings.Contains(redacted, "1234") {
+		t.Fatalf("expected the keys not to be redacted before registration, got %s", redacted)
+	}
+
+	RegisterArkRedactedModels(&testRedactedModel{})
+	RegisterArkRedactedHeaders("X-Tenant-Secret")
+	defer func() { redactionRegistry = newArkRedactionRegistry() }()
+
+	redacted := string(RedactArkJSON([]byte(data)))
+	if strings.Contains(redacted, "AAAA") || strings.Contains(redacted, "1234") || !strings.Contains(redacted, "db.example.com") {
+		t.Errorf("expected the tagged fields to be redacted, got %s", redacted)
+	}
+	headers := RedactArkHeaders(http.Header{"X-Tenant-Secret": {"value"}})
+	if headers.Get("X-Tenant-Secret") != ArkRedactedValue {
+		t.Errorf("expected the registered header to be redacted, got %v", headers)
+	}
+}
+
+func TestSerializeResponseToJSON_Redaction(t *testing.T) {
+	body := io.NopCloser(strings.NewReader(`{"token":{"key":"short-lived"},"metadata":{"expires_at":"2026-01-01T00:00:00Z"}}`))
+	result := SerializeResponseToJSON(body)
+	if strings.Contains(result, "short-lived") || !strings.Contains(result, "expires_at") {
+		t.Errorf("expected the token to be redacted, got %s", result)
+	}
+}
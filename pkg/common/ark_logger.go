@@ -5,10 +5,16 @@
 package common
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"os"
+	"runtime"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // Log level constants for ArkLogger.
@@ -33,16 +39,26 @@ const (
 	LoggerStyle        = "LOGGER_STYLE"
 	LogLevel           = "LOG_LEVEL"
 	LoggerStyleDefault = "default"
+	LoggerStyleJSON    = "json"
 )
 
+// LevelFatal is the slog level of the messages logged with ArkLogger.Fatal.
+const LevelFatal = slog.LevelError + 4
+
 // ArkLogger provides structured logging with configurable levels and output formatting.
 //
-// ArkLogger wraps the standard log.Logger and adds features like:
+// ArkLogger is built on log/slog and adds features like:
 // - Configurable log levels (Debug, Info, Warning, Error, Critical)
-// - Color-coded console output
+// - Color-coded console output, or JSON output
+// - Structured attributes, attached per logger or per message
+// - Redaction of tokens, passwords, authorization headers and cookies
 // - Environment variable configuration
 // - Verbose mode control
 //
+// Records are passed to the slog.Handler of the logger, or to the handler set by
+// SetArkLogHandler when the logger has none. Without any handler, records are
+// written as colored text through the embedded log.Logger.
+//
 // The logger supports both static configuration and dynamic environment-based
 // configuration for flexible deployment scenarios.
 type ArkLogger struct {
@@ -51,6 +67,55 @@ type ArkLogger struct {
 	logLevel               int
 	name                   string
 	resolveLogLevelFromEnv bool
+	handler                atomic.Pointer[arkLogHandlerHolder]
+	attrs                  []slog.Attr
+}
+
+// arkLogHandlerHolder allows storing a nil handler in an atomic pointer.
+type arkLogHandlerHolder struct {
+	handler slog.Handler
+}
+
+var defaultLogHandler atomic.Pointer[arkLogHandlerHolder]
+
+// SetArkLogHandler routes the records of every ArkLogger without a handler of its own to the given handler.
+//
+// This lets embedding applications send the SDK logs to their own log pipeline,
+// along with their own attributes. The verbosity and log level of the loggers
+// still apply, and secrets are redacted before records reach the handler.
+//
+// Parameters:
+//   - handler: The handler to use, nil restores the default text output
+//
+// Example:
+//
+//	handler := slog.NewJSONHandler(os.Stderr, nil).WithAttrs([]slog.Attr{slog.String("tenant", "acme")})
+//	common.SetArkLogHandler(handler)
+func SetArkLogHandler(handler slog.Handler) {
+	defaultLogHandler.Store(&arkLogHandlerHolder{handler: handler})
+}
+
+// NewArkJSONHandler creates a slog.Handler that writes records as JSON lines.
+//
+// Levels are named after the ArkLogger levels, so records logged with Warning
+// and Fatal have the "WARNING" and "FATAL" levels.
+//
+// Parameters:
+//   - w: The writer to write the records to
+//
+// Returns the JSON handler.
+func NewArkJSONHandler(w io.Writer) slog.Handler {
+	return slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.LevelKey {
+				if level, ok := attr.Value.Any().(slog.Level); ok {
+					attr.Value = slog.StringValue(arkLevelName(level))
+				}
+			}
+			return attr
+		},
+	})
 }
 
 // NewArkLogger creates a new instance of ArkLogger with the specified configuration.
@@ -162,6 +227,218 @@ func (l *ArkLogger) SetVerbose(value bool) {
 	l.verbose = value
 }
 
+// SetHandler sets the slog.Handler the records of the logger are passed to.
+// It is safe to call while other goroutines log with the logger.
+//
+// Parameters:
+//   - handler: The handler to use, nil falls back to the handler set by SetArkLogHandler or the default text output
+func (l *ArkLogger) SetHandler(handler slog.Handler) {
+	l.handler.Store(&arkLogHandlerHolder{handler: handler})
+}
+
+// With returns a copy of the logger that adds the given attributes to every record.
+//
+// The arguments are converted to attributes the same way slog.Logger.With does,
+// so they may be slog.Attr values or alternating keys and values.
+//
+// Parameters:
+//   - args: The attributes to add
+//
+// Returns the new logger.
+//
+// Example:
+//
+//	tenantLogger := logger.With("tenant", "acme", "profile", "ark")
+//	tenantLogger.Info("Listing accounts")
+func (l *ArkLogger) With(args ...any) *ArkLogger {
+	record := slog.NewRecord(time.Time{}, slog.LevelInfo, "", 0)
+	record.Add(args...)
+	logger := &ArkLogger{
+		Logger:                 l.Logger,
+		verbose:                l.verbose,
+		logLevel:               l.logLevel,
+		name:                   l.name,
+		resolveLogLevelFromEnv: l.resolveLogLevelFromEnv,
+		attrs:                  append(make([]slog.Attr, 0, len(l.attrs)+record.NumAttrs()), l.attrs...),
+	}
+	logger.handler.Store(l.handler.Load())
+	record.Attrs(func(attr slog.Attr) bool {
+		logger.attrs = append(logger.attrs, redactArkLogAttr(attr))
+		return true
+	})
+	return logger
+}
+
+// LogAttrs logs a message with structured attributes at the given level.
+//
+// Parameters:
+//   - ctx: The context passed to the handler
+//   - level: The log level of the message (0=Critical, 1=Error, 2=Warning, 3=Info, 4=Debug)
+//   - msg: The message, which is not used as a format string
+//   - attrs: The attributes of the message
+//
+// Example:
+//
+//	logger.LogAttrs(ctx, common.Info, "Request completed",
+//	    slog.String("route", "/api/accounts"), slog.Int("status", 200))
+func (l *ArkLogger) LogAttrs(ctx context.Context, level int, msg string, attrs ...slog.Attr) {
+	l.log(ctx, level, msg, attrs)
+}
+
+func (l *ArkLogger) log(ctx context.Context, level int, msg string, attrs []slog.Attr) {
+	if !l.verbose {
+		return
+	}
+	if l.LogLevel() < level {
+		return
+	}
+	handler := l.activeHandler()
+	slogLevel := arkLevelToSlog(level)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !handler.Enabled(ctx, slogLevel) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	record := slog.NewRecord(time.Now(), slogLevel, redactArkLogMessage(msg), pcs[0])
+	if _, isText := handler.(*arkTextHandler); !isText && l.name != "" {
+		record.AddAttrs(slog.String("logger", l.name))
+	}
+	record.AddAttrs(l.attrs...)
+	for _, attr := range attrs {
+		record.AddAttrs(redactArkLogAttr(attr))
+	}
+	_ = handler.Handle(ctx, record)
+}
+
+func (l *ArkLogger) activeHandler() slog.Handler {
+	if holder := l.handler.Load(); holder != nil && holder.handler != nil {
+		return holder.handler
+	}
+	if holder := defaultLogHandler.Load(); holder != nil && holder.handler != nil {
+		return holder.handler
+	}
+	return &arkTextHandler{logger: l.Logger}
+}
+
+func arkLevelToSlog(level int) slog.Level {
+	switch {
+	case level >= Debug:
+		return slog.LevelDebug
+	case level == Info:
+		return slog.LevelInfo
+	case level == Warning:
+		return slog.LevelWarn
+	case level == Error:
+		return slog.LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+func arkLevelName(level slog.Level) string {
+	switch {
+	case level >= LevelFatal:
+		return "FATAL"
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// arkTextHandler writes records as colored text lines through a log.Logger, the default output of ArkLogger.
+type arkTextHandler struct {
+	logger *log.Logger
+	attrs  []slog.Attr
+	group  string
+}
+
+func (h *arkTextHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *arkTextHandler) Handle(_ context.Context, record slog.Record) error {
+	var color string
+	switch {
+	case record.Level >= LevelFatal:
+		color = "\033[1;31m"
+	case record.Level >= slog.LevelError:
+		color = "\033[31m"
+	case record.Level >= slog.LevelWarn:
+		color = "\033[33m"
+	case record.Level >= slog.LevelInfo:
+		color = "\033[32m"
+	default:
+		color = "\033[1;32m"
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "| %s | %s%s\033[0m", arkLevelName(record.Level), color, record.Message)
+	for _, attr := range h.attrs {
+		writeArkTextAttr(&builder, "", attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		writeArkTextAttr(&builder, h.group, attr)
+		return true
+	})
+	h.logger.Println(builder.String())
+	return nil
+}
+
+func (h *arkTextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.attrs = append(append([]slog.Attr{}, h.attrs...), prefixArkAttrs(h.group, attrs)...)
+	return &handler
+}
+
+func (h *arkTextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.group = h.group + name + "."
+	return &handler
+}
+
+func prefixArkAttrs(prefix string, attrs []slog.Attr) []slog.Attr {
+	if prefix == "" {
+		return attrs
+	}
+	prefixed := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		prefixed[i] = slog.Attr{Key: prefix + attr.Key, Value: attr.Value}
+	}
+	return prefixed
+}
+
+func writeArkTextAttr(builder *strings.Builder, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			writeArkTextAttr(builder, groupPrefix, groupAttr)
+		}
+		return
+	}
+	value := attr.Value.String()
+	if strings.ContainsAny(value, " \t\n\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+	fmt.Fprintf(builder, " %s%s=%s", prefix, attr.Key, value)
+}
+
 // Debug logs a debug message with green color formatting.
 //
 // Debug messages are only output when the logger is in verbose mode and
@@ -176,14 +453,7 @@ func (l *ArkLogger) SetVerbose(value bool) {
 //
 //	logger.Debug("Processing user %s with ID %d", username, userID)
 func (l *ArkLogger) Debug(msg string, v ...interface{}) {
-	if !l.verbose {
-		return
-	}
-	if l.LogLevel() < Debug {
-		return
-	}
-	colorMsg := fmt.Sprintf("| DEBUG | \033[1;32m%s\033[0m", fmt.Sprintf(msg, v...))
-	l.Logger.Println(colorMsg)
+	l.log(context.Background(), Debug, fmt.Sprintf(msg, v...), nil)
 }
 
 // Info logs an informational message with green color formatting.
@@ -200,14 +470,7 @@ func (l *ArkLogger) Debug(msg string, v ...interface{}) {
 //
 //	logger.Info("User %s logged in successfully", username)
 func (l *ArkLogger) Info(msg string, v ...interface{}) {
-	if !l.verbose {
-		return
-	}
-	if l.LogLevel() < Info {
-		return
-	}
-	colorMsg := fmt.Sprintf("| INFO | \033[32m%s\033[0m", fmt.Sprintf(msg, v...))
-	l.Logger.Println(colorMsg)
+	l.log(context.Background(), Info, fmt.Sprintf(msg, v...), nil)
 }
 
 // Warning logs a warning message with yellow color formatting.
@@ -224,14 +487,7 @@ func (l *ArkLogger) Info(msg string, v ...interface{}) {
 //
 //	logger.Warning("Rate limit approaching for user %s", username)
 func (l *ArkLogger) Warning(msg string, v ...interface{}) {
-	if !l.verbose {
-		return
-	}
-	if l.LogLevel() < Warning {
-		return
-	}
-	colorMsg := fmt.Sprintf("| WARNING | \033[33m%s\033[0m", fmt.Sprintf(msg, v...))
-	l.Logger.Println(colorMsg)
+	l.log(context.Background(), Warning, fmt.Sprintf(msg, v...), nil)
 }
 
 // Error logs an error message with red color formatting.
@@ -248,14 +504,7 @@ func (l *ArkLogger) Warning(msg string, v ...interface{}) {
 //
 //	logger.Error("Failed to connect to database: %v", err)
 func (l *ArkLogger) Error(msg string, v ...interface{}) {
-	if !l.verbose {
-		return
-	}
-	if l.LogLevel() < Error {
-		return
-	}
-	colorMsg := fmt.Sprintf("| ERROR | \033[31m%s\033[0m", fmt.Sprintf(msg, v...))
-	l.Logger.Println(colorMsg)
+	l.log(context.Background(), Error, fmt.Sprintf(msg, v...), nil)
 }
 
 // Fatal logs a fatal error message with bright red color formatting and exits the program.
@@ -280,8 +529,7 @@ func (l *ArkLogger) Fatal(msg string, v ...interface{}) {
 	if l.LogLevel() < Critical {
 		return
 	}
-	colorMsg := fmt.Sprintf("| FATAL | \033[1;31m%s\033[0m", fmt.Sprintf(msg, v...))
-	l.Logger.Println(colorMsg)
+	l.log(context.Background(), Critical, fmt.Sprintf(msg, v...), nil)
 	os.Exit(-1)
 }
 
@@ -296,6 +544,9 @@ func (l *ArkLogger) Fatal(msg string, v ...interface{}) {
 //   - app: Application name used as the logger prefix
 //   - logLevel: Static log level (0-4), or -1 to resolve from environment
 //
+// The "default" style writes colored text lines, and the "json" style writes
+// JSON lines to stdout.
+//
 // Returns a configured ArkLogger instance, or nil if an unsupported logger
 // style is specified in the LOGGER_STYLE environment variable.
 //
@@ -321,6 +572,11 @@ func GetLogger(app string, logLevel int) *ArkLogger {
 		logger.SetPrefix(fmt.Sprintf(logFormat, app))
 		return logger
 	}
+	if loggerStyle == LoggerStyleJSON {
+		logger := NewArkLogger(app, logLevel, true, resolveLogLevelFromEnv)
+		logger.SetHandler(NewArkJSONHandler(os.Stdout))
+		return logger
+	}
 	return nil
 }
 
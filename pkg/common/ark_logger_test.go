@@ -2,9 +2,15 @@ package common
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -800,3 +806,116 @@ func TestGlobalLogger(t *testing.T) {
 		t.Error("Expected GlobalLogger to resolve log level from environment")
 	}
 }
+
+func TestArkLogger_JSONHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewArkLogger("test", Debug, true, false)
+	logger.SetHandler(NewArkJSONHandler(&buf))
+
+	logger.With("service", "privilegecloud").LogAttrs(context.Background(), Warning, "Request failed",
+		slog.String("route", "/api/Accounts/{id}"), slog.Int("status", 503), slog.Int64("duration_ms", 120))
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON record, got %q: %v", buf.String(), err)
+	}
+	expected := map[string]interface{}{
+		"level":       "WARNING",
+		"msg":         "Request failed",
+		"logger":      "test",
+		"service":     "privilegecloud",
+		"route":       "/api/Accounts/{id}",
+		"status":      float64(503),
+		"duration_ms": float64(120),
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, record[key])
+		}
+	}
+}
+
+func TestArkLogger_TextAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewArkLogger("test", Info, true, false)
+	logger.Logger = log.New(&buf, "", 0)
+
+	logger.With("service", "cmgr").LogAttrs(context.Background(), Info, "Listing networks", slog.String("route", "/api/pool-service/networks"))
+	logger.LogAttrs(context.Background(), Debug, "Filtered out")
+
+	expected := "| INFO | \033[32mListing networks\033[0m service=cmgr route=/api/pool-service/networks\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestArkLogger_Redaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewArkLogger("test", Debug, true, false)
+	logger.SetHandler(NewArkJSONHandler(&buf))
+
+	logger.Debug("Sending %s", `{"username":"admin","password":"hunter2"}`)
+	logger.LogAttrs(context.Background(), Info, "Headers",
+		slog.String("access_token", "abc.def.ghi"),
+		slog.Any("headers", http.Header{"Authorization": {"Bearer abc.def.ghi"}, "Accept": {"application/json"}}),
+		slog.Group("request", slog.String("Cookie", "session=12345")))
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "abc.def.ghi", "session=12345"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, output)
+		}
+	}
+	if !strings.Contains(output, "admin") || !strings.Contains(output, "application/json") {
+		t.Errorf("expected values that are not secrets to be kept, got %s", output)
+	}
+}
+
+func TestSetArkLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	SetArkLogHandler(NewArkJSONHandler(&buf))
+	defer SetArkLogHandler(nil)
+
+	logger := NewArkLogger("test", Info, true, false)
+	logger.Info("Routed to %s", "the default handler")
+	if !strings.Contains(buf.String(), `"msg":"Routed to the default handler"`) {
+		t.Errorf("expected the record to reach the default handler, got %q", buf.String())
+	}
+
+	var own bytes.Buffer
+	logger.SetHandler(NewArkJSONHandler(&own))
+	logger.Info("Routed to the logger handler")
+	if strings.Contains(buf.String(), "logger handler") || !strings.Contains(own.String(), "logger handler") {
+		t.Errorf("expected the handler of the logger to take precedence")
+	}
+}
+
+func TestArkLogger_SetHandlerConcurrent(t *testing.T) {
+	logger := NewArkLogger("test", Debug, true, false)
+	logger.SetHandler(NewArkJSONHandler(io.Discard))
+	child := logger.With("service", "cmgr")
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if i%2 == 0 {
+					logger.SetHandler(NewArkJSONHandler(io.Discard))
+				} else {
+					logger.Info("Logging while the handler changes")
+					child.Info("Logging from a child logger")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var buf bytes.Buffer
+	logger.SetHandler(NewArkJSONHandler(&buf))
+	logger.Info("Routed to the last handler")
+	if !strings.Contains(buf.String(), "last handler") {
+		t.Errorf("expected the record to reach the last handler set, got %q", buf.String())
+	}
+}
@@ -4,6 +4,13 @@
 // including pagination support and common data structures for API responses.
 package common
 
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
 // ArkPage represents a generic paginated response container from the Ark service.
 //
 // ArkPage is a type-safe generic structure that wraps paginated API responses.
@@ -42,3 +49,305 @@ package common
 type ArkPage[T any] struct {
 	Items []*T `json:"items" mapstructure:"items"`
 }
+
+// ArkPageIterator is an iterator over pages of items of type T.
+//
+// Every iteration yields either a page and a nil error, or a nil page and the
+// error that stopped the pagination, in which case it is the last iteration.
+// Iteration runs on the caller's goroutine, so a consumer that breaks out of
+// the loop early stops the pagination without leaving anything running behind.
+//
+// Example:
+//
+//	for page, err := range service.ListAccountsIter(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    for _, account := range page.Items {
+//	        fmt.Println(account.Name)
+//	    }
+//	}
+type ArkPageIterator[T any] = iter.Seq2[*ArkPage[T], error]
+
+// NewArkSinglePageIterator creates a page iterator for endpoints that return all their items in a single response.
+//
+// Parameters:
+//   - ctx: Context checked before the page is fetched and passed to fetch
+//   - fetch: Function that retrieves the page
+//
+// Returns an ArkPageIterator yielding the single page.
+//
+// Example:
+//
+//	pages := NewArkSinglePageIterator(ctx, func(ctx context.Context) (*ArkPage[Scan], error) {
+//	    return fetchScans(ctx)
+//	})
+func NewArkSinglePageIterator[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context) (*ArkPage[T], error),
+) ArkPageIterator[T] {
+	return func(yield func(*ArkPage[T], error) bool) {
+		if err := ctx.Err(); err != nil {
+			yield(nil, err)
+			return
+		}
+		page, err := fetch(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		yield(page, nil)
+	}
+}
+
+// NewArkCursorPageIterator creates a page iterator for cursor / continuation token based pagination.
+//
+// The fetch function is called with an empty cursor for the first page, and
+// returns the page along with the cursor of the next page. Pagination ends
+// when the returned cursor is empty. A cursor that repeats the previous one
+// is reported as an error, since the pagination would otherwise never end.
+//
+// Parameters:
+//   - ctx: Context checked before every page is fetched and passed to fetch
+//   - fetch: Function that retrieves a single page for the given cursor
+//
+// Returns an ArkPageIterator over all pages.
+//
+// Example:
+//
+//	pages := NewArkCursorPageIterator(ctx, func(ctx context.Context, cursor string) (*ArkPage[Pool], string, error) {
+//	    return fetchPools(ctx, cursor)
+//	})
+func NewArkCursorPageIterator[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context, cursor string) (*ArkPage[T], string, error),
+) ArkPageIterator[T] {
+	return func(yield func(*ArkPage[T], error) bool) {
+		cursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			page, nextCursor, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+			if nextCursor == "" {
+				return
+			}
+			if nextCursor == cursor {
+				yield(nil, fmt.Errorf("pagination stuck, cursor [%s] did not change between requests", cursor))
+				return
+			}
+			cursor = nextCursor
+		}
+	}
+}
+
+// NewArkOffsetPageIterator creates a page iterator for offset based pagination.
+//
+// The fetch function is called with the given starting offset for the first
+// page, and with the offset advanced by the number of items returned for every
+// following page. Pagination ends when an empty page is returned, the empty
+// page itself is not yielded.
+//
+// Parameters:
+//   - ctx: Context checked before every page is fetched and passed to fetch
+//   - offset: The offset of the first page
+//   - fetch: Function that retrieves a single page for the given offset
+//
+// Returns an ArkPageIterator over all pages.
+//
+// Example:
+//
+//	pages := NewArkOffsetPageIterator(ctx, 0, func(ctx context.Context, offset int) (*ArkPage[Session], error) {
+//	    return fetchSessions(ctx, offset)
+//	})
+func NewArkOffsetPageIterator[T any](
+	ctx context.Context,
+	offset int,
+	fetch func(ctx context.Context, offset int) (*ArkPage[T], error),
+) ArkPageIterator[T] {
+	return func(yield func(*ArkPage[T], error) bool) {
+		currentOffset := offset
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			page, err := fetch(ctx, currentOffset)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if page == nil || len(page.Items) == 0 {
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+			currentOffset += len(page.Items)
+		}
+	}
+}
+
+// NewArkNextLinkPageIterator creates a page iterator for nextLink based pagination.
+//
+// The fetch function is called with the given query for the first page, and
+// returns the page along with the nextLink of the response. The query of the
+// nextLink is used as the query of the next page, and pagination ends when the
+// returned nextLink is empty.
+//
+// Parameters:
+//   - ctx: Context checked before every page is fetched and passed to fetch
+//   - query: The query parameters of the first page, not modified by the iterator
+//   - fetch: Function that retrieves a single page for the given query
+//
+// Returns an ArkPageIterator over all pages.
+//
+// Example:
+//
+//	pages := NewArkNextLinkPageIterator(ctx, map[string]string{"limit": "100"}, func(ctx context.Context, query map[string]string) (*ArkPage[Safe], string, error) {
+//	    return fetchSafes(ctx, query)
+//	})
+func NewArkNextLinkPageIterator[T any](
+	ctx context.Context,
+	query map[string]string,
+	fetch func(ctx context.Context, query map[string]string) (*ArkPage[T], string, error),
+) ArkPageIterator[T] {
+	return func(yield func(*ArkPage[T], error) bool) {
+		currentQuery := make(map[string]string, len(query))
+		for key, value := range query {
+			currentQuery[key] = value
+		}
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			page, nextLink, err := fetch(ctx, currentQuery)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+			if nextLink == "" {
+				return
+			}
+			nextURL, err := url.Parse(nextLink)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to parse next link [%s]: %w", nextLink, err))
+				return
+			}
+			currentQuery = make(map[string]string)
+			for key, values := range nextURL.Query() {
+				if len(values) > 0 {
+					currentQuery[key] = values[0]
+				}
+			}
+		}
+	}
+}
+
+// MapArkPageIterator converts every page of the given iterator using the given function.
+//
+// Errors of the source iterator are passed through as is, and an error returned
+// by convert stops the iteration.
+//
+// Parameters:
+//   - pages: The source page iterator
+//   - convert: Function converting a single page
+//
+// Returns an ArkPageIterator over the converted pages.
+//
+// Example:
+//
+//	typed := MapArkPageIterator(rawPages, func(page *ArkPage[map[string]interface{}]) (*ArkPage[Policy], error) {
+//	    return decodePolicies(page)
+//	})
+func MapArkPageIterator[T any, U any](
+	pages ArkPageIterator[T],
+	convert func(page *ArkPage[T]) (*ArkPage[U], error),
+) ArkPageIterator[U] {
+	return func(yield func(*ArkPage[U], error) bool) {
+		for page, err := range pages {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			converted, err := convert(page)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(converted, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CollectArkPageItems consumes the given iterator and returns the items of all pages.
+//
+// Parameters:
+//   - pages: The page iterator to consume
+//
+// Returns all the items, or the items collected so far and the error that stopped the pagination.
+//
+// Example:
+//
+//	accounts, err := CollectArkPageItems(service.ListAccountsIter(ctx))
+func CollectArkPageItems[T any](pages ArkPageIterator[T]) ([]*T, error) {
+	items := make([]*T, 0)
+	for page, err := range pages {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, page.Items...)
+	}
+	return items, nil
+}
+
+// ArkPageChannel runs the given iterator on a goroutine and sends its pages over a channel.
+//
+// This is used to keep the channel based listing APIs on top of page iterators.
+// The channel is closed when the pagination ends. A pagination error is logged
+// with the given logger and closes the channel as well, use the iterator itself
+// to handle errors. The goroutine exits when ctx is done, so consumers that stop
+// reading the channel early should cancel ctx.
+//
+// Parameters:
+//   - ctx: Context that stops the goroutine when done
+//   - pages: The page iterator to run
+//   - logger: Logger used to report pagination errors
+//
+// Returns a receive only channel of pages.
+//
+// Example:
+//
+//	return ArkPageChannel(ctx, s.ListAccountsIter(ctx), s.Logger), nil
+func ArkPageChannel[T any](ctx context.Context, pages ArkPageIterator[T], logger *ArkLogger) <-chan *ArkPage[T] {
+	results := make(chan *ArkPage[T])
+	go func() {
+		defer close(results)
+		for page, err := range pages {
+			if err != nil {
+				logger.Error("Failed to list pages: %v", err)
+				return
+			}
+			select {
+			case results <- page:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
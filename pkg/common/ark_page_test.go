@@ -0,0 +1,386 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+type testPageItem struct {
+	ID int
+}
+
+func makeTestPage(ids ...int) *ArkPage[testPageItem] {
+	page := &ArkPage[testPageItem]{Items: make([]*testPageItem, 0, len(ids))}
+	for _, id := range ids {
+		page.Items = append(page.Items, &testPageItem{ID: id})
+	}
+	return page
+}
+
+func collectTestPageIDs(t *testing.T, pages ArkPageIterator[testPageItem]) ([]int, error) {
+	t.Helper()
+	items, err := CollectArkPageItems(pages)
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids, err
+}
+
+func TestNewArkSinglePageIterator(t *testing.T) {
+	tests := []struct {
+		name          string
+		fetchErr      error
+		expectedIDs   []int
+		expectedError bool
+	}{
+		{
+			name:        "success_yields_the_page",
+			expectedIDs: []int{1, 2, 3},
+		},
+		{
+			name:          "error_yields_the_error",
+			fetchErr:      errors.New("fetch failed"),
+			expectedIDs:   []int{},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := 0
+			pages := NewArkSinglePageIterator(context.Background(), func(ctx context.Context) (*ArkPage[testPageItem], error) {
+				fetches++
+				if tt.fetchErr != nil {
+					return nil, tt.fetchErr
+				}
+				return makeTestPage(1, 2, 3), nil
+			})
+			ids, err := collectTestPageIDs(t, pages)
+			if tt.expectedError && err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expectedIDs) {
+				t.Errorf("Expected items %v, got %v", tt.expectedIDs, ids)
+			}
+			if fetches != 1 {
+				t.Errorf("Expected 1 fetch, got %d", fetches)
+			}
+		})
+	}
+}
+
+func TestNewArkCursorPageIterator(t *testing.T) {
+	tests := []struct {
+		name             string
+		pages            map[string]*ArkPage[testPageItem]
+		cursors          map[string]string
+		failOnCursor     string
+		expectedIDs      []int
+		expectedError    bool
+		expectedErrorMsg string
+		expectedFetches  int
+	}{
+		{
+			name:            "success_single_page",
+			pages:           map[string]*ArkPage[testPageItem]{"": makeTestPage(1, 2)},
+			cursors:         map[string]string{"": ""},
+			expectedIDs:     []int{1, 2},
+			expectedFetches: 1,
+		},
+		{
+			name: "success_multiple_pages",
+			pages: map[string]*ArkPage[testPageItem]{
+				"":   makeTestPage(1, 2),
+				"c1": makeTestPage(3),
+				"c2": makeTestPage(4, 5),
+			},
+			cursors:         map[string]string{"": "c1", "c1": "c2", "c2": ""},
+			expectedIDs:     []int{1, 2, 3, 4, 5},
+			expectedFetches: 3,
+		},
+		{
+			name: "error_on_second_page",
+			pages: map[string]*ArkPage[testPageItem]{
+				"": makeTestPage(1, 2),
+			},
+			cursors:          map[string]string{"": "c1"},
+			failOnCursor:     "c1",
+			expectedIDs:      []int{1, 2},
+			expectedError:    true,
+			expectedErrorMsg: "fetch failed",
+			expectedFetches:  2,
+		},
+		{
+			name: "error_stuck_cursor",
+			pages: map[string]*ArkPage[testPageItem]{
+				"":   makeTestPage(1),
+				"c1": makeTestPage(2),
+			},
+			cursors:          map[string]string{"": "c1", "c1": "c1"},
+			expectedIDs:      []int{1, 2},
+			expectedError:    true,
+			expectedErrorMsg: "pagination stuck, cursor [c1] did not change between requests",
+			expectedFetches:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := 0
+			pages := NewArkCursorPageIterator(context.Background(), func(ctx context.Context, cursor string) (*ArkPage[testPageItem], string, error) {
+				fetches++
+				if tt.failOnCursor != "" && cursor == tt.failOnCursor {
+					return nil, "", errors.New("fetch failed")
+				}
+				return tt.pages[cursor], tt.cursors[cursor], nil
+			})
+			ids, err := collectTestPageIDs(t, pages)
+			if tt.expectedError {
+				if err == nil {
+					t.Fatalf("Expected error but got none")
+				}
+				if err.Error() != tt.expectedErrorMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.expectedErrorMsg, err.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expectedIDs) {
+				t.Errorf("Expected items %v, got %v", tt.expectedIDs, ids)
+			}
+			if fetches != tt.expectedFetches {
+				t.Errorf("Expected %d fetches, got %d", tt.expectedFetches, fetches)
+			}
+		})
+	}
+}
+
+func TestNewArkOffsetPageIterator(t *testing.T) {
+	tests := []struct {
+		name            string
+		startOffset     int
+		pages           map[int]*ArkPage[testPageItem]
+		expectedIDs     []int
+		expectedOffsets []int
+	}{
+		{
+			name:            "success_empty_first_page",
+			pages:           map[int]*ArkPage[testPageItem]{},
+			expectedIDs:     []int{},
+			expectedOffsets: []int{0},
+		},
+		{
+			name: "success_multiple_pages",
+			pages: map[int]*ArkPage[testPageItem]{
+				0: makeTestPage(1, 2),
+				2: makeTestPage(3, 4, 5),
+			},
+			expectedIDs:     []int{1, 2, 3, 4, 5},
+			expectedOffsets: []int{0, 2, 5},
+		},
+		{
+			name:        "success_starting_offset",
+			startOffset: 10,
+			pages: map[int]*ArkPage[testPageItem]{
+				10: makeTestPage(11),
+			},
+			expectedIDs:     []int{11},
+			expectedOffsets: []int{10, 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offsets := make([]int, 0)
+			pages := NewArkOffsetPageIterator(context.Background(), tt.startOffset, func(ctx context.Context, offset int) (*ArkPage[testPageItem], error) {
+				offsets = append(offsets, offset)
+				if page, ok := tt.pages[offset]; ok {
+					return page, nil
+				}
+				return makeTestPage(), nil
+			})
+			ids, err := collectTestPageIDs(t, pages)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expectedIDs) {
+				t.Errorf("Expected items %v, got %v", tt.expectedIDs, ids)
+			}
+			if fmt.Sprint(offsets) != fmt.Sprint(tt.expectedOffsets) {
+				t.Errorf("Expected offsets %v, got %v", tt.expectedOffsets, offsets)
+			}
+		})
+	}
+}
+
+func TestNewArkNextLinkPageIterator(t *testing.T) {
+	initialQuery := map[string]string{"limit": "2"}
+	queries := make([]string, 0)
+	pages := NewArkNextLinkPageIterator(context.Background(), initialQuery, func(ctx context.Context, query map[string]string) (*ArkPage[testPageItem], string, error) {
+		values := url.Values{}
+		for key, value := range query {
+			values.Set(key, value)
+		}
+		queries = append(queries, values.Encode())
+		switch query["offset"] {
+		case "":
+			return makeTestPage(1, 2), "https://example.com/api/items?limit=2&offset=2", nil
+		case "2":
+			return makeTestPage(3), "", nil
+		}
+		return nil, "", fmt.Errorf("unexpected query %v", query)
+	})
+	ids, err := collectTestPageIDs(t, pages)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if fmt.Sprint(ids) != fmt.Sprint([]int{1, 2, 3}) {
+		t.Errorf("Expected items [1 2 3], got %v", ids)
+	}
+	expectedQueries := []string{"limit=2", "limit=2&offset=2"}
+	if fmt.Sprint(queries) != fmt.Sprint(expectedQueries) {
+		t.Errorf("Expected queries %v, got %v", expectedQueries, queries)
+	}
+	if len(initialQuery) != 1 || initialQuery["limit"] != "2" {
+		t.Errorf("Expected initial query to be left untouched, got %v", initialQuery)
+	}
+}
+
+func TestArkPageIteratorEarlyBreak(t *testing.T) {
+	fetches := 0
+	pages := NewArkCursorPageIterator(context.Background(), func(ctx context.Context, cursor string) (*ArkPage[testPageItem], string, error) {
+		fetches++
+		return makeTestPage(fetches), fmt.Sprintf("c%d", fetches), nil
+	})
+	for page, err := range pages {
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if page.Items[0].ID == 2 {
+			break
+		}
+	}
+	if fetches != 2 {
+		t.Errorf("Expected 2 fetches, got %d", fetches)
+	}
+}
+
+func TestArkPageIteratorCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fetches := 0
+	pages := NewArkOffsetPageIterator(ctx, 0, func(ctx context.Context, offset int) (*ArkPage[testPageItem], error) {
+		fetches++
+		cancel()
+		return makeTestPage(offset), nil
+	})
+	ids, err := collectTestPageIDs(t, pages)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled error, got: %v", err)
+	}
+	if fmt.Sprint(ids) != fmt.Sprint([]int{0}) {
+		t.Errorf("Expected items [0], got %v", ids)
+	}
+	if fetches != 1 {
+		t.Errorf("Expected 1 fetch, got %d", fetches)
+	}
+}
+
+func TestMapArkPageIterator(t *testing.T) {
+	tests := []struct {
+		name          string
+		failOnID      int
+		expectedIDs   []int
+		expectedError bool
+	}{
+		{
+			name:        "success_converts_all_pages",
+			expectedIDs: []int{10, 20, 30},
+		},
+		{
+			name:          "error_conversion_stops_iteration",
+			failOnID:      3,
+			expectedIDs:   []int{10, 20},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := NewArkCursorPageIterator(context.Background(), func(ctx context.Context, cursor string) (*ArkPage[testPageItem], string, error) {
+				if cursor == "" {
+					return makeTestPage(1, 2), "next", nil
+				}
+				return makeTestPage(3), "", nil
+			})
+			mapped := MapArkPageIterator(source, func(page *ArkPage[testPageItem]) (*ArkPage[testPageItem], error) {
+				converted := makeTestPage()
+				for _, item := range page.Items {
+					if item.ID == tt.failOnID {
+						return nil, errors.New("conversion failed")
+					}
+					converted.Items = append(converted.Items, &testPageItem{ID: item.ID * 10})
+				}
+				return converted, nil
+			})
+			ids, err := collectTestPageIDs(t, mapped)
+			if tt.expectedError && err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expectedIDs) {
+				t.Errorf("Expected items %v, got %v", tt.expectedIDs, ids)
+			}
+		})
+	}
+}
+
+func TestArkPageChannel(t *testing.T) {
+	t.Run("success_delivers_all_pages", func(t *testing.T) {
+		source := NewArkCursorPageIterator(context.Background(), func(ctx context.Context, cursor string) (*ArkPage[testPageItem], string, error) {
+			if cursor == "" {
+				return makeTestPage(1), "next", nil
+			}
+			return makeTestPage(2), "", nil
+		})
+		ids := make([]int, 0)
+		for page := range ArkPageChannel(context.Background(), source, GetLogger("test", Unknown)) {
+			for _, item := range page.Items {
+				ids = append(ids, item.ID)
+			}
+		}
+		if fmt.Sprint(ids) != fmt.Sprint([]int{1, 2}) {
+			t.Errorf("Expected items [1 2], got %v", ids)
+		}
+	})
+	t.Run("error_closes_channel_on_fetch_error", func(t *testing.T) {
+		source := NewArkCursorPageIterator(context.Background(), func(ctx context.Context, cursor string) (*ArkPage[testPageItem], string, error) {
+			if cursor == "" {
+				return makeTestPage(1), "next", nil
+			}
+			return nil, "", errors.New("fetch failed")
+		})
+		count := 0
+		for range ArkPageChannel(context.Background(), source, GetLogger("test", Unknown)) {
+			count++
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 page, got %d", count)
+		}
+	})
+	t.Run("error_stops_on_canceled_context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		source := NewArkCursorPageIterator(ctx, func(ctx context.Context, cursor string) (*ArkPage[testPageItem], string, error) {
+			return makeTestPage(1), cursor + "x", nil
+		})
+		results := ArkPageChannel(ctx, source, GetLogger("test", Unknown))
+		<-results
+		cancel()
+		for range results {
+		}
+	})
+}
@@ -0,0 +1,400 @@
+package common
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ArkRateLimiter limits the rate and concurrency of the requests made by ArkClient.
+//
+// Acquire is called before every attempt of a request, including retries, and blocks
+// until the request may be sent or the context is done. The returned release function
+// is called once the response body is closed or the request failed, and must be safe
+// to call more than once.
+//
+// The request is fully built when passed to Acquire, so implementations can key their
+// limits by its host, method and path. The name of the calling service is available
+// through ArkServiceNameFromContext on the request context.
+//
+// Requests are not limited by default. Limits only apply once a limiter is set on a
+// client with SetRateLimiter or for the whole process with SetDefaultArkRateLimiter.
+type ArkRateLimiter interface {
+	Acquire(ctx context.Context, req *http.Request) (release func(), err error)
+}
+
+// ArkRateLimit describes the limits applied to a group of requests.
+//
+// Fields:
+//   - RequestsPerSecond: Sustained number of requests per second (0 for no rate limit)
+//   - Burst: Number of requests that may be sent at once above the sustained rate (at least 1)
+//   - MaxConcurrency: Maximum number of requests in flight at the same time (0 for no cap)
+type ArkRateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second" mapstructure:"requests_per_second"`
+	Burst             int     `json:"burst" mapstructure:"burst"`
+	MaxConcurrency    int     `json:"max_concurrency" mapstructure:"max_concurrency"`
+}
+
+// ArkRouteRateLimit applies a rate limit to the requests of a service that match a route pattern.
+//
+// Patterns use path.Match syntax. A pattern starting with "/" is matched against the
+// whole URL path of the request, any other pattern is matched against its trailing
+// segments, so "api/Safes/*/Members" matches "/passwordvault/api/Safes/abc/Members".
+//
+// Fields:
+//   - Method: HTTP method the limit applies to (empty for all methods)
+//   - Pattern: Route pattern the limit applies to
+//   - Limit: The limits applied to matching requests, on top of the limits of the service
+type ArkRouteRateLimit struct {
+	Method  string       `json:"method,omitempty" mapstructure:"method,omitempty"`
+	Pattern string       `json:"pattern" mapstructure:"pattern"`
+	Limit   ArkRateLimit `json:"limit" mapstructure:"limit"`
+}
+
+// ArkRateLimiterConfig configures an ArkTokenBucketRateLimiter.
+//
+// Limits are applied per tenant host and service name. Requests whose service has
+// no entry in Services use Default. Route limits are applied in addition to the
+// limits of the service, the first matching route limit of a service is used.
+//
+// Fields:
+//   - Default: Limits of services without an explicit entry
+//   - Services: Limits per service name
+//   - Routes: Route limits per service name
+//   - OnWait: Optional callback invoked with the limiter key and wait time of every delayed request
+type ArkRateLimiterConfig struct {
+	Default  ArkRateLimit                           `json:"default" mapstructure:"default"`
+	Services map[string]ArkRateLimit                `json:"services,omitempty" mapstructure:"services,omitempty"`
+	Routes   map[string][]ArkRouteRateLimit         `json:"routes,omitempty" mapstructure:"routes,omitempty"`
+	OnWait   func(key string, waited time.Duration) `json:"-" mapstructure:"-"`
+}
+
+// ArkRateLimiterStats holds the wait time metrics of a single limiter key.
+//
+// Fields:
+//   - Requests: Number of requests that were admitted
+//   - Delayed: Number of requests that had to wait before being admitted
+//   - TotalWait: Total time spent waiting by all requests
+//   - MaxWait: Longest time a single request waited
+//   - InFlight: Number of admitted requests that were not released yet
+type ArkRateLimiterStats struct {
+	Requests  int64         `json:"requests"`
+	Delayed   int64         `json:"delayed"`
+	TotalWait time.Duration `json:"total_wait"`
+	MaxWait   time.Duration `json:"max_wait"`
+	InFlight  int64         `json:"in_flight"`
+}
+
+// DefaultArkRateLimiterConfig returns rate limits suited for bulk operations against the platform services.
+//
+// The limits stay below the throttling thresholds of the services, so that
+// concurrent bulk jobs run at a steady rate instead of failing with 429 responses.
+// They are not applied on their own, pass the config to NewArkTokenBucketRateLimiter
+// and set the limiter with SetDefaultArkRateLimiter or WithRateLimiter to use them.
+//
+// Returns a new ArkRateLimiterConfig with the default limits.
+func DefaultArkRateLimiterConfig() *ArkRateLimiterConfig {
+	return &ArkRateLimiterConfig{
+		Default: ArkRateLimit{RequestsPerSecond: 20, Burst: 40, MaxConcurrency: 16},
+		Services: map[string]ArkRateLimit{
+			"privilegecloud":      {RequestsPerSecond: 10, Burst: 20, MaxConcurrency: 8},
+			"connectormanagement": {RequestsPerSecond: 10, Burst: 20, MaxConcurrency: 8},
+			"dpa":                 {RequestsPerSecond: 10, Burst: 20, MaxConcurrency: 8},
+			"secretshub":          {RequestsPerSecond: 5, Burst: 10, MaxConcurrency: 4},
+			"sessionmonitoring":   {RequestsPerSecond: 10, Burst: 20, MaxConcurrency: 8},
+			"uap":                 {RequestsPerSecond: 10, Burst: 20, MaxConcurrency: 8},
+		},
+		Routes: map[string][]ArkRouteRateLimit{},
+	}
+}
+
+// arkTokenBucket is a token bucket that hands out reservations, letting callers wait outside its lock.
+type arkTokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newArkTokenBucket(rate float64, burst int, now time.Time) *arkTokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &arkTokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller must wait before using it.
+func (b *arkTokenBucket) reserve(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (b *arkTokenBucket) cancel() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// arkLimiterEntry holds the bucket, semaphore and metrics of a single limiter key.
+type arkLimiterEntry struct {
+	key       string
+	bucket    *arkTokenBucket
+	semaphore chan struct{}
+	stats     ArkRateLimiterStats
+	mutex     sync.Mutex
+}
+
+func (e *arkLimiterEntry) acquire(ctx context.Context, onWait func(string, time.Duration)) error {
+	start := time.Now()
+	if e.bucket != nil {
+		if wait := e.bucket.reserve(start); wait > 0 {
+			if err := sleepContext(ctx, wait); err != nil {
+				e.bucket.cancel()
+				return err
+			}
+		}
+	}
+	if e.semaphore != nil {
+		select {
+		case e.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			if e.bucket != nil {
+				e.bucket.cancel()
+			}
+			return ctx.Err()
+		}
+	}
+	waited := time.Since(start)
+	e.mutex.Lock()
+	e.stats.Requests++
+	e.stats.InFlight++
+	if waited > time.Millisecond {
+		e.stats.Delayed++
+		e.stats.TotalWait += waited
+		e.stats.MaxWait = max(e.stats.MaxWait, waited)
+	}
+	e.mutex.Unlock()
+	if waited > time.Millisecond && onWait != nil {
+		onWait(e.key, waited)
+	}
+	return nil
+}
+
+func (e *arkLimiterEntry) release() {
+	if e.semaphore != nil {
+		<-e.semaphore
+	}
+	e.mutex.Lock()
+	e.stats.InFlight--
+	e.mutex.Unlock()
+}
+
+// ArkTokenBucketRateLimiter is an ArkRateLimiter based on token buckets and concurrency semaphores.
+//
+// Each tenant host and service name gets its own bucket and semaphore, so a single
+// limiter can be shared by all the clients of a process, including clients of
+// different tenants. It is safe for concurrent use.
+type ArkTokenBucketRateLimiter struct {
+	config  *ArkRateLimiterConfig
+	entries map[string]*arkLimiterEntry
+	mutex   sync.Mutex
+}
+
+// NewArkTokenBucketRateLimiter creates a new ArkTokenBucketRateLimiter.
+//
+// Parameters:
+//   - config: The limits to apply, DefaultArkRateLimiterConfig is used if nil
+//
+// Returns the new limiter.
+//
+// Example:
+//
+//	config := common.DefaultArkRateLimiterConfig()
+//	config.Routes["privilegecloud"] = []common.ArkRouteRateLimit{
+//	    {Method: http.MethodPost, Pattern: "api/Safes/*/Members", Limit: common.ArkRateLimit{RequestsPerSecond: 2, Burst: 2}},
+//	}
+//	common.SetDefaultArkRateLimiter(common.NewArkTokenBucketRateLimiter(config))
+func NewArkTokenBucketRateLimiter(config *ArkRateLimiterConfig) *ArkTokenBucketRateLimiter {
+	if config == nil {
+		config = DefaultArkRateLimiterConfig()
+	}
+	return &ArkTokenBucketRateLimiter{
+		config:  config,
+		entries: make(map[string]*arkLimiterEntry),
+	}
+}
+
+// Acquire waits until the request is allowed by the limits of its service and route.
+func (l *ArkTokenBucketRateLimiter) Acquire(ctx context.Context, req *http.Request) (func(), error) {
+	serviceName := ArkServiceNameFromContext(req.Context())
+	serviceKey := req.URL.Host + "/" + serviceName
+	limit, ok := l.config.Services[serviceName]
+	if !ok {
+		limit = l.config.Default
+	}
+	entries := []*arkLimiterEntry{l.entry(serviceKey, limit)}
+	for _, routeLimit := range l.config.Routes[serviceName] {
+		if routeLimit.Method != "" && !strings.EqualFold(routeLimit.Method, req.Method) {
+			continue
+		}
+		if matchRoutePattern(routeLimit.Pattern, req.URL.Path) {
+			entries = append(entries, l.entry(serviceKey+" "+strings.ToUpper(routeLimit.Method)+" "+routeLimit.Pattern, routeLimit.Limit))
+			break
+		}
+	}
+	for i, entry := range entries {
+		if err := entry.acquire(ctx, l.config.OnWait); err != nil {
+			for _, acquired := range entries[:i] {
+				acquired.release()
+			}
+			return nil, err
+		}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, entry := range entries {
+				entry.release()
+			}
+		})
+	}, nil
+}
+
+// Stats returns the wait time metrics of every limiter key used so far.
+//
+// Keys are made of the tenant host and service name, followed by the method and
+// pattern for route limits, such as "tenant.privilegecloud.cyberark.cloud/privilegecloud".
+//
+// Returns a snapshot of the metrics keyed by limiter key.
+func (l *ArkTokenBucketRateLimiter) Stats() map[string]ArkRateLimiterStats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	stats := make(map[string]ArkRateLimiterStats, len(l.entries))
+	for key, entry := range l.entries {
+		entry.mutex.Lock()
+		stats[key] = entry.stats
+		entry.mutex.Unlock()
+	}
+	return stats
+}
+
+// Keys returns the sorted limiter keys used so far.
+func (l *ArkTokenBucketRateLimiter) Keys() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	keys := make([]string, 0, len(l.entries))
+	for key := range l.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (l *ArkTokenBucketRateLimiter) entry(key string, limit ArkRateLimit) *arkLimiterEntry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if entry, ok := l.entries[key]; ok {
+		return entry
+	}
+	entry := &arkLimiterEntry{key: key}
+	if limit.RequestsPerSecond > 0 {
+		entry.bucket = newArkTokenBucket(limit.RequestsPerSecond, limit.Burst, time.Now())
+	}
+	if limit.MaxConcurrency > 0 {
+		entry.semaphore = make(chan struct{}, limit.MaxConcurrency)
+	}
+	l.entries[key] = entry
+	return entry
+}
+
+// matchRoutePattern matches a route pattern against a URL path, see ArkRouteRateLimit.
+func matchRoutePattern(pattern string, urlPath string) bool {
+	if strings.HasPrefix(pattern, "/") {
+		matched, _ := path.Match(pattern, urlPath)
+		return matched
+	}
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	for i := range segments {
+		if matched, _ := path.Match(pattern, strings.Join(segments[i:], "/")); matched {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	defaultRateLimiter      ArkRateLimiter
+	defaultRateLimiterMutex sync.RWMutex
+)
+
+// SetDefaultArkRateLimiter sets the rate limiter used by every ArkClient that has no limiter of its own.
+//
+// Sharing a single limiter between all clients makes the limits apply to the
+// whole process, regardless of how many service instances are created. No
+// limiter is set by default, and a nil limiter disables rate limiting again.
+//
+// Parameters:
+//   - limiter: The rate limiter to use by default
+//
+// Example:
+//
+//	common.SetDefaultArkRateLimiter(common.NewArkTokenBucketRateLimiter(common.DefaultArkRateLimiterConfig()))
+func SetDefaultArkRateLimiter(limiter ArkRateLimiter) {
+	defaultRateLimiterMutex.Lock()
+	defer defaultRateLimiterMutex.Unlock()
+	defaultRateLimiter = limiter
+}
+
+// GetDefaultArkRateLimiter returns the rate limiter used by clients that have no limiter of their own.
+//
+// Returns the default rate limiter, or nil if rate limiting is disabled by default.
+func GetDefaultArkRateLimiter() ArkRateLimiter {
+	defaultRateLimiterMutex.RLock()
+	defer defaultRateLimiterMutex.RUnlock()
+	return defaultRateLimiter
+}
+
+// ArkServiceNameFromContext returns the name of the service a request was made to by ArkClient.
+//
+// Parameters:
+//   - ctx: The context of the request
+//
+// Returns the service name, or an empty string if unknown.
+func ArkServiceNameFromContext(ctx context.Context) string {
+	serviceName, _ := ctx.Value(arkServiceNameContextKey{}).(string)
+	return serviceName
+}
+
+// releasingReadCloser calls a release function once the wrapped body is closed.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releasingReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+	return err
+}
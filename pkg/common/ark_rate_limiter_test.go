@@ -0,0 +1,233 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestArkTokenBucket_Reserve(t *testing.T) {
+	now := time.Now()
+	bucket := newArkTokenBucket(10, 3, now)
+	for i := 0; i < 3; i++ {
+		if wait := bucket.reserve(now); wait != 0 {
+			t.Fatalf("expected burst reservation %d to be immediate, got %v", i, wait)
+		}
+	}
+	if wait := bucket.reserve(now); wait != 100*time.Millisecond {
+		t.Errorf("expected the first reservation over the burst to wait 100ms, got %v", wait)
+	}
+	bucket.cancel()
+	if wait := bucket.reserve(now.Add(100 * time.Millisecond)); wait != 0 {
+		t.Errorf("expected the bucket to refill over time, got %v", wait)
+	}
+}
+
+func TestMatchRoutePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		urlPath  string
+		expected bool
+	}{
+		{name: "success_absolute_match", pattern: "/passwordvault/api/Accounts", urlPath: "/passwordvault/api/Accounts", expected: true},
+		{name: "error_absolute_no_match", pattern: "/api/Accounts", urlPath: "/passwordvault/api/Accounts", expected: false},
+		{name: "success_trailing_segments", pattern: "api/Accounts", urlPath: "/passwordvault/api/Accounts", expected: true},
+		{name: "success_wildcard_segment", pattern: "api/Safes/*/Members", urlPath: "/passwordvault/api/Safes/abc/Members", expected: true},
+		{name: "error_wildcard_does_not_cross_segments", pattern: "api/*/Members", urlPath: "/passwordvault/api/Safes/abc/Members", expected: false},
+		{name: "error_partial_segment_no_match", pattern: "Accounts", urlPath: "/passwordvault/api/MyAccounts", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := matchRoutePattern(tt.pattern, tt.urlPath); result != tt.expected {
+				t.Errorf("matchRoutePattern(%q, %q) = %v, want %v", tt.pattern, tt.urlPath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArkTokenBucketRateLimiter_ConcurrencyCap(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewArkTokenBucketRateLimiter(&ArkRateLimiterConfig{
+		Default: ArkRateLimit{MaxConcurrency: 2},
+	})
+	client := newTestRetryClient(server.URL, nil)
+	client.SetRateLimiter(limiter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.Get(context.Background(), "api/resource", nil)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			_ = response.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+	}
+	for key, stats := range limiter.Stats() {
+		if stats.Requests != 6 || stats.InFlight != 0 {
+			t.Errorf("unexpected stats for %s: %+v", key, stats)
+		}
+		if stats.Delayed == 0 || stats.TotalWait == 0 {
+			t.Errorf("expected delayed requests to be recorded for %s: %+v", key, stats)
+		}
+	}
+}
+
+func TestArkTokenBucketRateLimiter_ServiceAndRouteLimits(t *testing.T) {
+	var waits sync.Map
+	limiter := NewArkTokenBucketRateLimiter(&ArkRateLimiterConfig{
+		Default: ArkRateLimit{RequestsPerSecond: 1000, Burst: 1000},
+		Services: map[string]ArkRateLimit{
+			"privilegecloud": {RequestsPerSecond: 1000, Burst: 1000},
+		},
+		Routes: map[string][]ArkRouteRateLimit{
+			"privilegecloud": {
+				{Method: http.MethodPost, Pattern: "api/Safes/*/Members", Limit: ArkRateLimit{RequestsPerSecond: 20, Burst: 1}},
+			},
+		},
+		OnWait: func(key string, waited time.Duration) {
+			waits.Store(key, waited)
+		},
+	})
+	ctx := contextWithArkServiceName(context.Background(), "privilegecloud")
+	newRequest := func(method string, url string) *http.Request {
+		req, _ := http.NewRequestWithContext(ctx, method, url, nil)
+		return req
+	}
+
+	for i := 0; i < 2; i++ {
+		release, err := limiter.Acquire(ctx, newRequest(http.MethodPost, "https://tenant.example.com/passwordvault/api/Safes/abc/Members"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		release()
+		release()
+	}
+	release, err := limiter.Acquire(ctx, newRequest(http.MethodGet, "https://tenant.example.com/passwordvault/api/Safes/abc/Members"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release()
+
+	stats := limiter.Stats()
+	serviceStats := stats["tenant.example.com/privilegecloud"]
+	routeStats := stats["tenant.example.com/privilegecloud POST api/Safes/*/Members"]
+	if serviceStats.Requests != 3 || serviceStats.InFlight != 0 {
+		t.Errorf("unexpected service stats %+v", serviceStats)
+	}
+	if routeStats.Requests != 2 || routeStats.Delayed != 1 || routeStats.InFlight != 0 {
+		t.Errorf("unexpected route stats %+v", routeStats)
+	}
+	if _, ok := waits.Load("tenant.example.com/privilegecloud POST api/Safes/*/Members"); !ok {
+		t.Errorf("expected the wait callback to be called for the route limit")
+	}
+	if len(limiter.Keys()) != 2 {
+		t.Errorf("expected 2 limiter keys, got %v", limiter.Keys())
+	}
+}
+
+func TestArkTokenBucketRateLimiter_ContextCanceled(t *testing.T) {
+	limiter := NewArkTokenBucketRateLimiter(&ArkRateLimiterConfig{
+		Default: ArkRateLimit{RequestsPerSecond: 0.01, Burst: 1},
+	})
+	req, _ := http.NewRequest(http.MethodGet, "https://tenant.example.com/api", nil)
+	release, err := limiter.Acquire(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestArkTokenBucketRateLimiter_ContextCanceledWaitingForConcurrency(t *testing.T) {
+	limiter := NewArkTokenBucketRateLimiter(&ArkRateLimiterConfig{
+		Default: ArkRateLimit{RequestsPerSecond: 0.01, Burst: 2, MaxConcurrency: 1},
+	})
+	req, _ := http.NewRequest(http.MethodGet, "https://tenant.example.com/api", nil)
+	release, err := limiter.Acquire(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	release()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err = limiter.Acquire(ctx, req)
+	if err != nil {
+		t.Fatalf("expected the token of the canceled request to be returned, got %v", err)
+	}
+	release()
+}
+
+func TestArkClient_DefaultRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewArkTokenBucketRateLimiter(nil)
+	SetDefaultArkRateLimiter(limiter)
+	defer SetDefaultArkRateLimiter(nil)
+
+	client := newTestRetryClient(server.URL, nil)
+	client.SetServiceName("privilegecloud")
+	response, err := client.Get(context.Background(), "api/resource", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stats := limiter.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("expected the default limiter to be used, got %v", stats)
+	}
+	for _, entry := range stats {
+		if entry.InFlight != 1 {
+			t.Errorf("expected the request to be in flight until its body is closed, got %+v", entry)
+		}
+	}
+	_ = response.Body.Close()
+	for _, entry := range limiter.Stats() {
+		if entry.InFlight != 0 {
+			t.Errorf("expected the request to be released once its body is closed, got %+v", entry)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package common
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default values of the retry policy used by ArkClient.
+const (
+	DefaultRetryMaxAttempts    = 4
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff     = 30 * time.Second
+	DefaultRetryMultiplier     = 2.0
+	DefaultRetryJitter         = 0.2
+	DefaultRetryMaxRetryAfter  = 60 * time.Second
+)
+
+// DefaultRetryStatusCodes are the response status codes retried by the default retry policy.
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// idempotentMethods are the HTTP methods that are retried without an explicit opt in.
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+type arkRetryPolicyContextKey struct{}
+
+type arkRetryNonIdempotentContextKey struct{}
+
+// ArkRetryPolicy configures how ArkClient retries requests that failed with a transient error.
+//
+// A request is retried when its response status code is one of RetryStatusCodes,
+// or when the connection was reset before a response arrived and RetryConnectionResets
+// is set. The delay before each retry grows exponentially from InitialBackoff by
+// Multiplier up to MaxBackoff, and is randomized by Jitter. When the response carries
+// a Retry-After header, the client waits at least that long, unless it exceeds
+// MaxRetryAfter, in which case the response is returned to the caller as is.
+//
+// Requests with non-idempotent methods (POST and PATCH) are only retried when
+// RetryNonIdempotent is set, or when the request context was created with
+// WithArkRetryNonIdempotent.
+//
+// Fields:
+//   - MaxAttempts: Maximum number of attempts, including the first one (1 disables retries)
+//   - InitialBackoff: Delay before the first retry
+//   - MaxBackoff: Upper bound of the delay between retries
+//   - Multiplier: Factor applied to the delay after every retry
+//   - Jitter: Fraction of the delay, between 0 and 1, that is randomized
+//   - MaxRetryAfter: Longest Retry-After the client is willing to wait for
+//   - RetryStatusCodes: Response status codes that are retried
+//   - RetryConnectionResets: Whether requests failing with a reset connection are retried
+//   - RetryNonIdempotent: Whether POST and PATCH requests are retried as well
+type ArkRetryPolicy struct {
+	MaxAttempts           int
+	InitialBackoff        time.Duration
+	MaxBackoff            time.Duration
+	Multiplier            float64
+	Jitter                float64
+	MaxRetryAfter         time.Duration
+	RetryStatusCodes      []int
+	RetryConnectionResets bool
+	RetryNonIdempotent    bool
+}
+
+// DefaultArkRetryPolicy returns the retry policy ArkClient uses unless configured otherwise.
+//
+// The default policy makes up to 4 attempts of idempotent requests failing with
+// 429, 502, 503, 504 or a reset connection, backing off from 500ms up to 30s.
+//
+// Returns a new ArkRetryPolicy with the default values.
+//
+// Example:
+//
+//	policy := common.DefaultArkRetryPolicy()
+//	policy.MaxAttempts = 8
+//	client.SetRetryPolicy(policy)
+func DefaultArkRetryPolicy() *ArkRetryPolicy {
+	return &ArkRetryPolicy{
+		MaxAttempts:           DefaultRetryMaxAttempts,
+		InitialBackoff:        DefaultRetryInitialBackoff,
+		MaxBackoff:            DefaultRetryMaxBackoff,
+		Multiplier:            DefaultRetryMultiplier,
+		Jitter:                DefaultRetryJitter,
+		MaxRetryAfter:         DefaultRetryMaxRetryAfter,
+		RetryStatusCodes:      slices.Clone(DefaultRetryStatusCodes),
+		RetryConnectionResets: true,
+	}
+}
+
+// WithArkRetryPolicy returns a context that makes ArkClient use the given retry policy
+// for the requests made with it, instead of the policy of the client.
+//
+// This is useful with the WithContext variants of service methods, whose clients
+// are not directly accessible. A nil policy disables retries for the context.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - policy: The retry policy to use
+//
+// Returns the derived context.
+//
+// Example:
+//
+//	ctx := common.WithArkRetryPolicy(context.Background(), &common.ArkRetryPolicy{MaxAttempts: 1})
+//	accounts, err := accountsService.ListAccountsWithContext(ctx)
+func WithArkRetryPolicy(ctx context.Context, policy *ArkRetryPolicy) context.Context {
+	return context.WithValue(ctx, arkRetryPolicyContextKey{}, policy)
+}
+
+// WithArkRetryNonIdempotent returns a context that allows ArkClient to retry POST and PATCH
+// requests made with it.
+//
+// Only opt in for operations that are safe to repeat, since a request that timed
+// out or was rate limited by a proxy may still have been applied by the service.
+//
+// Parameters:
+//   - ctx: The parent context
+//
+// Returns the derived context.
+//
+// Example:
+//
+//	ctx := common.WithArkRetryNonIdempotent(context.Background())
+//	_, err := safesService.AddSafeMemberWithContext(ctx, addMember)
+func WithArkRetryNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, arkRetryNonIdempotentContextKey{}, true)
+}
+
+// retryPolicyFromContext returns the retry policy to use for a request made with the given context.
+func retryPolicyFromContext(ctx context.Context, fallback *ArkRetryPolicy) *ArkRetryPolicy {
+	if policy, ok := ctx.Value(arkRetryPolicyContextKey{}).(*ArkRetryPolicy); ok {
+		return policy
+	}
+	return fallback
+}
+
+// allowsMethod reports whether requests with the given method may be retried under the policy.
+func (p *ArkRetryPolicy) allowsMethod(ctx context.Context, method string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if slices.Contains(idempotentMethods, strings.ToUpper(method)) || p.RetryNonIdempotent {
+		return true
+	}
+	optIn, _ := ctx.Value(arkRetryNonIdempotentContextKey{}).(bool)
+	return optIn
+}
+
+// retriesStatus reports whether a response with the given status code should be retried.
+func (p *ArkRetryPolicy) retriesStatus(statusCode int) bool {
+	return slices.Contains(p.RetryStatusCodes, statusCode)
+}
+
+// retriesError reports whether a request that failed with the given error should be retried.
+func (p *ArkRetryPolicy) retriesError(err error) bool {
+	return p.RetryConnectionResets && IsConnectionReset(err)
+}
+
+// backoff returns the delay before the given retry, starting from 1 for the first retry.
+func (p *ArkRetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay = delay*(1-jitter) + delay*jitter*rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+// delay returns how long to wait before retrying the given response, and false if
+// the Retry-After requested by the service is longer than the policy allows.
+func (p *ArkRetryPolicy) delay(retry int, response *http.Response) (time.Duration, bool) {
+	delay := p.backoff(retry)
+	if response == nil {
+		return delay, true
+	}
+	retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		return delay, true
+	}
+	if p.MaxRetryAfter > 0 && retryAfter > p.MaxRetryAfter {
+		return 0, false
+	}
+	return max(delay, retryAfter), true
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration, returning early with the context error if it is done first.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
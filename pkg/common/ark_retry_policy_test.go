@@ -0,0 +1,326 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryPolicy() *ArkRetryPolicy {
+	policy := DefaultArkRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func newTestRetryClient(serverURL string, policy *ArkRetryPolicy) *ArkClient {
+	client := NewSimpleArkClient("")
+	client.BaseURL = serverURL
+	client.SetRetryPolicy(policy)
+	return client
+}
+
+func TestArkRetryPolicy_Backoff(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      *ArkRetryPolicy
+		retry       int
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			name:        "success_first_retry_uses_initial_backoff",
+			policy:      &ArkRetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2},
+			retry:       1,
+			expectedMin: 100 * time.Millisecond,
+			expectedMax: 100 * time.Millisecond,
+		},
+		{
+			name:        "success_grows_exponentially",
+			policy:      &ArkRetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2},
+			retry:       4,
+			expectedMin: 800 * time.Millisecond,
+			expectedMax: 800 * time.Millisecond,
+		},
+		{
+			name:        "success_capped_by_max_backoff",
+			policy:      &ArkRetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2, MaxBackoff: 300 * time.Millisecond},
+			retry:       10,
+			expectedMin: 300 * time.Millisecond,
+			expectedMax: 300 * time.Millisecond,
+		},
+		{
+			name:        "success_jitter_stays_within_fraction",
+			policy:      &ArkRetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2, Jitter: 0.5},
+			retry:       1,
+			expectedMin: 50 * time.Millisecond,
+			expectedMax: 100 * time.Millisecond,
+		},
+		{
+			name:        "success_multiplier_below_one_is_constant",
+			policy:      &ArkRetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 0},
+			retry:       3,
+			expectedMin: 100 * time.Millisecond,
+			expectedMax: 100 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				delay := tt.policy.backoff(tt.retry)
+				if delay < tt.expectedMin || delay > tt.expectedMax {
+					t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.retry, delay, tt.expectedMin, tt.expectedMax)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		value         string
+		expected      time.Duration
+		expectedFound bool
+	}{
+		{name: "error_empty", value: "", expectedFound: false},
+		{name: "success_seconds", value: "7", expected: 7 * time.Second, expectedFound: true},
+		{name: "error_negative_seconds", value: "-1", expectedFound: false},
+		{name: "success_http_date", value: now.Add(30 * time.Second).Format(http.TimeFormat), expected: 30 * time.Second, expectedFound: true},
+		{name: "success_http_date_in_past", value: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0, expectedFound: true},
+		{name: "error_invalid", value: "soon", expectedFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, found := parseRetryAfter(tt.value, now)
+			if found != tt.expectedFound || delay != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, delay, found, tt.expected, tt.expectedFound)
+			}
+		})
+	}
+}
+
+func TestArkClient_Retries(t *testing.T) {
+	tests := []struct {
+		name              string
+		method            string
+		failures          int
+		failureStatus     int
+		retryAfter        string
+		policy            func() *ArkRetryPolicy
+		optInContext      bool
+		expectedStatus    int
+		expectedCallCount int32
+	}{
+		{
+			name:              "success_get_retried_until_success",
+			method:            http.MethodGet,
+			failures:          2,
+			failureStatus:     http.StatusServiceUnavailable,
+			policy:            newTestRetryPolicy,
+			expectedStatus:    http.StatusOK,
+			expectedCallCount: 3,
+		},
+		{
+			name:              "success_put_replays_body",
+			method:            http.MethodPut,
+			failures:          1,
+			failureStatus:     http.StatusTooManyRequests,
+			retryAfter:        "0",
+			policy:            newTestRetryPolicy,
+			expectedStatus:    http.StatusOK,
+			expectedCallCount: 2,
+		},
+		{
+			name:              "error_gives_up_after_max_attempts",
+			method:            http.MethodGet,
+			failures:          10,
+			failureStatus:     http.StatusBadGateway,
+			policy:            newTestRetryPolicy,
+			expectedStatus:    http.StatusBadGateway,
+			expectedCallCount: DefaultRetryMaxAttempts,
+		},
+		{
+			name:              "error_non_retried_status",
+			method:            http.MethodGet,
+			failures:          1,
+			failureStatus:     http.StatusInternalServerError,
+			policy:            newTestRetryPolicy,
+			expectedStatus:    http.StatusInternalServerError,
+			expectedCallCount: 1,
+		},
+		{
+			name:              "error_post_not_retried_without_opt_in",
+			method:            http.MethodPost,
+			failures:          1,
+			failureStatus:     http.StatusServiceUnavailable,
+			policy:            newTestRetryPolicy,
+			expectedStatus:    http.StatusServiceUnavailable,
+			expectedCallCount: 1,
+		},
+		{
+			name:              "success_post_retried_with_context_opt_in",
+			method:            http.MethodPost,
+			failures:          1,
+			failureStatus:     http.StatusServiceUnavailable,
+			policy:            newTestRetryPolicy,
+			optInContext:      true,
+			expectedStatus:    http.StatusOK,
+			expectedCallCount: 2,
+		},
+		{
+			name:          "success_post_retried_with_policy_opt_in",
+			method:        http.MethodPost,
+			failures:      1,
+			failureStatus: http.StatusServiceUnavailable,
+			policy: func() *ArkRetryPolicy {
+				policy := newTestRetryPolicy()
+				policy.RetryNonIdempotent = true
+				return policy
+			},
+			expectedStatus:    http.StatusOK,
+			expectedCallCount: 2,
+		},
+		{
+			name:              "error_retry_after_longer_than_allowed",
+			method:            http.MethodGet,
+			failures:          1,
+			failureStatus:     http.StatusTooManyRequests,
+			retryAfter:        "3600",
+			policy:            newTestRetryPolicy,
+			expectedStatus:    http.StatusTooManyRequests,
+			expectedCallCount: 1,
+		},
+		{
+			name:              "error_nil_policy_disables_retries",
+			method:            http.MethodGet,
+			failures:          1,
+			failureStatus:     http.StatusServiceUnavailable,
+			policy:            func() *ArkRetryPolicy { return nil },
+			expectedStatus:    http.StatusServiceUnavailable,
+			expectedCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := calls.Add(1)
+				if r.Method == http.MethodPut || r.Method == http.MethodPost {
+					body, _ := io.ReadAll(r.Body)
+					if string(body) != `{"name":"value"}` {
+						t.Errorf("call %d received body %q", call, string(body))
+					}
+				}
+				if int(call) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.failureStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := newTestRetryClient(server.URL, tt.policy())
+			ctx := context.Background()
+			if tt.optInContext {
+				ctx = WithArkRetryNonIdempotent(ctx)
+			}
+			var body interface{}
+			if tt.method == http.MethodPut || tt.method == http.MethodPost {
+				body = map[string]string{"name": "value"}
+			}
+			response, err := client.doRequest(ctx, tt.method, "api/resource", body, nil, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, response.StatusCode)
+			}
+			if calls.Load() != tt.expectedCallCount {
+				t.Errorf("expected %d calls, got %d", tt.expectedCallCount, calls.Load())
+			}
+		})
+	}
+}
+
+func TestArkClient_RetryPolicyFromContext(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, newTestRetryPolicy())
+	ctx := WithArkRetryPolicy(context.Background(), &ArkRetryPolicy{MaxAttempts: 1})
+	response, err := client.Get(ctx, "api/resource", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("expected the context policy to disable retries, got %d calls", calls.Load())
+	}
+}
+
+func TestArkClient_RetryStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := newTestRetryPolicy()
+	policy.InitialBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	client := newTestRetryClient(server.URL, policy)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Get(ctx, "api/resource", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the backoff to be interrupted by the context")
+	}
+}
+
+func TestArkClient_RetriesConnectionResets(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("failed to hijack connection: %v", err)
+			}
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, newTestRetryPolicy())
+	response, err := client.Get(context.Background(), "api/resource", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("expected a successful retry, got status %d after %d calls", response.StatusCode, calls.Load())
+	}
+}
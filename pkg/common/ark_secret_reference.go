@@ -0,0 +1,235 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ArkSecretReferenceLiteral is the scheme of secrets given as is, used to escape secrets that start with the scheme of a reference.
+	ArkSecretReferenceLiteral = "literal"
+
+	defaultSecretExecTimeout = 30 * time.Second
+)
+
+// ArkSecretResolver resolves the secret references of a scheme, such as "env:ARK_SECRET", to their secret.
+type ArkSecretResolver interface {
+	// ResolveSecret returns the secret a reference points to, given without its scheme.
+	ResolveSecret(ctx context.Context, reference string) (string, error)
+}
+
+// ArkSecretResolverFunc is a function implementing ArkSecretResolver.
+type ArkSecretResolverFunc func(ctx context.Context, reference string) (string, error)
+
+// ResolveSecret calls the function.
+func (f ArkSecretResolverFunc) ResolveSecret(ctx context.Context, reference string) (string, error) {
+	return f(ctx, reference)
+}
+
+var (
+	secretResolversMutex sync.RWMutex
+	secretResolvers      = map[string]ArkSecretResolver{
+		"env":  ArkSecretResolverFunc(resolveEnvSecret),
+		"file": ArkSecretResolverFunc(resolveFileSecret),
+		"exec": ArkSecretResolverFunc(resolveExecSecret),
+	}
+)
+
+// RegisterArkSecretResolver registers the resolver of the secret references of a scheme.
+//
+// The env, file and exec schemes are built in:
+//   - env:NAME resolves to the value of the environment variable NAME
+//   - file:PATH resolves to the content of the file at PATH, without its trailing newline
+//   - exec:COMMAND ARGS resolves to the output of the command, without its trailing newline.
+//     The command is run without a shell, arguments may be quoted with single or double quotes
+//
+// Registering a scheme again replaces its resolver.
+//
+// Parameters:
+//   - scheme: The scheme of the references, without the trailing colon
+//   - resolver: The resolver of the references
+//
+// Returns an error if the scheme is empty or literal, or the resolver is nil.
+//
+// Example:
+//
+//	err := common.RegisterArkSecretResolver("vault", common.ArkSecretResolverFunc(
+//		func(ctx context.Context, reference string) (string, error) {
+//			return vaultClient.Read(ctx, reference)
+//		},
+//	))
+func RegisterArkSecretResolver(scheme string, resolver ArkSecretResolver) error {
+	if scheme == "" || strings.ContainsAny(scheme, ": ") {
+		return fmt.Errorf("invalid secret reference scheme [%s]", scheme)
+	}
+	if scheme == ArkSecretReferenceLiteral {
+		return errors.New("the literal secret reference scheme cannot be replaced")
+	}
+	if resolver == nil {
+		return errors.New("secret resolver must not be nil")
+	}
+	secretResolversMutex.Lock()
+	defer secretResolversMutex.Unlock()
+	secretResolvers[scheme] = resolver
+	return nil
+}
+
+// ArkSecretReferenceSchemes returns the schemes of the registered secret resolvers, sorted.
+func ArkSecretReferenceSchemes() []string {
+	secretResolversMutex.RLock()
+	defer secretResolversMutex.RUnlock()
+	schemes := make([]string, 0, len(secretResolvers)+1)
+	for scheme := range secretResolvers {
+		schemes = append(schemes, scheme)
+	}
+	schemes = append(schemes, ArkSecretReferenceLiteral)
+	sort.Strings(schemes)
+	return schemes
+}
+
+// IsArkSecretReference returns whether a value is a reference to a secret, by the scheme it starts with.
+func IsArkSecretReference(value string) bool {
+	scheme, _, found := strings.Cut(value, ":")
+	if !found {
+		return false
+	}
+	if scheme == ArkSecretReferenceLiteral {
+		return true
+	}
+	secretResolversMutex.RLock()
+	defer secretResolversMutex.RUnlock()
+	_, ok := secretResolvers[scheme]
+	return ok
+}
+
+// ResolveArkSecret resolves a secret reference with the resolver of its scheme.
+//
+// Values that do not start with the scheme of a registered resolver are returned as is,
+// and "literal:" is removed from values starting with it, so secrets which happen to start
+// with a scheme, such as "env:abc", are given as "literal:env:abc".
+//
+// Example:
+//
+//	secret, err := common.ResolveArkSecret(ctx, "file:/run/secrets/ark")
+//	if err != nil {
+//		// handle error
+//	}
+func ResolveArkSecret(ctx context.Context, value string) (string, error) {
+	scheme, reference, found := strings.Cut(value, ":")
+	if !found {
+		return value, nil
+	}
+	if scheme == ArkSecretReferenceLiteral {
+		return reference, nil
+	}
+	secretResolversMutex.RLock()
+	resolver, ok := secretResolvers[scheme]
+	secretResolversMutex.RUnlock()
+	if !ok {
+		return value, nil
+	}
+	secret, err := resolver.ResolveSecret(ctx, reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s secret reference: %w", scheme, err)
+	}
+	if secret == "" {
+		return "", fmt.Errorf("%s secret reference resolved to an empty secret", scheme)
+	}
+	return secret, nil
+}
+
+func resolveEnvSecret(_ context.Context, reference string) (string, error) {
+	value, ok := os.LookupEnv(reference)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", reference)
+	}
+	return value, nil
+}
+
+func resolveFileSecret(_ context.Context, reference string) (string, error) {
+	path := reference
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(homeDir, path[1:])
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func resolveExecSecret(ctx context.Context, reference string) (string, error) {
+	commandArgs, err := splitCommandLine(reference)
+	if err != nil {
+		return "", err
+	}
+	if len(commandArgs) == 0 {
+		return "", errors.New("no command given")
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSecretExecTimeout)
+		defer cancel()
+	}
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, commandArgs[0], commandArgs[1:]...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("command %s failed: %w: %s", commandArgs[0], err, message)
+		}
+		return "", fmt.Errorf("command %s failed: %w", commandArgs[0], err)
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// splitCommandLine splits a command line into its arguments, by whitespace outside of single or double quotes.
+func splitCommandLine(commandLine string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	for _, r := range commandLine {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote in command")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
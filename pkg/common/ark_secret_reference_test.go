@@ -0,0 +1,188 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveArkSecret(t *testing.T) {
+	t.Setenv("ARK_TEST_SECRET", "env-secret")
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		value         string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "success_plain_secret",
+			value:    "p@ssw0rd",
+			expected: "p@ssw0rd",
+		},
+		{
+			name:     "success_unknown_scheme_kept",
+			value:    "abc:def",
+			expected: "abc:def",
+		},
+		{
+			name:     "success_literal",
+			value:    "literal:env:ARK_TEST_SECRET",
+			expected: "env:ARK_TEST_SECRET",
+		},
+		{
+			name:     "success_env",
+			value:    "env:ARK_TEST_SECRET",
+			expected: "env-secret",
+		},
+		{
+			name:          "error_env_not_set",
+			value:         "env:ARK_TEST_SECRET_NOT_SET",
+			expectedError: "environment variable ARK_TEST_SECRET_NOT_SET is not set",
+		},
+		{
+			name:     "success_file_trailing_newline_trimmed",
+			value:    "file:" + secretFile,
+			expected: "file-secret",
+		},
+		{
+			name:          "error_file_missing",
+			value:         "file:" + filepath.Join(t.TempDir(), "missing"),
+			expectedError: "failed to resolve file secret reference",
+		},
+		{
+			name:     "success_exec",
+			value:    "exec:echo 'exec secret'",
+			expected: "exec secret",
+		},
+		{
+			name:          "error_exec_failure",
+			value:         "exec:false",
+			expectedError: "command false failed",
+		},
+		{
+			name:          "error_exec_empty_output",
+			value:         "exec:true",
+			expectedError: "exec secret reference resolved to an empty secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := ResolveArkSecret(context.Background(), tt.value)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if secret != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, secret)
+			}
+		})
+	}
+}
+
+func TestRegisterArkSecretResolver(t *testing.T) {
+	resolverErr := errors.New("vault unavailable")
+	err := RegisterArkSecretResolver("testvault", ArkSecretResolverFunc(func(_ context.Context, reference string) (string, error) {
+		if reference == "broken" {
+			return "", resolverErr
+		}
+		return "vault-" + reference, nil
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		secretResolversMutex.Lock()
+		defer secretResolversMutex.Unlock()
+		delete(secretResolvers, "testvault")
+	})
+
+	if !IsArkSecretReference("testvault:account") {
+		t.Error("expected testvault reference to be a secret reference")
+	}
+	secret, err := ResolveArkSecret(context.Background(), "testvault:account")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret != "vault-account" {
+		t.Errorf("expected vault-account, got %q", secret)
+	}
+	_, err = ResolveArkSecret(context.Background(), "testvault:broken")
+	if !errors.Is(err, resolverErr) {
+		t.Errorf("expected resolver error to be wrapped, got %v", err)
+	}
+
+	if err := RegisterArkSecretResolver(ArkSecretReferenceLiteral, ArkSecretResolverFunc(nil)); err == nil {
+		t.Error("expected an error replacing the literal scheme")
+	}
+	if err := RegisterArkSecretResolver("bad scheme", ArkSecretResolverFunc(nil)); err == nil {
+		t.Error("expected an error for an invalid scheme")
+	}
+	if err := RegisterArkSecretResolver("nilresolver", nil); err == nil {
+		t.Error("expected an error for a nil resolver")
+	}
+}
+
+func TestIsArkSecretReference(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{value: "env:NAME", expected: true},
+		{value: "file:/run/secrets/ark", expected: true},
+		{value: "exec:cat secret", expected: true},
+		{value: "literal:secret", expected: true},
+		{value: "secret", expected: false},
+		{value: "unknown:secret", expected: false},
+	}
+	for _, tt := range tests {
+		if got := IsArkSecretReference(tt.value); got != tt.expected {
+			t.Errorf("IsArkSecretReference(%q) = %v, expected %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		commandLine   string
+		expected      []string
+		expectedError bool
+	}{
+		{name: "success_plain", commandLine: "vault read  secret", expected: []string{"vault", "read", "secret"}},
+		{name: "success_quoted", commandLine: `kubectl get secret "ark creds" -o 'jsonpath={.data}'`, expected: []string{"kubectl", "get", "secret", "ark creds", "-o", "jsonpath={.data}"}},
+		{name: "success_empty_quotes", commandLine: `cmd ""`, expected: []string{"cmd", ""}},
+		{name: "error_unterminated", commandLine: `cmd "arg`, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := splitCommandLine(tt.commandLine)
+			if tt.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, args)
+			}
+		})
+	}
+}
@@ -59,13 +59,15 @@ func findFieldByName(schema reflect.Type, name string) *reflect.StructField {
 // SerializeResponseToJSON reads all data from the provided io.ReadCloser, attempts to
 // parse it as JSON, and returns a properly formatted JSON string. If the input data
 // is not valid JSON, it returns the original data as a string. This function is useful
-// for normalizing response data into a consistent JSON format.
+// for normalizing response data into a consistent JSON format before it is logged
+// or put in an error, so secrets such as passwords, tokens and private keys are
+// redacted from it, see RegisterArkRedactedKeys.
 //
 // Parameters:
 //   - response: The io.ReadCloser containing the response data to serialize
 //
-// Returns a JSON string representation of the response data, or the original data
-// as a string if JSON parsing fails.
+// Returns a redacted JSON string representation of the response data, or the
+// redacted original data as a string if JSON parsing fails.
 //
 // Example:
 //
@@ -79,11 +81,11 @@ func SerializeResponseToJSON(response io.ReadCloser) string {
 	jsonMap := make(map[string]interface{})
 	err = json.Unmarshal(data, &jsonMap)
 	if err != nil {
-		return string(data)
+		return RedactArkString(string(data))
 	}
-	jsonData, err := json.Marshal(jsonMap)
+	jsonData, err := json.Marshal(redactArkJSONValue(jsonMap))
 	if err != nil {
-		return string(data)
+		return RedactArkString(string(data))
 	}
 	return string(jsonData)
 }
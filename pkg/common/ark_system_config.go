@@ -208,21 +208,29 @@ func DisableVerboseLogging() {
 // SetLoggerStyle sets the logger style based on the provided string.
 //
 // SetLoggerStyle configures the LoggerStyle environment variable. If the
-// provided style is "default", it sets the style to "default"; otherwise,
-// it defaults to "default" regardless of the input value.
+// provided style is "default" or "json", it sets the style accordingly;
+// otherwise, it defaults to "default" regardless of the input value.
+// The global logger is switched to the output of the selected style.
 //
 // Parameters:
-//   - loggerStyle: The desired logger style ("default" or any other value defaults to "default")
+//   - loggerStyle: The desired logger style ("default", "json", or any other value defaults to "default")
 //
 // Example:
 //
-//	SetLoggerStyle("default")
-//	SetLoggerStyle("custom") // Also sets to "default"
+//	SetLoggerStyle("json")
+//	SetLoggerStyle("custom") // Sets to "default"
 func SetLoggerStyle(loggerStyle string) {
-	if loggerStyle == "default" {
-		_ = os.Setenv(LoggerStyle, loggerStyle)
+	if loggerStyle != LoggerStyleJSON {
+		loggerStyle = LoggerStyleDefault
+	}
+	_ = os.Setenv(LoggerStyle, loggerStyle)
+	if GlobalLogger == nil {
+		return
+	}
+	if loggerStyle == LoggerStyleJSON {
+		GlobalLogger.SetHandler(NewArkJSONHandler(os.Stdout))
 	} else {
-		_ = os.Setenv(LoggerStyle, "default")
+		GlobalLogger.SetHandler(nil)
 	}
 }
 
@@ -0,0 +1,320 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// ArkInstrumentationName is the instrumentation scope name of the spans and metrics emitted by the SDK.
+const ArkInstrumentationName = "github.com/cyberark/ark-sdk-golang"
+
+// Names of the metrics emitted by ArkClient.
+const (
+	ArkRequestsMetric        = "ark.client.requests"
+	ArkRequestDurationMetric = "ark.client.request.duration"
+	ArkRetriesMetric         = "ark.client.retries"
+	ArkTokenRefreshesMetric  = "ark.client.token_refreshes"
+)
+
+// ArkTelemetryConfig holds the OpenTelemetry providers used to instrument the SDK.
+//
+// Fields:
+//   - TracerProvider: Provider of the tracer used for service call and HTTP request spans (nil for no tracing)
+//   - MeterProvider: Provider of the meter used for request counters and latency histograms (nil for no metrics)
+type ArkTelemetryConfig struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// arkTelemetry holds the tracer and metric instruments built out of an ArkTelemetryConfig.
+type arkTelemetry struct {
+	tracer         trace.Tracer
+	requests       metric.Int64Counter
+	duration       metric.Float64Histogram
+	retries        metric.Int64Counter
+	tokenRefreshes metric.Int64Counter
+}
+
+var telemetry atomic.Pointer[arkTelemetry]
+
+func init() {
+	noopTelemetry, _ := newArkTelemetry(nil)
+	telemetry.Store(noopTelemetry)
+}
+
+func newArkTelemetry(config *ArkTelemetryConfig) (*arkTelemetry, error) {
+	var tracerProvider trace.TracerProvider = tracenoop.NewTracerProvider()
+	var meterProvider metric.MeterProvider = metricnoop.NewMeterProvider()
+	if config != nil && config.TracerProvider != nil {
+		tracerProvider = config.TracerProvider
+	}
+	if config != nil && config.MeterProvider != nil {
+		meterProvider = config.MeterProvider
+	}
+	meter := meterProvider.Meter(ArkInstrumentationName, metric.WithInstrumentationVersion(ArkVersion()))
+	requests, err := meter.Int64Counter(
+		ArkRequestsMetric,
+		metric.WithDescription("Number of HTTP requests sent to the platform services"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram(
+		ArkRequestDurationMetric,
+		metric.WithDescription("Duration of HTTP requests sent to the platform services, until the response headers are received"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	retries, err := meter.Int64Counter(
+		ArkRetriesMetric,
+		metric.WithDescription("Number of HTTP requests that were sent again after a transient failure"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tokenRefreshes, err := meter.Int64Counter(
+		ArkTokenRefreshesMetric,
+		metric.WithDescription("Number of token refreshes caused by unauthorized responses"),
+		metric.WithUnit("{refresh}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &arkTelemetry{
+		tracer:         tracerProvider.Tracer(ArkInstrumentationName, trace.WithInstrumentationVersion(ArkVersion())),
+		requests:       requests,
+		duration:       duration,
+		retries:        retries,
+		tokenRefreshes: tokenRefreshes,
+	}, nil
+}
+
+// SetArkTelemetry sets the OpenTelemetry providers used to instrument the SDK.
+//
+// The SDK emits no spans or metrics until providers are set. Once set, every
+// service call gets a span named after its service and method, such as
+// "pcloud.accounts.ListAccounts", with a child span for every HTTP request it
+// sends. Requests are also counted and timed per service, operation, method and
+// route. To use the global OpenTelemetry providers, pass otel.GetTracerProvider()
+// and otel.GetMeterProvider().
+//
+// Parameters:
+//   - config: The providers to use, nil disables the instrumentation again
+//
+// Returns an error if the metric instruments could not be created.
+//
+// Example:
+//
+//	err := common.SetArkTelemetry(&common.ArkTelemetryConfig{
+//	    TracerProvider: tracerProvider,
+//	    MeterProvider:  meterProvider,
+//	})
+func SetArkTelemetry(config *ArkTelemetryConfig) error {
+	newTelemetry, err := newArkTelemetry(config)
+	if err != nil {
+		return err
+	}
+	telemetry.Store(newTelemetry)
+	return nil
+}
+
+type arkServiceSpanContextKey struct{}
+
+type arkServiceSpan struct {
+	span      trace.Span
+	operation string
+}
+
+type arkTokenRefreshedContextKey struct{}
+
+// StartArkServiceSpan starts the span of a call to a service method.
+//
+// The span is named after the service and method, with the dashes of the service
+// name replaced by dots, so "pcloud-accounts" and "ListAccounts" make
+// "pcloud.accounts.ListAccounts". HTTP requests sent with the returned context
+// become children of the span, and the API errors of their responses are
+// recorded on it. The caller must end the span.
+//
+// Parameters:
+//   - ctx: The context of the call
+//   - serviceName: The name of the service, as in its service config
+//   - method: The name of the called method
+//
+// Returns the context holding the span, and the span.
+func StartArkServiceSpan(ctx context.Context, serviceName string, method string) (context.Context, trace.Span) {
+	operation := strings.ReplaceAll(serviceName, "-", ".") + "." + method
+	ctx, span := telemetry.Load().tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attribute.String("ark.service", serviceName),
+			attribute.String("ark.method", method),
+		),
+	)
+	return context.WithValue(ctx, arkServiceSpanContextKey{}, &arkServiceSpan{span: span, operation: operation}), span
+}
+
+// TraceArkPageIterator wraps a page iterator with the span of the service method that produced it.
+//
+// The span starts when iteration starts and ends when it stops, so it covers the
+// requests of all the pages that were retrieved. The iterator is built lazily
+// with the context of the span.
+//
+// Parameters:
+//   - ctx: The context of the call
+//   - serviceName: The name of the service, as in its service config
+//   - method: The name of the called method
+//   - pages: Builds the page iterator with the context of the span
+//
+// Returns the traced page iterator.
+func TraceArkPageIterator[T any](ctx context.Context, serviceName string, method string, pages func(ctx context.Context) ArkPageIterator[T]) ArkPageIterator[T] {
+	return func(yield func(*ArkPage[T], error) bool) {
+		spanCtx, span := StartArkServiceSpan(ctx, serviceName, method)
+		defer span.End()
+		pageCount := 0
+		defer func() {
+			span.SetAttributes(attribute.Int("ark.pages", pageCount))
+		}()
+		for page, err := range pages(spanCtx) {
+			if err != nil {
+				recordArkServiceError(spanCtx, err)
+			} else {
+				pageCount++
+			}
+			if !yield(page, err) {
+				return
+			}
+		}
+	}
+}
+
+// recordArkServiceError marks the service span of the context, if any, as failed with the given error.
+func recordArkServiceError(ctx context.Context, err error) {
+	if serviceSpan, ok := ctx.Value(arkServiceSpanContextKey{}).(*arkServiceSpan); ok {
+		serviceSpan.span.RecordError(err)
+		serviceSpan.span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func arkOperationFromContext(ctx context.Context) string {
+	if serviceSpan, ok := ctx.Value(arkServiceSpanContextKey{}).(*arkServiceSpan); ok {
+		return serviceSpan.operation
+	}
+	return ""
+}
+
+var (
+	versionSegmentPattern = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)*$`)
+	identifierCharacters  = "0123456789@:=%"
+)
+
+// arkRouteTemplate replaces the path segments that look like identifiers with "{id}",
+// so that metrics are not split per resource.
+func arkRouteTemplate(urlPath string) string {
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		if segment == "" || versionSegmentPattern.MatchString(segment) {
+			continue
+		}
+		if strings.ContainsAny(segment, identifierCharacters) || len(segment) > 40 {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// startHTTPSpan starts the span of a single HTTP request and returns the request carrying it.
+func (t *arkTelemetry) startHTTPSpan(req *http.Request, serviceName string, resendCount int) (*http.Request, trace.Span) {
+	route := arkRouteTemplate(req.URL.Path)
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("http.route", route),
+		attribute.String("server.address", req.URL.Hostname()),
+		attribute.String("url.path", req.URL.Path),
+		attribute.String("ark.service", serviceName),
+	}
+	if resendCount > 0 {
+		attributes = append(attributes, attribute.Int("http.request.resend_count", resendCount))
+	}
+	if refreshed, _ := req.Context().Value(arkTokenRefreshedContextKey{}).(bool); refreshed {
+		attributes = append(attributes, attribute.Bool("ark.token_refreshed", true))
+	}
+	ctx, span := t.tracer.Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	return req.WithContext(ctx), span
+}
+
+// endHTTPSpan records the outcome of an HTTP request on its span and metrics.
+//
+// The span is ended right away when the request failed, otherwise it is ended once
+// the response body is closed.
+func (t *arkTelemetry) endHTTPSpan(req *http.Request, span trace.Span, serviceName string, resp *http.Response, err error, started time.Time) {
+	attributes := []attribute.KeyValue{
+		attribute.String("ark.service", serviceName),
+		attribute.String("ark.operation", arkOperationFromContext(req.Context())),
+		attribute.String("http.request.method", req.Method),
+		attribute.String("http.route", arkRouteTemplate(req.URL.Path)),
+	}
+	if err != nil {
+		errorType := "transport"
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			errorType = "canceled"
+		}
+		attributes = append(attributes, attribute.String("error.type", errorType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+	} else {
+		attributes = append(attributes, attribute.Int("http.response.status_code", resp.StatusCode))
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			attributes = append(attributes, attribute.String("error.type", strconv.Itoa(resp.StatusCode)))
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	measurement := metric.WithAttributes(attributes...)
+	t.requests.Add(req.Context(), 1, measurement)
+	t.duration.Record(req.Context(), time.Since(started).Seconds(), measurement)
+}
+
+// recordRetry counts a request that is about to be sent again, adding an event to the span of the caller.
+func (t *arkTelemetry) recordRetry(ctx context.Context, req *http.Request, serviceName string, reason string, delay time.Duration) {
+	trace.SpanFromContext(ctx).AddEvent("ark.retry", trace.WithAttributes(
+		attribute.String("http.request.method", req.Method),
+		attribute.String("http.route", arkRouteTemplate(req.URL.Path)),
+		attribute.String("ark.retry.reason", reason),
+		attribute.Int64("ark.retry.delay_ms", delay.Milliseconds()),
+	))
+	t.retries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("ark.service", serviceName),
+		attribute.String("http.request.method", req.Method),
+		attribute.String("http.route", arkRouteTemplate(req.URL.Path)),
+	))
+}
+
+// recordTokenRefresh counts a token refresh caused by an unauthorized response, adding an
+// event to the span of the caller, and marks the following requests of the context as
+// sent with a refreshed token.
+func (t *arkTelemetry) recordTokenRefresh(ctx context.Context, serviceName string) context.Context {
+	trace.SpanFromContext(ctx).AddEvent("ark.token_refresh")
+	t.tokenRefreshes.Add(ctx, 1, metric.WithAttributes(attribute.String("ark.service", serviceName)))
+	return context.WithValue(ctx, arkTokenRefreshedContextKey{}, true)
+}
@@ -0,0 +1,282 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupTestTelemetry(t *testing.T) (*tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	err := SetArkTelemetry(&ArkTelemetryConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatalf("failed to set telemetry: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = SetArkTelemetry(nil)
+	})
+	return exporter, reader
+}
+
+func findSpan(spans tracetest.SpanStubs, name string) (tracetest.SpanStub, bool) {
+	for _, span := range spans {
+		if span.Name == name {
+			return span, true
+		}
+	}
+	return tracetest.SpanStub{}, false
+}
+
+func spanAttribute(span tracetest.SpanStub, key string) (attribute.Value, bool) {
+	for _, attr := range span.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func hasEvent(span tracetest.SpanStub, name string) bool {
+	for _, event := range span.Events {
+		if event.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func collectMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) (metricdata.Aggregation, bool) {
+	t.Helper()
+	var resourceMetrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &resourceMetrics); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if m.Name == name {
+				return m.Data, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func sumOf(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	t.Helper()
+	data, ok := collectMetric(t, reader, name)
+	if !ok {
+		return 0
+	}
+	var total int64
+	for _, point := range data.(metricdata.Sum[int64]).DataPoints {
+		total += point.Value
+	}
+	return total
+}
+
+func TestArkRouteTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		urlPath  string
+		expected string
+	}{
+		{name: "success_static_route", urlPath: "/passwordvault/api/Accounts", expected: "/passwordvault/api/Accounts"},
+		{name: "success_numeric_identifier", urlPath: "/api/accounts/12_34/secret/versions", expected: "/api/accounts/{id}/secret/versions"},
+		{name: "success_uuid_identifier", urlPath: "/api/pools/3f1b0c9e-5a7d-4e2b-9c1d-2f3a4b5c6d7e", expected: "/api/pools/{id}"},
+		{name: "success_email_identifier", urlPath: "/api/users/tina@cyberark.cloud", expected: "/api/users/{id}"},
+		{name: "success_version_segment_kept", urlPath: "/api/v2/secrets", expected: "/api/v2/secrets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := arkRouteTemplate(tt.urlPath); result != tt.expected {
+				t.Errorf("arkRouteTemplate(%q) = %q, want %q", tt.urlPath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArkTelemetry_NoopByDefault(t *testing.T) {
+	_, span := StartArkServiceSpan(context.Background(), "pcloud-accounts", "ListAccounts")
+	defer span.End()
+	if span.IsRecording() {
+		t.Errorf("expected spans not to be recorded without a tracer provider")
+	}
+}
+
+func TestArkClient_TelemetryRetries(t *testing.T) {
+	exporter, reader := setupTestTelemetry(t)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(server.URL, newTestRetryPolicy())
+	client.SetServiceName("privilegecloud")
+	ctx, serviceSpan := StartArkServiceSpan(context.Background(), "pcloud-accounts", "Account")
+	response, err := client.Get(ctx, "api/Accounts/12_3", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = response.Body.Close()
+	serviceSpan.End()
+
+	spans := exporter.GetSpans()
+	parent, ok := findSpan(spans, "pcloud.accounts.Account")
+	if !ok {
+		t.Fatalf("expected a service span, got %v", spans)
+	}
+	if !hasEvent(parent, "ark.retry") {
+		t.Errorf("expected the retry to be recorded on the service span")
+	}
+	var requestSpans []tracetest.SpanStub
+	for _, span := range spans {
+		if span.Name == "GET /api/Accounts/{id}" {
+			requestSpans = append(requestSpans, span)
+		}
+	}
+	if len(requestSpans) != 2 {
+		t.Fatalf("expected a span per HTTP request, got %v", spans)
+	}
+	for i, span := range requestSpans {
+		if span.Parent.SpanID() != parent.SpanContext.SpanID() {
+			t.Errorf("expected request span %d to be a child of the service span", i)
+		}
+	}
+	if status, _ := spanAttribute(requestSpans[0], "http.response.status_code"); status.AsInt64() != http.StatusServiceUnavailable {
+		t.Errorf("unexpected status of the first request %v", status)
+	}
+	if requestSpans[0].Status.Code != codes.Error {
+		t.Errorf("expected the failed request span to have an error status")
+	}
+	if resendCount, ok := spanAttribute(requestSpans[1], "http.request.resend_count"); !ok || resendCount.AsInt64() != 1 {
+		t.Errorf("expected the second request to be marked as resent, got %v", resendCount)
+	}
+
+	if requests := sumOf(t, reader, ArkRequestsMetric); requests != 2 {
+		t.Errorf("expected 2 requests to be counted, got %d", requests)
+	}
+	if retries := sumOf(t, reader, ArkRetriesMetric); retries != 1 {
+		t.Errorf("expected 1 retry to be counted, got %d", retries)
+	}
+	data, ok := collectMetric(t, reader, ArkRequestDurationMetric)
+	if !ok {
+		t.Fatalf("expected the request duration to be recorded")
+	}
+	for _, point := range data.(metricdata.Histogram[float64]).DataPoints {
+		route, _ := point.Attributes.Value("http.route")
+		operation, _ := point.Attributes.Value("ark.operation")
+		if route.AsString() != "/api/Accounts/{id}" || operation.AsString() != "pcloud.accounts.Account" {
+			t.Errorf("unexpected duration attributes %v", point.Attributes.ToSlice())
+		}
+	}
+}
+
+func TestArkClient_TelemetryTokenRefresh(t *testing.T) {
+	exporter, reader := setupTestTelemetry(t)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewArkClient("", "token", "Bearer", "Authorization", nil, func(client *ArkClient) error {
+		client.UpdateToken("refreshed", "Bearer")
+		return nil
+	})
+	client.BaseURL = server.URL
+	ctx, serviceSpan := StartArkServiceSpan(context.Background(), "cmgr", "ListNetworks")
+	response, err := client.Get(ctx, "api/networks", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = response.Body.Close()
+	serviceSpan.End()
+
+	spans := exporter.GetSpans()
+	parent, ok := findSpan(spans, "cmgr.ListNetworks")
+	if !ok || !hasEvent(parent, "ark.token_refresh") {
+		t.Errorf("expected the token refresh to be recorded on the service span, got %v", spans)
+	}
+	var refreshed int
+	for _, span := range spans {
+		if value, ok := spanAttribute(span, "ark.token_refreshed"); ok && value.AsBool() {
+			refreshed++
+		}
+	}
+	if refreshed != 1 {
+		t.Errorf("expected a single request to be sent with a refreshed token, got %d", refreshed)
+	}
+	if refreshes := sumOf(t, reader, ArkTokenRefreshesMetric); refreshes != 1 {
+		t.Errorf("expected 1 token refresh to be counted, got %d", refreshes)
+	}
+}
+
+func TestTraceArkPageIterator(t *testing.T) {
+	exporter, _ := setupTestTelemetry(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"message":"missing"}`)
+	}))
+	defer server.Close()
+	client := newTestRetryClient(server.URL, nil)
+
+	pages := TraceArkPageIterator(context.Background(), "pcloud-safes", "ListSafes", func(ctx context.Context) ArkPageIterator[string] {
+		return func(yield func(*ArkPage[string], error) bool) {
+			if !yield(&ArkPage[string]{Items: []*string{}}, nil) {
+				return
+			}
+			response, err := client.Get(ctx, "api/Safes", nil)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			defer response.Body.Close()
+			yield(nil, NewArkAPIError(response, "failed to list safes"))
+		}
+	})
+	var lastErr error
+	for _, err := range pages {
+		lastErr = err
+	}
+	if !errors.Is(lastErr, ErrNotFound) {
+		t.Fatalf("expected the API error to be returned, got %v", lastErr)
+	}
+
+	span, ok := findSpan(exporter.GetSpans(), "pcloud.safes.ListSafes")
+	if !ok {
+		t.Fatalf("expected a span covering the iteration")
+	}
+	if span.Status.Code != codes.Error || !strings.Contains(span.Status.Description, "failed to list safes") {
+		t.Errorf("expected the API error to be recorded on the span, got %+v", span.Status)
+	}
+	if pageCount, _ := spanAttribute(span, "ark.pages"); pageCount.AsInt64() != 1 {
+		t.Errorf("expected 1 page to be recorded, got %v", pageCount)
+	}
+}
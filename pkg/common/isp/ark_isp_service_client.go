@@ -8,16 +8,16 @@ package isp
 
 import (
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
-	"github.com/golang-jwt/jwt/v5"
 	cookiejar "github.com/juju/persistent-cookiejar"
 )
 
@@ -51,6 +51,7 @@ type ArkISPServiceClient struct {
 //   - basePath: Additional base path to append to the service URL
 //   - cookieJar: The cookie jar for maintaining session state
 //   - refreshConnectionCallback: Callback function to refresh the connection when needed
+//   - options: Optional client options, such as common.WithTransportConfig
 //
 // Returns a configured ArkISPServiceClient instance and any error that occurred during
 // initialization, particularly from URL parsing or service URL resolution.
@@ -80,6 +81,7 @@ func NewArkISPServiceClient(
 	basePath string,
 	cookieJar *cookiejar.Jar,
 	refreshConnectionCallback func(*common.ArkClient) error,
+	options ...common.ArkClientOption,
 ) (*ArkISPServiceClient, error) {
 	if tenantEnv == "" {
 		tenantEnv = commonmodels.AwsEnv(os.Getenv("DEPLOY_ENV"))
@@ -99,12 +101,13 @@ func NewArkISPServiceClient(
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse service URL: %w", err)
 	}
-	client := common.NewArkClient(serviceURL, token, "Bearer", authHeaderName, cookieJar, refreshConnectionCallback)
+	client := common.NewArkClient(serviceURL, token, "Bearer", authHeaderName, cookieJar, refreshConnectionCallback, options...)
 	client.SetHeader("Origin", fmt.Sprintf("%s://%s", parsedURL.Scheme, parsedURL.Host))
 	client.SetHeader("Referer", fmt.Sprintf("%s://%s", parsedURL.Scheme, parsedURL.Host))
 	client.SetHeader("Content-Type", "application/json")
 	client.SetHeader("Accept", "*/*")
 	client.SetHeader("Connection", "keep-alive")
+	client.SetServiceName(serviceName)
 
 	return &ArkISPServiceClient{
 		ArkClient: client,
@@ -149,16 +152,13 @@ func resolveServiceURL(
 	var tenantChosenSubdomain string
 
 	if token != "" {
-		parsedToken, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		claims, err := authmodels.ParseArkTokenClaims(token)
 		if err != nil {
 			return "", err
 		}
-		claims := parsedToken.Claims.(jwt.MapClaims)
-		if subdomain, ok := claims["subdomain"].(string); ok {
-			tenantChosenSubdomain = subdomain
-		}
-		if platformTokenDomain, ok := claims["platform_domain"].(string); ok {
-			platformDomain = platformTokenDomain
+		tenantChosenSubdomain = claims.Subdomain
+		if claims.PlatformDomain != "" {
+			platformDomain = claims.PlatformDomain
 			if strings.HasPrefix(platformDomain, "shell.") && serviceName != "" {
 				platformDomain = strings.TrimPrefix(platformDomain, "shell.")
 			}
@@ -187,13 +187,12 @@ func resolveServiceURL(
 	}
 
 	if tenantChosenSubdomain == "" {
-		parsedToken, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		claims, err := authmodels.ParseArkTokenClaims(token)
 		if err != nil {
 			return "", err
 		}
-		claims := parsedToken.Claims.(jwt.MapClaims)
-		if uniqueName, ok := claims["unique_name"].(string); ok {
-			fullDomain := strings.Split(uniqueName, "@")
+		if claims.Username != "" {
+			fullDomain := strings.Split(claims.Username, "@")
 			if len(fullDomain) > 1 {
 				domainPart := fullDomain[1]
 				for env, domain := range commonmodels.RootDomain {
@@ -247,9 +246,8 @@ func (client *ArkISPServiceClient) TenantEnv() commonmodels.AwsEnv {
 // requires a valid JWT token to be present in the client.
 //
 // Returns the tenant ID as a string and any error that occurred during JWT token
-// parsing. Returns an error if no token is available or if the token cannot be parsed.
-// Note: This method performs type assertion on the tenant_id claim and may panic if
-// the claim is not a string type.
+// parsing. Returns an error if no token is available, if the token cannot be parsed,
+// or if it has no tenant_id claim.
 //
 // Example:
 //
@@ -261,12 +259,13 @@ func (client *ArkISPServiceClient) TenantEnv() commonmodels.AwsEnv {
 //	fmt.Printf("Current tenant: %s", tenantID)
 func (client *ArkISPServiceClient) TenantID() (string, error) {
 	if client.ArkClient.GetToken() != "" {
-		parsedToken, _, err := new(jwt.Parser).ParseUnverified(client.ArkClient.GetToken(), jwt.MapClaims{})
+		claims, err := authmodels.ParseArkTokenClaims(client.ArkClient.GetToken())
 		if err != nil {
 			return "", err
 		}
-		claims := parsedToken.Claims.(jwt.MapClaims)
-		return claims["tenant_id"].(string), nil
+		if claims.TenantID != "" {
+			return claims.TenantID, nil
+		}
 	}
 	return "", fmt.Errorf("failed to retrieve tenant id")
 }
@@ -276,7 +275,11 @@ func (client *ArkISPServiceClient) TenantID() (string, error) {
 // This function creates an ISP service client using authentication information from
 // an existing ArkISPAuth instance. It extracts tenant environment information from
 // the auth token's username or metadata, decodes and sets up cookies from the token
-// metadata, and initializes the client with the appropriate configuration.
+// metadata, and initializes the client with the appropriate configuration. The
+// transport settings of the profile the authenticator was last used with, if any,
+// are applied to the client, followed by the client options of the authenticator.
+// The client is attached to the authenticator, so tokens the authenticator renews,
+// such as with its background refresh, are applied to it.
 //
 // Parameters:
 //   - ispAuth: The ArkISPAuth instance containing authentication information and tokens
@@ -284,6 +287,7 @@ func (client *ArkISPServiceClient) TenantID() (string, error) {
 //   - separator: The separator character used in URL construction
 //   - basePath: Additional base path to append to the service URL
 //   - refreshConnectionCallback: Callback function for connection refresh operations
+//   - options: Optional client options, applied after the transport settings of the active profile and the client options of the authenticator
 //
 // Returns a configured ArkISPServiceClient and any error that occurred during client
 // creation, cookie unmarshaling, or service URL resolution.
@@ -300,7 +304,7 @@ func (client *ArkISPServiceClient) TenantID() (string, error) {
 //	if err != nil {
 //	    return fmt.Errorf("failed to create client: %w", err)
 //	}
-func FromISPAuth(ispAuth *auth.ArkISPAuth, serviceName string, separator string, basePath string, refreshConnectionCallback func(*common.ArkClient) error) (*ArkISPServiceClient, error) {
+func FromISPAuth(ispAuth *auth.ArkISPAuth, serviceName string, separator string, basePath string, refreshConnectionCallback func(*common.ArkClient) error, options ...common.ArkClientOption) (*ArkISPServiceClient, error) {
 	var tenantEnv commonmodels.AwsEnv
 	var baseTenantURL string
 	if ispAuth.Token.Username != "" {
@@ -329,7 +333,16 @@ func FromISPAuth(ispAuth *auth.ArkISPAuth, serviceName string, separator string,
 			return nil, err
 		}
 	}
-	return NewArkISPServiceClient(serviceName, "", baseTenantURL, tenantEnv, ispAuth.Token.Token, "Authorization", separator, basePath, cookieJar, refreshConnectionCallback)
+	options = append(slices.Clone(ispAuth.ClientOptions()), options...)
+	if ispAuth.ActiveProfile != nil && ispAuth.ActiveProfile.TransportConfig != nil {
+		options = append([]common.ArkClientOption{common.WithTransportConfig(ispAuth.ActiveProfile.TransportConfig)}, options...)
+	}
+	client, err := NewArkISPServiceClient(serviceName, "", baseTenantURL, tenantEnv, ispAuth.Token.Token, "Authorization", separator, basePath, cookieJar, refreshConnectionCallback, options...)
+	if err != nil {
+		return nil, err
+	}
+	ispAuth.AttachClient(client.ArkClient)
+	return client, nil
 }
 
 // RefreshClient refreshes the ArkISPServiceClient with the latest authentication token and cookies.
@@ -359,13 +372,7 @@ func RefreshClient(client *common.ArkClient, ispAuth *auth.ArkISPAuth) error {
 		return err
 	}
 	if token != nil {
-		client.UpdateToken(token.Token, client.GetTokenType())
-		cookieJar := make(map[string]string)
-		if cookies, ok := token.Metadata["cookies"]; ok {
-			decoded, _ := base64.StdEncoding.DecodeString(cookies.(string))
-			_ = json.Unmarshal(decoded, &cookieJar)
-		}
-		client.UpdateCookies(cookieJar)
+		auth.UpdateClientToken(client, token)
 	}
 	return nil
 }
@@ -7,16 +7,24 @@
 package keyring
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
 )
 
 const (
@@ -37,22 +45,93 @@ const (
 	// ArkBasicKeyringFolderEnvVar is the environment variable name that can be used
 	// to override the default keyring folder location.
 	ArkBasicKeyringFolderEnvVar = "ARK_KEYRING_FOLDER"
+
+	// ArkKeyringPassphraseEnvVar is the environment variable name of the passphrase
+	// the key of the basic keyring is derived from.
+	ArkKeyringPassphraseEnvVar = "ARK_KEYRING_PASSPHRASE"
+)
+
+const (
+	// basicKeyringVersion is the version of the basic keyring file format.
+	basicKeyringVersion = 2
+	// basicKeyringCheckValue is sealed in the keyring to tell a wrong passphrase from a corrupted entry.
+	basicKeyringCheckValue = "ark-basic-keyring"
+	argon2idAlgorithm      = "argon2id"
+	argon2idTime           = 3
+	argon2idMemory         = 64 * 1024
+	argon2idThreads        = 4
+	argon2idSaltSize       = 16
+	keySize                = 32
+	lockRetryInterval      = 25 * time.Millisecond
+	lockTimeout            = 10 * time.Second
+	staleLockAge           = 30 * time.Second
+)
+
+// Errors of the basic keyring
+var (
+	// ErrBasicKeyringPassphraseRequired is returned when the keyring was protected with a passphrase and none is given.
+	ErrBasicKeyringPassphraseRequired = errors.New("basic keyring is protected by a passphrase, set " + ArkKeyringPassphraseEnvVar)
+	// ErrBasicKeyringWrongKey is returned when the keyring cannot be decrypted with the given passphrase or the local key.
+	ErrBasicKeyringWrongKey = errors.New("basic keyring cannot be decrypted with the given passphrase or local key")
 )
 
+var (
+	derivedKeysMutex sync.Mutex
+	derivedKeys      = map[[sha256.Size]byte][]byte{}
+)
+
+// basicKeyringKDF holds the parameters the key of the keyring is derived from its passphrase with.
+type basicKeyringKDF struct {
+	Algorithm string `json:"algorithm"`
+	Salt      string `json:"salt"`
+	Time      uint32 `json:"time"`
+	Memory    uint32 `json:"memory"`
+	Threads   uint8  `json:"threads"`
+}
+
+// basicKeyringFile is the content of the keyring file.
+//
+// Entries are sealed separately with AES-GCM, with a random nonce and their service
+// name and username as additional data. When KDF is nil, the key is the local key
+// stored next to the keyring, rather than one derived from a passphrase.
+type basicKeyringFile struct {
+	Version int                                     `json:"version"`
+	KDF     *basicKeyringKDF                        `json:"kdf,omitempty"`
+	Check   map[string]string                       `json:"check"`
+	Entries map[string]map[string]map[string]string `json:"entries"`
+}
+
+func (f *basicKeyringFile) setEntry(serviceName string, username string, entry map[string]string) {
+	if f.Entries == nil {
+		f.Entries = make(map[string]map[string]map[string]string)
+	}
+	if _, ok := f.Entries[serviceName]; !ok {
+		f.Entries[serviceName] = make(map[string]map[string]string)
+	}
+	f.Entries[serviceName][username] = entry
+}
+
 // ArkBasicKeyring is a simple keyring implementation that uses AES encryption to store passwords.
 //
-// ArkBasicKeyring provides secure password storage using AES-GCM encryption with the hostname
-// as the encryption key. Passwords are stored in a JSON file with MAC (Message Authentication
-// Code) validation to ensure data integrity. The keyring supports multiple services and
-// usernames within each service.
+// ArkBasicKeyring provides secure password storage using AES-GCM encryption. Passwords are
+// stored in a JSON file with MAC (Message Authentication Code) validation to ensure data
+// integrity. The keyring supports multiple services and usernames within each service.
 //
-// The encryption key is derived from the system hostname and padded using PKCS7 padding
-// to ensure consistent key length. Each password entry is encrypted separately with its
-// own nonce for security.
+// The encryption key is derived with Argon2id from the passphrase of the keyring, given to
+// NewArkBasicKeyringWithPassphrase or by the ARK_KEYRING_PASSPHRASE environment variable.
+// Without a passphrase, a random local key is generated and stored next to the keyring,
+// readable by its owner only. Each password entry is encrypted separately with a random
+// nonce, bound to its service name and username.
+//
+// Files are replaced atomically while holding a lock file, so that concurrent CLI
+// invocations do not lose each other's entries. Keyrings of the previous format, keyed
+// by the hostname, are migrated on first use.
 //
 // File Structure:
 //   - keyring: JSON file containing encrypted password data
 //   - mac: File containing SHA256 hash of keyring file for integrity validation
+//   - keyring.key: The local key, when the keyring has no passphrase
+//   - keyring.lock: Lock file held while the keyring is read or updated
 type ArkBasicKeyring struct {
 	ArkKeyringImpl
 	// basicFolderPath is the absolute path to the keyring folder
@@ -61,6 +140,12 @@ type ArkBasicKeyring struct {
 	keyringFilePath string
 	// macFilePath is the absolute path to the MAC validation file
 	macFilePath string
+	// keyFilePath is the absolute path to the local key file
+	keyFilePath string
+	// lockFilePath is the absolute path to the lock file
+	lockFilePath string
+	// passphrase is the passphrase the key is derived from, if any
+	passphrase string
 }
 
 // NewArkBasicKeyring creates a new ArkBasicKeyring instance with initialized folder and file paths.
@@ -68,6 +153,7 @@ type ArkBasicKeyring struct {
 // NewArkBasicKeyring initializes the keyring folder structure and returns a new ArkBasicKeyring
 // instance. The folder location is determined by the ArkBasicKeyringFolderEnvVar environment
 // variable, or defaults to DefaultBasicKeyringFolder within the user's HOME directory.
+// The passphrase of the keyring is taken from the ArkKeyringPassphraseEnvVar environment variable.
 //
 // The function automatically creates the keyring folder if it doesn't exist. If folder
 // creation fails, the function returns nil.
@@ -76,6 +162,7 @@ type ArkBasicKeyring struct {
 //
 // Environment Variables:
 //   - ARK_KEYRING_FOLDER: Override default keyring folder location
+//   - ARK_KEYRING_PASSPHRASE: Passphrase the key of the keyring is derived from
 //   - HOME: Used for default keyring folder path construction
 //
 // Example:
@@ -85,10 +172,39 @@ type ArkBasicKeyring struct {
 //	    // Handle keyring initialization failure
 //	}
 func NewArkBasicKeyring() *ArkBasicKeyring {
+	return NewArkBasicKeyringWithPassphrase(os.Getenv(ArkKeyringPassphraseEnvVar))
+}
+
+// NewArkBasicKeyringWithPassphrase creates a new ArkBasicKeyring instance, with a key derived from the given passphrase.
+//
+// An empty passphrase uses the local key of the keyring. Keyrings using the local key are
+// switched to the passphrase the first time they are opened with one, while keyrings
+// protected by a passphrase cannot be opened without it.
+//
+// Returns a new ArkBasicKeyring instance or nil if folder creation fails.
+//
+// Example:
+//
+//	keyring := NewArkBasicKeyringWithPassphrase(passphrase)
+func NewArkBasicKeyringWithPassphrase(passphrase string) *ArkBasicKeyring {
 	basicFolderPath := filepath.Join(os.Getenv("HOME"), DefaultBasicKeyringFolder)
 	if folder := os.Getenv(ArkBasicKeyringFolderEnvVar); folder != "" {
 		basicFolderPath = folder
 	}
+	return NewArkBasicKeyringAtFolder(basicFolderPath, passphrase)
+}
+
+// NewArkBasicKeyringAtFolder creates a new ArkBasicKeyring instance stored in the given folder, with a key derived from the given passphrase.
+//
+// Unlike NewArkBasicKeyring, the folder and passphrase are not taken from the environment. An empty
+// passphrase uses the local key of the keyring, as in NewArkBasicKeyringWithPassphrase.
+//
+// Returns a new ArkBasicKeyring instance or nil if folder creation fails.
+//
+// Example:
+//
+//	keyring := NewArkBasicKeyringAtFolder("/var/lib/myapp/tokens", passphrase)
+func NewArkBasicKeyringAtFolder(basicFolderPath string, passphrase string) *ArkBasicKeyring {
 	if _, err := os.Stat(basicFolderPath); os.IsNotExist(err) {
 		err := os.MkdirAll(basicFolderPath, os.ModePerm)
 		if err != nil {
@@ -99,20 +215,35 @@ func NewArkBasicKeyring() *ArkBasicKeyring {
 		basicFolderPath: basicFolderPath,
 		keyringFilePath: filepath.Join(basicFolderPath, "keyring"),
 		macFilePath:     filepath.Join(basicFolderPath, "mac"),
+		keyFilePath:     filepath.Join(basicFolderPath, "keyring.key"),
+		lockFilePath:    filepath.Join(basicFolderPath, "keyring.lock"),
+		passphrase:      passphrase,
 	}
 }
 
-func (b *ArkBasicKeyring) encrypt(secret []byte, data string) (map[string]string, error) {
+// entryAdditionalData binds a sealed entry to its service name and username, so entries cannot be swapped.
+func entryAdditionalData(serviceName string, username string) []byte {
+	return []byte(serviceName + "\x00" + username)
+}
+
+func (b *ArkBasicKeyring) newGCM(secret []byte) (cipher.AEAD, error) {
 	block, err := aes.NewCipher(secret)
 	if err != nil {
 		return nil, err
 	}
-	aesGCM, err := cipher.NewGCMWithNonceSize(block, nonceSize)
+	return cipher.NewGCMWithNonceSize(block, nonceSize)
+}
+
+func (b *ArkBasicKeyring) encrypt(secret []byte, serviceName string, username string, data string) (map[string]string, error) {
+	aesGCM, err := b.newGCM(secret)
 	if err != nil {
 		return nil, err
 	}
 	nonce := make([]byte, aesGCM.NonceSize())
-	ciphertextWithTag := aesGCM.Seal(nil, nonce, []byte(data), nil)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ciphertextWithTag := aesGCM.Seal(nil, nonce, []byte(data), entryAdditionalData(serviceName, username))
 	tag := ciphertextWithTag[len(ciphertextWithTag)-tagSize:]
 	ciphertext := ciphertextWithTag[:len(ciphertextWithTag)-tagSize]
 	return map[string]string{
@@ -122,12 +253,8 @@ func (b *ArkBasicKeyring) encrypt(secret []byte, data string) (map[string]string
 	}, nil
 }
 
-func (b *ArkBasicKeyring) decrypt(secret []byte, data map[string]string) (string, error) {
-	block, err := aes.NewCipher(secret)
-	if err != nil {
-		return "", err
-	}
-	aesGCM, err := cipher.NewGCMWithNonceSize(block, nonceSize)
+func (b *ArkBasicKeyring) decrypt(secret []byte, serviceName string, username string, data map[string]string) (string, error) {
+	aesGCM, err := b.newGCM(secret)
 	if err != nil {
 		return "", err
 	}
@@ -143,14 +270,246 @@ func (b *ArkBasicKeyring) decrypt(secret []byte, data map[string]string) (string
 	if err != nil {
 		return "", err
 	}
+	if len(nonce) != aesGCM.NonceSize() {
+		return "", errors.New("invalid keyring entry nonce")
+	}
 	fullCiphertext := append(ciphertext, tag...)
-	plaintext, err := aesGCM.Open(nil, nonce, fullCiphertext, nil)
+	plaintext, err := aesGCM.Open(nil, nonce, fullCiphertext, entryAdditionalData(serviceName, username))
 	if err != nil {
 		return "", err
 	}
 	return string(plaintext), nil
 }
 
+// deriveKey derives the key of the keyring from its passphrase, caching the keys derived by the process.
+func (b *ArkBasicKeyring) deriveKey(kdf *basicKeyringKDF) ([]byte, error) {
+	if kdf.Algorithm != argon2idAlgorithm {
+		return nil, fmt.Errorf("unsupported basic keyring key derivation [%s]", kdf.Algorithm)
+	}
+	salt, err := base64.StdEncoding.DecodeString(kdf.Salt)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%d\x00%d", b.passphrase, kdf.Salt, kdf.Time, kdf.Memory, kdf.Threads)))
+	derivedKeysMutex.Lock()
+	defer derivedKeysMutex.Unlock()
+	if key, ok := derivedKeys[cacheKey]; ok {
+		return key, nil
+	}
+	key := argon2.IDKey([]byte(b.passphrase), salt, kdf.Time, kdf.Memory, kdf.Threads, keySize)
+	derivedKeys[cacheKey] = key
+	return key, nil
+}
+
+// localKey returns the local key of the keyring, generating it on first use.
+func (b *ArkBasicKeyring) localKey() ([]byte, error) {
+	data, err := os.ReadFile(b.keyFilePath)
+	if err == nil {
+		key, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil || len(key) != keySize {
+			return nil, errors.New("invalid basic keyring local key")
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(b.keyFilePath, []byte(base64.StdEncoding.EncodeToString(key)), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// newKeyringFile creates an empty keyring, keyed by the passphrase if any, or by the local key.
+func (b *ArkBasicKeyring) newKeyringFile() (*basicKeyringFile, []byte, error) {
+	keyringFile := &basicKeyringFile{
+		Version: basicKeyringVersion,
+		Entries: make(map[string]map[string]map[string]string),
+	}
+	var key []byte
+	var err error
+	if b.passphrase != "" {
+		salt := make([]byte, argon2idSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, nil, err
+		}
+		keyringFile.KDF = &basicKeyringKDF{
+			Algorithm: argon2idAlgorithm,
+			Salt:      base64.StdEncoding.EncodeToString(salt),
+			Time:      argon2idTime,
+			Memory:    argon2idMemory,
+			Threads:   argon2idThreads,
+		}
+		key, err = b.deriveKey(keyringFile.KDF)
+	} else {
+		key, err = b.localKey()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	keyringFile.Check, err = b.encrypt(key, "", "", basicKeyringCheckValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keyringFile, key, nil
+}
+
+// keyringKey returns the key of the keyring file, checking it against the sealed check value.
+func (b *ArkBasicKeyring) keyringKey(keyringFile *basicKeyringFile) ([]byte, error) {
+	var key []byte
+	var err error
+	if keyringFile.KDF != nil {
+		if b.passphrase == "" {
+			return nil, ErrBasicKeyringPassphraseRequired
+		}
+		key, err = b.deriveKey(keyringFile.KDF)
+	} else {
+		key, err = b.localKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+	check, err := b.decrypt(key, "", "", keyringFile.Check)
+	if err != nil || subtle.ConstantTimeCompare([]byte(check), []byte(basicKeyringCheckValue)) != 1 {
+		return nil, ErrBasicKeyringWrongKey
+	}
+	return key, nil
+}
+
+// load loads the keyring file and its key, while the lock is held.
+//
+// Keyrings of the v1 format, and keyrings using the local key when a passphrase is given,
+// are converted, in which case the returned keyring has to be saved. Converting to the
+// passphrase fails if an entry cannot be decrypted, rather than dropping the entry.
+func (b *ArkBasicKeyring) load() (*basicKeyringFile, []byte, bool, error) {
+	if _, err := os.Stat(b.keyringFilePath); os.IsNotExist(err) {
+		keyringFile, key, err := b.newKeyringFile()
+		return keyringFile, key, false, err
+	}
+	data, err := b.validateMacAndGetData()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	var keyringFile basicKeyringFile
+	if err := json.Unmarshal([]byte(data), &keyringFile); err != nil || keyringFile.Version == 0 {
+		v1Keyring, err := b.parseV1Keyring([]byte(data))
+		if err != nil {
+			return nil, nil, false, err
+		}
+		migrated, key, err := b.newKeyringFile()
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if _, err := b.migrateV1Keyring(v1Keyring, migrated, key); err != nil {
+			return nil, nil, false, err
+		}
+		return migrated, key, true, nil
+	}
+	if keyringFile.Version > basicKeyringVersion {
+		return nil, nil, false, fmt.Errorf("unsupported basic keyring version [%d]", keyringFile.Version)
+	}
+	key, err := b.keyringKey(&keyringFile)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if keyringFile.KDF == nil && b.passphrase != "" {
+		rekeyed, newKey, err := b.newKeyringFile()
+		if err != nil {
+			return nil, nil, false, err
+		}
+		for serviceName, usernames := range keyringFile.Entries {
+			for username, entry := range usernames {
+				password, err := b.decrypt(key, serviceName, username, entry)
+				if err != nil {
+					return nil, nil, false, fmt.Errorf("failed to decrypt basic keyring entry [%s/%s] to rekey it with the passphrase: %w", serviceName, username, err)
+				}
+				sealed, err := b.encrypt(newKey, serviceName, username, password)
+				if err != nil {
+					return nil, nil, false, err
+				}
+				rekeyed.setEntry(serviceName, username, sealed)
+			}
+		}
+		return rekeyed, newKey, true, nil
+	}
+	if keyringFile.Entries == nil {
+		keyringFile.Entries = make(map[string]map[string]map[string]string)
+	}
+	return &keyringFile, key, false, nil
+}
+
+// save writes the keyring file and its MAC atomically, while the lock is held.
+func (b *ArkBasicKeyring) save(keyringFile *basicKeyringFile) error {
+	data, err := json.Marshal(keyringFile)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(b.keyringFilePath, data, 0600); err != nil {
+		return err
+	}
+	return b.updateMac()
+}
+
+// withLock runs the operation while holding the lock file of the keyring.
+//
+// Lock files older than staleLockAge are left behind by crashed processes, and are removed.
+func (b *ArkBasicKeyring) withLock(operation func() error) error {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		lockFile, err := os.OpenFile(b.lockFilePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = lockFile.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+		if info, statErr := os.Stat(b.lockFilePath); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(b.lockFilePath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return errors.New("timed out waiting for the basic keyring lock")
+		}
+		time.Sleep(lockRetryInterval)
+	}
+	defer func() {
+		_ = os.Remove(b.lockFilePath)
+	}()
+	return operation()
+}
+
+// writeFileAtomic writes the file to a temporary file in the same folder, and renames it over the file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		_ = os.Remove(tempPath)
+	}()
+	if _, err := tempFile.Write(data); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tempPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
 func (b *ArkBasicKeyring) getCurrentMac() (string, error) {
 	if _, err := os.Stat(b.macFilePath); os.IsNotExist(err) {
 		return "", errors.New("invalid keyring")
@@ -184,20 +543,14 @@ func (b *ArkBasicKeyring) updateMac() error {
 		return err
 	}
 	dataMac := sha256.Sum256(data)
-	return os.WriteFile(b.macFilePath, []byte(hex.EncodeToString(dataMac[:])), 0644)
-}
-
-func (b *ArkBasicKeyring) pKCS7Pad(data []byte, blockSize int) []byte {
-	padding := blockSize - len(data)%blockSize
-	padText := bytes.Repeat([]byte{byte(padding)}, padding)
-	return append(data, padText...)
+	return writeFileAtomic(b.macFilePath, []byte(hex.EncodeToString(dataMac[:])), 0600)
 }
 
 // SetPassword sets a password for a given service and username in the keyring.
 //
 // SetPassword encrypts and stores a password for the specified service and username
-// combination. The password is encrypted using AES-GCM with a key derived from the
-// system hostname. If a keyring file already exists, it loads the existing data and
+// combination. The password is encrypted using AES-GCM with a random nonce and the key
+// of the keyring. If a keyring file already exists, it loads the existing data and
 // adds the new entry. The function updates the MAC file after successful storage
 // to maintain data integrity validation.
 //
@@ -215,35 +568,18 @@ func (b *ArkBasicKeyring) pKCS7Pad(data []byte, blockSize int) []byte {
 //	    // Handle password storage error
 //	}
 func (b *ArkBasicKeyring) SetPassword(serviceName string, username string, password string) error {
-	key := make([]byte, blockSize)
-	hostname, _ := os.Hostname()
-	copy(key, b.pKCS7Pad([]byte(hostname), blockSize))
-	existingKeyring := make(map[string]map[string]map[string]string)
-	if _, err := os.Stat(b.keyringFilePath); err == nil {
-		data, err := b.validateMacAndGetData()
+	return b.withLock(func() error {
+		keyringFile, key, _, err := b.load()
 		if err != nil {
 			return err
 		}
-		if err := json.Unmarshal([]byte(data), &existingKeyring); err != nil {
+		encryptedPassword, err := b.encrypt(key, serviceName, username, password)
+		if err != nil {
 			return err
 		}
-	}
-	if _, ok := existingKeyring[serviceName]; !ok {
-		existingKeyring[serviceName] = make(map[string]map[string]string)
-	}
-	encryptedPassword, err := b.encrypt(key, password)
-	if err != nil {
-		return err
-	}
-	existingKeyring[serviceName][username] = encryptedPassword
-	data, err := json.Marshal(existingKeyring)
-	if err != nil {
-		return err
-	}
-	if err := os.WriteFile(b.keyringFilePath, data, 0644); err != nil {
-		return err
-	}
-	return b.updateMac()
+		keyringFile.setEntry(serviceName, username, encryptedPassword)
+		return b.save(keyringFile)
+	})
 }
 
 // GetPassword retrieves a password for a given service and username from the keyring.
@@ -252,6 +588,7 @@ func (b *ArkBasicKeyring) SetPassword(serviceName string, username string, passw
 // and username combination. The function validates the keyring MAC before accessing
 // the data to ensure integrity. If the keyring file doesn't exist, the service
 // doesn't exist, or the username doesn't exist, an empty string is returned without error.
+// Keyrings of the previous format are migrated on the first read.
 //
 // Parameters:
 //   - serviceName: The name of the service to retrieve password for
@@ -270,27 +607,28 @@ func (b *ArkBasicKeyring) SetPassword(serviceName string, username string, passw
 //	    // Password not found
 //	}
 func (b *ArkBasicKeyring) GetPassword(serviceName string, username string) (string, error) {
-	key := make([]byte, blockSize)
-	hostname, _ := os.Hostname()
-	copy(key, b.pKCS7Pad([]byte(hostname), blockSize))
 	if _, err := os.Stat(b.keyringFilePath); os.IsNotExist(err) {
 		return "", nil
 	}
-	data, err := b.validateMacAndGetData()
-	if err != nil {
-		return "", err
-	}
-	existingKeyring := make(map[string]map[string]map[string]string)
-	if err := json.Unmarshal([]byte(data), &existingKeyring); err != nil {
-		return "", err
-	}
-	if _, ok := existingKeyring[serviceName]; !ok {
-		return "", nil
-	}
-	if _, ok := existingKeyring[serviceName][username]; !ok {
-		return "", nil
-	}
-	return b.decrypt(key, existingKeyring[serviceName][username])
+	password := ""
+	err := b.withLock(func() error {
+		keyringFile, key, migrated, err := b.load()
+		if err != nil {
+			return err
+		}
+		if migrated {
+			if err := b.save(keyringFile); err != nil {
+				return err
+			}
+		}
+		entry, ok := keyringFile.Entries[serviceName][username]
+		if !ok {
+			return nil
+		}
+		password, err = b.decrypt(key, serviceName, username, entry)
+		return err
+	})
+	return password, err
 }
 
 // DeletePassword deletes a password for a given service and username from the keyring.
@@ -316,38 +654,86 @@ func (b *ArkBasicKeyring) DeletePassword(serviceName string, username string) er
 	if _, err := os.Stat(b.keyringFilePath); os.IsNotExist(err) {
 		return nil
 	}
-	data, err := b.validateMacAndGetData()
-	if err != nil {
-		return err
-	}
-	existingKeyring := make(map[string]map[string]map[string]string)
-	if err := json.Unmarshal([]byte(data), &existingKeyring); err != nil {
-		return err
-	}
-	if _, ok := existingKeyring[serviceName]; !ok {
-		return nil
+	return b.withLock(func() error {
+		keyringFile, _, migrated, err := b.load()
+		if err != nil {
+			return err
+		}
+		if _, ok := keyringFile.Entries[serviceName][username]; !ok {
+			if migrated {
+				return b.save(keyringFile)
+			}
+			return nil
+		}
+		delete(keyringFile.Entries[serviceName], username)
+		return b.save(keyringFile)
+	})
+}
+
+// ListEntries lists the entries of the given service names in the keyring, or all of its entries when none are given.
+//
+// ListEntries implements ArkKeyringEnumerator. Only the service names and usernames of the entries are returned,
+// their passwords are not decrypted. If the keyring file doesn't exist, no entries are returned.
+//
+// Parameters:
+//   - serviceNames: The service names to list the entries of, all of them if none are given
+//
+// Returns the entries sorted by service name and username, or an error if the keyring cannot be loaded.
+//
+// Example:
+//
+//	entries, err := keyring.ListEntries("my_service")
+//	if err != nil {
+//	    // Handle error
+//	}
+func (b *ArkBasicKeyring) ListEntries(serviceNames ...string) ([]ArkKeyringEntry, error) {
+	if _, err := os.Stat(b.keyringFilePath); os.IsNotExist(err) {
+		return nil, nil
 	}
-	if _, ok := existingKeyring[serviceName][username]; !ok {
+	var entries []ArkKeyringEntry
+	err := b.withLock(func() error {
+		keyringFile, _, migrated, err := b.load()
+		if err != nil {
+			return err
+		}
+		if migrated {
+			if err := b.save(keyringFile); err != nil {
+				return err
+			}
+		}
+		for serviceName, usernames := range keyringFile.Entries {
+			if len(serviceNames) > 0 && !slices.Contains(serviceNames, serviceName) {
+				continue
+			}
+			for username := range usernames {
+				entries = append(entries, ArkKeyringEntry{
+					ServiceName: serviceName,
+					Username:    username,
+					Backend:     ArkKeyringBackendBasic,
+				})
+			}
+		}
 		return nil
-	}
-	delete(existingKeyring[serviceName], username)
-	dataBytes, err := json.Marshal(existingKeyring)
+	})
 	if err != nil {
-		return err
-	}
-	if err := os.WriteFile(b.keyringFilePath, dataBytes, 0644); err != nil {
-		return err
+		return nil, err
 	}
-	return b.updateMac()
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ServiceName != entries[j].ServiceName {
+			return entries[i].ServiceName < entries[j].ServiceName
+		}
+		return entries[i].Username < entries[j].Username
+	})
+	return entries, nil
 }
 
-// ClearAllPasswords removes all stored passwords and MAC validation files from the keyring.
+// ClearAllPasswords removes all stored passwords, the MAC validation file and the local key from the keyring.
 //
-// ClearAllPasswords deletes the keyring file and its associated MAC file, effectively
-// clearing all stored passwords for all services and users. If either file does not exist,
-// the function returns nil without error (idempotent behavior).
+// ClearAllPasswords deletes the keyring file, its associated MAC file and the local key file
+// while holding the lock of the keyring, effectively clearing all stored passwords for all
+// services and users. Files that do not exist are skipped (idempotent behavior).
 //
-// Returns an error if file removal fails for either the keyring or MAC file.
+// Returns an error if acquiring the lock or removing any of the files fails.
 //
 // Example:
 //
@@ -356,16 +742,17 @@ func (b *ArkBasicKeyring) DeletePassword(serviceName string, username string) er
 //	    // Handle error
 //	}
 func (b *ArkBasicKeyring) ClearAllPasswords() error {
-	if _, err := os.Stat(b.keyringFilePath); os.IsNotExist(err) {
+	_, keyringErr := os.Stat(b.keyringFilePath)
+	_, keyErr := os.Stat(b.keyFilePath)
+	if os.IsNotExist(keyringErr) && os.IsNotExist(keyErr) {
 		return nil
 	}
-	err := os.Remove(b.keyringFilePath)
-	if err != nil {
-		return err
-	}
-	err = os.Remove(b.macFilePath)
-	if err != nil {
-		return err
-	}
-	return nil
+	return b.withLock(func() error {
+		for _, path := range []string{b.keyringFilePath, b.macFilePath, b.keyFilePath} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		return nil
+	})
 }
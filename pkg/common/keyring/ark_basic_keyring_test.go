@@ -1,9 +1,17 @@
 package keyring
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -907,3 +915,327 @@ func TestArkBasicKeyring_ClearAllPasswords(t *testing.T) {
 		})
 	}
 }
+
+func TestArkBasicKeyring_ClearAllPasswords_RemovesLocalKey(t *testing.T) {
+	folder := t.TempDir()
+	keyring := newTestBasicKeyring(t, folder, "")
+	if err := keyring.SetPassword("service", "user", "password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := keyring.ClearAllPasswords(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, path := range []string{keyring.keyringFilePath, keyring.macFilePath, keyring.keyFilePath, keyring.lockFilePath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", filepath.Base(path), err)
+		}
+	}
+	if err := keyring.ClearAllPasswords(); err != nil {
+		t.Errorf("Expected clearing an empty keyring to succeed, got %v", err)
+	}
+}
+
+// newTestBasicKeyring creates a basic keyring in a temporary folder, with the given passphrase.
+func newTestBasicKeyring(t *testing.T, folder string, passphrase string) *ArkBasicKeyring {
+	t.Helper()
+	t.Setenv(ArkBasicKeyringFolderEnvVar, folder)
+	keyring := NewArkBasicKeyringWithPassphrase(passphrase)
+	if keyring == nil {
+		t.Fatal("Failed to create keyring for test")
+	}
+	return keyring
+}
+
+// writeV1Keyring writes a keyring of the v1 format, sealed with the hostname key and an all-zero nonce.
+func writeV1Keyring(t *testing.T, keyring *ArkBasicKeyring, entries map[string]map[string]string) {
+	t.Helper()
+	block, err := aes.NewCipher(keyring.v1Key())
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+	aesGCM, err := cipher.NewGCMWithNonceSize(block, nonceSize)
+	if err != nil {
+		t.Fatalf("Failed to create GCM: %v", err)
+	}
+	v1Keyring := make(map[string]map[string]map[string]string)
+	for serviceName, usernames := range entries {
+		v1Keyring[serviceName] = make(map[string]map[string]string)
+		for username, password := range usernames {
+			nonce := make([]byte, nonceSize)
+			sealed := aesGCM.Seal(nil, nonce, []byte(password), nil)
+			v1Keyring[serviceName][username] = map[string]string{
+				"nonce":      base64.StdEncoding.EncodeToString(nonce),
+				"ciphertext": base64.StdEncoding.EncodeToString(sealed[:len(sealed)-tagSize]),
+				"tag":        base64.StdEncoding.EncodeToString(sealed[len(sealed)-tagSize:]),
+			}
+		}
+	}
+	data, err := json.Marshal(v1Keyring)
+	if err != nil {
+		t.Fatalf("Failed to marshal v1 keyring: %v", err)
+	}
+	if err := os.WriteFile(keyring.keyringFilePath, data, 0644); err != nil {
+		t.Fatalf("Failed to write v1 keyring: %v", err)
+	}
+	if err := keyring.updateMac(); err != nil {
+		t.Fatalf("Failed to write v1 MAC: %v", err)
+	}
+}
+
+func readKeyringFile(t *testing.T, keyring *ArkBasicKeyring) *basicKeyringFile {
+	t.Helper()
+	data, err := os.ReadFile(keyring.keyringFilePath)
+	if err != nil {
+		t.Fatalf("Failed to read keyring: %v", err)
+	}
+	var keyringFile basicKeyringFile
+	if err := json.Unmarshal(data, &keyringFile); err != nil {
+		t.Fatalf("Failed to parse keyring: %v", err)
+	}
+	return &keyringFile
+}
+
+func TestArkBasicKeyring_RandomNonces(t *testing.T) {
+	keyring := newTestBasicKeyring(t, t.TempDir(), "")
+	if err := keyring.SetPassword("service", "user1", "same-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := keyring.SetPassword("service", "user2", "same-password"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	keyringFile := readKeyringFile(t, keyring)
+	if keyringFile.Version != basicKeyringVersion {
+		t.Errorf("Expected version %d, got %d", basicKeyringVersion, keyringFile.Version)
+	}
+	entry1 := keyringFile.Entries["service"]["user1"]
+	entry2 := keyringFile.Entries["service"]["user2"]
+	if entry1["nonce"] == entry2["nonce"] {
+		t.Error("Expected entries to have different nonces")
+	}
+	if entry1["ciphertext"] == entry2["ciphertext"] {
+		t.Error("Expected the same password to be sealed differently")
+	}
+	info, err := os.Stat(keyring.keyringFilePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		t.Errorf("Expected keyring to be readable by its owner only, got %v", info.Mode().Perm())
+	}
+}
+
+func TestArkBasicKeyring_EntriesBoundToServiceAndUsername(t *testing.T) {
+	keyring := newTestBasicKeyring(t, t.TempDir(), "")
+	if err := keyring.SetPassword("service", "user1", "password1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := keyring.SetPassword("service", "user2", "password2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	keyringFile := readKeyringFile(t, keyring)
+	keyringFile.Entries["service"]["user1"], keyringFile.Entries["service"]["user2"] = keyringFile.Entries["service"]["user2"], keyringFile.Entries["service"]["user1"]
+	if err := keyring.save(keyringFile); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := keyring.GetPassword("service", "user1"); err == nil {
+		t.Error("Expected swapped entries to fail to decrypt")
+	}
+}
+
+func TestArkBasicKeyring_Passphrase(t *testing.T) {
+	tests := []struct {
+		name          string
+		setPassphrase string
+		getPassphrase string
+		expectedError error
+	}{
+		{
+			name:          "success_same_passphrase",
+			setPassphrase: "correct horse",
+			getPassphrase: "correct horse",
+		},
+		{
+			name:          "error_wrong_passphrase",
+			setPassphrase: "correct horse",
+			getPassphrase: "battery staple",
+			expectedError: ErrBasicKeyringWrongKey,
+		},
+		{
+			name:          "error_missing_passphrase",
+			setPassphrase: "correct horse",
+			expectedError: ErrBasicKeyringPassphraseRequired,
+		},
+		{
+			name:          "success_local_key_switched_to_passphrase",
+			getPassphrase: "correct horse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folder := t.TempDir()
+			if err := newTestBasicKeyring(t, folder, tt.setPassphrase).SetPassword("service", "user", "password"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			keyring := newTestBasicKeyring(t, folder, tt.getPassphrase)
+			password, err := keyring.GetPassword("service", "user")
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("Expected error %v, got %v", tt.expectedError, err)
+				}
+				if err := keyring.SetPassword("service", "user", "other"); !errors.Is(err, tt.expectedError) {
+					t.Errorf("Expected the keyring not to be overwritten, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if password != "password" {
+				t.Errorf("Expected 'password', got '%s'", password)
+			}
+			if tt.getPassphrase != "" && readKeyringFile(t, keyring).KDF == nil {
+				t.Error("Expected the keyring to be keyed by the passphrase")
+			}
+		})
+	}
+}
+
+func TestArkBasicKeyring_PassphraseRekeyKeepsUndecryptableEntries(t *testing.T) {
+	folder := t.TempDir()
+	localKeyring := newTestBasicKeyring(t, folder, "")
+	for _, username := range []string{"user1", "user2"} {
+		if err := localKeyring.SetPassword("service", username, "password"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	keyringFile := readKeyringFile(t, localKeyring)
+	keyringFile.Entries["service"]["user2"] = keyringFile.Entries["service"]["user1"]
+	data, err := json.Marshal(keyringFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal keyring: %v", err)
+	}
+	if err := os.WriteFile(localKeyring.keyringFilePath, data, 0600); err != nil {
+		t.Fatalf("Failed to write keyring: %v", err)
+	}
+	if err := localKeyring.updateMac(); err != nil {
+		t.Fatalf("Failed to write MAC: %v", err)
+	}
+
+	keyring := newTestBasicKeyring(t, folder, "correct horse")
+	if _, err := keyring.GetPassword("service", "user1"); err == nil || !strings.Contains(err.Error(), "[service/user2]") {
+		t.Fatalf("Expected an error naming the undecryptable entry, got %v", err)
+	}
+	if readKeyringFile(t, keyring).KDF != nil {
+		t.Error("Expected the keyring not to be rekeyed")
+	}
+	if password, err := localKeyring.GetPassword("service", "user1"); err != nil || password != "password" {
+		t.Errorf("Expected the entries to be kept, got %q, %v", password, err)
+	}
+}
+
+func TestArkBasicKeyring_MigratesV1(t *testing.T) {
+	keyring := newTestBasicKeyring(t, t.TempDir(), "")
+	writeV1Keyring(t, keyring, map[string]map[string]string{
+		"profile": {"ArkISPAuth-user": `{"token":"abc"}`},
+		"other":   {"ArkISPAuth-user2": "secret"},
+	})
+
+	password, err := keyring.GetPassword("profile", "ArkISPAuth-user")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if password != `{"token":"abc"}` {
+		t.Errorf("Expected migrated password, got '%s'", password)
+	}
+	keyringFile := readKeyringFile(t, keyring)
+	if keyringFile.Version != basicKeyringVersion {
+		t.Fatalf("Expected the keyring to be migrated to version %d, got %d", basicKeyringVersion, keyringFile.Version)
+	}
+	if nonce := keyringFile.Entries["other"]["ArkISPAuth-user2"]["nonce"]; nonce == base64.StdEncoding.EncodeToString(make([]byte, nonceSize)) {
+		t.Error("Expected migrated entries to be sealed with a random nonce")
+	}
+	password, err = keyring.GetPassword("other", "ArkISPAuth-user2")
+	if err != nil || password != "secret" {
+		t.Errorf("Expected 'secret', got '%s', %v", password, err)
+	}
+}
+
+func TestArkBasicKeyring_ConcurrentSetPassword(t *testing.T) {
+	folder := t.TempDir()
+	keyring := newTestBasicKeyring(t, folder, "")
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Every writer has its own instance, as concurrent CLI invocations do
+			errs <- NewArkBasicKeyringWithPassphrase("").SetPassword("service", fmt.Sprintf("user%d", i), fmt.Sprintf("password%d", i))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		password, err := keyring.GetPassword("service", fmt.Sprintf("user%d", i))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if password != fmt.Sprintf("password%d", i) {
+			t.Errorf("Expected entry of user%d to be kept, got '%s'", i, password)
+		}
+	}
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") || entry.Name() == "keyring.lock" {
+			t.Errorf("Expected no leftover file, got %s", entry.Name())
+		}
+	}
+}
+
+func TestArkBasicKeyring_ListEntries(t *testing.T) {
+	keyring := newTestBasicKeyring(t, t.TempDir(), "")
+	entries, err := keyring.ListEntries()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Expected no entries without a keyring, got %v, %v", entries, err)
+	}
+	for _, entry := range []ArkKeyringEntry{
+		{ServiceName: "profile2", Username: "isp-user2"},
+		{ServiceName: "profile1", Username: "sia-sso-user1"},
+		{ServiceName: "profile1", Username: "isp-user1"},
+	} {
+		if err := keyring.SetPassword(entry.ServiceName, entry.Username, "secret"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	entries, err = keyring.ListEntries()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []ArkKeyringEntry{
+		{ServiceName: "profile1", Username: "isp-user1", Backend: ArkKeyringBackendBasic},
+		{ServiceName: "profile1", Username: "sia-sso-user1", Backend: ArkKeyringBackendBasic},
+		{ServiceName: "profile2", Username: "isp-user2", Backend: ArkKeyringBackendBasic},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %v, got %v", expected, entries)
+	}
+
+	entries, err = keyring.ListEntries("profile2", "missing")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(entries, expected[2:]) {
+		t.Errorf("Expected %v, got %v", expected[2:], entries)
+	}
+}
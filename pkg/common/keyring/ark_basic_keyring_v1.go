@@ -0,0 +1,90 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+)
+
+// The v1 basic keyring format is a JSON map of service name to username to an entry
+// sealed with AES-GCM, with a key padded from the hostname and an all-zero nonce.
+// It is only read, to migrate its entries to the current format.
+
+// v1Key returns the key of v1 keyrings, which is the hostname padded with PKCS7.
+func (b *ArkBasicKeyring) v1Key() []byte {
+	key := make([]byte, blockSize)
+	hostname, _ := os.Hostname()
+	copy(key, b.pKCS7Pad([]byte(hostname), blockSize))
+	return key
+}
+
+// parseV1Keyring parses the entries of a v1 keyring.
+func (b *ArkBasicKeyring) parseV1Keyring(data []byte) (map[string]map[string]map[string]string, error) {
+	v1Keyring := make(map[string]map[string]map[string]string)
+	if err := json.Unmarshal(data, &v1Keyring); err != nil {
+		return nil, err
+	}
+	return v1Keyring, nil
+}
+
+// migrateV1Keyring decrypts the entries of a v1 keyring and seals them again with the key of the given keyring.
+//
+// Entries that cannot be decrypted, such as after the hostname changed, are dropped, as
+// they only hold cached tokens which are recreated on the next login.
+func (b *ArkBasicKeyring) migrateV1Keyring(v1Keyring map[string]map[string]map[string]string, keyringFile *basicKeyringFile, key []byte) (int, error) {
+	v1Key := b.v1Key()
+	dropped := 0
+	for serviceName, usernames := range v1Keyring {
+		for username, entry := range usernames {
+			password, err := b.decryptV1(v1Key, entry)
+			if err != nil {
+				dropped++
+				continue
+			}
+			sealed, err := b.encrypt(key, serviceName, username, password)
+			if err != nil {
+				return dropped, err
+			}
+			keyringFile.setEntry(serviceName, username, sealed)
+		}
+	}
+	return dropped, nil
+}
+
+func (b *ArkBasicKeyring) decryptV1(secret []byte, data map[string]string) (string, error) {
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return "", err
+	}
+	aesGCM, err := cipher.NewGCMWithNonceSize(block, nonceSize)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(data["nonce"])
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(data["ciphertext"])
+	if err != nil {
+		return "", err
+	}
+	tag, err := base64.StdEncoding.DecodeString(data["tag"])
+	if err != nil {
+		return "", err
+	}
+	fullCiphertext := append(ciphertext, tag...)
+	plaintext, err := aesGCM.Open(nil, nonce, fullCiphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func (b *ArkBasicKeyring) pKCS7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - len(data)%blockSize
+	padText := bytes.Repeat([]byte{byte(padding)}, padding)
+	return append(data, padText...)
+}
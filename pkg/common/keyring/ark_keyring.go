@@ -34,6 +34,29 @@ type ArkKeyringImpl interface {
 	ClearAllPasswords() error
 }
 
+// Backends of keyring entries
+const (
+	ArkKeyringBackendOS     = "os"
+	ArkKeyringBackendBasic  = "basic"
+	ArkKeyringBackendMemory = "memory"
+)
+
+// ArkKeyringEntry identifies an entry stored in a keyring, without its password.
+type ArkKeyringEntry struct {
+	ServiceName string
+	Username    string
+	Backend     string
+}
+
+// ArkKeyringEnumerator is implemented by keyring implementations which can list their entries.
+//
+// ListEntries lists the entries of the given service names, or of all the services when none
+// are given. OS keyrings are namespaced by service, and only list the entries of the given
+// service names.
+type ArkKeyringEnumerator interface {
+	ListEntries(serviceNames ...string) ([]ArkKeyringEntry, error)
+}
+
 // ArkKeyring represents a keyring for storing and retrieving authentication tokens.
 //
 // ArkKeyring provides a secure storage mechanism for authentication tokens with
@@ -45,6 +68,7 @@ type ArkKeyringImpl interface {
 // cleanup of expired tokens based on configurable time limits.
 type ArkKeyring struct {
 	serviceName string
+	impl        ArkKeyringImpl
 	logger      *common.ArkLogger
 }
 
@@ -70,6 +94,27 @@ func NewArkKeyring(serviceName string) *ArkKeyring {
 	}
 }
 
+// NewArkKeyringWithImpl creates a new instance of ArkKeyring which stores tokens in the given keyring implementation.
+//
+// Unlike NewArkKeyring, the keyring does not choose between the OS keyring and the basic keyring,
+// and does not fall back to the basic keyring, so tokens are only ever stored in the given implementation,
+// such as an ArkMemoryKeyring.
+//
+// Parameters:
+//   - serviceName: The name used to identify this service's tokens in the keyring
+//   - impl: The keyring implementation to store the tokens in
+//
+// Example:
+//
+//	keyring := NewArkKeyringWithImpl("myapp", NewArkMemoryKeyring())
+func NewArkKeyringWithImpl(serviceName string, impl ArkKeyringImpl) *ArkKeyring {
+	return &ArkKeyring{
+		serviceName: serviceName,
+		impl:        impl,
+		logger:      common.GetLogger("ArkKeyring", common.Unknown),
+	}
+}
+
 func (a *ArkKeyring) isDocker() bool {
 	if _, err := os.Stat("/.dockerenv"); err == nil {
 		return true
@@ -98,6 +143,8 @@ func (a *ArkKeyring) isWSL() bool {
 // Parameters:
 //   - enforceBasicKeyring: When true, forces the use of basic keyring regardless of environment
 //
+// Keyrings created with NewArkKeyringWithImpl always return their keyring implementation.
+//
 // Returns a ArkBasicKeyring instance configured for the current environment, or an
 // error if keyring initialization fails.
 //
@@ -108,6 +155,9 @@ func (a *ArkKeyring) isWSL() bool {
 //	    // handle error
 //	}
 func (a *ArkKeyring) GetKeyring(enforceBasicKeyring bool) (ArkKeyringImpl, error) {
+	if a.impl != nil {
+		return a.impl, nil
+	}
 	if a.isDocker() || a.isWSL() || os.Getenv(ArkBasicKeyringOverrideEnvVar) != "" || enforceBasicKeyring {
 		return NewArkBasicKeyring(), nil
 	}
@@ -216,24 +266,56 @@ func (a *ArkKeyring) LoadToken(profile *models.ArkProfile, postfix string, enfor
 		a.logger.Info("Token failed to be parsed [%v]", err)
 		return nil, err
 	}
-	if !time.Time(token.ExpiresIn).IsZero() {
-		if token.RefreshToken == "" && token.TokenType != auth.Internal && time.Time(token.ExpiresIn).Before(time.Now().Add(-DefaultExpirationGraceDeltaSeconds*time.Second)) {
-			a.logger.Info("Token is expired and no refresh token exists")
-			err := kr.DeletePassword(profile.ProfileName, a.serviceName+"-"+postfix)
-			if err != nil {
-				return nil, err
-			}
-			return nil, nil
-		}
-		if token.RefreshToken != "" && time.Time(token.ExpiresIn).Add(MaxKeyringRecordTimeHours*time.Hour).Before(time.Now()) {
-			a.logger.Info("Token is expired and has been in the cache for too long before another usage")
-			err := kr.DeletePassword(profile.ProfileName, a.serviceName+"-"+postfix)
-			if err != nil {
-				return nil, err
-			}
-			return nil, nil
+	if reason := StaleTokenReason(&token); reason != "" {
+		a.logger.Info("%s", reason)
+		err := kr.DeletePassword(profile.ProfileName, a.serviceName+"-"+postfix)
+		if err != nil {
+			return nil, err
 		}
+		return nil, nil
 	}
 	a.logger.Info("Loaded token successfully")
 	return &token, nil
 }
+
+// StaleTokenReason returns why the cached token can no longer be used and should be removed
+// from the cache, or an empty string if it can still be used or refreshed.
+//
+// Tokens without a refresh token are stale once expired beyond DefaultExpirationGraceDeltaSeconds,
+// and tokens with a refresh token once expired for more than MaxKeyringRecordTimeHours.
+func StaleTokenReason(token *auth.ArkToken) string {
+	if time.Time(token.ExpiresIn).IsZero() {
+		return ""
+	}
+	if token.RefreshToken == "" && token.TokenType != auth.Internal && time.Time(token.ExpiresIn).Before(time.Now().Add(-DefaultExpirationGraceDeltaSeconds*time.Second)) {
+		return "Token is expired and no refresh token exists"
+	}
+	if token.RefreshToken != "" && time.Time(token.ExpiresIn).Add(MaxKeyringRecordTimeHours*time.Hour).Before(time.Now()) {
+		return "Token is expired and has been in the cache for too long before another usage"
+	}
+	return ""
+}
+
+// RemoveToken removes the authentication token of the specified profile and postfix from the keyring.
+//
+// Parameters:
+//   - profile: The ARK profile containing the profile name used as the keyring username
+//   - postfix: A suffix added to the service name to match the key used during SaveToken
+//   - enforceBasicKeyring: When true, uses basic keyring without attempting system keyring first
+//
+// Returns an error if the token cannot be removed. Removing a token which does not exist is not an error.
+//
+// Example:
+//
+//	err := keyring.RemoveToken(profile, "access", false)
+//	if err != nil {
+//	    // handle remove error
+//	}
+func (a *ArkKeyring) RemoveToken(profile *models.ArkProfile, postfix string, enforceBasicKeyring bool) error {
+	a.logger.Info("Trying to remove token [%s-%s] of profile [%s]", a.serviceName, postfix, profile.ProfileName)
+	kr, err := a.GetKeyring(enforceBasicKeyring)
+	if err != nil {
+		return err
+	}
+	return kr.DeletePassword(profile.ProfileName, a.serviceName+"-"+postfix)
+}
@@ -0,0 +1,211 @@
+package keyring
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+// ErrKeyringNotEnumerable is returned when the entries of the keyring cannot be listed.
+var ErrKeyringNotEnumerable = errors.New("keyring does not support listing its entries")
+
+// ArkCachedToken describes a token cached in the keyring, without its secrets.
+//
+// The token itself, its refresh token and the values of its metadata, such as cookies, are never
+// exposed, only whether they exist. Stale tokens are tokens which LoadToken would remove, as they
+// are expired and cannot be refreshed anymore.
+type ArkCachedToken struct {
+	Profile         string             `json:"profile" mapstructure:"profile"`
+	ServiceName     string             `json:"service_name" mapstructure:"service_name"`
+	Postfix         string             `json:"postfix" mapstructure:"postfix"`
+	Backend         string             `json:"backend" mapstructure:"backend"`
+	TokenType       auth.ArkTokenType  `json:"token_type,omitempty" mapstructure:"token_type,omitempty"`
+	AuthMethod      auth.ArkAuthMethod `json:"auth_method,omitempty" mapstructure:"auth_method,omitempty"`
+	Username        string             `json:"username,omitempty" mapstructure:"username,omitempty"`
+	Endpoint        string             `json:"endpoint,omitempty" mapstructure:"endpoint,omitempty"`
+	ExpiresAt       *time.Time         `json:"expires_at,omitempty" mapstructure:"expires_at,omitempty"`
+	HasRefreshToken bool               `json:"has_refresh_token" mapstructure:"has_refresh_token"`
+	MetadataKeys    []string           `json:"metadata_keys,omitempty" mapstructure:"metadata_keys,omitempty"`
+	Expired         bool               `json:"expired" mapstructure:"expired"`
+	Stale           bool               `json:"stale" mapstructure:"stale"`
+	Error           string             `json:"error,omitempty" mapstructure:"error,omitempty"`
+}
+
+// backendKeyring returns the keyring implementation holding the entries of the given backend.
+func (a *ArkKeyring) backendKeyring(kr ArkKeyringImpl, backend string) ArkKeyringImpl {
+	if osKeyring, ok := kr.(*ArkOSProvidedKeyring); ok {
+		return osKeyring.backendKeyring(backend)
+	}
+	return kr
+}
+
+// parseTokenKey splits the keyring username of a token into its service name and postfix.
+//
+// Keys are of the form "serviceName-postfix". For a keyring without a service name, the service
+// name is assumed to end at the first dash.
+func (a *ArkKeyring) parseTokenKey(key string) (string, string, bool) {
+	if a.serviceName != "" {
+		postfix, ok := strings.CutPrefix(key, a.serviceName+"-")
+		return a.serviceName, postfix, ok
+	}
+	return strings.Cut(key, "-")
+}
+
+// describeToken describes a token read from the keyring, without its secrets.
+func (a *ArkKeyring) describeToken(cached *ArkCachedToken, tokenData string) {
+	var token auth.ArkToken
+	if err := json.Unmarshal([]byte(tokenData), &token); err != nil {
+		cached.Error = "token failed to be parsed"
+		cached.Stale = true
+		return
+	}
+	cached.TokenType = token.TokenType
+	cached.AuthMethod = token.AuthMethod
+	cached.Username = token.Username
+	cached.Endpoint = token.Endpoint
+	cached.HasRefreshToken = token.RefreshToken != ""
+	if expiresAt := time.Time(token.ExpiresIn); !expiresAt.IsZero() {
+		cached.ExpiresAt = &expiresAt
+		cached.Expired = expiresAt.Before(time.Now())
+	}
+	for key := range token.Metadata {
+		cached.MetadataKeys = append(cached.MetadataKeys, key)
+	}
+	sort.Strings(cached.MetadataKeys)
+	cached.Stale = StaleTokenReason(&token) != ""
+}
+
+// ListTokens lists the tokens cached in the keyring for the given profiles, without their secrets.
+//
+// ListTokens lists the tokens of the service name of the keyring, or of all service names if the
+// keyring has none. Tokens are listed from both the OS keyring and the basic keyring, and each
+// reports the backend it was found in. As OS keyrings are namespaced by profile, their tokens are
+// only listed for the given profiles, while the basic keyring lists the tokens of all profiles
+// when none are given. Tokens which cannot be read or parsed are listed with an error.
+//
+// Parameters:
+//   - profileNames: The names of the profiles to list the tokens of
+//
+// Returns the cached tokens sorted by profile, service name, postfix and backend, or
+// ErrKeyringNotEnumerable if the keyring cannot list its entries.
+//
+// Example:
+//
+//	tokens, err := keyring.NewArkKeyring("").ListTokens("default")
+//	if err != nil {
+//	    // handle error
+//	}
+//	for _, token := range tokens {
+//	    fmt.Println(token.Profile, token.ServiceName, token.Postfix, token.ExpiresAt)
+//	}
+func (a *ArkKeyring) ListTokens(profileNames ...string) ([]*ArkCachedToken, error) {
+	kr, err := a.GetKeyring(false)
+	if err != nil {
+		return nil, err
+	}
+	enumerator, ok := kr.(ArkKeyringEnumerator)
+	if !ok {
+		return nil, ErrKeyringNotEnumerable
+	}
+	entries, err := enumerator.ListEntries(profileNames...)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*ArkCachedToken, 0, len(entries))
+	for _, entry := range entries {
+		serviceName, postfix, ok := a.parseTokenKey(entry.Username)
+		if !ok {
+			continue
+		}
+		cached := &ArkCachedToken{
+			Profile:     entry.ServiceName,
+			ServiceName: serviceName,
+			Postfix:     postfix,
+			Backend:     entry.Backend,
+		}
+		tokenData, err := a.backendKeyring(kr, entry.Backend).GetPassword(entry.ServiceName, entry.Username)
+		if err != nil {
+			cached.Error = err.Error()
+		} else if tokenData != "" {
+			a.describeToken(cached, tokenData)
+		} else {
+			continue
+		}
+		tokens = append(tokens, cached)
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if tokens[i].Profile != tokens[j].Profile {
+			return tokens[i].Profile < tokens[j].Profile
+		}
+		if tokens[i].ServiceName != tokens[j].ServiceName {
+			return tokens[i].ServiceName < tokens[j].ServiceName
+		}
+		if tokens[i].Postfix != tokens[j].Postfix {
+			return tokens[i].Postfix < tokens[j].Postfix
+		}
+		return tokens[i].Backend < tokens[j].Backend
+	})
+	return tokens, nil
+}
+
+// DeleteToken deletes a cached token listed by ListTokens from the backend it was found in.
+//
+// Parameters:
+//   - cached: The cached token to delete
+//
+// Returns an error if the token cannot be deleted.
+//
+// Example:
+//
+//	err := keyring.DeleteToken(cached)
+//	if err != nil {
+//	    // handle error
+//	}
+func (a *ArkKeyring) DeleteToken(cached *ArkCachedToken) error {
+	a.logger.Info("Deleting token [%s-%s] of profile [%s] from the %s keyring", cached.ServiceName, cached.Postfix, cached.Profile, cached.Backend)
+	kr, err := a.GetKeyring(cached.Backend == ArkKeyringBackendBasic)
+	if err != nil {
+		return err
+	}
+	return a.backendKeyring(kr, cached.Backend).DeletePassword(cached.Profile, cached.ServiceName+"-"+cached.Postfix)
+}
+
+// PruneTokens deletes the stale tokens cached for the given profiles, and returns them.
+//
+// Stale tokens are tokens which are expired and cannot be refreshed anymore, or cannot be parsed,
+// which LoadToken would discard. When includeExpired is true, expired tokens which could still
+// be refreshed are deleted as well. Profiles are listed as in ListTokens.
+//
+// Parameters:
+//   - includeExpired: Whether to also delete expired tokens which have a refresh token
+//   - profileNames: The names of the profiles to prune the tokens of
+//
+// Returns the deleted tokens, or an error if the tokens cannot be listed or deleted.
+//
+// Example:
+//
+//	pruned, err := keyring.NewArkKeyring("").PruneTokens(false)
+//	if err != nil {
+//	    // handle error
+//	}
+func (a *ArkKeyring) PruneTokens(includeExpired bool, profileNames ...string) ([]*ArkCachedToken, error) {
+	tokens, err := a.ListTokens(profileNames...)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []*ArkCachedToken
+	for _, cached := range tokens {
+		if !cached.Stale && !(includeExpired && cached.Expired) {
+			continue
+		}
+		if err := a.DeleteToken(cached); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, cached)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,225 @@
+package keyring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+// setupCachedTokens caches tokens of two profiles and two service names in a basic keyring in a temporary folder.
+func setupCachedTokens(t *testing.T) {
+	t.Helper()
+	t.Setenv(ArkBasicKeyringOverrideEnvVar, "true")
+	t.Setenv(ArkBasicKeyringFolderEnvVar, t.TempDir())
+	t.Setenv(ArkKeyringPassphraseEnvVar, "")
+
+	tokens := []struct {
+		profile     string
+		serviceName string
+		postfix     string
+		token       *auth.ArkToken
+	}{
+		{
+			profile:     "profile1",
+			serviceName: "isp",
+			postfix:     "user-1@cyberark.cloud",
+			token: &auth.ArkToken{
+				Token:        "valid-secret-token",
+				TokenType:    auth.JWT,
+				Username:     "user-1@cyberark.cloud",
+				AuthMethod:   auth.Identity,
+				ExpiresIn:    commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour)),
+				RefreshToken: "valid-secret-refresh-token",
+				Metadata:     map[string]interface{}{"env": "prod", "cookies": "secret-cookies"},
+			},
+		},
+		{
+			profile:     "profile1",
+			serviceName: "sia-sso",
+			postfix:     "user-1@cyberark.cloud_short_lived_password",
+			token: &auth.ArkToken{
+				Token:     "expired-secret-token",
+				TokenType: auth.Password,
+				ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(-2 * time.Hour)),
+			},
+		},
+		{
+			profile:     "profile2",
+			serviceName: "isp",
+			postfix:     "user2",
+			token: &auth.ArkToken{
+				Token:        "refreshable-secret-token",
+				TokenType:    auth.JWT,
+				ExpiresIn:    commonmodels.ArkRFC3339Time(time.Now().Add(-time.Hour)),
+				RefreshToken: "refreshable-secret-refresh-token",
+			},
+		},
+	}
+	for _, tt := range tokens {
+		if err := NewArkKeyring(tt.serviceName).SaveToken(&models.ArkProfile{ProfileName: tt.profile}, tt.token, tt.postfix, false); err != nil {
+			t.Fatalf("Failed to save token: %v", err)
+		}
+	}
+}
+
+func TestArkKeyring_ListTokens(t *testing.T) {
+	setupCachedTokens(t)
+
+	tests := []struct {
+		name            string
+		serviceName     string
+		profileNames    []string
+		expectedPostfix []string
+	}{
+		{
+			name:            "success_lists_tokens_of_service_name",
+			serviceName:     "isp",
+			expectedPostfix: []string{"user-1@cyberark.cloud", "user2"},
+		},
+		{
+			name:            "success_lists_tokens_of_service_name_with_dash",
+			serviceName:     "sia-sso",
+			expectedPostfix: []string{"user-1@cyberark.cloud_short_lived_password"},
+		},
+		{
+			name:            "success_lists_tokens_of_profiles",
+			serviceName:     "isp",
+			profileNames:    []string{"profile2"},
+			expectedPostfix: []string{"user2"},
+		},
+		{
+			name:            "success_no_tokens_of_unknown_service_name",
+			serviceName:     "unknown",
+			expectedPostfix: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := NewArkKeyring(tt.serviceName).ListTokens(tt.profileNames...)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			postfixes := []string{}
+			for _, token := range tokens {
+				if token.ServiceName != tt.serviceName || token.Backend != ArkKeyringBackendBasic {
+					t.Errorf("Expected a %s token of the basic keyring, got %+v", tt.serviceName, token)
+				}
+				postfixes = append(postfixes, token.Postfix)
+			}
+			if strings.Join(postfixes, ",") != strings.Join(tt.expectedPostfix, ",") {
+				t.Errorf("Expected postfixes %v, got %v", tt.expectedPostfix, postfixes)
+			}
+		})
+	}
+}
+
+func TestArkKeyring_ListTokens_Details(t *testing.T) {
+	setupCachedTokens(t)
+
+	tokens, err := NewArkKeyring("").ListTokens("profile1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 tokens, got %d", len(tokens))
+	}
+	valid := tokens[0]
+	if valid.Profile != "profile1" || valid.ServiceName != "isp" || valid.Postfix != "user-1@cyberark.cloud" {
+		t.Errorf("Expected the key of the token to be parsed, got %+v", valid)
+	}
+	if valid.Expired || valid.Stale || !valid.HasRefreshToken || valid.ExpiresAt == nil {
+		t.Errorf("Expected a valid token with a refresh token, got %+v", valid)
+	}
+	if valid.Username != "user-1@cyberark.cloud" || valid.AuthMethod != auth.Identity || valid.TokenType != auth.JWT {
+		t.Errorf("Expected the details of the token, got %+v", valid)
+	}
+	if strings.Join(valid.MetadataKeys, ",") != "cookies,env" {
+		t.Errorf("Expected the metadata keys of the token, got %v", valid.MetadataKeys)
+	}
+	expired := tokens[1]
+	if !expired.Expired || !expired.Stale || expired.HasRefreshToken {
+		t.Errorf("Expected a stale token without a refresh token, got %+v", expired)
+	}
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("Expected the secrets of the tokens not to be exposed, got %s", data)
+	}
+}
+
+// TestArkKeyring_PruneTokens compares the keys of the tokens, as a keyring without a service name splits them at the first dash.
+func TestArkKeyring_PruneTokens(t *testing.T) {
+	tests := []struct {
+		name            string
+		includeExpired  bool
+		expectedPruned  []string
+		expectedRemains []string
+	}{
+		{
+			name:            "success_prunes_stale_tokens",
+			expectedPruned:  []string{"sia-sso-user-1@cyberark.cloud_short_lived_password"},
+			expectedRemains: []string{"isp-user-1@cyberark.cloud", "isp-user2"},
+		},
+		{
+			name:            "success_prunes_expired_tokens",
+			includeExpired:  true,
+			expectedPruned:  []string{"sia-sso-user-1@cyberark.cloud_short_lived_password", "isp-user2"},
+			expectedRemains: []string{"isp-user-1@cyberark.cloud"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupCachedTokens(t)
+			keyring := NewArkKeyring("")
+
+			pruned, err := keyring.PruneTokens(tt.includeExpired)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			prunedKeys := []string{}
+			for _, token := range pruned {
+				prunedKeys = append(prunedKeys, token.ServiceName+"-"+token.Postfix)
+			}
+			remaining, err := keyring.ListTokens()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			remainingKeys := []string{}
+			for _, token := range remaining {
+				remainingKeys = append(remainingKeys, token.ServiceName+"-"+token.Postfix)
+			}
+			if strings.Join(prunedKeys, ",") != strings.Join(tt.expectedPruned, ",") {
+				t.Errorf("Expected pruned %v, got %v", tt.expectedPruned, prunedKeys)
+			}
+			if strings.Join(remainingKeys, ",") != strings.Join(tt.expectedRemains, ",") {
+				t.Errorf("Expected remaining %v, got %v", tt.expectedRemains, remainingKeys)
+			}
+		})
+	}
+}
+
+func TestArkKeyring_DeleteToken(t *testing.T) {
+	setupCachedTokens(t)
+	keyring := NewArkKeyring("isp")
+	tokens, err := keyring.ListTokens("profile2")
+	if err != nil || len(tokens) != 1 {
+		t.Fatalf("Expected 1 token, got %v, %v", tokens, err)
+	}
+	if err := keyring.DeleteToken(tokens[0]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	token, err := keyring.LoadToken(&models.ArkProfile{ProfileName: "profile2"}, "user2", false)
+	if err != nil || token != nil {
+		t.Errorf("Expected the token to be deleted, got %v, %v", token, err)
+	}
+}
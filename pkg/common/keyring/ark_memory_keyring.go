@@ -0,0 +1,89 @@
+package keyring
+
+import (
+	"slices"
+	"sort"
+	"sync"
+)
+
+// ArkMemoryKeyring is a keyring implementation that keeps passwords in the memory of the process.
+//
+// ArkMemoryKeyring is meant for server processes which should not write tokens to disk,
+// and for tests. Passwords are lost when the process exits. It is safe for concurrent use.
+//
+// Example:
+//
+//	keyring := NewArkKeyringWithImpl("myapp", NewArkMemoryKeyring())
+type ArkMemoryKeyring struct {
+	mutex     sync.RWMutex
+	passwords map[string]map[string]string
+}
+
+// NewArkMemoryKeyring creates a new, empty ArkMemoryKeyring.
+func NewArkMemoryKeyring() *ArkMemoryKeyring {
+	return &ArkMemoryKeyring{
+		passwords: make(map[string]map[string]string),
+	}
+}
+
+// SetPassword sets a password for a given service and username.
+func (m *ArkMemoryKeyring) SetPassword(serviceName string, username string, password string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.passwords[serviceName]; !ok {
+		m.passwords[serviceName] = make(map[string]string)
+	}
+	m.passwords[serviceName][username] = password
+	return nil
+}
+
+// GetPassword retrieves the password of a given service and username, or an empty string if it doesn't exist.
+func (m *ArkMemoryKeyring) GetPassword(serviceName string, username string) (string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.passwords[serviceName][username], nil
+}
+
+// DeletePassword deletes the password of a given service and username, if it exists.
+func (m *ArkMemoryKeyring) DeletePassword(serviceName string, username string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.passwords[serviceName], username)
+	return nil
+}
+
+// ClearAllPasswords removes all the passwords of the keyring.
+func (m *ArkMemoryKeyring) ClearAllPasswords() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.passwords = make(map[string]map[string]string)
+	return nil
+}
+
+// ListEntries lists the entries of the given service names, or all of them when none are given.
+//
+// ListEntries implements ArkKeyringEnumerator, and returns the entries sorted by service name and username.
+func (m *ArkMemoryKeyring) ListEntries(serviceNames ...string) ([]ArkKeyringEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	var entries []ArkKeyringEntry
+	for serviceName, usernames := range m.passwords {
+		if len(serviceNames) > 0 && !slices.Contains(serviceNames, serviceName) {
+			continue
+		}
+		for username := range usernames {
+			entries = append(entries, ArkKeyringEntry{
+				ServiceName: serviceName,
+				Username:    username,
+				Backend:     ArkKeyringBackendMemory,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ServiceName != entries[j].ServiceName {
+			return entries[i].ServiceName < entries[j].ServiceName
+		}
+		return entries[i].Username < entries[j].Username
+	})
+	return entries, nil
+}
@@ -0,0 +1,91 @@
+package keyring
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
+)
+
+func TestArkMemoryKeyring(t *testing.T) {
+	keyring := NewArkMemoryKeyring()
+	if err := keyring.SetPassword("service2", "user", "password2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := keyring.SetPassword("service1", "user", "password1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if password, err := keyring.GetPassword("service1", "user"); err != nil || password != "password1" {
+		t.Errorf("Expected password1, got '%s', %v", password, err)
+	}
+	if password, err := keyring.GetPassword("service1", "missing"); err != nil || password != "" {
+		t.Errorf("Expected no password, got '%s', %v", password, err)
+	}
+
+	entries, err := keyring.ListEntries()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []ArkKeyringEntry{
+		{ServiceName: "service1", Username: "user", Backend: ArkKeyringBackendMemory},
+		{ServiceName: "service2", Username: "user", Backend: ArkKeyringBackendMemory},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %v, got %v", expected, entries)
+	}
+
+	if err := keyring.DeletePassword("service1", "user"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if password, _ := keyring.GetPassword("service1", "user"); password != "" {
+		t.Errorf("Expected the password to be deleted, got '%s'", password)
+	}
+	if err := keyring.ClearAllPasswords(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entries, _ := keyring.ListEntries(); len(entries) != 0 {
+		t.Errorf("Expected no entries after clear, got %v", entries)
+	}
+}
+
+// failingKeyring is a keyring implementation whose writes fail.
+type failingKeyring struct {
+	ArkMemoryKeyring
+}
+
+func (f *failingKeyring) SetPassword(serviceName string, username string, password string) error {
+	return errors.New("write failed")
+}
+
+func TestNewArkKeyringWithImpl(t *testing.T) {
+	t.Setenv(ArkBasicKeyringFolderEnvVar, t.TempDir())
+	profile := &models.ArkProfile{ProfileName: "profile"}
+	token := &auth.ArkToken{Token: "token", TokenType: auth.JWT, ExpiresIn: commonmodels.ArkRFC3339Time(time.Now().Add(time.Hour))}
+
+	memoryKeyring := NewArkMemoryKeyring()
+	keyring := NewArkKeyringWithImpl("service", memoryKeyring)
+	if kr, _ := keyring.GetKeyring(true); kr != memoryKeyring {
+		t.Errorf("Expected the given keyring implementation, got %T", kr)
+	}
+	if err := keyring.SaveToken(profile, token, "user", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if password, _ := memoryKeyring.GetPassword("profile", "service-user"); password == "" {
+		t.Error("Expected the token to be stored in the keyring implementation")
+	}
+	if err := keyring.RemoveToken(profile, "user", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded, err := keyring.LoadToken(profile, "user", false); err != nil || loaded != nil {
+		t.Errorf("Expected the token to be removed, got %v, %v", loaded, err)
+	}
+
+	failing := NewArkKeyringWithImpl("service", &failingKeyring{})
+	if err := failing.SaveToken(profile, token, "user", false); err == nil {
+		t.Error("Expected the error of the keyring implementation, without falling back to the basic keyring")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/99designs/keyring"
@@ -217,3 +218,65 @@ func (b *ArkOSProvidedKeyring) ClearAllPasswords() error {
 	}
 	return nil
 }
+
+// ListEntries lists the entries of the given service names in the OS keyring and the fallback keyring.
+//
+// ListEntries implements ArkKeyringEnumerator. As the OS keyring is namespaced by service, only the
+// entries of the given service names are listed from it, while the fallback keyring lists all of its
+// entries when no service names are given. Service names whose OS keyring cannot be opened or listed
+// are logged and skipped. Each entry reports the backend it was found in.
+//
+// Parameters:
+//   - serviceNames: The service names to list the entries of
+//
+// Returns the entries of the OS keyring followed by those of the fallback keyring, or an error if
+// the fallback keyring cannot be listed.
+//
+// Example:
+//
+//	entries, err := keyring.ListEntries("profile1", "profile2")
+//	if err != nil {
+//	    // Handle error
+//	}
+func (b *ArkOSProvidedKeyring) ListEntries(serviceNames ...string) ([]ArkKeyringEntry, error) {
+	var entries []ArkKeyringEntry
+	for _, serviceName := range serviceNames {
+		keyringStore, err := b.keyringForService(serviceName)
+		if err != nil {
+			b.logger.Warning("Failed to open OS keyring: %v. Listing basic keyring only.", err)
+			break
+		}
+		keys, err := keyringStore.Keys()
+		if err != nil {
+			b.logger.Warning("Failed to list keys from OS keyring of [%s]: %v", serviceName, err)
+			continue
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if username, ok := strings.CutPrefix(key, keyringPrefix+"_"); ok {
+				entries = append(entries, ArkKeyringEntry{
+					ServiceName: serviceName,
+					Username:    username,
+					Backend:     ArkKeyringBackendOS,
+				})
+			}
+		}
+	}
+	if enumerator, ok := b.fallbackKeyring.(ArkKeyringEnumerator); ok {
+		fallbackEntries, err := enumerator.ListEntries(serviceNames...)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fallbackEntries...)
+	}
+	return entries, nil
+}
+
+// backendKeyring returns the keyring implementation of the given backend, which is either the
+// OS keyring itself or its fallback keyring.
+func (b *ArkOSProvidedKeyring) backendKeyring(backend string) ArkKeyringImpl {
+	if backend == ArkKeyringBackendBasic {
+		return b.fallbackKeyring
+	}
+	return b
+}
@@ -2,6 +2,7 @@ package keyring
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/99designs/keyring"
@@ -443,3 +444,68 @@ func TestArkOSProvidedKeyring_ClearAllPasswords(t *testing.T) {
 		})
 	}
 }
+
+// mockEnumerableFallbackKeyring implements ArkKeyringImpl and ArkKeyringEnumerator for fallback logic.
+type mockEnumerableFallbackKeyring struct {
+	mockFallbackKeyring
+	entries []ArkKeyringEntry
+}
+
+func (m *mockEnumerableFallbackKeyring) ListEntries(serviceNames ...string) ([]ArkKeyringEntry, error) {
+	return m.entries, nil
+}
+
+func TestArkOSProvidedKeyring_ListEntries(t *testing.T) {
+	fallbackEntries := []ArkKeyringEntry{{ServiceName: "profile1", Username: "isp-user2", Backend: ArkKeyringBackendBasic}}
+	tests := []struct {
+		name            string
+		openErr         error
+		keysErr         error
+		keys            []string
+		fallback        ArkKeyringImpl
+		expectedEntries []ArkKeyringEntry
+	}{
+		{
+			name:     "success_lists_os_and_fallback_entries",
+			keys:     []string{"other_key", "ark_sdk_golang_isp-user1"},
+			fallback: &mockEnumerableFallbackKeyring{entries: fallbackEntries},
+			expectedEntries: []ArkKeyringEntry{
+				{ServiceName: "profile1", Username: "isp-user1", Backend: ArkKeyringBackendOS},
+				fallbackEntries[0],
+			},
+		},
+		{
+			name:            "success_fallback_not_enumerable",
+			keys:            []string{"ark_sdk_golang_isp-user1"},
+			fallback:        &mockFallbackKeyring{},
+			expectedEntries: []ArkKeyringEntry{{ServiceName: "profile1", Username: "isp-user1", Backend: ArkKeyringBackendOS}},
+		},
+		{
+			name:            "error_case_open_lists_fallback_only",
+			openErr:         errors.New("open error"),
+			fallback:        &mockEnumerableFallbackKeyring{entries: fallbackEntries},
+			expectedEntries: fallbackEntries,
+		},
+		{
+			name:            "error_case_keys_lists_fallback_only",
+			keysErr:         errors.New("keys error"),
+			fallback:        &mockEnumerableFallbackKeyring{entries: fallbackEntries},
+			expectedEntries: fallbackEntries,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore := patchOSKeyringOpen(&mockKeyringStoreClear{keys: tt.keys, keysErr: tt.keysErr}, tt.openErr)
+			defer restore()
+
+			entries, err := NewArkOSProvidedKeyring(tt.fallback).ListEntries("profile1")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(entries, tt.expectedEntries) {
+				t.Errorf("Expected %v, got %v", tt.expectedEntries, entries)
+			}
+		})
+	}
+}
@@ -17,9 +17,20 @@ var (
 
 	ConfigurationAllowedEmptyValues = map[string][]string{
 		"isp": {
+			"secret-ref",
 			"identity-url",
 			"identity-tenant-subdomain",
 			"identity-mfa-method",
+			"identity-mfa-totp-seed",
+			"identity-mfa-code-file",
+			"token-endpoint",
+			"private-key-file",
+			"key-id",
+			"token-env-var",
+			"token-file",
+			"issuer",
+			"client-id",
+			"redirect-uri",
 		},
 	}
 )
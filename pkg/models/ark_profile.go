@@ -7,12 +7,16 @@ package models
 import (
 	"encoding/json"
 
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 )
 
 // ArkProfile represents a profile configuration for the ARK SDK.
 // This structure contains the essential information needed to define a profile
-// including its name, description, and associated authentication profiles.
+// including its name, description, and associated authentication profiles, along
+// with the optional HTTP transport settings used by every client of the profile.
+// A profile may extend a base profile by name, inheriting the fields it does not set,
+// see profiles.ResolveProfile.
 // It supports JSON marshaling/unmarshaling with custom handling for auth profiles
 // to ensure proper type safety during deserialization.
 //
@@ -29,7 +33,9 @@ import (
 type ArkProfile struct {
 	ProfileName        string                          `json:"profile_name" mapstructure:"profile_name" validate:"required" flag:"profile-name" desc:"The name of the profile to use"`
 	ProfileDescription string                          `json:"profile_description" mapstructure:"profile_description" validate:"required" flag:"profile-description" desc:"Profile Description"`
+	Extends            string                          `json:"extends,omitempty" mapstructure:"extends,omitempty" flag:"-"`
 	AuthProfiles       map[string]*auth.ArkAuthProfile `json:"auth_profiles" mapstructure:"auth_profile" validate:"required" flag:"-"`
+	TransportConfig    *common.ArkTransportConfig      `json:"transport_config,omitempty" mapstructure:"transport_config,omitempty" flag:"-"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface for ArkProfile.
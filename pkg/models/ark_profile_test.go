@@ -342,6 +342,89 @@ func TestArkProfile_UnmarshalJSON_Integration(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "integration_client_credentials_auth",
+			jsonData: `{
+				"profile_name": "integration-test",
+				"auth_profiles": {
+					"isp": {
+						"username": "client@example.com",
+						"auth_method": "client_credentials",
+						"auth_method_settings": {
+							"token_endpoint": "https://identity.example.com/OAuth2/Token/app",
+							"client_auth_method": "private_key_jwt",
+							"private_key_file": "/keys/client.pem"
+						}
+					}
+				}
+			}`,
+			expectedError: false,
+			validateAuth: func(t *testing.T, authProfile *auth.ArkAuthProfile) {
+				settings, ok := authProfile.AuthMethodSettings.(*auth.ClientCredentialsArkAuthMethodSettings)
+				if !ok {
+					t.Errorf("Expected ClientCredentialsArkAuthMethodSettings, got %T", authProfile.AuthMethodSettings)
+					return
+				}
+				if settings.ClientAuthMethod != auth.PrivateKeyJWTAuth {
+					t.Errorf("Expected client auth method '%s', got '%s'", auth.PrivateKeyJWTAuth, settings.ClientAuthMethod)
+				}
+				if settings.PrivateKeyFile != "/keys/client.pem" {
+					t.Errorf("Expected private key file '/keys/client.pem', got '%s'", settings.PrivateKeyFile)
+				}
+			},
+		},
+		{
+			name: "integration_pre_issued_token_auth",
+			jsonData: `{
+				"profile_name": "integration-test",
+				"auth_profiles": {
+					"isp": {
+						"auth_method": "pre_issued_token",
+						"auth_method_settings": {
+							"token_env_var": "CI_ISP_TOKEN"
+						}
+					}
+				}
+			}`,
+			expectedError: false,
+			validateAuth: func(t *testing.T, authProfile *auth.ArkAuthProfile) {
+				settings, ok := authProfile.AuthMethodSettings.(*auth.PreIssuedTokenArkAuthMethodSettings)
+				if !ok {
+					t.Errorf("Expected PreIssuedTokenArkAuthMethodSettings, got %T", authProfile.AuthMethodSettings)
+					return
+				}
+				if settings.TokenEnvVar != "CI_ISP_TOKEN" {
+					t.Errorf("Expected token env var 'CI_ISP_TOKEN', got '%s'", settings.TokenEnvVar)
+				}
+			},
+		},
+		{
+			name: "integration_oidc_auth",
+			jsonData: `{
+				"profile_name": "integration-test",
+				"auth_profiles": {
+					"isp": {
+						"auth_method": "oidc",
+						"auth_method_settings": {
+							"identity_tenant_subdomain": "test-tenant",
+							"redirect_uri": "http://127.0.0.1:8250/callback",
+							"device_code": true
+						}
+					}
+				}
+			}`,
+			expectedError: false,
+			validateAuth: func(t *testing.T, authProfile *auth.ArkAuthProfile) {
+				settings, ok := authProfile.AuthMethodSettings.(*auth.OIDCArkAuthMethodSettings)
+				if !ok {
+					t.Errorf("Expected OIDCArkAuthMethodSettings, got %T", authProfile.AuthMethodSettings)
+					return
+				}
+				if settings.RedirectURI != "http://127.0.0.1:8250/callback" || !settings.DeviceCode {
+					t.Errorf("Unexpected OIDC settings %+v", settings)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,5 +1,13 @@
 package auth
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
 // ArkAuthMethod is a string type that represents the authentication method used in the Ark SDK.
 type ArkAuthMethod string
 
@@ -8,17 +16,33 @@ const (
 	Identity            ArkAuthMethod = "identity"
 	IdentityServiceUser ArkAuthMethod = "identity_service_user"
 	Direct              ArkAuthMethod = "direct"
+	ClientCredentials   ArkAuthMethod = "client_credentials"
+	PreIssuedToken      ArkAuthMethod = "pre_issued_token"
+	OIDC                ArkAuthMethod = "oidc"
 	Default             ArkAuthMethod = "default"
 	Other               ArkAuthMethod = "other"
 )
 
+// Client authentication methods of the ClientCredentials auth method.
+const (
+	ClientSecretAuth  = "client_secret"
+	PrivateKeyJWTAuth = "private_key_jwt"
+)
+
 // ArkAuthMethodSettings is an interface that defines the settings for different authentication methods.
 type ArkAuthMethodSettings interface{}
 
 // IdentityArkAuthMethodSettings is a struct that represents the settings for the Identity authentication method.
+//
+// MFA challenges can be answered without interaction, either with OATH codes generated
+// from IdentityMFATOTPSeed, or with codes of the IdentityMFAMethod read from IdentityMFACodeFile.
+// IdentityMFATOTPSeed is a long-lived second factor, so it should be a secret reference, such as
+// "file:/run/secrets/totp", rather than the raw seed stored next to the username in the profile.
 type IdentityArkAuthMethodSettings struct {
-	IdentityMFAMethod       string `json:"identity_mfa_method" mapstructure:"identity_mfa_method" validate:"oneof=pf sms email otp" flag:"identity-mfa-method" desc:"MFA Method to use by default [pf, sms, email, otp]"`
+	IdentityMFAMethod       string `json:"identity_mfa_method" mapstructure:"identity_mfa_method" validate:"oneof=pf sms email otp oath" flag:"identity-mfa-method" desc:"MFA Method to use by default [pf, sms, email, otp, oath]"`
 	IdentityMFAInteractive  bool   `json:"identity_mfa_interactive" mapstructure:"identity_mfa_interactive" validate:"required" flag:"identity-mfa-interactive" desc:"Allow Interactive MFA"`
+	IdentityMFATOTPSeed     string `json:"identity_mfa_totp_seed,omitempty" mapstructure:"identity_mfa_totp_seed" flag:"identity-mfa-totp-seed" desc:"Reference to the base32 seed or otpauth URI of the OATH authenticator, such as env:NAME or file:PATH, answering OATH MFA without interaction. Do not store a raw seed in the profile"`
+	IdentityMFACodeFile     string `json:"identity_mfa_code_file,omitempty" mapstructure:"identity_mfa_code_file" flag:"identity-mfa-code-file" desc:"File or named pipe to read MFA codes from, answering MFA without interaction"`
 	IdentityURL             string `json:"identity_url" mapstructure:"identity_url" flag:"identity-url" desc:"Identity Url"`
 	IdentityTenantSubdomain string `json:"identity_tenant_subdomain" mapstructure:"identity_tenant_subdomain" flag:"identity-tenant-subdomain" desc:"Identity Tenant Subdomain"`
 }
@@ -36,6 +60,49 @@ type DirectArkAuthMethodSettings struct {
 	Interactive bool   `json:"interactive" mapstructure:"interactive" flag:"interactive" desc:"Allow interactiveness"`
 }
 
+// ClientCredentialsArkAuthMethodSettings is a struct that represents the settings for the OAuth2 client credentials authentication method.
+//
+// The username of the auth profile is the client ID. With client_secret client authentication, the secret
+// is the client secret. With private_key_jwt, the client signs an assertion with the private key instead.
+// The token endpoint is resolved from the Identity URL or tenant subdomain and the application, unless given.
+type ClientCredentialsArkAuthMethodSettings struct {
+	IdentityURL                      string `json:"identity_url" mapstructure:"identity_url" flag:"identity-url" desc:"Identity Url"`
+	IdentityTenantSubdomain          string `json:"identity_tenant_subdomain" mapstructure:"identity_tenant_subdomain" flag:"identity-tenant-subdomain" desc:"Identity Tenant Subdomain"`
+	IdentityAuthorizationApplication string `json:"identity_authorization_application" mapstructure:"identity_authorization_application" flag:"identity-authorization-application" desc:"Identity Authorization Application" default:"__idaptive_cybr_user_oidc"`
+	TokenEndpoint                    string `json:"token_endpoint" mapstructure:"token_endpoint" flag:"token-endpoint" desc:"OAuth2 token endpoint, overrides the resolved one"`
+	Scope                            string `json:"scope" mapstructure:"scope" flag:"scope" desc:"OAuth2 scope to request" default:"api"`
+	ClientAuthMethod                 string `json:"client_auth_method" mapstructure:"client_auth_method" validate:"omitempty,oneof=client_secret private_key_jwt" flag:"client-auth-method" desc:"Client authentication method [client_secret, private_key_jwt]" default:"client_secret"`
+	PrivateKeyFile                   string `json:"private_key_file" mapstructure:"private_key_file" flag:"private-key-file" desc:"PEM file of the private key signing the private_key_jwt assertions"`
+	KeyID                            string `json:"key_id" mapstructure:"key_id" flag:"key-id" desc:"Key ID of the private key, set as the kid of the private_key_jwt assertions"`
+}
+
+// PreIssuedTokenArkAuthMethodSettings is a struct that represents the settings for the pre-issued token authentication method.
+//
+// The token is read from the first source set, in order: TokenCallback, TokenEnvVar and TokenFile.
+// It is read again from the same source on refresh. TokenCallback can only be set programmatically.
+type PreIssuedTokenArkAuthMethodSettings struct {
+	TokenEnvVar   string                 `json:"token_env_var" mapstructure:"token_env_var" flag:"token-env-var" desc:"Environment variable holding the token"`
+	TokenFile     string                 `json:"token_file" mapstructure:"token_file" flag:"token-file" desc:"File holding the token"`
+	TokenCallback func() (string, error) `json:"-" mapstructure:"-" flag:"-"`
+}
+
+// OIDCArkAuthMethodSettings is a struct that represents the settings for the OIDC login authentication method.
+//
+// The user logs in through the browser, with the authorization code flow and PKCE, and is redirected
+// back to a listener on the loopback interface. When no browser can be opened, such as in SSH sessions,
+// or when DeviceCode is set, the device authorization flow is used instead. The username is optional,
+// and is taken from the ID token when not set.
+type OIDCArkAuthMethodSettings struct {
+	IdentityURL                      string `json:"identity_url" mapstructure:"identity_url" flag:"identity-url" desc:"Identity Url"`
+	IdentityTenantSubdomain          string `json:"identity_tenant_subdomain" mapstructure:"identity_tenant_subdomain" flag:"identity-tenant-subdomain" desc:"Identity Tenant Subdomain"`
+	IdentityAuthorizationApplication string `json:"identity_authorization_application" mapstructure:"identity_authorization_application" flag:"identity-authorization-application" desc:"Identity Authorization Application" default:"__idaptive_cybr_user_oidc"`
+	Issuer                           string `json:"issuer" mapstructure:"issuer" flag:"issuer" desc:"OIDC issuer to discover the endpoints from, overrides the one of the identity application"`
+	ClientID                         string `json:"client_id" mapstructure:"client_id" flag:"client-id" desc:"OIDC client ID, defaults to the identity application"`
+	Scope                            string `json:"scope" mapstructure:"scope" flag:"scope" desc:"OAuth2 scope to request" default:"openid profile offline_access"`
+	RedirectURI                      string `json:"redirect_uri" mapstructure:"redirect_uri" flag:"redirect-uri" desc:"Loopback redirect URI of the browser login, such as http://127.0.0.1:8250/callback, on a random port when empty"`
+	DeviceCode                       bool   `json:"device_code" mapstructure:"device_code" flag:"device-code" desc:"Log in with the device authorization flow instead of the browser"`
+}
+
 // DefaultArkAuthMethodSettings is a struct that represents the default settings for the authentication method.
 type DefaultArkAuthMethodSettings struct{}
 
@@ -44,6 +111,9 @@ var ArkAuthMethodSettingsMap = map[ArkAuthMethod]interface{}{
 	Identity:            &IdentityArkAuthMethodSettings{},
 	IdentityServiceUser: &IdentityServiceUserArkAuthMethodSettings{},
 	Direct:              &DirectArkAuthMethodSettings{},
+	ClientCredentials:   &ClientCredentialsArkAuthMethodSettings{},
+	PreIssuedToken:      &PreIssuedTokenArkAuthMethodSettings{},
+	OIDC:                &OIDCArkAuthMethodSettings{},
 	Default:             &DefaultArkAuthMethodSettings{},
 }
 
@@ -52,9 +122,15 @@ var ArkAuthMethodsDescriptionMap = map[ArkAuthMethod]string{
 	Identity:            "Identity Personal User",
 	IdentityServiceUser: "Identity Service User",
 	Direct:              "Direct Endpoint Access",
+	ClientCredentials:   "OAuth2 Client Credentials",
+	PreIssuedToken:      "Pre-Issued Token",
+	OIDC:                "OIDC Browser Login",
 	Default:             "Default Authenticator Method",
 }
 
+// authMethodsMutex guards the maps and slices of auth methods, written by RegisterArkAuthMethod.
+var authMethodsMutex sync.RWMutex
+
 // ArkAuthMethodsRequireCredentials is a slice of ArkAuthMethod that require credentials.
 var ArkAuthMethodsRequireCredentials = []ArkAuthMethod{
 	Identity, IdentityServiceUser, Direct,
@@ -64,3 +140,71 @@ var ArkAuthMethodsRequireCredentials = []ArkAuthMethod{
 var ArkAuthMethodSharableCredentials = []ArkAuthMethod{
 	Identity,
 }
+
+// RegisterArkAuthMethod registers an authentication method of a custom authenticator.
+//
+// The settings type is used to decode the method settings of auth profiles, and
+// its flag and desc tags generate the settings flags of "ark configure".
+//
+// Parameters:
+//   - method: The name of the method, as stored in the auth profiles
+//   - description: The human readable description of the method
+//   - settings: A pointer to an empty settings struct of the method
+//   - requiresCredentials: Whether the method needs a username and a secret to authenticate
+//
+// It is safe to register methods while auth profiles are decoded, and the method helpers
+// of this package take the same lock, so concurrent readers should use them rather than
+// the exported maps and slices.
+//
+// Returns an error if the method is already registered or if the settings are not a pointer to a struct.
+//
+// Example:
+//
+//	type STSArkAuthMethodSettings struct {
+//		BrokerURL string `json:"broker_url" mapstructure:"broker_url" flag:"broker-url" desc:"Token broker URL"`
+//	}
+//
+//	err := auth.RegisterArkAuthMethod("sts", "STS Token Broker", &STSArkAuthMethodSettings{}, false)
+func RegisterArkAuthMethod(method ArkAuthMethod, description string, settings ArkAuthMethodSettings, requiresCredentials bool) error {
+	if method == "" {
+		return errors.New("auth method must not be empty")
+	}
+	settingsType := reflect.TypeOf(settings)
+	if settingsType == nil || settingsType.Kind() != reflect.Ptr || settingsType.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("settings of auth method %s must be a pointer to a struct", method)
+	}
+	authMethodsMutex.Lock()
+	defer authMethodsMutex.Unlock()
+	if _, exists := ArkAuthMethodSettingsMap[method]; exists {
+		return fmt.Errorf("auth method %s already registered", method)
+	}
+	ArkAuthMethodSettingsMap[method] = settings
+	ArkAuthMethodsDescriptionMap[method] = description
+	if requiresCredentials && !slices.Contains(ArkAuthMethodsRequireCredentials, method) {
+		ArkAuthMethodsRequireCredentials = append(ArkAuthMethodsRequireCredentials, method)
+	}
+	return nil
+}
+
+// newArkAuthMethodSettings returns new empty settings of a registered auth method.
+func newArkAuthMethodSettings(method ArkAuthMethod) (ArkAuthMethodSettings, bool) {
+	authMethodsMutex.RLock()
+	settings, exists := ArkAuthMethodSettingsMap[method]
+	authMethodsMutex.RUnlock()
+	if !exists {
+		return nil, false
+	}
+	return reflect.New(reflect.TypeOf(settings).Elem()).Interface(), true
+}
+
+// ArkAuthMethodRequiresCredentials tells whether an auth method needs a username and a secret to authenticate.
+//
+// Parameters:
+//   - method: The auth method to check
+//
+// Returns true if the method is one of ArkAuthMethodsRequireCredentials.
+func ArkAuthMethodRequiresCredentials(method ArkAuthMethod) bool {
+	authMethodsMutex.RLock()
+	defer authMethodsMutex.RUnlock()
+	return slices.Contains(ArkAuthMethodsRequireCredentials, method)
+}
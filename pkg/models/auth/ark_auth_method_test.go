@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type concurrentTestAuthMethodSettings struct {
+	Audience string `json:"audience" mapstructure:"audience"`
+}
+
+func TestRegisterArkAuthMethod(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        ArkAuthMethod
+		settings      ArkAuthMethodSettings
+		expectedError bool
+	}{
+		{name: "success_new_method", method: "register_test", settings: &concurrentTestAuthMethodSettings{}},
+		{name: "error_already_registered", method: Identity, settings: &concurrentTestAuthMethodSettings{}, expectedError: true},
+		{name: "error_empty_method", method: "", settings: &concurrentTestAuthMethodSettings{}, expectedError: true},
+		{name: "error_settings_not_a_pointer", method: "register_test_value", settings: concurrentTestAuthMethodSettings{}, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterArkAuthMethod(tt.method, "Register Test", tt.settings, true)
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !ArkAuthMethodRequiresCredentials(tt.method) {
+				t.Errorf("Expected %s to require credentials", tt.method)
+			}
+		})
+	}
+}
+
+func TestRegisterArkAuthMethod_ConcurrentWithUnmarshal(t *testing.T) {
+	if err := RegisterArkAuthMethod("concurrent_test_base", "Concurrent Test", &concurrentTestAuthMethodSettings{}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		method := ArkAuthMethod(fmt.Sprintf("concurrent_test_%d", i))
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := RegisterArkAuthMethod(method, "Concurrent Test", &concurrentTestAuthMethodSettings{}, i%2 == 0); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			var profile ArkAuthProfile
+			data := `{"username":"user","auth_method":"concurrent_test_base","auth_method_settings":{}}`
+			if err := json.Unmarshal([]byte(data), &profile); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			_ = ArkAuthMethodRequiresCredentials(method)
+		}()
+	}
+	wg.Wait()
+
+	var profile ArkAuthProfile
+	data := `{"username":"user","auth_method":"concurrent_test_3","auth_method_settings":{"audience":"workers"}}`
+	if err := json.Unmarshal([]byte(data), &profile); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settings, ok := profile.AuthMethodSettings.(*concurrentTestAuthMethodSettings); !ok || settings.Audience != "workers" {
+		t.Errorf("Expected the settings of the registered method, got %+v", profile.AuthMethodSettings)
+	}
+}
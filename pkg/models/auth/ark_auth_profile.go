@@ -6,8 +6,14 @@ import (
 )
 
 // ArkAuthProfile represents the authentication profile for Ark SIA.
+//
+// SecretRef references the secret to authenticate with, such as "env:ARK_SECRET", and is
+// resolved at authentication time when no secret is given, so that profiles hold no secrets.
+// The auth method may be omitted along with its settings, by auth profiles of profiles
+// extending a base profile, which only override fields such as the username.
 type ArkAuthProfile struct {
 	Username           string                `json:"username" mapstructure:"username" flag:"username" desc:"Username"`
+	SecretRef          string                `json:"secret_ref,omitempty" mapstructure:"secret_ref" flag:"secret-ref" desc:"Reference to the secret to authenticate with, such as env:NAME, file:PATH, exec:COMMAND or pcloud:SAFE/ACCOUNT"`
 	AuthMethod         ArkAuthMethod         `json:"auth_method" mapstructure:"auth_method" flag:"-"`
 	AuthMethodSettings ArkAuthMethodSettings `json:"auth_method_settings" mapstructure:"auth_method_settings" flag:"-"`
 }
@@ -26,6 +32,11 @@ func (a *ArkAuthProfile) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if a.AuthMethod == "" && (len(aux.AuthMethodSettings) == 0 || string(aux.AuthMethodSettings) == "null") {
+		a.AuthMethodSettings = nil
+		return nil
+	}
+
 	var settings ArkAuthMethodSettings
 	switch a.AuthMethod {
 	case Identity:
@@ -34,10 +45,19 @@ func (a *ArkAuthProfile) UnmarshalJSON(data []byte) error {
 		settings = &IdentityServiceUserArkAuthMethodSettings{}
 	case Direct:
 		settings = &DirectArkAuthMethodSettings{}
+	case ClientCredentials:
+		settings = &ClientCredentialsArkAuthMethodSettings{}
+	case PreIssuedToken:
+		settings = &PreIssuedTokenArkAuthMethodSettings{}
+	case OIDC:
+		settings = &OIDCArkAuthMethodSettings{}
 	case Default:
 		settings = &DefaultArkAuthMethodSettings{}
 	default:
-		return fmt.Errorf("unknown auth method: %s", a.AuthMethod)
+		var exists bool
+		if settings, exists = newArkAuthMethodSettings(a.AuthMethod); !exists {
+			return fmt.Errorf("unknown auth method: %s", a.AuthMethod)
+		}
 	}
 
 	if err := json.Unmarshal(aux.AuthMethodSettings, settings); err != nil {
@@ -47,3 +67,27 @@ func (a *ArkAuthProfile) UnmarshalJSON(data []byte) error {
 	a.AuthMethodSettings = settings
 	return nil
 }
+
+// RequiresUsername tells whether the auth profile needs a username to authenticate.
+//
+// Client credentials always need one, as the username is the client ID.
+func (a *ArkAuthProfile) RequiresUsername() bool {
+	return ArkAuthMethodRequiresCredentials(a.AuthMethod) || a.AuthMethod == ClientCredentials
+}
+
+// RequiresSecret tells whether the auth profile needs a secret to authenticate.
+//
+// Client credentials need the client secret, unless the client authenticates with a private key JWT.
+func (a *ArkAuthProfile) RequiresSecret() bool {
+	if a.AuthMethod != ClientCredentials {
+		return ArkAuthMethodRequiresCredentials(a.AuthMethod)
+	}
+	switch settings := a.AuthMethodSettings.(type) {
+	case *ClientCredentialsArkAuthMethodSettings:
+		return settings == nil || settings.ClientAuthMethod != PrivateKeyJWTAuth
+	case ClientCredentialsArkAuthMethodSettings:
+		return settings.ClientAuthMethod != PrivateKeyJWTAuth
+	default:
+		return true
+	}
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models/common"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ArkTokenClaims is a struct that represents the claims of an ISP token.
+type ArkTokenClaims struct {
+	TenantID       string                 `json:"tenant_id,omitempty" mapstructure:"tenant_id" desc:"ID of the tenant the token was issued for"`
+	Subdomain      string                 `json:"subdomain,omitempty" mapstructure:"subdomain" desc:"Subdomain of the tenant"`
+	PlatformDomain string                 `json:"platform_domain,omitempty" mapstructure:"platform_domain" desc:"Domain of the platform the tenant is hosted on"`
+	Username       string                 `json:"username,omitempty" mapstructure:"username" desc:"Username the token was issued to"`
+	Subject        string                 `json:"subject,omitempty" mapstructure:"subject" desc:"Subject of the token"`
+	Issuer         string                 `json:"issuer,omitempty" mapstructure:"issuer" desc:"Issuer of the token"`
+	Audience       []string               `json:"audience,omitempty" mapstructure:"audience" desc:"Audience of the token"`
+	Roles          []string               `json:"roles,omitempty" mapstructure:"roles" desc:"Roles granted by the token"`
+	Scopes         []string               `json:"scopes,omitempty" mapstructure:"scopes" desc:"Scopes granted by the token"`
+	IssuedAt       *common.ArkRFC3339Time `json:"issued_at,omitempty" mapstructure:"issued_at" desc:"When the token was issued"`
+	ExpiresAt      *common.ArkRFC3339Time `json:"expires_at,omitempty" mapstructure:"expires_at" desc:"When the token expires"`
+	Verified       bool                   `json:"verified" mapstructure:"verified" desc:"Whether the signature of the token was verified"`
+	Raw            map[string]interface{} `json:"-" mapstructure:"-"`
+}
+
+// ParseArkTokenClaims parses the claims of a JWT, without verifying its signature.
+func ParseArkTokenClaims(token string) (*ArkTokenClaims, error) {
+	if token == "" {
+		return nil, errors.New("token is empty")
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil, err
+	}
+	return NewArkTokenClaims(claims), nil
+}
+
+// NewArkTokenClaims creates ArkTokenClaims from the raw claims of a JWT.
+//
+// The username is taken from the unique_name, preferred_username or sub claims, in that order.
+// Roles are taken from the roles or role claims, and scopes from the scope or scp claims,
+// given either as a list or as a space separated string.
+func NewArkTokenClaims(claims map[string]interface{}) *ArkTokenClaims {
+	mapClaims := jwt.MapClaims(claims)
+	tokenClaims := &ArkTokenClaims{
+		TenantID:       stringClaim(claims, "tenant_id"),
+		Subdomain:      stringClaim(claims, "subdomain"),
+		PlatformDomain: stringClaim(claims, "platform_domain"),
+		Username:       stringClaim(claims, "unique_name", "preferred_username", "sub"),
+		Subject:        stringClaim(claims, "sub"),
+		Issuer:         stringClaim(claims, "iss"),
+		Roles:          listClaim(claims, "roles", "role"),
+		Scopes:         listClaim(claims, "scope", "scp"),
+		Raw:            claims,
+	}
+	if audience, err := mapClaims.GetAudience(); err == nil {
+		tokenClaims.Audience = audience
+	}
+	if issuedAt, err := mapClaims.GetIssuedAt(); err == nil && issuedAt != nil {
+		tokenClaims.IssuedAt = rfc3339Time(issuedAt.Time)
+	}
+	if expiresAt, err := mapClaims.GetExpirationTime(); err == nil && expiresAt != nil {
+		tokenClaims.ExpiresAt = rfc3339Time(expiresAt.Time)
+	}
+	return tokenClaims
+}
+
+// Claims parses the claims of the token, without verifying its signature.
+func (t *ArkToken) Claims() (*ArkTokenClaims, error) {
+	return ParseArkTokenClaims(t.Token)
+}
+
+// IsExpired returns whether the token expired, by its exp claim.
+func (c *ArkTokenClaims) IsExpired() bool {
+	return c.ExpiresAt != nil && time.Time(*c.ExpiresAt).Before(time.Now())
+}
+
+func stringClaim(claims map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func listClaim(claims map[string]interface{}, names ...string) []string {
+	for _, name := range names {
+		switch value := claims[name].(type) {
+		case string:
+			if fields := strings.Fields(value); len(fields) > 0 {
+				return fields
+			}
+		case []interface{}:
+			values := make([]string, 0, len(value))
+			for _, item := range value {
+				if itemString, ok := item.(string); ok {
+					values = append(values, itemString)
+				}
+			}
+			if len(values) > 0 {
+				return values
+			}
+		}
+	}
+	return nil
+}
+
+func rfc3339Time(t time.Time) *common.ArkRFC3339Time {
+	value := common.ArkRFC3339Time(t)
+	return &value
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func unsignedToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	return token
+}
+
+func TestParseArkTokenClaims(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name          string
+		claims        jwt.MapClaims
+		token         string
+		expectedError bool
+		validateFunc  func(t *testing.T, claims *ArkTokenClaims)
+	}{
+		{
+			name: "success_identity_token",
+			claims: jwt.MapClaims{
+				"iss":         "https://abc1234.id.cyberark.cloud/__idaptive_cybr_user_oidc/",
+				"sub":         "user-id",
+				"unique_name": "user@cyberark.cloud.12345",
+				"tenant_id":   "ABC1234",
+				"subdomain":   "tenant",
+				"aud":         "__idaptive_cybr_user_oidc",
+				"scope":       "all read",
+				"iat":         now.Add(-time.Hour).Unix(),
+				"exp":         now.Add(time.Hour).Unix(),
+			},
+			validateFunc: func(t *testing.T, claims *ArkTokenClaims) {
+				if claims.TenantID != "ABC1234" || claims.Subdomain != "tenant" {
+					t.Errorf("Unexpected tenant claims %+v", claims)
+				}
+				if claims.Username != "user@cyberark.cloud.12345" || claims.Subject != "user-id" {
+					t.Errorf("Unexpected user claims %+v", claims)
+				}
+				if !reflect.DeepEqual(claims.Scopes, []string{"all", "read"}) {
+					t.Errorf("Unexpected scopes %v", claims.Scopes)
+				}
+				if !reflect.DeepEqual(claims.Audience, []string{"__idaptive_cybr_user_oidc"}) {
+					t.Errorf("Unexpected audience %v", claims.Audience)
+				}
+				if claims.ExpiresAt == nil || !time.Time(*claims.ExpiresAt).Equal(now.Add(time.Hour)) {
+					t.Errorf("Unexpected expiry %v", claims.ExpiresAt)
+				}
+				if claims.IsExpired() || claims.Verified {
+					t.Error("Expected claims to be unexpired and unverified")
+				}
+			},
+		},
+		{
+			name: "success_fallback_claims",
+			claims: jwt.MapClaims{
+				"preferred_username": "client-id",
+				"sub":                "subject",
+				"role":               []interface{}{"admin", "auditor"},
+				"scp":                []interface{}{"read"},
+				"exp":                now.Add(-time.Minute).Unix(),
+			},
+			validateFunc: func(t *testing.T, claims *ArkTokenClaims) {
+				if claims.Username != "client-id" {
+					t.Errorf("Expected the preferred username, got %s", claims.Username)
+				}
+				if !reflect.DeepEqual(claims.Roles, []string{"admin", "auditor"}) || !reflect.DeepEqual(claims.Scopes, []string{"read"}) {
+					t.Errorf("Unexpected roles %v or scopes %v", claims.Roles, claims.Scopes)
+				}
+				if claims.IssuedAt != nil {
+					t.Error("Expected no issued at time")
+				}
+				if !claims.IsExpired() {
+					t.Error("Expected claims to be expired")
+				}
+			},
+		},
+		{
+			name:          "error_empty_token",
+			token:         "",
+			expectedError: true,
+		},
+		{
+			name:          "error_malformed_token",
+			token:         "not-a-jwt",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			token := tt.token
+			if tt.claims != nil {
+				token = unsignedToken(t, tt.claims)
+			}
+			claims, err := (&ArkToken{Token: token}).Claims()
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.validateFunc != nil {
+				tt.validateFunc(t, claims)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package profiles
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+)
+
+// InMemoryProfilesLoader is a ProfileLoader keeping profiles in memory.
+//
+// It is meant for tests and for applications embedding the SDK, which build their profiles
+// programmatically instead of reading them from files. Profiles are copied when saved and
+// loaded, so changes to a loaded profile only apply once it is saved again. The loader is
+// safe for concurrent use.
+type InMemoryProfilesLoader struct {
+	mutex    sync.RWMutex
+	profiles map[string]*models.ArkProfile
+}
+
+// NewInMemoryProfilesLoader creates an in-memory profile loader holding the given profiles.
+//
+// Parameters:
+//   - initialProfiles: The profiles the loader holds initially
+//
+// Returns the loader, or an error if a profile has no name or fails to be copied.
+//
+// Example:
+//
+//	loader, err := profiles.NewInMemoryProfilesLoader(&models.ArkProfile{ProfileName: "ark"})
+//	if err != nil {
+//		// handle error
+//	}
+//	var profileLoader profiles.ProfileLoader = loader
+func NewInMemoryProfilesLoader(initialProfiles ...*models.ArkProfile) (*InMemoryProfilesLoader, error) {
+	loader := &InMemoryProfilesLoader{profiles: map[string]*models.ArkProfile{}}
+	for _, profile := range initialProfiles {
+		if err := loader.SaveProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+	return loader, nil
+}
+
+// LoadDefaultProfile loads the effective profile of the name deduced by DeduceProfileName.
+//
+// Returns an empty profile if the profile does not exist, as FileSystemProfilesLoader does.
+func (l *InMemoryProfilesLoader) LoadDefaultProfile() (*models.ArkProfile, error) {
+	profileName := DeduceProfileName("")
+	if !l.ProfileExists(profileName) {
+		return &models.ArkProfile{}, nil
+	}
+	return LoadEffectiveProfile(l, profileName)
+}
+
+// LoadProfile loads a copy of the profile of the given name, or returns nil if it does not exist.
+func (l *InMemoryProfilesLoader) LoadProfile(profileName string) (*models.ArkProfile, error) {
+	l.mutex.RLock()
+	profile, ok := l.profiles[profileName]
+	l.mutex.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return cloneProfile(profile)
+}
+
+// SaveProfile saves a copy of the profile, replacing the profile of the same name.
+func (l *InMemoryProfilesLoader) SaveProfile(profile *models.ArkProfile) error {
+	if profile == nil || profile.ProfileName == "" {
+		return errors.New("profile name must be given")
+	}
+	cloned, err := cloneProfile(profile)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.profiles[profile.ProfileName] = cloned
+	return nil
+}
+
+// LoadAllProfiles loads copies of all the profiles, sorted by name.
+func (l *InMemoryProfilesLoader) LoadAllProfiles() ([]*models.ArkProfile, error) {
+	l.mutex.RLock()
+	names := make([]string, 0, len(l.profiles))
+	for name := range l.profiles {
+		names = append(names, name)
+	}
+	l.mutex.RUnlock()
+	sort.Strings(names)
+	var loadedProfiles []*models.ArkProfile
+	for _, name := range names {
+		profile, err := l.LoadProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		if profile != nil {
+			loadedProfiles = append(loadedProfiles, profile)
+		}
+	}
+	return loadedProfiles, nil
+}
+
+// DeleteProfile deletes the profile of the given name, if it exists.
+func (l *InMemoryProfilesLoader) DeleteProfile(profileName string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.profiles, profileName)
+	return nil
+}
+
+// ClearAllProfiles deletes all the profiles.
+func (l *InMemoryProfilesLoader) ClearAllProfiles() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.profiles = map[string]*models.ArkProfile{}
+	return nil
+}
+
+// ProfileExists checks if a profile of the given name exists.
+func (l *InMemoryProfilesLoader) ProfileExists(profileName string) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	_, ok := l.profiles[profileName]
+	return ok
+}
@@ -0,0 +1,77 @@
+package profiles
+
+import (
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+func TestInMemoryProfilesLoader(t *testing.T) {
+	loader, err := NewInMemoryProfilesLoader(
+		&models.ArkProfile{ProfileName: "second"},
+		&models.ArkProfile{
+			ProfileName: "first",
+			AuthProfiles: map[string]*auth.ArkAuthProfile{
+				"isp": {Username: "user", AuthMethod: auth.Identity, AuthMethodSettings: &auth.IdentityArkAuthMethodSettings{IdentityTenantSubdomain: "tenant"}},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var _ ProfileLoader = loader
+
+	all, err := loader.LoadAllProfiles()
+	if err != nil || len(all) != 2 || all[0].ProfileName != "first" || all[1].ProfileName != "second" {
+		t.Fatalf("Expected the profiles sorted by name, got %v, %v", all, err)
+	}
+
+	loaded, err := loader.LoadProfile("first")
+	if err != nil || loaded == nil {
+		t.Fatalf("Expected the profile, got %v, %v", loaded, err)
+	}
+	loaded.AuthProfiles["isp"].Username = "changed"
+	reloaded, _ := loader.LoadProfile("first")
+	if reloaded.AuthProfiles["isp"].Username != "user" {
+		t.Error("Expected loaded profiles to be copies")
+	}
+	if settings, ok := reloaded.AuthProfiles["isp"].AuthMethodSettings.(*auth.IdentityArkAuthMethodSettings); !ok || settings.IdentityTenantSubdomain != "tenant" {
+		t.Errorf("Expected the auth method settings to be kept, got %+v", reloaded.AuthProfiles["isp"].AuthMethodSettings)
+	}
+
+	if missing, err := loader.LoadProfile("missing"); err != nil || missing != nil {
+		t.Errorf("Expected no profile, got %v, %v", missing, err)
+	}
+	if err := loader.SaveProfile(&models.ArkProfile{}); err == nil {
+		t.Error("Expected an error saving a profile without a name")
+	}
+
+	if err := loader.DeleteProfile("second"); err != nil || loader.ProfileExists("second") {
+		t.Errorf("Expected the profile to be deleted, got %v", err)
+	}
+	if err := loader.ClearAllProfiles(); err != nil || loader.ProfileExists("first") {
+		t.Errorf("Expected the profiles to be cleared, got %v", err)
+	}
+}
+
+func TestInMemoryProfilesLoader_LoadDefaultProfile(t *testing.T) {
+	loader, err := NewInMemoryProfilesLoader(
+		&models.ArkProfile{ProfileName: "base", ProfileDescription: "Base"},
+		&models.ArkProfile{ProfileName: "child", Extends: "base"},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Setenv("ARK_PROFILE", "child")
+	profile, err := loader.LoadDefaultProfile()
+	if err != nil || profile.ProfileName != "child" || profile.ProfileDescription != "Base" {
+		t.Errorf("Expected the effective child profile, got %+v, %v", profile, err)
+	}
+
+	t.Setenv("ARK_PROFILE", "missing")
+	profile, err = loader.LoadDefaultProfile()
+	if err != nil || profile == nil || profile.ProfileName != "" {
+		t.Errorf("Expected an empty profile, got %+v, %v", profile, err)
+	}
+}
@@ -0,0 +1,211 @@
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+)
+
+// MultiFormatProfilesLoader is a ProfileLoader reading profiles in JSON, YAML or TOML files from a folder.
+//
+// The profile of a name is read from the first file found among "<name>", "<name>.json",
+// "<name>.yaml", "<name>.yml" and "<name>.toml", where files without an extension are
+// JSON, as written by FileSystemProfilesLoader. Both loaders can therefore share a folder.
+// Profiles are saved back in the format of their existing file, and new profiles are
+// saved in JSON files without an extension. Profile names which are not a single clean
+// file name, see ValidateProfileName, are rejected rather than resolved outside the folder.
+type MultiFormatProfilesLoader struct {
+	folder string
+}
+
+// NewMultiFormatProfilesLoader creates a profile loader reading JSON, YAML and TOML profile files from the given folder.
+//
+// Parameters:
+//   - folder: The folder of the profile files, the one of GetProfilesFolder if empty
+//
+// Returns the loader.
+//
+// Example:
+//
+//	var loader profiles.ProfileLoader = profiles.NewMultiFormatProfilesLoader("")
+//	profile, err := loader.LoadProfile("production")
+func NewMultiFormatProfilesLoader(folder string) *MultiFormatProfilesLoader {
+	return &MultiFormatProfilesLoader{folder: folder}
+}
+
+// profilesFolder returns the folder of the profile files.
+func (l *MultiFormatProfilesLoader) profilesFolder() string {
+	if l.folder != "" {
+		return l.folder
+	}
+	return GetProfilesFolder()
+}
+
+// profilePaths returns the paths the profile of the given name may be read from, in order.
+func (l *MultiFormatProfilesLoader) profilePaths(profileName string) []string {
+	basePath := filepath.Join(l.profilesFolder(), profileName)
+	paths := []string{basePath}
+	for _, format := range []ArkProfileFormat{ArkProfileFormatJSON, ArkProfileFormatYAML, ArkProfileFormatTOML} {
+		for _, extension := range profileFormatExtensions[format] {
+			paths = append(paths, basePath+extension)
+		}
+	}
+	return paths
+}
+
+// profilePath returns the path of the existing file of the profile of the given name, or an empty string.
+func (l *MultiFormatProfilesLoader) profilePath(profileName string) string {
+	if ValidateProfileName(profileName) != nil {
+		return ""
+	}
+	for _, path := range l.profilePaths(profileName) {
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+	}
+	return ""
+}
+
+// profileName returns the name of the profile stored in the file of the given name.
+func (l *MultiFormatProfilesLoader) profileName(fileName string) string {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	for _, extensions := range profileFormatExtensions {
+		for _, formatExtension := range extensions {
+			if extension == formatExtension {
+				return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+			}
+		}
+	}
+	return fileName
+}
+
+// LoadDefaultProfile loads the effective profile of the name deduced by DeduceProfileName.
+//
+// Returns an empty profile if the profile does not exist, as FileSystemProfilesLoader does.
+func (l *MultiFormatProfilesLoader) LoadDefaultProfile() (*models.ArkProfile, error) {
+	profileName := DeduceProfileName("")
+	if !l.ProfileExists(profileName) {
+		return &models.ArkProfile{}, nil
+	}
+	return LoadEffectiveProfile(l, profileName)
+}
+
+// LoadProfile loads the profile of the given name from its file, or returns nil if it does not exist.
+func (l *MultiFormatProfilesLoader) LoadProfile(profileName string) (*models.ArkProfile, error) {
+	if err := ValidateProfileName(profileName); err != nil {
+		return nil, err
+	}
+	path := l.profilePath(profileName)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var profile models.ArkProfile
+	if err := UnmarshalProfileData(data, ProfileFormatFromPath(path), &profile); err != nil {
+		return nil, err
+	}
+	if profile.ProfileName != "" {
+		if err := ValidateProfileName(profile.ProfileName); err != nil {
+			return nil, err
+		}
+	}
+	return &profile, nil
+}
+
+// SaveProfile saves the profile in the format of its existing file, or in a new JSON file.
+func (l *MultiFormatProfilesLoader) SaveProfile(profile *models.ArkProfile) error {
+	if err := ValidateProfileName(profile.ProfileName); err != nil {
+		return err
+	}
+	folder := l.profilesFolder()
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return err
+	}
+	path := l.profilePath(profile.ProfileName)
+	if path == "" {
+		path = filepath.Join(folder, profile.ProfileName)
+	}
+	data, err := MarshalProfileData(profile, ProfileFormatFromPath(path))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadAllProfiles loads the profiles of all the files of the folder, sorted by name.
+//
+// Files which fail to be loaded are skipped, as FileSystemProfilesLoader does. When several
+// files hold the same profile name, the profile is loaded once, from the first of them.
+func (l *MultiFormatProfilesLoader) LoadAllProfiles() ([]*models.ArkProfile, error) {
+	files, err := os.ReadDir(l.profilesFolder())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, file := range files {
+		if file.Type().IsRegular() {
+			names[l.profileName(file.Name())] = true
+		}
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	var loadedProfiles []*models.ArkProfile
+	for _, name := range sortedNames {
+		profile, err := l.LoadProfile(name)
+		if err != nil || profile == nil {
+			continue
+		}
+		loadedProfiles = append(loadedProfiles, profile)
+	}
+	return loadedProfiles, nil
+}
+
+// DeleteProfile deletes all the files of the profile of the given name.
+func (l *MultiFormatProfilesLoader) DeleteProfile(profileName string) error {
+	if err := ValidateProfileName(profileName); err != nil {
+		return err
+	}
+	for _, path := range l.profilePaths(profileName) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearAllProfiles deletes all the files of the folder, as FileSystemProfilesLoader does.
+func (l *MultiFormatProfilesLoader) ClearAllProfiles() error {
+	folder := l.profilesFolder()
+	files, err := os.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !file.IsDir() {
+			if err := os.Remove(filepath.Join(folder, file.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ProfileExists checks if a file of the profile of the given name exists.
+func (l *MultiFormatProfilesLoader) ProfileExists(profileName string) bool {
+	return l.profilePath(profileName) != ""
+}
@@ -0,0 +1,187 @@
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+const yamlTestProfile = `profile_name: yaml
+profile_description: YAML profile
+auth_profiles:
+  isp:
+    username: tina@cyberark.cloud
+    auth_method: identity
+    auth_method_settings:
+      identity_mfa_interactive: true
+      identity_tenant_subdomain: tenant
+transport_config:
+  request_timeout_seconds: 30
+`
+
+const tomlTestProfile = `profile_name = "toml"
+profile_description = "TOML profile"
+
+[auth_profiles.isp]
+username = "tina@cyberark.cloud"
+auth_method = "identity"
+
+[auth_profiles.isp.auth_method_settings]
+identity_mfa_interactive = true
+identity_tenant_subdomain = "tenant"
+
+[transport_config]
+request_timeout_seconds = 30
+`
+
+func TestProfileFormatFromPath(t *testing.T) {
+	tests := map[string]ArkProfileFormat{
+		"profile":           ArkProfileFormatJSON,
+		"profile.json":      ArkProfileFormatJSON,
+		"profile.yaml":      ArkProfileFormatYAML,
+		"profile.YML":       ArkProfileFormatYAML,
+		"profile.toml":      ArkProfileFormatTOML,
+		"profile.prod":      ArkProfileFormatJSON,
+		"/etc/ark/profiles": ArkProfileFormatJSON,
+	}
+	for path, expected := range tests {
+		if format := ProfileFormatFromPath(path); format != expected {
+			t.Errorf("Expected format %s of %s, got %s", expected, path, format)
+		}
+	}
+}
+
+func TestMultiFormatProfilesLoader_LoadProfile(t *testing.T) {
+	folder := t.TempDir()
+	files := map[string]string{
+		"json":      `{"profile_name": "json", "profile_description": "JSON profile", "auth_profiles": {"isp": {"username": "tina@cyberark.cloud", "auth_method": "identity", "auth_method_settings": {"identity_mfa_interactive": true, "identity_tenant_subdomain": "tenant"}}}, "transport_config": {"request_timeout_seconds": 30}}`,
+		"yaml.yaml": yamlTestProfile,
+		"toml.toml": tomlTestProfile,
+		"broken":    "{",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write profile: %v", err)
+		}
+	}
+	loader := NewMultiFormatProfilesLoader(folder)
+
+	for _, name := range []string{"json", "yaml", "toml"} {
+		t.Run(name, func(t *testing.T) {
+			profile, err := loader.LoadProfile(name)
+			if err != nil || profile == nil {
+				t.Fatalf("Expected the profile, got %v, %v", profile, err)
+			}
+			if profile.ProfileName != name || profile.ProfileDescription != strings.ToUpper(name)+" profile" {
+				t.Errorf("Expected the profile %s, got %+v", name, profile)
+			}
+			expectedSettings := &auth.IdentityArkAuthMethodSettings{IdentityMFAInteractive: true, IdentityTenantSubdomain: "tenant"}
+			if !reflect.DeepEqual(profile.AuthProfiles["isp"].AuthMethodSettings, expectedSettings) {
+				t.Errorf("Expected settings %+v, got %+v", expectedSettings, profile.AuthProfiles["isp"].AuthMethodSettings)
+			}
+			if !reflect.DeepEqual(profile.TransportConfig, &common.ArkTransportConfig{RequestTimeoutSeconds: 30}) {
+				t.Errorf("Expected the transport config, got %+v", profile.TransportConfig)
+			}
+		})
+	}
+
+	all, err := loader.LoadAllProfiles()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	names := []string{}
+	for _, profile := range all {
+		names = append(names, profile.ProfileName)
+	}
+	if strings.Join(names, ",") != "json,toml,yaml" {
+		t.Errorf("Expected the valid profiles sorted by name, got %v", names)
+	}
+	if missing, err := loader.LoadProfile("missing"); err != nil || missing != nil {
+		t.Errorf("Expected no profile, got %v, %v", missing, err)
+	}
+}
+
+func TestMultiFormatProfilesLoader_SaveProfile(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "yaml.yml"), []byte(yamlTestProfile), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "toml.toml"), []byte(tomlTestProfile), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	loader := NewMultiFormatProfilesLoader(folder)
+
+	for _, name := range []string{"yaml", "toml"} {
+		profile, err := loader.LoadProfile(name)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		profile.ProfileDescription = "Changed"
+		if err := loader.SaveProfile(profile); err != nil {
+			t.Fatalf("Expected no error saving %s, got %v", name, err)
+		}
+		saved, err := loader.LoadProfile(name)
+		if err != nil || saved.ProfileDescription != "Changed" || saved.AuthProfiles["isp"].Username != "tina@cyberark.cloud" {
+			t.Errorf("Expected the saved %s profile, got %+v, %v", name, saved, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(folder, "yaml.yml")); err != nil {
+		t.Errorf("Expected the YAML profile to be saved in its file, got %v", err)
+	}
+
+	if err := loader.SaveProfile(&models.ArkProfile{ProfileName: "new"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Setenv("ARK_PROFILES_FOLDER", folder)
+	if profile, err := (&FileSystemProfilesLoader{}).LoadProfile("new"); err != nil || profile == nil {
+		t.Errorf("Expected new profiles to be saved as JSON files without an extension, got %v, %v", profile, err)
+	}
+
+	if err := loader.DeleteProfile("yaml"); err != nil || loader.ProfileExists("yaml") {
+		t.Errorf("Expected the profile to be deleted, got %v", err)
+	}
+	if err := loader.ClearAllProfiles(); err != nil || loader.ProfileExists("toml") || loader.ProfileExists("new") {
+		t.Errorf("Expected the profiles to be cleared, got %v", err)
+	}
+}
+
+func TestMultiFormatProfilesLoader_InvalidProfileNames(t *testing.T) {
+	root := t.TempDir()
+	folder := filepath.Join(root, "profiles")
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	outside := filepath.Join(root, "outside.yaml")
+	if err := os.WriteFile(outside, []byte("profile_name: outside\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "escape.yaml"), []byte("profile_name: ../../.bashrc\n"), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	loader := NewMultiFormatProfilesLoader(folder)
+
+	if profile, err := loader.LoadProfile("../outside"); err == nil || profile != nil {
+		t.Errorf("Expected loading a profile outside of the folder to fail, got %v, %v", profile, err)
+	}
+	if loader.ProfileExists("../outside") {
+		t.Error("Expected a profile outside of the folder not to exist")
+	}
+	if err := loader.DeleteProfile("../outside"); err == nil {
+		t.Error("Expected deleting a profile outside of the folder to fail")
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("Expected the file outside of the folder to be kept, got %v", err)
+	}
+	if err := loader.SaveProfile(&models.ArkProfile{ProfileName: "../../.bashrc"}); err == nil {
+		t.Error("Expected saving a profile with a path as its name to fail")
+	}
+	if profile, err := loader.LoadProfile("escape"); err == nil || profile != nil {
+		t.Errorf("Expected a profile file naming a path to be rejected, got %v, %v", profile, err)
+	}
+}
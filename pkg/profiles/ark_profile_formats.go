@@ -0,0 +1,160 @@
+package profiles
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ArkProfileFormat is the serialization format of profile files.
+type ArkProfileFormat string
+
+// Formats profile files can be serialized in.
+const (
+	ArkProfileFormatJSON ArkProfileFormat = "json"
+	ArkProfileFormatYAML ArkProfileFormat = "yaml"
+	ArkProfileFormatTOML ArkProfileFormat = "toml"
+)
+
+// profileFormatExtensions are the file extensions of each profile format, the first one being the preferred one.
+var profileFormatExtensions = map[ArkProfileFormat][]string{
+	ArkProfileFormatJSON: {".json"},
+	ArkProfileFormatYAML: {".yaml", ".yml"},
+	ArkProfileFormatTOML: {".toml"},
+}
+
+// ProfileFormatFromPath returns the format of a profile file from its extension.
+//
+// Files with a .yaml or .yml extension are YAML, files with a .toml extension are TOML,
+// and any other file, including files without an extension, is JSON.
+//
+// Parameters:
+//   - path: The path of the profile file
+//
+// Returns the format of the file.
+//
+// Example:
+//
+//	format := ProfileFormatFromPath("production.yaml")
+//	// Returns ArkProfileFormatYAML
+func ProfileFormatFromPath(path string) ArkProfileFormat {
+	extension := strings.ToLower(filepath.Ext(path))
+	for format, extensions := range profileFormatExtensions {
+		for _, formatExtension := range extensions {
+			if extension == formatExtension {
+				return format
+			}
+		}
+	}
+	return ArkProfileFormatJSON
+}
+
+// UnmarshalProfileData unmarshals profile data of the given format into v.
+//
+// YAML and TOML data is converted to JSON before being unmarshalled, so the JSON field
+// names and the custom JSON unmarshalling of profiles apply to every format.
+//
+// Parameters:
+//   - data: The data to unmarshal
+//   - format: The format of the data
+//   - v: A pointer to the value to unmarshal the data into
+//
+// Returns an error if the data fails to be parsed.
+//
+// Example:
+//
+//	var profile models.ArkProfile
+//	err := UnmarshalProfileData(data, ArkProfileFormatYAML, &profile)
+func UnmarshalProfileData(data []byte, format ArkProfileFormat, v interface{}) error {
+	jsonData, err := profileDataToJSON(data, format)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// MarshalProfileData marshals v into profile data of the given format.
+//
+// JSON data is indented, as written by FileSystemProfilesLoader. YAML and TOML data is
+// converted from the JSON representation of v, and null values are omitted from TOML
+// data, which cannot represent them.
+//
+// Parameters:
+//   - v: The value to marshal
+//   - format: The format to marshal the value in
+//
+// Returns the marshalled data, or an error if the value fails to be marshalled.
+//
+// Example:
+//
+//	data, err := MarshalProfileData(profile, ArkProfileFormatTOML)
+func MarshalProfileData(v interface{}, format ArkProfileFormat) ([]byte, error) {
+	if format == ArkProfileFormatJSON || format == "" {
+		return json.MarshalIndent(v, "", "    ")
+	}
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(jsonData, &generic); err != nil {
+		return nil, err
+	}
+	switch format {
+	case ArkProfileFormatYAML:
+		return yaml.Marshal(generic)
+	case ArkProfileFormatTOML:
+		return toml.Marshal(withoutNullValues(generic))
+	default:
+		return nil, fmt.Errorf("unsupported profile format [%s]", format)
+	}
+}
+
+// profileDataToJSON converts profile data of the given format to JSON.
+func profileDataToJSON(data []byte, format ArkProfileFormat) ([]byte, error) {
+	var generic interface{}
+	switch format {
+	case ArkProfileFormatJSON, "":
+		return data, nil
+	case ArkProfileFormatYAML:
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return nil, err
+		}
+	case ArkProfileFormatTOML:
+		if err := toml.NewDecoder(bytes.NewReader(data)).Decode(&generic); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported profile format [%s]", format)
+	}
+	return json.Marshal(generic)
+}
+
+// withoutNullValues returns the generic value with the null values of its maps removed, recursively.
+func withoutNullValues(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		cleaned := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			if item != nil {
+				cleaned[key] = withoutNullValues(item)
+			}
+		}
+		return cleaned
+	case []interface{}:
+		cleaned := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			if item != nil {
+				cleaned = append(cleaned, withoutNullValues(item))
+			}
+		}
+		return cleaned
+	default:
+		return value
+	}
+}
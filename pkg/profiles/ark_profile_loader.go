@@ -8,9 +8,12 @@
 // environment variables and defaults.
 //
 // Key features:
-//   - Filesystem-based profile storage
+//   - Filesystem-based profile storage, in JSON, YAML or TOML files
+//   - In-memory and read-only bundle file profile loaders
+//   - Signed bundles of several profiles
 //   - Multiple profile management
 //   - Environment variable-based profile resolution
+//   - Profile inheritance and environment variable overrides of profile fields
 //   - JSON serialization for profile data
 //   - Automatic directory creation for profile storage
 //   - Profile existence checking and validation
@@ -39,8 +42,11 @@ package profiles
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/models"
 )
@@ -91,6 +97,10 @@ type FileSystemProfilesLoader struct {
 // profiles as JSON files in the local filesystem. This is the standard
 // implementation used throughout the ARK SDK.
 //
+// When the ARK_PROFILES_FILE environment variable is set, a ReadOnlyFileProfilesLoader of
+// that bundle file is returned instead, verifying the bundle with the public key of the
+// ARK_PROFILES_FILE_PUBLIC_KEY environment variable when it is set.
+//
 // Returns a pointer to a ProfileLoader interface that can be used for all
 // profile management operations.
 //
@@ -103,6 +113,9 @@ type FileSystemProfilesLoader struct {
 //	}
 func DefaultProfilesLoader() *ProfileLoader {
 	var profilesLoader ProfileLoader = &FileSystemProfilesLoader{}
+	if profilesFile := os.Getenv(ArkProfilesFileEnvVar); profilesFile != "" {
+		profilesLoader = NewReadOnlyFileProfilesLoader(profilesFile, os.Getenv(ArkProfilesFilePublicKeyEnvVar))
+	}
 	return &profilesLoader
 }
 
@@ -183,6 +196,32 @@ func DeduceProfileName(profileName string) string {
 	return DefaultProfileName()
 }
 
+// ValidateProfileName validates that a profile name can be used as the name of a profile file.
+//
+// Profile names are stored as file names in the profiles folder, so a name holding path
+// separators or relative path elements could otherwise read or write files outside of it.
+//
+// Parameters:
+//   - profileName: The profile name to validate
+//
+// Returns an error if the name is empty, is not a single clean path element, or is "." or "..".
+//
+// Example:
+//
+//	if err := ValidateProfileName("../../.bashrc"); err != nil {
+//		// reject the profile
+//	}
+func ValidateProfileName(profileName string) error {
+	if profileName == "" {
+		return errors.New("profile name is empty")
+	}
+	if profileName == "." || profileName == ".." || strings.Contains(profileName, "..") ||
+		strings.ContainsAny(profileName, `/\`) || filepath.Base(profileName) != profileName {
+		return fmt.Errorf("profile name [%s] is not a valid file name", profileName)
+	}
+	return nil
+}
+
 // LoadDefaultProfile loads the default profile from the file system.
 //
 // Loads the default profile by first determining the profile name using
@@ -190,10 +229,12 @@ func DeduceProfileName(profileName string) string {
 // and default resolution), then attempting to load that profile from the
 // filesystem.
 //
-// If the profile file exists, it loads and returns the profile. If the profile
-// file doesn't exist, it returns an empty ArkProfile struct rather than an error.
+// If the profile file exists, it loads and returns its effective profile, merged
+// over the profiles it extends and with the environment overrides applied, see
+// ResolveProfile. If the profile file doesn't exist, it returns an empty ArkProfile
+// struct rather than an error.
 //
-// Returns a pointer to the loaded ArkProfile and an error if file operations fail.
+// Returns a pointer to the loaded ArkProfile and an error if file operations or the resolution fail.
 // If no default profile exists, returns an empty profile without error.
 //
 // Example:
@@ -208,7 +249,7 @@ func (fspl *FileSystemProfilesLoader) LoadDefaultProfile() (*models.ArkProfile,
 	profileName := DeduceProfileName("")
 	profilePath := filepath.Join(folder, profileName)
 	if _, err := os.Stat(profilePath); err == nil {
-		return fspl.LoadProfile(profileName)
+		return LoadEffectiveProfile(fspl, profileName)
 	}
 	return &models.ArkProfile{}, nil
 }
@@ -248,6 +248,37 @@ func TestDeduceProfileName(t *testing.T) {
 	}
 }
 
+func TestValidateProfileName(t *testing.T) {
+	tests := []struct {
+		name          string
+		profileName   string
+		expectedError bool
+	}{
+		{name: "success_plain_name", profileName: "production"},
+		{name: "success_name_with_dots", profileName: "tenant.prod_1"},
+		{name: "error_empty_name", profileName: "", expectedError: true},
+		{name: "error_current_folder", profileName: ".", expectedError: true},
+		{name: "error_parent_folder", profileName: "..", expectedError: true},
+		{name: "error_relative_path", profileName: "../../.bashrc", expectedError: true},
+		{name: "error_absolute_path", profileName: "/etc/passwd", expectedError: true},
+		{name: "error_nested_path", profileName: "team/production", expectedError: true},
+		{name: "error_windows_separator", profileName: `..\evil`, expectedError: true},
+		{name: "error_double_dots_in_name", profileName: "prod..backup", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProfileName(tt.profileName)
+			if tt.expectedError && err == nil {
+				t.Errorf("Expected an error for profile name %q", tt.profileName)
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("Expected no error for profile name %q, got %v", tt.profileName, err)
+			}
+		})
+	}
+}
+
 func TestDefaultProfilesLoader(t *testing.T) {
 	tests := []struct {
 		name         string
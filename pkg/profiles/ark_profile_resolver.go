@@ -0,0 +1,395 @@
+package profiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+)
+
+// Environment variables overriding the fields of profiles.
+//
+// The name of an override is ArkProfileOverrideEnvVarPrefix followed by the JSON path of the
+// field in the profile, upper cased, with ArkProfileOverrideEnvVarSeparator between nested
+// fields. Auth profiles are referenced by their name, with dashes replaced by underscores:
+//
+//	ARK_PROFILE_DESCRIPTION=Production
+//	ARK_AUTH_PROFILES__ISP__USERNAME=tina@cyberark.cloud.1234567
+//	ARK_AUTH_PROFILES__ISP__AUTH_METHOD_SETTINGS__IDENTITY_TENANT_SUBDOMAIN=mytenant
+//	ARK_TRANSPORT_CONFIG__NO_PROXY=localhost,.internal
+const (
+	ArkProfileOverrideEnvVarPrefix    = "ARK_"
+	ArkProfileOverrideEnvVarSeparator = "__"
+)
+
+// nonOverridableProfileFields are the fields of profiles identifying them, which cannot be overridden.
+var nonOverridableProfileFields = []string{"profile_name", "extends"}
+
+// LoadEffectiveProfile loads a profile by name and resolves it into its effective profile.
+//
+// The effective profile is the profile merged over the profiles it extends, with the
+// environment overrides applied, as described in ResolveProfile. Profiles used to
+// authenticate and to run commands should be loaded with LoadEffectiveProfile, while
+// profiles which are edited and saved back should be loaded with the loader itself.
+//
+// Parameters:
+//   - loader: The loader to load the profile and the profiles it extends with
+//   - profileName: The name of the profile to load
+//
+// Returns the effective profile, or nil if the profile does not exist.
+// Returns an error if the profile or the profiles it extends fail to be loaded, or if an override is invalid.
+//
+// Example:
+//
+//	profile, err := profiles.LoadEffectiveProfile(*profiles.DefaultProfilesLoader(), "production")
+//	if err != nil {
+//		// handle error
+//	}
+func LoadEffectiveProfile(loader ProfileLoader, profileName string) (*models.ArkProfile, error) {
+	profile, err := loader.LoadProfile(profileName)
+	if err != nil || profile == nil {
+		return profile, err
+	}
+	return ResolveProfile(loader, profile)
+}
+
+// ResolveProfile resolves a profile into its effective profile.
+//
+// When the profile extends a base profile, the base profile is resolved first, and the fields set
+// in the profile are merged over it. Auth profiles are merged by name, and their method settings
+// are merged field by field when both use the same auth method, so a profile may only set the
+// username or the tenant subdomain of its base. Fields left empty, false or zero are inherited,
+// which means a profile cannot reset a field set by its base. The name and extends fields are
+// those of the profile itself.
+//
+// The environment overrides are then applied with ApplyProfileEnvOverrides. The given profile
+// is left unchanged.
+//
+// Parameters:
+//   - loader: The loader to load the profiles extended by the profile with
+//   - profile: The profile to resolve
+//
+// Returns the effective profile, or an error if a base profile does not exist, fails to be loaded,
+// extends the profile itself, or if an override is invalid.
+//
+// Example:
+//
+//	effective, err := profiles.ResolveProfile(loader, profile)
+//	if err != nil {
+//		// handle error
+//	}
+func ResolveProfile(loader ProfileLoader, profile *models.ArkProfile) (*models.ArkProfile, error) {
+	effective, err := resolveProfileInheritance(loader, profile, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyProfileEnvOverrides(effective); err != nil {
+		return nil, err
+	}
+	return effective, nil
+}
+
+// ApplyProfileEnvOverrides applies the environment variables overriding fields of the profile to it.
+//
+// Environment variables are named as described in ArkProfileOverrideEnvVarPrefix. Values of
+// strings are used as is, booleans and numbers are parsed, lists of strings are either JSON
+// arrays or comma separated values, and any other field, such as a whole auth profile, is
+// given as JSON. Overrides of fields the profile does not have, such as of auth profiles it
+// does not define, are ignored, as are other ARK_ environment variables. The name and extends
+// fields of the profile cannot be overridden.
+//
+// Overrides are applied from the least nested to the most nested, so an auth profile given as
+// JSON can be refined by overrides of its fields.
+//
+// Parameters:
+//   - profile: The profile to apply the overrides to
+//
+// Returns an error if the value of an override fails to be parsed.
+//
+// Example:
+//
+//	os.Setenv("ARK_AUTH_PROFILES__ISP__USERNAME", "tina@cyberark.cloud.1234567")
+//	err := profiles.ApplyProfileEnvOverrides(profile)
+//	// profile.AuthProfiles["isp"].Username is "tina@cyberark.cloud.1234567"
+func ApplyProfileEnvOverrides(profile *models.ArkProfile) error {
+	type override struct {
+		name  string
+		path  []string
+		value string
+	}
+	var overrides []override
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(name, ArkProfileOverrideEnvVarPrefix) {
+			continue
+		}
+		path := strings.Split(strings.TrimPrefix(name, ArkProfileOverrideEnvVarPrefix), ArkProfileOverrideEnvVarSeparator)
+		if isNonOverridableProfileField(path[0]) {
+			continue
+		}
+		overrides = append(overrides, override{name: name, path: path, value: value})
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		if len(overrides[i].path) != len(overrides[j].path) {
+			return len(overrides[i].path) < len(overrides[j].path)
+		}
+		return overrides[i].name < overrides[j].name
+	})
+	for _, o := range overrides {
+		if _, err := overrideProfileValue(reflect.ValueOf(profile).Elem(), o.path, o.value); err != nil {
+			return fmt.Errorf("failed to apply environment variable [%s] to profile [%s]: %w", o.name, profile.ProfileName, err)
+		}
+	}
+	return nil
+}
+
+// resolveProfileInheritance returns a copy of the profile merged over the profiles it extends.
+func resolveProfileInheritance(loader ProfileLoader, profile *models.ArkProfile, visited map[string]bool) (*models.ArkProfile, error) {
+	effective, err := cloneProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Extends == "" {
+		return effective, nil
+	}
+	visited[profile.ProfileName] = true
+	if visited[profile.Extends] {
+		return nil, fmt.Errorf("profile [%s] extends [%s], which extends it back", profile.ProfileName, profile.Extends)
+	}
+	if loader == nil {
+		return nil, fmt.Errorf("profile [%s] extends [%s], but no profile loader was given", profile.ProfileName, profile.Extends)
+	}
+	base, err := loader.LoadProfile(profile.Extends)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base profile [%s] of profile [%s]: %w", profile.Extends, profile.ProfileName, err)
+	}
+	if base == nil {
+		return nil, fmt.Errorf("base profile [%s] of profile [%s] does not exist", profile.Extends, profile.ProfileName)
+	}
+	resolved, err := resolveProfileInheritance(loader, base, visited)
+	if err != nil {
+		return nil, err
+	}
+	mergeProfileValues(reflect.ValueOf(resolved).Elem(), reflect.ValueOf(effective).Elem())
+	resolved.ProfileName = profile.ProfileName
+	resolved.Extends = profile.Extends
+	return resolved, nil
+}
+
+// cloneProfile returns a deep copy of the profile.
+func cloneProfile(profile *models.ArkProfile) (*models.ArkProfile, error) {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	var cloned models.ArkProfile
+	if err := json.Unmarshal(data, &cloned); err != nil {
+		return nil, err
+	}
+	return &cloned, nil
+}
+
+// mergeProfileValues merges the non-zero values of src over dst.
+//
+// Structs are merged field by field, maps key by key, and pointers and interfaces holding
+// the same type are merged through. Any other non-zero value of src replaces the one of dst.
+func mergeProfileValues(dst reflect.Value, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeProfileValues(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() || src.Elem().Kind() != reflect.Struct {
+			dst.Set(src)
+			return
+		}
+		mergeProfileValues(dst.Elem(), src.Elem())
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() || dst.Elem().Type() != src.Elem().Type() || src.Elem().Kind() != reflect.Pointer {
+			dst.Set(src)
+			return
+		}
+		mergeProfileValues(dst.Elem(), src.Elem())
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(src.Type()))
+		}
+		for _, key := range src.MapKeys() {
+			dstValue := dst.MapIndex(key)
+			if !dstValue.IsValid() {
+				dst.SetMapIndex(key, src.MapIndex(key))
+				continue
+			}
+			merged := reflect.New(dstValue.Type()).Elem()
+			merged.Set(dstValue)
+			mergeProfileValues(merged, src.MapIndex(key))
+			dst.SetMapIndex(key, merged)
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// profileFieldMatches returns whether a JSON field name or map key matches a segment of an override environment variable.
+func profileFieldMatches(name string, segment string) bool {
+	return segment != "" && strings.EqualFold(strings.ReplaceAll(name, "-", "_"), segment)
+}
+
+// isNonOverridableProfileField returns whether the top level segment of an override environment variable is a non-overridable field.
+func isNonOverridableProfileField(segment string) bool {
+	for _, name := range nonOverridableProfileFields {
+		if profileFieldMatches(name, segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// overrideProfileValue sets the value at the path of override segments under v.
+//
+// Returns whether the path exists under v, and an error if the value fails to be parsed.
+func overrideProfileValue(v reflect.Value, path []string, value string) (bool, error) {
+	if len(path) == 0 {
+		return true, parseProfileValue(v, value)
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return overrideProfileValue(v.Elem(), path, value)
+		}
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return false, nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		applied, err := overrideProfileValue(elem.Elem(), path, value)
+		if applied && err == nil {
+			v.Set(elem)
+		}
+		return applied, err
+	case reflect.Interface:
+		if v.IsNil() {
+			return false, nil
+		}
+		return overrideProfileValue(v.Elem(), path, value)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "" || name == "-" || !profileFieldMatches(name, path[0]) {
+				continue
+			}
+			if !v.Field(i).CanSet() {
+				return false, nil
+			}
+			return overrideProfileValue(v.Field(i), path[1:], value)
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return false, nil
+		}
+		for _, key := range v.MapKeys() {
+			if !profileFieldMatches(key.String(), path[0]) {
+				continue
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			applied, err := overrideProfileValue(elem, path[1:], value)
+			if applied && err == nil {
+				v.SetMapIndex(key, elem)
+			}
+			return applied, err
+		}
+	}
+	return false, nil
+}
+
+// parseProfileValue parses the value of an override environment variable into v.
+func parseProfileValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(strings.TrimSpace(value), "[") {
+			values := reflect.MakeSlice(v.Type(), 0, 0)
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					values = reflect.Append(values, reflect.ValueOf(item).Convert(v.Type().Elem()))
+				}
+			}
+			v.Set(values)
+			return nil
+		}
+		return unmarshalProfileValue(v, v.Type(), value)
+	case reflect.Interface:
+		if v.IsNil() || v.Elem().Kind() != reflect.Pointer {
+			return fmt.Errorf("field of type %s cannot be overridden", v.Type())
+		}
+		return unmarshalProfileValue(v, v.Elem().Type().Elem(), value)
+	default:
+		return unmarshalProfileValue(v, v.Type(), value)
+	}
+	return nil
+}
+
+// unmarshalProfileValue unmarshals the JSON value into a new value of the given type, and sets v to it.
+//
+// Pointer types are allocated through, so v is set to a pointer to the unmarshalled value.
+func unmarshalProfileValue(v reflect.Value, t reflect.Type, value string) error {
+	isPointer := t.Kind() == reflect.Pointer
+	if isPointer {
+		t = t.Elem()
+	}
+	parsed := reflect.New(t)
+	if err := json.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return err
+	}
+	if isPointer || v.Kind() == reflect.Interface {
+		v.Set(parsed)
+		return nil
+	}
+	v.Set(parsed.Elem())
+	return nil
+}
@@ -0,0 +1,285 @@
+package profiles
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+// writeTestProfiles writes the given profile files to a temporary profiles folder, and returns a loader of the folder.
+func writeTestProfiles(t *testing.T, profileFiles map[string]string) ProfileLoader {
+	t.Helper()
+	folder := t.TempDir()
+	t.Setenv("ARK_PROFILES_FOLDER", folder)
+	for name, data := range profileFiles {
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write profile: %v", err)
+		}
+	}
+	return &FileSystemProfilesLoader{}
+}
+
+const baseTestProfile = `{
+	"profile_name": "base",
+	"profile_description": "Base profile",
+	"auth_profiles": {
+		"isp": {
+			"username": "base@cyberark.cloud",
+			"auth_method": "identity",
+			"auth_method_settings": {
+				"identity_mfa_method": "email",
+				"identity_mfa_interactive": true,
+				"identity_url": "https://base.id.cyberark.cloud",
+				"identity_tenant_subdomain": "base"
+			}
+		}
+	},
+	"transport_config": {
+		"proxy_url": "http://proxy.internal:3128",
+		"request_timeout_seconds": 30
+	}
+}`
+
+func TestLoadEffectiveProfile_Inheritance(t *testing.T) {
+	loader := writeTestProfiles(t, map[string]string{
+		"base": baseTestProfile,
+		"tenant1": `{
+			"profile_name": "tenant1",
+			"extends": "base",
+			"auth_profiles": {
+				"isp": {
+					"username": "tina@cyberark.cloud",
+					"auth_method": "identity",
+					"auth_method_settings": {"identity_tenant_subdomain": "tenant1"}
+				}
+			}
+		}`,
+		"tenant1-user2": `{
+			"profile_name": "tenant1-user2",
+			"profile_description": "Second user",
+			"extends": "tenant1",
+			"auth_profiles": {"isp": {"username": "user2@cyberark.cloud"}}
+		}`,
+	})
+
+	profile, err := LoadEffectiveProfile(loader, "tenant1-user2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.ProfileName != "tenant1-user2" || profile.Extends != "tenant1" || profile.ProfileDescription != "Second user" {
+		t.Errorf("Expected the identity of the profile itself, got %+v", profile)
+	}
+	isp := profile.AuthProfiles["isp"]
+	if isp == nil || isp.Username != "user2@cyberark.cloud" || isp.AuthMethod != auth.Identity {
+		t.Fatalf("Expected the merged isp auth profile, got %+v", isp)
+	}
+	expectedSettings := &auth.IdentityArkAuthMethodSettings{
+		IdentityMFAMethod:       "email",
+		IdentityMFAInteractive:  true,
+		IdentityURL:             "https://base.id.cyberark.cloud",
+		IdentityTenantSubdomain: "tenant1",
+	}
+	if !reflect.DeepEqual(isp.AuthMethodSettings, expectedSettings) {
+		t.Errorf("Expected settings %+v, got %+v", expectedSettings, isp.AuthMethodSettings)
+	}
+	if profile.TransportConfig == nil || profile.TransportConfig.ProxyURL != "http://proxy.internal:3128" || profile.TransportConfig.RequestTimeoutSeconds != 30 {
+		t.Errorf("Expected the transport config of the base profile, got %+v", profile.TransportConfig)
+	}
+
+	raw, err := loader.LoadProfile("tenant1-user2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if raw.TransportConfig != nil || raw.AuthProfiles["isp"].AuthMethodSettings != nil {
+		t.Errorf("Expected the stored profile to be left unresolved, got %+v", raw)
+	}
+}
+
+func TestLoadEffectiveProfile_InheritanceErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		profileFiles  map[string]string
+		profileName   string
+		expectedError string
+	}{
+		{
+			name: "error_missing_base_profile",
+			profileFiles: map[string]string{
+				"child": `{"profile_name": "child", "extends": "missing"}`,
+			},
+			profileName:   "child",
+			expectedError: "base profile [missing] of profile [child] does not exist",
+		},
+		{
+			name: "error_extends_itself",
+			profileFiles: map[string]string{
+				"child": `{"profile_name": "child", "extends": "child"}`,
+			},
+			profileName:   "child",
+			expectedError: "extends it back",
+		},
+		{
+			name: "error_extends_cycle",
+			profileFiles: map[string]string{
+				"first":  `{"profile_name": "first", "extends": "second"}`,
+				"second": `{"profile_name": "second", "extends": "first"}`,
+			},
+			profileName:   "first",
+			expectedError: "profile [second] extends [first], which extends it back",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := writeTestProfiles(t, tt.profileFiles)
+			_, err := LoadEffectiveProfile(loader, tt.profileName)
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestLoadEffectiveProfile_MissingProfile(t *testing.T) {
+	loader := writeTestProfiles(t, map[string]string{})
+	profile, err := LoadEffectiveProfile(loader, "missing")
+	if err != nil || profile != nil {
+		t.Errorf("Expected no profile and no error, got %v, %v", profile, err)
+	}
+}
+
+func TestApplyProfileEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		validateFunc  func(t *testing.T, profile *models.ArkProfile)
+		expectedError string
+	}{
+		{
+			name: "success_overrides_top_level_field",
+			env:  map[string]string{"ARK_PROFILE_DESCRIPTION": "Overridden"},
+			validateFunc: func(t *testing.T, profile *models.ArkProfile) {
+				if profile.ProfileDescription != "Overridden" {
+					t.Errorf("Expected the description to be overridden, got %s", profile.ProfileDescription)
+				}
+			},
+		},
+		{
+			name: "success_overrides_auth_method_settings",
+			env: map[string]string{
+				"ARK_AUTH_PROFILES__ISP__USERNAME":                                        "tina@cyberark.cloud",
+				"ARK_AUTH_PROFILES__ISP__AUTH_METHOD_SETTINGS__IDENTITY_TENANT_SUBDOMAIN": "tenant2",
+				"ARK_AUTH_PROFILES__ISP__AUTH_METHOD_SETTINGS__IDENTITY_MFA_INTERACTIVE":  "false",
+			},
+			validateFunc: func(t *testing.T, profile *models.ArkProfile) {
+				isp := profile.AuthProfiles["isp"]
+				settings := isp.AuthMethodSettings.(*auth.IdentityArkAuthMethodSettings)
+				if isp.Username != "tina@cyberark.cloud" || settings.IdentityTenantSubdomain != "tenant2" || settings.IdentityMFAInteractive {
+					t.Errorf("Expected the isp auth profile to be overridden, got %+v, %+v", isp, settings)
+				}
+				if settings.IdentityMFAMethod != "email" || settings.IdentityURL != "https://base.id.cyberark.cloud" {
+					t.Errorf("Expected the other settings to be kept, got %+v", settings)
+				}
+			},
+		},
+		{
+			name: "success_overrides_transport_config",
+			env: map[string]string{
+				"ARK_TRANSPORT_CONFIG__NO_PROXY":                "localhost, .internal",
+				"ARK_TRANSPORT_CONFIG__CA_BUNDLE_FILES":         `["/etc/ssl/ca.pem"]`,
+				"ARK_TRANSPORT_CONFIG__REQUEST_TIMEOUT_SECONDS": "60",
+			},
+			validateFunc: func(t *testing.T, profile *models.ArkProfile) {
+				expected := &common.ArkTransportConfig{
+					ProxyURL:              "http://proxy.internal:3128",
+					NoProxy:               []string{"localhost", ".internal"},
+					CABundleFiles:         []string{"/etc/ssl/ca.pem"},
+					RequestTimeoutSeconds: 60,
+				}
+				if !reflect.DeepEqual(profile.TransportConfig, expected) {
+					t.Errorf("Expected transport config %+v, got %+v", expected, profile.TransportConfig)
+				}
+			},
+		},
+		{
+			name: "success_overrides_auth_profile_as_json_then_its_fields",
+			env: map[string]string{
+				"ARK_AUTH_PROFILES__ISP":           `{"username": "json@cyberark.cloud", "auth_method": "identity_service_user", "auth_method_settings": {"identity_authorization_application": "app"}}`,
+				"ARK_AUTH_PROFILES__ISP__USERNAME": "field@cyberark.cloud",
+			},
+			validateFunc: func(t *testing.T, profile *models.ArkProfile) {
+				isp := profile.AuthProfiles["isp"]
+				settings, ok := isp.AuthMethodSettings.(*auth.IdentityServiceUserArkAuthMethodSettings)
+				if isp.Username != "field@cyberark.cloud" || isp.AuthMethod != auth.IdentityServiceUser || !ok || settings.IdentityAuthorizationApplication != "app" {
+					t.Errorf("Expected the isp auth profile to be replaced and refined, got %+v", isp)
+				}
+			},
+		},
+		{
+			name: "success_ignores_unknown_and_identity_fields",
+			env: map[string]string{
+				"ARK_PROFILE_NAME":                 "renamed",
+				"ARK_EXTENDS":                      "other",
+				"ARK_AUTH_PROFILES__SIA__USERNAME": "unknown",
+				"ARK_UNKNOWN_SETTING":              "true",
+			},
+			validateFunc: func(t *testing.T, profile *models.ArkProfile) {
+				if profile.ProfileName != "base" || profile.Extends != "" || len(profile.AuthProfiles) != 1 {
+					t.Errorf("Expected the profile to be left unchanged, got %+v", profile)
+				}
+			},
+		},
+		{
+			name:          "error_invalid_value",
+			env:           map[string]string{"ARK_AUTH_PROFILES__ISP__AUTH_METHOD_SETTINGS__IDENTITY_MFA_INTERACTIVE": "maybe"},
+			expectedError: "ARK_AUTH_PROFILES__ISP__AUTH_METHOD_SETTINGS__IDENTITY_MFA_INTERACTIVE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			var profile models.ArkProfile
+			if err := json.Unmarshal([]byte(baseTestProfile), &profile); err != nil {
+				t.Fatalf("Failed to parse profile: %v", err)
+			}
+			err := ApplyProfileEnvOverrides(&profile)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			tt.validateFunc(t, &profile)
+		})
+	}
+}
+
+func TestFileSystemProfilesLoader_LoadDefaultProfile_Effective(t *testing.T) {
+	writeTestProfiles(t, map[string]string{
+		"base":  baseTestProfile,
+		"child": `{"profile_name": "child", "extends": "base"}`,
+	})
+	t.Setenv("ARK_PROFILE", "child")
+	t.Setenv("ARK_AUTH_PROFILES__ISP__USERNAME", "env@cyberark.cloud")
+
+	profile, err := (&FileSystemProfilesLoader{}).LoadDefaultProfile()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.ProfileName != "child" || profile.AuthProfiles["isp"] == nil || profile.AuthProfiles["isp"].Username != "env@cyberark.cloud" {
+		t.Errorf("Expected the effective child profile, got %+v", profile)
+	}
+}
@@ -0,0 +1,346 @@
+package profiles
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+)
+
+// ArkProfilesBundleVersion is the version of the profiles bundles written by MarshalProfilesBundle.
+const ArkProfilesBundleVersion = 1
+
+// Signature algorithms of profiles bundles, by the type of the signing key.
+const (
+	ArkProfilesBundleAlgorithmEd25519 = "ed25519"
+	ArkProfilesBundleAlgorithmECDSA   = "ecdsa-sha256"
+	ArkProfilesBundleAlgorithmRSA     = "rsa-pkcs1v15-sha256"
+)
+
+// Errors of profiles bundles verification.
+var (
+	// ErrProfilesBundleNotSigned is returned when a bundle must be verified but is not signed.
+	ErrProfilesBundleNotSigned = errors.New("profiles bundle is not signed")
+	// ErrProfilesBundleSignatureInvalid is returned when the signature of a bundle does not match its content or key.
+	ErrProfilesBundleSignatureInvalid = errors.New("profiles bundle signature is invalid")
+)
+
+// ArkProfilesBundle is a bundle of several profiles, distributed as a single file.
+//
+// Bundles are written by "ark profiles export" and read by "ark profiles import" and by
+// ReadOnlyFileProfilesLoader. A bundle is either the JSON, YAML or TOML document of this
+// struct, or a signed JSON document holding it along with its ArkProfilesBundleSignature.
+type ArkProfilesBundle struct {
+	Version    int                  `json:"version" mapstructure:"version"`
+	ExportedAt time.Time            `json:"exported_at" mapstructure:"exported_at"`
+	Profiles   []*models.ArkProfile `json:"profiles" mapstructure:"profiles"`
+}
+
+// ArkProfilesBundleSignature is the signature of a profiles bundle.
+//
+// The signature covers the canonical JSON of the bundle, with sorted keys and without
+// whitespace, so it does not depend on the formatting of the bundle file. The key ID is
+// the hex encoded SHA-256 of the DER encoded public key of the signing key.
+type ArkProfilesBundleSignature struct {
+	Algorithm string `json:"algorithm" mapstructure:"algorithm"`
+	KeyID     string `json:"key_id" mapstructure:"key_id"`
+	Value     string `json:"value" mapstructure:"value"`
+}
+
+// arkSignedProfilesBundle is the document of a signed profiles bundle.
+type arkSignedProfilesBundle struct {
+	Bundle    json.RawMessage             `json:"bundle,omitempty"`
+	Signature *ArkProfilesBundleSignature `json:"signature,omitempty"`
+}
+
+// NewArkProfilesBundle creates a bundle of the given profiles, exported now.
+//
+// Parameters:
+//   - bundleProfiles: The profiles of the bundle
+//
+// Returns the bundle.
+//
+// Example:
+//
+//	bundle := profiles.NewArkProfilesBundle(loadedProfiles)
+func NewArkProfilesBundle(bundleProfiles []*models.ArkProfile) *ArkProfilesBundle {
+	if bundleProfiles == nil {
+		bundleProfiles = []*models.ArkProfile{}
+	}
+	return &ArkProfilesBundle{
+		Version:    ArkProfilesBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Profiles:   bundleProfiles,
+	}
+}
+
+// MarshalProfilesBundle marshals a profiles bundle to JSON, signed with the given key if any.
+//
+// Bundles are signed with Ed25519, ECDSA or RSA keys, see ReadProfilesBundleSigningKey.
+//
+// Parameters:
+//   - bundle: The bundle to marshal
+//   - signer: The private key to sign the bundle with, or nil to leave it unsigned
+//
+// Returns the JSON document of the bundle, or an error if it fails to be marshalled or signed.
+//
+// Example:
+//
+//	signer, err := profiles.ReadProfilesBundleSigningKey("bundle-signing.pem")
+//	if err != nil {
+//		// handle error
+//	}
+//	data, err := profiles.MarshalProfilesBundle(profiles.NewArkProfilesBundle(loadedProfiles), signer)
+func MarshalProfilesBundle(bundle *ArkProfilesBundle, signer crypto.Signer) ([]byte, error) {
+	if signer == nil {
+		return json.MarshalIndent(bundle, "", "    ")
+	}
+	payload, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signProfilesBundle(payload, signer)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&arkSignedProfilesBundle{Bundle: payload, Signature: signature}, "", "    ")
+}
+
+// UnmarshalProfilesBundle unmarshals a profiles bundle of the given format, verifying its signature with the given key if any.
+//
+// When a public key is given, the bundle must be signed by its private key. Otherwise, the
+// signature of signed bundles is returned without being verified.
+//
+// Parameters:
+//   - data: The document of the bundle
+//   - format: The format of the document
+//   - publicKey: The public key to verify the bundle with, or nil to skip the verification
+//
+// Returns the bundle and its signature, which is nil for unsigned bundles. Returns
+// ErrProfilesBundleNotSigned or ErrProfilesBundleSignatureInvalid if the verification fails,
+// or an error if the document fails to be parsed or holds profiles without a name.
+//
+// Example:
+//
+//	publicKey, err := profiles.ReadProfilesBundleVerificationKey("bundle-signing.pub")
+//	if err != nil {
+//		// handle error
+//	}
+//	bundle, _, err := profiles.UnmarshalProfilesBundle(data, profiles.ArkProfileFormatJSON, publicKey)
+func UnmarshalProfilesBundle(data []byte, format ArkProfileFormat, publicKey crypto.PublicKey) (*ArkProfilesBundle, *ArkProfilesBundleSignature, error) {
+	jsonData, err := profileDataToJSON(data, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	var signed arkSignedProfilesBundle
+	if err := json.Unmarshal(jsonData, &signed); err != nil {
+		return nil, nil, err
+	}
+	payload := jsonData
+	if signed.Bundle != nil {
+		payload = signed.Bundle
+	}
+	if publicKey != nil {
+		if signed.Bundle == nil || signed.Signature == nil {
+			return nil, nil, ErrProfilesBundleNotSigned
+		}
+		if err := verifyProfilesBundle(payload, signed.Signature, publicKey); err != nil {
+			return nil, nil, err
+		}
+	}
+	var bundle ArkProfilesBundle
+	if err := json.Unmarshal(payload, &bundle); err != nil {
+		return nil, nil, err
+	}
+	if bundle.Version > ArkProfilesBundleVersion {
+		return nil, nil, fmt.Errorf("profiles bundle version [%d] is not supported", bundle.Version)
+	}
+	for _, profile := range bundle.Profiles {
+		if profile == nil || profile.ProfileName == "" {
+			return nil, nil, errors.New("profiles bundle holds a profile without a name")
+		}
+		if err := ValidateProfileName(profile.ProfileName); err != nil {
+			return nil, nil, fmt.Errorf("profiles bundle holds an invalid profile: %w", err)
+		}
+	}
+	return &bundle, signed.Signature, nil
+}
+
+// ReadProfilesBundleSigningKey reads a PEM encoded private key to sign profiles bundles with.
+//
+// PKCS#8 encoded Ed25519, ECDSA and RSA keys are supported, as well as PKCS#1 RSA keys and SEC 1 EC keys.
+//
+// Parameters:
+//   - path: The path of the PEM file of the private key
+//
+// Returns the private key, or an error if it fails to be read or parsed.
+//
+// Example:
+//
+//	signer, err := profiles.ReadProfilesBundleSigningKey("bundle-signing.pem")
+func ReadProfilesBundleSigningKey(path string) (crypto.Signer, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("failed to parse the private key in [%s]", path)
+}
+
+// ReadProfilesBundleVerificationKey reads a PEM encoded public key or certificate to verify profiles bundles with.
+//
+// PKIX encoded public keys, PKCS#1 RSA public keys and certificates are supported.
+//
+// Parameters:
+//   - path: The path of the PEM file of the public key or certificate
+//
+// Returns the public key, or an error if it fails to be read or parsed.
+//
+// Example:
+//
+//	publicKey, err := profiles.ReadProfilesBundleVerificationKey("bundle-signing.pub")
+func ReadProfilesBundleVerificationKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if certificate, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return certificate.PublicKey, nil
+	}
+	return nil, fmt.Errorf("failed to parse the public key in [%s]", path)
+}
+
+// readPEMBlock reads the first PEM block of a file.
+func readPEMBlock(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file [%s]: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found in [%s]", path)
+	}
+	return block, nil
+}
+
+// canonicalProfilesBundle returns the canonical JSON of a bundle, with sorted keys and without whitespace.
+func canonicalProfilesBundle(payload []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// profilesBundleKeyID returns the ID of a public key, the hex encoded SHA-256 of its DER encoding.
+func profilesBundleKeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// signProfilesBundle signs the canonical JSON of a bundle.
+func signProfilesBundle(payload []byte, signer crypto.Signer) (*ArkProfilesBundleSignature, error) {
+	canonical, err := canonicalProfilesBundle(payload)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := profilesBundleKeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(canonical)
+	var algorithm string
+	var value []byte
+	switch signer.Public().(type) {
+	case ed25519.PublicKey:
+		algorithm = ArkProfilesBundleAlgorithmEd25519
+		value, err = signer.Sign(rand.Reader, canonical, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		algorithm = ArkProfilesBundleAlgorithmECDSA
+		value, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case *rsa.PublicKey:
+		algorithm = ArkProfilesBundleAlgorithmRSA
+		value, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", signer.Public())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ArkProfilesBundleSignature{
+		Algorithm: algorithm,
+		KeyID:     keyID,
+		Value:     base64.StdEncoding.EncodeToString(value),
+	}, nil
+}
+
+// verifyProfilesBundle verifies the signature of the canonical JSON of a bundle with the given public key.
+func verifyProfilesBundle(payload []byte, signature *ArkProfilesBundleSignature, publicKey crypto.PublicKey) error {
+	keyID, err := profilesBundleKeyID(publicKey)
+	if err != nil {
+		return err
+	}
+	if signature.KeyID != keyID {
+		return fmt.Errorf("%w: signed by key [%s], not by key [%s]", ErrProfilesBundleSignatureInvalid, signature.KeyID, keyID)
+	}
+	value, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrProfilesBundleSignatureInvalid, err)
+	}
+	canonical, err := canonicalProfilesBundle(payload)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(canonical)
+	verified := false
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		verified = signature.Algorithm == ArkProfilesBundleAlgorithmEd25519 && ed25519.Verify(key, canonical, value)
+	case *ecdsa.PublicKey:
+		verified = signature.Algorithm == ArkProfilesBundleAlgorithmECDSA && ecdsa.VerifyASN1(key, digest[:], value)
+	case *rsa.PublicKey:
+		verified = signature.Algorithm == ArkProfilesBundleAlgorithmRSA && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], value) == nil
+	default:
+		return fmt.Errorf("unsupported verification key type %T", publicKey)
+	}
+	if !verified {
+		return ErrProfilesBundleSignatureInvalid
+	}
+	return nil
+}
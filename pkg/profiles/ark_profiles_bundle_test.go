@@ -0,0 +1,168 @@
+package profiles
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/models/auth"
+)
+
+// writeTestSigningKeys writes a PKCS#8 private key and its PKIX public key as PEM files, and returns their paths.
+func writeTestSigningKeys(t *testing.T, signer crypto.Signer) (string, string) {
+	t.Helper()
+	folder := t.TempDir()
+	privateDER, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	privatePath := filepath.Join(folder, "signing.pem")
+	publicPath := filepath.Join(folder, "signing.pub")
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600); err != nil {
+		t.Fatalf("Failed to write private key: %v", err)
+	}
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0644); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+	return privatePath, publicPath
+}
+
+// testBundleProfiles returns the profiles of the test bundles.
+func testBundleProfiles() []*models.ArkProfile {
+	return []*models.ArkProfile{
+		{
+			ProfileName:        "base",
+			ProfileDescription: "Base <profile> & co",
+			AuthProfiles: map[string]*auth.ArkAuthProfile{
+				"isp": {Username: "tina@cyberark.cloud", AuthMethod: auth.Identity, AuthMethodSettings: &auth.IdentityArkAuthMethodSettings{IdentityTenantSubdomain: "tenant"}},
+			},
+		},
+		{ProfileName: "child", Extends: "base"},
+	}
+}
+
+func TestMarshalProfilesBundle_Signed(t *testing.T) {
+	_, ed25519Key, _ := ed25519.GenerateKey(rand.Reader)
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	tests := []struct {
+		name              string
+		signer            crypto.Signer
+		expectedAlgorithm string
+	}{
+		{name: "success_ed25519", signer: ed25519Key, expectedAlgorithm: ArkProfilesBundleAlgorithmEd25519},
+		{name: "success_ecdsa", signer: ecdsaKey, expectedAlgorithm: ArkProfilesBundleAlgorithmECDSA},
+		{name: "success_rsa", signer: rsaKey, expectedAlgorithm: ArkProfilesBundleAlgorithmRSA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privatePath, publicPath := writeTestSigningKeys(t, tt.signer)
+			signer, err := ReadProfilesBundleSigningKey(privatePath)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			publicKey, err := ReadProfilesBundleVerificationKey(publicPath)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			data, err := MarshalProfilesBundle(NewArkProfilesBundle(testBundleProfiles()), signer)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			bundle, signature, err := UnmarshalProfilesBundle(data, ArkProfileFormatJSON, publicKey)
+			if err != nil {
+				t.Fatalf("Expected the bundle to be verified, got %v", err)
+			}
+			if signature == nil || signature.Algorithm != tt.expectedAlgorithm {
+				t.Errorf("Expected a %s signature, got %+v", tt.expectedAlgorithm, signature)
+			}
+			if len(bundle.Profiles) != 2 || bundle.Profiles[1].Extends != "base" || bundle.Version != ArkProfilesBundleVersion {
+				t.Errorf("Expected the profiles of the bundle, got %+v", bundle)
+			}
+			if settings, ok := bundle.Profiles[0].AuthProfiles["isp"].AuthMethodSettings.(*auth.IdentityArkAuthMethodSettings); !ok || settings.IdentityTenantSubdomain != "tenant" {
+				t.Errorf("Expected the auth method settings of the profile, got %+v", bundle.Profiles[0].AuthProfiles["isp"])
+			}
+
+			var compacted bytes.Buffer
+			if err := json.Compact(&compacted, data); err != nil {
+				t.Fatalf("Failed to compact bundle: %v", err)
+			}
+			if _, _, err := UnmarshalProfilesBundle(compacted.Bytes(), ArkProfileFormatJSON, publicKey); err != nil {
+				t.Errorf("Expected the verification not to depend on the formatting, got %v", err)
+			}
+
+			tampered := bytes.Replace(data, []byte("tina@cyberark.cloud"), []byte("mallory@cyberark.cloud"), 1)
+			if _, _, err := UnmarshalProfilesBundle(tampered, ArkProfileFormatJSON, publicKey); !errors.Is(err, ErrProfilesBundleSignatureInvalid) {
+				t.Errorf("Expected a tampered bundle to be rejected, got %v", err)
+			}
+
+			_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+			if _, _, err := UnmarshalProfilesBundle(data, ArkProfileFormatJSON, otherKey.Public()); !errors.Is(err, ErrProfilesBundleSignatureInvalid) {
+				t.Errorf("Expected a bundle signed by another key to be rejected, got %v", err)
+			}
+
+			if _, unverified, err := UnmarshalProfilesBundle(data, ArkProfileFormatJSON, nil); err != nil || unverified == nil {
+				t.Errorf("Expected the signature to be returned without verification, got %v, %v", unverified, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalProfilesBundle_Unsigned(t *testing.T) {
+	data, err := MarshalProfilesBundle(NewArkProfilesBundle(testBundleProfiles()), nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	bundle, signature, err := UnmarshalProfilesBundle(data, ArkProfileFormatJSON, nil)
+	if err != nil || signature != nil || len(bundle.Profiles) != 2 {
+		t.Errorf("Expected the unsigned bundle, got %+v, %+v, %v", bundle, signature, err)
+	}
+
+	_, publicKey, _ := ed25519.GenerateKey(rand.Reader)
+	if _, _, err := UnmarshalProfilesBundle(data, ArkProfileFormatJSON, publicKey); !errors.Is(err, ErrProfilesBundleNotSigned) {
+		t.Errorf("Expected an unsigned bundle to be rejected when verified, got %v", err)
+	}
+
+	yamlBundle := []byte("profiles:\n  - profile_name: first\n  - profile_name: second\n    extends: first\n")
+	bundle, _, err = UnmarshalProfilesBundle(yamlBundle, ArkProfileFormatYAML, nil)
+	if err != nil || len(bundle.Profiles) != 2 || bundle.Profiles[1].Extends != "first" {
+		t.Errorf("Expected the YAML bundle, got %+v, %v", bundle, err)
+	}
+
+	if _, _, err := UnmarshalProfilesBundle([]byte(`{"profiles": [{"profile_description": "no name"}]}`), ArkProfileFormatJSON, nil); err == nil {
+		t.Error("Expected an error for a profile without a name")
+	}
+	for _, profileName := range []string{"../../.bashrc", "/etc/passwd", "team/prod", ".."} {
+		data, err := MarshalProfilesBundle(NewArkProfilesBundle([]*models.ArkProfile{{ProfileName: profileName}}), nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, _, err := UnmarshalProfilesBundle(data, ArkProfileFormatJSON, nil); err == nil {
+			t.Errorf("Expected an error for a profile named %q", profileName)
+		}
+	}
+	if _, _, err := UnmarshalProfilesBundle([]byte("profiles:\n  - profile_name: ../escaped\n"), ArkProfileFormatYAML, nil); err == nil {
+		t.Error("Expected an error for a YAML profile with a path as its name")
+	}
+	if _, _, err := UnmarshalProfilesBundle([]byte(`{"version": 99, "profiles": []}`), ArkProfileFormatJSON, nil); err == nil {
+		t.Error("Expected an error for an unsupported version")
+	}
+}
@@ -0,0 +1,123 @@
+package profiles
+
+import (
+	"crypto"
+	"errors"
+	"os"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+)
+
+// Environment variables making DefaultProfilesLoader read the profiles from a single bundle file.
+const (
+	ArkProfilesFileEnvVar          = "ARK_PROFILES_FILE"
+	ArkProfilesFilePublicKeyEnvVar = "ARK_PROFILES_FILE_PUBLIC_KEY"
+)
+
+// ErrProfilesLoaderReadOnly is returned when modifying the profiles of a read-only loader.
+var ErrProfilesLoaderReadOnly = errors.New("profiles are read-only")
+
+// ReadOnlyFileProfilesLoader is a read-only ProfileLoader reading the profiles of a single bundle file.
+//
+// The file is a profiles bundle, see ArkProfilesBundle, in JSON, YAML or TOML according to its
+// extension. It is meant to distribute the same profiles to many machines as a single artifact,
+// and is read again on every load so that updates of the file apply immediately. When a public
+// key file is given, the bundle must be signed by its private key, and profiles are not loaded
+// otherwise. Saving and deleting profiles fails with ErrProfilesLoaderReadOnly.
+type ReadOnlyFileProfilesLoader struct {
+	path          string
+	publicKeyPath string
+}
+
+// NewReadOnlyFileProfilesLoader creates a read-only profile loader of the given bundle file.
+//
+// Parameters:
+//   - path: The path of the bundle file
+//   - publicKeyPath: The path of the PEM file of the public key the bundle must be signed with, optional
+//
+// Returns the loader.
+//
+// Example:
+//
+//	var loader profiles.ProfileLoader = profiles.NewReadOnlyFileProfilesLoader("/etc/ark/profiles.json", "/etc/ark/profiles.pub")
+//	profile, err := loader.LoadProfile("production")
+func NewReadOnlyFileProfilesLoader(path string, publicKeyPath string) *ReadOnlyFileProfilesLoader {
+	return &ReadOnlyFileProfilesLoader{path: path, publicKeyPath: publicKeyPath}
+}
+
+// loadBundle reads and verifies the bundle file.
+func (l *ReadOnlyFileProfilesLoader) loadBundle() (*ArkProfilesBundle, error) {
+	var publicKey crypto.PublicKey
+	if l.publicKeyPath != "" {
+		var err error
+		if publicKey, err = ReadProfilesBundleVerificationKey(l.publicKeyPath); err != nil {
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil, err
+	}
+	bundle, _, err := UnmarshalProfilesBundle(data, ProfileFormatFromPath(l.path), publicKey)
+	return bundle, err
+}
+
+// LoadDefaultProfile loads the effective profile of the name deduced by DeduceProfileName.
+//
+// Returns an empty profile if the profile does not exist, as FileSystemProfilesLoader does.
+func (l *ReadOnlyFileProfilesLoader) LoadDefaultProfile() (*models.ArkProfile, error) {
+	profile, err := LoadEffectiveProfile(l, DeduceProfileName(""))
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return &models.ArkProfile{}, nil
+	}
+	return profile, nil
+}
+
+// LoadProfile loads the profile of the given name from the bundle, or returns nil if it does not exist.
+func (l *ReadOnlyFileProfilesLoader) LoadProfile(profileName string) (*models.ArkProfile, error) {
+	bundle, err := l.loadBundle()
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range bundle.Profiles {
+		if profile.ProfileName == profileName {
+			return profile, nil
+		}
+	}
+	return nil, nil
+}
+
+// LoadAllProfiles loads all the profiles of the bundle, in their order in the bundle.
+func (l *ReadOnlyFileProfilesLoader) LoadAllProfiles() ([]*models.ArkProfile, error) {
+	bundle, err := l.loadBundle()
+	if err != nil {
+		return nil, err
+	}
+	return bundle.Profiles, nil
+}
+
+// SaveProfile fails with ErrProfilesLoaderReadOnly.
+func (l *ReadOnlyFileProfilesLoader) SaveProfile(profile *models.ArkProfile) error {
+	return ErrProfilesLoaderReadOnly
+}
+
+// DeleteProfile fails with ErrProfilesLoaderReadOnly.
+func (l *ReadOnlyFileProfilesLoader) DeleteProfile(profileName string) error {
+	return ErrProfilesLoaderReadOnly
+}
+
+// ClearAllProfiles fails with ErrProfilesLoaderReadOnly.
+func (l *ReadOnlyFileProfilesLoader) ClearAllProfiles() error {
+	return ErrProfilesLoaderReadOnly
+}
+
+// ProfileExists checks if the bundle holds a profile of the given name.
+//
+// Returns false if the bundle fails to be read or verified.
+func (l *ReadOnlyFileProfilesLoader) ProfileExists(profileName string) bool {
+	profile, err := l.LoadProfile(profileName)
+	return err == nil && profile != nil
+}
@@ -0,0 +1,73 @@
+package profiles
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
+)
+
+func TestReadOnlyFileProfilesLoader(t *testing.T) {
+	_, signingKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, publicKeyPath := writeTestSigningKeys(t, signingKey)
+	data, err := MarshalProfilesBundle(NewArkProfilesBundle(testBundleProfiles()), signingKey)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "profiles.json")
+	if err := os.WriteFile(bundlePath, data, 0644); err != nil {
+		t.Fatalf("Failed to write bundle: %v", err)
+	}
+
+	loader := NewReadOnlyFileProfilesLoader(bundlePath, publicKeyPath)
+	all, err := loader.LoadAllProfiles()
+	if err != nil || len(all) != 2 || all[0].ProfileName != "base" || all[1].ProfileName != "child" {
+		t.Fatalf("Expected the profiles of the bundle in order, got %v, %v", all, err)
+	}
+	if !loader.ProfileExists("child") || loader.ProfileExists("missing") {
+		t.Error("Expected only the profiles of the bundle to exist")
+	}
+
+	t.Setenv("ARK_PROFILE", "child")
+	profile, err := loader.LoadDefaultProfile()
+	if err != nil || profile.ProfileName != "child" || profile.AuthProfiles["isp"] == nil {
+		t.Errorf("Expected the effective child profile, got %+v, %v", profile, err)
+	}
+
+	if err := loader.SaveProfile(&models.ArkProfile{ProfileName: "new"}); !errors.Is(err, ErrProfilesLoaderReadOnly) {
+		t.Errorf("Expected saving to fail, got %v", err)
+	}
+	if err := loader.DeleteProfile("base"); !errors.Is(err, ErrProfilesLoaderReadOnly) {
+		t.Errorf("Expected deleting to fail, got %v", err)
+	}
+	if err := loader.ClearAllProfiles(); !errors.Is(err, ErrProfilesLoaderReadOnly) {
+		t.Errorf("Expected clearing to fail, got %v", err)
+	}
+
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherPublicKeyPath := writeTestSigningKeys(t, otherKey)
+	if _, err := NewReadOnlyFileProfilesLoader(bundlePath, otherPublicKeyPath).LoadProfile("base"); !errors.Is(err, ErrProfilesBundleSignatureInvalid) {
+		t.Errorf("Expected a bundle signed by another key to be rejected, got %v", err)
+	}
+}
+
+func TestDefaultProfilesLoader_ProfilesFile(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(bundlePath, []byte("profiles:\n  - profile_name: fleet\n"), 0644); err != nil {
+		t.Fatalf("Failed to write bundle: %v", err)
+	}
+	t.Setenv(ArkProfilesFileEnvVar, bundlePath)
+	t.Setenv(ArkProfilesFilePublicKeyEnvVar, "")
+
+	loader := DefaultProfilesLoader()
+	if _, ok := (*loader).(*ReadOnlyFileProfilesLoader); !ok {
+		t.Fatalf("Expected a read-only file loader, got %T", *loader)
+	}
+	if profile, err := (*loader).LoadProfile("fleet"); err != nil || profile == nil {
+		t.Errorf("Expected the profile of the bundle file, got %v, %v", profile, err)
+	}
+}
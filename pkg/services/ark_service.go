@@ -1,20 +1,27 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"slices"
+	"sync"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ArkServiceConfig defines the configuration for an Ark service.
+//
+// RedactedModels lists the models of the service with fields tagged `redact:"true"`,
+// whose keys are redacted from logs and errors once the service is registered.
 type ArkServiceConfig struct {
 	ServiceName                string
 	RequiredAuthenticatorNames []string
 	OptionalAuthenticatorNames []string
 	ActionsConfigurations      map[actions.ArkServiceActionType][]actions.ArkServiceActionDefinition
+	RedactedModels             []interface{}
 }
 
 // ArkService is an interface that defines the methods for an Ark service.
@@ -27,6 +34,8 @@ type ArkBaseService struct {
 	Service        ArkService
 	Logger         *common.ArkLogger
 	authenticators []auth.ArkAuth
+	clients        []*common.ArkClient
+	clientsMutex   sync.Mutex
 }
 
 // NewArkBaseService creates a new instance of ArkBaseService with the provided service and authenticators.
@@ -71,6 +80,39 @@ func (s *ArkBaseService) Authenticator(authName string) (auth.ArkAuth, error) {
 	return nil, fmt.Errorf("%s Failed to find authenticator %s", s.Service.ServiceConfig().ServiceName, authName)
 }
 
+// StartSpan starts the tracing span of a call to the given method of the service, see common.StartArkServiceSpan.
+func (s *ArkBaseService) StartSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return common.StartArkServiceSpan(ctx, s.Service.ServiceConfig().ServiceName, method)
+}
+
+// AddClient registers a client of the service, so that it is released when the service is closed.
+func (s *ArkBaseService) AddClient(client *common.ArkClient) {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+	s.clients = append(s.clients, client)
+}
+
+// clientAttacher is implemented by authenticators that apply the tokens they renew to attached clients, such as auth.ArkAuthBase.
+type clientAttacher interface {
+	DetachClient(client *common.ArkClient)
+}
+
+// Close releases the idle connections of the clients of the service and wipes their tokens.
+// The clients are detached from the authenticators of the service, so renewed tokens are no longer applied to them.
+func (s *ArkBaseService) Close() error {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+	for _, client := range s.clients {
+		for _, authenticator := range s.authenticators {
+			if attacher, ok := authenticator.(clientAttacher); ok {
+				attacher.DetachClient(client)
+			}
+		}
+		client.Close()
+	}
+	return nil
+}
+
 // HasAuthenticator checks if the ArkBaseService has an authenticator with the specified name.
 func (s *ArkBaseService) HasAuthenticator(authName string) bool {
 	for _, authenticator := range s.authenticators {
@@ -92,6 +134,7 @@ func Register(serviceConfig ArkServiceConfig, topLevel bool) error {
 		return fmt.Errorf("service %s already registered", serviceConfig.ServiceName)
 	}
 	serviceRegistry[serviceConfig.ServiceName] = serviceConfig
+	common.RegisterArkRedactedModels(serviceConfig.RedactedModels...)
 	if topLevel {
 		topLevelServices = append(topLevelServices, serviceConfig.ServiceName)
 	}
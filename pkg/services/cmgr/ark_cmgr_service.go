@@ -65,6 +65,7 @@ func NewArkCmgrService(authenticators ...auth.ArkAuth) (*ArkCmgrService, error)
 		return nil, err
 	}
 	cmgrService.client = client
+	baseService.AddClient(client.ArkClient)
 	cmgrService.ispAuth = ispAuth
 	cmgrService.ArkBaseService = baseService
 	return cmgrService, nil
@@ -79,107 +80,109 @@ func (s *ArkCmgrService) refreshCmgrAuth(client *common.ArkClient) error {
 }
 
 func listCommonPools[PageItemType any](
+	ctx context.Context,
 	logger *common.ArkLogger,
 	client *isp.ArkISPServiceClient,
 	name string, route string,
 	commonFilter *cmgrmodels.ArkCmgrPoolsCommonFilter,
-	idMappings map[string]string) (<-chan *common.ArkPage[PageItemType], error) {
+	idMappings map[string]string) common.ArkPageIterator[PageItemType] {
 	logger.Info("Listing %s", name)
-	pageChannel := make(chan *common.ArkPage[PageItemType])
-	go func() {
-		defer close(pageChannel)
-		filters := map[string]string{
-			"projection": "EXTENDED",
+	filters := map[string]string{
+		"projection": "EXTENDED",
+	}
+	if commonFilter != nil {
+		if commonFilter.Filter != "" {
+			filters["filter"] = commonFilter.Filter
 		}
-		if commonFilter != nil {
-			if commonFilter.Filter != "" {
-				filters["filter"] = commonFilter.Filter
-			}
-			if commonFilter.Order != "" {
-				filters["order"] = commonFilter.Order
-			}
-			if commonFilter.PageSize != 0 {
-				filters["pageSize"] = fmt.Sprintf("%d", commonFilter.PageSize)
-			}
-			if commonFilter.Sort != "" {
-				filters["sort"] = commonFilter.Sort
-			}
-			if commonFilter.Projection != "" {
-				filters["projection"] = commonFilter.Projection
-			}
+		if commonFilter.Order != "" {
+			filters["order"] = commonFilter.Order
 		}
-		var contToken string
-		for {
-			if contToken != "" {
-				filters["continuation_token"] = contToken
-			}
-			response, err := client.Get(context.Background(), route, filters)
-			if err != nil {
-				logger.Error("Failed to list %s: %v", name, err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				logger.Error("Failed to list %s - [%d] - [%s]", name, response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
+		if commonFilter.PageSize != 0 {
+			filters["pageSize"] = fmt.Sprintf("%d", commonFilter.PageSize)
+		}
+		if commonFilter.Sort != "" {
+			filters["sort"] = commonFilter.Sort
+		}
+		if commonFilter.Projection != "" {
+			filters["projection"] = commonFilter.Projection
+		}
+	}
+	return common.NewArkCursorPageIterator(ctx, func(ctx context.Context, contToken string) (*common.ArkPage[PageItemType], string, error) {
+		query := make(map[string]string, len(filters)+1)
+		for key, value := range filters {
+			query[key] = value
+		}
+		if contToken != "" {
+			query["continuation_token"] = contToken
+		}
+		response, err := client.Get(ctx, route, query)
+		if err != nil {
+			return nil, "", err
+		}
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
 			if err != nil {
-				logger.Error("Failed to decode response for %s: %v", name, err)
-				return
+				common.GlobalLogger.Warning("Error closing response body")
 			}
-			resultMap := result.(map[string]interface{})
-			if idMappings != nil && len(idMappings) > 0 {
-				for _, resourceItem := range resultMap["resources"].([]interface{}) {
-					for key, value := range idMappings {
-						if _, ok := resourceItem.(map[string]interface{})[key]; ok {
-							resourceItem.(map[string]interface{})[value] = resourceItem.(map[string]interface{})[key]
-						}
+		}(response.Body)
+		if response.StatusCode != http.StatusOK {
+			return nil, "", common.NewArkAPIError(response, fmt.Sprintf("failed to list %s", name))
+		}
+		result, err := common.DeserializeJSONSnake(response.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decode response for %s: %w", name, err)
+		}
+		resultMap := result.(map[string]interface{})
+		if idMappings != nil && len(idMappings) > 0 {
+			for _, resourceItem := range resultMap["resources"].([]interface{}) {
+				for key, value := range idMappings {
+					if _, ok := resourceItem.(map[string]interface{})[key]; ok {
+						resourceItem.(map[string]interface{})[value] = resourceItem.(map[string]interface{})[key]
 					}
-					if _, ok := resourceItem.(map[string]interface{})["assigned_pools"]; ok {
-						for _, pool := range resourceItem.(map[string]interface{})["assigned_pools"].([]interface{}) {
-							pool.(map[string]interface{})["pool_id"] = pool.(map[string]interface{})["id"]
-						}
+				}
+				if _, ok := resourceItem.(map[string]interface{})["assigned_pools"]; ok {
+					for _, pool := range resourceItem.(map[string]interface{})["assigned_pools"].([]interface{}) {
+						pool.(map[string]interface{})["pool_id"] = pool.(map[string]interface{})["id"]
 					}
 				}
 			}
+		}
 
-			var items []*PageItemType
-			err = mapstructure.Decode(resultMap["resources"], &items)
-			if err != nil {
-				logger.Error("Failed to decode resources for %s: %v", name, err)
-				return
-			}
-			pageChannel <- &common.ArkPage[PageItemType]{Items: items}
-			pageInfo, ok := resultMap["page"].(map[string]interface{})
-			if !ok || pageInfo["continuation_token"] == nil || pageInfo["continuation_token"] == "" {
-				break
-			}
-			contToken = pageInfo["continuation_token"].(string)
-			if totalResources, ok := pageInfo["total_resources_count"].(float64); ok {
-				if pageSize, ok := pageInfo["page_size"].(float64); ok && totalResources == pageSize {
-					break
-				}
+		var items []*PageItemType
+		err = mapstructure.Decode(resultMap["resources"], &items)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decode resources for %s: %w", name, err)
+		}
+		page := &common.ArkPage[PageItemType]{Items: items}
+		pageInfo, ok := resultMap["page"].(map[string]interface{})
+		if !ok || pageInfo["continuation_token"] == nil || pageInfo["continuation_token"] == "" {
+			return page, "", nil
+		}
+		if totalResources, ok := pageInfo["total_resources_count"].(float64); ok {
+			if pageSize, ok := pageInfo["page_size"].(float64); ok && totalResources == pageSize {
+				return page, "", nil
 			}
 		}
-	}()
-	return pageChannel, nil
+		return page, pageInfo["continuation_token"].(string), nil
+	})
 }
 
 // AddNetwork adds a new network to the connector management service.
 func (s *ArkCmgrService) AddNetwork(addNetwork *cmgrmodels.ArkCmgrAddNetwork) (*cmgrmodels.ArkCmgrNetwork, error) {
+	return s.AddNetworkWithContext(context.Background(), addNetwork)
+}
+
+// AddNetworkWithContext is AddNetwork with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) AddNetworkWithContext(ctx context.Context, addNetwork *cmgrmodels.ArkCmgrAddNetwork) (*cmgrmodels.ArkCmgrNetwork, error) {
+	ctx, span := s.StartSpan(ctx, "AddNetwork")
+	defer span.End()
 	s.Logger.Info("Adding network [%s]", addNetwork.Name)
 	var addNetworkJSON map[string]interface{}
 	err := mapstructure.Decode(addNetwork, &addNetworkJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Post(context.Background(), networksURL, addNetworkJSON)
+	response, err := s.client.Post(ctx, networksURL, addNetworkJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +193,7 @@ func (s *ArkCmgrService) AddNetwork(addNetwork *cmgrmodels.ArkCmgrAddNetwork) (*
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add network - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add network")
 	}
 	networkJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -208,17 +211,24 @@ func (s *ArkCmgrService) AddNetwork(addNetwork *cmgrmodels.ArkCmgrAddNetwork) (*
 
 // UpdateNetwork updates an existing network in the connector management service.
 func (s *ArkCmgrService) UpdateNetwork(updateNetwork *cmgrmodels.ArkCmgrUpdateNetwork) (*cmgrmodels.ArkCmgrNetwork, error) {
+	return s.UpdateNetworkWithContext(context.Background(), updateNetwork)
+}
+
+// UpdateNetworkWithContext is UpdateNetwork with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) UpdateNetworkWithContext(ctx context.Context, updateNetwork *cmgrmodels.ArkCmgrUpdateNetwork) (*cmgrmodels.ArkCmgrNetwork, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateNetwork")
+	defer span.End()
 	s.Logger.Info("Updating network [%s]", updateNetwork.NetworkID)
 	if updateNetwork.Name == "" {
 		s.Logger.Info("Nothing to update")
-		return s.Network(&cmgrmodels.ArkCmgrGetNetwork{NetworkID: updateNetwork.NetworkID})
+		return s.NetworkWithContext(ctx, &cmgrmodels.ArkCmgrGetNetwork{NetworkID: updateNetwork.NetworkID})
 	}
 	var updateNetworkJSON map[string]interface{}
 	err := mapstructure.Decode(updateNetwork, &updateNetworkJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Patch(context.Background(), fmt.Sprintf(networkURL, updateNetwork.NetworkID), updateNetworkJSON)
+	response, err := s.client.Patch(ctx, fmt.Sprintf(networkURL, updateNetwork.NetworkID), updateNetworkJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +239,7 @@ func (s *ArkCmgrService) UpdateNetwork(updateNetwork *cmgrmodels.ArkCmgrUpdateNe
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update network - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update network")
 	}
 	networkJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -247,51 +257,89 @@ func (s *ArkCmgrService) UpdateNetwork(updateNetwork *cmgrmodels.ArkCmgrUpdateNe
 
 // DeleteNetwork deletes an existing network from the connector management service.
 func (s *ArkCmgrService) DeleteNetwork(deleteNetwork *cmgrmodels.ArkCmgrDeleteNetwork) error {
+	return s.DeleteNetworkWithContext(context.Background(), deleteNetwork)
+}
+
+// DeleteNetworkWithContext is DeleteNetwork with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) DeleteNetworkWithContext(ctx context.Context, deleteNetwork *cmgrmodels.ArkCmgrDeleteNetwork) error {
+	ctx, span := s.StartSpan(ctx, "DeleteNetwork")
+	defer span.End()
 	s.Logger.Info("Deleting network [%s]", deleteNetwork.NetworkID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(networkURL, deleteNetwork.NetworkID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(networkURL, deleteNetwork.NetworkID), nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete network - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete network")
 	}
 	return nil
 }
 
 // ListNetworks lists all networks in the connector management service.
 func (s *ArkCmgrService) ListNetworks() (<-chan *ArkCmgrNetworkPage, error) {
-	s.Logger.Info("Listing all networks")
-	return listCommonPools[cmgrmodels.ArkCmgrNetwork](
-		s.Logger,
-		s.client,
-		"networks",
-		networksURL,
-		nil,
-		map[string]string{
-			"id": "network_id",
-		},
-	)
+	return s.ListNetworksWithContext(context.Background())
+}
+
+// ListNetworksWithContext is ListNetworks with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListNetworksWithContext(ctx context.Context) (<-chan *ArkCmgrNetworkPage, error) {
+	return common.ArkPageChannel(ctx, s.ListNetworksIter(ctx), s.Logger), nil
+}
+
+// ListNetworksIter returns an iterator over all the networks pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListNetworksIter(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrNetwork] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListNetworks", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrNetwork] {
+		s.Logger.Info("Listing all networks")
+		return listCommonPools[cmgrmodels.ArkCmgrNetwork](ctx,
+			s.Logger,
+			s.client,
+			"networks",
+			networksURL,
+			nil,
+			map[string]string{
+				"id": "network_id",
+			},
+		)
+	})
 }
 
 // ListNetworksBy lists networks by the specified filter in the connector management service.
 func (s *ArkCmgrService) ListNetworksBy(networksFilter *cmgrmodels.ArkCmgrNetworksFilter) (<-chan *ArkCmgrNetworkPage, error) {
-	s.Logger.Info("Listing networks by filter [%v]", networksFilter)
-	return listCommonPools[cmgrmodels.ArkCmgrNetwork](
-		s.Logger,
-		s.client,
-		"networks",
-		networksURL,
-		&networksFilter.ArkCmgrPoolsCommonFilter,
-		map[string]string{
-			"id": "network_id",
-		},
-	)
+	return s.ListNetworksByWithContext(context.Background(), networksFilter)
+}
+
+// ListNetworksByWithContext is ListNetworksBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListNetworksByWithContext(ctx context.Context, networksFilter *cmgrmodels.ArkCmgrNetworksFilter) (<-chan *ArkCmgrNetworkPage, error) {
+	return common.ArkPageChannel(ctx, s.ListNetworksByIter(ctx, networksFilter), s.Logger), nil
+}
+
+// ListNetworksByIter returns an iterator over the networks pages filtered by the given filter, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListNetworksByIter(ctx context.Context, networksFilter *cmgrmodels.ArkCmgrNetworksFilter) common.ArkPageIterator[cmgrmodels.ArkCmgrNetwork] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListNetworksBy", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrNetwork] {
+		s.Logger.Info("Listing networks by filter [%v]", networksFilter)
+		return listCommonPools[cmgrmodels.ArkCmgrNetwork](ctx,
+			s.Logger,
+			s.client,
+			"networks",
+			networksURL,
+			&networksFilter.ArkCmgrPoolsCommonFilter,
+			map[string]string{
+				"id": "network_id",
+			},
+		)
+	})
 }
 
 // Network retrieves a specific network by its ID from the connector management service.
 func (s *ArkCmgrService) Network(getNetwork *cmgrmodels.ArkCmgrGetNetwork) (*cmgrmodels.ArkCmgrNetwork, error) {
+	return s.NetworkWithContext(context.Background(), getNetwork)
+}
+
+// NetworkWithContext is Network with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) NetworkWithContext(ctx context.Context, getNetwork *cmgrmodels.ArkCmgrGetNetwork) (*cmgrmodels.ArkCmgrNetwork, error) {
+	ctx, span := s.StartSpan(ctx, "Network")
+	defer span.End()
 	s.Logger.Info("Retrieving network [%s]", getNetwork.NetworkID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(networkURL, getNetwork.NetworkID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(networkURL, getNetwork.NetworkID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -302,7 +350,7 @@ func (s *ArkCmgrService) Network(getNetwork *cmgrmodels.ArkCmgrGetNetwork) (*cmg
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve network - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve network")
 	}
 	networkJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -320,17 +368,18 @@ func (s *ArkCmgrService) Network(getNetwork *cmgrmodels.ArkCmgrGetNetwork) (*cmg
 
 // NetworksStats retrieves statistics about networks in the connector management service.
 func (s *ArkCmgrService) NetworksStats() (*cmgrmodels.ArkCmgrNetworksStats, error) {
+	return s.NetworksStatsWithContext(context.Background())
+}
+
+// NetworksStatsWithContext is NetworksStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) NetworksStatsWithContext(ctx context.Context) (*cmgrmodels.ArkCmgrNetworksStats, error) {
+	ctx, span := s.StartSpan(ctx, "NetworksStats")
+	defer span.End()
 	s.Logger.Info("Retrieving networks stats")
-	networksChan, err := s.ListNetworks()
+	networks, err := common.CollectArkPageItems(s.ListNetworksIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	networks := make([]*cmgrmodels.ArkCmgrNetwork, 0)
-	for page := range networksChan {
-		for _, network := range page.Items {
-			networks = append(networks, network)
-		}
-	}
 	var networksStats cmgrmodels.ArkCmgrNetworksStats
 	networksStats.NetworksCount = len(networks)
 	networksStats.PoolsCountPerNetwork = make(map[string]int)
@@ -342,6 +391,13 @@ func (s *ArkCmgrService) NetworksStats() (*cmgrmodels.ArkCmgrNetworksStats, erro
 
 // AddPool adds a new pool to the connector management service.
 func (s *ArkCmgrService) AddPool(addPool *cmgrmodels.ArkCmgrAddPool) (*cmgrmodels.ArkCmgrPool, error) {
+	return s.AddPoolWithContext(context.Background(), addPool)
+}
+
+// AddPoolWithContext is AddPool with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) AddPoolWithContext(ctx context.Context, addPool *cmgrmodels.ArkCmgrAddPool) (*cmgrmodels.ArkCmgrPool, error) {
+	ctx, span := s.StartSpan(ctx, "AddPool")
+	defer span.End()
 	s.Logger.Info("Adding pool [%s]", addPool.Name)
 	var addPoolJSON map[string]interface{}
 	err := mapstructure.Decode(addPool, &addPoolJSON)
@@ -351,7 +407,7 @@ func (s *ArkCmgrService) AddPool(addPool *cmgrmodels.ArkCmgrAddPool) (*cmgrmodel
 	if addPool.AssignedNetworkIDs == nil || len(addPool.AssignedNetworkIDs) == 0 {
 		return nil, fmt.Errorf("no networks assigned to the pool")
 	}
-	response, err := s.client.Post(context.Background(), poolsURL, addPoolJSON)
+	response, err := s.client.Post(ctx, poolsURL, addPoolJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -362,7 +418,7 @@ func (s *ArkCmgrService) AddPool(addPool *cmgrmodels.ArkCmgrAddPool) (*cmgrmodel
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add pool - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add pool")
 	}
 	poolJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -380,17 +436,24 @@ func (s *ArkCmgrService) AddPool(addPool *cmgrmodels.ArkCmgrAddPool) (*cmgrmodel
 
 // UpdatePool updates an existing pool in the connector management service.
 func (s *ArkCmgrService) UpdatePool(updatePool *cmgrmodels.ArkCmgrUpdatePool) (*cmgrmodels.ArkCmgrPool, error) {
+	return s.UpdatePoolWithContext(context.Background(), updatePool)
+}
+
+// UpdatePoolWithContext is UpdatePool with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) UpdatePoolWithContext(ctx context.Context, updatePool *cmgrmodels.ArkCmgrUpdatePool) (*cmgrmodels.ArkCmgrPool, error) {
+	ctx, span := s.StartSpan(ctx, "UpdatePool")
+	defer span.End()
 	s.Logger.Info("Updating pool [%s]", updatePool.PoolID)
 	if updatePool.Name == "" && updatePool.Description == "" && updatePool.AssignedNetworkIDs == nil {
 		s.Logger.Info("Nothing to update")
-		return s.Pool(&cmgrmodels.ArkCmgrGetPool{PoolID: updatePool.PoolID})
+		return s.PoolWithContext(ctx, &cmgrmodels.ArkCmgrGetPool{PoolID: updatePool.PoolID})
 	}
 	var updatePoolJSON map[string]interface{}
 	err := mapstructure.Decode(updatePool, &updatePoolJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Patch(context.Background(), fmt.Sprintf(poolURL, updatePool.PoolID), updatePoolJSON)
+	response, err := s.client.Patch(ctx, fmt.Sprintf(poolURL, updatePool.PoolID), updatePoolJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -401,7 +464,7 @@ func (s *ArkCmgrService) UpdatePool(updatePool *cmgrmodels.ArkCmgrUpdatePool) (*
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update pool - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update pool")
 	}
 	poolJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -419,51 +482,89 @@ func (s *ArkCmgrService) UpdatePool(updatePool *cmgrmodels.ArkCmgrUpdatePool) (*
 
 // DeletePool deletes an existing pool from the connector management service.
 func (s *ArkCmgrService) DeletePool(deletePool *cmgrmodels.ArkCmgrDeletePool) error {
+	return s.DeletePoolWithContext(context.Background(), deletePool)
+}
+
+// DeletePoolWithContext is DeletePool with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) DeletePoolWithContext(ctx context.Context, deletePool *cmgrmodels.ArkCmgrDeletePool) error {
+	ctx, span := s.StartSpan(ctx, "DeletePool")
+	defer span.End()
 	s.Logger.Info("Deleting pool [%s]", deletePool.PoolID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(poolURL, deletePool.PoolID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(poolURL, deletePool.PoolID), nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete pool - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete pool")
 	}
 	return nil
 }
 
 // ListPools lists all pools in the connector management service.
 func (s *ArkCmgrService) ListPools() (<-chan *ArkCmgrPoolPage, error) {
-	s.Logger.Info("Listing all pools")
-	return listCommonPools[cmgrmodels.ArkCmgrPool](
-		s.Logger,
-		s.client,
-		"pools",
-		poolsURL,
-		nil,
-		map[string]string{
-			"id": "pool_id",
-		},
-	)
+	return s.ListPoolsWithContext(context.Background())
+}
+
+// ListPoolsWithContext is ListPools with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListPoolsWithContext(ctx context.Context) (<-chan *ArkCmgrPoolPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoolsIter(ctx), s.Logger), nil
+}
+
+// ListPoolsIter returns an iterator over all the pools pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListPoolsIter(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPool] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPools", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPool] {
+		s.Logger.Info("Listing all pools")
+		return listCommonPools[cmgrmodels.ArkCmgrPool](ctx,
+			s.Logger,
+			s.client,
+			"pools",
+			poolsURL,
+			nil,
+			map[string]string{
+				"id": "pool_id",
+			},
+		)
+	})
 }
 
 // ListPoolsBy lists pools by the specified filter in the connector management service.
 func (s *ArkCmgrService) ListPoolsBy(poolsFilter *cmgrmodels.ArkCmgrPoolsFilter) (<-chan *ArkCmgrPoolPage, error) {
-	s.Logger.Info("Listing pools by filter [%v]", poolsFilter)
-	return listCommonPools[cmgrmodels.ArkCmgrPool](
-		s.Logger,
-		s.client,
-		"pools",
-		poolsURL,
-		&poolsFilter.ArkCmgrPoolsCommonFilter,
-		map[string]string{
-			"id": "pool_id",
-		},
-	)
+	return s.ListPoolsByWithContext(context.Background(), poolsFilter)
+}
+
+// ListPoolsByWithContext is ListPoolsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListPoolsByWithContext(ctx context.Context, poolsFilter *cmgrmodels.ArkCmgrPoolsFilter) (<-chan *ArkCmgrPoolPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoolsByIter(ctx, poolsFilter), s.Logger), nil
+}
+
+// ListPoolsByIter returns an iterator over the pools pages filtered by the given filter, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListPoolsByIter(ctx context.Context, poolsFilter *cmgrmodels.ArkCmgrPoolsFilter) common.ArkPageIterator[cmgrmodels.ArkCmgrPool] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoolsBy", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPool] {
+		s.Logger.Info("Listing pools by filter [%v]", poolsFilter)
+		return listCommonPools[cmgrmodels.ArkCmgrPool](ctx,
+			s.Logger,
+			s.client,
+			"pools",
+			poolsURL,
+			&poolsFilter.ArkCmgrPoolsCommonFilter,
+			map[string]string{
+				"id": "pool_id",
+			},
+		)
+	})
 }
 
 // Pool retrieves a specific pool by its ID from the connector management service.
 func (s *ArkCmgrService) Pool(getPool *cmgrmodels.ArkCmgrGetPool) (*cmgrmodels.ArkCmgrPool, error) {
+	return s.PoolWithContext(context.Background(), getPool)
+}
+
+// PoolWithContext is Pool with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) PoolWithContext(ctx context.Context, getPool *cmgrmodels.ArkCmgrGetPool) (*cmgrmodels.ArkCmgrPool, error) {
+	ctx, span := s.StartSpan(ctx, "Pool")
+	defer span.End()
 	s.Logger.Info("Retrieving pool [%s]", getPool.PoolID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(poolURL, getPool.PoolID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(poolURL, getPool.PoolID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -474,7 +575,7 @@ func (s *ArkCmgrService) Pool(getPool *cmgrmodels.ArkCmgrGetPool) (*cmgrmodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve pool - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve pool")
 	}
 	poolJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -492,17 +593,18 @@ func (s *ArkCmgrService) Pool(getPool *cmgrmodels.ArkCmgrGetPool) (*cmgrmodels.A
 
 // PoolsStats retrieves statistics about pools in the connector management service.
 func (s *ArkCmgrService) PoolsStats() (*cmgrmodels.ArkCmgrPoolsStats, error) {
+	return s.PoolsStatsWithContext(context.Background())
+}
+
+// PoolsStatsWithContext is PoolsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) PoolsStatsWithContext(ctx context.Context) (*cmgrmodels.ArkCmgrPoolsStats, error) {
+	ctx, span := s.StartSpan(ctx, "PoolsStats")
+	defer span.End()
 	s.Logger.Info("Retrieving pools stats")
-	poolsChan, err := s.ListPools()
+	pools, err := common.CollectArkPageItems(s.ListPoolsIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	pools := make([]*cmgrmodels.ArkCmgrPool, 0)
-	for page := range poolsChan {
-		for _, pool := range page.Items {
-			pools = append(pools, pool)
-		}
-	}
 	var poolsStats cmgrmodels.ArkCmgrPoolsStats
 	poolsStats.PoolsCount = len(pools)
 	poolsStats.NetworksCountPerPool = make(map[string]int)
@@ -518,6 +620,13 @@ func (s *ArkCmgrService) PoolsStats() (*cmgrmodels.ArkCmgrPoolsStats, error) {
 
 // AddPoolIdentifier adds a new identifier to a specific pool in the connector management service.
 func (s *ArkCmgrService) AddPoolIdentifier(addPoolIdentifier *cmgrmodels.ArkCmgrAddPoolSingleIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifier, error) {
+	return s.AddPoolIdentifierWithContext(context.Background(), addPoolIdentifier)
+}
+
+// AddPoolIdentifierWithContext is AddPoolIdentifier with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) AddPoolIdentifierWithContext(ctx context.Context, addPoolIdentifier *cmgrmodels.ArkCmgrAddPoolSingleIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifier, error) {
+	ctx, span := s.StartSpan(ctx, "AddPoolIdentifier")
+	defer span.End()
 	s.Logger.Info("Adding pool identifier [%v]", addPoolIdentifier)
 	var addPoolIdentifierJSON map[string]interface{}
 	err := mapstructure.Decode(addPoolIdentifier, &addPoolIdentifierJSON)
@@ -525,7 +634,7 @@ func (s *ArkCmgrService) AddPoolIdentifier(addPoolIdentifier *cmgrmodels.ArkCmgr
 		return nil, err
 	}
 	delete(addPoolIdentifierJSON, "pool_id")
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(poolIdentifiersURL, addPoolIdentifier.PoolID), addPoolIdentifierJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(poolIdentifiersURL, addPoolIdentifier.PoolID), addPoolIdentifierJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -536,7 +645,7 @@ func (s *ArkCmgrService) AddPoolIdentifier(addPoolIdentifier *cmgrmodels.ArkCmgr
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add pool identifier - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add pool identifier")
 	}
 	poolIdentifierJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -554,6 +663,13 @@ func (s *ArkCmgrService) AddPoolIdentifier(addPoolIdentifier *cmgrmodels.ArkCmgr
 
 // AddPoolIdentifiers adds multiple identifiers to a specific pool in the connector management service.
 func (s *ArkCmgrService) AddPoolIdentifiers(addPoolIdentifiers *cmgrmodels.ArkCmgrAddPoolBulkIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifiers, error) {
+	return s.AddPoolIdentifiersWithContext(context.Background(), addPoolIdentifiers)
+}
+
+// AddPoolIdentifiersWithContext is AddPoolIdentifiers with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) AddPoolIdentifiersWithContext(ctx context.Context, addPoolIdentifiers *cmgrmodels.ArkCmgrAddPoolBulkIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifiers, error) {
+	ctx, span := s.StartSpan(ctx, "AddPoolIdentifiers")
+	defer span.End()
 	s.Logger.Info("Adding pool identifiers [%v]", addPoolIdentifiers)
 	requests := make(map[string]interface{})
 	for index, identifier := range addPoolIdentifiers.Identifiers {
@@ -567,7 +683,7 @@ func (s *ArkCmgrService) AddPoolIdentifiers(addPoolIdentifiers *cmgrmodels.ArkCm
 	payload := map[string]interface{}{
 		"requests": requests,
 	}
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(poolIdentifiersBulkURL, addPoolIdentifiers.PoolID), payload)
+	response, err := s.client.Post(ctx, fmt.Sprintf(poolIdentifiersBulkURL, addPoolIdentifiers.PoolID), payload)
 	if err != nil {
 		return nil, err
 	}
@@ -578,7 +694,7 @@ func (s *ArkCmgrService) AddPoolIdentifiers(addPoolIdentifiers *cmgrmodels.ArkCm
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusMultiStatus {
-		return nil, fmt.Errorf("failed to add pool identifiers - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add pool identifiers")
 	}
 	bulkResponsesJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -592,7 +708,7 @@ func (s *ArkCmgrService) AddPoolIdentifiers(addPoolIdentifiers *cmgrmodels.ArkCm
 	identifiers := make([]*cmgrmodels.ArkCmgrPoolIdentifier, 0)
 	for _, identifierResponse := range bulkResponses.Responses {
 		if identifierResponse.StatusCode != http.StatusCreated {
-			return nil, fmt.Errorf("failed to add pool identifiers bulk - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+			return nil, common.NewArkAPIError(response, "failed to add pool identifiers bulk")
 		}
 		identifierResponse.Body["identifier_id"] = identifierResponse.Body["id"]
 		var identifier cmgrmodels.ArkCmgrPoolIdentifier
@@ -607,15 +723,22 @@ func (s *ArkCmgrService) AddPoolIdentifiers(addPoolIdentifiers *cmgrmodels.ArkCm
 
 // UpdatePoolIdentifier updates an existing identifier in a specific pool in the connector management service.
 func (s *ArkCmgrService) UpdatePoolIdentifier(updatePoolIdentifier *cmgrmodels.ArkCmgrUpdatePoolIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifier, error) {
+	return s.UpdatePoolIdentifierWithContext(context.Background(), updatePoolIdentifier)
+}
+
+// UpdatePoolIdentifierWithContext is UpdatePoolIdentifier with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) UpdatePoolIdentifierWithContext(ctx context.Context, updatePoolIdentifier *cmgrmodels.ArkCmgrUpdatePoolIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifier, error) {
+	ctx, span := s.StartSpan(ctx, "UpdatePoolIdentifier")
+	defer span.End()
 	s.Logger.Info("Updating pool identifier [%s] from pool [%s]", updatePoolIdentifier.IdentifierID, updatePoolIdentifier.PoolID)
-	err := s.DeletePoolIdentifier(&cmgrmodels.ArkCmgrDeletePoolSingleIdentifier{
+	err := s.DeletePoolIdentifierWithContext(ctx, &cmgrmodels.ArkCmgrDeletePoolSingleIdentifier{
 		IdentifierID: updatePoolIdentifier.IdentifierID,
 		PoolID:       updatePoolIdentifier.PoolID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return s.AddPoolIdentifier(&cmgrmodels.ArkCmgrAddPoolSingleIdentifier{
+	return s.AddPoolIdentifierWithContext(ctx, &cmgrmodels.ArkCmgrAddPoolSingleIdentifier{
 		Type:   updatePoolIdentifier.Type,
 		Value:  updatePoolIdentifier.Value,
 		PoolID: updatePoolIdentifier.PoolID,
@@ -624,19 +747,33 @@ func (s *ArkCmgrService) UpdatePoolIdentifier(updatePoolIdentifier *cmgrmodels.A
 
 // DeletePoolIdentifier deletes an identifier from a specific pool in the connector management service.
 func (s *ArkCmgrService) DeletePoolIdentifier(deletePoolIdentifier *cmgrmodels.ArkCmgrDeletePoolSingleIdentifier) error {
+	return s.DeletePoolIdentifierWithContext(context.Background(), deletePoolIdentifier)
+}
+
+// DeletePoolIdentifierWithContext is DeletePoolIdentifier with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) DeletePoolIdentifierWithContext(ctx context.Context, deletePoolIdentifier *cmgrmodels.ArkCmgrDeletePoolSingleIdentifier) error {
+	ctx, span := s.StartSpan(ctx, "DeletePoolIdentifier")
+	defer span.End()
 	s.Logger.Info("Deleting pool identifier [%s]", deletePoolIdentifier.IdentifierID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(poolIdentifierURL, deletePoolIdentifier.PoolID, deletePoolIdentifier.IdentifierID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(poolIdentifierURL, deletePoolIdentifier.PoolID, deletePoolIdentifier.IdentifierID), nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete pool identifier - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete pool identifier")
 	}
 	return nil
 }
 
 // DeletePoolIdentifiers deletes multiple identifiers from a specific pool in the connector management service.
 func (s *ArkCmgrService) DeletePoolIdentifiers(deletePoolIdentifiers *cmgrmodels.ArkCmgrDeletePoolBulkIdentifier) error {
+	return s.DeletePoolIdentifiersWithContext(context.Background(), deletePoolIdentifiers)
+}
+
+// DeletePoolIdentifiersWithContext is DeletePoolIdentifiers with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) DeletePoolIdentifiersWithContext(ctx context.Context, deletePoolIdentifiers *cmgrmodels.ArkCmgrDeletePoolBulkIdentifier) error {
+	ctx, span := s.StartSpan(ctx, "DeletePoolIdentifiers")
+	defer span.End()
 	s.Logger.Info("Deleting pool identifiers [%s]", deletePoolIdentifiers.PoolID)
 	requests := make(map[string]interface{})
 	for index, identifier := range deletePoolIdentifiers.Identifiers {
@@ -650,7 +787,7 @@ func (s *ArkCmgrService) DeletePoolIdentifiers(deletePoolIdentifiers *cmgrmodels
 	payload := map[string]interface{}{
 		"requests": requests,
 	}
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(poolIdentifiersBulkURL, deletePoolIdentifiers.PoolID), payload)
+	response, err := s.client.Post(ctx, fmt.Sprintf(poolIdentifiersBulkURL, deletePoolIdentifiers.PoolID), payload)
 	if err != nil {
 		return err
 	}
@@ -661,7 +798,7 @@ func (s *ArkCmgrService) DeletePoolIdentifiers(deletePoolIdentifiers *cmgrmodels
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusMultiStatus {
-		return fmt.Errorf("failed to delete pool identifiers - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete pool identifiers")
 	}
 	bulkResponsesJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -674,7 +811,7 @@ func (s *ArkCmgrService) DeletePoolIdentifiers(deletePoolIdentifiers *cmgrmodels
 	}
 	for _, identifierResponse := range bulkResponses.Responses {
 		if identifierResponse.StatusCode != http.StatusNoContent {
-			return fmt.Errorf("failed to delete pool identifiers - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+			return common.NewArkAPIError(response, "failed to delete pool identifiers")
 		}
 	}
 	return nil
@@ -682,42 +819,72 @@ func (s *ArkCmgrService) DeletePoolIdentifiers(deletePoolIdentifiers *cmgrmodels
 
 // ListPoolIdentifiers lists all identifiers in a specific pool in the connector management service.
 func (s *ArkCmgrService) ListPoolIdentifiers(listPoolIdentifiers *cmgrmodels.ArkCmgrListPoolIdentifiers) (<-chan *ArkCmgrPoolIdentifierPage, error) {
-	s.Logger.Info("Listing pool identifiers [%v]", listPoolIdentifiers)
-	return listCommonPools[cmgrmodels.ArkCmgrPoolIdentifier](
-		s.Logger,
-		s.client,
-		"pool identifiers",
-		fmt.Sprintf(poolIdentifiersURL, listPoolIdentifiers.PoolID),
-		nil,
-		map[string]string{
-			"id": "identifier_id",
-		},
-	)
+	return s.ListPoolIdentifiersWithContext(context.Background(), listPoolIdentifiers)
+}
+
+// ListPoolIdentifiersWithContext is ListPoolIdentifiers with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListPoolIdentifiersWithContext(ctx context.Context, listPoolIdentifiers *cmgrmodels.ArkCmgrListPoolIdentifiers) (<-chan *ArkCmgrPoolIdentifierPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoolIdentifiersIter(ctx, listPoolIdentifiers), s.Logger), nil
+}
+
+// ListPoolIdentifiersIter returns an iterator over all the identifiers pages of a pool, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListPoolIdentifiersIter(ctx context.Context, listPoolIdentifiers *cmgrmodels.ArkCmgrListPoolIdentifiers) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolIdentifier] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoolIdentifiers", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolIdentifier] {
+		s.Logger.Info("Listing pool identifiers [%v]", listPoolIdentifiers)
+		return listCommonPools[cmgrmodels.ArkCmgrPoolIdentifier](ctx,
+			s.Logger,
+			s.client,
+			"pool identifiers",
+			fmt.Sprintf(poolIdentifiersURL, listPoolIdentifiers.PoolID),
+			nil,
+			map[string]string{
+				"id": "identifier_id",
+			},
+		)
+	})
 }
 
 // ListPoolIdentifiersBy lists identifiers by the specified filter in a specific pool in the connector management service.
 func (s *ArkCmgrService) ListPoolIdentifiersBy(identifiersFilters *cmgrmodels.ArkCmgrPoolIdentifiersFilter) (<-chan *ArkCmgrPoolIdentifierPage, error) {
-	s.Logger.Info("Listing pool identifiers by filter [%v]", identifiersFilters)
-	return listCommonPools[cmgrmodels.ArkCmgrPoolIdentifier](
-		s.Logger,
-		s.client,
-		"pool identifiers",
-		fmt.Sprintf(poolIdentifiersURL, identifiersFilters.PoolID),
-		&identifiersFilters.ArkCmgrPoolsCommonFilter,
-		map[string]string{
-			"id": "identifier_id",
-		},
-	)
+	return s.ListPoolIdentifiersByWithContext(context.Background(), identifiersFilters)
+}
+
+// ListPoolIdentifiersByWithContext is ListPoolIdentifiersBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListPoolIdentifiersByWithContext(ctx context.Context, identifiersFilters *cmgrmodels.ArkCmgrPoolIdentifiersFilter) (<-chan *ArkCmgrPoolIdentifierPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoolIdentifiersByIter(ctx, identifiersFilters), s.Logger), nil
+}
+
+// ListPoolIdentifiersByIter returns an iterator over the identifiers pages of a pool filtered by the given filter, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListPoolIdentifiersByIter(ctx context.Context, identifiersFilters *cmgrmodels.ArkCmgrPoolIdentifiersFilter) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolIdentifier] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoolIdentifiersBy", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolIdentifier] {
+		s.Logger.Info("Listing pool identifiers by filter [%v]", identifiersFilters)
+		return listCommonPools[cmgrmodels.ArkCmgrPoolIdentifier](ctx,
+			s.Logger,
+			s.client,
+			"pool identifiers",
+			fmt.Sprintf(poolIdentifiersURL, identifiersFilters.PoolID),
+			&identifiersFilters.ArkCmgrPoolsCommonFilter,
+			map[string]string{
+				"id": "identifier_id",
+			},
+		)
+	})
 }
 
 // PoolIdentifier retrieves a specific identifier by its ID from a specific pool in the connector management service.
 func (s *ArkCmgrService) PoolIdentifier(getIdentifier *cmgrmodels.ArkCmgrGetPoolIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifier, error) {
+	return s.PoolIdentifierWithContext(context.Background(), getIdentifier)
+}
+
+// PoolIdentifierWithContext is PoolIdentifier with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) PoolIdentifierWithContext(ctx context.Context, getIdentifier *cmgrmodels.ArkCmgrGetPoolIdentifier) (*cmgrmodels.ArkCmgrPoolIdentifier, error) {
+	ctx, span := s.StartSpan(ctx, "PoolIdentifier")
+	defer span.End()
 	s.Logger.Info("Retrieving pool identifier [%s] from pool [%s]", getIdentifier.IdentifierID, getIdentifier.PoolID)
-	identifiers, err := s.ListPoolIdentifiers(&cmgrmodels.ArkCmgrListPoolIdentifiers{PoolID: getIdentifier.PoolID})
-	if err != nil {
-		return nil, err
-	}
-	for page := range identifiers {
+	for page, err := range s.ListPoolIdentifiersIter(ctx, &cmgrmodels.ArkCmgrListPoolIdentifiers{PoolID: getIdentifier.PoolID}) {
+		if err != nil {
+			return nil, err
+		}
 		for _, identifier := range page.Items {
 			if identifier.IdentifierID == getIdentifier.IdentifierID {
 				return identifier, nil
@@ -729,38 +896,69 @@ func (s *ArkCmgrService) PoolIdentifier(getIdentifier *cmgrmodels.ArkCmgrGetPool
 
 // ListPoolsComponents lists all components in the connector management service.
 func (s *ArkCmgrService) ListPoolsComponents() (<-chan *ArkCmgrPoolComponentPage, error) {
-	s.Logger.Info("Listing pools components")
-	return listCommonPools[cmgrmodels.ArkCmgrPoolComponent](
-		s.Logger,
-		s.client,
-		"pools components",
-		fmt.Sprintf(poolsComponentsURL),
-		nil,
-		map[string]string{
-			"id": "component_id",
-		},
-	)
+	return s.ListPoolsComponentsWithContext(context.Background())
+}
+
+// ListPoolsComponentsWithContext is ListPoolsComponents with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListPoolsComponentsWithContext(ctx context.Context) (<-chan *ArkCmgrPoolComponentPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoolsComponentsIter(ctx), s.Logger), nil
+}
+
+// ListPoolsComponentsIter returns an iterator over all the pools components pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListPoolsComponentsIter(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolComponent] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoolsComponents", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolComponent] {
+		s.Logger.Info("Listing pools components")
+		return listCommonPools[cmgrmodels.ArkCmgrPoolComponent](ctx,
+			s.Logger,
+			s.client,
+			"pools components",
+			fmt.Sprintf(poolsComponentsURL),
+			nil,
+			map[string]string{
+				"id": "component_id",
+			},
+		)
+	})
 }
 
 // ListPoolsComponentsBy lists components by the specified filter in the connector management service.
 func (s *ArkCmgrService) ListPoolsComponentsBy(componentsFilters *cmgrmodels.ArkCmgrPoolComponentsFilter) (<-chan *ArkCmgrPoolComponentPage, error) {
-	s.Logger.Info("Listing pools components by filter [%v]", componentsFilters)
-	return listCommonPools[cmgrmodels.ArkCmgrPoolComponent](
-		s.Logger,
-		s.client,
-		"pools components",
-		fmt.Sprintf(poolsComponentsURL),
-		&componentsFilters.ArkCmgrPoolsCommonFilter,
-		map[string]string{
-			"id": "component_id",
-		},
-	)
+	return s.ListPoolsComponentsByWithContext(context.Background(), componentsFilters)
+}
+
+// ListPoolsComponentsByWithContext is ListPoolsComponentsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) ListPoolsComponentsByWithContext(ctx context.Context, componentsFilters *cmgrmodels.ArkCmgrPoolComponentsFilter) (<-chan *ArkCmgrPoolComponentPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoolsComponentsByIter(ctx, componentsFilters), s.Logger), nil
+}
+
+// ListPoolsComponentsByIter returns an iterator over the pools components pages filtered by the given filter, stopping with an error if a page fails to be retrieved.
+func (s *ArkCmgrService) ListPoolsComponentsByIter(ctx context.Context, componentsFilters *cmgrmodels.ArkCmgrPoolComponentsFilter) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolComponent] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoolsComponentsBy", func(ctx context.Context) common.ArkPageIterator[cmgrmodels.ArkCmgrPoolComponent] {
+		s.Logger.Info("Listing pools components by filter [%v]", componentsFilters)
+		return listCommonPools[cmgrmodels.ArkCmgrPoolComponent](ctx,
+			s.Logger,
+			s.client,
+			"pools components",
+			fmt.Sprintf(poolsComponentsURL),
+			&componentsFilters.ArkCmgrPoolsCommonFilter,
+			map[string]string{
+				"id": "component_id",
+			},
+		)
+	})
 }
 
 // PoolComponent retrieves a specific component by its ID from the connector management service.
 func (s *ArkCmgrService) PoolComponent(getPoolComponent *cmgrmodels.ArkCmgrGetPoolComponent) (*cmgrmodels.ArkCmgrPoolComponent, error) {
+	return s.PoolComponentWithContext(context.Background(), getPoolComponent)
+}
+
+// PoolComponentWithContext is PoolComponent with a caller provided context that is passed down to every request made by the call.
+func (s *ArkCmgrService) PoolComponentWithContext(ctx context.Context, getPoolComponent *cmgrmodels.ArkCmgrGetPoolComponent) (*cmgrmodels.ArkCmgrPoolComponent, error) {
+	ctx, span := s.StartSpan(ctx, "PoolComponent")
+	defer span.End()
 	s.Logger.Info("Retrieving pool component [%s]", getPoolComponent.ComponentID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(poolComponentURL, getPoolComponent.PoolID, getPoolComponent.ComponentID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(poolComponentURL, getPoolComponent.PoolID, getPoolComponent.ComponentID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -771,7 +969,7 @@ func (s *ArkCmgrService) PoolComponent(getPoolComponent *cmgrmodels.ArkCmgrGetPo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve pool component - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve pool component")
 	}
 	poolComponentJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -12,7 +12,6 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/common/identity"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	directoriesmodels "github.com/cyberark/ark-sdk-golang/pkg/services/identity/directories/models"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/mitchellh/mapstructure"
 
 	"io"
@@ -57,13 +56,11 @@ func NewArkIdentityDirectoriesService(authenticators ...auth.ArkAuth) (*ArkIdent
 	if err != nil {
 		return nil, err
 	}
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(ispAuth.Token.Token, jwt.MapClaims{})
+	claims, err := ispAuth.TokenClaims(false)
 	if err != nil {
 		return nil, err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	identityIss := claims["iss"].(string)
-	identityURL, err := url.Parse(identityIss)
+	identityURL, err := url.Parse(claims.Issuer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse identity URL: %w", err)
 	}
@@ -74,6 +71,7 @@ func NewArkIdentityDirectoriesService(authenticators ...auth.ArkAuth) (*ArkIdent
 		"X-IDAP-NATIVE-CLIENT": "true",
 	})
 	identityDirectoriesService.client = client
+	baseService.AddClient(client.ArkClient)
 	identityDirectoriesService.ispAuth = ispAuth
 	identityDirectoriesService.ArkBaseService = baseService
 	identityDirectoriesService.env = commonmodels.GetDeployEnv()
@@ -90,11 +88,18 @@ func (s *ArkIdentityDirectoriesService) refreshIdentityDirectoriesAuth(client *c
 
 // ListDirectories retrieves the directory services for the specified directories.
 func (s *ArkIdentityDirectoriesService) ListDirectories(listDirectories *directoriesmodels.ArkIdentityListDirectories) ([]*directoriesmodels.ArkIdentityDirectory, error) {
+	return s.ListDirectoriesWithContext(context.Background(), listDirectories)
+}
+
+// ListDirectoriesWithContext is ListDirectories with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityDirectoriesService) ListDirectoriesWithContext(ctx context.Context, listDirectories *directoriesmodels.ArkIdentityListDirectories) ([]*directoriesmodels.ArkIdentityDirectory, error) {
+	ctx, span := s.StartSpan(ctx, "ListDirectories")
+	defer span.End()
 	if listDirectories.Directories == nil || len(listDirectories.Directories) == 0 {
 		listDirectories.Directories = identity.AllDirectoryTypes
 	}
 	s.Logger.Info("Retrieving directory services for directories [%v]", listDirectories)
-	response, err := s.client.Get(context.Background(), getDirectoryServicesURL, nil)
+	response, err := s.client.Get(ctx, getDirectoryServicesURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +110,7 @@ func (s *ArkIdentityDirectoriesService) ListDirectories(listDirectories *directo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get directory services - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get directory services")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -137,8 +142,57 @@ func (s *ArkIdentityDirectoriesService) ListDirectories(listDirectories *directo
 
 // ListDirectoriesEntities retrieves the entities for the specified directories.
 func (s *ArkIdentityDirectoriesService) ListDirectoriesEntities(listDirectoriesEntities *directoriesmodels.ArkIdentityListDirectoriesEntities) (<-chan *ArkIdentityEntitiesPage, error) {
+	return s.ListDirectoriesEntitiesWithContext(context.Background(), listDirectoriesEntities)
+}
+
+// ListDirectoriesEntitiesWithContext is ListDirectoriesEntities with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityDirectoriesService) ListDirectoriesEntitiesWithContext(ctx context.Context, listDirectoriesEntities *directoriesmodels.ArkIdentityListDirectoriesEntities) (<-chan *ArkIdentityEntitiesPage, error) {
+	ctx, span := s.StartSpan(ctx, "ListDirectoriesEntities")
+	defer span.End()
+	entities, err := s.listDirectoriesEntities(ctx, listDirectoriesEntities)
+	if err != nil {
+		return nil, err
+	}
+	return common.ArkPageChannel(ctx, entitiesPages(entities, listDirectoriesEntities.PageSize), s.Logger), nil
+}
+
+// ListDirectoriesEntitiesIter returns an iterator over the entities pages of the specified directories, stopping with an error if the entities fail to be retrieved.
+func (s *ArkIdentityDirectoriesService) ListDirectoriesEntitiesIter(ctx context.Context, listDirectoriesEntities *directoriesmodels.ArkIdentityListDirectoriesEntities) common.ArkPageIterator[directoriesmodels.ArkIdentityEntity] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListDirectoriesEntities", func(ctx context.Context) common.ArkPageIterator[directoriesmodels.ArkIdentityEntity] {
+		return func(yield func(*ArkIdentityEntitiesPage, error) bool) {
+			entities, err := s.listDirectoriesEntities(ctx, listDirectoriesEntities)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for page, err := range entitiesPages(entities, listDirectoriesEntities.PageSize) {
+				if !yield(page, err) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// entitiesPages splits the queried entities into pages of the requested size, the directory service query returns them all at once.
+func entitiesPages(entities []*directoriesmodels.ArkIdentityEntity, pageSize int) common.ArkPageIterator[directoriesmodels.ArkIdentityEntity] {
+	return func(yield func(*ArkIdentityEntitiesPage, error) bool) {
+		for len(entities) > 0 {
+			if pageSize <= 0 || len(entities) <= pageSize {
+				yield(&ArkIdentityEntitiesPage{Items: entities}, nil)
+				return
+			}
+			if !yield(&ArkIdentityEntitiesPage{Items: entities[:pageSize]}, nil) {
+				return
+			}
+			entities = entities[pageSize:]
+		}
+	}
+}
+
+func (s *ArkIdentityDirectoriesService) listDirectoriesEntities(ctx context.Context, listDirectoriesEntities *directoriesmodels.ArkIdentityListDirectoriesEntities) ([]*directoriesmodels.ArkIdentityEntity, error) {
 	s.Logger.Info("Listing directories entities")
-	directories, err := s.ListDirectories(&directoriesmodels.ArkIdentityListDirectories{
+	directories, err := s.ListDirectoriesWithContext(ctx, &directoriesmodels.ArkIdentityListDirectories{
 		Directories: listDirectoriesEntities.Directories,
 	})
 	if err != nil {
@@ -175,7 +229,7 @@ func (s *ArkIdentityDirectoriesService) ListDirectoriesEntities(listDirectoriesE
 			delete(directoryRequestMap, exclusion)
 		}
 	}
-	response, err := s.client.Post(context.Background(), directoryServiceQueryURL, directoryRequestMap)
+	response, err := s.client.Post(ctx, directoryServiceQueryURL, directoryRequestMap)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +240,7 @@ func (s *ArkIdentityDirectoriesService) ListDirectoriesEntities(listDirectoriesE
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get directory entities - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get directory entities")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -255,27 +309,20 @@ func (s *ArkIdentityDirectoriesService) ListDirectoriesEntities(listDirectoriesE
 			entities = append(entities, &roleEntityIfs)
 		}
 	}
-	output := make(chan *ArkIdentityEntitiesPage)
-	go func() {
-		defer close(output)
-		for len(entities) > 0 {
-			if len(entities) <= listDirectoriesEntities.PageSize {
-				output <- &ArkIdentityEntitiesPage{Items: entities}
-				break
-			} else {
-				page := entities[:listDirectoriesEntities.PageSize]
-				entities = entities[listDirectoriesEntities.PageSize:]
-				output <- &ArkIdentityEntitiesPage{Items: page}
-			}
-		}
-	}()
-	return output, nil
+	return entities, nil
 }
 
 // TenantDefaultSuffix retrieves the default tenant suffix for the identity directories service.
 func (s *ArkIdentityDirectoriesService) TenantDefaultSuffix() (string, error) {
+	return s.TenantDefaultSuffixWithContext(context.Background())
+}
+
+// TenantDefaultSuffixWithContext is TenantDefaultSuffix with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityDirectoriesService) TenantDefaultSuffixWithContext(ctx context.Context) (string, error) {
+	ctx, span := s.StartSpan(ctx, "TenantDefaultSuffix")
+	defer span.End()
 	s.Logger.Info("Discovering default tenant suffix")
-	response, err := s.client.Post(context.Background(), tenantSuffixURL, nil)
+	response, err := s.client.Post(ctx, tenantSuffixURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -286,7 +333,7 @@ func (s *ArkIdentityDirectoriesService) TenantDefaultSuffix() (string, error) {
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get tenant default suffix - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "failed to get tenant default suffix")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
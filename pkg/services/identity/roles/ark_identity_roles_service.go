@@ -59,6 +59,7 @@ func NewArkIdentityRolesService(authenticators ...auth.ArkAuth) (*ArkIdentityRol
 		"X-IDAP-NATIVE-CLIENT": "true",
 	})
 	identityRolesService.client = client
+	baseService.AddClient(client.ArkClient)
 	identityRolesService.ispAuth = ispAuth
 	identityRolesService.ArkBaseService = baseService
 	return identityRolesService, nil
@@ -74,8 +75,15 @@ func (s *ArkIdentityRolesService) refreshIdentityRolesAuth(client *common.ArkCli
 
 // CreateRole creates a new role in the identity service.
 func (s *ArkIdentityRolesService) CreateRole(createRole *rolesmodels.ArkIdentityCreateRole) (*rolesmodels.ArkIdentityRole, error) {
+	return s.CreateRoleWithContext(context.Background(), createRole)
+}
+
+// CreateRoleWithContext is CreateRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) CreateRoleWithContext(ctx context.Context, createRole *rolesmodels.ArkIdentityCreateRole) (*rolesmodels.ArkIdentityRole, error) {
+	ctx, span := s.StartSpan(ctx, "CreateRole")
+	defer span.End()
 	s.Logger.Info("Trying to create role [%s]", createRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{
 		RoleName: createRole.RoleName,
 	})
 	if err == nil && roleID != "" {
@@ -91,7 +99,7 @@ func (s *ArkIdentityRolesService) CreateRole(createRole *rolesmodels.ArkIdentity
 	if createRole.Description != "" {
 		createRoleRequest["Description"] = createRole.Description
 	}
-	response, err := s.client.Post(context.Background(), createRoleURL, createRoleRequest)
+	response, err := s.client.Post(ctx, createRoleURL, createRoleRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +110,7 @@ func (s *ArkIdentityRolesService) CreateRole(createRole *rolesmodels.ArkIdentity
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to create role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to create role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -119,7 +127,7 @@ func (s *ArkIdentityRolesService) CreateRole(createRole *rolesmodels.ArkIdentity
 	}
 	s.Logger.Info("Role created with id [%s]", roleID)
 	if len(createRole.AdminRights) > 0 {
-		err = s.AddAdminRightsToRole(&rolesmodels.ArkIdentityAddAdminRightsToRole{
+		err = s.AddAdminRightsToRoleWithContext(ctx, &rolesmodels.ArkIdentityAddAdminRightsToRole{
 			RoleID:      roleDetails.RoleID,
 			AdminRights: createRole.AdminRights,
 		})
@@ -132,8 +140,15 @@ func (s *ArkIdentityRolesService) CreateRole(createRole *rolesmodels.ArkIdentity
 
 // UpdateRole updates an existing role in the identity service.
 func (s *ArkIdentityRolesService) UpdateRole(updateRole *rolesmodels.ArkIdentityUpdateRole) error {
+	return s.UpdateRoleWithContext(context.Background(), updateRole)
+}
+
+// UpdateRoleWithContext is UpdateRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) UpdateRoleWithContext(ctx context.Context, updateRole *rolesmodels.ArkIdentityUpdateRole) error {
+	ctx, span := s.StartSpan(ctx, "UpdateRole")
+	defer span.End()
 	if updateRole.RoleName != "" && updateRole.RoleID == "" {
-		roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: updateRole.RoleName})
+		roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: updateRole.RoleName})
 		if err != nil {
 			return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 		}
@@ -149,7 +164,7 @@ func (s *ArkIdentityRolesService) UpdateRole(updateRole *rolesmodels.ArkIdentity
 	if updateRole.Description != "" {
 		updateDict["Description"] = updateRole.Description
 	}
-	response, err := s.client.Post(context.Background(), updateRoleURL, updateDict)
+	response, err := s.client.Post(ctx, updateRoleURL, updateDict)
 	if err != nil {
 		return fmt.Errorf("failed to update role: %v", err)
 	}
@@ -160,7 +175,7 @@ func (s *ArkIdentityRolesService) UpdateRole(updateRole *rolesmodels.ArkIdentity
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to update role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to update role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -176,8 +191,15 @@ func (s *ArkIdentityRolesService) UpdateRole(updateRole *rolesmodels.ArkIdentity
 
 // ListRoleMembers retrieves the members of a role in the identity service.
 func (s *ArkIdentityRolesService) ListRoleMembers(listRoleMembers *rolesmodels.ArkIdentityListRoleMembers) ([]*rolesmodels.ArkIdentityRoleMember, error) {
+	return s.ListRoleMembersWithContext(context.Background(), listRoleMembers)
+}
+
+// ListRoleMembersWithContext is ListRoleMembers with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) ListRoleMembersWithContext(ctx context.Context, listRoleMembers *rolesmodels.ArkIdentityListRoleMembers) ([]*rolesmodels.ArkIdentityRoleMember, error) {
+	ctx, span := s.StartSpan(ctx, "ListRoleMembers")
+	defer span.End()
 	if listRoleMembers.RoleName != "" && listRoleMembers.RoleID == "" {
-		roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: listRoleMembers.RoleName})
+		roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: listRoleMembers.RoleName})
 		if err != nil {
 			return nil, fmt.Errorf("failed to retrieve role ID by name: %v", err)
 		}
@@ -187,7 +209,7 @@ func (s *ArkIdentityRolesService) ListRoleMembers(listRoleMembers *rolesmodels.A
 	requestBody := map[string]interface{}{
 		"Name": listRoleMembers.RoleID,
 	}
-	response, err := s.client.Post(context.Background(), roleMembersURL, requestBody)
+	response, err := s.client.Post(ctx, roleMembersURL, requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list role members: %v", err)
 	}
@@ -198,7 +220,7 @@ func (s *ArkIdentityRolesService) ListRoleMembers(listRoleMembers *rolesmodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list role members - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list role members")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -227,6 +249,13 @@ func (s *ArkIdentityRolesService) ListRoleMembers(listRoleMembers *rolesmodels.A
 
 // AddAdminRightsToRole adds admin rights to a role in the identity service.
 func (s *ArkIdentityRolesService) AddAdminRightsToRole(addAdminRightsToRole *rolesmodels.ArkIdentityAddAdminRightsToRole) error {
+	return s.AddAdminRightsToRoleWithContext(context.Background(), addAdminRightsToRole)
+}
+
+// AddAdminRightsToRoleWithContext is AddAdminRightsToRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) AddAdminRightsToRoleWithContext(ctx context.Context, addAdminRightsToRole *rolesmodels.ArkIdentityAddAdminRightsToRole) error {
+	ctx, span := s.StartSpan(ctx, "AddAdminRightsToRole")
+	defer span.End()
 	s.Logger.Info("Adding admin rights [%v] to role [%s]", addAdminRightsToRole.AdminRights, addAdminRightsToRole.RoleName)
 
 	if addAdminRightsToRole.RoleID == "" && addAdminRightsToRole.RoleName == "" {
@@ -237,7 +266,7 @@ func (s *ArkIdentityRolesService) AddAdminRightsToRole(addAdminRightsToRole *rol
 		roleID = addAdminRightsToRole.RoleID
 	} else {
 		var err error
-		roleID, err = s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: addAdminRightsToRole.RoleName})
+		roleID, err = s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: addAdminRightsToRole.RoleName})
 		if err != nil {
 			return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 		}
@@ -249,7 +278,7 @@ func (s *ArkIdentityRolesService) AddAdminRightsToRole(addAdminRightsToRole *rol
 			"Path": adminRight,
 		}
 	}
-	response, err := s.client.Post(context.Background(), addAdminRightsToRoleURL, requestBody)
+	response, err := s.client.Post(ctx, addAdminRightsToRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to add admin rights to role: %v", err)
 	}
@@ -260,7 +289,7 @@ func (s *ArkIdentityRolesService) AddAdminRightsToRole(addAdminRightsToRole *rol
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to add admin rights to role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to add admin rights to role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -276,6 +305,13 @@ func (s *ArkIdentityRolesService) AddAdminRightsToRole(addAdminRightsToRole *rol
 
 // RoleIDByName retrieves the role ID by its name.
 func (s *ArkIdentityRolesService) RoleIDByName(roleIDByName *rolesmodels.ArkIdentityRoleIDByName) (string, error) {
+	return s.RoleIDByNameWithContext(context.Background(), roleIDByName)
+}
+
+// RoleIDByNameWithContext is RoleIDByName with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) RoleIDByNameWithContext(ctx context.Context, roleIDByName *rolesmodels.ArkIdentityRoleIDByName) (string, error) {
+	ctx, span := s.StartSpan(ctx, "RoleIDByName")
+	defer span.End()
 	s.Logger.Info("Retrieving role ID for name [%s]", roleIDByName.RoleName)
 	directoriesService, err := directories.NewArkIdentityDirectoriesService(s.ispAuth)
 	if err != nil {
@@ -299,7 +335,7 @@ func (s *ArkIdentityRolesService) RoleIDByName(roleIDByName *rolesmodels.ArkIden
 	if err != nil {
 		return "", fmt.Errorf("failed to decode specific role request: %v", err)
 	}
-	response, err := s.client.Post(context.Background(), directoryServiceQueryURL, specificRoleRequestBody)
+	response, err := s.client.Post(ctx, directoryServiceQueryURL, specificRoleRequestBody)
 	if err != nil {
 		return "", fmt.Errorf("failed to query directory services role: %v", err)
 	}
@@ -310,7 +346,7 @@ func (s *ArkIdentityRolesService) RoleIDByName(roleIDByName *rolesmodels.ArkIden
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to query for directory services role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "failed to query for directory services role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -334,8 +370,15 @@ func (s *ArkIdentityRolesService) RoleIDByName(roleIDByName *rolesmodels.ArkIden
 
 // AddUserToRole adds a user to a role in the identity service.
 func (s *ArkIdentityRolesService) AddUserToRole(addUserToRole *rolesmodels.ArkIdentityAddUserToRole) error {
+	return s.AddUserToRoleWithContext(context.Background(), addUserToRole)
+}
+
+// AddUserToRoleWithContext is AddUserToRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) AddUserToRoleWithContext(ctx context.Context, addUserToRole *rolesmodels.ArkIdentityAddUserToRole) error {
+	ctx, span := s.StartSpan(ctx, "AddUserToRole")
+	defer span.End()
 	s.Logger.Info("Adding user [%s] to role [%s]", addUserToRole.Username, addUserToRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: addUserToRole.RoleName})
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: addUserToRole.RoleName})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 	}
@@ -343,7 +386,7 @@ func (s *ArkIdentityRolesService) AddUserToRole(addUserToRole *rolesmodels.ArkId
 		"Name":  roleID,
 		"Users": []string{addUserToRole.Username},
 	}
-	response, err := s.client.Post(context.Background(), addUserToRoleURL, requestBody)
+	response, err := s.client.Post(ctx, addUserToRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to add user to role: %v", err)
 	}
@@ -354,7 +397,7 @@ func (s *ArkIdentityRolesService) AddUserToRole(addUserToRole *rolesmodels.ArkId
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to add user to role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to add user to role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -370,8 +413,15 @@ func (s *ArkIdentityRolesService) AddUserToRole(addUserToRole *rolesmodels.ArkId
 
 // AddGroupToRole adds a group to a role in the identity service.
 func (s *ArkIdentityRolesService) AddGroupToRole(addGroupToRole *rolesmodels.ArkIdentityAddGroupToRole) error {
+	return s.AddGroupToRoleWithContext(context.Background(), addGroupToRole)
+}
+
+// AddGroupToRoleWithContext is AddGroupToRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) AddGroupToRoleWithContext(ctx context.Context, addGroupToRole *rolesmodels.ArkIdentityAddGroupToRole) error {
+	ctx, span := s.StartSpan(ctx, "AddGroupToRole")
+	defer span.End()
 	s.Logger.Info("Adding group [%s] to role [%s]", addGroupToRole.GroupName, addGroupToRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: addGroupToRole.RoleName})
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: addGroupToRole.RoleName})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 	}
@@ -379,7 +429,7 @@ func (s *ArkIdentityRolesService) AddGroupToRole(addGroupToRole *rolesmodels.Ark
 		"Name":   roleID,
 		"Groups": []string{addGroupToRole.GroupName},
 	}
-	response, err := s.client.Post(context.Background(), addUserToRoleURL, requestBody)
+	response, err := s.client.Post(ctx, addUserToRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to add group to role: %v", err)
 	}
@@ -390,7 +440,7 @@ func (s *ArkIdentityRolesService) AddGroupToRole(addGroupToRole *rolesmodels.Ark
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to add group to role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to add group to role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -406,8 +456,15 @@ func (s *ArkIdentityRolesService) AddGroupToRole(addGroupToRole *rolesmodels.Ark
 
 // AddRoleToRole adds a role to another role in the identity service.
 func (s *ArkIdentityRolesService) AddRoleToRole(addRoleToRole *rolesmodels.ArkIdentityAddRoleToRole) error {
+	return s.AddRoleToRoleWithContext(context.Background(), addRoleToRole)
+}
+
+// AddRoleToRoleWithContext is AddRoleToRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) AddRoleToRoleWithContext(ctx context.Context, addRoleToRole *rolesmodels.ArkIdentityAddRoleToRole) error {
+	ctx, span := s.StartSpan(ctx, "AddRoleToRole")
+	defer span.End()
 	s.Logger.Info("Adding role [%s] to role [%s]", addRoleToRole.RoleNameToAdd, addRoleToRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: addRoleToRole.RoleName})
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: addRoleToRole.RoleName})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 	}
@@ -415,7 +472,7 @@ func (s *ArkIdentityRolesService) AddRoleToRole(addRoleToRole *rolesmodels.ArkId
 		"Name":  roleID,
 		"Roles": []string{addRoleToRole.RoleNameToAdd},
 	}
-	response, err := s.client.Post(context.Background(), addUserToRoleURL, requestBody)
+	response, err := s.client.Post(ctx, addUserToRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to add role to role: %v", err)
 	}
@@ -426,7 +483,7 @@ func (s *ArkIdentityRolesService) AddRoleToRole(addRoleToRole *rolesmodels.ArkId
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to add role to role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to add role to role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -442,8 +499,15 @@ func (s *ArkIdentityRolesService) AddRoleToRole(addRoleToRole *rolesmodels.ArkId
 
 // RemoveUserFromRole removes a user from a role in the identity service.
 func (s *ArkIdentityRolesService) RemoveUserFromRole(removeUserFromRole *rolesmodels.ArkIdentityRemoveUserFromRole) error {
+	return s.RemoveUserFromRoleWithContext(context.Background(), removeUserFromRole)
+}
+
+// RemoveUserFromRoleWithContext is RemoveUserFromRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) RemoveUserFromRoleWithContext(ctx context.Context, removeUserFromRole *rolesmodels.ArkIdentityRemoveUserFromRole) error {
+	ctx, span := s.StartSpan(ctx, "RemoveUserFromRole")
+	defer span.End()
 	s.Logger.Info("Removing user [%s] from role [%s]", removeUserFromRole.Username, removeUserFromRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: removeUserFromRole.RoleName})
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: removeUserFromRole.RoleName})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 	}
@@ -451,7 +515,7 @@ func (s *ArkIdentityRolesService) RemoveUserFromRole(removeUserFromRole *rolesmo
 		"Name":  roleID,
 		"Users": []string{removeUserFromRole.Username},
 	}
-	response, err := s.client.Post(context.Background(), removeUserFromRoleURL, requestBody)
+	response, err := s.client.Post(ctx, removeUserFromRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to remove user from role: %v", err)
 	}
@@ -462,7 +526,7 @@ func (s *ArkIdentityRolesService) RemoveUserFromRole(removeUserFromRole *rolesmo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to remove user from role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to remove user from role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -478,8 +542,15 @@ func (s *ArkIdentityRolesService) RemoveUserFromRole(removeUserFromRole *rolesmo
 
 // RemoveGroupFromRole removes a group from a role in the identity service.
 func (s *ArkIdentityRolesService) RemoveGroupFromRole(removeGroupFromRole *rolesmodels.ArkIdentityRemoveGroupFromRole) error {
+	return s.RemoveGroupFromRoleWithContext(context.Background(), removeGroupFromRole)
+}
+
+// RemoveGroupFromRoleWithContext is RemoveGroupFromRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) RemoveGroupFromRoleWithContext(ctx context.Context, removeGroupFromRole *rolesmodels.ArkIdentityRemoveGroupFromRole) error {
+	ctx, span := s.StartSpan(ctx, "RemoveGroupFromRole")
+	defer span.End()
 	s.Logger.Info("Removing group [%s] from role [%s]", removeGroupFromRole.GroupName, removeGroupFromRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: removeGroupFromRole.RoleName})
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: removeGroupFromRole.RoleName})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 	}
@@ -487,7 +558,7 @@ func (s *ArkIdentityRolesService) RemoveGroupFromRole(removeGroupFromRole *roles
 		"Name":   roleID,
 		"Groups": []string{removeGroupFromRole.GroupName},
 	}
-	response, err := s.client.Post(context.Background(), removeUserFromRoleURL, requestBody)
+	response, err := s.client.Post(ctx, removeUserFromRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to remove group from role: %v", err)
 	}
@@ -498,7 +569,7 @@ func (s *ArkIdentityRolesService) RemoveGroupFromRole(removeGroupFromRole *roles
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to remove group from role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to remove group from role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -514,8 +585,15 @@ func (s *ArkIdentityRolesService) RemoveGroupFromRole(removeGroupFromRole *roles
 
 // RemoveRoleFromRole removes a role from another role in the identity service.
 func (s *ArkIdentityRolesService) RemoveRoleFromRole(removeRoleFromRole *rolesmodels.ArkIdentityRemoveRoleFromRole) error {
+	return s.RemoveRoleFromRoleWithContext(context.Background(), removeRoleFromRole)
+}
+
+// RemoveRoleFromRoleWithContext is RemoveRoleFromRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) RemoveRoleFromRoleWithContext(ctx context.Context, removeRoleFromRole *rolesmodels.ArkIdentityRemoveRoleFromRole) error {
+	ctx, span := s.StartSpan(ctx, "RemoveRoleFromRole")
+	defer span.End()
 	s.Logger.Info("Removing role [%s] from role [%s]", removeRoleFromRole.RoleNameToRemove, removeRoleFromRole.RoleName)
-	roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: removeRoleFromRole.RoleName})
+	roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: removeRoleFromRole.RoleName})
 	if err != nil {
 		return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 	}
@@ -523,7 +601,7 @@ func (s *ArkIdentityRolesService) RemoveRoleFromRole(removeRoleFromRole *rolesmo
 		"Name":  roleID,
 		"Roles": []string{removeRoleFromRole.RoleNameToRemove},
 	}
-	response, err := s.client.Post(context.Background(), removeUserFromRoleURL, requestBody)
+	response, err := s.client.Post(ctx, removeUserFromRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to remove role from role: %v", err)
 	}
@@ -534,7 +612,7 @@ func (s *ArkIdentityRolesService) RemoveRoleFromRole(removeRoleFromRole *rolesmo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to remove role from role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to remove role from role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -550,9 +628,16 @@ func (s *ArkIdentityRolesService) RemoveRoleFromRole(removeRoleFromRole *rolesmo
 
 // DeleteRole deletes a role in the identity service.
 func (s *ArkIdentityRolesService) DeleteRole(deleteRole *rolesmodels.ArkIdentityDeleteRole) error {
+	return s.DeleteRoleWithContext(context.Background(), deleteRole)
+}
+
+// DeleteRoleWithContext is DeleteRole with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityRolesService) DeleteRoleWithContext(ctx context.Context, deleteRole *rolesmodels.ArkIdentityDeleteRole) error {
+	ctx, span := s.StartSpan(ctx, "DeleteRole")
+	defer span.End()
 	s.Logger.Info("Deleting role [%s]", deleteRole.RoleName)
 	if deleteRole.RoleName != "" && deleteRole.RoleID == "" {
-		roleID, err := s.RoleIDByName(&rolesmodels.ArkIdentityRoleIDByName{RoleName: deleteRole.RoleName})
+		roleID, err := s.RoleIDByNameWithContext(ctx, &rolesmodels.ArkIdentityRoleIDByName{RoleName: deleteRole.RoleName})
 		if err != nil {
 			return fmt.Errorf("failed to retrieve role ID by name: %v", err)
 		}
@@ -561,7 +646,7 @@ func (s *ArkIdentityRolesService) DeleteRole(deleteRole *rolesmodels.ArkIdentity
 	requestBody := map[string]interface{}{
 		"Name": deleteRole.RoleID,
 	}
-	response, err := s.client.Post(context.Background(), deleteRoleURL, requestBody)
+	response, err := s.client.Post(ctx, deleteRoleURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to delete role: %v", err)
 	}
@@ -572,7 +657,7 @@ func (s *ArkIdentityRolesService) DeleteRole(deleteRole *rolesmodels.ArkIdentity
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete role - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete role")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
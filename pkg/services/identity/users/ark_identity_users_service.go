@@ -60,6 +60,7 @@ func NewArkIdentityUsersService(authenticators ...auth.ArkAuth) (*ArkIdentityUse
 		"X-IDAP-NATIVE-CLIENT": "true",
 	})
 	identityUsersService.client = client
+	baseService.AddClient(client.ArkClient)
 	identityUsersService.ispAuth = ispAuth
 	identityUsersService.ArkBaseService = baseService
 	return identityUsersService, nil
@@ -75,6 +76,13 @@ func (s *ArkIdentityUsersService) refreshIdentityUsersAuth(client *common.ArkCli
 
 // CreateUser creates a new user in the identity service.
 func (s *ArkIdentityUsersService) CreateUser(createUser *usersmodels.ArkIdentityCreateUser) (*usersmodels.ArkIdentityUser, error) {
+	return s.CreateUserWithContext(context.Background(), createUser)
+}
+
+// CreateUserWithContext is CreateUser with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) CreateUserWithContext(ctx context.Context, createUser *usersmodels.ArkIdentityCreateUser) (*usersmodels.ArkIdentityUser, error) {
+	ctx, span := s.StartSpan(ctx, "CreateUser")
+	defer span.End()
 	if createUser.Username == "" {
 		createUser.Username = fmt.Sprintf("ark_user_%s", common.RandomString(10))
 	}
@@ -116,7 +124,7 @@ func (s *ArkIdentityUsersService) CreateUser(createUser *usersmodels.ArkIdentity
 		"SendEmailInvite":         "false",
 		"SendSmsInvite":           "false",
 	}
-	response, err := s.client.Post(context.Background(), createUserURL, createUserRequest)
+	response, err := s.client.Post(ctx, createUserURL, createUserRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +135,7 @@ func (s *ArkIdentityUsersService) CreateUser(createUser *usersmodels.ArkIdentity
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to create user - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to create user")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -166,10 +174,17 @@ func (s *ArkIdentityUsersService) CreateUser(createUser *usersmodels.ArkIdentity
 
 // UpdateUser updates an existing user in the identity service.
 func (s *ArkIdentityUsersService) UpdateUser(updateUser *usersmodels.ArkIdentityUpdateUser) error {
+	return s.UpdateUserWithContext(context.Background(), updateUser)
+}
+
+// UpdateUserWithContext is UpdateUser with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) UpdateUserWithContext(ctx context.Context, updateUser *usersmodels.ArkIdentityUpdateUser) error {
+	ctx, span := s.StartSpan(ctx, "UpdateUser")
+	defer span.End()
 	s.Logger.Info("Updating identity user [%s]", updateUser.Username)
 	var err error
 	if updateUser.Username != "" && updateUser.UserID == "" {
-		updateUser.UserID, err = s.UserIDByName(&usersmodels.ArkIdentityUserIDByName{Username: updateUser.Username})
+		updateUser.UserID, err = s.UserIDByNameWithContext(ctx, &usersmodels.ArkIdentityUserIDByName{Username: updateUser.Username})
 		if err != nil {
 			return err
 		}
@@ -192,7 +207,7 @@ func (s *ArkIdentityUsersService) UpdateUser(updateUser *usersmodels.ArkIdentity
 		updateMap["MobileNumber"] = updateUser.MobileNumber
 	}
 	updateMap["ID"] = updateUser.UserID
-	response, err := s.client.Post(context.Background(), updateUserURL, updateMap)
+	response, err := s.client.Post(ctx, updateUserURL, updateMap)
 	if err != nil {
 		return err
 	}
@@ -203,7 +218,7 @@ func (s *ArkIdentityUsersService) UpdateUser(updateUser *usersmodels.ArkIdentity
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to update user - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to update user")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -219,6 +234,13 @@ func (s *ArkIdentityUsersService) UpdateUser(updateUser *usersmodels.ArkIdentity
 
 // DeleteUser deletes an existing user in the identity service.
 func (s *ArkIdentityUsersService) DeleteUser(deleteUser *usersmodels.ArkIdentityDeleteUser) error {
+	return s.DeleteUserWithContext(context.Background(), deleteUser)
+}
+
+// DeleteUserWithContext is DeleteUser with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) DeleteUserWithContext(ctx context.Context, deleteUser *usersmodels.ArkIdentityDeleteUser) error {
+	ctx, span := s.StartSpan(ctx, "DeleteUser")
+	defer span.End()
 	s.Logger.Info("Deleting identity user [%s]", deleteUser.Username)
 	if deleteUser.Username == "" && deleteUser.UserID == "" {
 		return fmt.Errorf("userID or username is required")
@@ -228,7 +250,7 @@ func (s *ArkIdentityUsersService) DeleteUser(deleteUser *usersmodels.ArkIdentity
 	if deleteUser.UserID == "" && deleteUser.Username != "" {
 		deleteMap["ID"] = deleteUser.Username
 	}
-	response, err := s.client.Post(context.Background(), deleteUserURL, deleteMap)
+	response, err := s.client.Post(ctx, deleteUserURL, deleteMap)
 	if err != nil {
 		return err
 	}
@@ -239,7 +261,7 @@ func (s *ArkIdentityUsersService) DeleteUser(deleteUser *usersmodels.ArkIdentity
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete user - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete user")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -255,13 +277,20 @@ func (s *ArkIdentityUsersService) DeleteUser(deleteUser *usersmodels.ArkIdentity
 
 // DeleteUsers deletes multiple users in the identity service.
 func (s *ArkIdentityUsersService) DeleteUsers(deleteUsers *usersmodels.ArkIdentityDeleteUsers) error {
+	return s.DeleteUsersWithContext(context.Background(), deleteUsers)
+}
+
+// DeleteUsersWithContext is DeleteUsers with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) DeleteUsersWithContext(ctx context.Context, deleteUsers *usersmodels.ArkIdentityDeleteUsers) error {
+	ctx, span := s.StartSpan(ctx, "DeleteUsers")
+	defer span.End()
 	s.Logger.Info("Deleting identity users [%v]", deleteUsers.UserIDs)
 	if len(deleteUsers.UserIDs) == 0 {
 		return fmt.Errorf("userIDs is required")
 	}
 	deleteMap := make(map[string]interface{})
 	deleteMap["Users"] = deleteUsers.UserIDs
-	response, err := s.client.Post(context.Background(), removeUsersURL, deleteMap)
+	response, err := s.client.Post(ctx, removeUsersURL, deleteMap)
 	if err != nil {
 		return err
 	}
@@ -272,7 +301,7 @@ func (s *ArkIdentityUsersService) DeleteUsers(deleteUsers *usersmodels.ArkIdenti
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete users - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete users")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -288,6 +317,13 @@ func (s *ArkIdentityUsersService) DeleteUsers(deleteUsers *usersmodels.ArkIdenti
 
 // UserIDByName retrieves the user ID by username.
 func (s *ArkIdentityUsersService) UserIDByName(user *usersmodels.ArkIdentityUserIDByName) (string, error) {
+	return s.UserIDByNameWithContext(context.Background(), user)
+}
+
+// UserIDByNameWithContext is UserIDByName with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) UserIDByNameWithContext(ctx context.Context, user *usersmodels.ArkIdentityUserIDByName) (string, error) {
+	ctx, span := s.StartSpan(ctx, "UserIDByName")
+	defer span.End()
 	s.Logger.Info("Getting identity user ID by name [%s]", user.Username)
 	if user.Username == "" {
 		return "", fmt.Errorf("username is required")
@@ -295,7 +331,7 @@ func (s *ArkIdentityUsersService) UserIDByName(user *usersmodels.ArkIdentityUser
 	redrockQuery := map[string]interface{}{
 		"Script": fmt.Sprintf("Select ID, Username from User WHERE Username='%s'", strings.ToLower(user.Username)),
 	}
-	response, err := s.client.Post(context.Background(), redrockQueryURL, redrockQuery)
+	response, err := s.client.Post(ctx, redrockQueryURL, redrockQuery)
 	if err != nil {
 		return "", err
 	}
@@ -306,7 +342,7 @@ func (s *ArkIdentityUsersService) UserIDByName(user *usersmodels.ArkIdentityUser
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get user ID - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "failed to get user ID")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -324,6 +360,13 @@ func (s *ArkIdentityUsersService) UserIDByName(user *usersmodels.ArkIdentityUser
 
 // UserByName retrieves the user by username.
 func (s *ArkIdentityUsersService) UserByName(user *usersmodels.ArkIdentityUserByName) (*usersmodels.ArkIdentityUser, error) {
+	return s.UserByNameWithContext(context.Background(), user)
+}
+
+// UserByNameWithContext is UserByName with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) UserByNameWithContext(ctx context.Context, user *usersmodels.ArkIdentityUserByName) (*usersmodels.ArkIdentityUser, error) {
+	ctx, span := s.StartSpan(ctx, "UserByName")
+	defer span.End()
 	s.Logger.Info("Getting identity user by name [%s]", user.Username)
 	if user.Username == "" {
 		return nil, fmt.Errorf("username is required")
@@ -331,7 +374,7 @@ func (s *ArkIdentityUsersService) UserByName(user *usersmodels.ArkIdentityUserBy
 	redrockQuery := map[string]interface{}{
 		"Script": fmt.Sprintf("Select ID, Username, DisplayName, Email, MobileNumber, LastLogin from User WHERE Username='%s'", strings.ToLower(user.Username)),
 	}
-	response, err := s.client.Post(context.Background(), redrockQueryURL, redrockQuery)
+	response, err := s.client.Post(ctx, redrockQueryURL, redrockQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -342,7 +385,7 @@ func (s *ArkIdentityUsersService) UserByName(user *usersmodels.ArkIdentityUserBy
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get user - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get user")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -382,6 +425,13 @@ func (s *ArkIdentityUsersService) UserByName(user *usersmodels.ArkIdentityUserBy
 
 // UserByID retrieves the user by user ID.
 func (s *ArkIdentityUsersService) UserByID(userByID *usersmodels.ArkIdentityUserByID) (*usersmodels.ArkIdentityUser, error) {
+	return s.UserByIDWithContext(context.Background(), userByID)
+}
+
+// UserByIDWithContext is UserByID with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) UserByIDWithContext(ctx context.Context, userByID *usersmodels.ArkIdentityUserByID) (*usersmodels.ArkIdentityUser, error) {
+	ctx, span := s.StartSpan(ctx, "UserByID")
+	defer span.End()
 	s.Logger.Info("Getting identity user by id [%s]", userByID.UserID)
 	if userByID.UserID == "" {
 		return nil, fmt.Errorf("userID is required")
@@ -389,7 +439,7 @@ func (s *ArkIdentityUsersService) UserByID(userByID *usersmodels.ArkIdentityUser
 	redrockQuery := map[string]interface{}{
 		"Script": fmt.Sprintf("Select ID, Username, DisplayName, Email, MobileNumber, LastLogin from User WHERE ID='%s'", userByID.UserID),
 	}
-	response, err := s.client.Post(context.Background(), redrockQueryURL, redrockQuery)
+	response, err := s.client.Post(ctx, redrockQueryURL, redrockQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -400,7 +450,7 @@ func (s *ArkIdentityUsersService) UserByID(userByID *usersmodels.ArkIdentityUser
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get user - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get user")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -440,15 +490,22 @@ func (s *ArkIdentityUsersService) UserByID(userByID *usersmodels.ArkIdentityUser
 
 // ResetUserPassword resets the password for an existing user in the identity service.
 func (s *ArkIdentityUsersService) ResetUserPassword(resetUserPassword *usersmodels.ArkIdentityResetUserPassword) error {
+	return s.ResetUserPasswordWithContext(context.Background(), resetUserPassword)
+}
+
+// ResetUserPasswordWithContext is ResetUserPassword with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) ResetUserPasswordWithContext(ctx context.Context, resetUserPassword *usersmodels.ArkIdentityResetUserPassword) error {
+	ctx, span := s.StartSpan(ctx, "ResetUserPassword")
+	defer span.End()
 	s.Logger.Info("Resetting identity user password [%s]", resetUserPassword.Username)
-	userID, err := s.UserIDByName(&usersmodels.ArkIdentityUserIDByName{Username: resetUserPassword.Username})
+	userID, err := s.UserIDByNameWithContext(ctx, &usersmodels.ArkIdentityUserIDByName{Username: resetUserPassword.Username})
 	if err != nil {
 		return err
 	}
 	resetPasswordMap := make(map[string]interface{})
 	resetPasswordMap["ID"] = userID
 	resetPasswordMap["newPassword"] = resetUserPassword.NewPassword
-	response, err := s.client.Post(context.Background(), resetUserPasswordURL, resetPasswordMap)
+	response, err := s.client.Post(ctx, resetUserPasswordURL, resetPasswordMap)
 	if err != nil {
 		return err
 	}
@@ -459,7 +516,7 @@ func (s *ArkIdentityUsersService) ResetUserPassword(resetUserPassword *usersmode
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to reset user password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to reset user password")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -475,11 +532,18 @@ func (s *ArkIdentityUsersService) ResetUserPassword(resetUserPassword *usersmode
 
 // UserInfo retrieves the user info from the identity service.
 func (s *ArkIdentityUsersService) UserInfo() (*usersmodels.ArkIdentityUserInfo, error) {
+	return s.UserInfoWithContext(context.Background())
+}
+
+// UserInfoWithContext is UserInfo with a caller provided context that is passed down to every request made by the call.
+func (s *ArkIdentityUsersService) UserInfoWithContext(ctx context.Context) (*usersmodels.ArkIdentityUserInfo, error) {
+	ctx, span := s.StartSpan(ctx, "UserInfo")
+	defer span.End()
 	s.Logger.Info("Getting identity user info")
 	userInfoMap := map[string]interface{}{
 		"Scopes": []string{"userInfo"},
 	}
-	response, err := s.client.Post(context.Background(), userInfoURL, userInfoMap)
+	response, err := s.client.Post(ctx, userInfoURL, userInfoMap)
 	if err != nil {
 		return nil, err
 	}
@@ -490,7 +554,7 @@ func (s *ArkIdentityUsersService) UserInfo() (*usersmodels.ArkIdentityUserInfo,
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get user info - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get user info")
 	}
 	var result map[string]interface{}
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -4,6 +4,7 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	identityusersactions "github.com/cyberark/ark-sdk-golang/pkg/services/identity/users/actions"
+	usersmodels "github.com/cyberark/ark-sdk-golang/pkg/services/identity/users/models"
 )
 
 // ServiceConfig is the configuration for the identity users service.
@@ -16,6 +17,10 @@ var ServiceConfig = services.ArkServiceConfig{
 			identityusersactions.CLIAction,
 		},
 	},
+	RedactedModels: []interface{}{
+		&usersmodels.ArkIdentityCreateUser{},
+		&usersmodels.ArkIdentityResetUserPassword{},
+	},
 }
 
 // ServiceGenerator is the function that generates a new instance of the ArkIdentityUsersService.
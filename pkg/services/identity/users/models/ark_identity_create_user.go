@@ -11,6 +11,6 @@ type ArkIdentityCreateUser struct {
 	Email        string   `json:"email,omitempty" mapstructure:"email" flag:"email" desc:"Email of the user"`
 	MobileNumber string   `json:"mobile_number,omitempty" mapstructure:"mobile_number" flag:"mobile-number" desc:"Mobile number of the user"`
 	Suffix       string   `json:"suffix,omitempty" mapstructure:"suffix" flag:"suffix" desc:"Suffix to use for the username"`
-	Password     string   `json:"password" mapstructure:"password" flag:"password" desc:"Password of the user"`
+	Password     string   `json:"password" mapstructure:"password" flag:"password" desc:"Password of the user" redact:"true"`
 	Roles        []string `json:"roles" mapstructure:"roles" flag:"roles" desc:"Roles to add the user to, defaulted to DpaAdmin,global auditor,System Administrator"`
 }
@@ -0,0 +1,59 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cyberark/ark-sdk-golang/pkg/common"
+	accountsmodels "github.com/cyberark/ark-sdk-golang/pkg/services/pcloud/accounts/models"
+)
+
+// ArkPCloudSecretReferenceScheme is the scheme of secret references to pCloud accounts, such as "pcloud:SAFE/ACCOUNT".
+const ArkPCloudSecretReferenceScheme = "pcloud"
+
+// SecretResolver returns a secret resolver of references to pCloud accounts, fetching their credentials with AccountCredentials.
+//
+// References are either the id of the account, or the safe name and account name separated by a slash, such as "Safe/Account".
+// The resolver is registered for the pcloud scheme with common.RegisterArkSecretResolver, so that auth profiles of other
+// tenants or of service users can reference passwords stored in the vault.
+//
+// Example:
+//
+//	err := common.RegisterArkSecretResolver(accounts.ArkPCloudSecretReferenceScheme, accountsService.SecretResolver())
+func (s *ArkPCloudAccountsService) SecretResolver() common.ArkSecretResolver {
+	return common.ArkSecretResolverFunc(s.resolveAccountSecret)
+}
+
+func (s *ArkPCloudAccountsService) resolveAccountSecret(ctx context.Context, reference string) (string, error) {
+	accountID := reference
+	if safeName, accountName, found := strings.Cut(reference, "/"); found {
+		var err error
+		accountID, err = s.accountIDByName(ctx, safeName, accountName)
+		if err != nil {
+			return "", err
+		}
+	}
+	credentials, err := s.AccountCredentialsWithContext(ctx, &accountsmodels.ArkPCloudGetAccountCredentials{
+		AccountID:  accountID,
+		ActionType: accountsmodels.Show,
+	})
+	if err != nil {
+		return "", err
+	}
+	return credentials.Password, nil
+}
+
+func (s *ArkPCloudAccountsService) accountIDByName(ctx context.Context, safeName string, accountName string) (string, error) {
+	for page, err := range s.ListAccountsByIter(ctx, &accountsmodels.ArkPCloudAccountsFilter{Search: accountName, SafeName: safeName}) {
+		if err != nil {
+			return "", err
+		}
+		for _, account := range page.Items {
+			if account.SafeName == safeName && account.Name == accountName {
+				return account.AccountID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("account [%s] was not found in safe [%s]", accountName, safeName)
+}
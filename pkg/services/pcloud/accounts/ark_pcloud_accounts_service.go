@@ -13,7 +13,6 @@ import (
 
 	"io"
 	"net/http"
-	"net/url"
 	"strings"
 )
 
@@ -62,6 +61,7 @@ func NewArkPCloudAccountsService(authenticators ...auth.ArkAuth) (*ArkPCloudAcco
 		return nil, err
 	}
 	pcloudAccountsService.client = client
+	baseService.AddClient(client.ArkClient)
 	pcloudAccountsService.ispAuth = ispAuth
 	pcloudAccountsService.ArkBaseService = baseService
 	return pcloudAccountsService, nil
@@ -76,13 +76,14 @@ func (s *ArkPCloudAccountsService) refreshPCloudAccountsAuth(client *common.ArkC
 }
 
 func (s *ArkPCloudAccountsService) listAccountsWithFilters(
+	ctx context.Context,
 	search string,
 	searchType string,
 	sort string,
 	offset int,
 	limit int,
 	safeName string,
-) (<-chan *ArkPCloudAccountsPage, error) {
+) common.ArkPageIterator[accountsmodels.ArkPCloudAccount] {
 	query := map[string]string{}
 	if search != "" {
 		query["search"] = search
@@ -102,102 +103,114 @@ func (s *ArkPCloudAccountsService) listAccountsWithFilters(
 	if safeName != "" {
 		query["filter"] = fmt.Sprintf("safeName eq %s", safeName)
 	}
-	results := make(chan *ArkPCloudAccountsPage)
-	go func() {
-		defer close(results)
-		for {
-			response, err := s.client.Get(context.Background(), accountsURL, query)
-			if err != nil {
-				s.Logger.Error("Failed to list accounts: %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list accounts - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
-			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
-				return
-			}
-			resultMap := result.(map[string]interface{})
-			var accountsJSON []interface{}
-			if value, ok := resultMap["value"]; ok {
-				accountsJSON = value.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list accounts, unexpected result")
-				return
-			}
-			for i, account := range accountsJSON {
-				if accountMap, ok := account.(map[string]interface{}); ok {
-					if accountID, ok := accountMap["id"]; ok {
-						accountsJSON[i].(map[string]interface{})["account_id"] = accountID
-					}
-					if userName, ok := accountMap["user_name"]; ok {
-						accountsJSON[i].(map[string]interface{})["username"] = userName
-					}
-				}
-			}
-			var accounts []*accountsmodels.ArkPCloudAccount
-			if err := mapstructure.Decode(accountsJSON, &accounts); err != nil {
-				s.Logger.Error("Failed to validate accounts: %v", err)
-				return
+	return common.NewArkNextLinkPageIterator(ctx, query, s.listAccountsPage)
+}
+
+func (s *ArkPCloudAccountsService) listAccountsPage(ctx context.Context, query map[string]string) (*ArkPCloudAccountsPage, string, error) {
+	response, err := s.client.Get(ctx, accountsURL, query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", common.NewArkAPIError(response, "failed to list accounts")
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	resultMap := result.(map[string]interface{})
+	var accountsJSON []interface{}
+	if value, ok := resultMap["value"]; ok {
+		accountsJSON = value.([]interface{})
+	} else {
+		return nil, "", fmt.Errorf("failed to list accounts, unexpected result")
+	}
+	for i, account := range accountsJSON {
+		if accountMap, ok := account.(map[string]interface{}); ok {
+			if accountID, ok := accountMap["id"]; ok {
+				accountsJSON[i].(map[string]interface{})["account_id"] = accountID
 			}
-			results <- &ArkPCloudAccountsPage{Items: accounts}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
-				}
-			} else {
-				break
+			if userName, ok := accountMap["user_name"]; ok {
+				accountsJSON[i].(map[string]interface{})["username"] = userName
 			}
 		}
-	}()
-	return results, nil
+	}
+	var accounts []*accountsmodels.ArkPCloudAccount
+	if err := mapstructure.Decode(accountsJSON, &accounts); err != nil {
+		return nil, "", err
+	}
+	nextLink, _ := resultMap["nextLink"].(string)
+	return &ArkPCloudAccountsPage{Items: accounts}, nextLink, nil
 }
 
 // ListAccounts retrieves a list of ArkPCloudAccount pages.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/GetAccounts.htm
 func (s *ArkPCloudAccountsService) ListAccounts() (<-chan *ArkPCloudAccountsPage, error) {
-	return s.listAccountsWithFilters(
-		"",
-		"",
-		"",
-		0,
-		0,
-		"",
-	)
+	return s.ListAccountsWithContext(context.Background())
+}
+
+// ListAccountsWithContext is ListAccounts with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) ListAccountsWithContext(ctx context.Context) (<-chan *ArkPCloudAccountsPage, error) {
+	return common.ArkPageChannel(ctx, s.ListAccountsIter(ctx), s.Logger), nil
+}
+
+// ListAccountsIter returns an iterator over all the accounts pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkPCloudAccountsService) ListAccountsIter(ctx context.Context) common.ArkPageIterator[accountsmodels.ArkPCloudAccount] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListAccounts", func(ctx context.Context) common.ArkPageIterator[accountsmodels.ArkPCloudAccount] {
+		return s.listAccountsWithFilters(ctx,
+			"",
+			"",
+			"",
+			0,
+			0,
+			"",
+		)
+	})
 }
 
 // ListAccountsBy retrieves a list of ArkPCloudAccount pages with filters.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/GetAccounts.htm
 func (s *ArkPCloudAccountsService) ListAccountsBy(accountsFilters *accountsmodels.ArkPCloudAccountsFilter) (<-chan *ArkPCloudAccountsPage, error) {
-	return s.listAccountsWithFilters(
-		accountsFilters.Search,
-		accountsFilters.SearchType,
-		accountsFilters.Sort,
-		accountsFilters.Offset,
-		accountsFilters.Limit,
-		accountsFilters.SafeName,
-	)
+	return s.ListAccountsByWithContext(context.Background(), accountsFilters)
+}
+
+// ListAccountsByWithContext is ListAccountsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) ListAccountsByWithContext(ctx context.Context, accountsFilters *accountsmodels.ArkPCloudAccountsFilter) (<-chan *ArkPCloudAccountsPage, error) {
+	return common.ArkPageChannel(ctx, s.ListAccountsByIter(ctx, accountsFilters), s.Logger), nil
+}
+
+// ListAccountsByIter returns an iterator over the accounts pages filtered by the given filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkPCloudAccountsService) ListAccountsByIter(ctx context.Context, accountsFilters *accountsmodels.ArkPCloudAccountsFilter) common.ArkPageIterator[accountsmodels.ArkPCloudAccount] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListAccountsBy", func(ctx context.Context) common.ArkPageIterator[accountsmodels.ArkPCloudAccount] {
+		return s.listAccountsWithFilters(ctx,
+			accountsFilters.Search,
+			accountsFilters.SearchType,
+			accountsFilters.Sort,
+			accountsFilters.Offset,
+			accountsFilters.Limit,
+			accountsFilters.SafeName,
+		)
+	})
 }
 
 // ListAccountSecretVersions retrieves a list of ArkPCloudAccountSecretVersion.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Secrets-Get-versions.htm
 func (s *ArkPCloudAccountsService) ListAccountSecretVersions(listAccountSecretVersions *accountsmodels.ArkPCloudListAccountSecretVersions) ([]*accountsmodels.ArkPCloudAccountSecretVersion, error) {
+	return s.ListAccountSecretVersionsWithContext(context.Background(), listAccountSecretVersions)
+}
+
+// ListAccountSecretVersionsWithContext is ListAccountSecretVersions with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) ListAccountSecretVersionsWithContext(ctx context.Context, listAccountSecretVersions *accountsmodels.ArkPCloudListAccountSecretVersions) ([]*accountsmodels.ArkPCloudAccountSecretVersion, error) {
+	ctx, span := s.StartSpan(ctx, "ListAccountSecretVersions")
+	defer span.End()
 	s.Logger.Info("Retrieving account secret versions [%s]", listAccountSecretVersions.AccountID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(accountSecretVersionsURL, listAccountSecretVersions.AccountID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(accountSecretVersionsURL, listAccountSecretVersions.AccountID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +221,7 @@ func (s *ArkPCloudAccountsService) ListAccountSecretVersions(listAccountSecretVe
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get account secret versions - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get account secret versions")
 	}
 	accountsSecretVersionsJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -226,8 +239,15 @@ func (s *ArkPCloudAccountsService) ListAccountSecretVersions(listAccountSecretVe
 // GenerateAccountCredentials generate a new random password for an existing account with policy restrictions.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Secrets-Generate-Password.htm
 func (s *ArkPCloudAccountsService) GenerateAccountCredentials(generateAccountCredentials *accountsmodels.ArkPCloudGenerateAccountCredentials) (*accountsmodels.ArkPCloudAccountCredentials, error) {
+	return s.GenerateAccountCredentialsWithContext(context.Background(), generateAccountCredentials)
+}
+
+// GenerateAccountCredentialsWithContext is GenerateAccountCredentials with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) GenerateAccountCredentialsWithContext(ctx context.Context, generateAccountCredentials *accountsmodels.ArkPCloudGenerateAccountCredentials) (*accountsmodels.ArkPCloudAccountCredentials, error) {
+	ctx, span := s.StartSpan(ctx, "GenerateAccountCredentials")
+	defer span.End()
 	s.Logger.Info("Generating account credentials [%s]", generateAccountCredentials.AccountID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(generateAccountCredentialsURL, generateAccountCredentials.AccountID), nil)
+	response, err := s.client.Post(ctx, fmt.Sprintf(generateAccountCredentialsURL, generateAccountCredentials.AccountID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -238,7 +258,7 @@ func (s *ArkPCloudAccountsService) GenerateAccountCredentials(generateAccountCre
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to generate account credentials - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to generate account credentials")
 	}
 	accountSecretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -256,8 +276,15 @@ func (s *ArkPCloudAccountsService) GenerateAccountCredentials(generateAccountCre
 // VerifyAccountCredentials marks the account for password verification by CPM.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Verify-credentials-v9-10.htm
 func (s *ArkPCloudAccountsService) VerifyAccountCredentials(verifyAccountCredentials *accountsmodels.ArkPCloudVerifyAccountCredentials) error {
+	return s.VerifyAccountCredentialsWithContext(context.Background(), verifyAccountCredentials)
+}
+
+// VerifyAccountCredentialsWithContext is VerifyAccountCredentials with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) VerifyAccountCredentialsWithContext(ctx context.Context, verifyAccountCredentials *accountsmodels.ArkPCloudVerifyAccountCredentials) error {
+	ctx, span := s.StartSpan(ctx, "VerifyAccountCredentials")
+	defer span.End()
 	s.Logger.Info("Verifying account credentials [%s]", verifyAccountCredentials.AccountID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(verifyAccountCredentialsURL, verifyAccountCredentials.AccountID), nil)
+	response, err := s.client.Post(ctx, fmt.Sprintf(verifyAccountCredentialsURL, verifyAccountCredentials.AccountID), nil)
 	if err != nil {
 		return err
 	}
@@ -268,7 +295,7 @@ func (s *ArkPCloudAccountsService) VerifyAccountCredentials(verifyAccountCredent
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to verify account credentials - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to verify account credentials")
 	}
 	return nil
 }
@@ -276,8 +303,15 @@ func (s *ArkPCloudAccountsService) VerifyAccountCredentials(verifyAccountCredent
 // ChangeAccountCredentials marks the account for password changing immediately by CPM.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Change-credentials-immediately.htm
 func (s *ArkPCloudAccountsService) ChangeAccountCredentials(changeAccountCredentials *accountsmodels.ArkPCloudChangeAccountCredentials) error {
+	return s.ChangeAccountCredentialsWithContext(context.Background(), changeAccountCredentials)
+}
+
+// ChangeAccountCredentialsWithContext is ChangeAccountCredentials with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) ChangeAccountCredentialsWithContext(ctx context.Context, changeAccountCredentials *accountsmodels.ArkPCloudChangeAccountCredentials) error {
+	ctx, span := s.StartSpan(ctx, "ChangeAccountCredentials")
+	defer span.End()
 	s.Logger.Info("Changing account credentials [%s]", changeAccountCredentials.AccountID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(changeAccountCredentialsURL, changeAccountCredentials.AccountID), nil)
+	response, err := s.client.Post(ctx, fmt.Sprintf(changeAccountCredentialsURL, changeAccountCredentials.AccountID), nil)
 	if err != nil {
 		return err
 	}
@@ -288,7 +322,7 @@ func (s *ArkPCloudAccountsService) ChangeAccountCredentials(changeAccountCredent
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to change account credentials - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to change account credentials")
 	}
 	return nil
 }
@@ -296,13 +330,20 @@ func (s *ArkPCloudAccountsService) ChangeAccountCredentials(changeAccountCredent
 // SetAccountNextCredentials marks the account to have its password changed to the given one via CPM.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/SetNextPassword.htm
 func (s *ArkPCloudAccountsService) SetAccountNextCredentials(setAccountNextCredentials *accountsmodels.ArkPCloudSetAccountNextCredentials) error {
+	return s.SetAccountNextCredentialsWithContext(context.Background(), setAccountNextCredentials)
+}
+
+// SetAccountNextCredentialsWithContext is SetAccountNextCredentials with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) SetAccountNextCredentialsWithContext(ctx context.Context, setAccountNextCredentials *accountsmodels.ArkPCloudSetAccountNextCredentials) error {
+	ctx, span := s.StartSpan(ctx, "SetAccountNextCredentials")
+	defer span.End()
 	s.Logger.Info("Setting account next credentials [%s]", setAccountNextCredentials.AccountID)
 	setAccountNextCredentialsJSON, err := common.SerializeJSONCamel(setAccountNextCredentials)
 	if err != nil {
 		return err
 	}
 	delete(setAccountNextCredentialsJSON, "accountId")
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(setAccountNextCredentialsURL, setAccountNextCredentials.AccountID), setAccountNextCredentialsJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(setAccountNextCredentialsURL, setAccountNextCredentials.AccountID), setAccountNextCredentialsJSON)
 	if err != nil {
 		return err
 	}
@@ -313,7 +354,7 @@ func (s *ArkPCloudAccountsService) SetAccountNextCredentials(setAccountNextCrede
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to set account next credentials - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to set account next credentials")
 	}
 	return nil
 }
@@ -321,13 +362,20 @@ func (s *ArkPCloudAccountsService) SetAccountNextCredentials(setAccountNextCrede
 // UpdateAccountCredentialsInVault updates the account credentials only in the vault without changing it on the machine itself.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/ChangeCredentialsInVault.htm
 func (s *ArkPCloudAccountsService) UpdateAccountCredentialsInVault(updateAccountCredentialsInVault *accountsmodels.ArkPCloudUpdateAccountCredentialsInVault) error {
+	return s.UpdateAccountCredentialsInVaultWithContext(context.Background(), updateAccountCredentialsInVault)
+}
+
+// UpdateAccountCredentialsInVaultWithContext is UpdateAccountCredentialsInVault with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) UpdateAccountCredentialsInVaultWithContext(ctx context.Context, updateAccountCredentialsInVault *accountsmodels.ArkPCloudUpdateAccountCredentialsInVault) error {
+	ctx, span := s.StartSpan(ctx, "UpdateAccountCredentialsInVault")
+	defer span.End()
 	s.Logger.Info("Updating account credentials in vault [%s]", updateAccountCredentialsInVault.AccountID)
 	updateAccountCredentialsInVaultJSON, err := common.SerializeJSONCamel(updateAccountCredentialsInVault)
 	if err != nil {
 		return err
 	}
 	delete(updateAccountCredentialsInVaultJSON, "accountId")
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(updateAccountCredentialsInVaultURL, updateAccountCredentialsInVault.AccountID), updateAccountCredentialsInVaultJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(updateAccountCredentialsInVaultURL, updateAccountCredentialsInVault.AccountID), updateAccountCredentialsInVaultJSON)
 	if err != nil {
 		return err
 	}
@@ -338,7 +386,7 @@ func (s *ArkPCloudAccountsService) UpdateAccountCredentialsInVault(updateAccount
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to update account credentials in vault - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to update account credentials in vault")
 	}
 	return nil
 }
@@ -346,8 +394,15 @@ func (s *ArkPCloudAccountsService) UpdateAccountCredentialsInVault(updateAccount
 // ReconcileAccountCredentials marks the account for reconciliation.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Reconcile-account.htm
 func (s *ArkPCloudAccountsService) ReconcileAccountCredentials(reconcileAccountCredentials *accountsmodels.ArkPCloudReconcileAccountCredentials) error {
+	return s.ReconcileAccountCredentialsWithContext(context.Background(), reconcileAccountCredentials)
+}
+
+// ReconcileAccountCredentialsWithContext is ReconcileAccountCredentials with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) ReconcileAccountCredentialsWithContext(ctx context.Context, reconcileAccountCredentials *accountsmodels.ArkPCloudReconcileAccountCredentials) error {
+	ctx, span := s.StartSpan(ctx, "ReconcileAccountCredentials")
+	defer span.End()
 	s.Logger.Info("Reconciling account credentials [%s]", reconcileAccountCredentials.AccountID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(reconcileAccountCredentialsURL, reconcileAccountCredentials.AccountID), nil)
+	response, err := s.client.Post(ctx, fmt.Sprintf(reconcileAccountCredentialsURL, reconcileAccountCredentials.AccountID), nil)
 	if err != nil {
 		return err
 	}
@@ -358,7 +413,7 @@ func (s *ArkPCloudAccountsService) ReconcileAccountCredentials(reconcileAccountC
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to reconcile account credentials - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to reconcile account credentials")
 	}
 	return nil
 }
@@ -366,8 +421,15 @@ func (s *ArkPCloudAccountsService) ReconcileAccountCredentials(reconcileAccountC
 // Account retrieves an ArkPCloudAccount by its ID.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Get%20Account%20Details.htm?
 func (s *ArkPCloudAccountsService) Account(getAccount *accountsmodels.ArkPCloudGetAccount) (*accountsmodels.ArkPCloudAccount, error) {
+	return s.AccountWithContext(context.Background(), getAccount)
+}
+
+// AccountWithContext is Account with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) AccountWithContext(ctx context.Context, getAccount *accountsmodels.ArkPCloudGetAccount) (*accountsmodels.ArkPCloudAccount, error) {
+	ctx, span := s.StartSpan(ctx, "Account")
+	defer span.End()
 	s.Logger.Info("Retrieving account [%s]", getAccount.AccountID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(accountURL, getAccount.AccountID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(accountURL, getAccount.AccountID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -378,7 +440,7 @@ func (s *ArkPCloudAccountsService) Account(getAccount *accountsmodels.ArkPCloudG
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve account")
 	}
 	accountJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -402,6 +464,13 @@ func (s *ArkPCloudAccountsService) Account(getAccount *accountsmodels.ArkPCloudG
 // AccountCredentials retrieves the credentials of an ArkPCloudAccount by its ID.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/GetPasswordValueV10.htm?
 func (s *ArkPCloudAccountsService) AccountCredentials(getAccount *accountsmodels.ArkPCloudGetAccountCredentials) (*accountsmodels.ArkPCloudAccountCredentials, error) {
+	return s.AccountCredentialsWithContext(context.Background(), getAccount)
+}
+
+// AccountCredentialsWithContext is AccountCredentials with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) AccountCredentialsWithContext(ctx context.Context, getAccount *accountsmodels.ArkPCloudGetAccountCredentials) (*accountsmodels.ArkPCloudAccountCredentials, error) {
+	ctx, span := s.StartSpan(ctx, "AccountCredentials")
+	defer span.End()
 	s.Logger.Info("Retrieving account credentials [%s]", getAccount.AccountID)
 	accountCredentialsJSON, err := common.SerializeJSONCamel(getAccount)
 	if err != nil {
@@ -414,7 +483,7 @@ func (s *ArkPCloudAccountsService) AccountCredentials(getAccount *accountsmodels
 		key = strings.Title(key)
 		accountCredentialsJSONCamel[key] = value
 	}
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(retrieveAccountCredentialsURL, getAccount.AccountID), accountCredentialsJSONCamel)
+	response, err := s.client.Post(ctx, fmt.Sprintf(retrieveAccountCredentialsURL, getAccount.AccountID), accountCredentialsJSONCamel)
 	if err != nil {
 		return nil, err
 	}
@@ -425,7 +494,7 @@ func (s *ArkPCloudAccountsService) AccountCredentials(getAccount *accountsmodels
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve account credentials - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve account credentials")
 	}
 	rawData, err := io.ReadAll(response.Body)
 	if err != nil {
@@ -441,6 +510,13 @@ func (s *ArkPCloudAccountsService) AccountCredentials(getAccount *accountsmodels
 // AddAccount adds a new ArkPCloudAccount.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Add%20Account%20v10.htm?
 func (s *ArkPCloudAccountsService) AddAccount(addAccount *accountsmodels.ArkPCloudAddAccount) (*accountsmodels.ArkPCloudAccount, error) {
+	return s.AddAccountWithContext(context.Background(), addAccount)
+}
+
+// AddAccountWithContext is AddAccount with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) AddAccountWithContext(ctx context.Context, addAccount *accountsmodels.ArkPCloudAddAccount) (*accountsmodels.ArkPCloudAccount, error) {
+	ctx, span := s.StartSpan(ctx, "AddAccount")
+	defer span.End()
 	s.Logger.Info("Adding account [%s]", addAccount.Name)
 	addAccountJSON, err := common.SerializeJSONCamel(addAccount)
 	if err != nil {
@@ -473,7 +549,7 @@ func (s *ArkPCloudAccountsService) AddAccount(addAccount *accountsmodels.ArkPClo
 			addAccountJSON["remoteMachinesAccess"].(map[string]interface{})["accessRestrictedToRemoteMachines"] = addAccount.AccessRestrictedToRemoteMachines
 		}
 	}
-	response, err := s.client.Post(context.Background(), accountsURL, addAccountJSON)
+	response, err := s.client.Post(ctx, accountsURL, addAccountJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -484,7 +560,7 @@ func (s *ArkPCloudAccountsService) AddAccount(addAccount *accountsmodels.ArkPClo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add account")
 	}
 	accountJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -509,6 +585,13 @@ func (s *ArkPCloudAccountsService) AddAccount(addAccount *accountsmodels.ArkPClo
 // UpdateAccount updates an existing ArkPCloudAccount.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/UpdateAccount%20v10.htm
 func (s *ArkPCloudAccountsService) UpdateAccount(updateAccount *accountsmodels.ArkPCloudUpdateAccount) (*accountsmodels.ArkPCloudAccount, error) {
+	return s.UpdateAccountWithContext(context.Background(), updateAccount)
+}
+
+// UpdateAccountWithContext is UpdateAccount with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) UpdateAccountWithContext(ctx context.Context, updateAccount *accountsmodels.ArkPCloudUpdateAccount) (*accountsmodels.ArkPCloudAccount, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateAccount")
+	defer span.End()
 	s.Logger.Info("Updating account [%s]", updateAccount.AccountID)
 	updateAccountJSON, err := common.SerializeJSONCamel(updateAccount)
 	if err != nil {
@@ -553,7 +636,7 @@ func (s *ArkPCloudAccountsService) UpdateAccount(updateAccount *accountsmodels.A
 	}
 	var account accountsmodels.ArkPCloudAccount
 	if len(operations) == 0 {
-		pcloudAccount, err := s.Account(&accountsmodels.ArkPCloudGetAccount{
+		pcloudAccount, err := s.AccountWithContext(ctx, &accountsmodels.ArkPCloudGetAccount{
 			AccountID: updateAccount.AccountID,
 		})
 		if err != nil {
@@ -561,7 +644,7 @@ func (s *ArkPCloudAccountsService) UpdateAccount(updateAccount *accountsmodels.A
 		}
 		account = *pcloudAccount
 	} else {
-		response, err := s.client.Patch(context.Background(), fmt.Sprintf(accountURL, updateAccount.AccountID), operations)
+		response, err := s.client.Patch(ctx, fmt.Sprintf(accountURL, updateAccount.AccountID), operations)
 		if err != nil {
 			return nil, err
 		}
@@ -572,7 +655,7 @@ func (s *ArkPCloudAccountsService) UpdateAccount(updateAccount *accountsmodels.A
 			}
 		}(response.Body)
 		if response.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to update account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+			return nil, common.NewArkAPIError(response, "failed to update account")
 		}
 		accountJSON, err := common.DeserializeJSONSnake(response.Body)
 		if err != nil {
@@ -591,7 +674,7 @@ func (s *ArkPCloudAccountsService) UpdateAccount(updateAccount *accountsmodels.A
 		}
 	}
 	if updateAccount.Secret != "" {
-		err = s.UpdateAccountCredentialsInVault(&accountsmodels.ArkPCloudUpdateAccountCredentialsInVault{
+		err = s.UpdateAccountCredentialsInVaultWithContext(ctx, &accountsmodels.ArkPCloudUpdateAccountCredentialsInVault{
 			AccountID:      updateAccount.AccountID,
 			NewCredentials: updateAccount.Secret,
 		})
@@ -605,8 +688,15 @@ func (s *ArkPCloudAccountsService) UpdateAccount(updateAccount *accountsmodels.A
 // DeleteAccount deletes an existing account.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Delete%20Account.htm
 func (s *ArkPCloudAccountsService) DeleteAccount(deleteAccount *accountsmodels.ArkPCloudDeleteAccount) error {
+	return s.DeleteAccountWithContext(context.Background(), deleteAccount)
+}
+
+// DeleteAccountWithContext is DeleteAccount with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) DeleteAccountWithContext(ctx context.Context, deleteAccount *accountsmodels.ArkPCloudDeleteAccount) error {
+	ctx, span := s.StartSpan(ctx, "DeleteAccount")
+	defer span.End()
 	s.Logger.Info("Deleting account [%s]", deleteAccount.AccountID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(accountURL, deleteAccount.AccountID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(accountURL, deleteAccount.AccountID), nil)
 	if err != nil {
 		return err
 	}
@@ -617,7 +707,7 @@ func (s *ArkPCloudAccountsService) DeleteAccount(deleteAccount *accountsmodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete account")
 	}
 	return nil
 }
@@ -625,13 +715,20 @@ func (s *ArkPCloudAccountsService) DeleteAccount(deleteAccount *accountsmodels.A
 // LinkAccount links an account
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PrivCloud-SS/Latest/en/Content/WebServices/Link-account.htm
 func (s *ArkPCloudAccountsService) LinkAccount(linkAccount *accountsmodels.ArkPCloudLinkAccount) error {
+	return s.LinkAccountWithContext(context.Background(), linkAccount)
+}
+
+// LinkAccountWithContext is LinkAccount with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) LinkAccountWithContext(ctx context.Context, linkAccount *accountsmodels.ArkPCloudLinkAccount) error {
+	ctx, span := s.StartSpan(ctx, "LinkAccount")
+	defer span.End()
 	s.Logger.Info("Linking account [%v]", linkAccount)
 	linkAccountJSON, err := common.SerializeJSONCamel(linkAccount)
 	if err != nil {
 		return err
 	}
 	delete(linkAccountJSON, "account_id")
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(linkAccountURL, linkAccount.AccountID), linkAccountJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(linkAccountURL, linkAccount.AccountID), linkAccountJSON)
 	if err != nil {
 		return err
 	}
@@ -642,7 +739,7 @@ func (s *ArkPCloudAccountsService) LinkAccount(linkAccount *accountsmodels.ArkPC
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to link account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to link account")
 	}
 	return nil
 }
@@ -650,8 +747,15 @@ func (s *ArkPCloudAccountsService) LinkAccount(linkAccount *accountsmodels.ArkPC
 // UnlinkAccount unlinks an account
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PrivCloud-SS/Latest/en/Content/WebServices/Link-account-unlink.htm
 func (s *ArkPCloudAccountsService) UnlinkAccount(unlinkAccount *accountsmodels.ArkPCloudUnlinkAccount) error {
+	return s.UnlinkAccountWithContext(context.Background(), unlinkAccount)
+}
+
+// UnlinkAccountWithContext is UnlinkAccount with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) UnlinkAccountWithContext(ctx context.Context, unlinkAccount *accountsmodels.ArkPCloudUnlinkAccount) error {
+	ctx, span := s.StartSpan(ctx, "UnlinkAccount")
+	defer span.End()
 	s.Logger.Info("Unlinking account [%s] index [%s]", unlinkAccount.AccountID, unlinkAccount.ExtraPasswordIndex)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(unlinkAccountURL, unlinkAccount.AccountID, unlinkAccount.ExtraPasswordIndex), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(unlinkAccountURL, unlinkAccount.AccountID, unlinkAccount.ExtraPasswordIndex), nil)
 	if err != nil {
 		return err
 	}
@@ -662,24 +766,25 @@ func (s *ArkPCloudAccountsService) UnlinkAccount(unlinkAccount *accountsmodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to unlink account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to unlink account")
 	}
 	return nil
 }
 
 // AccountsStats retrieves the statistics of ArkPCloudAccounts.
 func (s *ArkPCloudAccountsService) AccountsStats() (*accountsmodels.ArkPCloudAccountsStats, error) {
+	return s.AccountsStatsWithContext(context.Background())
+}
+
+// AccountsStatsWithContext is AccountsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudAccountsService) AccountsStatsWithContext(ctx context.Context) (*accountsmodels.ArkPCloudAccountsStats, error) {
+	ctx, span := s.StartSpan(ctx, "AccountsStats")
+	defer span.End()
 	s.Logger.Info("Retrieving accounts stats")
-	accountsChan, err := s.ListAccounts()
+	accounts, err := common.CollectArkPageItems(s.ListAccountsIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	accounts := make([]*accountsmodels.ArkPCloudAccount, 0)
-	for page := range accountsChan {
-		for _, account := range page.Items {
-			accounts = append(accounts, account)
-		}
-	}
 	var accountsStats accountsmodels.ArkPCloudAccountsStats
 	accountsStats.AccountsCount = len(accounts)
 	accountsStats.AccountsCountByPlatformID = make(map[string]int)
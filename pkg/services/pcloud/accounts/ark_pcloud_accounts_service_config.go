@@ -4,6 +4,7 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	pcloudaccountsactions "github.com/cyberark/ark-sdk-golang/pkg/services/pcloud/accounts/actions"
+	accountsmodels "github.com/cyberark/ark-sdk-golang/pkg/services/pcloud/accounts/models"
 )
 
 // ServiceConfig is the configuration for the pcloud accounts service.
@@ -22,6 +23,13 @@ var ServiceConfig = services.ArkServiceConfig{
 			pcloudaccountsactions.TerraformActionAccountDataSource,
 		},
 	},
+	RedactedModels: []interface{}{
+		&accountsmodels.ArkPCloudAccountCredentials{},
+		&accountsmodels.ArkPCloudAddAccount{},
+		&accountsmodels.ArkPCloudUpdateAccount{},
+		&accountsmodels.ArkPCloudSetAccountNextCredentials{},
+		&accountsmodels.ArkPCloudUpdateAccountCredentialsInVault{},
+	},
 }
 
 // ServiceGenerator is the function that generates a new instance of the ArkPCloudAccountsService.
@@ -4,7 +4,7 @@ package models
 type ArkPCloudUpdateAccount struct {
 	ArkPCloudAccountSecretManagement     `mapstructure:",squash"`
 	ArkPCloudAccountRemoteMachinesAccess `mapstructure:",squash"`
-	Secret                               string                 `json:"secret" mapstructure:"secret" desc:"The secret of the account to update" flag:"secret"`
+	Secret                               string                 `json:"secret" mapstructure:"secret" desc:"The secret of the account to update" flag:"secret" redact:"true"`
 	AccountID                            string                 `json:"account_id" mapstructure:"account_id" desc:"The account id to update" flag:"account-id" validate:"required"`
 	Name                                 string                 `json:"name,omitempty" mapstructure:"name,omitempty" desc:"Name of the account to update" flag:"name"`
 	Address                              string                 `json:"address,omitempty" mapstructure:"address,omitempty" desc:"Address of the account to update" flag:"address"`
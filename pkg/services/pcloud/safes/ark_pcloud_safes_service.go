@@ -13,7 +13,6 @@ import (
 
 	"io"
 	"net/http"
-	"net/url"
 	"reflect"
 	"sync"
 )
@@ -117,6 +116,7 @@ func NewArkPCloudSafesService(authenticators ...auth.ArkAuth) (*ArkPCloudSafesSe
 		return nil, err
 	}
 	pcloudSafesService.client = client
+	baseService.AddClient(client.ArkClient)
 	pcloudSafesService.ispAuth = ispAuth
 	pcloudSafesService.ArkBaseService = baseService
 	return pcloudSafesService, nil
@@ -131,11 +131,12 @@ func (s *ArkPCloudSafesService) refreshPCloudSafesAuth(client *common.ArkClient)
 }
 
 func (s *ArkPCloudSafesService) listSafesWithFilters(
+	ctx context.Context,
 	search string,
 	sort string,
 	offset int,
 	limit int,
-) (<-chan *ArkPCloudSafesPage, error) {
+) common.ArkPageIterator[safesmodels.ArkPCloudSafe] {
 	query := map[string]string{}
 	if search != "" {
 		query["search"] = search
@@ -149,78 +150,60 @@ func (s *ArkPCloudSafesService) listSafesWithFilters(
 	if limit > 0 {
 		query["limit"] = fmt.Sprintf("%d", limit)
 	}
-	results := make(chan *ArkPCloudSafesPage)
-	go func() {
-		defer close(results)
-		for {
-			response, err := s.client.Get(context.Background(), safesURL, query)
-			if err != nil {
-				s.Logger.Error("Failed to list safes: %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list safes - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
-			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
-				return
-			}
-			resultMap := result.(map[string]interface{})
-			var safesJSON []interface{}
-			if value, ok := resultMap["value"]; ok {
-				safesJSON = value.([]interface{})
-			} else if safesData, ok := resultMap["Safes"]; ok {
-				safesJSON = safesData.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list safes, unexpected result")
-				return
-			}
-			for i, safe := range safesJSON {
-				if safeMap, ok := safe.(map[string]interface{}); ok {
-					if safeID, ok := safeMap["safe_url_id"]; ok {
-						safesJSON[i].(map[string]interface{})["safe_id"] = safeID
-					}
-				}
-			}
-			var safes []*safesmodels.ArkPCloudSafe
-			if err := mapstructure.Decode(safesJSON, &safes); err != nil {
-				s.Logger.Error("Failed to validate safes: %v", err)
-				return
-			}
-			results <- &ArkPCloudSafesPage{Items: safes}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
-				}
-			} else {
-				break
+	return common.NewArkNextLinkPageIterator(ctx, query, s.listSafesPage)
+}
+
+func (s *ArkPCloudSafesService) listSafesPage(ctx context.Context, query map[string]string) (*ArkPCloudSafesPage, string, error) {
+	response, err := s.client.Get(ctx, safesURL, query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", common.NewArkAPIError(response, "failed to list safes")
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	resultMap := result.(map[string]interface{})
+	var safesJSON []interface{}
+	if value, ok := resultMap["value"]; ok {
+		safesJSON = value.([]interface{})
+	} else if safesData, ok := resultMap["Safes"]; ok {
+		safesJSON = safesData.([]interface{})
+	} else {
+		return nil, "", fmt.Errorf("failed to list safes, unexpected result")
+	}
+	for i, safe := range safesJSON {
+		if safeMap, ok := safe.(map[string]interface{}); ok {
+			if safeID, ok := safeMap["safe_url_id"]; ok {
+				safesJSON[i].(map[string]interface{})["safe_id"] = safeID
 			}
 		}
-	}()
-	return results, nil
+	}
+	var safes []*safesmodels.ArkPCloudSafe
+	if err := mapstructure.Decode(safesJSON, &safes); err != nil {
+		return nil, "", err
+	}
+	nextLink, _ := resultMap["nextLink"].(string)
+	return &ArkPCloudSafesPage{Items: safes}, nextLink, nil
 }
 
 func (s *ArkPCloudSafesService) listSafeMembersWithFilters(
+	ctx context.Context,
 	safeID string,
 	search string,
 	sort string,
 	offset int,
 	limit int,
 	memberType string,
-) (<-chan *ArkPCloudSafeMembersPage, error) {
+) common.ArkPageIterator[safesmodels.ArkPCloudSafeMember] {
 	query := map[string]string{}
 	if search != "" {
 		query["search"] = search
@@ -237,130 +220,168 @@ func (s *ArkPCloudSafesService) listSafeMembersWithFilters(
 	if memberType != "" {
 		query["filter"] = fmt.Sprintf("memberType eq %s", memberType)
 	}
-	results := make(chan *ArkPCloudSafeMembersPage)
-	go func() {
-		defer close(results)
-		for {
-			response, err := s.client.Get(context.Background(), fmt.Sprintf(safeMembersURL, safeID), query)
-			if err != nil {
-				s.Logger.Error("Failed to list safe members: %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list safe members - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
-			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
-				return
-			}
-			resultMap := result.(map[string]interface{})
-			var membersJSON []interface{}
-			if value, ok := resultMap["value"]; ok {
-				membersJSON = value.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list safe members, unexpected result")
-				return
-			}
-			for i, safeMember := range membersJSON {
-				if safeMemberMap, ok := safeMember.(map[string]interface{}); ok {
-					if safeID, ok := safeMemberMap["safe_url_id"]; ok {
-						membersJSON[i].(map[string]interface{})["safe_id"] = safeID
-					}
-				}
-			}
-			var members []*safesmodels.ArkPCloudSafeMember
-			if err := mapstructure.Decode(membersJSON, &members); err != nil {
-				s.Logger.Error("Failed to validate safe members: %v", err)
-				return
-			}
-			for _, member := range members {
-				member.PermissionSet = safesmodels.Custom
-				for permissionSet, permissions := range SafeMembersPermissionsSets {
-					if reflect.DeepEqual(member.Permissions, permissions) {
-						member.PermissionSet = permissionSet
-						break
-					}
-				}
+	return common.NewArkNextLinkPageIterator(ctx, query, func(ctx context.Context, query map[string]string) (*ArkPCloudSafeMembersPage, string, error) {
+		return s.listSafeMembersPage(ctx, safeID, query)
+	})
+}
+
+func (s *ArkPCloudSafesService) listSafeMembersPage(ctx context.Context, safeID string, query map[string]string) (*ArkPCloudSafeMembersPage, string, error) {
+	response, err := s.client.Get(ctx, fmt.Sprintf(safeMembersURL, safeID), query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", common.NewArkAPIError(response, "failed to list safe members")
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	resultMap := result.(map[string]interface{})
+	var membersJSON []interface{}
+	if value, ok := resultMap["value"]; ok {
+		membersJSON = value.([]interface{})
+	} else {
+		return nil, "", fmt.Errorf("failed to list safe members, unexpected result")
+	}
+	for i, safeMember := range membersJSON {
+		if safeMemberMap, ok := safeMember.(map[string]interface{}); ok {
+			if safeID, ok := safeMemberMap["safe_url_id"]; ok {
+				membersJSON[i].(map[string]interface{})["safe_id"] = safeID
 			}
-			results <- &ArkPCloudSafeMembersPage{Items: members}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
-				}
-			} else {
+		}
+	}
+	var members []*safesmodels.ArkPCloudSafeMember
+	if err := mapstructure.Decode(membersJSON, &members); err != nil {
+		return nil, "", err
+	}
+	for _, member := range members {
+		member.PermissionSet = safesmodels.Custom
+		for permissionSet, permissions := range SafeMembersPermissionsSets {
+			if reflect.DeepEqual(member.Permissions, permissions) {
+				member.PermissionSet = permissionSet
 				break
 			}
 		}
-	}()
-	return results, nil
+	}
+	nextLink, _ := resultMap["nextLink"].(string)
+	return &ArkPCloudSafeMembersPage{Items: members}, nextLink, nil
 }
 
 // ListSafes returns a channel of ArkPCloudSafesPage containing all safes.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Safes%20Web%20Services%20-%20List%20Safes.htm?
 func (s *ArkPCloudSafesService) ListSafes() (<-chan *ArkPCloudSafesPage, error) {
-	return s.listSafesWithFilters(
-		"",
-		"",
-		0,
-		0,
-	)
+	return s.ListSafesWithContext(context.Background())
+}
+
+// ListSafesWithContext is ListSafes with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) ListSafesWithContext(ctx context.Context) (<-chan *ArkPCloudSafesPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSafesIter(ctx), s.Logger), nil
+}
+
+// ListSafesIter returns an iterator over all the safes pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkPCloudSafesService) ListSafesIter(ctx context.Context) common.ArkPageIterator[safesmodels.ArkPCloudSafe] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSafes", func(ctx context.Context) common.ArkPageIterator[safesmodels.ArkPCloudSafe] {
+		return s.listSafesWithFilters(ctx,
+			"",
+			"",
+			0,
+			0,
+		)
+	})
 }
 
 // ListSafesBy returns a channel of ArkPCloudSafesPage containing safes filtered by the given filters.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Safes%20Web%20Services%20-%20List%20Safes.htm?
 func (s *ArkPCloudSafesService) ListSafesBy(safesFilters *safesmodels.ArkPCloudSafesFilters) (<-chan *ArkPCloudSafesPage, error) {
-	return s.listSafesWithFilters(
-		safesFilters.Search,
-		safesFilters.Sort,
-		safesFilters.Offset,
-		safesFilters.Limit,
-	)
+	return s.ListSafesByWithContext(context.Background(), safesFilters)
+}
+
+// ListSafesByWithContext is ListSafesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) ListSafesByWithContext(ctx context.Context, safesFilters *safesmodels.ArkPCloudSafesFilters) (<-chan *ArkPCloudSafesPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSafesByIter(ctx, safesFilters), s.Logger), nil
+}
+
+// ListSafesByIter returns an iterator over the safes pages filtered by the given filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkPCloudSafesService) ListSafesByIter(ctx context.Context, safesFilters *safesmodels.ArkPCloudSafesFilters) common.ArkPageIterator[safesmodels.ArkPCloudSafe] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSafesBy", func(ctx context.Context) common.ArkPageIterator[safesmodels.ArkPCloudSafe] {
+		return s.listSafesWithFilters(ctx,
+			safesFilters.Search,
+			safesFilters.Sort,
+			safesFilters.Offset,
+			safesFilters.Limit,
+		)
+	})
 }
 
 // ListSafeMembers returns a channel of ArkPCloudSafeMembersPage containing all safe members.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Safe%20Members%20WS%20-%20List%20Safe%20Members.htm
 func (s *ArkPCloudSafesService) ListSafeMembers(listSafeMembers *safesmodels.ArkPCloudListSafeMembers) (<-chan *ArkPCloudSafeMembersPage, error) {
-	return s.listSafeMembersWithFilters(
-		listSafeMembers.SafeID,
-		"",
-		"",
-		0,
-		0,
-		"",
-	)
+	return s.ListSafeMembersWithContext(context.Background(), listSafeMembers)
+}
+
+// ListSafeMembersWithContext is ListSafeMembers with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) ListSafeMembersWithContext(ctx context.Context, listSafeMembers *safesmodels.ArkPCloudListSafeMembers) (<-chan *ArkPCloudSafeMembersPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSafeMembersIter(ctx, listSafeMembers), s.Logger), nil
+}
+
+// ListSafeMembersIter returns an iterator over all the safe members pages of a safe, stopping with an error if a page fails to be retrieved.
+func (s *ArkPCloudSafesService) ListSafeMembersIter(ctx context.Context, listSafeMembers *safesmodels.ArkPCloudListSafeMembers) common.ArkPageIterator[safesmodels.ArkPCloudSafeMember] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSafeMembers", func(ctx context.Context) common.ArkPageIterator[safesmodels.ArkPCloudSafeMember] {
+		return s.listSafeMembersWithFilters(ctx,
+			listSafeMembers.SafeID,
+			"",
+			"",
+			0,
+			0,
+			"",
+		)
+	})
 }
 
 // ListSafeMembersBy returns a channel of ArkPCloudSafeMembersPage containing safe members filtered by the given filters.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Safe%20Members%20WS%20-%20List%20Safe%20Members.htm
 func (s *ArkPCloudSafesService) ListSafeMembersBy(safeMembersFilters *safesmodels.ArkPCloudSafeMembersFilters) (<-chan *ArkPCloudSafeMembersPage, error) {
-	return s.listSafeMembersWithFilters(
-		safeMembersFilters.SafeID,
-		safeMembersFilters.Search,
-		safeMembersFilters.Sort,
-		safeMembersFilters.Offset,
-		safeMembersFilters.Limit,
-		safeMembersFilters.MemberType,
-	)
+	return s.ListSafeMembersByWithContext(context.Background(), safeMembersFilters)
+}
+
+// ListSafeMembersByWithContext is ListSafeMembersBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) ListSafeMembersByWithContext(ctx context.Context, safeMembersFilters *safesmodels.ArkPCloudSafeMembersFilters) (<-chan *ArkPCloudSafeMembersPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSafeMembersByIter(ctx, safeMembersFilters), s.Logger), nil
+}
+
+// ListSafeMembersByIter returns an iterator over the safe members pages filtered by the given filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkPCloudSafesService) ListSafeMembersByIter(ctx context.Context, safeMembersFilters *safesmodels.ArkPCloudSafeMembersFilters) common.ArkPageIterator[safesmodels.ArkPCloudSafeMember] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSafeMembersBy", func(ctx context.Context) common.ArkPageIterator[safesmodels.ArkPCloudSafeMember] {
+		return s.listSafeMembersWithFilters(ctx,
+			safeMembersFilters.SafeID,
+			safeMembersFilters.Search,
+			safeMembersFilters.Sort,
+			safeMembersFilters.Offset,
+			safeMembersFilters.Limit,
+			safeMembersFilters.MemberType,
+		)
+	})
 }
 
 // Safe retrieves a safe by its ID.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Safes%20Web%20Services%20-%20Get%20Safes%20Details.htm
 func (s *ArkPCloudSafesService) Safe(getSafe *safesmodels.ArkPCloudGetSafe) (*safesmodels.ArkPCloudSafe, error) {
+	return s.SafeWithContext(context.Background(), getSafe)
+}
+
+// SafeWithContext is Safe with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) SafeWithContext(ctx context.Context, getSafe *safesmodels.ArkPCloudGetSafe) (*safesmodels.ArkPCloudSafe, error) {
+	ctx, span := s.StartSpan(ctx, "Safe")
+	defer span.End()
 	s.Logger.Info("Retrieving safe [%s]", getSafe.SafeID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(safeURL, getSafe.SafeID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(safeURL, getSafe.SafeID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +392,7 @@ func (s *ArkPCloudSafesService) Safe(getSafe *safesmodels.ArkPCloudGetSafe) (*sa
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve safe - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve safe")
 	}
 	safeJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -392,8 +413,15 @@ func (s *ArkPCloudSafesService) Safe(getSafe *safesmodels.ArkPCloudGetSafe) (*sa
 // SafeMember retrieves a safe member by its safe ID and member name.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/Safe%20Members%20WS%20-%20List%20Safe%20Member.htm
 func (s *ArkPCloudSafesService) SafeMember(getSafeMember *safesmodels.ArkPCloudGetSafeMember) (*safesmodels.ArkPCloudSafeMember, error) {
+	return s.SafeMemberWithContext(context.Background(), getSafeMember)
+}
+
+// SafeMemberWithContext is SafeMember with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) SafeMemberWithContext(ctx context.Context, getSafeMember *safesmodels.ArkPCloudGetSafeMember) (*safesmodels.ArkPCloudSafeMember, error) {
+	ctx, span := s.StartSpan(ctx, "SafeMember")
+	defer span.End()
 	s.Logger.Info("Retrieving safe member [%s] [%s]", getSafeMember.SafeID, getSafeMember.MemberName)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(safeMemberURL, getSafeMember.SafeID, getSafeMember.MemberName), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(safeMemberURL, getSafeMember.SafeID, getSafeMember.MemberName), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -404,7 +432,7 @@ func (s *ArkPCloudSafesService) SafeMember(getSafeMember *safesmodels.ArkPCloudG
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve safe member - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve safe member")
 	}
 	safeMemberJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -432,6 +460,13 @@ func (s *ArkPCloudSafesService) SafeMember(getSafeMember *safesmodels.ArkPCloudG
 // AddSafe adds a new safe.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Add%20Safe.htm
 func (s *ArkPCloudSafesService) AddSafe(addSafe *safesmodels.ArkPCloudAddSafe) (*safesmodels.ArkPCloudSafe, error) {
+	return s.AddSafeWithContext(context.Background(), addSafe)
+}
+
+// AddSafeWithContext is AddSafe with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) AddSafeWithContext(ctx context.Context, addSafe *safesmodels.ArkPCloudAddSafe) (*safesmodels.ArkPCloudSafe, error) {
+	ctx, span := s.StartSpan(ctx, "AddSafe")
+	defer span.End()
 	s.Logger.Info("Adding safe [%s]", addSafe.SafeName)
 	addSafeJSON, err := common.SerializeJSONCamel(addSafe)
 	if err != nil {
@@ -447,7 +482,7 @@ func (s *ArkPCloudSafesService) AddSafe(addSafe *safesmodels.ArkPCloudAddSafe) (
 	if _, ok := addSafeJSON["numberOfDaysRetention"]; !ok {
 		addSafeJSON["numberOfDaysRetention"] = 0
 	}
-	response, err := s.client.Post(context.Background(), safesURL, addSafeJSON)
+	response, err := s.client.Post(ctx, safesURL, addSafeJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -458,7 +493,7 @@ func (s *ArkPCloudSafesService) AddSafe(addSafe *safesmodels.ArkPCloudAddSafe) (
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add safe - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add safe")
 	}
 	safeJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -479,6 +514,13 @@ func (s *ArkPCloudSafesService) AddSafe(addSafe *safesmodels.ArkPCloudAddSafe) (
 // AddSafeMember adds a new member to a safe.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Add%20Safe%20Member.htm
 func (s *ArkPCloudSafesService) AddSafeMember(addSafeMember *safesmodels.ArkPCloudAddSafeMember) (*safesmodels.ArkPCloudSafeMember, error) {
+	return s.AddSafeMemberWithContext(context.Background(), addSafeMember)
+}
+
+// AddSafeMemberWithContext is AddSafeMember with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) AddSafeMemberWithContext(ctx context.Context, addSafeMember *safesmodels.ArkPCloudAddSafeMember) (*safesmodels.ArkPCloudSafeMember, error) {
+	ctx, span := s.StartSpan(ctx, "AddSafeMember")
+	defer span.End()
 	s.Logger.Info("Adding safe member [%s] [%s]", addSafeMember.SafeID, addSafeMember.MemberName)
 	if addSafeMember.PermissionSet == safesmodels.Custom && addSafeMember.Permissions == nil {
 		return nil, fmt.Errorf("permission set is custom but permissions are not set")
@@ -496,7 +538,7 @@ func (s *ArkPCloudSafesService) AddSafeMember(addSafeMember *safesmodels.ArkPClo
 	}
 	delete(addSafeMemberJSON, "permissionSet")
 	delete(addSafeMemberJSON, "safeId")
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(safeMembersURL, addSafeMember.SafeID), addSafeMemberJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(safeMembersURL, addSafeMember.SafeID), addSafeMemberJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -507,7 +549,7 @@ func (s *ArkPCloudSafesService) AddSafeMember(addSafeMember *safesmodels.ArkPClo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add safe member - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add safe member")
 	}
 	safeMemberJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -535,8 +577,15 @@ func (s *ArkPCloudSafesService) AddSafeMember(addSafeMember *safesmodels.ArkPClo
 // DeleteSafe deletes a safe by its ID.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Delete%20Safe.htm
 func (s *ArkPCloudSafesService) DeleteSafe(deleteSafe *safesmodels.ArkPCloudDeleteSafe) error {
+	return s.DeleteSafeWithContext(context.Background(), deleteSafe)
+}
+
+// DeleteSafeWithContext is DeleteSafe with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) DeleteSafeWithContext(ctx context.Context, deleteSafe *safesmodels.ArkPCloudDeleteSafe) error {
+	ctx, span := s.StartSpan(ctx, "DeleteSafe")
+	defer span.End()
 	s.Logger.Info("Deleting safe [%s]", deleteSafe.SafeID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(safeURL, deleteSafe.SafeID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(safeURL, deleteSafe.SafeID), nil)
 	if err != nil {
 		return err
 	}
@@ -547,7 +596,7 @@ func (s *ArkPCloudSafesService) DeleteSafe(deleteSafe *safesmodels.ArkPCloudDele
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete safe - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete safe")
 	}
 	return nil
 }
@@ -555,8 +604,15 @@ func (s *ArkPCloudSafesService) DeleteSafe(deleteSafe *safesmodels.ArkPCloudDele
 // DeleteSafeMember deletes a member from a safe by its safe ID and member name.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Delete%20Safe%20Member.htm
 func (s *ArkPCloudSafesService) DeleteSafeMember(deleteSafeMember *safesmodels.ArkPCloudDeleteSafeMember) error {
+	return s.DeleteSafeMemberWithContext(context.Background(), deleteSafeMember)
+}
+
+// DeleteSafeMemberWithContext is DeleteSafeMember with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) DeleteSafeMemberWithContext(ctx context.Context, deleteSafeMember *safesmodels.ArkPCloudDeleteSafeMember) error {
+	ctx, span := s.StartSpan(ctx, "DeleteSafeMember")
+	defer span.End()
 	s.Logger.Info("Deleting safe member [%s] [%s]", deleteSafeMember.SafeID, deleteSafeMember.MemberName)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(safeMemberURL, deleteSafeMember.SafeID, deleteSafeMember.MemberName), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(safeMemberURL, deleteSafeMember.SafeID, deleteSafeMember.MemberName), nil)
 	if err != nil {
 		return err
 	}
@@ -567,7 +623,7 @@ func (s *ArkPCloudSafesService) DeleteSafeMember(deleteSafeMember *safesmodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete safe member - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete safe member")
 	}
 	return nil
 }
@@ -575,6 +631,13 @@ func (s *ArkPCloudSafesService) DeleteSafeMember(deleteSafeMember *safesmodels.A
 // UpdateSafe updates a safe by its ID.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Update%20Safe.htm
 func (s *ArkPCloudSafesService) UpdateSafe(updateSafe *safesmodels.ArkPCloudUpdateSafe) (*safesmodels.ArkPCloudSafe, error) {
+	return s.UpdateSafeWithContext(context.Background(), updateSafe)
+}
+
+// UpdateSafeWithContext is UpdateSafe with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) UpdateSafeWithContext(ctx context.Context, updateSafe *safesmodels.ArkPCloudUpdateSafe) (*safesmodels.ArkPCloudSafe, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateSafe")
+	defer span.End()
 	s.Logger.Info("Updating safe [%s]", updateSafe.SafeID)
 	updateSafeJSON, err := common.SerializeJSONCamel(updateSafe)
 	if err != nil {
@@ -582,12 +645,12 @@ func (s *ArkPCloudSafesService) UpdateSafe(updateSafe *safesmodels.ArkPCloudUpda
 	}
 	delete(updateSafeJSON, "safeId")
 	if len(updateSafeJSON) == 0 {
-		return s.Safe(&safesmodels.ArkPCloudGetSafe{SafeID: updateSafe.SafeID})
+		return s.SafeWithContext(ctx, &safesmodels.ArkPCloudGetSafe{SafeID: updateSafe.SafeID})
 	}
 	if _, ok := updateSafeJSON["numberOfDaysRetention"]; !ok {
 		updateSafeJSON["numberOfDaysRetention"] = 0
 	}
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(safeURL, updateSafe.SafeID), updateSafeJSON)
+	response, err := s.client.Put(ctx, fmt.Sprintf(safeURL, updateSafe.SafeID), updateSafeJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -598,7 +661,7 @@ func (s *ArkPCloudSafesService) UpdateSafe(updateSafe *safesmodels.ArkPCloudUpda
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update safe - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update safe")
 	}
 	safeJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -619,6 +682,13 @@ func (s *ArkPCloudSafesService) UpdateSafe(updateSafe *safesmodels.ArkPCloudUpda
 // UpdateSafeMember updates a member of a safe by its safe ID and member name.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/WebServices/Update%20Safe%20Member.htm
 func (s *ArkPCloudSafesService) UpdateSafeMember(updateSafeMember *safesmodels.ArkPCloudUpdateSafeMember) (*safesmodels.ArkPCloudSafeMember, error) {
+	return s.UpdateSafeMemberWithContext(context.Background(), updateSafeMember)
+}
+
+// UpdateSafeMemberWithContext is UpdateSafeMember with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) UpdateSafeMemberWithContext(ctx context.Context, updateSafeMember *safesmodels.ArkPCloudUpdateSafeMember) (*safesmodels.ArkPCloudSafeMember, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateSafeMember")
+	defer span.End()
 	s.Logger.Info("Updating safe member [%s] [%s]", updateSafeMember.SafeID, updateSafeMember.MemberName)
 	if updateSafeMember.PermissionSet == safesmodels.Custom && updateSafeMember.Permissions == nil {
 		return nil, fmt.Errorf("permission set is custom but permissions are not set")
@@ -638,9 +708,9 @@ func (s *ArkPCloudSafesService) UpdateSafeMember(updateSafeMember *safesmodels.A
 	delete(updateSafeMemberJSON, "memberName")
 	delete(updateSafeMemberJSON, "permissionSet")
 	if len(updateSafeMemberJSON) == 0 {
-		return s.SafeMember(&safesmodels.ArkPCloudGetSafeMember{SafeID: updateSafeMember.SafeID, MemberName: updateSafeMember.MemberName})
+		return s.SafeMemberWithContext(ctx, &safesmodels.ArkPCloudGetSafeMember{SafeID: updateSafeMember.SafeID, MemberName: updateSafeMember.MemberName})
 	}
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(safeMemberURL, updateSafeMember.SafeID, updateSafeMember.MemberName), updateSafeMemberJSON)
+	response, err := s.client.Put(ctx, fmt.Sprintf(safeMemberURL, updateSafeMember.SafeID, updateSafeMember.MemberName), updateSafeMemberJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -651,7 +721,7 @@ func (s *ArkPCloudSafesService) UpdateSafeMember(updateSafeMember *safesmodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update safe member - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update safe member")
 	}
 	safeMemberJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -678,17 +748,18 @@ func (s *ArkPCloudSafesService) UpdateSafeMember(updateSafeMember *safesmodels.A
 
 // SafesStats retrieves statistics about safes.
 func (s *ArkPCloudSafesService) SafesStats() (*safesmodels.ArkPCloudSafesStats, error) {
+	return s.SafesStatsWithContext(context.Background())
+}
+
+// SafesStatsWithContext is SafesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) SafesStatsWithContext(ctx context.Context) (*safesmodels.ArkPCloudSafesStats, error) {
+	ctx, span := s.StartSpan(ctx, "SafesStats")
+	defer span.End()
 	s.Logger.Info("Retrieving safes stats")
-	safesChan, err := s.ListSafes()
+	safes, err := common.CollectArkPageItems(s.ListSafesIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	safes := make([]*safesmodels.ArkPCloudSafe, 0)
-	for page := range safesChan {
-		for _, safe := range page.Items {
-			safes = append(safes, safe)
-		}
-	}
 	var safesStats safesmodels.ArkPCloudSafesStats
 	safesStats.SafesCount = len(safes)
 	safesStats.SafesCountByLocation = make(map[string]int)
@@ -708,17 +779,18 @@ func (s *ArkPCloudSafesService) SafesStats() (*safesmodels.ArkPCloudSafesStats,
 
 // SafeMembersStats retrieves statistics about safe members for a specific safe.
 func (s *ArkPCloudSafesService) SafeMembersStats(getSafeMembersStats *safesmodels.ArkPCloudGetSafeMembersStats) (*safesmodels.ArkPCloudSafeMembersStats, error) {
+	return s.SafeMembersStatsWithContext(context.Background(), getSafeMembersStats)
+}
+
+// SafeMembersStatsWithContext is SafeMembersStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) SafeMembersStatsWithContext(ctx context.Context, getSafeMembersStats *safesmodels.ArkPCloudGetSafeMembersStats) (*safesmodels.ArkPCloudSafeMembersStats, error) {
+	ctx, span := s.StartSpan(ctx, "SafeMembersStats")
+	defer span.End()
 	s.Logger.Info("Retrieving safe members stats [%s]", getSafeMembersStats.SafeID)
-	safeMembersChan, err := s.ListSafeMembers(&safesmodels.ArkPCloudListSafeMembers{SafeID: getSafeMembersStats.SafeID})
+	safeMembers, err := common.CollectArkPageItems(s.ListSafeMembersIter(ctx, &safesmodels.ArkPCloudListSafeMembers{SafeID: getSafeMembersStats.SafeID}))
 	if err != nil {
 		return nil, err
 	}
-	safeMembers := make([]*safesmodels.ArkPCloudSafeMember, 0)
-	for page := range safeMembersChan {
-		for _, safeMember := range page.Items {
-			safeMembers = append(safeMembers, safeMember)
-		}
-	}
 	var safeMembersStats safesmodels.ArkPCloudSafeMembersStats
 	safeMembersStats.SafeMembersCount = len(safeMembers)
 	safeMembersStats.SafeMembersPermissionSets = make(map[string]int)
@@ -741,23 +813,32 @@ func (s *ArkPCloudSafesService) SafeMembersStats(getSafeMembersStats *safesmodel
 
 // SafesMembersStats retrieves statistics about safe members for all safes.
 func (s *ArkPCloudSafesService) SafesMembersStats() (*safesmodels.ArkPCloudSafesMembersStats, error) {
+	return s.SafesMembersStatsWithContext(context.Background())
+}
+
+// SafesMembersStatsWithContext is SafesMembersStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkPCloudSafesService) SafesMembersStatsWithContext(ctx context.Context) (*safesmodels.ArkPCloudSafesMembersStats, error) {
+	ctx, span := s.StartSpan(ctx, "SafesMembersStats")
+	defer span.End()
 	s.Logger.Info("Retrieving safes members stats")
-	safesChan, err := s.ListSafes()
-	if err != nil {
-		return nil, err
-	}
 	safesMembersStats := make(map[string]safesmodels.ArkPCloudSafeMembersStats)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	var firstErr error
 	var once sync.Once
 
-	for page := range safesChan {
+	for page, err := range s.ListSafesIter(ctx) {
+		if err != nil {
+			once.Do(func() {
+				firstErr = err
+			})
+			break
+		}
 		for _, safe := range page.Items {
 			wg.Add(1)
 			go func(safe *safesmodels.ArkPCloudSafe) {
 				defer wg.Done()
-				safeMembersStats, err := s.SafeMembersStats(&safesmodels.ArkPCloudGetSafeMembersStats{SafeID: safe.SafeID})
+				safeMembersStats, err := s.SafeMembersStatsWithContext(ctx, &safesmodels.ArkPCloudGetSafeMembersStats{SafeID: safe.SafeID})
 				if err != nil {
 					once.Do(func() {
 						firstErr = err
@@ -2,7 +2,6 @@ package configuration
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 
@@ -44,6 +43,7 @@ func NewArkSecHubConfigurationService(authenticators ...auth.ArkAuth) (*ArkSecHu
 		return nil, err
 	}
 	configurationService.client = client
+	baseService.AddClient(client.ArkClient)
 	configurationService.ispAuth = ispAuth
 	configurationService.ArkBaseService = baseService
 	return configurationService, nil
@@ -60,8 +60,15 @@ func (s *ArkSecHubConfigurationService) refreshSecHubAuth(client *common.ArkClie
 // Configuration retrieves the configuration info from the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/r3a0vv9er2enm-view-configuration
 func (s *ArkSecHubConfigurationService) Configuration() (*configurationmodels.ArkSecHubGetConfiguration, error) {
+	return s.ConfigurationWithContext(context.Background())
+}
+
+// ConfigurationWithContext is Configuration with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubConfigurationService) ConfigurationWithContext(ctx context.Context) (*configurationmodels.ArkSecHubGetConfiguration, error) {
+	ctx, span := s.StartSpan(ctx, "Configuration")
+	defer span.End()
 	s.Logger.Info("Getting configuration")
-	response, err := s.client.Get(context.Background(), sechubURL, nil)
+	response, err := s.client.Get(ctx, sechubURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +79,7 @@ func (s *ArkSecHubConfigurationService) Configuration() (*configurationmodels.Ar
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get configuration - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get configuration")
 	}
 	configurationJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -89,12 +96,19 @@ func (s *ArkSecHubConfigurationService) Configuration() (*configurationmodels.Ar
 // SetConfiguration updates the configuration info in the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/eko5hfu8sg16o-update-configuration
 func (s *ArkSecHubConfigurationService) SetConfiguration(setConfiguration *configurationmodels.ArkSecHubSetConfiguration) (*configurationmodels.ArkSecHubGetConfiguration, error) {
+	return s.SetConfigurationWithContext(context.Background(), setConfiguration)
+}
+
+// SetConfigurationWithContext is SetConfiguration with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubConfigurationService) SetConfigurationWithContext(ctx context.Context, setConfiguration *configurationmodels.ArkSecHubSetConfiguration) (*configurationmodels.ArkSecHubGetConfiguration, error) {
+	ctx, span := s.StartSpan(ctx, "SetConfiguration")
+	defer span.End()
 	s.Logger.Info("Updating configuration. Setting secret validity to [%d]", setConfiguration.SyncSettings.SecretValidity)
 	setConfigurationJSON, err := common.SerializeJSONCamel(setConfiguration)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Patch(context.Background(), sechubURL, setConfigurationJSON)
+	response, err := s.client.Patch(ctx, sechubURL, setConfigurationJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +119,7 @@ func (s *ArkSecHubConfigurationService) SetConfiguration(setConfiguration *confi
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update configuration - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update configuration")
 	}
 	configurationJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
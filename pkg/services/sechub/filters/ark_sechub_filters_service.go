@@ -48,6 +48,7 @@ func NewArkSecHubFiltersService(authenticators ...auth.ArkAuth) (*ArkSecHubFilte
 		return nil, err
 	}
 	filtersService.client = client
+	baseService.AddClient(client.ArkClient)
 	filtersService.ispAuth = ispAuth
 	filtersService.ArkBaseService = baseService
 	return filtersService, nil
@@ -64,6 +65,13 @@ func (s *ArkSecHubFiltersService) refreshSecHubAuth(client *common.ArkClient) er
 // Filter retrieves the filters info from the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/rqykgubx980ul-get-secrets-filter
 func (s *ArkSecHubFiltersService) Filter(getFilters *filtersmodels.ArkSecHubGetFilter) (*filtersmodels.ArkSecHubFilter, error) {
+	return s.FilterWithContext(context.Background(), getFilters)
+}
+
+// FilterWithContext is Filter with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubFiltersService) FilterWithContext(ctx context.Context, getFilters *filtersmodels.ArkSecHubGetFilter) (*filtersmodels.ArkSecHubFilter, error) {
+	ctx, span := s.StartSpan(ctx, "Filter")
+	defer span.End()
 	if getFilters.StoreID == "" {
 		s.Logger.Info("Setting Secret Store ID to default")
 		getFilters.StoreID = "default"
@@ -73,7 +81,7 @@ func (s *ArkSecHubFiltersService) Filter(getFilters *filtersmodels.ArkSecHubGetF
 		getFilters.FilterID = "default"
 	}
 	s.Logger.Info("Getting filter")
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(filterURL, getFilters.StoreID, getFilters.FilterID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(filterURL, getFilters.StoreID, getFilters.FilterID), nil)
 	if err != nil {
 		s.Logger.Error("Failed to list filters: %v", err)
 		return nil, err
@@ -85,8 +93,7 @@ func (s *ArkSecHubFiltersService) Filter(getFilters *filtersmodels.ArkSecHubGetF
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		s.Logger.Error("Failed to list Secret Store Filters - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-		return nil, err
+		return nil, common.NewArkAPIError(response, "failed to list secret store filters")
 	}
 	filterJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -104,64 +111,73 @@ func (s *ArkSecHubFiltersService) Filter(getFilters *filtersmodels.ArkSecHubGetF
 // ListFilters retrieves the filters info from the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/punr36gz4tuqe-get-all-secrets-filters
 func (s *ArkSecHubFiltersService) ListFilters(getFilters *filtersmodels.ArkSecHubGetFilters) (<-chan *ArkSecHubFiltersPage, error) {
-	if getFilters.StoreID == "" {
-		s.Logger.Info("Setting Secret Store ID to default")
-		getFilters.StoreID = "default"
-	}
-	s.Logger.Info("Getting filters")
+	return s.ListFiltersWithContext(context.Background(), getFilters)
+}
 
-	results := make(chan *ArkSecHubFiltersPage)
-	go func() {
-		defer close(results)
-		response, err := s.client.Get(context.Background(), fmt.Sprintf(sechubURL, getFilters.StoreID), nil)
-		if err != nil {
-			s.Logger.Error("Failed to list filters: %v", err)
-			return
+// ListFiltersWithContext is ListFilters with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubFiltersService) ListFiltersWithContext(ctx context.Context, getFilters *filtersmodels.ArkSecHubGetFilters) (<-chan *ArkSecHubFiltersPage, error) {
+	return common.ArkPageChannel(ctx, s.ListFiltersIter(ctx, getFilters), s.Logger), nil
+}
+
+// ListFiltersIter returns an iterator over the filters pages of a secret store, stopping with an error if the filters fail to be retrieved.
+func (s *ArkSecHubFiltersService) ListFiltersIter(ctx context.Context, getFilters *filtersmodels.ArkSecHubGetFilters) common.ArkPageIterator[filtersmodels.ArkSecHubFilter] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListFilters", func(ctx context.Context) common.ArkPageIterator[filtersmodels.ArkSecHubFilter] {
+		if getFilters.StoreID == "" {
+			s.Logger.Info("Setting Secret Store ID to default")
+			getFilters.StoreID = "default"
 		}
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
+		s.Logger.Info("Getting filters")
+		return common.NewArkSinglePageIterator(ctx, func(ctx context.Context) (*ArkSecHubFiltersPage, error) {
+			response, err := s.client.Get(ctx, fmt.Sprintf(sechubURL, getFilters.StoreID), nil)
 			if err != nil {
-				common.GlobalLogger.Warning("Error closing response body")
+				return nil, err
 			}
-		}(response.Body)
-		if response.StatusCode != http.StatusOK {
-			s.Logger.Error("Failed to list Secret Store Filters - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-			return
-		}
-		result, err := common.DeserializeJSONSnake(response.Body)
-		if err != nil {
-			s.Logger.Error("Failed to decode response: %v", err)
-			return
-		}
-		resultMap := result.(map[string]interface{})
-		var filtersJSON []interface{}
-		if filters, ok := resultMap["filters"]; ok {
-			filtersJSON = filters.([]interface{})
-		} else {
-			s.Logger.Error("Failed to list Secret Store filters, unexpected result")
-			return
-		}
-		for i, filtersMember := range filtersJSON {
-			if filtersMemberMap, ok := filtersMember.(map[string]interface{}); ok {
-				if ID, ok := filtersMemberMap["id"]; ok {
-					filtersJSON[i].(map[string]interface{})["id"] = ID
+			defer func(Body io.ReadCloser) {
+				err := Body.Close()
+				if err != nil {
+					common.GlobalLogger.Warning("Error closing response body")
 				}
+			}(response.Body)
+			if response.StatusCode != http.StatusOK {
+				return nil, common.NewArkAPIError(response, "failed to list secret store filters")
 			}
-		}
-		var filters []*filtersmodels.ArkSecHubFilter
-		if err := mapstructure.Decode(filtersJSON, &filters); err != nil {
-			s.Logger.Error("Failed to validate Secret Store filters: %v", err)
-			return
-		}
-
-		results <- &ArkSecHubFiltersPage{Items: filters}
-	}()
-	return results, nil
+			result, err := common.DeserializeJSONSnake(response.Body)
+			if err != nil {
+				return nil, err
+			}
+			resultMap := result.(map[string]interface{})
+			var filtersJSON []interface{}
+			if filters, ok := resultMap["filters"]; ok {
+				filtersJSON = filters.([]interface{})
+			} else {
+				return nil, fmt.Errorf("failed to list secret store filters, unexpected result")
+			}
+			for i, filtersMember := range filtersJSON {
+				if filtersMemberMap, ok := filtersMember.(map[string]interface{}); ok {
+					if ID, ok := filtersMemberMap["id"]; ok {
+						filtersJSON[i].(map[string]interface{})["id"] = ID
+					}
+				}
+			}
+			var filters []*filtersmodels.ArkSecHubFilter
+			if err := mapstructure.Decode(filtersJSON, &filters); err != nil {
+				return nil, err
+			}
+			return &ArkSecHubFiltersPage{Items: filters}, nil
+		})
+	})
 }
 
 // AddFilter adds a new filter for a specific secret store id
 // https://api-docs.cyberark.com/docs/secretshub-api/ifgbuo8tmt1en-create-secrets-filter
 func (s *ArkSecHubFiltersService) AddFilter(filter *filtersmodels.ArkSecHubAddFilter) (*filtersmodels.ArkSecHubFilter, error) {
+	return s.AddFilterWithContext(context.Background(), filter)
+}
+
+// AddFilterWithContext is AddFilter with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubFiltersService) AddFilterWithContext(ctx context.Context, filter *filtersmodels.ArkSecHubAddFilter) (*filtersmodels.ArkSecHubFilter, error) {
+	ctx, span := s.StartSpan(ctx, "AddFilter")
+	defer span.End()
 	s.Logger.Info("Adding filter for secret store [%s]", filter.StoreID)
 	bodyMap := map[string]interface{}{
 		"type": filter.Type,
@@ -169,7 +185,7 @@ func (s *ArkSecHubFiltersService) AddFilter(filter *filtersmodels.ArkSecHubAddFi
 			"safeName": filter.Data.SafeName,
 		},
 	}
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(sechubURL, filter.StoreID), bodyMap)
+	response, err := s.client.Post(ctx, fmt.Sprintf(sechubURL, filter.StoreID), bodyMap)
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +196,7 @@ func (s *ArkSecHubFiltersService) AddFilter(filter *filtersmodels.ArkSecHubAddFi
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to create filter - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to create filter")
 	}
 	filterJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -197,8 +213,15 @@ func (s *ArkSecHubFiltersService) AddFilter(filter *filtersmodels.ArkSecHubAddFi
 // DeleteFilter deletes a specified filter based on secret store id and filter id
 // https://api-docs.cyberark.com/docs/secretshub-api/h8q9q5xtkxqgz-delete-secrets-filter
 func (s *ArkSecHubFiltersService) DeleteFilter(filter *filtersmodels.ArkSecHubDeleteFilter) error {
+	return s.DeleteFilterWithContext(context.Background(), filter)
+}
+
+// DeleteFilterWithContext is DeleteFilter with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubFiltersService) DeleteFilterWithContext(ctx context.Context, filter *filtersmodels.ArkSecHubDeleteFilter) error {
+	ctx, span := s.StartSpan(ctx, "DeleteFilter")
+	defer span.End()
 	s.Logger.Info("Deleting secret store [%s] filter [%s]", filter.StoreID, filter.FilterID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(filterURL, filter.StoreID, filter.FilterID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(filterURL, filter.StoreID, filter.FilterID), nil)
 	if err != nil {
 		return err
 	}
@@ -209,7 +232,7 @@ func (s *ArkSecHubFiltersService) DeleteFilter(filter *filtersmodels.ArkSecHubDe
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete filter - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete filter")
 	}
 	return nil
 }
@@ -52,6 +52,7 @@ func NewArkSecHubScansService(authenticators ...auth.ArkAuth) (*ArkSecHubScansSe
 		"Accept": "application/x.secretshub.beta+json",
 	})
 	scansService.client = client
+	baseService.AddClient(client.ArkClient)
 	scansService.ispAuth = ispAuth
 	scansService.ArkBaseService = baseService
 	return scansService, nil
@@ -68,60 +69,69 @@ func (s *ArkSecHubScansService) refreshSecHubAuth(client *common.ArkClient) erro
 // Scans retrieves the scans info from the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/78cprz38emhrb-get-scans
 func (s *ArkSecHubScansService) Scans() (<-chan *ArkSecHubScansPage, error) {
-	s.Logger.Info("Getting scans")
+	return s.ScansWithContext(context.Background())
+}
 
-	results := make(chan *ArkSecHubScansPage)
-	go func() {
-		defer close(results)
-		response, err := s.client.Get(context.Background(), sechubURL, nil)
-		if err != nil {
-			s.Logger.Error("Failed to list filters: %v", err)
-			return
-		}
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
+// ScansWithContext is Scans with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubScansService) ScansWithContext(ctx context.Context) (<-chan *ArkSecHubScansPage, error) {
+	return common.ArkPageChannel(ctx, s.ScansIter(ctx), s.Logger), nil
+}
+
+// ScansIter returns an iterator over the scans pages, stopping with an error if the scans fail to be retrieved.
+func (s *ArkSecHubScansService) ScansIter(ctx context.Context) common.ArkPageIterator[scansmodels.ArkSecHubScan] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "Scans", func(ctx context.Context) common.ArkPageIterator[scansmodels.ArkSecHubScan] {
+		s.Logger.Info("Getting scans")
+		return common.NewArkSinglePageIterator(ctx, func(ctx context.Context) (*ArkSecHubScansPage, error) {
+			response, err := s.client.Get(ctx, sechubURL, nil)
 			if err != nil {
-				common.GlobalLogger.Warning("Error closing response body")
+				return nil, err
 			}
-		}(response.Body)
-		if response.StatusCode != http.StatusOK {
-			s.Logger.Error("Failed to list Secret Store Scans - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-			return
-		}
-		result, err := common.DeserializeJSONSnake(response.Body)
-		if err != nil {
-			s.Logger.Error("Failed to decode response: %v", err)
-			return
-		}
-		resultMap := result.(map[string]interface{})
-		var scansJSON []interface{}
-		if scans, ok := resultMap["scans"]; ok {
-			scansJSON = scans.([]interface{})
-		} else {
-			s.Logger.Error("Failed to list Secret Store scans, unexpected result")
-			return
-		}
-		for i, scansMember := range scansJSON {
-			if scansMemberMap, ok := scansMember.(map[string]interface{}); ok {
-				if ID, ok := scansMemberMap["id"]; ok {
-					scansJSON[i].(map[string]interface{})["id"] = ID
+			defer func(Body io.ReadCloser) {
+				err := Body.Close()
+				if err != nil {
+					common.GlobalLogger.Warning("Error closing response body")
 				}
+			}(response.Body)
+			if response.StatusCode != http.StatusOK {
+				return nil, common.NewArkAPIError(response, "failed to list secret store scans")
 			}
-		}
-		var scans []*scansmodels.ArkSecHubScan
-		if err := mapstructure.Decode(scansJSON, &scans); err != nil {
-			s.Logger.Error("Failed to validate Secret Store scans: %v", err)
-			return
-		}
-
-		results <- &ArkSecHubScansPage{Items: scans}
-	}()
-	return results, nil
+			result, err := common.DeserializeJSONSnake(response.Body)
+			if err != nil {
+				return nil, err
+			}
+			resultMap := result.(map[string]interface{})
+			var scansJSON []interface{}
+			if scans, ok := resultMap["scans"]; ok {
+				scansJSON = scans.([]interface{})
+			} else {
+				return nil, fmt.Errorf("failed to list secret store scans, unexpected result")
+			}
+			for i, scansMember := range scansJSON {
+				if scansMemberMap, ok := scansMember.(map[string]interface{}); ok {
+					if ID, ok := scansMemberMap["id"]; ok {
+						scansJSON[i].(map[string]interface{})["id"] = ID
+					}
+				}
+			}
+			var scans []*scansmodels.ArkSecHubScan
+			if err := mapstructure.Decode(scansJSON, &scans); err != nil {
+				return nil, err
+			}
+			return &ArkSecHubScansPage{Items: scans}, nil
+		})
+	})
 }
 
 // TriggerScan triggers scans in the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/kyc9azwliw2xa-trigger-scan
 func (s *ArkSecHubScansService) TriggerScan(triggerScan *scansmodels.ArkSecHubTriggerScans) (*scansmodels.ArkSecHubScanIDs, error) {
+	return s.TriggerScanWithContext(context.Background(), triggerScan)
+}
+
+// TriggerScanWithContext is TriggerScan with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubScansService) TriggerScanWithContext(ctx context.Context, triggerScan *scansmodels.ArkSecHubTriggerScans) (*scansmodels.ArkSecHubScanIDs, error) {
+	ctx, span := s.StartSpan(ctx, "TriggerScan")
+	defer span.End()
 	bodyMap := scansmodels.ArkSecHubScanMap{
 		Scope: scansmodels.ArkSecHubSecretStoreIds{
 			SecretStoresIds: triggerScan.SecretStoresIds,
@@ -132,7 +142,7 @@ func (s *ArkSecHubScansService) TriggerScan(triggerScan *scansmodels.ArkSecHubTr
 		return nil, err
 	}
 	s.Logger.Info("Triggering scan. Scan ID %s", triggerScan.ID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(triggerURL, triggerScan.Type, triggerScan.ID), bodyMapJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(triggerURL, triggerScan.Type, triggerScan.ID), bodyMapJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -143,7 +153,7 @@ func (s *ArkSecHubScansService) TriggerScan(triggerScan *scansmodels.ArkSecHubTr
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("failed to update scans - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update scans")
 	}
 	scansJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -159,15 +169,18 @@ func (s *ArkSecHubScansService) TriggerScan(triggerScan *scansmodels.ArkSecHubTr
 
 // ScansStats retrieves statistics about scans.
 func (s *ArkSecHubScansService) ScansStats() (*scansmodels.ArkSecHubScanStats, error) {
+	return s.ScansStatsWithContext(context.Background())
+}
+
+// ScansStatsWithContext is ScansStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubScansService) ScansStatsWithContext(ctx context.Context) (*scansmodels.ArkSecHubScanStats, error) {
+	ctx, span := s.StartSpan(ctx, "ScansStats")
+	defer span.End()
 	s.Logger.Info("Retrieving scan stats")
-	scansChan, err := s.Scans()
+	scans, err := common.CollectArkPageItems(s.ScansIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	scans := make([]*scansmodels.ArkSecHubScan, 0)
-	for page := range scansChan {
-		scans = append(scans, page.Items...)
-	}
 	var scanStats scansmodels.ArkSecHubScanStats
 	scanStats.ScansCount = len(scans)
 	scanStats.ScansCountByCreator = make(map[string]int)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
@@ -52,6 +51,7 @@ func NewArkSecHubSecretsService(authenticators ...auth.ArkAuth) (*ArkSecHubSecre
 		"Accept": "application/x.secretshub.beta+json",
 	})
 	secretsService.client = client
+	baseService.AddClient(client.ArkClient)
 	secretsService.ispAuth = ispAuth
 	secretsService.ArkBaseService = baseService
 	return secretsService, nil
@@ -66,12 +66,13 @@ func (s *ArkSecHubSecretsService) refreshSecHubAuth(client *common.ArkClient) er
 }
 
 func (s *ArkSecHubSecretsService) getSecretsWithFilters(
+	ctx context.Context,
 	projection string,
 	filter string,
 	limit int,
 	offset int,
 	sort string,
-) (<-chan *ArkSecHubSecretsPage, error) {
+) common.ArkPageIterator[secretsmodels.ArkSecHubSecret] {
 	query := map[string]string{}
 	if projection != "" {
 		query["projection"] = projection
@@ -88,102 +89,110 @@ func (s *ArkSecHubSecretsService) getSecretsWithFilters(
 	if sort != "" {
 		query["sort"] = sort
 	}
-	results := make(chan *ArkSecHubSecretsPage)
-	go func() {
-		defer close(results)
-		for {
-			response, err := s.client.Get(context.Background(), sechubURL, query)
-			if err != nil {
-				s.Logger.Error("Failed to list Secrets %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list Secrets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
-			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
-				return
-			}
-			resultMap := result.(map[string]interface{})
-			var secretsJSON []interface{}
-			if secrets, ok := resultMap["secrets"]; ok {
-				secretsJSON = secrets.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list Secrets, unexpected result")
-				return
-			}
-			for i, secrets := range secretsJSON {
-				if secretsMap, ok := secrets.(map[string]interface{}); ok {
-					if secretStoreID, ok := secretsMap["id"]; ok {
-						secretsJSON[i].(map[string]interface{})["id"] = secretStoreID
-					}
-				}
-			}
-			var secrets []*secretsmodels.ArkSecHubSecret
-			if err := mapstructure.Decode(secretsJSON, &secrets); err != nil {
-				s.Logger.Error("Failed to validate Secrets: %v", err)
-				return
-			}
-			results <- &ArkSecHubSecretsPage{Items: secrets}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
-				}
-			} else {
-				break
+	return common.NewArkNextLinkPageIterator(ctx, query, s.getSecretsPage)
+}
+
+func (s *ArkSecHubSecretsService) getSecretsPage(ctx context.Context, query map[string]string) (*ArkSecHubSecretsPage, string, error) {
+	response, err := s.client.Get(ctx, sechubURL, query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", common.NewArkAPIError(response, "failed to list secrets")
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	resultMap := result.(map[string]interface{})
+	var secretsJSON []interface{}
+	if secrets, ok := resultMap["secrets"]; ok {
+		secretsJSON = secrets.([]interface{})
+	} else {
+		return nil, "", fmt.Errorf("failed to list secrets, unexpected result")
+	}
+	for i, secrets := range secretsJSON {
+		if secretsMap, ok := secrets.(map[string]interface{}); ok {
+			if secretStoreID, ok := secretsMap["id"]; ok {
+				secretsJSON[i].(map[string]interface{})["id"] = secretStoreID
 			}
 		}
-	}()
-	return results, nil
+	}
+	var secrets []*secretsmodels.ArkSecHubSecret
+	if err := mapstructure.Decode(secretsJSON, &secrets); err != nil {
+		return nil, "", err
+	}
+	nextLink, _ := resultMap["nextLink"].(string)
+	return &ArkSecHubSecretsPage{Items: secrets}, nextLink, nil
 }
 
 // Secrets returns a channel of ArkSecHubSecretsPage containing all Secret Stores.
 // https://api-docs.cyberark.com/docs/secretshub-api/kdyou8dae9r8m-get-secrets
 func (s *ArkSecHubSecretsService) Secrets() (<-chan *ArkSecHubSecretsPage, error) {
-	return s.getSecretsWithFilters(
-		"",
-		"",
-		0,
-		0,
-		"",
-	)
+	return s.SecretsWithContext(context.Background())
+}
+
+// SecretsWithContext is Secrets with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretsService) SecretsWithContext(ctx context.Context) (<-chan *ArkSecHubSecretsPage, error) {
+	return common.ArkPageChannel(ctx, s.SecretsIter(ctx), s.Logger), nil
+}
+
+// SecretsIter returns an iterator over all the secrets pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkSecHubSecretsService) SecretsIter(ctx context.Context) common.ArkPageIterator[secretsmodels.ArkSecHubSecret] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "Secrets", func(ctx context.Context) common.ArkPageIterator[secretsmodels.ArkSecHubSecret] {
+		return s.getSecretsWithFilters(ctx,
+			"",
+			"",
+			0,
+			0,
+			"",
+		)
+	})
 }
 
 // ListSecretsBy returns a channel of ArkSecHubSecretsPage containing secrets filtered by the given filters.
 func (s *ArkSecHubSecretsService) ListSecretsBy(secretsFilters *secretsmodels.ArkSecHubSecretsFilter) (<-chan *ArkSecHubSecretsPage, error) {
-	return s.getSecretsWithFilters(
-		secretsFilters.Projection,
-		secretsFilters.Filter,
-		secretsFilters.Limit,
-		secretsFilters.Offset,
-		secretsFilters.Sort,
-	)
+	return s.ListSecretsByWithContext(context.Background(), secretsFilters)
+}
+
+// ListSecretsByWithContext is ListSecretsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretsService) ListSecretsByWithContext(ctx context.Context, secretsFilters *secretsmodels.ArkSecHubSecretsFilter) (<-chan *ArkSecHubSecretsPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSecretsByIter(ctx, secretsFilters), s.Logger), nil
+}
+
+// ListSecretsByIter returns an iterator over the secrets pages filtered by the given filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkSecHubSecretsService) ListSecretsByIter(ctx context.Context, secretsFilters *secretsmodels.ArkSecHubSecretsFilter) common.ArkPageIterator[secretsmodels.ArkSecHubSecret] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSecretsBy", func(ctx context.Context) common.ArkPageIterator[secretsmodels.ArkSecHubSecret] {
+		return s.getSecretsWithFilters(ctx,
+			secretsFilters.Projection,
+			secretsFilters.Filter,
+			secretsFilters.Limit,
+			secretsFilters.Offset,
+			secretsFilters.Sort,
+		)
+	})
 }
 
 // SecretsStats retrieves statistics about secrets.
 func (s *ArkSecHubSecretsService) SecretsStats() (*secretsmodels.ArkSecHubSecretsStats, error) {
+	return s.SecretsStatsWithContext(context.Background())
+}
+
+// SecretsStatsWithContext is SecretsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretsService) SecretsStatsWithContext(ctx context.Context) (*secretsmodels.ArkSecHubSecretsStats, error) {
+	ctx, span := s.StartSpan(ctx, "SecretsStats")
+	defer span.End()
 	s.Logger.Info("Retrieving secret stats")
-	secretsChan, err := s.Secrets()
+	secrets, err := common.CollectArkPageItems(s.SecretsIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	secrets := make([]*secretsmodels.ArkSecHubSecret, 0)
-	for page := range secretsChan {
-		secrets = append(secrets, page.Items...)
-	}
 	var secretsStats secretsmodels.ArkSecHubSecretsStats
 	secretsStats.SecretsCount = len(secrets)
 	secretsStats.SecretsCountByVendorType = make(map[string]int)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 
 	secretstoresmodels "github.com/cyberark/ark-sdk-golang/pkg/services/sechub/secretstores/models"
 	"github.com/mitchellh/mapstructure"
@@ -53,6 +52,7 @@ func NewArkSecHubSecretStoresService(authenticators ...auth.ArkAuth) (*ArkSecHub
 		return nil, err
 	}
 	secretStoresService.client = client
+	baseService.AddClient(client.ArkClient)
 	secretStoresService.ispAuth = ispAuth
 	secretStoresService.ArkBaseService = baseService
 	return secretStoresService, nil
@@ -67,9 +67,10 @@ func (s *ArkSecHubSecretStoresService) refreshSecHubAuth(client *common.ArkClien
 }
 
 func (s *ArkSecHubSecretStoresService) getSecretStoresWithFilters(
+	ctx context.Context,
 	behavior string,
 	filter string,
-) (<-chan *ArkSecHubSecretStoresPage, error) {
+) common.ArkPageIterator[secretstoresmodels.ArkSecHubSecretStore] {
 	query := map[string]string{}
 	if behavior != "" {
 		query["behavior"] = behavior
@@ -77,94 +78,106 @@ func (s *ArkSecHubSecretStoresService) getSecretStoresWithFilters(
 	/*if len(filter) != 0 {
 		query["filter"] = filter
 	}*/
-	results := make(chan *ArkSecHubSecretStoresPage)
-	go func() {
-		defer close(results)
-		for {
-			response, err := s.client.Get(context.Background(), sechubURL, query)
-			if err != nil {
-				s.Logger.Error("Failed to list Secret Stores: %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list Secret Stores - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
-			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
-				return
-			}
-			resultMap := result.(map[string]interface{})
-			var secretStoresJSON []interface{}
-			if secretStore, ok := resultMap["secret_stores"]; ok {
-				secretStoresJSON = secretStore.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list Secret Stores, unexpected result")
-				return
-			}
-			for i, secretStore := range secretStoresJSON {
-				if secretStoresMap, ok := secretStore.(map[string]interface{}); ok {
-					if secretStoreID, ok := secretStoresMap["id"]; ok {
-						secretStoresJSON[i].(map[string]interface{})["id"] = secretStoreID
-					}
-				}
-			}
-			var secretStores []*secretstoresmodels.ArkSecHubSecretStore
-			if err := mapstructure.Decode(secretStoresJSON, &secretStores); err != nil {
-				s.Logger.Error("Failed to validate Secret Stores: %v", err)
-				return
-			}
-			results <- &ArkSecHubSecretStoresPage{Items: secretStores}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
-				}
-			} else {
-				break
+	return common.NewArkNextLinkPageIterator(ctx, query, s.getSecretStoresPage)
+}
+
+func (s *ArkSecHubSecretStoresService) getSecretStoresPage(ctx context.Context, query map[string]string) (*ArkSecHubSecretStoresPage, string, error) {
+	response, err := s.client.Get(ctx, sechubURL, query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", common.NewArkAPIError(response, "failed to list secret stores")
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	resultMap := result.(map[string]interface{})
+	var secretStoresJSON []interface{}
+	if secretStore, ok := resultMap["secret_stores"]; ok {
+		secretStoresJSON = secretStore.([]interface{})
+	} else {
+		return nil, "", fmt.Errorf("failed to list secret stores, unexpected result")
+	}
+	for i, secretStore := range secretStoresJSON {
+		if secretStoresMap, ok := secretStore.(map[string]interface{}); ok {
+			if secretStoreID, ok := secretStoresMap["id"]; ok {
+				secretStoresJSON[i].(map[string]interface{})["id"] = secretStoreID
 			}
 		}
-	}()
-	return results, nil
+	}
+	var secretStores []*secretstoresmodels.ArkSecHubSecretStore
+	if err := mapstructure.Decode(secretStoresJSON, &secretStores); err != nil {
+		return nil, "", err
+	}
+	nextLink, _ := resultMap["nextLink"].(string)
+	return &ArkSecHubSecretStoresPage{Items: secretStores}, nextLink, nil
 }
 
 // ListSecretStores returns a channel of ArkSecHubSecretStoresPage containing all Secret Stores.
 func (s *ArkSecHubSecretStoresService) ListSecretStores() (<-chan *ArkSecHubSecretStoresPage, error) {
-	return s.getSecretStoresWithFilters(
-		"",
-		"",
-	)
+	return s.ListSecretStoresWithContext(context.Background())
+}
+
+// ListSecretStoresWithContext is ListSecretStores with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) ListSecretStoresWithContext(ctx context.Context) (<-chan *ArkSecHubSecretStoresPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSecretStoresIter(ctx), s.Logger), nil
+}
+
+// ListSecretStoresIter returns an iterator over all the secret stores pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkSecHubSecretStoresService) ListSecretStoresIter(ctx context.Context) common.ArkPageIterator[secretstoresmodels.ArkSecHubSecretStore] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSecretStores", func(ctx context.Context) common.ArkPageIterator[secretstoresmodels.ArkSecHubSecretStore] {
+		return s.getSecretStoresWithFilters(ctx,
+			"",
+			"",
+		)
+	})
 }
 
 // ListSecretStoresBy returns a channel of ArkSecHubSecretsPage containing secrets filtered by the given filters.
 func (s *ArkSecHubSecretStoresService) ListSecretStoresBy(secretStoresFilters *secretstoresmodels.ArkSecHubSecretStoresFilters) (<-chan *ArkSecHubSecretStoresPage, error) {
-	var behavior string
-	if secretStoresFilters.Behavior != "" {
-		behavior = secretStoresFilters.Behavior
-	}
-	return s.getSecretStoresWithFilters(
-		behavior,
-		secretStoresFilters.Filters,
-	)
+	return s.ListSecretStoresByWithContext(context.Background(), secretStoresFilters)
+}
+
+// ListSecretStoresByWithContext is ListSecretStoresBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) ListSecretStoresByWithContext(ctx context.Context, secretStoresFilters *secretstoresmodels.ArkSecHubSecretStoresFilters) (<-chan *ArkSecHubSecretStoresPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSecretStoresByIter(ctx, secretStoresFilters), s.Logger), nil
+}
+
+// ListSecretStoresByIter returns an iterator over the secret stores pages filtered by the given filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkSecHubSecretStoresService) ListSecretStoresByIter(ctx context.Context, secretStoresFilters *secretstoresmodels.ArkSecHubSecretStoresFilters) common.ArkPageIterator[secretstoresmodels.ArkSecHubSecretStore] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSecretStoresBy", func(ctx context.Context) common.ArkPageIterator[secretstoresmodels.ArkSecHubSecretStore] {
+		var behavior string
+		if secretStoresFilters.Behavior != "" {
+			behavior = secretStoresFilters.Behavior
+		}
+		return s.getSecretStoresWithFilters(ctx,
+			behavior,
+			secretStoresFilters.Filters,
+		)
+	})
 }
 
 // SecretStore returns an individual secret store.
 // https://api-docs.cyberark.com/docs/secretshub-api/tw80b23aww65j-get-a-secret-store
 func (s *ArkSecHubSecretStoresService) SecretStore(
+	getSecretStore *secretstoresmodels.ArkSecHubGetSecretStore) (*secretstoresmodels.ArkSecHubSecretStore, error) {
+	return s.SecretStoreWithContext(context.Background(), getSecretStore)
+}
+
+// SecretStoreWithContext is SecretStore with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) SecretStoreWithContext(
+	ctx context.Context,
 	getSecretStore *secretstoresmodels.ArkSecHubGetSecretStore) (*secretstoresmodels.ArkSecHubSecretStore, error) {
 	s.Logger.Info("Retrieving secret store [%s]", getSecretStore.SecretStoreID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(secretStoreURL, getSecretStore.SecretStoreID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(secretStoreURL, getSecretStore.SecretStoreID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +188,7 @@ func (s *ArkSecHubSecretStoresService) SecretStore(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve secret store - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve secret store")
 	}
 	secretStoreJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -196,9 +209,16 @@ func (s *ArkSecHubSecretStoresService) SecretStore(
 // SecretStoreConnStatus retrieves the connection status of a secret store.
 // https://api-docs.cyberark.com/docs/secretshub-api/b7f2joyxr9ekn-get-connection-status-of-secret-store
 func (s *ArkSecHubSecretStoresService) SecretStoreConnStatus(
+	getSecretStoreConnStatus *secretstoresmodels.ArkSecHubGetSecretStoreConnectionStatus) (*secretstoresmodels.ArkSecHubGetSecretStoreConnectionStatusResponse, error) {
+	return s.SecretStoreConnStatusWithContext(context.Background(), getSecretStoreConnStatus)
+}
+
+// SecretStoreConnStatusWithContext is SecretStoreConnStatus with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) SecretStoreConnStatusWithContext(
+	ctx context.Context,
 	getSecretStoreConnStatus *secretstoresmodels.ArkSecHubGetSecretStoreConnectionStatus) (*secretstoresmodels.ArkSecHubGetSecretStoreConnectionStatusResponse, error) {
 	s.Logger.Info("Retrieving secret store connection status [%s]", getSecretStoreConnStatus.SecretStoreID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(connStatusURL, getSecretStoreConnStatus.SecretStoreID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(connStatusURL, getSecretStoreConnStatus.SecretStoreID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +229,7 @@ func (s *ArkSecHubSecretStoresService) SecretStoreConnStatus(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve secret store connection status - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve secret store connection status")
 	}
 	connStatusJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -226,6 +246,13 @@ func (s *ArkSecHubSecretStoresService) SecretStoreConnStatus(
 // CreateSecretStore creates a new secret store
 // https://api-docs.cyberark.com/docs/secretshub-api/99oqbphsqgomi-create-secret-store
 func (s *ArkSecHubSecretStoresService) CreateSecretStore(secretStore *secretstoresmodels.ArkSecHubCreateSecretStore) (*secretstoresmodels.ArkSecHubSecretStore, error) {
+	return s.CreateSecretStoreWithContext(context.Background(), secretStore)
+}
+
+// CreateSecretStoreWithContext is CreateSecretStore with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) CreateSecretStoreWithContext(ctx context.Context, secretStore *secretstoresmodels.ArkSecHubCreateSecretStore) (*secretstoresmodels.ArkSecHubSecretStore, error) {
+	ctx, span := s.StartSpan(ctx, "CreateSecretStore")
+	defer span.End()
 	s.Logger.Info("Creating secret store[%s]", secretStore.Name)
 	createSecretStoreJSON, err := common.SerializeJSONCamel(secretStore)
 	if err != nil {
@@ -235,7 +262,7 @@ func (s *ArkSecHubSecretStoresService) CreateSecretStore(secretStore *secretstor
 		delete(createSecretStoreJSON, "description")
 		createSecretStoreJSON["description"] = secretStore.Description
 	}
-	response, err := s.client.Post(context.Background(), sechubURL, createSecretStoreJSON)
+	response, err := s.client.Post(ctx, sechubURL, createSecretStoreJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +273,7 @@ func (s *ArkSecHubSecretStoresService) CreateSecretStore(secretStore *secretstor
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to create secret store - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to create secret store")
 	}
 	secretStoreJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -263,12 +290,19 @@ func (s *ArkSecHubSecretStoresService) CreateSecretStore(secretStore *secretstor
 // UpdateSecretStore updates a secret store
 // https://api-docs.cyberark.com/docs/secretshub-api/99oqbphsqgomi-create-secret-store
 func (s *ArkSecHubSecretStoresService) UpdateSecretStore(secretStore *secretstoresmodels.ArkSecHubUpdateSecretStore) (*secretstoresmodels.ArkSecHubSecretStore, error) {
+	return s.UpdateSecretStoreWithContext(context.Background(), secretStore)
+}
+
+// UpdateSecretStoreWithContext is UpdateSecretStore with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) UpdateSecretStoreWithContext(ctx context.Context, secretStore *secretstoresmodels.ArkSecHubUpdateSecretStore) (*secretstoresmodels.ArkSecHubSecretStore, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateSecretStore")
+	defer span.End()
 	s.Logger.Info("Updating secret store[%s]", secretStore.Name)
 	updateSecretStoreJSON, err := common.SerializeJSONCamel(secretStore)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Patch(context.Background(), fmt.Sprintf(secretStoreURL, secretStore.SecretStoreID), updateSecretStoreJSON)
+	response, err := s.client.Patch(ctx, fmt.Sprintf(secretStoreURL, secretStore.SecretStoreID), updateSecretStoreJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +313,7 @@ func (s *ArkSecHubSecretStoresService) UpdateSecretStore(secretStore *secretstor
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to udpate secret store - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to udpate secret store")
 	}
 	secretStoreJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -296,12 +330,19 @@ func (s *ArkSecHubSecretStoresService) UpdateSecretStore(secretStore *secretstor
 // SetSecretStoreState sets the state of a secret store.
 // https://api-docs.cyberark.com/docs/secretshub-api/qb5o0s8br9nxg-set-secret-store-state
 func (s *ArkSecHubSecretStoresService) SetSecretStoreState(
+	setSecretStoreState *secretstoresmodels.ArkSecHubSetSecretStoreState) error {
+	return s.SetSecretStoreStateWithContext(context.Background(), setSecretStoreState)
+}
+
+// SetSecretStoreStateWithContext is SetSecretStoreState with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) SetSecretStoreStateWithContext(
+	ctx context.Context,
 	setSecretStoreState *secretstoresmodels.ArkSecHubSetSecretStoreState) error {
 	s.Logger.Info("Setting secret store state [%s]", setSecretStoreState.SecretStoreID)
 	bodyMap := map[string]string{
 		"action": setSecretStoreState.Action,
 	}
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(stateURL, setSecretStoreState.SecretStoreID), bodyMap)
+	response, err := s.client.Put(ctx, fmt.Sprintf(stateURL, setSecretStoreState.SecretStoreID), bodyMap)
 	if err != nil {
 		return err
 	}
@@ -312,7 +353,7 @@ func (s *ArkSecHubSecretStoresService) SetSecretStoreState(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to set secret store state - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to set secret store state")
 	}
 	return nil
 }
@@ -320,13 +361,20 @@ func (s *ArkSecHubSecretStoresService) SetSecretStoreState(
 // SetSecretStoresState sets the state of multiple secret stores
 // https://api-docs.cyberark.com/docs/secretshub-api/hxzzult869lhk-set-state-for-multiple-secret-stores
 func (s *ArkSecHubSecretStoresService) SetSecretStoresState(
+	setSecretStoresState *secretstoresmodels.ArkSecHubSetSecretStoresState) (*secretstoresmodels.ArkSecHubSetSecretStoresStateResponse, error) {
+	return s.SetSecretStoresStateWithContext(context.Background(), setSecretStoresState)
+}
+
+// SetSecretStoresStateWithContext is SetSecretStoresState with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) SetSecretStoresStateWithContext(
+	ctx context.Context,
 	setSecretStoresState *secretstoresmodels.ArkSecHubSetSecretStoresState) (*secretstoresmodels.ArkSecHubSetSecretStoresStateResponse, error) {
 	s.Logger.Info("Setting multiple secret store states [%s] to [%s]", setSecretStoresState.SecretStoreIDs, setSecretStoresState.Action)
 	bodyMap := map[string]interface{}{
 		"action":         setSecretStoresState.Action,
 		"secretStoreIds": setSecretStoresState.SecretStoreIDs,
 	}
-	response, err := s.client.Put(context.Background(), statesURL, bodyMap)
+	response, err := s.client.Put(ctx, statesURL, bodyMap)
 	if err != nil {
 		return nil, err
 	}
@@ -337,7 +385,7 @@ func (s *ArkSecHubSecretStoresService) SetSecretStoresState(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusMultiStatus {
-		return nil, fmt.Errorf("failed to set secret stores state - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to set secret stores state")
 	}
 	secretStoresStateJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -354,8 +402,15 @@ func (s *ArkSecHubSecretStoresService) SetSecretStoresState(
 // DeleteSecretStore deletes a specified secret store based on ID
 // https://api-docs.cyberark.com/docs/secretshub-api/88xyegf662fxm-delete-secret-store
 func (s *ArkSecHubSecretStoresService) DeleteSecretStore(secretStore *secretstoresmodels.ArkSecHubDeleteSecretStore) error {
+	return s.DeleteSecretStoreWithContext(context.Background(), secretStore)
+}
+
+// DeleteSecretStoreWithContext is DeleteSecretStore with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) DeleteSecretStoreWithContext(ctx context.Context, secretStore *secretstoresmodels.ArkSecHubDeleteSecretStore) error {
+	ctx, span := s.StartSpan(ctx, "DeleteSecretStore")
+	defer span.End()
 	s.Logger.Info("Deleting secret store")
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(secretStoreURL, secretStore.SecretStoreID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(secretStoreURL, secretStore.SecretStoreID), nil)
 	if err != nil {
 		return err
 	}
@@ -366,22 +421,25 @@ func (s *ArkSecHubSecretStoresService) DeleteSecretStore(secretStore *secretstor
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete secret store - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete secret store")
 	}
 	return nil
 }
 
 // SecretStoresStats retrieves statistics about secret stores.
 func (s *ArkSecHubSecretStoresService) SecretStoresStats() (*secretstoresmodels.ArkSecHubSecretStoresStats, error) {
+	return s.SecretStoresStatsWithContext(context.Background())
+}
+
+// SecretStoresStatsWithContext is SecretStoresStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSecretStoresService) SecretStoresStatsWithContext(ctx context.Context) (*secretstoresmodels.ArkSecHubSecretStoresStats, error) {
+	ctx, span := s.StartSpan(ctx, "SecretStoresStats")
+	defer span.End()
 	s.Logger.Info("Retrieving secret store stats")
-	secretStoresChan, err := s.ListSecretStores()
+	secretStores, err := common.CollectArkPageItems(s.ListSecretStoresIter(ctx))
 	if err != nil {
 		return nil, err
 	}
-	secretStores := make([]*secretstoresmodels.ArkSecHubSecretStore, 0)
-	for page := range secretStoresChan {
-		secretStores = append(secretStores, page.Items...)
-	}
 	var secretStoresStats secretstoresmodels.ArkSecHubSecretStoresStats
 	secretStoresStats.SecretStoresCount = len(secretStores)
 	secretStoresStats.SecretStoresCountByType = make(map[string]int)
@@ -4,6 +4,7 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	sechubsecretstoresactions "github.com/cyberark/ark-sdk-golang/pkg/services/sechub/secretstores/actions"
+	secretstoresmodels "github.com/cyberark/ark-sdk-golang/pkg/services/sechub/secretstores/models"
 )
 
 // ServiceConfig is the configuration for the Secrets Hub Secret Stores service.
@@ -16,6 +17,10 @@ var ServiceConfig = services.ArkServiceConfig{
 			sechubsecretstoresactions.CLIAction,
 		},
 	},
+	RedactedModels: []interface{}{
+		&secretstoresmodels.ArkSecHubCreateSecretStore{},
+		&secretstoresmodels.ArkSecHubUpdateSecretStore{},
+	},
 }
 
 // ServiceGenerator is the function that creates a new instance of the SecHub Secret Stores service.
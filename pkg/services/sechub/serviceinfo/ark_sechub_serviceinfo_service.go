@@ -2,7 +2,6 @@ package serviceinfo
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 
@@ -44,6 +43,7 @@ func NewArkSecHubServiceInfoService(authenticators ...auth.ArkAuth) (*ArkSecHubS
 		return nil, err
 	}
 	serviceInfoService.client = client
+	baseService.AddClient(client.ArkClient)
 	serviceInfoService.ispAuth = ispAuth
 	serviceInfoService.ArkBaseService = baseService
 	return serviceInfoService, nil
@@ -60,8 +60,15 @@ func (s *ArkSecHubServiceInfoService) refreshSecHubAuth(client *common.ArkClient
 // ServiceInfo retrieves the service info from the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/b7c22j9aexv8r-service-info
 func (s *ArkSecHubServiceInfoService) ServiceInfo() (*serviceinfomodels.ArkSecHubGetServiceInfo, error) {
+	return s.ServiceInfoWithContext(context.Background())
+}
+
+// ServiceInfoWithContext is ServiceInfo with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubServiceInfoService) ServiceInfoWithContext(ctx context.Context) (*serviceinfomodels.ArkSecHubGetServiceInfo, error) {
+	ctx, span := s.StartSpan(ctx, "ServiceInfo")
+	defer span.End()
 	s.Logger.Info("Getting serviceinfo")
-	response, err := s.client.Get(context.Background(), sechubURL, nil)
+	response, err := s.client.Get(ctx, sechubURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +79,7 @@ func (s *ArkSecHubServiceInfoService) ServiceInfo() (*serviceinfomodels.ArkSecHu
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get service info - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get service info")
 	}
 	serviceinfoJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
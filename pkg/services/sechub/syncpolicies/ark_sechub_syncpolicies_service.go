@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
@@ -50,6 +49,7 @@ func NewArkSecHubSyncPoliciesService(authenticators ...auth.ArkAuth) (*ArkSecHub
 		return nil, err
 	}
 	syncPoliciesService.client = client
+	baseService.AddClient(client.ArkClient)
 	syncPoliciesService.ispAuth = ispAuth
 	syncPoliciesService.ArkBaseService = baseService
 	return syncPoliciesService, nil
@@ -64,9 +64,10 @@ func (s *ArkSecHubSyncPoliciesService) refreshSecHubAuth(client *common.ArkClien
 }
 
 func (s *ArkSecHubSyncPoliciesService) getSyncPoliciesWithFilters(
+	ctx context.Context,
 	projection string,
 	filter string,
-) (<-chan *ArkSecHubSyncPoliciesPage, error) {
+) common.ArkPageIterator[syncpoliciesmodels.ArkSecHubPolicy] {
 	query := map[string]string{}
 	if projection != "" {
 		query["projection"] = projection
@@ -74,95 +75,107 @@ func (s *ArkSecHubSyncPoliciesService) getSyncPoliciesWithFilters(
 	if filter != "" {
 		query["filter"] = filter
 	}
-	results := make(chan *ArkSecHubSyncPoliciesPage)
-	go func() {
-		defer close(results)
-		for {
-			response, err := s.client.Get(context.Background(), sechubURL, query)
-			if err != nil {
-				s.Logger.Error("Failed to list Sync Policies: %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list Sync Policies - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
-			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
-				return
-			}
-			resultMap := result.(map[string]interface{})
-			var syncPoliciesJSON []interface{}
-			if syncPolicy, ok := resultMap["policies"]; ok {
-				syncPoliciesJSON = syncPolicy.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list Sync Policies, unexpected result")
-				return
-			}
-			for i, syncPolicy := range syncPoliciesJSON {
-				if syncPoliciesMap, ok := syncPolicy.(map[string]interface{}); ok {
-					if syncPolicyID, ok := syncPoliciesMap["id"]; ok {
-						syncPoliciesJSON[i].(map[string]interface{})["id"] = syncPolicyID
-					}
-				}
-			}
-			var syncPolicies []*syncpoliciesmodels.ArkSecHubPolicy
-			if err := mapstructure.Decode(syncPoliciesJSON, &syncPolicies); err != nil {
-				s.Logger.Error("Failed to validate Sync Policies: %v", err)
-				return
-			}
-			results <- &ArkSecHubSyncPoliciesPage{Items: syncPolicies}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
-				}
-			} else {
-				break
+	return common.NewArkNextLinkPageIterator(ctx, query, s.getSyncPoliciesPage)
+}
+
+func (s *ArkSecHubSyncPoliciesService) getSyncPoliciesPage(ctx context.Context, query map[string]string) (*ArkSecHubSyncPoliciesPage, string, error) {
+	response, err := s.client.Get(ctx, sechubURL, query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", common.NewArkAPIError(response, "failed to list sync policies")
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	resultMap := result.(map[string]interface{})
+	var syncPoliciesJSON []interface{}
+	if syncPolicy, ok := resultMap["policies"]; ok {
+		syncPoliciesJSON = syncPolicy.([]interface{})
+	} else {
+		return nil, "", fmt.Errorf("failed to list sync policies, unexpected result")
+	}
+	for i, syncPolicy := range syncPoliciesJSON {
+		if syncPoliciesMap, ok := syncPolicy.(map[string]interface{}); ok {
+			if syncPolicyID, ok := syncPoliciesMap["id"]; ok {
+				syncPoliciesJSON[i].(map[string]interface{})["id"] = syncPolicyID
 			}
 		}
-	}()
-	return results, nil
+	}
+	var syncPolicies []*syncpoliciesmodels.ArkSecHubPolicy
+	if err := mapstructure.Decode(syncPoliciesJSON, &syncPolicies); err != nil {
+		return nil, "", err
+	}
+	nextLink, _ := resultMap["nextLink"].(string)
+	return &ArkSecHubSyncPoliciesPage{Items: syncPolicies}, nextLink, nil
 }
 
 // ListSyncPolicies returns a channel of ArkSecHubSyncPoliciesPage containing all Sync Policies.
 func (s *ArkSecHubSyncPoliciesService) ListSyncPolicies(syncPolicies *syncpoliciesmodels.ArkSecHubGetSyncPolicies) (<-chan *ArkSecHubSyncPoliciesPage, error) {
-	var projection string
-	if syncPolicies.Projection != "" {
-		projection = syncPolicies.Projection
-	}
-	return s.getSyncPoliciesWithFilters(
-		projection,
-		"",
-	)
+	return s.ListSyncPoliciesWithContext(context.Background(), syncPolicies)
+}
+
+// ListSyncPoliciesWithContext is ListSyncPolicies with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) ListSyncPoliciesWithContext(ctx context.Context, syncPolicies *syncpoliciesmodels.ArkSecHubGetSyncPolicies) (<-chan *ArkSecHubSyncPoliciesPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSyncPoliciesIter(ctx, syncPolicies), s.Logger), nil
+}
+
+// ListSyncPoliciesIter returns an iterator over all the sync policies pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkSecHubSyncPoliciesService) ListSyncPoliciesIter(ctx context.Context, syncPolicies *syncpoliciesmodels.ArkSecHubGetSyncPolicies) common.ArkPageIterator[syncpoliciesmodels.ArkSecHubPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSyncPolicies", func(ctx context.Context) common.ArkPageIterator[syncpoliciesmodels.ArkSecHubPolicy] {
+		var projection string
+		if syncPolicies.Projection != "" {
+			projection = syncPolicies.Projection
+		}
+		return s.getSyncPoliciesWithFilters(ctx,
+			projection,
+			"",
+		)
+	})
 }
 
 // ListSyncPoliciesBy returns a channel of ArkSecHubSyncPoliciesPage containing secrets filtered by the given filters.
 func (s *ArkSecHubSyncPoliciesService) ListSyncPoliciesBy(syncPoliciesFilters *syncpoliciesmodels.ArkSecHubSyncPoliciesFilters) (<-chan *ArkSecHubSyncPoliciesPage, error) {
-	var projection string
-	if syncPoliciesFilters.Projection != "" {
-		projection = syncPoliciesFilters.Projection
-	}
-	return s.getSyncPoliciesWithFilters(
-		projection,
-		syncPoliciesFilters.Filters,
-	)
+	return s.ListSyncPoliciesByWithContext(context.Background(), syncPoliciesFilters)
+}
+
+// ListSyncPoliciesByWithContext is ListSyncPoliciesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) ListSyncPoliciesByWithContext(ctx context.Context, syncPoliciesFilters *syncpoliciesmodels.ArkSecHubSyncPoliciesFilters) (<-chan *ArkSecHubSyncPoliciesPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSyncPoliciesByIter(ctx, syncPoliciesFilters), s.Logger), nil
+}
+
+// ListSyncPoliciesByIter returns an iterator over the sync policies pages filtered by the given filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkSecHubSyncPoliciesService) ListSyncPoliciesByIter(ctx context.Context, syncPoliciesFilters *syncpoliciesmodels.ArkSecHubSyncPoliciesFilters) common.ArkPageIterator[syncpoliciesmodels.ArkSecHubPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSyncPoliciesBy", func(ctx context.Context) common.ArkPageIterator[syncpoliciesmodels.ArkSecHubPolicy] {
+		var projection string
+		if syncPoliciesFilters.Projection != "" {
+			projection = syncPoliciesFilters.Projection
+		}
+		return s.getSyncPoliciesWithFilters(ctx,
+			projection,
+			syncPoliciesFilters.Filters,
+		)
+	})
 }
 
 // SyncPolicy returns an individual sync policy
 // https://api-docs.cyberark.com/docs/secretshub-api/f5jjh0rv9ivfs-get-sync-policy
 func (s *ArkSecHubSyncPoliciesService) SyncPolicy(
+	getSyncPolicy *syncpoliciesmodels.ArkSecHubGetSyncPolicy) (*syncpoliciesmodels.ArkSecHubPolicy, error) {
+	return s.SyncPolicyWithContext(context.Background(), getSyncPolicy)
+}
+
+// SyncPolicyWithContext is SyncPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) SyncPolicyWithContext(
+	ctx context.Context,
 	getSyncPolicy *syncpoliciesmodels.ArkSecHubGetSyncPolicy) (*syncpoliciesmodels.ArkSecHubPolicy, error) {
 	s.Logger.Info("Retrieving sync policy [%s]", getSyncPolicy.PolicyID)
 	query := map[string]string{}
@@ -171,7 +184,7 @@ func (s *ArkSecHubSyncPoliciesService) SyncPolicy(
 	} else {
 		query["projection"] = "REGULAR"
 	}
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(policyURL, getSyncPolicy.PolicyID), query)
+	response, err := s.client.Get(ctx, fmt.Sprintf(policyURL, getSyncPolicy.PolicyID), query)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +195,7 @@ func (s *ArkSecHubSyncPoliciesService) SyncPolicy(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve sync policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve sync policy")
 	}
 	syncPolicyJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -203,6 +216,13 @@ func (s *ArkSecHubSyncPoliciesService) SyncPolicy(
 // CreateSyncPolicy creates a new sync policy
 // https://api-docs.cyberark.com/docs/secretshub-api/3kf2d2n01bm5x-create-sync-policy
 func (s *ArkSecHubSyncPoliciesService) CreateSyncPolicy(syncPolicy *syncpoliciesmodels.ArkSechubCreateSyncPolicy) (*syncpoliciesmodels.ArkSecHubPolicy, error) {
+	return s.CreateSyncPolicyWithContext(context.Background(), syncPolicy)
+}
+
+// CreateSyncPolicyWithContext is CreateSyncPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) CreateSyncPolicyWithContext(ctx context.Context, syncPolicy *syncpoliciesmodels.ArkSechubCreateSyncPolicy) (*syncpoliciesmodels.ArkSecHubPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "CreateSyncPolicy")
+	defer span.End()
 	s.Logger.Info("Creating sync policy [%s]", syncPolicy.Name)
 	createSyncPolicyJSON, err := common.SerializeJSONCamel(syncPolicy)
 	if err != nil {
@@ -216,7 +236,7 @@ func (s *ArkSecHubSyncPoliciesService) CreateSyncPolicy(syncPolicy *syncpolicies
 	if syncPolicy.Transformation.Predefined == "default" {
 		delete(createSyncPolicyJSON, "transformation")
 	}
-	response, err := s.client.Post(context.Background(), sechubURL, createSyncPolicyJSON)
+	response, err := s.client.Post(ctx, sechubURL, createSyncPolicyJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +247,7 @@ func (s *ArkSecHubSyncPoliciesService) CreateSyncPolicy(syncPolicy *syncpolicies
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to create sync policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to create sync policy")
 	}
 	syncPolicyJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -244,12 +264,19 @@ func (s *ArkSecHubSyncPoliciesService) CreateSyncPolicy(syncPolicy *syncpolicies
 // SetSyncPolicyState sets the state of a sync policy.
 // https://api-docs.cyberark.com/docs/secretshub-api/by05aodbep6xy-set-sync-policy-state
 func (s *ArkSecHubSyncPoliciesService) SetSyncPolicyState(
+	setSyncPolicyState *syncpoliciesmodels.ArkSecHubSetSyncPolicyState) error {
+	return s.SetSyncPolicyStateWithContext(context.Background(), setSyncPolicyState)
+}
+
+// SetSyncPolicyStateWithContext is SetSyncPolicyState with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) SetSyncPolicyStateWithContext(
+	ctx context.Context,
 	setSyncPolicyState *syncpoliciesmodels.ArkSecHubSetSyncPolicyState) error {
 	s.Logger.Info("Setting sync policy state [%s] to [%s]", setSyncPolicyState.PolicyID, setSyncPolicyState.Action)
 	bodyMap := map[string]string{
 		"action": setSyncPolicyState.Action,
 	}
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(policyStateURL, setSyncPolicyState.PolicyID), bodyMap)
+	response, err := s.client.Put(ctx, fmt.Sprintf(policyStateURL, setSyncPolicyState.PolicyID), bodyMap)
 	if err != nil {
 		return err
 	}
@@ -260,7 +287,7 @@ func (s *ArkSecHubSyncPoliciesService) SetSyncPolicyState(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to set sync policy state - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to set sync policy state")
 	}
 	return nil
 }
@@ -268,8 +295,15 @@ func (s *ArkSecHubSyncPoliciesService) SetSyncPolicyState(
 // DeleteSyncPolicy deletes a specified secret store based on ID
 // https://api-docs.cyberark.com/docs/secretshub-api/lgbolpf4ka7oa-delete-sync-policy
 func (s *ArkSecHubSyncPoliciesService) DeleteSyncPolicy(syncPolicy *syncpoliciesmodels.ArkSecHubDeleteSyncPolicy) error {
+	return s.DeleteSyncPolicyWithContext(context.Background(), syncPolicy)
+}
+
+// DeleteSyncPolicyWithContext is DeleteSyncPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) DeleteSyncPolicyWithContext(ctx context.Context, syncPolicy *syncpoliciesmodels.ArkSecHubDeleteSyncPolicy) error {
+	ctx, span := s.StartSpan(ctx, "DeleteSyncPolicy")
+	defer span.End()
 	s.Logger.Info("Deleting secret store")
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(policyURL, syncPolicy.PolicyID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(policyURL, syncPolicy.PolicyID), nil)
 	if err != nil {
 		return err
 	}
@@ -280,25 +314,28 @@ func (s *ArkSecHubSyncPoliciesService) DeleteSyncPolicy(syncPolicy *syncpolicies
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete sync policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete sync policy")
 	}
 	return nil
 }
 
 // SyncPoliciesStats retrieves statistics about sync policies.
 func (s *ArkSecHubSyncPoliciesService) SyncPoliciesStats() (*syncpoliciesmodels.ArkSecHubSyncPoliciesStats, error) {
+	return s.SyncPoliciesStatsWithContext(context.Background())
+}
+
+// SyncPoliciesStatsWithContext is SyncPoliciesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSecHubSyncPoliciesService) SyncPoliciesStatsWithContext(ctx context.Context) (*syncpoliciesmodels.ArkSecHubSyncPoliciesStats, error) {
+	ctx, span := s.StartSpan(ctx, "SyncPoliciesStats")
+	defer span.End()
 	s.Logger.Info("Retrieving sync policy stats")
 	var projection = syncpoliciesmodels.ArkSecHubGetSyncPolicies{
 		Projection: "REGULAR",
 	}
-	syncPoliciesChan, err := s.ListSyncPolicies(&projection)
+	syncPolicies, err := common.CollectArkPageItems(s.ListSyncPoliciesIter(ctx, &projection))
 	if err != nil {
 		return nil, err
 	}
-	syncPolicies := make([]*syncpoliciesmodels.ArkSecHubPolicy, 0)
-	for page := range syncPoliciesChan {
-		syncPolicies = append(syncPolicies, page.Items...)
-	}
 	var syncPoliciesStats syncpoliciesmodels.ArkSecHubSyncPoliciesStats
 	syncPoliciesStats.SyncPoliciesCount = len(syncPolicies)
 	syncPoliciesStats.SyncPoliciesCountByCreator = make(map[string]int)
@@ -98,6 +98,7 @@ func NewArkSIAAccessService(authenticators ...auth.ArkAuth) (*ArkSIAAccessServic
 		return nil, err
 	}
 	accessService.client = client
+	baseService.AddClient(client.ArkClient)
 	accessService.ispAuth = ispAuth
 	accessService.ArkBaseService = baseService
 	return accessService, nil
@@ -317,6 +318,13 @@ func (s *ArkSIAAccessService) uninstallConnectorOnMachine(
 
 // TestConnectorReachability tests the reachability of a connector.
 func (s *ArkSIAAccessService) TestConnectorReachability(testReachabilityRequest *accessmodels.ArkSIATestConnectorReachability) (*accessmodels.ArkSIAReachabilityTestResponse, error) {
+	return s.TestConnectorReachabilityWithContext(context.Background(), testReachabilityRequest)
+}
+
+// TestConnectorReachabilityWithContext is TestConnectorReachability with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAAccessService) TestConnectorReachabilityWithContext(ctx context.Context, testReachabilityRequest *accessmodels.ArkSIATestConnectorReachability) (*accessmodels.ArkSIAReachabilityTestResponse, error) {
+	ctx, span := s.StartSpan(ctx, "TestConnectorReachability")
+	defer span.End()
 	s.Logger.Info("Starting connector reachability test. ConnectorID: %s", testReachabilityRequest.ConnectorID)
 	var testReachabilityRequestJSON = map[string]interface{}{
 		"targets": []map[string]interface{}{
@@ -327,7 +335,7 @@ func (s *ArkSIAAccessService) TestConnectorReachability(testReachabilityRequest
 		},
 		"checkBackendEndpoints": testReachabilityRequest.CheckBackendEndpoints,
 	}
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(connectorTestReachabilityURL, testReachabilityRequest.ConnectorID), testReachabilityRequestJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(connectorTestReachabilityURL, testReachabilityRequest.ConnectorID), testReachabilityRequestJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +346,7 @@ func (s *ArkSIAAccessService) TestConnectorReachability(testReachabilityRequest
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to test connector reachability - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to test connector reachability")
 	}
 	reachabilityTestResponseJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -354,13 +362,20 @@ func (s *ArkSIAAccessService) TestConnectorReachability(testReachabilityRequest
 
 // ConnectorSetupScript creates the setup script for the connector.
 func (s *ArkSIAAccessService) ConnectorSetupScript(getConnectorSetupScript *accessmodels.ArkSIAGetConnectorSetupScript) (*accessmodels.ArkSIAConnectorSetupScript, error) {
+	return s.ConnectorSetupScriptWithContext(context.Background(), getConnectorSetupScript)
+}
+
+// ConnectorSetupScriptWithContext is ConnectorSetupScript with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAAccessService) ConnectorSetupScriptWithContext(ctx context.Context, getConnectorSetupScript *accessmodels.ArkSIAGetConnectorSetupScript) (*accessmodels.ArkSIAConnectorSetupScript, error) {
+	ctx, span := s.StartSpan(ctx, "ConnectorSetupScript")
+	defer span.End()
 	s.Logger.Info("Retrieving new connector setup script")
 	var getConnectorSetupScriptJSON map[string]interface{}
 	err := mapstructure.Decode(getConnectorSetupScript, &getConnectorSetupScriptJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Post(context.Background(), connectorSetupScriptURL, getConnectorSetupScriptJSON)
+	response, err := s.client.Post(ctx, connectorSetupScriptURL, getConnectorSetupScriptJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +386,7 @@ func (s *ArkSIAAccessService) ConnectorSetupScript(getConnectorSetupScript *acce
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to retrieve connector setup script - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve connector setup script")
 	}
 	connectorSetupScriptJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -387,12 +402,19 @@ func (s *ArkSIAAccessService) ConnectorSetupScript(getConnectorSetupScript *acce
 
 // InstallConnector installs the connector on the target machine.
 func (s *ArkSIAAccessService) InstallConnector(installConnector *accessmodels.ArkSIAInstallConnector) (*accessmodels.ArkSIAAccessConnectorID, error) {
+	return s.InstallConnectorWithContext(context.Background(), installConnector)
+}
+
+// InstallConnectorWithContext is InstallConnector with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAAccessService) InstallConnectorWithContext(ctx context.Context, installConnector *accessmodels.ArkSIAInstallConnector) (*accessmodels.ArkSIAAccessConnectorID, error) {
+	ctx, span := s.StartSpan(ctx, "InstallConnector")
+	defer span.End()
 	s.Logger.Info(
 		"Installing connector on machine [%s] of type [%s]",
 		installConnector.TargetMachine,
 		installConnector.ConnectorOS,
 	)
-	installationScript, err := s.ConnectorSetupScript(&accessmodels.ArkSIAGetConnectorSetupScript{
+	installationScript, err := s.ConnectorSetupScriptWithContext(ctx, &accessmodels.ArkSIAGetConnectorSetupScript{
 		ConnectorOS:     installConnector.ConnectorOS,
 		ConnectorPoolID: installConnector.ConnectorPoolID,
 		ConnectorType:   installConnector.ConnectorType,
@@ -415,6 +437,13 @@ func (s *ArkSIAAccessService) InstallConnector(installConnector *accessmodels.Ar
 
 // UninstallConnector uninstalls the connector from the target machine.
 func (s *ArkSIAAccessService) UninstallConnector(uninstallConnector *accessmodels.ArkSIAUninstallConnector) error {
+	return s.UninstallConnectorWithContext(context.Background(), uninstallConnector)
+}
+
+// UninstallConnectorWithContext is UninstallConnector with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAAccessService) UninstallConnectorWithContext(ctx context.Context, uninstallConnector *accessmodels.ArkSIAUninstallConnector) error {
+	ctx, span := s.StartSpan(ctx, "UninstallConnector")
+	defer span.End()
 	s.Logger.Info(
 		"Uninstalling connector [%s] from machine",
 		uninstallConnector.ConnectorID,
@@ -432,7 +461,7 @@ func (s *ArkSIAAccessService) UninstallConnector(uninstallConnector *accessmodel
 	if err != nil {
 		return err
 	}
-	return s.DeleteConnector(&accessmodels.ArkSIADeleteConnector{
+	return s.DeleteConnectorWithContext(ctx, &accessmodels.ArkSIADeleteConnector{
 		ConnectorID: uninstallConnector.ConnectorID,
 		RetryCount:  uninstallConnector.RetryCount,
 		RetryDelay:  uninstallConnector.RetryDelay,
@@ -441,13 +470,20 @@ func (s *ArkSIAAccessService) UninstallConnector(uninstallConnector *accessmodel
 
 // DeleteConnector deletes the connector from the target machine.
 func (s *ArkSIAAccessService) DeleteConnector(deleteConnector *accessmodels.ArkSIADeleteConnector) error {
+	return s.DeleteConnectorWithContext(context.Background(), deleteConnector)
+}
+
+// DeleteConnectorWithContext is DeleteConnector with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAAccessService) DeleteConnectorWithContext(ctx context.Context, deleteConnector *accessmodels.ArkSIADeleteConnector) error {
+	ctx, span := s.StartSpan(ctx, "DeleteConnector")
+	defer span.End()
 	s.Logger.Info(
 		"Deleting connector [%s] from machine",
 		deleteConnector.ConnectorID,
 	)
 	currentTryCount := 0
 	for {
-		response, err := s.client.Delete(context.Background(), fmt.Sprintf(connectorURL, deleteConnector.ConnectorID), nil)
+		response, err := s.client.Delete(ctx, fmt.Sprintf(connectorURL, deleteConnector.ConnectorID), nil)
 		if err != nil {
 			return err
 		}
@@ -455,10 +491,14 @@ func (s *ArkSIAAccessService) DeleteConnector(deleteConnector *accessmodels.ArkS
 			if currentTryCount < deleteConnector.RetryCount {
 				currentTryCount++
 				s.Logger.Warning("Failed to delete connector, retrying... [%d/%d]", currentTryCount, deleteConnector.RetryCount)
-				time.Sleep(time.Duration(deleteConnector.RetryDelay) * time.Second)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(time.Duration(deleteConnector.RetryDelay) * time.Second):
+				}
 				continue
 			}
-			return fmt.Errorf("failed to delete connector - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+			return common.NewArkAPIError(response, "failed to delete connector")
 		}
 		break
 	}
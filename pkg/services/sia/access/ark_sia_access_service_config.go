@@ -4,6 +4,7 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	siaaccessactions "github.com/cyberark/ark-sdk-golang/pkg/services/sia/access/actions"
+	accessmodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/access/models"
 )
 
 // ServiceConfig is the configuration for the ArkSIAAccessService.
@@ -19,6 +20,10 @@ var ServiceConfig = services.ArkServiceConfig{
 			siaaccessactions.TerraformActionAccessConnectorResource,
 		},
 	},
+	RedactedModels: []interface{}{
+		&accessmodels.ArkSIAInstallConnector{},
+		&accessmodels.ArkSIAUninstallConnector{},
+	},
 }
 
 // ServiceGenerator is the function that creates a new instance of the SIA Access service.
@@ -7,9 +7,9 @@ type ArkSIAInstallConnector struct {
 	ConnectorPoolID    string `json:"connector_pool_id" mapstructure:"connector_pool_id" flag:"connector-pool-id" desc:"The connector pool which the connector will be part of, if not given, the connector will be assigned to the default one" validate:"required"`
 	TargetMachine      string `json:"target_machine" mapstructure:"target_machine" desc:"Target machine on which to install the connector on"`
 	Username           string `json:"username" mapstructure:"username" desc:"Username to connect with to the target machine"`
-	Password           string `json:"password,omitempty" mapstructure:"password" desc:"Password to connect with to the target machine"`
+	Password           string `json:"password,omitempty" mapstructure:"password" desc:"Password to connect with to the target machine" redact:"true"`
 	PrivateKeyPath     string `json:"private_key_path,omitempty" mapstructure:"private_key_path" desc:"Private key file path to use for connecting to the target machine via ssh"`
-	PrivateKeyContents string `json:"private_key_contents,omitempty" mapstructure:"private_key_contents" desc:"Private key contents to use for connecting to the target machine via ssh"`
+	PrivateKeyContents string `json:"private_key_contents,omitempty" mapstructure:"private_key_contents" desc:"Private key contents to use for connecting to the target machine via ssh" redact:"true"`
 	RetryCount         int    `json:"retry_count" mapstructure:"retry_count" flag:"retry-count" desc:"Number of times to retry to connect if it fails" default:"10"`
 	RetryDelay         int    `json:"retry_delay" mapstructure:"retry_delay" flag:"retry-delay" desc:"Delay in seconds between retries" default:"5"`
 }
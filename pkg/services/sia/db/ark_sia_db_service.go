@@ -18,12 +18,12 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/common/isp"
+	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	dbmodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/db/models"
 	"github.com/cyberark/ark-sdk-golang/pkg/services/sia/sso"
 	ssomodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/sso/models"
 	workspacesdbmodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/workspaces/db/models"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 const (
@@ -58,6 +58,7 @@ func NewArkSIADBService(authenticators ...auth.ArkAuth) (*ArkSIADBService, error
 		return nil, err
 	}
 	dbService.client = client
+	baseService.AddClient(client.ArkClient)
 	dbService.ispAuth = ispAuth
 	dbService.ArkBaseService = baseService
 	dbService.ssoService, err = sso.NewArkSIASSOService(ispAuth)
@@ -76,28 +77,26 @@ func (s *ArkSIADBService) refreshSIAAuth(client *common.ArkClient) error {
 }
 
 func (s *ArkSIADBService) proxyAddress(dbType string) (string, error) {
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return "", err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	return fmt.Sprintf("%s.%s.%s", claims["subdomain"], dbType, claims["platform_domain"]), nil
+	return fmt.Sprintf("%s.%s.%s", claims.Subdomain, dbType, claims.PlatformDomain), nil
 }
 
 func (s *ArkSIADBService) connectionString(targetAddress string, targetUsername string, networkName string) (string, error) {
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return "", err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
 	addressNetwork := targetAddress
 	if networkName != "" {
 		addressNetwork = fmt.Sprintf("%s#%s", targetAddress, networkName)
 	}
 	if targetUsername != "" {
-		return fmt.Sprintf("%s#%s@%s@%s", claims["unique_name"], claims["subdomain"], targetUsername, addressNetwork), nil
+		return fmt.Sprintf("%s#%s@%s@%s", claims.Username, claims.Subdomain, targetUsername, addressNetwork), nil
 	}
-	return fmt.Sprintf("%s#%s@%s", claims["unique_name"], claims["subdomain"], addressNetwork), nil
+	return fmt.Sprintf("%s#%s@%s", claims.Username, claims.Subdomain, addressNetwork), nil
 }
 
 func (s *ArkSIADBService) addToPgPass(username, address, password string) error {
@@ -200,6 +199,7 @@ func (s *ArkSIADBService) execute(commandLine string) error {
 }
 
 func (s *ArkSIADBService) generateAssets(
+	ctx context.Context,
 	assetType string,
 	connectionMethod string,
 	responseFormat string,
@@ -219,7 +219,7 @@ func (s *ArkSIADBService) generateAssets(
 	if resourceType != "" {
 		assetsRequest["resource_type"] = resourceType
 	}
-	response, err := s.client.Post(context.Background(), assetsURL, assetsRequest)
+	response, err := s.client.Post(ctx, assetsURL, assetsRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +230,7 @@ func (s *ArkSIADBService) generateAssets(
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to generate assets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to generate assets")
 	}
 	if responseFormat == dbmodels.ResponseFormatRaw {
 		respBytes, err := io.ReadAll(response.Body)
@@ -248,6 +248,13 @@ func (s *ArkSIADBService) generateAssets(
 
 // Psql executes a PostgreSQL command using the provided execution parameters.
 func (s *ArkSIADBService) Psql(psqlExecution *dbmodels.ArkSIADBPsqlExecution) error {
+	return s.PsqlWithContext(context.Background(), psqlExecution)
+}
+
+// PsqlWithContext is Psql with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIADBService) PsqlWithContext(ctx context.Context, psqlExecution *dbmodels.ArkSIADBPsqlExecution) error {
+	ctx, span := s.StartSpan(ctx, "Psql")
+	defer span.End()
 	proxyAddress, err := s.proxyAddress("postgres")
 	if err != nil {
 		return err
@@ -256,7 +263,7 @@ func (s *ArkSIADBService) Psql(psqlExecution *dbmodels.ArkSIADBPsqlExecution) er
 	if err != nil {
 		return err
 	}
-	password, err := s.ssoService.ShortLivedPassword(&ssomodels.ArkSIASSOGetShortLivedPassword{
+	password, err := s.ssoService.ShortLivedPasswordWithContext(ctx, &ssomodels.ArkSIASSOGetShortLivedPassword{
 		Service: "DPA-DB",
 	})
 	if err != nil {
@@ -278,6 +285,13 @@ func (s *ArkSIADBService) Psql(psqlExecution *dbmodels.ArkSIADBPsqlExecution) er
 
 // Mysql executes a MySQL command using the provided execution parameters.
 func (s *ArkSIADBService) Mysql(mysqlExecution *dbmodels.ArkSIADBMysqlExecution) error {
+	return s.MysqlWithContext(context.Background(), mysqlExecution)
+}
+
+// MysqlWithContext is Mysql with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIADBService) MysqlWithContext(ctx context.Context, mysqlExecution *dbmodels.ArkSIADBMysqlExecution) error {
+	ctx, span := s.StartSpan(ctx, "Mysql")
+	defer span.End()
 	proxyAddress, err := s.proxyAddress("mysql")
 	if err != nil {
 		return err
@@ -286,7 +300,7 @@ func (s *ArkSIADBService) Mysql(mysqlExecution *dbmodels.ArkSIADBMysqlExecution)
 	if err != nil {
 		return err
 	}
-	password, err := s.ssoService.ShortLivedPassword(&ssomodels.ArkSIASSOGetShortLivedPassword{
+	password, err := s.ssoService.ShortLivedPasswordWithContext(ctx, &ssomodels.ArkSIASSOGetShortLivedPassword{
 		Service: "DPA-DB",
 	})
 	if err != nil {
@@ -308,6 +322,13 @@ func (s *ArkSIADBService) Mysql(mysqlExecution *dbmodels.ArkSIADBMysqlExecution)
 
 // Sqlcmd executes a sqlcmd command using the provided execution parameters.
 func (s *ArkSIADBService) Sqlcmd(sqlcmdExecution *dbmodels.ArkSIADBSqlcmdExecution) error {
+	return s.SqlcmdWithContext(context.Background(), sqlcmdExecution)
+}
+
+// SqlcmdWithContext is Sqlcmd with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIADBService) SqlcmdWithContext(ctx context.Context, sqlcmdExecution *dbmodels.ArkSIADBSqlcmdExecution) error {
+	ctx, span := s.StartSpan(ctx, "Sqlcmd")
+	defer span.End()
 	proxyAddress, err := s.proxyAddress("mssql")
 	if err != nil {
 		return err
@@ -316,7 +337,7 @@ func (s *ArkSIADBService) Sqlcmd(sqlcmdExecution *dbmodels.ArkSIADBSqlcmdExecuti
 	if err != nil {
 		return err
 	}
-	password, err := s.ssoService.ShortLivedPassword(&ssomodels.ArkSIASSOGetShortLivedPassword{
+	password, err := s.ssoService.ShortLivedPasswordWithContext(ctx, &ssomodels.ArkSIASSOGetShortLivedPassword{
 		Service: "DPA-DB",
 	})
 	if err != nil {
@@ -328,8 +349,15 @@ func (s *ArkSIADBService) Sqlcmd(sqlcmdExecution *dbmodels.ArkSIADBSqlcmdExecuti
 
 // GenerateOracleTnsNames generates Oracle TNS names and writes them to the specified folder.
 func (s *ArkSIADBService) GenerateOracleTnsNames(generateOracleAssets *dbmodels.ArkSIADBOracleGenerateAssets) error {
+	return s.GenerateOracleTnsNamesWithContext(context.Background(), generateOracleAssets)
+}
+
+// GenerateOracleTnsNamesWithContext is GenerateOracleTnsNames with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIADBService) GenerateOracleTnsNamesWithContext(ctx context.Context, generateOracleAssets *dbmodels.ArkSIADBOracleGenerateAssets) error {
+	ctx, span := s.StartSpan(ctx, "GenerateOracleTnsNames")
+	defer span.End()
 	s.Logger.Info("Generating Oracle TNS names")
-	assetsData, err := s.generateAssets(
+	assetsData, err := s.generateAssets(ctx,
 		dbmodels.AssetTypeOracleTNSAssets,
 		generateOracleAssets.ConnectionMethod,
 		generateOracleAssets.ResponseFormat,
@@ -392,9 +420,16 @@ func (s *ArkSIADBService) GenerateOracleTnsNames(generateOracleAssets *dbmodels.
 
 // GenerateProxyFullChain generates a proxy full chain asset and writes it to the specified folder.
 func (s *ArkSIADBService) GenerateProxyFullChain(generateProxyFullChain *dbmodels.ArkSIADBProxyFullChainGenerateAssets) error {
+	return s.GenerateProxyFullChainWithContext(context.Background(), generateProxyFullChain)
+}
+
+// GenerateProxyFullChainWithContext is GenerateProxyFullChain with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIADBService) GenerateProxyFullChainWithContext(ctx context.Context, generateProxyFullChain *dbmodels.ArkSIADBProxyFullChainGenerateAssets) error {
+	ctx, span := s.StartSpan(ctx, "GenerateProxyFullChain")
+	defer span.End()
 	s.Logger.Info("Generating proxy full chain")
 
-	assetsData, err := s.generateAssets(
+	assetsData, err := s.generateAssets(ctx,
 		dbmodels.AssetTypeProxyFullChain,
 		generateProxyFullChain.ConnectionMethod,
 		generateProxyFullChain.ResponseFormat,
@@ -3,7 +3,6 @@ package k8s
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
@@ -52,6 +51,7 @@ func NewArkSIAK8SService(authenticators ...auth.ArkAuth) (*ArkSIAK8SService, err
 		return nil, err
 	}
 	k8sService.client = client
+	baseService.AddClient(client.ArkClient)
 	k8sService.ispAuth = ispAuth
 	k8sService.ArkBaseService = baseService
 	return k8sService, nil
@@ -67,8 +67,15 @@ func (s *ArkSIAK8SService) refreshSIAAuth(client *common.ArkClient) error {
 
 // GenerateKubeconfig generates a kubeconfig file for the SIA K8S service and saves it to the specified folder.
 func (s *ArkSIAK8SService) GenerateKubeconfig(generateKubeConfig *k8smodels.ArkSIAK8SGenerateKubeconfig) (string, error) {
+	return s.GenerateKubeconfigWithContext(context.Background(), generateKubeConfig)
+}
+
+// GenerateKubeconfigWithContext is GenerateKubeconfig with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAK8SService) GenerateKubeconfigWithContext(ctx context.Context, generateKubeConfig *k8smodels.ArkSIAK8SGenerateKubeconfig) (string, error) {
+	ctx, span := s.StartSpan(ctx, "GenerateKubeconfig")
+	defer span.End()
 	s.Logger.Info("Getting kubeconfig")
-	response, err := s.client.Get(context.Background(), kubeConfigGenerationURL, nil)
+	response, err := s.client.Get(ctx, kubeConfigGenerationURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -79,7 +86,7 @@ func (s *ArkSIAK8SService) GenerateKubeconfig(generateKubeConfig *k8smodels.ArkS
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get kubeconfig - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "failed to get kubeconfig")
 	}
 	folderPath := generateKubeConfig.Folder
 	if folderPath == "" {
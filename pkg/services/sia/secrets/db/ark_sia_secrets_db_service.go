@@ -50,6 +50,7 @@ func NewArkSIASecretsDBService(authenticators ...auth.ArkAuth) (*ArkSIASecretsDB
 		return nil, err
 	}
 	secretsDBService.client = client
+	baseService.AddClient(client.ArkClient)
 	secretsDBService.ispAuth = ispAuth
 	secretsDBService.ArkBaseService = baseService
 	return secretsDBService, nil
@@ -79,7 +80,7 @@ func (s *ArkSIASecretsDBService) parseSecretTagsIntoMap(secretJSONMap map[string
 	}
 }
 
-func (s *ArkSIASecretsDBService) listSecretsWithFilters(secretType string, tags map[string]string) (*dbsecretsmodels.ArkSIADBSecretMetadataList, error) {
+func (s *ArkSIASecretsDBService) listSecretsWithFilters(ctx context.Context, secretType string, tags map[string]string) (*dbsecretsmodels.ArkSIADBSecretMetadataList, error) {
 	params := make(map[string]string)
 	if secretType != "" {
 		params["secret_type"] = secretType
@@ -89,7 +90,7 @@ func (s *ArkSIASecretsDBService) listSecretsWithFilters(secretType string, tags
 			params[key] = value
 		}
 	}
-	response, err := s.client.Get(context.Background(), secretsURL, params)
+	response, err := s.client.Get(ctx, secretsURL, params)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +101,7 @@ func (s *ArkSIASecretsDBService) listSecretsWithFilters(secretType string, tags
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list secrets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list secrets")
 	}
 	secretsJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -126,6 +127,13 @@ func (s *ArkSIASecretsDBService) listSecretsWithFilters(secretType string, tags
 
 // AddSecret adds a new secret to the Ark SIA DB.
 func (s *ArkSIASecretsDBService) AddSecret(addSecret *dbsecretsmodels.ArkSIADBAddSecret) (*dbsecretsmodels.ArkSIADBSecretMetadata, error) {
+	return s.AddSecretWithContext(context.Background(), addSecret)
+}
+
+// AddSecretWithContext is AddSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) AddSecretWithContext(ctx context.Context, addSecret *dbsecretsmodels.ArkSIADBAddSecret) (*dbsecretsmodels.ArkSIADBSecretMetadata, error) {
+	ctx, span := s.StartSpan(ctx, "AddSecret")
+	defer span.End()
 	if addSecret.StoreType == "" {
 		storeType, ok := dbsecretsmodels.SecretTypeToStoreDict[addSecret.SecretType]
 		if !ok {
@@ -198,7 +206,7 @@ func (s *ArkSIASecretsDBService) AddSecret(addSecret *dbsecretsmodels.ArkSIADBAd
 	default:
 		return nil, fmt.Errorf("unsupported secret type: %s", addSecret.SecretType)
 	}
-	response, err := s.client.Post(context.Background(), secretsURL, addSecretJSON)
+	response, err := s.client.Post(ctx, secretsURL, addSecretJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +217,7 @@ func (s *ArkSIASecretsDBService) AddSecret(addSecret *dbsecretsmodels.ArkSIADBAd
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add secret - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add secret")
 	}
 	secretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -227,8 +235,15 @@ func (s *ArkSIASecretsDBService) AddSecret(addSecret *dbsecretsmodels.ArkSIADBAd
 
 // UpdateSecret updates an existing secret in the Ark SIA DB.
 func (s *ArkSIASecretsDBService) UpdateSecret(updateSecret *dbsecretsmodels.ArkSIADBUpdateSecret) (*dbsecretsmodels.ArkSIADBSecretMetadata, error) {
+	return s.UpdateSecretWithContext(context.Background(), updateSecret)
+}
+
+// UpdateSecretWithContext is UpdateSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) UpdateSecretWithContext(ctx context.Context, updateSecret *dbsecretsmodels.ArkSIADBUpdateSecret) (*dbsecretsmodels.ArkSIADBSecretMetadata, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateSecret")
+	defer span.End()
 	if updateSecret.SecretName != "" && updateSecret.SecretID == "" {
-		secrets, err := s.ListSecretsBy(&dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: updateSecret.SecretName})
+		secrets, err := s.ListSecretsByWithContext(ctx, &dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: updateSecret.SecretName})
 		if err != nil || len(secrets.Secrets) == 0 {
 			return nil, fmt.Errorf("failed to find secret by name: %v", err)
 		}
@@ -301,7 +316,7 @@ func (s *ArkSIASecretsDBService) UpdateSecret(updateSecret *dbsecretsmodels.ArkS
 			"private_key": updateSecret.AtlasPrivateKey,
 		}
 	}
-	response, err := s.client.Patch(context.Background(), fmt.Sprintf(secretURL, updateSecret.SecretID), updateSecretMap)
+	response, err := s.client.Patch(ctx, fmt.Sprintf(secretURL, updateSecret.SecretID), updateSecretMap)
 	if err != nil {
 		return nil, err
 	}
@@ -313,7 +328,7 @@ func (s *ArkSIASecretsDBService) UpdateSecret(updateSecret *dbsecretsmodels.ArkS
 	}(response.Body)
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update secret - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update secret")
 	}
 	secretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -332,15 +347,22 @@ func (s *ArkSIASecretsDBService) UpdateSecret(updateSecret *dbsecretsmodels.ArkS
 
 // DeleteSecret deletes a secret from the Ark SIA DB.
 func (s *ArkSIASecretsDBService) DeleteSecret(deleteSecret *dbsecretsmodels.ArkSIADBDeleteSecret) error {
+	return s.DeleteSecretWithContext(context.Background(), deleteSecret)
+}
+
+// DeleteSecretWithContext is DeleteSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) DeleteSecretWithContext(ctx context.Context, deleteSecret *dbsecretsmodels.ArkSIADBDeleteSecret) error {
+	ctx, span := s.StartSpan(ctx, "DeleteSecret")
+	defer span.End()
 	if deleteSecret.SecretName != "" && deleteSecret.SecretID == "" {
-		secrets, err := s.ListSecretsBy(&dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: deleteSecret.SecretName})
+		secrets, err := s.ListSecretsByWithContext(ctx, &dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: deleteSecret.SecretName})
 		if err != nil || len(secrets.Secrets) == 0 {
 			return fmt.Errorf("failed to find secret by name: %v", err)
 		}
 		deleteSecret.SecretID = secrets.Secrets[0].SecretID
 	}
 	s.Logger.Info("Deleting db secret by id [%s]", deleteSecret.SecretID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(secretURL, deleteSecret.SecretID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(secretURL, deleteSecret.SecretID), nil)
 	if err != nil {
 		return err
 	}
@@ -351,19 +373,33 @@ func (s *ArkSIASecretsDBService) DeleteSecret(deleteSecret *dbsecretsmodels.ArkS
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete db secret [%s] - [%d]", common.SerializeResponseToJSON(response.Body), response.StatusCode)
+		return common.NewArkAPIError(response, "failed to delete db secret")
 	}
 	return nil
 }
 
 // ListSecrets lists all secrets in the Ark SIA DB.
 func (s *ArkSIASecretsDBService) ListSecrets() (*dbsecretsmodels.ArkSIADBSecretMetadataList, error) {
-	return s.listSecretsWithFilters("", nil)
+	return s.ListSecretsWithContext(context.Background())
+}
+
+// ListSecretsWithContext is ListSecrets with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) ListSecretsWithContext(ctx context.Context) (*dbsecretsmodels.ArkSIADBSecretMetadataList, error) {
+	ctx, span := s.StartSpan(ctx, "ListSecrets")
+	defer span.End()
+	return s.listSecretsWithFilters(ctx, "", nil)
 }
 
 // ListSecretsBy lists secrets in the Ark SIA DB by the given filter.
 func (s *ArkSIASecretsDBService) ListSecretsBy(filter *dbsecretsmodels.ArkSIADBSecretsFilter) (*dbsecretsmodels.ArkSIADBSecretMetadataList, error) {
-	secrets, err := s.listSecretsWithFilters(filter.SecretType, filter.Tags)
+	return s.ListSecretsByWithContext(context.Background(), filter)
+}
+
+// ListSecretsByWithContext is ListSecretsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) ListSecretsByWithContext(ctx context.Context, filter *dbsecretsmodels.ArkSIADBSecretsFilter) (*dbsecretsmodels.ArkSIADBSecretMetadataList, error) {
+	ctx, span := s.StartSpan(ctx, "ListSecretsBy")
+	defer span.End()
+	secrets, err := s.listSecretsWithFilters(ctx, filter.SecretType, filter.Tags)
 	if err != nil {
 		return nil, err
 	}
@@ -403,15 +439,22 @@ func (s *ArkSIASecretsDBService) ListSecretsBy(filter *dbsecretsmodels.ArkSIADBS
 
 // EnableSecret enables a secret in the Ark SIA DB.
 func (s *ArkSIASecretsDBService) EnableSecret(enableSecret *dbsecretsmodels.ArkSIADBEnableSecret) error {
+	return s.EnableSecretWithContext(context.Background(), enableSecret)
+}
+
+// EnableSecretWithContext is EnableSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) EnableSecretWithContext(ctx context.Context, enableSecret *dbsecretsmodels.ArkSIADBEnableSecret) error {
+	ctx, span := s.StartSpan(ctx, "EnableSecret")
+	defer span.End()
 	if enableSecret.SecretName != "" && enableSecret.SecretID == "" {
-		secrets, err := s.ListSecretsBy(&dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: enableSecret.SecretName})
+		secrets, err := s.ListSecretsByWithContext(ctx, &dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: enableSecret.SecretName})
 		if err != nil || len(secrets.Secrets) == 0 {
 			return fmt.Errorf("failed to find secret by name: %v", err)
 		}
 		enableSecret.SecretID = secrets.Secrets[0].SecretID
 	}
 	s.Logger.Info("Enabling db secret by id [%s]", enableSecret.SecretID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(enableSecretURL, enableSecret.SecretID), nil)
+	response, err := s.client.Post(ctx, fmt.Sprintf(enableSecretURL, enableSecret.SecretID), nil)
 	if err != nil {
 		return err
 	}
@@ -422,22 +465,29 @@ func (s *ArkSIASecretsDBService) EnableSecret(enableSecret *dbsecretsmodels.ArkS
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to enable db secret [%s] - [%d]", common.SerializeResponseToJSON(response.Body), response.StatusCode)
+		return common.NewArkAPIError(response, "failed to enable db secret")
 	}
 	return nil
 }
 
 // DisableSecret disables a secret in the Ark SIA DB.
 func (s *ArkSIASecretsDBService) DisableSecret(enableSecret *dbsecretsmodels.ArkSIADBDisableSecret) error {
+	return s.DisableSecretWithContext(context.Background(), enableSecret)
+}
+
+// DisableSecretWithContext is DisableSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) DisableSecretWithContext(ctx context.Context, enableSecret *dbsecretsmodels.ArkSIADBDisableSecret) error {
+	ctx, span := s.StartSpan(ctx, "DisableSecret")
+	defer span.End()
 	if enableSecret.SecretName != "" && enableSecret.SecretID == "" {
-		secrets, err := s.ListSecretsBy(&dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: enableSecret.SecretName})
+		secrets, err := s.ListSecretsByWithContext(ctx, &dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: enableSecret.SecretName})
 		if err != nil || len(secrets.Secrets) == 0 {
 			return fmt.Errorf("failed to find secret by name: %v", err)
 		}
 		enableSecret.SecretID = secrets.Secrets[0].SecretID
 	}
 	s.Logger.Info("Disabling db secret by id [%s]", enableSecret.SecretID)
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(disableSecretURL, enableSecret.SecretID), nil)
+	response, err := s.client.Post(ctx, fmt.Sprintf(disableSecretURL, enableSecret.SecretID), nil)
 	if err != nil {
 		return err
 	}
@@ -448,22 +498,29 @@ func (s *ArkSIASecretsDBService) DisableSecret(enableSecret *dbsecretsmodels.Ark
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to disable db secret [%s] - [%d]", common.SerializeResponseToJSON(response.Body), response.StatusCode)
+		return common.NewArkAPIError(response, "failed to disable db secret")
 	}
 	return nil
 }
 
 // Secret retrieves a secret from the Ark SIA DB by its ID.
 func (s *ArkSIASecretsDBService) Secret(getSecret *dbsecretsmodels.ArkSIADBGetSecret) (*dbsecretsmodels.ArkSIADBSecretMetadata, error) {
+	return s.SecretWithContext(context.Background(), getSecret)
+}
+
+// SecretWithContext is Secret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) SecretWithContext(ctx context.Context, getSecret *dbsecretsmodels.ArkSIADBGetSecret) (*dbsecretsmodels.ArkSIADBSecretMetadata, error) {
+	ctx, span := s.StartSpan(ctx, "Secret")
+	defer span.End()
 	if getSecret.SecretName != "" && getSecret.SecretID == "" {
-		secrets, err := s.ListSecretsBy(&dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: getSecret.SecretName})
+		secrets, err := s.ListSecretsByWithContext(ctx, &dbsecretsmodels.ArkSIADBSecretsFilter{SecretName: getSecret.SecretName})
 		if err != nil || len(secrets.Secrets) == 0 {
 			return nil, fmt.Errorf("failed to find secret by name: %v", err)
 		}
 		getSecret.SecretID = secrets.Secrets[0].SecretID
 	}
 	s.Logger.Info("Retrieving db secret by id [%s]", getSecret.SecretID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(secretURL, getSecret.SecretID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(secretURL, getSecret.SecretID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -476,7 +533,7 @@ func (s *ArkSIASecretsDBService) Secret(getSecret *dbsecretsmodels.ArkSIADBGetSe
 
 	// Check response status
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve db secret [%s] - [%d]", common.SerializeResponseToJSON(response.Body), response.StatusCode)
+		return nil, common.NewArkAPIError(response, "failed to retrieve db secret")
 	}
 	secretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -495,8 +552,15 @@ func (s *ArkSIASecretsDBService) Secret(getSecret *dbsecretsmodels.ArkSIADBGetSe
 
 // SecretsStats retrieves the statistics of secrets in the Ark SIA DB.
 func (s *ArkSIASecretsDBService) SecretsStats() (*dbsecretsmodels.ArkSIADBSecretsStats, error) {
+	return s.SecretsStatsWithContext(context.Background())
+}
+
+// SecretsStatsWithContext is SecretsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsDBService) SecretsStatsWithContext(ctx context.Context) (*dbsecretsmodels.ArkSIADBSecretsStats, error) {
+	ctx, span := s.StartSpan(ctx, "SecretsStats")
+	defer span.End()
 	s.Logger.Info("Calculating secrets statistics")
-	secretsList, err := s.ListSecrets()
+	secretsList, err := s.ListSecretsWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
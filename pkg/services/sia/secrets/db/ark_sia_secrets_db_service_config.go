@@ -4,6 +4,8 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	siasecretsdbactions "github.com/cyberark/ark-sdk-golang/pkg/services/sia/secrets/db/actions"
+	dbsecretsmodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/secrets/db/models"
+	"github.com/cyberark/ark-sdk-golang/pkg/services/sia/secrets/db/models/secretsdata"
 )
 
 // ServiceConfig is the configuration for the SIA DB secrets service.
@@ -22,6 +24,13 @@ var ServiceConfig = services.ArkServiceConfig{
 			siasecretsdbactions.TerraformActionSecretsDBDataSource,
 		},
 	},
+	RedactedModels: []interface{}{
+		&dbsecretsmodels.ArkSIADBAddSecret{},
+		&dbsecretsmodels.ArkSIADBUpdateSecret{},
+		&secretsdata.ArkSIADBUserPasswordSecretData{},
+		&secretsdata.ArkSIADBIAMUserSecretData{},
+		&secretsdata.ArkSIADBAtlasAccessKeysSecretData{},
+	},
 }
 
 // ServiceGenerator is the function that creates a new instance of the SIA DB secrets service.
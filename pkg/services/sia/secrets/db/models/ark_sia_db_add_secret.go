@@ -11,7 +11,7 @@ type ArkSIADBAddSecret struct {
 
 	// Username Password Secret Type
 	Username string `json:"username,omitempty" mapstructure:"username" flag:"username" desc:"Name or id of the user for username_password type"`
-	Password string `json:"password,omitempty" mapstructure:"password" flag:"password" desc:"Password of the user for username_password type"`
+	Password string `json:"password,omitempty" mapstructure:"password" flag:"password" desc:"Password of the user for username_password type" redact:"true"`
 
 	// PAM Account Secret Type
 	PAMSafe        string `json:"pam_safe,omitempty" mapstructure:"pam_safe" flag:"pam-safe" desc:"Safe of the account for pam_account type"`
@@ -21,9 +21,9 @@ type ArkSIADBAddSecret struct {
 	IAMAccount         string `json:"iam_account,omitempty" mapstructure:"iam_account" flag:"iam-account" desc:"Account number of the iam user"`
 	IAMUsername        string `json:"iam_username,omitempty" mapstructure:"iam_username" flag:"iam-username" desc:"Username portion in the ARN of the iam user"`
 	IAMAccessKeyID     string `json:"iam_access_key_id,omitempty" mapstructure:"iam_access_key_id" flag:"iam-access-key-id" desc:"Access key id of the user"`
-	IAMSecretAccessKey string `json:"iam_secret_access_key,omitempty" mapstructure:"iam_secret_access_key" flag:"iam-secret-access-key" desc:"Secret access key of the user"`
+	IAMSecretAccessKey string `json:"iam_secret_access_key,omitempty" mapstructure:"iam_secret_access_key" flag:"iam-secret-access-key" desc:"Secret access key of the user" redact:"true"`
 
 	// Atlas Secret Type
 	AtlasPublicKey  string `json:"atlas_public_key,omitempty" mapstructure:"atlas_public_key" flag:"atlas-public-key" desc:"Public part of mongo atlas access keys"`
-	AtlasPrivateKey string `json:"atlas_private_key,omitempty" mapstructure:"atlas_private_key" flag:"atlas-private-key" desc:"Private part of mongo atlas access keys"`
+	AtlasPrivateKey string `json:"atlas_private_key,omitempty" mapstructure:"atlas_private_key" flag:"atlas-private-key" desc:"Private part of mongo atlas access keys" redact:"true"`
 }
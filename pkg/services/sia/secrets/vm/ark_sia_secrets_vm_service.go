@@ -48,6 +48,7 @@ func NewArkSIASecretsVMService(authenticators ...auth.ArkAuth) (*ArkSIASecretsVM
 		return nil, err
 	}
 	secretsVMService.client = client
+	baseService.AddClient(client.ArkClient)
 	secretsVMService.ispAuth = ispAuth
 	secretsVMService.ArkBaseService = baseService
 	return secretsVMService, nil
@@ -63,6 +64,13 @@ func (s *ArkSIASecretsVMService) refreshSIAAuth(client *common.ArkClient) error
 
 // AddSecret adds a new secret to the SIA VM secrets service.
 func (s *ArkSIASecretsVMService) AddSecret(addSecret *vmsecretsmodels.ArkSIAVMAddSecret) (*vmsecretsmodels.ArkSIAVMSecret, error) {
+	return s.AddSecretWithContext(context.Background(), addSecret)
+}
+
+// AddSecretWithContext is AddSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) AddSecretWithContext(ctx context.Context, addSecret *vmsecretsmodels.ArkSIAVMAddSecret) (*vmsecretsmodels.ArkSIAVMSecret, error) {
+	ctx, span := s.StartSpan(ctx, "AddSecret")
+	defer span.End()
 	s.Logger.Info("Adding new vm secret")
 	addSecretJSON := map[string]interface{}{
 		"secret_name": addSecret.SecretName,
@@ -97,7 +105,7 @@ func (s *ArkSIASecretsVMService) AddSecret(addSecret *vmsecretsmodels.ArkSIAVMAd
 	} else {
 		addSecretJSON["secret_details"] = map[string]interface{}{}
 	}
-	response, err := s.client.Post(context.Background(), secretsURL, addSecretJSON)
+	response, err := s.client.Post(ctx, secretsURL, addSecretJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +116,7 @@ func (s *ArkSIASecretsVMService) AddSecret(addSecret *vmsecretsmodels.ArkSIAVMAd
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add secret - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add secret")
 	}
 	secretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -124,6 +132,13 @@ func (s *ArkSIASecretsVMService) AddSecret(addSecret *vmsecretsmodels.ArkSIAVMAd
 
 // ChangeSecret changes an existing secret in the SIA VM secrets service.
 func (s *ArkSIASecretsVMService) ChangeSecret(changeSecret *vmsecretsmodels.ArkSIAVMChangeSecret) (*vmsecretsmodels.ArkSIAVMSecret, error) {
+	return s.ChangeSecretWithContext(context.Background(), changeSecret)
+}
+
+// ChangeSecretWithContext is ChangeSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) ChangeSecretWithContext(ctx context.Context, changeSecret *vmsecretsmodels.ArkSIAVMChangeSecret) (*vmsecretsmodels.ArkSIAVMSecret, error) {
+	ctx, span := s.StartSpan(ctx, "ChangeSecret")
+	defer span.End()
 	s.Logger.Info("Changing existing vm secret with id [%s]", changeSecret.SecretID)
 	changeSecretJSON := map[string]interface{}{
 		"is_active": !changeSecret.IsDisabled,
@@ -150,7 +165,7 @@ func (s *ArkSIASecretsVMService) ChangeSecret(changeSecret *vmsecretsmodels.ArkS
 	if changeSecret.SecretDetails != nil {
 		changeSecretJSON["secret_details"] = changeSecret.SecretDetails
 	}
-	response, err := s.client.Post(context.Background(), fmt.Sprintf(secretURL, changeSecret.SecretID), changeSecretJSON)
+	response, err := s.client.Post(ctx, fmt.Sprintf(secretURL, changeSecret.SecretID), changeSecretJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +176,7 @@ func (s *ArkSIASecretsVMService) ChangeSecret(changeSecret *vmsecretsmodels.ArkS
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to change secret - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to change secret")
 	}
 	secretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -177,18 +192,25 @@ func (s *ArkSIASecretsVMService) ChangeSecret(changeSecret *vmsecretsmodels.ArkS
 
 // DeleteSecret deletes a secret from the SIA VM secrets service.
 func (s *ArkSIASecretsVMService) DeleteSecret(deleteSecret *vmsecretsmodels.ArkSIAVMDeleteSecret) error {
+	return s.DeleteSecretWithContext(context.Background(), deleteSecret)
+}
+
+// DeleteSecretWithContext is DeleteSecret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) DeleteSecretWithContext(ctx context.Context, deleteSecret *vmsecretsmodels.ArkSIAVMDeleteSecret) error {
+	ctx, span := s.StartSpan(ctx, "DeleteSecret")
+	defer span.End()
 	s.Logger.Info("Deleting secret [%s]", deleteSecret.SecretID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(secretURL, deleteSecret.SecretID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(secretURL, deleteSecret.SecretID), nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete secret - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete secret")
 	}
 	return nil
 }
 
-func (s *ArkSIASecretsVMService) listSecretsWithFilter(secretType string, secretDetails map[string]interface{}) ([]*vmsecretsmodels.ArkSIAVMSecret, error) {
+func (s *ArkSIASecretsVMService) listSecretsWithFilter(ctx context.Context, secretType string, secretDetails map[string]interface{}) ([]*vmsecretsmodels.ArkSIAVMSecret, error) {
 	filterJSON := map[string]string{}
 	if secretType != "" {
 		filterJSON["secret_type"] = secretType
@@ -200,7 +222,7 @@ func (s *ArkSIASecretsVMService) listSecretsWithFilter(secretType string, secret
 			}
 		}
 	}
-	response, err := s.client.Get(context.Background(), secretsURL, nil)
+	response, err := s.client.Get(ctx, secretsURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +233,7 @@ func (s *ArkSIASecretsVMService) listSecretsWithFilter(secretType string, secret
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list secrets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list secrets")
 	}
 	secretsResponseJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -227,18 +249,32 @@ func (s *ArkSIASecretsVMService) listSecretsWithFilter(secretType string, secret
 
 // ListSecrets lists all secrets in the SIA VM secrets service.
 func (s *ArkSIASecretsVMService) ListSecrets() ([]*vmsecretsmodels.ArkSIAVMSecret, error) {
+	return s.ListSecretsWithContext(context.Background())
+}
+
+// ListSecretsWithContext is ListSecrets with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) ListSecretsWithContext(ctx context.Context) ([]*vmsecretsmodels.ArkSIAVMSecret, error) {
+	ctx, span := s.StartSpan(ctx, "ListSecrets")
+	defer span.End()
 	s.Logger.Info("Listing all secrets")
-	return s.listSecretsWithFilter("", nil)
+	return s.listSecretsWithFilter(ctx, "", nil)
 }
 
 // ListSecretsBy lists secrets in the SIA VM secrets service by filter.
 func (s *ArkSIASecretsVMService) ListSecretsBy(filter *vmsecretsmodels.ArkSIAVMSecretsFilter) ([]*vmsecretsmodels.ArkSIAVMSecret, error) {
+	return s.ListSecretsByWithContext(context.Background(), filter)
+}
+
+// ListSecretsByWithContext is ListSecretsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) ListSecretsByWithContext(ctx context.Context, filter *vmsecretsmodels.ArkSIAVMSecretsFilter) ([]*vmsecretsmodels.ArkSIAVMSecret, error) {
+	ctx, span := s.StartSpan(ctx, "ListSecretsBy")
+	defer span.End()
 	s.Logger.Info("Listing secrets by filters [%v]", filter)
 	secretType := ""
 	if filter.SecretTypes != nil && len(filter.SecretTypes) > 0 {
 		secretType = filter.SecretTypes[0]
 	}
-	secrets, err := s.listSecretsWithFilter(secretType, filter.SecretDetails)
+	secrets, err := s.listSecretsWithFilter(ctx, secretType, filter.SecretDetails)
 	if err != nil {
 		return nil, err
 	}
@@ -272,8 +308,15 @@ func (s *ArkSIASecretsVMService) ListSecretsBy(filter *vmsecretsmodels.ArkSIAVMS
 
 // Secret retrieves a specific secret from the SIA VM secrets service.
 func (s *ArkSIASecretsVMService) Secret(getSecret *vmsecretsmodels.ArkSIAVMGetSecret) (*vmsecretsmodels.ArkSIAVMSecret, error) {
+	return s.SecretWithContext(context.Background(), getSecret)
+}
+
+// SecretWithContext is Secret with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) SecretWithContext(ctx context.Context, getSecret *vmsecretsmodels.ArkSIAVMGetSecret) (*vmsecretsmodels.ArkSIAVMSecret, error) {
+	ctx, span := s.StartSpan(ctx, "Secret")
+	defer span.End()
 	s.Logger.Info("Getting secret [%s]", getSecret.SecretID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(secretURL, getSecret.SecretID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(secretURL, getSecret.SecretID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -284,7 +327,7 @@ func (s *ArkSIASecretsVMService) Secret(getSecret *vmsecretsmodels.ArkSIAVMGetSe
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get secret - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get secret")
 	}
 	secretJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -300,7 +343,14 @@ func (s *ArkSIASecretsVMService) Secret(getSecret *vmsecretsmodels.ArkSIAVMGetSe
 
 // SecretsStats retrieves statistics about secrets in the SIA VM secrets service.
 func (s *ArkSIASecretsVMService) SecretsStats() (*vmsecretsmodels.ArkSIAVMSecretsStats, error) {
-	secrets, err := s.ListSecrets()
+	return s.SecretsStatsWithContext(context.Background())
+}
+
+// SecretsStatsWithContext is SecretsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASecretsVMService) SecretsStatsWithContext(ctx context.Context) (*vmsecretsmodels.ArkSIAVMSecretsStats, error) {
+	ctx, span := s.StartSpan(ctx, "SecretsStats")
+	defer span.End()
+	secrets, err := s.ListSecretsWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,6 @@ package sshca
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -48,6 +47,7 @@ func NewArkSIASSHCAService(authenticators ...auth.ArkAuth) (*ArkSIASSHCAService,
 		return nil, err
 	}
 	sshCaService.client = client
+	baseService.AddClient(client.ArkClient)
 	sshCaService.ispAuth = ispAuth
 	sshCaService.ArkBaseService = baseService
 	return sshCaService, nil
@@ -63,47 +63,75 @@ func (s *ArkSIASSHCAService) refreshSIAAuth(client *common.ArkClient) error {
 
 // GenerateNewCA generates a new CA key version.
 func (s *ArkSIASSHCAService) GenerateNewCA() error {
+	return s.GenerateNewCAWithContext(context.Background())
+}
+
+// GenerateNewCAWithContext is GenerateNewCA with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSHCAService) GenerateNewCAWithContext(ctx context.Context) error {
+	ctx, span := s.StartSpan(ctx, "GenerateNewCA")
+	defer span.End()
 	s.Logger.Info("Generate new CA key version")
-	response, err := s.client.Post(context.Background(), generateNewCAKeyURL, nil)
+	response, err := s.client.Post(ctx, generateNewCAKeyURL, nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("Failed to generate new CA key  - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "Failed to generate new CA key ")
 	}
 	return nil
 }
 
 // DeactivatePreviousCa Deactivate previous CA key version.
 func (s *ArkSIASSHCAService) DeactivatePreviousCa() error {
+	return s.DeactivatePreviousCaWithContext(context.Background())
+}
+
+// DeactivatePreviousCaWithContext is DeactivatePreviousCa with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSHCAService) DeactivatePreviousCaWithContext(ctx context.Context) error {
+	ctx, span := s.StartSpan(ctx, "DeactivatePreviousCa")
+	defer span.End()
 	s.Logger.Info("Deactivate previous CA key version")
-	response, err := s.client.Post(context.Background(), deactivatePreviousCAKeyURL, nil)
+	response, err := s.client.Post(ctx, deactivatePreviousCAKeyURL, nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("Failed to deactivate previous CA key  - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "Failed to deactivate previous CA key ")
 	}
 	return nil
 }
 
 // ReactivatePreviousCa Deactivate previous CA key version.
 func (s *ArkSIASSHCAService) ReactivatePreviousCa() error {
+	return s.ReactivatePreviousCaWithContext(context.Background())
+}
+
+// ReactivatePreviousCaWithContext is ReactivatePreviousCa with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSHCAService) ReactivatePreviousCaWithContext(ctx context.Context) error {
+	ctx, span := s.StartSpan(ctx, "ReactivatePreviousCa")
+	defer span.End()
 	s.Logger.Info("Reactivate previous CA key version")
-	response, err := s.client.Post(context.Background(), reactivatePreviousCAKeyURL, nil)
+	response, err := s.client.Post(ctx, reactivatePreviousCAKeyURL, nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("Failed to reactivate previous CA key  - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "Failed to reactivate previous CA key ")
 	}
 	return nil
 }
 
 // PublicKey retrieves the public key for the SSH CA.
 func (s *ArkSIASSHCAService) PublicKey(getPublicKey *sshcamodels.ArkSIAGetSSHPublicKey) (string, error) {
+	return s.PublicKeyWithContext(context.Background(), getPublicKey)
+}
+
+// PublicKeyWithContext is PublicKey with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSHCAService) PublicKeyWithContext(ctx context.Context, getPublicKey *sshcamodels.ArkSIAGetSSHPublicKey) (string, error) {
+	ctx, span := s.StartSpan(ctx, "PublicKey")
+	defer span.End()
 	s.Logger.Info("Getting public key")
-	response, err := s.client.Get(context.Background(), publicKeyURL, nil)
+	response, err := s.client.Get(ctx, publicKeyURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -114,7 +142,7 @@ func (s *ArkSIASSHCAService) PublicKey(getPublicKey *sshcamodels.ArkSIAGetSSHPub
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Failed to get public key  - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "Failed to get public key ")
 	}
 	publicKey, err := io.ReadAll(response.Body)
 	if err != nil {
@@ -141,8 +169,15 @@ func (s *ArkSIASSHCAService) PublicKey(getPublicKey *sshcamodels.ArkSIAGetSSHPub
 
 // PublicKeyScript retrieves the public key script for the SSH CA.
 func (s *ArkSIASSHCAService) PublicKeyScript(getPublicKey *sshcamodels.ArkSIAGetSSHPublicKey) (string, error) {
+	return s.PublicKeyScriptWithContext(context.Background(), getPublicKey)
+}
+
+// PublicKeyScriptWithContext is PublicKeyScript with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSHCAService) PublicKeyScriptWithContext(ctx context.Context, getPublicKey *sshcamodels.ArkSIAGetSSHPublicKey) (string, error) {
+	ctx, span := s.StartSpan(ctx, "PublicKeyScript")
+	defer span.End()
 	s.Logger.Info("Getting public key script")
-	response, err := s.client.Get(context.Background(), publicKeyScriptURL, nil)
+	response, err := s.client.Get(ctx, publicKeyScriptURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -153,7 +188,7 @@ func (s *ArkSIASSHCAService) PublicKeyScript(getPublicKey *sshcamodels.ArkSIAGet
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Failed to get public key script  - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "Failed to get public key script ")
 	}
 	publicKeyScript, err := io.ReadAll(response.Body)
 	if err != nil {
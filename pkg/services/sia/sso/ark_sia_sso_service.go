@@ -19,12 +19,12 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/common/isp"
 	"github.com/cyberark/ark-sdk-golang/pkg/common/keyring"
+	"github.com/cyberark/ark-sdk-golang/pkg/models"
 	authmodels "github.com/cyberark/ark-sdk-golang/pkg/models/auth"
 	commonmodels "github.com/cyberark/ark-sdk-golang/pkg/models/common"
 	"github.com/cyberark/ark-sdk-golang/pkg/profiles"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	ssomodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/sso/models"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -68,6 +68,7 @@ func NewArkSIASSOService(authenticators ...auth.ArkAuth) (*ArkSIASSOService, err
 		return nil, err
 	}
 	ssoService.client = client
+	baseService.AddClient(client.ArkClient)
 	ssoService.ispAuth = ispAuth
 	ssoService.ArkBaseService = baseService
 	return ssoService, nil
@@ -81,19 +82,27 @@ func (s *ArkSIASSOService) refreshSIAAuth(client *common.ArkClient) error {
 	return nil
 }
 
+// cacheProfile returns the profile short-lived tokens are cached under, which is the profile
+// the authenticator was used with, or the default profile when it was not used with any.
+func (s *ArkSIASSOService) cacheProfile() (*models.ArkProfile, error) {
+	if s.ispAuth.ActiveProfile != nil {
+		return s.ispAuth.ActiveProfile, nil
+	}
+	return (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+}
+
 func (s *ArkSIASSOService) loadFromCache(tokenType string) (*ssomodels.ArkSIASSOAcquireTokenResponse, error) {
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return nil, err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	defaultProfile, err := (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+	profile, err := s.cacheProfile()
 	if err != nil {
 		return nil, err
 	}
 	token, err := s.cacheKeyring.LoadToken(
-		defaultProfile,
-		fmt.Sprintf("%s_%s_sia_sso_short_lived_%s", claims["tenant_id"], claims["unique_name"], tokenType),
+		profile,
+		fmt.Sprintf("%s_%s_sia_sso_short_lived_%s", claims.TenantID, claims.Username, tokenType),
 		false,
 	)
 	if err != nil {
@@ -111,12 +120,11 @@ func (s *ArkSIASSOService) loadFromCache(tokenType string) (*ssomodels.ArkSIASSO
 }
 
 func (s *ArkSIASSOService) saveToCache(result *ssomodels.ArkSIASSOAcquireTokenResponse, tokenType string) error {
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	defaultProfile, err := (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+	profile, err := s.cacheProfile()
 	if err != nil {
 		return err
 	}
@@ -140,21 +148,20 @@ func (s *ArkSIASSOService) saveToCache(result *ssomodels.ArkSIASSOAcquireTokenRe
 		ExpiresIn: commonmodels.ArkRFC3339Time(expiresIn),
 	}
 	return s.cacheKeyring.SaveToken(
-		defaultProfile,
+		profile,
 		token,
-		fmt.Sprintf("%s_%s_sia_sso_short_lived_%s", claims["tenant_id"], claims["unique_name"], tokenType),
+		fmt.Sprintf("%s_%s_sia_sso_short_lived_%s", claims.TenantID, claims.Username, tokenType),
 		false,
 	)
 }
 
 func (s *ArkSIASSOService) outputClientCertificate(folder string, outputFormat string, result *ssomodels.ArkSIASSOAcquireTokenResponse) error {
 	folderPath := common.ExpandFolder(folder)
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	baseName := strings.Split(claims["unique_name"].(string), "@")[0]
+	baseName := strings.Split(claims.Username, "@")[0]
 	clientCertificate := result.Token["client_certificate"].(string)
 	privateKey := result.Token["private_key"].(string)
 
@@ -216,12 +223,11 @@ func (s *ArkSIASSOService) saveOracleSSOWallet(folder string, unzipWallet bool,
 		}
 	}
 	if !unzipWallet {
-		parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+		claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 		if err != nil {
 			return err
 		}
-		claims := parsedToken.Claims.(jwt.MapClaims)
-		baseName := strings.Split(claims["unique_name"].(string), "@")[0]
+		baseName := strings.Split(claims.Username, "@")[0]
 		err = os.WriteFile(filepath.Join(folderPath, baseName+"_wallet.zip"), wallet, 0644)
 		if err != nil {
 			return err
@@ -266,12 +272,11 @@ func (s *ArkSIASSOService) saveOracleSSOWallet(folder string, unzipWallet bool,
 
 func (s *ArkSIASSOService) saveOraclePEMWallet(folder string, result *ssomodels.ArkSIASSOAcquireTokenResponse) error {
 	folderPath := common.ExpandFolder(folder)
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	baseName := strings.Split(claims["unique_name"].(string), "@")[0]
+	baseName := strings.Split(claims.Username, "@")[0]
 	pemWallet, err := base64.StdEncoding.DecodeString(result.Token["pem_wallet"].(string))
 	if err != nil {
 		return err
@@ -306,6 +311,13 @@ func (s *ArkSIASSOService) saveRDPFile(getShortLivedRDPFile *ssomodels.ArkSIASSO
 
 // ShortLivedPassword generates a short-lived password token for the user to connect.
 func (s *ArkSIASSOService) ShortLivedPassword(getShortLivedPassword *ssomodels.ArkSIASSOGetShortLivedPassword) (string, error) {
+	return s.ShortLivedPasswordWithContext(context.Background(), getShortLivedPassword)
+}
+
+// ShortLivedPasswordWithContext is ShortLivedPassword with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSOService) ShortLivedPasswordWithContext(ctx context.Context, getShortLivedPassword *ssomodels.ArkSIASSOGetShortLivedPassword) (string, error) {
+	ctx, span := s.StartSpan(ctx, "ShortLivedPassword")
+	defer span.End()
 	s.Logger.Info("Generating short lived password token")
 	if getShortLivedPassword.AllowCaching {
 		result, err := s.loadFromCache("password")
@@ -313,7 +325,7 @@ func (s *ArkSIASSOService) ShortLivedPassword(getShortLivedPassword *ssomodels.A
 			return result.Token["key"].(string), nil
 		}
 	}
-	response, err := s.client.Post(context.Background(), acquireSsoTokenURL, map[string]interface{}{
+	response, err := s.client.Post(ctx, acquireSsoTokenURL, map[string]interface{}{
 		"token_type": "password",
 		"service":    getShortLivedPassword.Service,
 	})
@@ -327,7 +339,7 @@ func (s *ArkSIASSOService) ShortLivedPassword(getShortLivedPassword *ssomodels.A
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to generate short lived password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "failed to generate short lived password")
 	}
 	var result ssomodels.ArkSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -340,11 +352,18 @@ func (s *ArkSIASSOService) ShortLivedPassword(getShortLivedPassword *ssomodels.A
 		}
 		return key, nil
 	}
-	return "", fmt.Errorf("failed to generate short lived password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return "", common.NewArkAPIError(response, "failed to generate short lived password")
 }
 
 // ShortLivedClientCertificate generates a short-lived client certificate for the user to connect.
 func (s *ArkSIASSOService) ShortLivedClientCertificate(getShortLivedClientCertificate *ssomodels.ArkSIASSOGetShortLivedClientCertificate) error {
+	return s.ShortLivedClientCertificateWithContext(context.Background(), getShortLivedClientCertificate)
+}
+
+// ShortLivedClientCertificateWithContext is ShortLivedClientCertificate with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSOService) ShortLivedClientCertificateWithContext(ctx context.Context, getShortLivedClientCertificate *ssomodels.ArkSIASSOGetShortLivedClientCertificate) error {
+	ctx, span := s.StartSpan(ctx, "ShortLivedClientCertificate")
+	defer span.End()
 	s.Logger.Info("Generating short lived client certificate")
 	if getShortLivedClientCertificate.AllowCaching {
 		result, err := s.loadFromCache("client_certificate")
@@ -352,7 +371,7 @@ func (s *ArkSIASSOService) ShortLivedClientCertificate(getShortLivedClientCertif
 			return s.outputClientCertificate(getShortLivedClientCertificate.Folder, getShortLivedClientCertificate.OutputFormat, result)
 		}
 	}
-	response, err := s.client.Post(context.Background(), acquireSsoTokenURL, map[string]interface{}{
+	response, err := s.client.Post(ctx, acquireSsoTokenURL, map[string]interface{}{
 		"token_type": "client_certificate",
 		"service":    getShortLivedClientCertificate.Service,
 	})
@@ -366,7 +385,7 @@ func (s *ArkSIASSOService) ShortLivedClientCertificate(getShortLivedClientCertif
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to generate short lived client certificate - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to generate short lived client certificate")
 	}
 	var result ssomodels.ArkSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -381,11 +400,18 @@ func (s *ArkSIASSOService) ShortLivedClientCertificate(getShortLivedClientCertif
 			return s.outputClientCertificate(getShortLivedClientCertificate.Folder, getShortLivedClientCertificate.OutputFormat, &result)
 		}
 	}
-	return fmt.Errorf("failed to generate short lived client certificate - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return common.NewArkAPIError(response, "failed to generate short lived client certificate")
 }
 
 // ShortLivedOracleWallet generates a short-lived oracle wallet for the user to connect to oracle databases.
 func (s *ArkSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *ssomodels.ArkSIASSOGetShortLivedOracleWallet) error {
+	return s.ShortLivedOracleWalletWithContext(context.Background(), getShortLivedOracleWallet)
+}
+
+// ShortLivedOracleWalletWithContext is ShortLivedOracleWallet with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSOService) ShortLivedOracleWalletWithContext(ctx context.Context, getShortLivedOracleWallet *ssomodels.ArkSIASSOGetShortLivedOracleWallet) error {
+	ctx, span := s.StartSpan(ctx, "ShortLivedOracleWallet")
+	defer span.End()
 	s.Logger.Info("Generating short lived oracle wallet")
 	if getShortLivedOracleWallet.AllowCaching {
 		result, err := s.loadFromCache("oracle_wallet")
@@ -398,7 +424,7 @@ func (s *ArkSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *sso
 			}
 		}
 	}
-	response, err := s.client.Post(context.Background(), acquireSsoTokenURL, map[string]interface{}{
+	response, err := s.client.Post(ctx, acquireSsoTokenURL, map[string]interface{}{
 		"token_type": "oracle_wallet",
 		"service":    "DPA-DB",
 		"token_parameters": map[string]interface{}{
@@ -415,7 +441,7 @@ func (s *ArkSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *sso
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to generate short lived oracle wallet - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to generate short lived oracle wallet")
 	}
 	var result ssomodels.ArkSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -434,11 +460,18 @@ func (s *ArkSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *sso
 		}
 		return s.saveOraclePEMWallet(getShortLivedOracleWallet.Folder, &result)
 	}
-	return fmt.Errorf("failed to generate short lived oracle wallet - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return common.NewArkAPIError(response, "failed to generate short lived oracle wallet")
 }
 
 // ShortLivedRdpFile generates a short-lived RDP file for the user to connect to remote desktops.
 func (s *ArkSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.ArkSIASSOGetShortLivedRDPFile) error {
+	return s.ShortLivedRdpFileWithContext(context.Background(), getShortLivedRDPFile)
+}
+
+// ShortLivedRdpFileWithContext is ShortLivedRdpFile with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSOService) ShortLivedRdpFileWithContext(ctx context.Context, getShortLivedRDPFile *ssomodels.ArkSIASSOGetShortLivedRDPFile) error {
+	ctx, span := s.StartSpan(ctx, "ShortLivedRdpFile")
+	defer span.End()
 	s.Logger.Info("Generating short lived rdp file")
 	if getShortLivedRDPFile.AllowCaching {
 		result, err := s.loadFromCache("rdp_file")
@@ -452,7 +485,7 @@ func (s *ArkSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.Ark
 		"targetUser":         getShortLivedRDPFile.TargetUser,
 		"elevatedPrivileges": getShortLivedRDPFile.ElevatedPrivileges,
 	}
-	response, err := s.client.Post(context.Background(), acquireSsoTokenURL, map[string]interface{}{
+	response, err := s.client.Post(ctx, acquireSsoTokenURL, map[string]interface{}{
 		"token_type":            "rdp_file",
 		"service":               "DPA-RDP",
 		"token_parameters":      tokenParameters,
@@ -468,7 +501,7 @@ func (s *ArkSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.Ark
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to generate short lived rdp file - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to generate short lived rdp file")
 	}
 	var result ssomodels.ArkSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
@@ -481,13 +514,20 @@ func (s *ArkSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.Ark
 		}
 		return s.saveRDPFile(getShortLivedRDPFile, &result)
 	}
-	return fmt.Errorf("failed to generate short rdp file - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return common.NewArkAPIError(response, "failed to generate short rdp file")
 }
 
 // ShortLivedSSHKey generates a short-lived SSH key for the user to connect to remote servers.
 func (s *ArkSIASSOService) ShortLivedSSHKey(getSSHKey *ssomodels.ArkSIASSOGetSSHKey) (string, error) {
+	return s.ShortLivedSSHKeyWithContext(context.Background(), getSSHKey)
+}
+
+// ShortLivedSSHKeyWithContext is ShortLivedSSHKey with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSOService) ShortLivedSSHKeyWithContext(ctx context.Context, getSSHKey *ssomodels.ArkSIASSOGetSSHKey) (string, error) {
+	ctx, span := s.StartSpan(ctx, "ShortLivedSSHKey")
+	defer span.End()
 	s.Logger.Info("Getting short lived ssh sso key")
-	response, err := s.client.Get(context.Background(), sshSsoKeyURL, nil)
+	response, err := s.client.Get(ctx, sshSsoKeyURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -498,7 +538,7 @@ func (s *ArkSIASSOService) ShortLivedSSHKey(getSSHKey *ssomodels.ArkSIASSOGetSSH
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get short lived ssh sso key - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return "", common.NewArkAPIError(response, "failed to get short lived ssh sso key")
 	}
 	folderPath := getSSHKey.Folder
 	if folderPath == "" {
@@ -514,12 +554,11 @@ func (s *ArkSIASSOService) ShortLivedSSHKey(getSSHKey *ssomodels.ArkSIASSOGetSSH
 			return "", err
 		}
 	}
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.client.GetToken(), jwt.MapClaims{})
+	claims, err := authmodels.ParseArkTokenClaims(s.client.GetToken())
 	if err != nil {
 		return "", err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	baseName := fmt.Sprintf("sia_ssh_key_%s.pem", strings.Split(claims["unique_name"].(string), "@")[0])
+	baseName := fmt.Sprintf("sia_ssh_key_%s.pem", strings.Split(claims.Username, "@")[0])
 	fullPath := filepath.Join(folderPath, baseName)
 	resp, err := io.ReadAll(response.Body)
 	if err != nil {
@@ -534,10 +573,17 @@ func (s *ArkSIASSOService) ShortLivedSSHKey(getSSHKey *ssomodels.ArkSIASSOGetSSH
 
 // ShortLivedTokenInfo retrieves information about a short-lived token.
 func (s *ArkSIASSOService) ShortLivedTokenInfo(getTokenInfo *ssomodels.ArkSIASSOGetTokenInfo) (*ssomodels.ArkSIASSOTokenInfo, error) {
+	return s.ShortLivedTokenInfoWithContext(context.Background(), getTokenInfo)
+}
+
+// ShortLivedTokenInfoWithContext is ShortLivedTokenInfo with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIASSOService) ShortLivedTokenInfoWithContext(ctx context.Context, getTokenInfo *ssomodels.ArkSIASSOGetTokenInfo) (*ssomodels.ArkSIASSOTokenInfo, error) {
+	ctx, span := s.StartSpan(ctx, "ShortLivedTokenInfo")
+	defer span.End()
 	s.Logger.Info("Getting short lived token info")
 	getTokenInfoParams := map[string]string{}
 	_ = mapstructure.Decode(getTokenInfo, &getTokenInfoParams)
-	response, err := s.client.Get(context.Background(), tokenSsoInfoURL, getTokenInfoParams)
+	response, err := s.client.Get(ctx, tokenSsoInfoURL, getTokenInfoParams)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +594,7 @@ func (s *ArkSIASSOService) ShortLivedTokenInfo(getTokenInfo *ssomodels.ArkSIASSO
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get short lived token info - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get short lived token info")
 	}
 	var tokenInfo ssomodels.ArkSIASSOTokenInfo
 	err = json.NewDecoder(response.Body).Decode(&tokenInfo)
@@ -4,6 +4,7 @@ import (
 	"github.com/cyberark/ark-sdk-golang/pkg/models/actions"
 	"github.com/cyberark/ark-sdk-golang/pkg/services"
 	siassoactions "github.com/cyberark/ark-sdk-golang/pkg/services/sia/sso/actions"
+	ssomodels "github.com/cyberark/ark-sdk-golang/pkg/services/sia/sso/models"
 )
 
 // ServiceConfig is the configuration for the SSO service.
@@ -16,6 +17,9 @@ var ServiceConfig = services.ArkServiceConfig{
 			siassoactions.CLIAction,
 		},
 	},
+	RedactedModels: []interface{}{
+		&ssomodels.ArkSIASSOAcquireTokenResponse{},
+	},
 }
 
 // ServiceGenerator is the function that creates a new instance of the SIA SSO service.
@@ -2,6 +2,6 @@ package models
 
 // ArkSIASSOAcquireTokenResponse is a struct that represents the response from the Ark SIA SSO service for acquiring a token.
 type ArkSIASSOAcquireTokenResponse struct {
-	Token    map[string]interface{} `json:"token" validate:"required" mapstructure:"token"`
+	Token    map[string]interface{} `json:"token" validate:"required" mapstructure:"token" redact:"true"`
 	Metadata map[string]interface{} `json:"metadata" validate:"required" mapstructure:"metadata"`
 }
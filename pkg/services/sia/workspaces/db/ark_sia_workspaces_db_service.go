@@ -47,6 +47,7 @@ func NewArkSIAWorkspacesDBService(authenticators ...auth.ArkAuth) (*ArkSIAWorksp
 		return nil, err
 	}
 	dbService.client = client
+	baseService.AddClient(client.ArkClient)
 	dbService.ispAuth = ispAuth
 	dbService.ArkBaseService = baseService
 	return dbService, nil
@@ -76,7 +77,7 @@ func (s *ArkSIAWorkspacesDBService) refreshSIAAuth(client *common.ArkClient) err
 	return nil
 }
 
-func (s *ArkSIAWorkspacesDBService) listDatabasesWithFilters(providerFamily string, tags []workspacesdbmodels.ArkSIADBTag) (*workspacesdbmodels.ArkSIADBDatabaseInfoList, error) {
+func (s *ArkSIAWorkspacesDBService) listDatabasesWithFilters(ctx context.Context, providerFamily string, tags []workspacesdbmodels.ArkSIADBTag) (*workspacesdbmodels.ArkSIADBDatabaseInfoList, error) {
 	params := make(map[string]string)
 	if providerFamily != "" {
 		params["provider-family"] = providerFamily
@@ -86,7 +87,7 @@ func (s *ArkSIAWorkspacesDBService) listDatabasesWithFilters(providerFamily stri
 			params[fmt.Sprintf("key.%s", tag.Key)] = tag.Value
 		}
 	}
-	response, err := s.client.Get(context.Background(), resourcesURL, params)
+	response, err := s.client.Get(ctx, resourcesURL, params)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +98,7 @@ func (s *ArkSIAWorkspacesDBService) listDatabasesWithFilters(providerFamily stri
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list databases with filters - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list databases with filters")
 	}
 
 	databasesJSON, err := common.DeserializeJSONSnake(response.Body)
@@ -111,6 +112,13 @@ func (s *ArkSIAWorkspacesDBService) listDatabasesWithFilters(providerFamily stri
 
 // AddDatabase adds a new database to the SIA workspace.
 func (s *ArkSIAWorkspacesDBService) AddDatabase(addDatabase *workspacesdbmodels.ArkSIADBAddDatabase) (*workspacesdbmodels.ArkSIADBDatabase, error) {
+	return s.AddDatabaseWithContext(context.Background(), addDatabase)
+}
+
+// AddDatabaseWithContext is AddDatabase with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) AddDatabaseWithContext(ctx context.Context, addDatabase *workspacesdbmodels.ArkSIADBAddDatabase) (*workspacesdbmodels.ArkSIADBDatabase, error) {
+	ctx, span := s.StartSpan(ctx, "AddDatabase")
+	defer span.End()
 	s.Logger.Info("Adding database [%s]", addDatabase.Name)
 	// Validate ProviderEngine
 	if !slices.Contains(workspacesdbmodels.DatabaseEngineTypes, addDatabase.ProviderEngine) {
@@ -146,7 +154,7 @@ func (s *ArkSIAWorkspacesDBService) AddDatabase(addDatabase *workspacesdbmodels.
 			idx++
 		}
 	}
-	response, err := s.client.Post(context.Background(), resourcesURL, addDatabaseJSON)
+	response, err := s.client.Post(ctx, resourcesURL, addDatabaseJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +165,7 @@ func (s *ArkSIAWorkspacesDBService) AddDatabase(addDatabase *workspacesdbmodels.
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to database - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to database")
 	}
 	databaseJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -169,13 +177,20 @@ func (s *ArkSIAWorkspacesDBService) AddDatabase(addDatabase *workspacesdbmodels.
 		return nil, fmt.Errorf("missing target_id in response")
 	}
 	getDatabase := &workspacesdbmodels.ArkSIADBGetDatabase{ID: int(databaseID)}
-	return s.Database(getDatabase)
+	return s.DatabaseWithContext(ctx, getDatabase)
 }
 
 // DeleteDatabase deletes a database.
 func (s *ArkSIAWorkspacesDBService) DeleteDatabase(deleteDatabase *workspacesdbmodels.ArkSIADBDeleteDatabase) error {
+	return s.DeleteDatabaseWithContext(context.Background(), deleteDatabase)
+}
+
+// DeleteDatabaseWithContext is DeleteDatabase with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) DeleteDatabaseWithContext(ctx context.Context, deleteDatabase *workspacesdbmodels.ArkSIADBDeleteDatabase) error {
+	ctx, span := s.StartSpan(ctx, "DeleteDatabase")
+	defer span.End()
 	if deleteDatabase.Name != "" && deleteDatabase.ID == 0 {
-		databases, err := s.ListDatabasesBy(&workspacesdbmodels.ArkSIADBDatabasesFilter{Name: deleteDatabase.Name})
+		databases, err := s.ListDatabasesByWithContext(ctx, &workspacesdbmodels.ArkSIADBDatabasesFilter{Name: deleteDatabase.Name})
 		if err != nil {
 			return fmt.Errorf("failed to fetch database ID by name: %w", err)
 		}
@@ -185,7 +200,7 @@ func (s *ArkSIAWorkspacesDBService) DeleteDatabase(deleteDatabase *workspacesdbm
 		deleteDatabase.ID = databases.Items[0].ID
 	}
 	s.Logger.Info("Deleting database [%d]", deleteDatabase.ID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(resourceURL, deleteDatabase.ID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(resourceURL, deleteDatabase.ID), nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete database: %w", err)
 	}
@@ -196,7 +211,7 @@ func (s *ArkSIAWorkspacesDBService) DeleteDatabase(deleteDatabase *workspacesdbm
 	}(response.Body)
 
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete database - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete database")
 	}
 
 	return nil
@@ -204,8 +219,15 @@ func (s *ArkSIAWorkspacesDBService) DeleteDatabase(deleteDatabase *workspacesdbm
 
 // UpdateDatabase updates a database.
 func (s *ArkSIAWorkspacesDBService) UpdateDatabase(updateDatabase *workspacesdbmodels.ArkSIADBUpdateDatabase) (*workspacesdbmodels.ArkSIADBDatabase, error) {
+	return s.UpdateDatabaseWithContext(context.Background(), updateDatabase)
+}
+
+// UpdateDatabaseWithContext is UpdateDatabase with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) UpdateDatabaseWithContext(ctx context.Context, updateDatabase *workspacesdbmodels.ArkSIADBUpdateDatabase) (*workspacesdbmodels.ArkSIADBDatabase, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateDatabase")
+	defer span.End()
 	if updateDatabase.Name != "" && updateDatabase.ID == 0 {
-		databases, err := s.ListDatabasesBy(&workspacesdbmodels.ArkSIADBDatabasesFilter{Name: updateDatabase.Name})
+		databases, err := s.ListDatabasesByWithContext(ctx, &workspacesdbmodels.ArkSIADBDatabasesFilter{Name: updateDatabase.Name})
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch database ID by name: %w", err)
 		}
@@ -218,7 +240,7 @@ func (s *ArkSIAWorkspacesDBService) UpdateDatabase(updateDatabase *workspacesdbm
 	if updateDatabase.ProviderEngine != "" && !slices.Contains(workspacesdbmodels.DatabaseEngineTypes, updateDatabase.ProviderEngine) {
 		return nil, fmt.Errorf("invalid provider engine: %s", updateDatabase.ProviderEngine)
 	}
-	existingDatabase, err := s.Database(&workspacesdbmodels.ArkSIADBGetDatabase{ID: updateDatabase.ID, Name: updateDatabase.Name})
+	existingDatabase, err := s.DatabaseWithContext(ctx, &workspacesdbmodels.ArkSIADBGetDatabase{ID: updateDatabase.ID, Name: updateDatabase.Name})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve existing database: %w", err)
 	}
@@ -283,7 +305,7 @@ func (s *ArkSIAWorkspacesDBService) UpdateDatabase(updateDatabase *workspacesdbm
 	}
 
 	s.Logger.Info("Updating database [%d]", updateDatabase.ID)
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(resourceURL, updateDatabase.ID), mergedDatabase)
+	response, err := s.client.Put(ctx, fmt.Sprintf(resourceURL, updateDatabase.ID), mergedDatabase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update database: %w", err)
 	}
@@ -293,17 +315,24 @@ func (s *ArkSIAWorkspacesDBService) UpdateDatabase(updateDatabase *workspacesdbm
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update database - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update database")
 	}
-	return s.Database(&workspacesdbmodels.ArkSIADBGetDatabase{ID: updateDatabase.ID})
+	return s.DatabaseWithContext(ctx, &workspacesdbmodels.ArkSIADBGetDatabase{ID: updateDatabase.ID})
 }
 
 // Database retrieves a database by id or name.
 func (s *ArkSIAWorkspacesDBService) Database(getDatabase *workspacesdbmodels.ArkSIADBGetDatabase) (*workspacesdbmodels.ArkSIADBDatabase, error) {
+	return s.DatabaseWithContext(context.Background(), getDatabase)
+}
+
+// DatabaseWithContext is Database with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) DatabaseWithContext(ctx context.Context, getDatabase *workspacesdbmodels.ArkSIADBGetDatabase) (*workspacesdbmodels.ArkSIADBDatabase, error) {
+	ctx, span := s.StartSpan(ctx, "Database")
+	defer span.End()
 	// If Name is provided but ID is not, fetch the ID by filtering databases
 	if getDatabase.Name != "" && getDatabase.ID == 0 {
 		filter := &workspacesdbmodels.ArkSIADBDatabasesFilter{Name: getDatabase.Name}
-		databases, err := s.ListDatabasesBy(filter)
+		databases, err := s.ListDatabasesByWithContext(ctx, filter)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list databases: %w", err)
 		}
@@ -313,7 +342,7 @@ func (s *ArkSIAWorkspacesDBService) Database(getDatabase *workspacesdbmodels.Ark
 		getDatabase.ID = databases.Items[0].ID
 	}
 	s.Logger.Info("Getting database [%d]", getDatabase.ID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(resourceURL, getDatabase.ID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(resourceURL, getDatabase.ID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -324,7 +353,7 @@ func (s *ArkSIAWorkspacesDBService) Database(getDatabase *workspacesdbmodels.Ark
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get database - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get database")
 	}
 
 	databaseJSON, err := common.DeserializeJSONSnake(response.Body)
@@ -340,17 +369,31 @@ func (s *ArkSIAWorkspacesDBService) Database(getDatabase *workspacesdbmodels.Ark
 
 // ListDatabases lists all databases.
 func (s *ArkSIAWorkspacesDBService) ListDatabases() (*workspacesdbmodels.ArkSIADBDatabaseInfoList, error) {
+	return s.ListDatabasesWithContext(context.Background())
+}
+
+// ListDatabasesWithContext is ListDatabases with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) ListDatabasesWithContext(ctx context.Context) (*workspacesdbmodels.ArkSIADBDatabaseInfoList, error) {
+	ctx, span := s.StartSpan(ctx, "ListDatabases")
+	defer span.End()
 	s.Logger.Info("Listing all databases")
-	return s.listDatabasesWithFilters("", nil)
+	return s.listDatabasesWithFilters(ctx, "", nil)
 }
 
 // ListDatabasesBy filters databases by the given filters.
 func (s *ArkSIAWorkspacesDBService) ListDatabasesBy(databasesFilter *workspacesdbmodels.ArkSIADBDatabasesFilter) (*workspacesdbmodels.ArkSIADBDatabaseInfoList, error) {
+	return s.ListDatabasesByWithContext(context.Background(), databasesFilter)
+}
+
+// ListDatabasesByWithContext is ListDatabasesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) ListDatabasesByWithContext(ctx context.Context, databasesFilter *workspacesdbmodels.ArkSIADBDatabasesFilter) (*workspacesdbmodels.ArkSIADBDatabaseInfoList, error) {
+	ctx, span := s.StartSpan(ctx, "ListDatabasesBy")
+	defer span.End()
 	if databasesFilter.ProviderEngine != "" && !slices.Contains(workspacesdbmodels.DatabaseEngineTypes, databasesFilter.ProviderEngine) {
 		return nil, fmt.Errorf("invalid provider engine: %s", databasesFilter.ProviderEngine)
 	}
 	s.Logger.Info("Listing databases by filters [%+v]", databasesFilter)
-	databases, err := s.listDatabasesWithFilters(databasesFilter.ProviderFamily, databasesFilter.Tags)
+	databases, err := s.listDatabasesWithFilters(ctx, databasesFilter.ProviderFamily, databasesFilter.Tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list databases with filters: %w", err)
 	}
@@ -401,8 +444,15 @@ func (s *ArkSIAWorkspacesDBService) ListDatabasesBy(databasesFilter *workspacesd
 
 // DatabasesStats calculates statistics about databases.
 func (s *ArkSIAWorkspacesDBService) DatabasesStats() (*workspacesdbmodels.ArkSIADBDatabasesStats, error) {
+	return s.DatabasesStatsWithContext(context.Background())
+}
+
+// DatabasesStatsWithContext is DatabasesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesDBService) DatabasesStatsWithContext(ctx context.Context) (*workspacesdbmodels.ArkSIADBDatabasesStats, error) {
+	ctx, span := s.StartSpan(ctx, "DatabasesStats")
+	defer span.End()
 	s.Logger.Info("Calculating databases stats")
-	databases, err := s.ListDatabases()
+	databases, err := s.ListDatabasesWithContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list databases: %w", err)
 	}
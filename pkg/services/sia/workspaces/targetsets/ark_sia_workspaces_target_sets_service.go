@@ -48,6 +48,7 @@ func NewArkSIAWorkspacesTargetSetsService(authenticators ...auth.ArkAuth) (*ArkS
 		return nil, err
 	}
 	targetSetsService.client = client
+	baseService.AddClient(client.ArkClient)
 	targetSetsService.ispAuth = ispAuth
 	targetSetsService.ArkBaseService = baseService
 	return targetSetsService, nil
@@ -63,13 +64,20 @@ func (s *ArkSIAWorkspacesTargetSetsService) refreshSIAAuth(client *common.ArkCli
 
 // AddTargetSet adds a new target set with related strong account.
 func (s *ArkSIAWorkspacesTargetSetsService) AddTargetSet(addTargetSet *targetsetsmodels.ArkSIAAddTargetSet) (*targetsetsmodels.ArkSIATargetSet, error) {
+	return s.AddTargetSetWithContext(context.Background(), addTargetSet)
+}
+
+// AddTargetSetWithContext is AddTargetSet with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) AddTargetSetWithContext(ctx context.Context, addTargetSet *targetsetsmodels.ArkSIAAddTargetSet) (*targetsetsmodels.ArkSIATargetSet, error) {
+	ctx, span := s.StartSpan(ctx, "AddTargetSet")
+	defer span.End()
 	s.Logger.Info("Adding target set [%s]", addTargetSet.Name)
 	var addTargetSetJSON map[string]interface{}
 	err := mapstructure.Decode(addTargetSet, &addTargetSetJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Post(context.Background(), targetSetsURL, addTargetSetJSON)
+	response, err := s.client.Post(ctx, targetSetsURL, addTargetSetJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +88,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) AddTargetSet(addTargetSet *targetset
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("failed to add target set - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add target set")
 	}
 	targetSetJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -100,13 +108,20 @@ func (s *ArkSIAWorkspacesTargetSetsService) AddTargetSet(addTargetSet *targetset
 
 // BulkAddTargetSets adds multiple target sets with related strong account.
 func (s *ArkSIAWorkspacesTargetSetsService) BulkAddTargetSets(bulkAddTargetSets *targetsetsmodels.ArkSIABulkAddTargetSets) (*targetsetsmodels.ArkSIABulkTargetSetResponse, error) {
+	return s.BulkAddTargetSetsWithContext(context.Background(), bulkAddTargetSets)
+}
+
+// BulkAddTargetSetsWithContext is BulkAddTargetSets with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) BulkAddTargetSetsWithContext(ctx context.Context, bulkAddTargetSets *targetsetsmodels.ArkSIABulkAddTargetSets) (*targetsetsmodels.ArkSIABulkTargetSetResponse, error) {
+	ctx, span := s.StartSpan(ctx, "BulkAddTargetSets")
+	defer span.End()
 	s.Logger.Info("Bulk adding target set [%v]", bulkAddTargetSets)
 	var bulkAddTargetSetsJSON map[string]interface{}
 	err := mapstructure.Decode(bulkAddTargetSets, &bulkAddTargetSetsJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Post(context.Background(), bulkTargetSetsURL, bulkAddTargetSetsJSON)
+	response, err := s.client.Post(ctx, bulkTargetSetsURL, bulkAddTargetSetsJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +132,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) BulkAddTargetSets(bulkAddTargetSets
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusMultiStatus {
-		return nil, fmt.Errorf("failed to bulk add target set - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to bulk add target set")
 	}
 	bulkTargetSetRespJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -133,26 +148,40 @@ func (s *ArkSIAWorkspacesTargetSetsService) BulkAddTargetSets(bulkAddTargetSets
 
 // DeleteTargetSet deletes a target set.
 func (s *ArkSIAWorkspacesTargetSetsService) DeleteTargetSet(deleteTargetSet *targetsetsmodels.ArkSIADeleteTargetSet) error {
+	return s.DeleteTargetSetWithContext(context.Background(), deleteTargetSet)
+}
+
+// DeleteTargetSetWithContext is DeleteTargetSet with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) DeleteTargetSetWithContext(ctx context.Context, deleteTargetSet *targetsetsmodels.ArkSIADeleteTargetSet) error {
+	ctx, span := s.StartSpan(ctx, "DeleteTargetSet")
+	defer span.End()
 	s.Logger.Info("Deleting target set [%s]", deleteTargetSet.ID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(targetSetURL, deleteTargetSet.ID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(targetSetURL, deleteTargetSet.ID), nil)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete target set - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete target set")
 	}
 	return nil
 }
 
 // BulkDeleteTargetSets deletes multiple target sets.
 func (s *ArkSIAWorkspacesTargetSetsService) BulkDeleteTargetSets(bulkDeleteTargetSets *targetsetsmodels.ArkSIABulkDeleteTargetSets) (*targetsetsmodels.ArkSIABulkTargetSetResponse, error) {
+	return s.BulkDeleteTargetSetsWithContext(context.Background(), bulkDeleteTargetSets)
+}
+
+// BulkDeleteTargetSetsWithContext is BulkDeleteTargetSets with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) BulkDeleteTargetSetsWithContext(ctx context.Context, bulkDeleteTargetSets *targetsetsmodels.ArkSIABulkDeleteTargetSets) (*targetsetsmodels.ArkSIABulkTargetSetResponse, error) {
+	ctx, span := s.StartSpan(ctx, "BulkDeleteTargetSets")
+	defer span.End()
 	s.Logger.Info("Bulk deleting target set [%v]", bulkDeleteTargetSets)
 	var bulkDeleteTargetSetsJSON map[string]interface{}
 	err := mapstructure.Decode(bulkDeleteTargetSets, &bulkDeleteTargetSetsJSON)
 	if err != nil {
 		return nil, err
 	}
-	response, err := s.client.Delete(context.Background(), bulkTargetSetsURL, bulkDeleteTargetSetsJSON)
+	response, err := s.client.Delete(ctx, bulkTargetSetsURL, bulkDeleteTargetSetsJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +192,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) BulkDeleteTargetSets(bulkDeleteTarge
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusMultiStatus {
-		return nil, fmt.Errorf("failed to bulk delete target set - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to bulk delete target set")
 	}
 	bulkTargetSetRespJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -179,6 +208,13 @@ func (s *ArkSIAWorkspacesTargetSetsService) BulkDeleteTargetSets(bulkDeleteTarge
 
 // UpdateTargetSet updates a target set.
 func (s *ArkSIAWorkspacesTargetSetsService) UpdateTargetSet(updateTargetSet *targetsetsmodels.ArkSIAUpdateTargetSet) (*targetsetsmodels.ArkSIATargetSet, error) {
+	return s.UpdateTargetSetWithContext(context.Background(), updateTargetSet)
+}
+
+// UpdateTargetSetWithContext is UpdateTargetSet with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) UpdateTargetSetWithContext(ctx context.Context, updateTargetSet *targetsetsmodels.ArkSIAUpdateTargetSet) (*targetsetsmodels.ArkSIATargetSet, error) {
+	ctx, span := s.StartSpan(ctx, "UpdateTargetSet")
+	defer span.End()
 	s.Logger.Info("Updating target set [%s]", updateTargetSet.ID)
 	var updateTargetSetJSON map[string]interface{}
 	err := mapstructure.Decode(updateTargetSet, &updateTargetSetJSON)
@@ -186,7 +222,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) UpdateTargetSet(updateTargetSet *tar
 		return nil, err
 	}
 	delete(updateTargetSetJSON, "id")
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(targetSetURL, updateTargetSet.ID), updateTargetSetJSON)
+	response, err := s.client.Put(ctx, fmt.Sprintf(targetSetURL, updateTargetSet.ID), updateTargetSetJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +233,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) UpdateTargetSet(updateTargetSet *tar
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update target set - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to update target set")
 	}
 	targetSetJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -217,8 +253,15 @@ func (s *ArkSIAWorkspacesTargetSetsService) UpdateTargetSet(updateTargetSet *tar
 
 // ListTargetSets lists all target sets.
 func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSets() ([]*targetsetsmodels.ArkSIATargetSet, error) {
+	return s.ListTargetSetsWithContext(context.Background())
+}
+
+// ListTargetSetsWithContext is ListTargetSets with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSetsWithContext(ctx context.Context) ([]*targetsetsmodels.ArkSIATargetSet, error) {
+	ctx, span := s.StartSpan(ctx, "ListTargetSets")
+	defer span.End()
 	s.Logger.Info("Listing all target sets")
-	response, err := s.client.Get(context.Background(), targetSetsURL, nil)
+	response, err := s.client.Get(ctx, targetSetsURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +272,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSets() ([]*targetsetsmodel
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list target sets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list target sets")
 	}
 	targetSetsResponseJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -251,8 +294,15 @@ func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSets() ([]*targetsetsmodel
 
 // ListTargetSetsBy filters target sets by the provided filter.
 func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSetsBy(targetSetsFilter *targetsetsmodels.ArkSIATargetSetsFilter) ([]*targetsetsmodels.ArkSIATargetSet, error) {
+	return s.ListTargetSetsByWithContext(context.Background(), targetSetsFilter)
+}
+
+// ListTargetSetsByWithContext is ListTargetSetsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSetsByWithContext(ctx context.Context, targetSetsFilter *targetsetsmodels.ArkSIATargetSetsFilter) ([]*targetsetsmodels.ArkSIATargetSet, error) {
+	ctx, span := s.StartSpan(ctx, "ListTargetSetsBy")
+	defer span.End()
 	s.Logger.Info("Listing target sets by filter [%v]", targetSetsFilter)
-	targetSets, err := s.ListTargetSets()
+	targetSets, err := s.ListTargetSetsWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -279,8 +329,15 @@ func (s *ArkSIAWorkspacesTargetSetsService) ListTargetSetsBy(targetSetsFilter *t
 
 // TargetSet retrieves a target set by name.
 func (s *ArkSIAWorkspacesTargetSetsService) TargetSet(getTargetSet *targetsetsmodels.ArkSIAGetTargetSet) (*targetsetsmodels.ArkSIATargetSet, error) {
+	return s.TargetSetWithContext(context.Background(), getTargetSet)
+}
+
+// TargetSetWithContext is TargetSet with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) TargetSetWithContext(ctx context.Context, getTargetSet *targetsetsmodels.ArkSIAGetTargetSet) (*targetsetsmodels.ArkSIATargetSet, error) {
+	ctx, span := s.StartSpan(ctx, "TargetSet")
+	defer span.End()
 	s.Logger.Info("Getting target set [%s]", getTargetSet.ID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(targetSetURL, getTargetSet.ID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(targetSetURL, getTargetSet.ID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +348,7 @@ func (s *ArkSIAWorkspacesTargetSetsService) TargetSet(getTargetSet *targetsetsmo
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get target set - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get target set")
 	}
 	targetSetJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -311,7 +368,14 @@ func (s *ArkSIAWorkspacesTargetSetsService) TargetSet(getTargetSet *targetsetsmo
 
 // TargetSetsStats retrieves statistics about target sets.
 func (s *ArkSIAWorkspacesTargetSetsService) TargetSetsStats() (*targetsetsmodels.ArkSIATargetSetsStats, error) {
-	targetSets, err := s.ListTargetSets()
+	return s.TargetSetsStatsWithContext(context.Background())
+}
+
+// TargetSetsStatsWithContext is TargetSetsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSIAWorkspacesTargetSetsService) TargetSetsStatsWithContext(ctx context.Context) (*targetsetsmodels.ArkSIATargetSetsStats, error) {
+	ctx, span := s.StartSpan(ctx, "TargetSetsStats")
+	defer span.End()
+	targetSets, err := s.ListTargetSetsWithContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +57,7 @@ func NewArkSMService(authenticators ...auth.ArkAuth) (*ArkSMService, error) {
 		return nil, err
 	}
 	SMService.client = client
+	baseService.AddClient(client.ArkClient)
 	SMService.ispAuth = ispAuth
 	SMService.ArkBaseService = baseService
 	return SMService, nil
@@ -78,11 +79,11 @@ func (s *ArkSMService) searchParamsFromFilter(sessionsFilter *smmodels.ArkSMSess
 }
 
 // callListSessions private function that retrieves a list of sessions, parameters can be passed to filter the results.
-func (s *ArkSMService) callListSessions(params map[string]string) (*smmodels.ArkSMSessions, error) {
+func (s *ArkSMService) callListSessions(ctx context.Context, params map[string]string) (*smmodels.ArkSMSessions, error) {
 	if params == nil {
 		params = make(map[string]string)
 	}
-	response, err := s.client.Get(context.Background(), sessionsURL, params)
+	response, err := s.client.Get(ctx, sessionsURL, params)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +94,7 @@ func (s *ArkSMService) callListSessions(params map[string]string) (*smmodels.Ark
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list sessions - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list sessions")
 	}
 	sessionsJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -108,11 +109,11 @@ func (s *ArkSMService) callListSessions(params map[string]string) (*smmodels.Ark
 }
 
 // callListSessionActivities private function that retrieves a list of activities for a session, parameters can be passed to filter the results.
-func (s *ArkSMService) callListSessionActivities(sessionID string, params map[string]string) (*smmodels.ArkSMSessionActivities, error) {
+func (s *ArkSMService) callListSessionActivities(ctx context.Context, sessionID string, params map[string]string) (*smmodels.ArkSMSessionActivities, error) {
 	if params == nil {
 		params = make(map[string]string)
 	}
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(sessionActivitiesURL, sessionID), params)
+	response, err := s.client.Get(ctx, fmt.Sprintf(sessionActivitiesURL, sessionID), params)
 	if err != nil {
 		s.Logger.Error("failed to list session activities: %v", err)
 		return nil, err
@@ -124,7 +125,7 @@ func (s *ArkSMService) callListSessionActivities(sessionID string, params map[st
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list session activities - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to list session activities")
 	}
 	sessionActivitiesJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -139,73 +140,74 @@ func (s *ArkSMService) callListSessionActivities(sessionID string, params map[st
 }
 
 // listPagedSessions private function that retrieves a list of sessions, parameters can be passed to filter the results.
-func (s *ArkSMService) listPagedSessions(params map[string]string) (<-chan *ArkSMPage, error) {
-	results := make(chan *ArkSMPage)
-	if params == nil {
-		params = make(map[string]string)
-	}
-	offset := 0
-	go func() {
-		defer close(results)
-		for {
-			sessionsResponse, err := s.callListSessions(params)
-			if err != nil {
-				s.Logger.Error("failed to list sessions: %v", err)
-				return
-			}
-			if sessionsResponse.ReturnedCount == 0 {
-				break
-			}
-			sessions := make([]*smmodels.ArkSMSession, len(sessionsResponse.Sessions))
-			for i := range sessionsResponse.Sessions {
-				sessions[i] = &sessionsResponse.Sessions[i]
-			}
-			results <- &ArkSMPage{Items: sessions}
-			offset += sessionsResponse.ReturnedCount
-			params["offset"] = strconv.Itoa(offset)
+func (s *ArkSMService) listPagedSessions(ctx context.Context, params map[string]string) common.ArkPageIterator[smmodels.ArkSMSession] {
+	return common.NewArkOffsetPageIterator(ctx, 0, func(ctx context.Context, offset int) (*ArkSMPage, error) {
+		pageParams := make(map[string]string, len(params)+1)
+		for key, value := range params {
+			pageParams[key] = value
+		}
+		if offset > 0 {
+			pageParams["offset"] = strconv.Itoa(offset)
 		}
-	}()
-	return results, nil
+		sessionsResponse, err := s.callListSessions(ctx, pageParams)
+		if err != nil {
+			return nil, err
+		}
+		sessions := make([]*smmodels.ArkSMSession, len(sessionsResponse.Sessions))
+		for i := range sessionsResponse.Sessions {
+			sessions[i] = &sessionsResponse.Sessions[i]
+		}
+		return &ArkSMPage{Items: sessions}, nil
+	})
 }
 
 // listActivities private function that retrieves the activities by session ID
 // parameters can be passed to filter the results.
-func (s *ArkSMService) listPagedSessionActivities(sessionID string) (<-chan *ArkSMActivitiesPage, error) {
-	results := make(chan *ArkSMActivitiesPage)
-	params := make(map[string]string)
-	offset := 0
-	go func() {
-		defer close(results)
-		for {
-			sessionActivitiesResponse, err := s.callListSessionActivities(sessionID, params)
-			if err != nil {
-				s.Logger.Error("failed to list session activities: %v", err)
-				return
-			}
-			if sessionActivitiesResponse.ReturnedCount == 0 {
-				break
-			}
-			activities := make([]*smmodels.ArkSMSessionActivity, len(sessionActivitiesResponse.Activities))
-			for i := range sessionActivitiesResponse.Activities {
-				activities[i] = &sessionActivitiesResponse.Activities[i]
-			}
-			results <- &ArkSMActivitiesPage{Items: activities}
-			offset += sessionActivitiesResponse.ReturnedCount
+func (s *ArkSMService) listPagedSessionActivities(ctx context.Context, sessionID string) common.ArkPageIterator[smmodels.ArkSMSessionActivity] {
+	return common.NewArkOffsetPageIterator(ctx, 0, func(ctx context.Context, offset int) (*ArkSMActivitiesPage, error) {
+		params := make(map[string]string)
+		if offset > 0 {
 			params["offset"] = strconv.Itoa(offset)
-
 		}
-	}()
-	return results, nil
+		sessionActivitiesResponse, err := s.callListSessionActivities(ctx, sessionID, params)
+		if err != nil {
+			return nil, err
+		}
+		activities := make([]*smmodels.ArkSMSessionActivity, len(sessionActivitiesResponse.Activities))
+		for i := range sessionActivitiesResponse.Activities {
+			activities[i] = &sessionActivitiesResponse.Activities[i]
+		}
+		return &ArkSMActivitiesPage{Items: activities}, nil
+	})
 }
 
 // ListSessions retrieves a list of sessions
 func (s *ArkSMService) ListSessions() (<-chan *ArkSMPage, error) {
-	return s.listPagedSessions(nil)
+	return s.ListSessionsWithContext(context.Background())
+}
+
+// ListSessionsWithContext is ListSessions with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) ListSessionsWithContext(ctx context.Context) (<-chan *ArkSMPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSessionsIter(ctx), s.Logger), nil
+}
+
+// ListSessionsIter returns an iterator over all the sessions pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkSMService) ListSessionsIter(ctx context.Context) common.ArkPageIterator[smmodels.ArkSMSession] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSessions", func(ctx context.Context) common.ArkPageIterator[smmodels.ArkSMSession] {
+		return s.listPagedSessions(ctx, nil)
+	})
 }
 
 // CountSessions retrieves the count of sessions on the last 24 hours
 func (s *ArkSMService) CountSessions() (int, error) {
-	sessions, err := s.callListSessions(nil)
+	return s.CountSessionsWithContext(context.Background())
+}
+
+// CountSessionsWithContext is CountSessions with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) CountSessionsWithContext(ctx context.Context) (int, error) {
+	ctx, span := s.StartSpan(ctx, "CountSessions")
+	defer span.End()
+	sessions, err := s.callListSessions(ctx, nil)
 	if err != nil {
 		s.Logger.Error("failed to count sessions: %v", err)
 		return 0, err
@@ -215,12 +217,31 @@ func (s *ArkSMService) CountSessions() (int, error) {
 
 // ListSessionsBy retrieves a list of sessions and applies an optional filter.
 func (s *ArkSMService) ListSessionsBy(filter *smmodels.ArkSMSessionsFilter) (<-chan *ArkSMPage, error) {
-	return s.listPagedSessions(s.searchParamsFromFilter(filter))
+	return s.ListSessionsByWithContext(context.Background(), filter)
+}
+
+// ListSessionsByWithContext is ListSessionsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) ListSessionsByWithContext(ctx context.Context, filter *smmodels.ArkSMSessionsFilter) (<-chan *ArkSMPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSessionsByIter(ctx, filter), s.Logger), nil
+}
+
+// ListSessionsByIter returns an iterator over the sessions pages filtered by the given filter, stopping with an error if a page fails to be retrieved.
+func (s *ArkSMService) ListSessionsByIter(ctx context.Context, filter *smmodels.ArkSMSessionsFilter) common.ArkPageIterator[smmodels.ArkSMSession] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSessionsBy", func(ctx context.Context) common.ArkPageIterator[smmodels.ArkSMSession] {
+		return s.listPagedSessions(ctx, s.searchParamsFromFilter(filter))
+	})
 }
 
 // CountSessionsBy retrieves the count of sessions on the last 24 hours and applies an optional filter.
 func (s *ArkSMService) CountSessionsBy(filter *smmodels.ArkSMSessionsFilter) (int, error) {
-	sessions, err := s.callListSessions(s.searchParamsFromFilter(filter))
+	return s.CountSessionsByWithContext(context.Background(), filter)
+}
+
+// CountSessionsByWithContext is CountSessionsBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) CountSessionsByWithContext(ctx context.Context, filter *smmodels.ArkSMSessionsFilter) (int, error) {
+	ctx, span := s.StartSpan(ctx, "CountSessionsBy")
+	defer span.End()
+	sessions, err := s.callListSessions(ctx, s.searchParamsFromFilter(filter))
 	if err != nil {
 		s.Logger.Error("failed to count sessions: %v", err)
 		return 0, err
@@ -230,12 +251,31 @@ func (s *ArkSMService) CountSessionsBy(filter *smmodels.ArkSMSessionsFilter) (in
 
 // ListSessionActivities retrieves the activities of a session by its ID
 func (s *ArkSMService) ListSessionActivities(sessionActivities *smmodels.ArkSIASMGetSessionActivities) (<-chan *ArkSMActivitiesPage, error) {
-	return s.listPagedSessionActivities(sessionActivities.SessionID)
+	return s.ListSessionActivitiesWithContext(context.Background(), sessionActivities)
+}
+
+// ListSessionActivitiesWithContext is ListSessionActivities with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) ListSessionActivitiesWithContext(ctx context.Context, sessionActivities *smmodels.ArkSIASMGetSessionActivities) (<-chan *ArkSMActivitiesPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSessionActivitiesIter(ctx, sessionActivities), s.Logger), nil
+}
+
+// ListSessionActivitiesIter returns an iterator over the activities pages of a session, stopping with an error if a page fails to be retrieved.
+func (s *ArkSMService) ListSessionActivitiesIter(ctx context.Context, sessionActivities *smmodels.ArkSIASMGetSessionActivities) common.ArkPageIterator[smmodels.ArkSMSessionActivity] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSessionActivities", func(ctx context.Context) common.ArkPageIterator[smmodels.ArkSMSessionActivity] {
+		return s.listPagedSessionActivities(ctx, sessionActivities.SessionID)
+	})
 }
 
 // CountSessionActivities retrieves the count all session activities by session id
 func (s *ArkSMService) CountSessionActivities(activities *smmodels.ArkSIASMGetSessionActivities) (int, error) {
-	sessionActivities, err := s.callListSessionActivities(activities.SessionID, nil)
+	return s.CountSessionActivitiesWithContext(context.Background(), activities)
+}
+
+// CountSessionActivitiesWithContext is CountSessionActivities with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) CountSessionActivitiesWithContext(ctx context.Context, activities *smmodels.ArkSIASMGetSessionActivities) (int, error) {
+	ctx, span := s.StartSpan(ctx, "CountSessionActivities")
+	defer span.End()
+	sessionActivities, err := s.callListSessionActivities(ctx, activities.SessionID, nil)
 	if err != nil {
 		s.Logger.Error("failed counting session activities: %v", err)
 		return 0, err
@@ -245,66 +285,69 @@ func (s *ArkSMService) CountSessionActivities(activities *smmodels.ArkSIASMGetSe
 
 // ListSessionActivitiesBy retrieves the activities of a session by its ID and applies an optional filter.
 func (s *ArkSMService) ListSessionActivitiesBy(filter *smmodels.ArkSMSessionActivitiesFilter) (<-chan *ArkSMActivitiesPage, error) {
-	pagedSessionActivities, err := s.listPagedSessionActivities(filter.SessionID)
-	if err != nil {
-		s.Logger.Error("failed to list session activities: %v", err)
-		return nil, err
-	}
-	out := make(chan *ArkSMActivitiesPage)
+	return s.ListSessionActivitiesByWithContext(context.Background(), filter)
+}
 
-	go func() {
-		defer close(out)
+// ListSessionActivitiesByWithContext is ListSessionActivitiesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) ListSessionActivitiesByWithContext(ctx context.Context, filter *smmodels.ArkSMSessionActivitiesFilter) (<-chan *ArkSMActivitiesPage, error) {
+	return common.ArkPageChannel(ctx, s.ListSessionActivitiesByIter(ctx, filter), s.Logger), nil
+}
 
-		for page := range pagedSessionActivities {
+// ListSessionActivitiesByIter returns an iterator over the activities pages of a session filtered by the given filter, stopping with an error if a page fails to be retrieved.
+func (s *ArkSMService) ListSessionActivitiesByIter(ctx context.Context, filter *smmodels.ArkSMSessionActivitiesFilter) common.ArkPageIterator[smmodels.ArkSMSessionActivity] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListSessionActivitiesBy", func(ctx context.Context) common.ArkPageIterator[smmodels.ArkSMSessionActivity] {
+		return common.MapArkPageIterator(s.listPagedSessionActivities(ctx, filter.SessionID), func(page *ArkSMActivitiesPage) (*ArkSMActivitiesPage, error) {
 			filteredItems := make([]*smmodels.ArkSMSessionActivity, 0, len(page.Items))
-
 			for _, activity := range page.Items {
 				if filter.CommandContains == "" || strings.Contains(activity.Command, filter.CommandContains) {
 					filteredItems = append(filteredItems, activity)
 				}
 			}
-
-			out <- &ArkSMActivitiesPage{
-				Items: filteredItems,
-			}
-		}
-	}()
-
-	return out, nil
+			return &ArkSMActivitiesPage{Items: filteredItems}, nil
+		})
+	})
 }
 
 // CountSessionActivitiesBy retrieves the count all session activities by session id and applies an optional filter.
 func (s *ArkSMService) CountSessionActivitiesBy(filter *smmodels.ArkSMSessionActivitiesFilter) (int, error) {
-	pagedSessionActivities, err := s.ListSessionActivitiesBy(filter)
-	if err != nil {
-		s.Logger.Error("failed counting session activities: %v", err)
-		return 0, err
-	}
+	return s.CountSessionActivitiesByWithContext(context.Background(), filter)
+}
+
+// CountSessionActivitiesByWithContext is CountSessionActivitiesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) CountSessionActivitiesByWithContext(ctx context.Context, filter *smmodels.ArkSMSessionActivitiesFilter) (int, error) {
+	ctx, span := s.StartSpan(ctx, "CountSessionActivitiesBy")
+	defer span.End()
 	count := 0
-	for page := range pagedSessionActivities {
+	for page, err := range s.ListSessionActivitiesByIter(ctx, filter) {
+		if err != nil {
+			s.Logger.Error("failed counting session activities: %v", err)
+			return 0, err
+		}
 		count += len(page.Items)
 	}
-	return count, err
+	return count, nil
 }
 
 // SessionsStats retrieves the session statistics for the SM service.
 func (s *ArkSMService) SessionsStats() (*smmodels.ArkSMSessionsStats, error) {
+	return s.SessionsStatsWithContext(context.Background())
+}
+
+// SessionsStatsWithContext is SessionsStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) SessionsStatsWithContext(ctx context.Context) (*smmodels.ArkSMSessionsStats, error) {
+	ctx, span := s.StartSpan(ctx, "SessionsStats")
+	defer span.End()
 	s.Logger.Info("Calculating sessions stats for the last 30 days")
 	startTimeFrom := time.Now().AddDate(0, 0, -30).UTC().Format("2006-01-02T15:04:05Z")
 
 	filter := smmodels.ArkSMSessionsFilter{
 		Search: fmt.Sprintf("startTime ge %s", startTimeFrom),
 	}
-	pages, err := s.ListSessionsBy(&filter)
+	sessions, err := common.CollectArkPageItems(s.ListSessionsByIter(ctx, &filter))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sessions: %w", err)
 	}
 
-	var sessions []*smmodels.ArkSMSession
-	for page := range pages {
-		sessions = append(sessions, page.Items...)
-	}
-
 	stats := &smmodels.ArkSMSessionsStats{}
 	stats.SessionsCount = len(sessions)
 	stats.SessionsFailureCount = 0
@@ -328,8 +371,15 @@ func (s *ArkSMService) SessionsStats() (*smmodels.ArkSMSessionsStats, error) {
 
 // Session retrieves a session by its ID
 func (s *ArkSMService) Session(getSession *smmodels.ArkSIASMGetSession) (*smmodels.ArkSMSession, error) {
+	return s.SessionWithContext(context.Background(), getSession)
+}
+
+// SessionWithContext is Session with a caller provided context that is passed down to every request made by the call.
+func (s *ArkSMService) SessionWithContext(ctx context.Context, getSession *smmodels.ArkSIASMGetSession) (*smmodels.ArkSMSession, error) {
+	ctx, span := s.StartSpan(ctx, "Session")
+	defer span.End()
 	s.Logger.Info("Getting session [%s]", getSession.SessionID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(sessionURL, getSession.SessionID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(sessionURL, getSession.SessionID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +390,7 @@ func (s *ArkSMService) Session(getSession *smmodels.ArkSIASMGetSession) (*smmode
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get session - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to get session")
 	}
 	sessionJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -1,6 +1,7 @@
 package uap
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
@@ -43,71 +44,73 @@ func NewArkUAPService(authenticators ...auth.ArkAuth) (*ArkUAPService, error) {
 	if err != nil {
 		return nil, err
 	}
+	baseService.AddClient(uapService.baseService.Client().ArkClient)
 	return uapService, nil
 }
 
 // ListPolicies retrieves all policies.
 func (s *ArkUAPService) ListPolicies() (<-chan *ArkUAPPolicyPage, error) {
-	s.Logger.Info("Listing all policies")
-	policyPagesWithType := make(chan *ArkUAPPolicyPage)
-	go func() {
+	return s.ListPoliciesWithContext(context.Background())
+}
+
+// ListPoliciesWithContext is ListPolicies with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPService) ListPoliciesWithContext(ctx context.Context) (<-chan *ArkUAPPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesIter(ctx), s.Logger), nil
+}
+
+// ListPoliciesIter returns an iterator over all the policies pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPService) ListPoliciesIter(ctx context.Context) common.ArkPageIterator[uapcommonmodels.ArkUAPCommonAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPolicies", func(ctx context.Context) common.ArkPageIterator[uapcommonmodels.ArkUAPCommonAccessPolicy] {
+		s.Logger.Info("Listing all policies")
 		filters := uapcommonmodels.NewArkUAPFilters()
-		policyPages, err := s.baseService.BaseListPolicies(filters)
-		if err != nil {
-			return
-		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			policies := ArkUAPPolicyPage{Items: make([]*uapcommonmodels.ArkUAPCommonAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var commonPolicy uapcommonmodels.ArkUAPCommonAccessPolicy
-				err = mapstructure.Decode(*policy, &commonPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				policies.Items[idx] = &commonPolicy
-			}
-			policyPagesWithType <- &policies
-		}
-	}()
-	return policyPagesWithType, nil
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, filters), s.decodePoliciesPage)
+	})
 }
 
 // ListPoliciesBy retrieves policies based on the provided filters.
 func (s *ArkUAPService) ListPoliciesBy(filters *uapcommonmodels.ArkUAPFilters) (<-chan *ArkUAPPolicyPage, error) {
-	s.Logger.Info("Listing policies by filter")
-	policyPagesWithType := make(chan *ArkUAPPolicyPage)
-	go func() {
+	return s.ListPoliciesByWithContext(context.Background(), filters)
+}
+
+// ListPoliciesByWithContext is ListPoliciesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPService) ListPoliciesByWithContext(ctx context.Context, filters *uapcommonmodels.ArkUAPFilters) (<-chan *ArkUAPPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesByIter(ctx, filters), s.Logger), nil
+}
+
+// ListPoliciesByIter returns an iterator over the policies pages matching the provided filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPService) ListPoliciesByIter(ctx context.Context, filters *uapcommonmodels.ArkUAPFilters) common.ArkPageIterator[uapcommonmodels.ArkUAPCommonAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoliciesBy", func(ctx context.Context) common.ArkPageIterator[uapcommonmodels.ArkUAPCommonAccessPolicy] {
+		s.Logger.Info("Listing policies by filter")
 		if filters == nil {
 			filters = uapcommonmodels.NewArkUAPFilters()
 		}
-		policyPages, err := s.baseService.BaseListPolicies(filters)
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, filters), s.decodePoliciesPage)
+	})
+}
+
+func (s *ArkUAPService) decodePoliciesPage(page *uap.ArkUAPBasePolicyPage) (*ArkUAPPolicyPage, error) {
+	policies := ArkUAPPolicyPage{Items: make([]*uapcommonmodels.ArkUAPCommonAccessPolicy, len(page.Items))}
+	for idx, policy := range page.Items {
+		var commonPolicy uapcommonmodels.ArkUAPCommonAccessPolicy
+		err := mapstructure.Decode(*policy, &commonPolicy)
 		if err != nil {
-			s.Logger.Error("Failed to list policies by filter: %v", err)
-			close(policyPagesWithType)
-			return
+			s.Logger.Error("Failed to decode policy page: %v", err)
+			continue
 		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			policies := ArkUAPPolicyPage{Items: make([]*uapcommonmodels.ArkUAPCommonAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var commonPolicy uapcommonmodels.ArkUAPCommonAccessPolicy
-				err = mapstructure.Decode(*policy, &commonPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				policies.Items[idx] = &commonPolicy
-			}
-			policyPagesWithType <- &policies
-		}
-	}()
-	return policyPagesWithType, nil
+		policies.Items[idx] = &commonPolicy
+	}
+	return &policies, nil
 }
 
 // PolicyStatus retrieves the status of a policy by its ID or name.
 func (s *ArkUAPService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	return s.PolicyStatusWithContext(context.Background(), getPolicyStatus)
+}
+
+// PolicyStatusWithContext is PolicyStatus with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPService) PolicyStatusWithContext(ctx context.Context, getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	ctx, span := s.StartSpan(ctx, "PolicyStatus")
+	defer span.End()
 	if getPolicyStatus == nil {
 		return "", fmt.Errorf("getPolicyStatus cannot be nil")
 	}
@@ -116,14 +119,21 @@ func (s *ArkUAPService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUAPGetP
 	}
 	s.Logger.Info("Retrieving policy status for ID [%s] and name [%s]", getPolicyStatus.PolicyID, getPolicyStatus.PolicyName)
 	respType := reflect.TypeOf(uapcommonmodels.ArkUAPCommonAccessPolicy{})
-	return s.baseService.BasePolicyStatus(getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
+	return s.baseService.BasePolicyStatusWithContext(ctx, getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
 }
 
 // PoliciesStats retrieves statistics for all policies.
 func (s *ArkUAPService) PoliciesStats() (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	return s.PoliciesStatsWithContext(context.Background())
+}
+
+// PoliciesStatsWithContext is PoliciesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPService) PoliciesStatsWithContext(ctx context.Context) (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	ctx, span := s.StartSpan(ctx, "PoliciesStats")
+	defer span.End()
 	s.Logger.Info("Retrieving policies statistics")
 	filters := uapcommonmodels.NewArkUAPFilters()
-	stats, err := s.baseService.BasePoliciesStats(filters)
+	stats, err := s.baseService.BasePoliciesStatsWithContext(ctx, filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve policies statistics: %w", err)
 	}
@@ -44,6 +44,11 @@ func NewArkUAPBaseService(ispAuth *auth.ArkISPAuth) (*ArkUAPBaseService, error)
 	return uapService, nil
 }
 
+// Client returns the client the base service sends its requests with.
+func (s *ArkUAPBaseService) Client() *isp.ArkISPServiceClient {
+	return s.client
+}
+
 func (s *ArkUAPBaseService) refreshUapAuth(client *common.ArkClient) error {
 	err := isp.RefreshClient(client, s.ispAuth)
 	if err != nil {
@@ -54,8 +59,13 @@ func (s *ArkUAPBaseService) refreshUapAuth(client *common.ArkClient) error {
 
 // BaseAddPolicy adds a new policy.
 func (s *ArkUAPBaseService) BaseAddPolicy(addPolicy map[string]interface{}) (*uapcommonmodels.ArkUAPResponse, error) {
+	return s.BaseAddPolicyWithContext(context.Background(), addPolicy)
+}
+
+// BaseAddPolicyWithContext is BaseAddPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BaseAddPolicyWithContext(ctx context.Context, addPolicy map[string]interface{}) (*uapcommonmodels.ArkUAPResponse, error) {
 	s.logger.Info("Adding new policy")
-	response, err := s.client.Post(context.Background(), policiesURL, addPolicy)
+	response, err := s.client.Post(ctx, policiesURL, addPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +76,7 @@ func (s *ArkUAPBaseService) BaseAddPolicy(addPolicy map[string]interface{}) (*ua
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to add policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to add policy")
 	}
 	policyIDJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
@@ -82,8 +92,13 @@ func (s *ArkUAPBaseService) BaseAddPolicy(addPolicy map[string]interface{}) (*ua
 
 // BasePolicy retrieves a policy by ID.
 func (s *ArkUAPBaseService) BasePolicy(policyID string, schema *reflect.Type) (map[string]interface{}, error) {
+	return s.BasePolicyWithContext(context.Background(), policyID, schema)
+}
+
+// BasePolicyWithContext is BasePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BasePolicyWithContext(ctx context.Context, policyID string, schema *reflect.Type) (map[string]interface{}, error) {
 	s.logger.Info("Retrieving policy [%s]", policyID)
-	response, err := s.client.Get(context.Background(), fmt.Sprintf(policyURL, policyID), nil)
+	response, err := s.client.Get(ctx, fmt.Sprintf(policyURL, policyID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +109,7 @@ func (s *ArkUAPBaseService) BasePolicy(policyID string, schema *reflect.Type) (m
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to retrieve policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, common.NewArkAPIError(response, "failed to retrieve policy")
 	}
 	policyJSON, err := common.DeserializeJSONSnakeSchema(response.Body, schema)
 	if err != nil {
@@ -105,8 +120,13 @@ func (s *ArkUAPBaseService) BasePolicy(policyID string, schema *reflect.Type) (m
 
 // BaseUpdatePolicy updates an existing policy.
 func (s *ArkUAPBaseService) BaseUpdatePolicy(policyID string, updatePolicy map[string]interface{}) error {
+	return s.BaseUpdatePolicyWithContext(context.Background(), policyID, updatePolicy)
+}
+
+// BaseUpdatePolicyWithContext is BaseUpdatePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BaseUpdatePolicyWithContext(ctx context.Context, policyID string, updatePolicy map[string]interface{}) error {
 	s.logger.Info("Updating policy [%s]", policyID)
-	response, err := s.client.Put(context.Background(), fmt.Sprintf(policyURL, policyID), updatePolicy)
+	response, err := s.client.Put(ctx, fmt.Sprintf(policyURL, policyID), updatePolicy)
 	if err != nil {
 		return err
 	}
@@ -117,15 +137,20 @@ func (s *ArkUAPBaseService) BaseUpdatePolicy(policyID string, updatePolicy map[s
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to update policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to update policy")
 	}
 	return nil
 }
 
 // BaseDeletePolicy deletes a policy by ID.
 func (s *ArkUAPBaseService) BaseDeletePolicy(policyID string) error {
+	return s.BaseDeletePolicyWithContext(context.Background(), policyID)
+}
+
+// BaseDeletePolicyWithContext is BaseDeletePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BaseDeletePolicyWithContext(ctx context.Context, policyID string) error {
 	s.logger.Info("Deleting policy [%s]", policyID)
-	response, err := s.client.Delete(context.Background(), fmt.Sprintf(policyURL, policyID), nil)
+	response, err := s.client.Delete(ctx, fmt.Sprintf(policyURL, policyID), nil)
 	if err != nil {
 		return err
 	}
@@ -136,31 +161,33 @@ func (s *ArkUAPBaseService) BaseDeletePolicy(policyID string) error {
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete policy - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return common.NewArkAPIError(response, "failed to delete policy")
 	}
 	return nil
 }
 
 // BaseListPolicies retrieves all policies with optional filters.
 func (s *ArkUAPBaseService) BaseListPolicies(filters *uapcommonmodels.ArkUAPFilters) (<-chan *ArkUAPBasePolicyPage, error) {
+	return s.BaseListPoliciesWithContext(context.Background(), filters)
+}
+
+// BaseListPoliciesWithContext is BaseListPolicies with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BaseListPoliciesWithContext(ctx context.Context, filters *uapcommonmodels.ArkUAPFilters) (<-chan *ArkUAPBasePolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.BaseListPoliciesIter(ctx, filters), s.logger), nil
+}
+
+// BaseListPoliciesIter returns an iterator over the raw policies pages matching the optional filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPBaseService) BaseListPoliciesIter(ctx context.Context, filters *uapcommonmodels.ArkUAPFilters) common.ArkPageIterator[map[string]interface{}] {
 	s.logger.Info("Listing policies")
 	if filters == nil {
 		filters = uapcommonmodels.NewArkUAPFilters()
 	}
-
-	pageChannel := make(chan *ArkUAPBasePolicyPage)
-	go func() {
-		defer close(pageChannel)
-
-		var nextToken string
-		var prevToken string
+	return func(yield func(*ArkUAPBasePolicyPage, error) bool) {
+		if filters.MaxPages <= 0 {
+			return
+		}
 		pageCount := 0
-
-		for {
-			if pageCount >= filters.MaxPages {
-				break
-			}
-
+		common.NewArkCursorPageIterator(ctx, func(ctx context.Context, nextToken string) (*ArkUAPBasePolicyPage, string, error) {
 			pageCount++
 
 			// Build query parameters
@@ -171,8 +198,7 @@ func (s *ArkUAPBaseService) BaseListPolicies(filters *uapcommonmodels.ArkUAPFilt
 			queryParams := request.BuildGetQueryParams()
 			queryParamsJSON, err := common.SerializeJSONCamel(queryParams)
 			if err != nil {
-				s.logger.Error("Failed to serialize query parameters: %v", err)
-				return
+				return nil, "", fmt.Errorf("failed to serialize query parameters: %w", err)
 			}
 			queryParamsJSONParams := make(map[string]string)
 			for key, value := range queryParamsJSON {
@@ -181,10 +207,9 @@ func (s *ArkUAPBaseService) BaseListPolicies(filters *uapcommonmodels.ArkUAPFilt
 
 			// Make API call
 			s.logger.Info("Requesting policies with next_token [%s] [%v]", nextToken, queryParamsJSONParams)
-			response, err := s.client.Get(context.Background(), policiesURL, queryParamsJSONParams)
+			response, err := s.client.Get(ctx, policiesURL, queryParamsJSONParams)
 			if err != nil {
-				s.logger.Error("Failed to list policies: %v", err)
-				return
+				return nil, "", err
 			}
 			defer func(Body io.ReadCloser) {
 				err := Body.Close()
@@ -195,20 +220,17 @@ func (s *ArkUAPBaseService) BaseListPolicies(filters *uapcommonmodels.ArkUAPFilt
 
 			// Check response status
 			if response.StatusCode != http.StatusOK {
-				s.logger.Error("Failed to list policies - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
+				return nil, "", common.NewArkAPIError(response, "failed to list policies")
 			}
 
 			// Parse response
 			resultJSON, err := common.DeserializeJSONSnake(response.Body)
 			if err != nil {
-				s.logger.Error("Failed to decode response: %v", err)
-				return
+				return nil, "", err
 			}
 			policiesJSONs, ok := resultJSON.(map[string]interface{})["results"].([]interface{})
 			if !ok {
-				s.logger.Error("Response does not contain 'results' key")
-				return
+				return nil, "", fmt.Errorf("response does not contain 'results' key")
 			}
 			policiesJSONsOut := make([]*map[string]interface{}, len(policiesJSONs))
 			for i, policyJSONInterface := range policiesJSONs {
@@ -219,46 +241,40 @@ func (s *ArkUAPBaseService) BaseListPolicies(filters *uapcommonmodels.ArkUAPFilt
 				}
 				policiesJSONsOut[i] = &policyJSON
 			}
+			page := &ArkUAPBasePolicyPage{Items: policiesJSONsOut}
 
-			// Send page to channel
-			pageChannel <- &ArkUAPBasePolicyPage{Items: policiesJSONsOut}
-
-			// Update tokens
+			// Resolve the token of the next page
 			tempNextToken, ok := resultJSON.(map[string]interface{})["next_token"].(string)
 			if !ok {
-				s.logger.Error("Response does not contain 'next_token' key or it is not a string")
-				return
+				s.logger.Debug("Response does not contain 'next_token' key or it is not a string, stopping pagination")
+				return page, "", nil
 			}
-			prevToken, nextToken = nextToken, tempNextToken
-
-			// Break if no next token or pagination loop detected
-			if nextToken == "" || nextToken == prevToken {
-				if nextToken == prevToken {
-					s.logger.Error("Pagination stuck: next_token did not change between requests")
-				}
-				break
+			if pageCount >= filters.MaxPages {
+				return page, "", nil
 			}
 			if len(policiesJSONs) < queryParams.Limit {
-				s.logger.Info("No more policies to retrieve, breaking pagination loop")
-				break
+				s.logger.Info("No more policies to retrieve, stopping pagination")
+				return page, "", nil
 			}
-		}
-	}()
-
-	return pageChannel, nil
+			return page, tempNextToken, nil
+		})(yield)
+	}
 }
 
 // BasePolicyByName retrieves a policy by its name.
 func (s *ArkUAPBaseService) BasePolicyByName(policyName string) (map[string]interface{}, error) {
+	return s.BasePolicyByNameWithContext(context.Background(), policyName)
+}
+
+// BasePolicyByNameWithContext is BasePolicyByName with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BasePolicyByNameWithContext(ctx context.Context, policyName string) (map[string]interface{}, error) {
 	s.logger.Info("Retrieving policy by name [%s]", policyName)
 	filters := uapcommonmodels.NewArkUAPFilters()
 	filters.TextSearch = policyName
-	policies, err := s.BaseListPolicies(filters)
-	if err != nil {
-		return nil, err
-	}
-
-	for page := range policies {
+	for page, err := range s.BaseListPoliciesIter(ctx, filters) {
+		if err != nil {
+			return nil, err
+		}
 		for _, policy := range page.Items {
 			metadataJSON, ok := (*policy)["metadata"].(map[string]interface{})
 			if !ok {
@@ -279,16 +295,21 @@ func (s *ArkUAPBaseService) BasePolicyByName(policyName string) (map[string]inte
 
 // BasePolicyStatus retrieves the status of a policy by its ID or name.
 func (s *ArkUAPBaseService) BasePolicyStatus(policyID string, policyName string, schema *reflect.Type) (string, error) {
+	return s.BasePolicyStatusWithContext(context.Background(), policyID, policyName, schema)
+}
+
+// BasePolicyStatusWithContext is BasePolicyStatus with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BasePolicyStatusWithContext(ctx context.Context, policyID string, policyName string, schema *reflect.Type) (string, error) {
 	s.logger.Info("Retrieving policy status for [%s] with name [%s]", policyID, policyName)
 	var policy map[string]interface{}
 	var err error
 	if policyID != "" {
-		policy, err = s.BasePolicy(policyID, schema)
+		policy, err = s.BasePolicyWithContext(ctx, policyID, schema)
 		if err != nil {
 			return "", fmt.Errorf("failed to retrieve policy status for ID '%s' and name '%s': %w", policyID, policyName, err)
 		}
 	} else if policyName != "" {
-		policy, err = s.BasePolicyByName(policyName)
+		policy, err = s.BasePolicyByNameWithContext(ctx, policyName)
 		if err != nil {
 			return "", fmt.Errorf("failed to retrieve policy status for ID '%s' and name '%s': %w", policyID, policyName, err)
 		}
@@ -309,17 +330,21 @@ func (s *ArkUAPBaseService) BasePolicyStatus(policyID string, policyName string,
 
 // BasePoliciesStats retrieves statistics about policies.
 func (s *ArkUAPBaseService) BasePoliciesStats(filters *uapcommonmodels.ArkUAPFilters) (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	return s.BasePoliciesStatsWithContext(context.Background(), filters)
+}
+
+// BasePoliciesStatsWithContext is BasePoliciesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPBaseService) BasePoliciesStatsWithContext(ctx context.Context, filters *uapcommonmodels.ArkUAPFilters) (*uapcommonmodels.ArkUAPPoliciesStats, error) {
 	policiesStats := &uapcommonmodels.ArkUAPPoliciesStats{
 		PoliciesCount:            0,
 		PoliciesCountPerStatus:   make(map[string]int),
 		PoliciesCountPerProvider: make(map[string]int),
 	}
 	s.logger.Info("Retrieving policies stats")
-	policies, err := s.BaseListPolicies(filters)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve policies stats: %w", err)
-	}
-	for page := range policies {
+	for page, err := range s.BaseListPoliciesIter(ctx, filters) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve policies stats: %w", err)
+		}
 		for _, policy := range page.Items {
 			policiesStats.PoliciesCount++
 			metadataJSON, ok := (*policy)["metadata"].(map[string]interface{})
@@ -1,6 +1,7 @@
 package sca
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -48,6 +49,7 @@ func NewArkUAPSCAService(authenticators ...auth.ArkAuth) (*ArkUAPSCAService, err
 	if err != nil {
 		return nil, err
 	}
+	baseService.AddClient(uapScaService.baseService.Client().ArkClient)
 	return uapScaService, nil
 }
 
@@ -64,6 +66,13 @@ func (s *ArkUAPSCAService) deserializeTargets(policy *uapscamodels.ArkUAPSCAClou
 
 // AddPolicy adds a new policy with the given information.
 func (s *ArkUAPSCAService) AddPolicy(addPolicy *uapscamodels.ArkUAPSCACloudConsoleAccessPolicy) (*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, error) {
+	return s.AddPolicyWithContext(context.Background(), addPolicy)
+}
+
+// AddPolicyWithContext is AddPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) AddPolicyWithContext(ctx context.Context, addPolicy *uapscamodels.ArkUAPSCACloudConsoleAccessPolicy) (*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "AddPolicy")
+	defer span.End()
 	s.Logger.Info("Adding new policy [%s]", addPolicy.Metadata.Name)
 	addPolicy.Metadata.PolicyEntitlement.TargetCategory = commonmodels.CategoryTypeCloudConsole
 	if addPolicy.Metadata.PolicyTags == nil {
@@ -77,13 +86,13 @@ func (s *ArkUAPSCAService) AddPolicy(addPolicy *uapscamodels.ArkUAPSCACloudConso
 	if err != nil {
 		return nil, err
 	}
-	policyResp, err := s.baseService.BaseAddPolicy(policyJSON)
+	policyResp, err := s.baseService.BaseAddPolicyWithContext(ctx, policyJSON)
 	if err != nil {
 		return nil, err
 	}
 	retryCount := 0
 	for {
-		policy, err := s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+		policy, err := s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 			PolicyID: policyResp.PolicyID,
 		})
 		if err != nil {
@@ -102,16 +111,23 @@ func (s *ArkUAPSCAService) AddPolicy(addPolicy *uapscamodels.ArkUAPSCACloudConso
 		}
 		retryCount++
 	}
-	return s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+	return s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 		PolicyID: policyResp.PolicyID,
 	})
 }
 
 // Policy retrieves a policy by its ID.
 func (s *ArkUAPSCAService) Policy(policyRequest *uapcommonmodels.ArkUAPGetPolicyRequest) (*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, error) {
+	return s.PolicyWithContext(context.Background(), policyRequest)
+}
+
+// PolicyWithContext is Policy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) PolicyWithContext(ctx context.Context, policyRequest *uapcommonmodels.ArkUAPGetPolicyRequest) (*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "Policy")
+	defer span.End()
 	s.Logger.Info("Retrieving policy [%s]", policyRequest.PolicyID)
 	respType := reflect.TypeOf(uapscamodels.ArkUAPSCACloudConsoleAccessPolicy{})
-	policyJSON, err := s.baseService.BasePolicy(policyRequest.PolicyID, &respType)
+	policyJSON, err := s.baseService.BasePolicyWithContext(ctx, policyRequest.PolicyID, &respType)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +145,13 @@ func (s *ArkUAPSCAService) Policy(policyRequest *uapcommonmodels.ArkUAPGetPolicy
 
 // UpdatePolicy edits an existing policy with the given information.
 func (s *ArkUAPSCAService) UpdatePolicy(updatePolicy *uapscamodels.ArkUAPSCACloudConsoleAccessPolicy) (*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, error) {
+	return s.UpdatePolicyWithContext(context.Background(), updatePolicy)
+}
+
+// UpdatePolicyWithContext is UpdatePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) UpdatePolicyWithContext(ctx context.Context, updatePolicy *uapscamodels.ArkUAPSCACloudConsoleAccessPolicy) (*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "UpdatePolicy")
+	defer span.End()
 	s.Logger.Info("Updating policy [%s]", updatePolicy.Metadata.PolicyID)
 	policyJSON, err := common.SerializeJSONCamel(updatePolicy)
 	if err != nil {
@@ -138,13 +161,13 @@ func (s *ArkUAPSCAService) UpdatePolicy(updatePolicy *uapscamodels.ArkUAPSCAClou
 	if err != nil {
 		return nil, err
 	}
-	err = s.baseService.BaseUpdatePolicy(updatePolicy.Metadata.PolicyID, policyJSON)
+	err = s.baseService.BaseUpdatePolicyWithContext(ctx, updatePolicy.Metadata.PolicyID, policyJSON)
 	if err != nil {
 		return nil, err
 	}
 	retryCount := 0
 	for {
-		policy, err := s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+		policy, err := s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 			PolicyID: updatePolicy.Metadata.PolicyID,
 		})
 		if err != nil {
@@ -163,83 +186,91 @@ func (s *ArkUAPSCAService) UpdatePolicy(updatePolicy *uapscamodels.ArkUAPSCAClou
 		}
 		retryCount++
 	}
-	return s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+	return s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 		PolicyID: updatePolicy.Metadata.PolicyID,
 	})
 }
 
 // ListPolicies retrieves all policies.
 func (s *ArkUAPSCAService) ListPolicies() (<-chan *ArkUAPSCAPolicyPage, error) {
-	s.Logger.Info("Listing all policies")
-	policyPagesWithType := make(chan *ArkUAPSCAPolicyPage)
-	go func() {
+	return s.ListPoliciesWithContext(context.Background())
+}
+
+// ListPoliciesWithContext is ListPolicies with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) ListPoliciesWithContext(ctx context.Context) (<-chan *ArkUAPSCAPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesIter(ctx), s.Logger), nil
+}
+
+// ListPoliciesIter returns an iterator over all the cloud console access policies pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPSCAService) ListPoliciesIter(ctx context.Context) common.ArkPageIterator[uapscamodels.ArkUAPSCACloudConsoleAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPolicies", func(ctx context.Context) common.ArkPageIterator[uapscamodels.ArkUAPSCACloudConsoleAccessPolicy] {
+		s.Logger.Info("Listing all policies")
 		filters := uapcommonmodels.NewArkUAPFilters()
 		filters.TargetCategory = []string{commonmodels.CategoryTypeCloudConsole}
-		policyPages, err := s.baseService.BaseListPolicies(filters)
-		if err != nil {
-			return
-		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			scaPolicies := ArkUAPSCAPolicyPage{Items: make([]*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var scaPolicy uapscamodels.ArkUAPSCACloudConsoleAccessPolicy
-				err = mapstructure.Decode(*policy, &scaPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				scaPolicies.Items[idx] = &scaPolicy
-			}
-			policyPagesWithType <- &scaPolicies
-		}
-	}()
-	return policyPagesWithType, nil
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, filters), s.decodePoliciesPage)
+	})
 }
 
 // ListPoliciesBy retrieves policies based on the provided filters.
 func (s *ArkUAPSCAService) ListPoliciesBy(filters *uapscamodels.ArkUAPSCAFilters) (<-chan *ArkUAPSCAPolicyPage, error) {
-	s.Logger.Info("Listing policies by filter")
-	policyPagesWithType := make(chan *ArkUAPSCAPolicyPage)
-	go func() {
+	return s.ListPoliciesByWithContext(context.Background(), filters)
+}
+
+// ListPoliciesByWithContext is ListPoliciesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) ListPoliciesByWithContext(ctx context.Context, filters *uapscamodels.ArkUAPSCAFilters) (<-chan *ArkUAPSCAPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesByIter(ctx, filters), s.Logger), nil
+}
+
+// ListPoliciesByIter returns an iterator over the cloud console access policies pages matching the provided filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPSCAService) ListPoliciesByIter(ctx context.Context, filters *uapscamodels.ArkUAPSCAFilters) common.ArkPageIterator[uapscamodels.ArkUAPSCACloudConsoleAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoliciesBy", func(ctx context.Context) common.ArkPageIterator[uapscamodels.ArkUAPSCACloudConsoleAccessPolicy] {
+		s.Logger.Info("Listing policies by filter")
 		if filters == nil {
 			filters = &uapscamodels.ArkUAPSCAFilters{
 				ArkUAPFilters: *uapcommonmodels.NewArkUAPFilters(),
 			}
 		}
 		filters.TargetCategory = []string{commonmodels.CategoryTypeCloudConsole}
-		policyPages, err := s.baseService.BaseListPolicies(&filters.ArkUAPFilters)
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, &filters.ArkUAPFilters), s.decodePoliciesPage)
+	})
+}
+
+func (s *ArkUAPSCAService) decodePoliciesPage(page *uap.ArkUAPBasePolicyPage) (*ArkUAPSCAPolicyPage, error) {
+	scaPolicies := ArkUAPSCAPolicyPage{Items: make([]*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, len(page.Items))}
+	for idx, policy := range page.Items {
+		var scaPolicy uapscamodels.ArkUAPSCACloudConsoleAccessPolicy
+		err := mapstructure.Decode(*policy, &scaPolicy)
 		if err != nil {
-			s.Logger.Error("Failed to list policies by filter: %v", err)
-			close(policyPagesWithType)
-			return
-		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			scaPolicies := ArkUAPSCAPolicyPage{Items: make([]*uapscamodels.ArkUAPSCACloudConsoleAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var scaPolicy uapscamodels.ArkUAPSCACloudConsoleAccessPolicy
-				err = mapstructure.Decode(*policy, &scaPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				scaPolicies.Items[idx] = &scaPolicy
-			}
-			policyPagesWithType <- &scaPolicies
+			s.Logger.Error("Failed to decode policy page: %v", err)
+			continue
 		}
-	}()
-	return policyPagesWithType, nil
+		scaPolicies.Items[idx] = &scaPolicy
+	}
+	return &scaPolicies, nil
 }
 
 // DeletePolicy deletes a policy by its ID.
 func (s *ArkUAPSCAService) DeletePolicy(deletePolicy *uapcommonmodels.ArkUAPDeletePolicyRequest) error {
+	return s.DeletePolicyWithContext(context.Background(), deletePolicy)
+}
+
+// DeletePolicyWithContext is DeletePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) DeletePolicyWithContext(ctx context.Context, deletePolicy *uapcommonmodels.ArkUAPDeletePolicyRequest) error {
+	ctx, span := s.StartSpan(ctx, "DeletePolicy")
+	defer span.End()
 	s.Logger.Info("Deleting policy [%s]", deletePolicy.PolicyID)
-	return s.baseService.BaseDeletePolicy(deletePolicy.PolicyID)
+	return s.baseService.BaseDeletePolicyWithContext(ctx, deletePolicy.PolicyID)
 }
 
 // PolicyStatus retrieves the status of a policy by its ID or name.
 func (s *ArkUAPSCAService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	return s.PolicyStatusWithContext(context.Background(), getPolicyStatus)
+}
+
+// PolicyStatusWithContext is PolicyStatus with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) PolicyStatusWithContext(ctx context.Context, getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	ctx, span := s.StartSpan(ctx, "PolicyStatus")
+	defer span.End()
 	if getPolicyStatus == nil {
 		return "", fmt.Errorf("getPolicyStatus cannot be nil")
 	}
@@ -248,15 +279,22 @@ func (s *ArkUAPSCAService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUAPG
 	}
 	s.Logger.Info("Retrieving policy status for ID [%s] and name [%s]", getPolicyStatus.PolicyID, getPolicyStatus.PolicyName)
 	respType := reflect.TypeOf(uapscamodels.ArkUAPSCACloudConsoleAccessPolicy{})
-	return s.baseService.BasePolicyStatus(getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
+	return s.baseService.BasePolicyStatusWithContext(ctx, getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
 }
 
 // PoliciesStats calculates policies statistics.
 func (s *ArkUAPSCAService) PoliciesStats() (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	return s.PoliciesStatsWithContext(context.Background())
+}
+
+// PoliciesStatsWithContext is PoliciesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSCAService) PoliciesStatsWithContext(ctx context.Context) (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	ctx, span := s.StartSpan(ctx, "PoliciesStats")
+	defer span.End()
 	s.Logger.Info("Calculating policies statistics")
 	filters := uapcommonmodels.NewArkUAPFilters()
 	filters.TargetCategory = []string{commonmodels.CategoryTypeCloudConsole}
-	return s.baseService.BasePoliciesStats(filters)
+	return s.baseService.BasePoliciesStatsWithContext(ctx, filters)
 }
 
 // ServiceConfig returns the service configuration for ArkUAPSCAService.
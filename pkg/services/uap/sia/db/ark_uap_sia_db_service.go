@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
@@ -45,6 +46,7 @@ func NewArkUAPSIADBService(authenticators ...auth.ArkAuth) (*ArkUAPSIADBService,
 	if err != nil {
 		return nil, err
 	}
+	baseService.AddClient(uapSiaDbService.baseService.Client().ArkClient)
 	return uapSiaDbService, nil
 }
 
@@ -83,6 +85,13 @@ func (s *ArkUAPSIADBService) deserializeProfile(policy *uapsiadbmodels.ArkUAPSIA
 
 // AddPolicy adds a new policy with the given information.
 func (s *ArkUAPSIADBService) AddPolicy(addPolicy *uapsiadbmodels.ArkUAPSIADBAccessPolicy) (*uapsiadbmodels.ArkUAPSIADBAccessPolicy, error) {
+	return s.AddPolicyWithContext(context.Background(), addPolicy)
+}
+
+// AddPolicyWithContext is AddPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) AddPolicyWithContext(ctx context.Context, addPolicy *uapsiadbmodels.ArkUAPSIADBAccessPolicy) (*uapsiadbmodels.ArkUAPSIADBAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "AddPolicy")
+	defer span.End()
 	s.Logger.Info("Adding new policy [%s]", addPolicy.Metadata.Name)
 	addPolicy.Metadata.PolicyEntitlement.TargetCategory = commonmodels.CategoryTypeDB
 	if addPolicy.Metadata.PolicyTags == nil {
@@ -97,20 +106,27 @@ func (s *ArkUAPSIADBService) AddPolicy(addPolicy *uapsiadbmodels.ArkUAPSIADBAcce
 	if err != nil {
 		return nil, err
 	}
-	policyResp, err := s.baseService.BaseAddPolicy(policyJSON)
+	policyResp, err := s.baseService.BaseAddPolicyWithContext(ctx, policyJSON)
 	if err != nil {
 		return nil, err
 	}
-	return s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+	return s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 		PolicyID: policyResp.PolicyID,
 	})
 }
 
 // Policy retrieves a policy by its ID.
 func (s *ArkUAPSIADBService) Policy(policyRequest *uapcommonmodels.ArkUAPGetPolicyRequest) (*uapsiadbmodels.ArkUAPSIADBAccessPolicy, error) {
+	return s.PolicyWithContext(context.Background(), policyRequest)
+}
+
+// PolicyWithContext is Policy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) PolicyWithContext(ctx context.Context, policyRequest *uapcommonmodels.ArkUAPGetPolicyRequest) (*uapsiadbmodels.ArkUAPSIADBAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "Policy")
+	defer span.End()
 	s.Logger.Info("Retrieving policy [%s]", policyRequest.PolicyID)
 	respType := reflect.TypeOf(uapsiadbmodels.ArkUAPSIADBAccessPolicy{})
-	policyJSON, err := s.baseService.BasePolicy(policyRequest.PolicyID, &respType)
+	policyJSON, err := s.baseService.BasePolicyWithContext(ctx, policyRequest.PolicyID, &respType)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +144,13 @@ func (s *ArkUAPSIADBService) Policy(policyRequest *uapcommonmodels.ArkUAPGetPoli
 
 // UpdatePolicy edits an existing policy with the given information.
 func (s *ArkUAPSIADBService) UpdatePolicy(updatePolicy *uapsiadbmodels.ArkUAPSIADBAccessPolicy) (*uapsiadbmodels.ArkUAPSIADBAccessPolicy, error) {
+	return s.UpdatePolicyWithContext(context.Background(), updatePolicy)
+}
+
+// UpdatePolicyWithContext is UpdatePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) UpdatePolicyWithContext(ctx context.Context, updatePolicy *uapsiadbmodels.ArkUAPSIADBAccessPolicy) (*uapsiadbmodels.ArkUAPSIADBAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "UpdatePolicy")
+	defer span.End()
 	s.Logger.Info("Updating policy [%s]", updatePolicy.Metadata.PolicyID)
 	policyType := reflect.TypeOf(uapsiadbmodels.ArkUAPSIADBAccessPolicy{})
 	policyJSON, err := common.SerializeJSONCamelSchema(updatePolicy, &policyType)
@@ -138,87 +161,95 @@ func (s *ArkUAPSIADBService) UpdatePolicy(updatePolicy *uapsiadbmodels.ArkUAPSIA
 	if err != nil {
 		return nil, err
 	}
-	err = s.baseService.BaseUpdatePolicy(updatePolicy.Metadata.PolicyID, policyJSON)
+	err = s.baseService.BaseUpdatePolicyWithContext(ctx, updatePolicy.Metadata.PolicyID, policyJSON)
 	if err != nil {
 		return nil, err
 	}
-	return s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+	return s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 		PolicyID: updatePolicy.Metadata.PolicyID,
 	})
 }
 
 // ListPolicies retrieves all policies.
 func (s *ArkUAPSIADBService) ListPolicies() (<-chan *ArkUAPDBPolicyPage, error) {
-	s.Logger.Info("Listing all policies")
-	policyPagesWithType := make(chan *ArkUAPDBPolicyPage)
-	go func() {
+	return s.ListPoliciesWithContext(context.Background())
+}
+
+// ListPoliciesWithContext is ListPolicies with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) ListPoliciesWithContext(ctx context.Context) (<-chan *ArkUAPDBPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesIter(ctx), s.Logger), nil
+}
+
+// ListPoliciesIter returns an iterator over all the db access policies pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPSIADBService) ListPoliciesIter(ctx context.Context) common.ArkPageIterator[uapsiadbmodels.ArkUAPSIADBAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPolicies", func(ctx context.Context) common.ArkPageIterator[uapsiadbmodels.ArkUAPSIADBAccessPolicy] {
+		s.Logger.Info("Listing all policies")
 		filters := uapcommonmodels.NewArkUAPFilters()
 		filters.TargetCategory = []string{commonmodels.CategoryTypeDB}
-		policyPages, err := s.baseService.BaseListPolicies(filters)
-		if err != nil {
-			return
-		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			dbPolicies := ArkUAPDBPolicyPage{Items: make([]*uapsiadbmodels.ArkUAPSIADBAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var dbPolicy uapsiadbmodels.ArkUAPSIADBAccessPolicy
-				err = mapstructure.Decode(*policy, &dbPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				dbPolicies.Items[idx] = &dbPolicy
-			}
-			policyPagesWithType <- &dbPolicies
-		}
-	}()
-	return policyPagesWithType, nil
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, filters), s.decodePoliciesPage)
+	})
 }
 
 // ListPoliciesBy retrieves policies based on the provided filters.
 func (s *ArkUAPSIADBService) ListPoliciesBy(filters *uapsiadbmodels.ArkUAPSIADBFilters) (<-chan *ArkUAPDBPolicyPage, error) {
-	s.Logger.Info("Listing policies by filter")
-	policyPagesWithType := make(chan *ArkUAPDBPolicyPage)
-	go func() {
+	return s.ListPoliciesByWithContext(context.Background(), filters)
+}
+
+// ListPoliciesByWithContext is ListPoliciesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) ListPoliciesByWithContext(ctx context.Context, filters *uapsiadbmodels.ArkUAPSIADBFilters) (<-chan *ArkUAPDBPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesByIter(ctx, filters), s.Logger), nil
+}
+
+// ListPoliciesByIter returns an iterator over the db access policies pages matching the provided filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPSIADBService) ListPoliciesByIter(ctx context.Context, filters *uapsiadbmodels.ArkUAPSIADBFilters) common.ArkPageIterator[uapsiadbmodels.ArkUAPSIADBAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoliciesBy", func(ctx context.Context) common.ArkPageIterator[uapsiadbmodels.ArkUAPSIADBAccessPolicy] {
+		s.Logger.Info("Listing policies by filter")
 		if filters == nil {
 			filters = &uapsiadbmodels.ArkUAPSIADBFilters{
 				ArkUAPFilters: *uapcommonmodels.NewArkUAPFilters(),
 			}
 		}
 		filters.TargetCategory = []string{commonmodels.CategoryTypeDB}
-		policyPages, err := s.baseService.BaseListPolicies(&filters.ArkUAPFilters)
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, &filters.ArkUAPFilters), s.decodePoliciesPage)
+	})
+}
+
+func (s *ArkUAPSIADBService) decodePoliciesPage(page *uap.ArkUAPBasePolicyPage) (*ArkUAPDBPolicyPage, error) {
+	dbPolicies := ArkUAPDBPolicyPage{Items: make([]*uapsiadbmodels.ArkUAPSIADBAccessPolicy, len(page.Items))}
+	for idx, policy := range page.Items {
+		var dbPolicy uapsiadbmodels.ArkUAPSIADBAccessPolicy
+		err := mapstructure.Decode(*policy, &dbPolicy)
 		if err != nil {
-			s.Logger.Error("Failed to list policies by filter: %v", err)
-			close(policyPagesWithType)
-			return
+			s.Logger.Error("Failed to decode policy page: %v", err)
+			continue
 		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			dbPolicies := ArkUAPDBPolicyPage{Items: make([]*uapsiadbmodels.ArkUAPSIADBAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var dbPolicy uapsiadbmodels.ArkUAPSIADBAccessPolicy
-				err = mapstructure.Decode(*policy, &dbPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				dbPolicies.Items[idx] = &dbPolicy
-			}
-			policyPagesWithType <- &dbPolicies
-		}
-	}()
-	return policyPagesWithType, nil
+		dbPolicies.Items[idx] = &dbPolicy
+	}
+	return &dbPolicies, nil
 }
 
 // DeletePolicy deletes a policy by its ID.
 func (s *ArkUAPSIADBService) DeletePolicy(deletePolicy *uapcommonmodels.ArkUAPDeletePolicyRequest) error {
+	return s.DeletePolicyWithContext(context.Background(), deletePolicy)
+}
+
+// DeletePolicyWithContext is DeletePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) DeletePolicyWithContext(ctx context.Context, deletePolicy *uapcommonmodels.ArkUAPDeletePolicyRequest) error {
+	ctx, span := s.StartSpan(ctx, "DeletePolicy")
+	defer span.End()
 	s.Logger.Info("Deleting policy [%s]", deletePolicy.PolicyID)
-	return s.baseService.BaseDeletePolicy(deletePolicy.PolicyID)
+	return s.baseService.BaseDeletePolicyWithContext(ctx, deletePolicy.PolicyID)
 }
 
 // PolicyStatus retrieves the status of a policy by its ID or name.
 func (s *ArkUAPSIADBService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	return s.PolicyStatusWithContext(context.Background(), getPolicyStatus)
+}
+
+// PolicyStatusWithContext is PolicyStatus with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) PolicyStatusWithContext(ctx context.Context, getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	ctx, span := s.StartSpan(ctx, "PolicyStatus")
+	defer span.End()
 	if getPolicyStatus == nil {
 		return "", fmt.Errorf("getPolicyStatus cannot be nil")
 	}
@@ -227,15 +258,22 @@ func (s *ArkUAPSIADBService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUA
 	}
 	s.Logger.Info("Retrieving policy status for ID [%s] and name [%s]", getPolicyStatus.PolicyID, getPolicyStatus.PolicyName)
 	respType := reflect.TypeOf(uapsiadbmodels.ArkUAPSIADBAccessPolicy{})
-	return s.baseService.BasePolicyStatus(getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
+	return s.baseService.BasePolicyStatusWithContext(ctx, getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
 }
 
 // PoliciesStats calculates policies statistics.
 func (s *ArkUAPSIADBService) PoliciesStats() (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	return s.PoliciesStatsWithContext(context.Background())
+}
+
+// PoliciesStatsWithContext is PoliciesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIADBService) PoliciesStatsWithContext(ctx context.Context) (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	ctx, span := s.StartSpan(ctx, "PoliciesStats")
+	defer span.End()
 	s.Logger.Info("Calculating policies statistics")
 	filters := uapcommonmodels.NewArkUAPFilters()
 	filters.TargetCategory = []string{commonmodels.CategoryTypeDB}
-	return s.baseService.BasePoliciesStats(filters)
+	return s.baseService.BasePoliciesStatsWithContext(ctx, filters)
 }
 
 // ServiceConfig returns the service configuration for ArkUAPSIADBService.
@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
@@ -45,11 +46,19 @@ func NewArkUAPSIAVMService(authenticators ...auth.ArkAuth) (*ArkUAPSIAVMService,
 	if err != nil {
 		return nil, err
 	}
+	baseService.AddClient(uapSiaVMService.baseService.Client().ArkClient)
 	return uapSiaVMService, nil
 }
 
 // AddPolicy adds a new policy with the given information.
 func (s *ArkUAPSIAVMService) AddPolicy(addPolicy *uapsiavmmodels.ArkUAPSIAVMAccessPolicy) (*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, error) {
+	return s.AddPolicyWithContext(context.Background(), addPolicy)
+}
+
+// AddPolicyWithContext is AddPolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) AddPolicyWithContext(ctx context.Context, addPolicy *uapsiavmmodels.ArkUAPSIAVMAccessPolicy) (*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "AddPolicy")
+	defer span.End()
 	s.Logger.Info("Adding new policy [%s]", addPolicy.Metadata.Name)
 	addPolicy.Metadata.PolicyEntitlement.TargetCategory = commonmodels.CategoryTypeVM
 	if addPolicy.Metadata.PolicyTags == nil {
@@ -64,20 +73,27 @@ func (s *ArkUAPSIAVMService) AddPolicy(addPolicy *uapsiavmmodels.ArkUAPSIAVMAcce
 	if err != nil {
 		return nil, err
 	}
-	policyResp, err := s.baseService.BaseAddPolicy(addPolicyJSON.(map[string]interface{}))
+	policyResp, err := s.baseService.BaseAddPolicyWithContext(ctx, addPolicyJSON.(map[string]interface{}))
 	if err != nil {
 		return nil, err
 	}
-	return s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+	return s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 		PolicyID: policyResp.PolicyID,
 	})
 }
 
 // Policy retrieves a policy by its ID.
 func (s *ArkUAPSIAVMService) Policy(policyRequest *uapcommonmodels.ArkUAPGetPolicyRequest) (*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, error) {
+	return s.PolicyWithContext(context.Background(), policyRequest)
+}
+
+// PolicyWithContext is Policy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) PolicyWithContext(ctx context.Context, policyRequest *uapcommonmodels.ArkUAPGetPolicyRequest) (*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "Policy")
+	defer span.End()
 	s.Logger.Info("Retrieving policy [%s]", policyRequest.PolicyID)
 	respType := reflect.TypeOf(uapsiavmmodels.ArkUAPSIAVMAccessPolicy{})
-	policyJSON, err := s.baseService.BasePolicy(policyRequest.PolicyID, &respType)
+	policyJSON, err := s.baseService.BasePolicyWithContext(ctx, policyRequest.PolicyID, &respType)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +108,13 @@ func (s *ArkUAPSIAVMService) Policy(policyRequest *uapcommonmodels.ArkUAPGetPoli
 
 // UpdatePolicy edits an existing policy with the given information.
 func (s *ArkUAPSIAVMService) UpdatePolicy(updatePolicy *uapsiavmmodels.ArkUAPSIAVMAccessPolicy) (*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, error) {
+	return s.UpdatePolicyWithContext(context.Background(), updatePolicy)
+}
+
+// UpdatePolicyWithContext is UpdatePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) UpdatePolicyWithContext(ctx context.Context, updatePolicy *uapsiavmmodels.ArkUAPSIAVMAccessPolicy) (*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, error) {
+	ctx, span := s.StartSpan(ctx, "UpdatePolicy")
+	defer span.End()
 	s.Logger.Info("Updating policy [%s]", updatePolicy.Metadata.PolicyID)
 	policyType := reflect.TypeOf(uapsiavmmodels.ArkUAPSIAVMAccessPolicy{})
 	updatePolicySerialized, err := updatePolicy.Serialize()
@@ -102,87 +125,95 @@ func (s *ArkUAPSIAVMService) UpdatePolicy(updatePolicy *uapsiavmmodels.ArkUAPSIA
 	if err != nil {
 		return nil, err
 	}
-	err = s.baseService.BaseUpdatePolicy(updatePolicy.Metadata.PolicyID, updatePolicyJSON.(map[string]interface{}))
+	err = s.baseService.BaseUpdatePolicyWithContext(ctx, updatePolicy.Metadata.PolicyID, updatePolicyJSON.(map[string]interface{}))
 	if err != nil {
 		return nil, err
 	}
-	return s.Policy(&uapcommonmodels.ArkUAPGetPolicyRequest{
+	return s.PolicyWithContext(ctx, &uapcommonmodels.ArkUAPGetPolicyRequest{
 		PolicyID: updatePolicy.Metadata.PolicyID,
 	})
 }
 
 // ListPolicies retrieves all policies.
 func (s *ArkUAPSIAVMService) ListPolicies() (<-chan *ArkUAPVMPolicyPage, error) {
-	s.Logger.Info("Listing all policies")
-	policyPagesWithType := make(chan *ArkUAPVMPolicyPage)
-	go func() {
+	return s.ListPoliciesWithContext(context.Background())
+}
+
+// ListPoliciesWithContext is ListPolicies with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) ListPoliciesWithContext(ctx context.Context) (<-chan *ArkUAPVMPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesIter(ctx), s.Logger), nil
+}
+
+// ListPoliciesIter returns an iterator over all the vm access policies pages, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPSIAVMService) ListPoliciesIter(ctx context.Context) common.ArkPageIterator[uapsiavmmodels.ArkUAPSIAVMAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPolicies", func(ctx context.Context) common.ArkPageIterator[uapsiavmmodels.ArkUAPSIAVMAccessPolicy] {
+		s.Logger.Info("Listing all policies")
 		filters := uapcommonmodels.NewArkUAPFilters()
 		filters.TargetCategory = []string{commonmodels.CategoryTypeVM}
-		policyPages, err := s.baseService.BaseListPolicies(filters)
-		if err != nil {
-			return
-		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			vmPolicies := ArkUAPVMPolicyPage{Items: make([]*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var vmPolicy uapsiavmmodels.ArkUAPSIAVMAccessPolicy
-				err = mapstructure.Decode(*policy, &vmPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				vmPolicies.Items[idx] = &vmPolicy
-			}
-			policyPagesWithType <- &vmPolicies
-		}
-	}()
-	return policyPagesWithType, nil
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, filters), s.decodePoliciesPage)
+	})
 }
 
 // ListPoliciesBy retrieves policies based on the provided filters.
 func (s *ArkUAPSIAVMService) ListPoliciesBy(filters *uapsiavmmodels.ArkUAPSIAVMFilters) (<-chan *ArkUAPVMPolicyPage, error) {
-	s.Logger.Info("Listing policies by filter")
-	policyPagesWithType := make(chan *ArkUAPVMPolicyPage)
-	go func() {
+	return s.ListPoliciesByWithContext(context.Background(), filters)
+}
+
+// ListPoliciesByWithContext is ListPoliciesBy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) ListPoliciesByWithContext(ctx context.Context, filters *uapsiavmmodels.ArkUAPSIAVMFilters) (<-chan *ArkUAPVMPolicyPage, error) {
+	return common.ArkPageChannel(ctx, s.ListPoliciesByIter(ctx, filters), s.Logger), nil
+}
+
+// ListPoliciesByIter returns an iterator over the vm access policies pages matching the provided filters, stopping with an error if a page fails to be retrieved.
+func (s *ArkUAPSIAVMService) ListPoliciesByIter(ctx context.Context, filters *uapsiavmmodels.ArkUAPSIAVMFilters) common.ArkPageIterator[uapsiavmmodels.ArkUAPSIAVMAccessPolicy] {
+	return common.TraceArkPageIterator(ctx, s.ServiceConfig().ServiceName, "ListPoliciesBy", func(ctx context.Context) common.ArkPageIterator[uapsiavmmodels.ArkUAPSIAVMAccessPolicy] {
+		s.Logger.Info("Listing policies by filter")
 		if filters == nil {
 			filters = &uapsiavmmodels.ArkUAPSIAVMFilters{
 				ArkUAPFilters: *uapcommonmodels.NewArkUAPFilters(),
 			}
 		}
 		filters.TargetCategory = []string{commonmodels.CategoryTypeVM}
-		policyPages, err := s.baseService.BaseListPolicies(&filters.ArkUAPFilters)
+		return common.MapArkPageIterator(s.baseService.BaseListPoliciesIter(ctx, &filters.ArkUAPFilters), s.decodePoliciesPage)
+	})
+}
+
+func (s *ArkUAPSIAVMService) decodePoliciesPage(page *uap.ArkUAPBasePolicyPage) (*ArkUAPVMPolicyPage, error) {
+	vmPolicies := ArkUAPVMPolicyPage{Items: make([]*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, len(page.Items))}
+	for idx, policy := range page.Items {
+		var vmPolicy uapsiavmmodels.ArkUAPSIAVMAccessPolicy
+		err := mapstructure.Decode(*policy, &vmPolicy)
 		if err != nil {
-			s.Logger.Error("Failed to list policies by filter: %v", err)
-			close(policyPagesWithType)
-			return
-		}
-		defer close(policyPagesWithType)
-		for page := range policyPages {
-			vmPolicies := ArkUAPVMPolicyPage{Items: make([]*uapsiavmmodels.ArkUAPSIAVMAccessPolicy, len(page.Items))}
-			for idx, policy := range page.Items {
-				var vmPolicy uapsiavmmodels.ArkUAPSIAVMAccessPolicy
-				err = mapstructure.Decode(*policy, &vmPolicy)
-				if err != nil {
-					s.Logger.Error("Failed to decode policy page: %v", err)
-					continue
-				}
-				vmPolicies.Items[idx] = &vmPolicy
-			}
-			policyPagesWithType <- &vmPolicies
+			s.Logger.Error("Failed to decode policy page: %v", err)
+			continue
 		}
-	}()
-	return policyPagesWithType, nil
+		vmPolicies.Items[idx] = &vmPolicy
+	}
+	return &vmPolicies, nil
 }
 
 // DeletePolicy deletes a policy by its ID.
 func (s *ArkUAPSIAVMService) DeletePolicy(deletePolicy *uapcommonmodels.ArkUAPDeletePolicyRequest) error {
+	return s.DeletePolicyWithContext(context.Background(), deletePolicy)
+}
+
+// DeletePolicyWithContext is DeletePolicy with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) DeletePolicyWithContext(ctx context.Context, deletePolicy *uapcommonmodels.ArkUAPDeletePolicyRequest) error {
+	ctx, span := s.StartSpan(ctx, "DeletePolicy")
+	defer span.End()
 	s.Logger.Info("Deleting policy [%s]", deletePolicy.PolicyID)
-	return s.baseService.BaseDeletePolicy(deletePolicy.PolicyID)
+	return s.baseService.BaseDeletePolicyWithContext(ctx, deletePolicy.PolicyID)
 }
 
 // PolicyStatus retrieves the status of a policy by its ID or name.
 func (s *ArkUAPSIAVMService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	return s.PolicyStatusWithContext(context.Background(), getPolicyStatus)
+}
+
+// PolicyStatusWithContext is PolicyStatus with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) PolicyStatusWithContext(ctx context.Context, getPolicyStatus *uapcommonmodels.ArkUAPGetPolicyStatus) (string, error) {
+	ctx, span := s.StartSpan(ctx, "PolicyStatus")
+	defer span.End()
 	if getPolicyStatus == nil {
 		return "", fmt.Errorf("getPolicyStatus cannot be nil")
 	}
@@ -191,15 +222,22 @@ func (s *ArkUAPSIAVMService) PolicyStatus(getPolicyStatus *uapcommonmodels.ArkUA
 	}
 	s.Logger.Info("Retrieving policy status for ID [%s] and name [%s]", getPolicyStatus.PolicyID, getPolicyStatus.PolicyName)
 	respType := reflect.TypeOf(uapsiavmmodels.ArkUAPSIAVMAccessPolicy{})
-	return s.baseService.BasePolicyStatus(getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
+	return s.baseService.BasePolicyStatusWithContext(ctx, getPolicyStatus.PolicyID, getPolicyStatus.PolicyName, &respType)
 }
 
 // PoliciesStats calculates policies statistics.
 func (s *ArkUAPSIAVMService) PoliciesStats() (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	return s.PoliciesStatsWithContext(context.Background())
+}
+
+// PoliciesStatsWithContext is PoliciesStats with a caller provided context that is passed down to every request made by the call.
+func (s *ArkUAPSIAVMService) PoliciesStatsWithContext(ctx context.Context) (*uapcommonmodels.ArkUAPPoliciesStats, error) {
+	ctx, span := s.StartSpan(ctx, "PoliciesStats")
+	defer span.End()
 	s.Logger.Info("Calculating policies statistics")
 	filters := uapcommonmodels.NewArkUAPFilters()
 	filters.TargetCategory = []string{commonmodels.CategoryTypeVM}
-	return s.baseService.BasePoliciesStats(filters)
+	return s.baseService.BasePoliciesStatsWithContext(ctx, filters)
 }
 
 // ServiceConfig returns the service configuration for ArkUAPSIAVMService.
@@ -12,7 +12,8 @@
 //
 // Key features:
 //   - Centralized access to all ARK services
-//   - Lazy loading and caching of service instances
+//   - Lazy loading and caching of service instances, safe for concurrent use
+//   - Explicit lifecycle, with invalidation of single services and Close
 //   - Authenticator management and distribution to services
 //   - Profile-based configuration management
 //   - Service dependency injection with authenticators
@@ -46,10 +47,16 @@
 //	if err != nil {
 //		// handle error
 //	}
+//
+//	// Release the connections and tokens held by the API
+//	defer api.Close()
 package api
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/cyberark/ark-sdk-golang/pkg/auth"
 	"github.com/cyberark/ark-sdk-golang/pkg/models"
@@ -73,12 +80,22 @@ import (
 // automatically distributed to services based on their configuration requirements.
 // Each service specifies required and optional authenticators, and ArkAPI ensures
 // the appropriate authenticators are provided during initialization.
+//
+// ArkAPI is safe for concurrent use. Each service is created once, even when it is
+// first accessed by several goroutines at the same time, and different services are
+// created independently of each other.
 type ArkAPI struct {
 	authenticators []auth.ArkAuth
 	services       map[string]*services.ArkService
 	profile        *models.ArkProfile
+	mutex          sync.Mutex
+	serviceMutexes map[string]*sync.Mutex
+	closed         bool
 }
 
+// ErrArkAPIClosed is returned when accessing a service of an ArkAPI that was closed.
+var ErrArkAPIClosed = errors.New("ark api is closed")
+
 // NewArkAPI creates a new ArkAPI instance with the provided authenticators and profile.
 //
 // Initializes a new ArkAPI instance that serves as the central access point for all
@@ -210,4 +227,150 @@ func (api *ArkAPI) Profile() *models.ArkProfile {
 	return api.profile
 }
 
+// InvalidateService discards the cached instance of a service, so that it is created again on next access.
+//
+// This is meant for credential rotation, where a service has to pick up the new
+// credentials of its authenticators. The idle connections and tokens of the
+// discarded instance are released. Invalidating a service that was not created
+// yet does nothing.
+//
+// Parameters:
+//   - serviceName: The name of the service, as in its ServiceConfig
+//
+// Returns an error if the discarded instance failed to close.
+//
+// Example:
+//
+//	_, err := ispAuth.Authenticate(nil, authProfile, rotatedSecret, true, false)
+//	if err != nil {
+//		// handle error
+//	}
+//	err = api.InvalidateService(accounts.ServiceConfig.ServiceName)
+//	accountsService, err := api.PcloudAccounts() // Created with the new token
+func (api *ArkAPI) InvalidateService(serviceName string) error {
+	serviceMutex := api.serviceMutex(serviceName)
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	api.mutex.Lock()
+	service, ok := api.services[serviceName]
+	delete(api.services, serviceName)
+	api.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	return closeService(*service)
+}
+
+// Close releases the resources held by the API and its services.
+//
+// The idle connections of the services are closed, and the clients of the services are
+// detached from the authenticators. The authenticators themselves are not closed, as they
+// are usually shared with other APIs, such as the ones of auth.GetAuthenticator, and their
+// tokens and background refresh stay in use. Close them explicitly when they are no longer
+// needed. Once closed, accessing a service returns ErrArkAPIClosed. Calling Close more than
+// once does nothing.
+//
+// Returns the errors of the services that failed to close, joined.
+//
+// Example:
+//
+//	api, err := NewArkAPI(authenticators, nil)
+//	if err != nil {
+//		// handle error
+//	}
+//	defer api.Close()
+func (api *ArkAPI) Close() error {
+	api.mutex.Lock()
+	if api.closed {
+		api.mutex.Unlock()
+		return nil
+	}
+	api.closed = true
+	cachedServices := api.services
+	api.services = make(map[string]*services.ArkService)
+	api.mutex.Unlock()
+
+	var errs []error
+	for _, service := range cachedServices {
+		errs = append(errs, closeService(*service))
+	}
+	return errors.Join(errs...)
+}
+
+// serviceMutex returns the mutex serializing the creation and invalidation of a service.
+func (api *ArkAPI) serviceMutex(serviceName string) *sync.Mutex {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if api.serviceMutexes == nil {
+		api.serviceMutexes = make(map[string]*sync.Mutex)
+	}
+	serviceMutex, ok := api.serviceMutexes[serviceName]
+	if !ok {
+		serviceMutex = &sync.Mutex{}
+		api.serviceMutexes[serviceName] = serviceMutex
+	}
+	return serviceMutex
+}
+
+// cachedService returns the cached instance of a service, if any.
+func (api *ArkAPI) cachedService(serviceName string) (services.ArkService, bool, error) {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if api.closed {
+		return nil, false, ErrArkAPIClosed
+	}
+	service, ok := api.services[serviceName]
+	if !ok {
+		return nil, false, nil
+	}
+	return *service, true, nil
+}
+
+// loadService returns the cached instance of a service, creating it with its generator on first access.
+//
+// Concurrent first accesses to the same service wait for a single creation. A
+// failed creation is not cached, so the next access tries again.
+func loadService[T services.ArkService](api *ArkAPI, config services.ArkServiceConfig, generator func(...auth.ArkAuth) (T, error)) (T, error) {
+	var empty T
+	if service, ok, err := api.cachedService(config.ServiceName); err != nil || ok {
+		if err != nil {
+			return empty, err
+		}
+		return service.(T), nil
+	}
+	serviceMutex := api.serviceMutex(config.ServiceName)
+	serviceMutex.Lock()
+	defer serviceMutex.Unlock()
+	if service, ok, err := api.cachedService(config.ServiceName); err != nil || ok {
+		if err != nil {
+			return empty, err
+		}
+		return service.(T), nil
+	}
+	service, err := generator(api.loadServiceAuthenticators(config)...)
+	if err != nil {
+		return empty, err
+	}
+	var baseService services.ArkService = service
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if api.closed {
+		_ = closeService(baseService)
+		return empty, ErrArkAPIClosed
+	}
+	if api.services == nil {
+		api.services = make(map[string]*services.ArkService)
+	}
+	api.services[config.ServiceName] = &baseService
+	return service, nil
+}
+
+// closeService closes a service that holds resources to release.
+func closeService(service services.ArkService) error {
+	if closer, ok := service.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // +gen:methods
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
 	"os"
@@ -110,11 +111,17 @@ func main() {
 	out = replaceMarker(out, "// +gen:imports", importBlock)
 	out = replaceMarker(out, "// +gen:methods", methods)
 
+	// Format the output as gofmt does, so regenerating leaves a formatted file unchanged
+	formatted, err := format.Source([]byte(out))
+	if err != nil {
+		panic(err)
+	}
+
 	outPath := filepath.Join(moduleRoot, "pkg", "ark_api.go")
 	if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
 		panic(err)
 	}
-	if err := os.WriteFile(outPath, []byte(out), 0o644); err != nil {
+	if err := os.WriteFile(outPath, formatted, 0o644); err != nil {
 		panic(err)
 	}
 }
@@ -349,16 +356,7 @@ func buildMethods(svcs []serviceInfo) string {
 	var b bytes.Buffer
 	for _, s := range svcs {
 		fmt.Fprintf(&b, "func (api *ArkAPI) %s() (%s, error) {\n", s.MethodName, s.RetExpr)
-		fmt.Fprintf(&b, "\tif serviceIfs, ok := api.services[%s.ServiceConfig.ServiceName]; ok {\n", s.Alias)
-		fmt.Fprintf(&b, "\t\treturn (*serviceIfs).(%s), nil\n", s.RetExpr)
-		fmt.Fprintf(&b, "\t}\n")
-		fmt.Fprintf(&b, "\tservice, err := %s.ServiceGenerator(api.loadServiceAuthenticators(%s.ServiceConfig)...)\n", s.Alias, s.Alias)
-		fmt.Fprintf(&b, "\tif err != nil {\n")
-		fmt.Fprintf(&b, "\t\treturn nil, err\n")
-		fmt.Fprintf(&b, "\t}\n")
-		fmt.Fprintf(&b, "\tvar baseService services.ArkService = service\n")
-		fmt.Fprintf(&b, "\tapi.services[%s.ServiceConfig.ServiceName] = &baseService\n", s.Alias)
-		fmt.Fprintf(&b, "\treturn service, nil\n")
+		fmt.Fprintf(&b, "\treturn loadService(api, %s.ServiceConfig, %s.ServiceGenerator)\n", s.Alias, s.Alias)
 		b.WriteString("}\n\n")
 	}
 	return b.String()